@@ -0,0 +1,520 @@
+// Package buildtsi builds tsi1 indexes from the TSM and WAL data of shards using the
+// in-memory index.
+package buildtsi
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
+	"github.com/influxdata/influxdb/tsdb/index/tsi1"
+)
+
+// seriesBatchSize is the number of series added to a log file at a time.
+const seriesBatchSize = 10000
+
+// Command represents the program execution for "influx_inspect buildtsi".
+type Command struct {
+	Stderr io.Writer
+	Stdout io.Writer
+
+	database        string
+	retentionPolicy string
+	maxLogFileSize  int64
+
+	mu sync.Mutex
+}
+
+// NewCommand returns a new instance of Command.
+func NewCommand() *Command {
+	return &Command{
+		Stderr: os.Stderr,
+		Stdout: os.Stdout,
+	}
+}
+
+// shardInfo is the location of a shard to index.
+type shardInfo struct {
+	db, rp  string
+	id      uint64
+	dataDir string
+	walDir  string
+}
+
+// Run executes the command.
+func (cmd *Command) Run(args ...string) error {
+	var dataDir, walDir string
+	var concurrency int
+	fs := flag.NewFlagSet("buildtsi", flag.ExitOnError)
+	fs.StringVar(&dataDir, "datadir", os.Getenv("HOME")+"/.influxdb/data", "data directory")
+	fs.StringVar(&walDir, "waldir", os.Getenv("HOME")+"/.influxdb/wal", "WAL directory")
+	fs.StringVar(&cmd.database, "database", "", "optional: database name")
+	fs.StringVar(&cmd.retentionPolicy, "retention", "", "optional: retention policy")
+	fs.IntVar(&concurrency, "concurrency", runtime.GOMAXPROCS(0), "number of shards indexed at a time")
+	fs.Int64Var(&cmd.maxLogFileSize, "max-log-file-size", tsi1.DefaultMaxLogFileSize, "size of the log written before it is compacted into an index file")
+
+	fs.SetOutput(cmd.Stdout)
+	fs.Usage = cmd.printUsage
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	} else if fs.NArg() > 0 {
+		fmt.Fprintf(cmd.Stderr, "unexpected arguments: %v\n", fs.Args())
+		cmd.printUsage()
+		return nil
+	} else if cmd.retentionPolicy != "" && cmd.database == "" {
+		return errors.New("must specify -database when -retention is set")
+	} else if concurrency < 1 {
+		return errors.New("concurrency must be at least 1")
+	}
+
+	shards, err := cmd.shards(dataDir, walDir)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	ch := make(chan shardInfo)
+	errs := make(chan error, len(shards))
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for sh := range ch {
+				if err := cmd.buildShard(sh); err != nil {
+					errs <- fmt.Errorf("%s/%s/%d: %s", sh.db, sh.rp, sh.id, err)
+				}
+			}
+		}()
+	}
+	for _, sh := range shards {
+		ch <- sh
+	}
+	close(ch)
+	wg.Wait()
+	close(errs)
+
+	var failed int
+	for err := range errs {
+		fmt.Fprintln(cmd.Stderr, err)
+		failed++
+	}
+
+	fmt.Fprintf(cmd.Stdout, "Indexed %d of %d shards in %v\n", len(shards)-failed, len(shards), time.Since(start))
+	if failed > 0 {
+		return fmt.Errorf("%d shards could not be indexed", failed)
+	}
+	return nil
+}
+
+// shards returns the shards in dataDir that don't have an index directory yet.
+func (cmd *Command) shards(dataDir, walDir string) ([]shardInfo, error) {
+	dbs, err := ioutil.ReadDir(dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var shards []shardInfo
+	for _, db := range dbs {
+		if !db.IsDir() || (cmd.database != "" && db.Name() != cmd.database) {
+			continue
+		}
+
+		rps, err := ioutil.ReadDir(filepath.Join(dataDir, db.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, rp := range rps {
+			if !rp.IsDir() || (cmd.retentionPolicy != "" && rp.Name() != cmd.retentionPolicy) {
+				continue
+			}
+
+			ids, err := ioutil.ReadDir(filepath.Join(dataDir, db.Name(), rp.Name()))
+			if err != nil {
+				return nil, err
+			}
+			for _, fi := range ids {
+				id, err := strconv.ParseUint(fi.Name(), 10, 64)
+				if !fi.IsDir() || err != nil {
+					continue
+				}
+
+				sh := shardInfo{
+					db:      db.Name(),
+					rp:      rp.Name(),
+					id:      id,
+					dataDir: filepath.Join(dataDir, db.Name(), rp.Name(), fi.Name()),
+					walDir:  filepath.Join(walDir, db.Name(), rp.Name(), fi.Name()),
+				}
+
+				if _, err := os.Stat(filepath.Join(sh.dataDir, "index")); err == nil {
+					cmd.printf("%s/%s/%d: index already exists, skipping\n", sh.db, sh.rp, sh.id)
+					continue
+				} else if !os.IsNotExist(err) {
+					return nil, err
+				}
+				shards = append(shards, sh)
+			}
+		}
+	}
+
+	sort.Sort(shardInfos(shards))
+	return shards, nil
+}
+
+// buildShard builds the index of a shard in a temporary directory and moves it into
+// place once it is complete.  The temporary directory is removed if building the index
+// fails.  If the command is interrupted it is left behind, and is removed when the
+// shard is indexed again.
+func (cmd *Command) buildShard(sh shardInfo) (err error) {
+	start := time.Now()
+	tmpPath := filepath.Join(sh.dataDir, "index.tmp")
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return err
+	} else if err := os.MkdirAll(tmpPath, 0777); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(tmpPath)
+		}
+	}()
+
+	b := &indexBuilder{path: tmpPath, maxLogFileSize: cmd.maxLogFileSize}
+	defer b.close()
+
+	tsmFiles, err := filepath.Glob(filepath.Join(sh.dataDir, fmt.Sprintf("*.%s", tsm1.TSMFileExtension)))
+	if err != nil {
+		return err
+	}
+	sort.Strings(tsmFiles)
+	for _, path := range tsmFiles {
+		if err := b.addTSMFile(path); err != nil {
+			return fmt.Errorf("%s: %s", path, err)
+		}
+	}
+
+	walFiles, err := filepath.Glob(filepath.Join(sh.walDir, fmt.Sprintf("%s*.%s", tsm1.WALFilePrefix, tsm1.WALFileExtension)))
+	if err != nil {
+		return err
+	}
+	sort.Strings(walFiles)
+	if err := b.addWALFiles(walFiles); err != nil {
+		return err
+	}
+
+	if err := b.finish(); err != nil {
+		return err
+	} else if err := os.Rename(tmpPath, filepath.Join(sh.dataDir, "index")); err != nil {
+		return err
+	}
+
+	cmd.printf("%s/%s/%d: indexed %d series from %d TSM and %d WAL files into %d index files in %v\n",
+		sh.db, sh.rp, sh.id, b.seriesN, len(tsmFiles), len(walFiles), len(b.files), time.Since(start))
+	return nil
+}
+
+// printf writes progress to stdout.  Shards are indexed concurrently so lines are
+// written one at a time.
+func (cmd *Command) printf(format string, v ...interface{}) {
+	cmd.mu.Lock()
+	defer cmd.mu.Unlock()
+	fmt.Fprintf(cmd.Stdout, format, v...)
+}
+
+// printUsage prints the usage message to STDERR.
+func (cmd *Command) printUsage() {
+	usage := fmt.Sprintf(`Builds a tsi1 index for each shard that uses the in-memory index from
+the series keys in its TSM files and WAL segments.  The server must not be
+running, and index-version must be set to "tsi1" before it is restarted.
+
+Usage: influx_inspect buildtsi [flags]
+
+    -datadir <path>
+            Data storage path
+            Defaults to "%[1]s/.influxdb/data".
+    -waldir <path>
+            WAL storage path
+            Defaults to "%[1]s/.influxdb/wal".
+    -database <name>
+            Only index shards of the database
+    -retention <name>
+            Only index shards of the retention policy (requires -database)
+    -concurrency <n>
+            Number of shards indexed at a time
+            Defaults to the number of CPUs.
+    -max-log-file-size <bytes>
+            Size of the log written before it is compacted into an index file
+            Defaults to %[2]d.
+`, os.Getenv("HOME"), tsi1.DefaultMaxLogFileSize)
+
+	fmt.Fprintf(cmd.Stdout, usage)
+}
+
+// shardInfos sorts shards by database, retention policy and ID.
+type shardInfos []shardInfo
+
+func (a shardInfos) Len() int      { return len(a) }
+func (a shardInfos) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a shardInfos) Less(i, j int) bool {
+	if a[i].db != a[j].db {
+		return a[i].db < a[j].db
+	} else if a[i].rp != a[j].rp {
+		return a[i].rp < a[j].rp
+	}
+	return a[i].id < a[j].id
+}
+
+// indexBuilder writes series to log files and compacts each log file into an index
+// file once it reaches maxLogFileSize.
+type indexBuilder struct {
+	path           string
+	maxLogFileSize int64
+
+	logFile *tsi1.LogFile
+	files   []string
+
+	// seriesN is the number of series added.  A series may be added to more than one
+	// index file if the log file is compacted between its keys.
+	seriesN int
+
+	// The series of the current batch and the last series key added.
+	names     [][]byte
+	tagsSlice []models.Tags
+	lastKey   []byte
+}
+
+// addTSMFile adds the series of every key in a TSM file.
+func (b *indexBuilder) addTSMFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	defer r.Close()
+
+	for i, n := 0, r.KeyCount(); i < n; i++ {
+		key, _ := r.KeyAt(i)
+		if err := b.addKey(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addWALFiles adds the series of every key with values in the WAL segments at paths,
+// in order, once the deletes written to the segments are applied.  Deletes of keys in
+// TSM files are also written to their tombstones, so they're already applied to the
+// keys of the files.
+func (b *indexBuilder) addWALFiles(paths []string) error {
+	// The times of the values of each key.
+	times := make(map[string][]int64)
+	for _, path := range paths {
+		if err := readWALFile(path, times); err != nil {
+			return fmt.Errorf("%s: %s", path, err)
+		}
+	}
+
+	keys := make([]string, 0, len(times))
+	for key := range times {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := b.addKey([]byte(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readWALFile adds the times of the values written to a WAL segment to times, and
+// removes the keys and times deleted by the segment.
+func readWALFile(path string, times map[string][]int64) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := tsm1.NewWALSegmentReader(f)
+	defer r.Close()
+
+	for r.Next() {
+		entry, err := r.Read()
+		if err != nil {
+			// The end of the last segment may be partially written.
+			break
+		}
+
+		switch e := entry.(type) {
+		case *tsm1.WriteWALEntry:
+			for key, values := range e.Values {
+				for _, v := range values {
+					times[key] = append(times[key], v.UnixNano())
+				}
+			}
+
+		case *tsm1.DeleteWALEntry:
+			for _, key := range e.Keys {
+				delete(times, key)
+			}
+
+		case *tsm1.DeleteRangeWALEntry:
+			for _, key := range e.Keys {
+				a, ok := times[key]
+				if !ok {
+					continue
+				}
+
+				n := 0
+				for _, t := range a {
+					if t < e.Min || t > e.Max {
+						a[n] = t
+						n++
+					}
+				}
+				if n == 0 {
+					delete(times, key)
+				} else {
+					times[key] = a[:n]
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// addKey adds the series of a series field key.  Consecutive keys of the same series
+// are only added once; the log file ignores series that it already holds.
+func (b *indexBuilder) addKey(key []byte) error {
+	seriesKey, _ := tsm1.SeriesAndFieldFromCompositeKey(key)
+	if bytes.Equal(seriesKey, b.lastKey) {
+		return nil
+	}
+	b.lastKey = append(b.lastKey[:0], seriesKey...)
+
+	seriesKey = append([]byte(nil), seriesKey...)
+	tags, err := models.ParseTags(seriesKey)
+	if err != nil {
+		return err
+	}
+	b.names = append(b.names, tsdb.MeasurementFromSeriesKey(seriesKey))
+	b.tagsSlice = append(b.tagsSlice, tags)
+
+	if len(b.names) >= seriesBatchSize {
+		return b.flush()
+	}
+	return nil
+}
+
+// flush adds the current batch of series to the log file and compacts the log file if
+// it is full.
+func (b *indexBuilder) flush() error {
+	if len(b.names) == 0 {
+		return nil
+	}
+
+	if b.logFile == nil {
+		b.logFile = tsi1.NewLogFile(filepath.Join(b.path, tsi1.FormatLogFileName(len(b.files)+1)))
+		if err := b.logFile.Open(); err != nil {
+			return err
+		}
+	}
+
+	// Series of earlier TSM files and WAL segments may already be in the log file.
+	var names [][]byte
+	var tagsSlice []models.Tags
+	for i := range b.names {
+		if exists, _ := b.logFile.HasSeries(b.names[i], b.tagsSlice[i], nil); !exists {
+			names = append(names, b.names[i])
+			tagsSlice = append(tagsSlice, b.tagsSlice[i])
+		}
+	}
+	b.names, b.tagsSlice = b.names[:0], b.tagsSlice[:0]
+
+	if err := b.logFile.AddSeriesList(names, tagsSlice); err != nil {
+		return err
+	}
+	b.seriesN += len(names)
+
+	if b.logFile.Size() >= b.maxLogFileSize {
+		return b.compact()
+	}
+	return nil
+}
+
+// compact writes the log file to an index file and removes it.
+func (b *indexBuilder) compact() error {
+	id := len(b.files) + 1
+	f, err := os.Create(filepath.Join(b.path, tsi1.FormatIndexFileName(id)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := b.logFile.WriteTo(f); err != nil {
+		return err
+	} else if err := f.Sync(); err != nil {
+		return err
+	} else if err := f.Close(); err != nil {
+		return err
+	}
+
+	if err := b.logFile.Close(); err != nil {
+		return err
+	} else if err := os.Remove(b.logFile.Path()); err != nil {
+		return err
+	}
+	b.logFile = nil
+
+	b.files = append(b.files, tsi1.FormatIndexFileName(id))
+	return nil
+}
+
+// finish compacts the remaining series and writes the manifest.  The manifest lists
+// the newest files first.
+func (b *indexBuilder) finish() error {
+	if err := b.flush(); err != nil {
+		return err
+	} else if b.logFile != nil {
+		if err := b.compact(); err != nil {
+			return err
+		}
+	}
+
+	m := &tsi1.Manifest{Files: make([]string, len(b.files))}
+	for i, name := range b.files {
+		m.Files[len(b.files)-i-1] = name
+	}
+	return tsi1.WriteManifestFile(filepath.Join(b.path, tsi1.ManifestFileName), m)
+}
+
+// close closes the log file if the index was not finished.
+func (b *indexBuilder) close() {
+	if b.logFile != nil {
+		b.logFile.Close()
+		b.logFile = nil
+	}
+}
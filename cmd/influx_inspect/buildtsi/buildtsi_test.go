@@ -0,0 +1,166 @@
+package buildtsi_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/influxdata/influxdb/cmd/influx_inspect/buildtsi"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
+	"github.com/influxdata/influxdb/tsdb/index/tsi1"
+)
+
+// Ensure the command builds an index from the TSM files and WAL segments of a shard,
+// without the series deleted in the WAL segments.
+func TestCommand_Run(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	dataDir := filepath.Join(dir, "data")
+	walDir := filepath.Join(dir, "wal")
+	shardDir := filepath.Join(dataDir, "db0", "rp0", "1")
+
+	MustWriteTSMFile(filepath.Join(shardDir, "000000001-000000001.tsm"), []string{
+		tsm1.SeriesFieldKey("cpu,host=a", "value"),
+		tsm1.SeriesFieldKey("cpu,host=a", "value2"),
+		tsm1.SeriesFieldKey("cpu,host=b", "value"),
+	})
+	MustWriteTSMFile(filepath.Join(shardDir, "000000002-000000001.tsm"), []string{
+		tsm1.SeriesFieldKey("cpu,host=b", "value"),
+		tsm1.SeriesFieldKey(`disk\ io,path=/`, "value"),
+	})
+	MustWriteWALFile(filepath.Join(walDir, "db0", "rp0", "1", "_00001.wal"), NewWriteWALEntry([]string{
+		tsm1.SeriesFieldKey("cpu,host=a", "value"),
+		tsm1.SeriesFieldKey("mem", "value"),
+		tsm1.SeriesFieldKey("net", "value"),
+		tsm1.SeriesFieldKey("swap", "value"),
+	}))
+	MustWriteWALFile(filepath.Join(walDir, "db0", "rp0", "1", "_00002.wal"),
+		&tsm1.DeleteWALEntry{Keys: []string{tsm1.SeriesFieldKey("swap", "value")}},
+		&tsm1.DeleteRangeWALEntry{Keys: []string{tsm1.SeriesFieldKey("net", "value"), tsm1.SeriesFieldKey("mem", "value")}, Min: 0, Max: 0},
+		NewWriteWALEntry([]string{tsm1.SeriesFieldKey("mem", "value")}),
+	)
+
+	// A shard that already has an index is skipped.
+	if err := os.MkdirAll(filepath.Join(dataDir, "db0", "rp0", "2", "index"), 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	cmd := buildtsi.NewCommand()
+	cmd.Stdout, cmd.Stderr = &stdout, &stdout
+	if err := cmd.Run("-datadir", dataDir, "-waldir", walDir, "-max-log-file-size", "1"); err != nil {
+		t.Fatalf("unexpected error: %s\n%s", err, stdout.String())
+	}
+
+	if _, err := os.Stat(filepath.Join(shardDir, "index.tmp")); !os.IsNotExist(err) {
+		t.Fatalf("expected temporary index directory to be removed: %v", err)
+	}
+	if fis, err := ioutil.ReadDir(filepath.Join(dataDir, "db0", "rp0", "2", "index")); err != nil {
+		t.Fatal(err)
+	} else if len(fis) != 0 {
+		t.Fatalf("expected existing index to be skipped, got %d files", len(fis))
+	}
+
+	idx := tsi1.NewIndex()
+	idx.Path = filepath.Join(shardDir, "index")
+	if err := idx.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer idx.Close()
+
+	fs := idx.RetainFileSet()
+	defer fs.Release()
+	for _, s := range []struct {
+		name string
+		tags models.Tags
+	}{
+		{name: "cpu", tags: models.NewTags(map[string]string{"host": "a"})},
+		{name: "cpu", tags: models.NewTags(map[string]string{"host": "b"})},
+		{name: "disk io", tags: models.NewTags(map[string]string{"path": "/"})},
+		{name: "mem"},
+	} {
+		if !fs.HasSeries([]byte(s.name), s.tags, nil) {
+			t.Errorf("expected series %s %v to be indexed", s.name, s.tags)
+		}
+	}
+	for _, name := range []string{"net", "swap"} {
+		if fs.HasSeries([]byte(name), nil, nil) {
+			t.Errorf("expected deleted series %s not to be indexed", name)
+		}
+	}
+}
+
+// MustTempDir returns a temporary directory.  Panic on error.
+func MustTempDir() string {
+	dir, err := ioutil.TempDir("", "buildtsi-")
+	if err != nil {
+		panic(err)
+	}
+	return dir
+}
+
+// MustWriteTSMFile writes a TSM file with a value for each key.  Keys must be sorted.
+// Panic on error.
+func MustWriteTSMFile(path string, keys []string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		panic(err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		panic(err)
+	}
+
+	w, err := tsm1.NewTSMWriter(f)
+	if err != nil {
+		panic(err)
+	}
+	for _, key := range keys {
+		if err := w.Write(key, []tsm1.Value{tsm1.NewValue(0, 1.0)}); err != nil {
+			panic(err)
+		}
+	}
+	if err := w.WriteIndex(); err != nil {
+		panic(err)
+	} else if err := w.Close(); err != nil {
+		panic(err)
+	}
+}
+
+// NewWriteWALEntry returns a WAL entry writing a value for each key.
+func NewWriteWALEntry(keys []string) *tsm1.WriteWALEntry {
+	e := &tsm1.WriteWALEntry{Values: make(map[string][]tsm1.Value)}
+	for _, key := range keys {
+		e.Values[key] = []tsm1.Value{tsm1.NewValue(0, 1.0)}
+	}
+	return e
+}
+
+// MustWriteWALFile writes a WAL segment with entries.  Panic on error.
+func MustWriteWALFile(path string, entries ...tsm1.WALEntry) {
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		panic(err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	w := tsm1.NewWALSegmentWriter(f)
+	for _, e := range entries {
+		b, err := e.Encode(nil)
+		if err != nil {
+			panic(err)
+		} else if err := w.Write(e.Type(), snappy.Encode(nil, b)); err != nil {
+			panic(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		panic(err)
+	}
+}
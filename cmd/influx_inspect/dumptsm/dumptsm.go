@@ -187,7 +187,7 @@ func (cmd *Command) dump() error {
 			values := encoded[int(j)+int(tsLen):]
 
 			tsEncoding := timeEnc[int(ts[0]>>4)]
-			vEncoding := encDescs[int(blockType+1)][values[0]>>4]
+			vEncoding := tsm1.CodecName(blockType, values[0]>>4)
 
 			typeDesc := blockTypes[blockType]
 
@@ -241,7 +241,10 @@ func (cmd *Command) dump() error {
 		}
 		fmt.Printf("    %s: ", strings.Title(fieldType[i]))
 		for j, v := range counts {
-			fmt.Printf("\t%s: %d (%d%%) ", encDescs[i][j], v, int(float64(v)/float64(blockCount)*100))
+			if v == 0 {
+				continue
+			}
+			fmt.Printf("\t%s: %d (%d%%) ", encodingName(i, byte(j)), v, int(float64(v)/float64(blockCount)*100))
 		}
 		println()
 	}
@@ -282,31 +285,25 @@ Usage: influx_inspect dumptsm [flags] <path
 
 var (
 	fieldType = []string{
-		"timestamp", "float", "int", "bool", "string",
+		"timestamp", "float", "int", "bool", "string", "unsigned",
 	}
 	blockTypes = []string{
-		"float64", "int64", "bool", "string",
+		"float64", "int64", "bool", "string", "uint64",
 	}
 	timeEnc = []string{
 		"none", "s8b", "rle",
 	}
-	floatEnc = []string{
-		"none", "gor",
-	}
-	intEnc = []string{
-		"none", "s8b", "rle",
-	}
-	boolEnc = []string{
-		"none", "bp",
-	}
-	stringEnc = []string{
-		"none", "snpy",
-	}
-	encDescs = [][]string{
-		timeEnc, floatEnc, intEnc, boolEnc, stringEnc,
-	}
 )
 
+// encodingName returns the name of encoding enc for the timestamps (typ 0) or the
+// values (typ is the block type + 1) of a block.
+func encodingName(typ int, enc byte) string {
+	if typ == 0 {
+		return timeEnc[enc]
+	}
+	return tsm1.CodecName(byte(typ-1), enc)
+}
+
 type blockStats struct {
 	min, max int
 	counts   [][]int
@@ -31,9 +31,11 @@ Usage: influx_inspect [[command] [arguments]]
 
 The commands are:
 
+    buildtsi             builds tsi1 indexes for shards using the in-memory index
     dumptsm              dumps low-level details about tsm1 files.
     export               exports raw data from a shard to line protocol
     help                 display this help message
+    repair               rewrites TSM files without their corrupt blocks
     report               displays a shard level report
     verify               verifies integrity of TSM files
 
@@ -0,0 +1,141 @@
+// Package repair rewrites corrupt TSM files without their bad blocks.
+package repair
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
+)
+
+// Command represents the program execution for "influx_inspect repair".
+type Command struct {
+	Stderr io.Writer
+	Stdout io.Writer
+}
+
+// NewCommand returns a new instance of Command.
+func NewCommand() *Command {
+	return &Command{
+		Stderr: os.Stderr,
+		Stdout: os.Stdout,
+	}
+}
+
+// Run executes the command.
+func (cmd *Command) Run(args ...string) error {
+	var path string
+	fs := flag.NewFlagSet("repair", flag.ExitOnError)
+	fs.StringVar(&path, "dir", os.Getenv("HOME")+"/.influxdb", "Root storage path. [$HOME/.influxdb]")
+
+	fs.SetOutput(cmd.Stdout)
+	fs.Usage = cmd.printUsage
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	dataPath := filepath.Join(path, "data")
+	ext := fmt.Sprintf(".%s", tsm1.TSMFileExtension)
+
+	// Group the TSM files by shard directory so each shard's files are replaced together.
+	shards := make(map[string][]string)
+	err := filepath.Walk(dataPath, func(path string, f os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if filepath.Ext(path) == ext {
+			dir := filepath.Dir(path)
+			shards[dir] = append(shards[dir], path)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	dirs := make([]string, 0, len(shards))
+	for dir := range shards {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	tw := tabwriter.NewWriter(cmd.Stdout, 16, 8, 0, '\t', 0)
+	defer tw.Flush()
+
+	var repairedFiles, quarantinedBlocks int
+	for _, dir := range dirs {
+		n, m, err := cmd.repairShard(tw, dir, shards[dir])
+		if err != nil {
+			return err
+		}
+		repairedFiles += n
+		quarantinedBlocks += m
+	}
+
+	fmt.Fprintf(tw, "Repaired Files: %d, Quarantined Blocks: %d, in %vs\n", repairedFiles, quarantinedBlocks, time.Since(start).Seconds())
+	return nil
+}
+
+// repairShard repairs the TSM files in the shard directory dir.  It returns the number
+// of files repaired and the number of blocks quarantined.
+func (cmd *Command) repairShard(w io.Writer, dir string, files []string) (int, int, error) {
+	store := tsm1.NewFileStore(dir)
+	if err := store.Open(); err != nil {
+		return 0, 0, err
+	}
+	defer store.Close()
+
+	compactor := &tsm1.Compactor{
+		Dir:       dir,
+		FileStore: store,
+	}
+	compactor.Open()
+	defer compactor.Close()
+
+	var repairedFiles, quarantinedBlocks int
+	for _, f := range files {
+		newFiles, quarantined, err := compactor.Repair(f)
+		if err != nil {
+			fmt.Fprintf(w, "%s: could not be repaired: %v\n", f, err)
+			continue
+		} else if len(quarantined) == 0 {
+			fmt.Fprintf(w, "%s: healthy\n", f)
+			continue
+		}
+
+		if err := store.Replace([]string{f}, newFiles); err != nil {
+			return 0, 0, fmt.Errorf("%s: %v", f, err)
+		}
+
+		for _, b := range quarantined {
+			fmt.Fprintf(w, "%s: quarantined key %q from %d to %d: %s\n", f, b.Key, b.MinTime, b.MaxTime, b.Reason)
+		}
+		repairedFiles++
+		quarantinedBlocks += len(quarantined)
+	}
+	return repairedFiles, quarantinedBlocks, nil
+}
+
+// printUsage prints the usage message to STDERR.
+func (cmd *Command) printUsage() {
+	usage := fmt.Sprintf(`Rewrites TSM files without any blocks that fail verification.
+The key and time range of each dropped block is written to a .%[2]s
+file next to the TSM file.  The server must not be running.
+
+Usage: influx_inspect repair [flags]
+
+    -dir <path>
+            Root storage path
+            Defaults to "%[1]s/.influxdb".
+ `, os.Getenv("HOME"), tsm1.QuarantineFileExtension)
+
+	fmt.Fprintf(cmd.Stdout, usage)
+}
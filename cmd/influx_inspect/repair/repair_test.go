@@ -0,0 +1,164 @@
+package repair_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb/cmd/influx_inspect/repair"
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
+)
+
+// Ensure the command quarantines corrupt blocks and leaves healthy files untouched.
+func TestCommand_Run(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	corruptDir := filepath.Join(dir, "data", "db0", "rp0", "1")
+	healthyDir := filepath.Join(dir, "data", "db0", "rp0", "2")
+
+	corrupt := MustWriteTSMFile(filepath.Join(corruptDir, "000000001-000000001.tsm"), []string{"cpu,host=a#!~#value", "cpu,host=b#!~#value"})
+	healthy := MustWriteTSMFile(filepath.Join(healthyDir, "000000001-000000001.tsm"), []string{"mem#!~#value"})
+
+	// Corrupt the values of the block for host a.
+	r := MustOpenTSMReader(corrupt)
+	offset := r.Entries("cpu,host=a#!~#value")[0].Offset
+	r.Close()
+	MustCorruptFile(corrupt, offset+10)
+
+	var stdout bytes.Buffer
+	cmd := repair.NewCommand()
+	cmd.Stdout, cmd.Stderr = &stdout, &stdout
+	if err := cmd.Run("-dir", dir); err != nil {
+		t.Fatalf("unexpected error: %s\n%s", err, stdout.String())
+	}
+
+	out := stdout.String()
+	if !strings.Contains(out, healthy+": healthy") {
+		t.Fatalf("expected healthy file to be reported:\n%s", out)
+	} else if !strings.Contains(out, `quarantined key "cpu,host=a#!~#value"`) {
+		t.Fatalf("expected quarantined block to be reported:\n%s", out)
+	} else if !strings.Contains(out, "Repaired Files: 1, Quarantined Blocks: 1") {
+		t.Fatalf("unexpected summary:\n%s", out)
+	}
+
+	// The quarantine file is kept next to the original file name.
+	if buf, err := ioutil.ReadFile(corrupt + "." + tsm1.QuarantineFileExtension); err != nil {
+		t.Fatal(err)
+	} else if !strings.Contains(string(buf), "cpu,host=a#!~#value") {
+		t.Fatalf("unexpected quarantine file: %s", buf)
+	}
+
+	// The repaired shard only holds the healthy block.
+	files, err := filepath.Glob(filepath.Join(corruptDir, "*."+tsm1.TSMFileExtension))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(files) != 1 || files[0] == corrupt {
+		t.Fatalf("unexpected TSM files: %v", files)
+	}
+
+	r = MustOpenTSMReader(files[0])
+	defer r.Close()
+	if keys := r.KeyCount(); keys != 1 {
+		t.Fatalf("unexpected key count: %d", keys)
+	} else if !r.Contains("cpu,host=b#!~#value") {
+		t.Fatal("expected healthy block to be kept")
+	} else if err := r.Verify(nil, nil); err != nil {
+		t.Fatalf("unexpected verify error: %v", err)
+	}
+
+	// Healthy files are unchanged.
+	if _, err := os.Stat(healthy); err != nil {
+		t.Fatal(err)
+	} else if _, err := os.Stat(healthy + "." + tsm1.QuarantineFileExtension); !os.IsNotExist(err) {
+		t.Fatalf("unexpected quarantine file: %v", err)
+	}
+}
+
+// Ensure a shard without TSM files is reported with nothing repaired.
+func TestCommand_Run_Empty(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "data", "db0", "rp0", "1"), 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	cmd := repair.NewCommand()
+	cmd.Stdout, cmd.Stderr = &stdout, &stdout
+	if err := cmd.Run("-dir", dir); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if !strings.Contains(stdout.String(), "Repaired Files: 0, Quarantined Blocks: 0") {
+		t.Fatalf("unexpected output:\n%s", stdout.String())
+	}
+}
+
+// MustTempDir returns a temporary directory. Panic on error.
+func MustTempDir() string {
+	dir, err := ioutil.TempDir("", "influx-inspect-repair-")
+	if err != nil {
+		panic(err)
+	}
+	return dir
+}
+
+// MustWriteTSMFile writes a TSM file to path with a value for each of keys and returns
+// path. Panic on error.
+func MustWriteTSMFile(path string, keys []string) string {
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		panic(err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		panic(err)
+	}
+
+	w, err := tsm1.NewTSMWriter(f)
+	if err != nil {
+		panic(err)
+	}
+	for _, key := range keys {
+		if err := w.Write(key, []tsm1.Value{tsm1.NewValue(0, 1.0), tsm1.NewValue(1, 2.0)}); err != nil {
+			panic(err)
+		}
+	}
+
+	if err := w.WriteIndex(); err != nil {
+		panic(err)
+	} else if err := w.Close(); err != nil {
+		panic(err)
+	}
+	return path
+}
+
+// MustOpenTSMReader returns a reader for the TSM file at path. Panic on error.
+func MustOpenTSMReader(path string) *tsm1.TSMReader {
+	f, err := os.Open(path)
+	if err != nil {
+		panic(err)
+	}
+
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// MustCorruptFile overwrites two bytes of the file at path at offset. Panic on error.
+func MustCorruptFile(path string, offset int64) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0666)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteAt([]byte{0xff, 0xff}, offset); err != nil {
+		panic(err)
+	}
+}
@@ -24,6 +24,7 @@ type Command struct {
 	Stdout io.Writer
 
 	dir      string
+	coldDir  string
 	pattern  string
 	detailed bool
 }
@@ -41,6 +42,7 @@ func (cmd *Command) Run(args ...string) error {
 	fs := flag.NewFlagSet("report", flag.ExitOnError)
 	fs.StringVar(&cmd.pattern, "pattern", "", "Include only files matching a pattern")
 	fs.BoolVar(&cmd.detailed, "detailed", false, "Report detailed cardinality estimates")
+	fs.StringVar(&cmd.coldDir, "cold-dir", "", "Include files moved to the cold tier directory of the shard")
 
 	fs.SetOutput(cmd.Stdout)
 	fs.Usage = cmd.printUsage
@@ -57,6 +59,14 @@ func (cmd *Command) Run(args ...string) error {
 		return err
 	}
 
+	if cmd.coldDir != "" {
+		coldFiles, err := filepath.Glob(filepath.Join(cmd.coldDir, fmt.Sprintf("*.%s", tsm1.TSMFileExtension)))
+		if err != nil {
+			return err
+		}
+		files = append(files, coldFiles...)
+	}
+
 	var filtered []string
 	if cmd.pattern != "" {
 		for _, f := range files {
@@ -72,13 +82,17 @@ func (cmd *Command) Run(args ...string) error {
 	}
 
 	tw := tabwriter.NewWriter(cmd.Stdout, 8, 8, 1, '\t', 0)
-	fmt.Fprintln(tw, strings.Join([]string{"File", "Series", "Load Time"}, "\t"))
+	fmt.Fprintln(tw, strings.Join([]string{"File", "Tier", "Series", "Size", "Load Time"}, "\t"))
 
 	totalSeries := hllpp.New()
 	tagCardinalities := map[string]*hllpp.HLLPP{}
 	measCardinalities := map[string]*hllpp.HLLPP{}
 	fieldCardinalities := map[string]*hllpp.HLLPP{}
 
+	// Number of files and bytes in each storage tier
+	tierFiles := map[tsm1.Tier]int{}
+	tierSizes := map[tsm1.Tier]int64{}
+
 	for _, f := range files {
 		file, err := os.OpenFile(f, os.O_RDONLY, 0600)
 		if err != nil {
@@ -128,11 +142,25 @@ func (cmd *Command) Run(args ...string) error {
 				}
 			}
 		}
+		tier := tsm1.HotTier
+		if cmd.coldDir != "" && filepath.Dir(f) == filepath.Clean(cmd.coldDir) {
+			tier = tsm1.ColdTier
+		}
+
+		size := int64(reader.Size())
+		for _, ts := range reader.TombstoneFiles() {
+			size += int64(ts.Size)
+		}
+		tierFiles[tier]++
+		tierSizes[tier] += size
+
 		reader.Close()
 
 		fmt.Fprintln(tw, strings.Join([]string{
 			filepath.Base(file.Name()),
+			tier.String(),
 			strconv.FormatInt(int64(seriesCount), 10),
+			strconv.FormatInt(size, 10),
 			loadTime.String(),
 		}, "\t"))
 		tw.Flush()
@@ -143,6 +171,10 @@ func (cmd *Command) Run(args ...string) error {
 	fmt.Printf("Statistics\n")
 	fmt.Printf("\tSeries:\n")
 	fmt.Printf("\t\tTotal (est): %d\n", totalSeries.Count())
+	fmt.Printf("\tTiers:\n")
+	for _, tier := range []tsm1.Tier{tsm1.HotTier, tsm1.ColdTier} {
+		fmt.Printf("\t\t%v: %d files, %d bytes\n", tier, tierFiles[tier], tierSizes[tier])
+	}
 
 	if cmd.detailed {
 		fmt.Printf("\tMeasurements (est):\n")
@@ -186,6 +218,8 @@ Usage: influx_inspect report [flags]
     -detailed
             Report detailed cardinality estimates.
             Defaults to "false".
+    -cold-dir <path>
+            Include the TSM files of the shard moved to this cold tier directory.
 `
 
 	fmt.Fprintf(cmd.Stdout, usage)
@@ -164,6 +164,7 @@ func NewServer(c *Config, buildInfo *BuildInfo) (*Server, error) {
 	}
 
 	s.TSDBStore = tsdb.NewStore(c.Data.Dir)
+	s.Monitor.RegisterDiagnosticsClient("corrupt-shards", s.TSDBStore)
 	s.TSDBStore.EngineOptions.Config = c.Data
 
 	// Copy TSDB configuration.
@@ -262,6 +263,7 @@ func (s *Server) appendHTTPDService(c httpd.Config) {
 	srv.Handler.QueryExecutor = s.QueryExecutor
 	srv.Handler.Monitor = s.Monitor
 	srv.Handler.PointsWriter = s.PointsWriter
+	srv.Handler.TSDBStore = s.TSDBStore
 	srv.Handler.Version = s.buildInfo.Version
 
 	s.Services = append(s.Services, srv)
@@ -408,6 +410,11 @@ func (s *Server) Open() error {
 	s.Monitor.WithLogger(s.Logger)
 
 	// Open TSDB store.
+	// Apply the measurement quotas stored in the meta data.
+	for _, dbi := range s.MetaClient.Databases() {
+		s.TSDBStore.SetMeasurementQuotas(dbi.Name, coordinator.MeasurementQuotas(&dbi))
+	}
+
 	if err := s.TSDBStore.Open(); err != nil {
 		return fmt.Errorf("open tsdb store: %s", err)
 	}
@@ -452,6 +459,7 @@ func (s *Server) Close() error {
 	}
 
 	s.config.deregisterDiagnostics(s.Monitor)
+	s.Monitor.DeregisterDiagnosticsClient("corrupt-shards")
 
 	if s.PointsWriter != nil {
 		s.PointsWriter.Close()
@@ -23,10 +23,16 @@ type MetaClient interface {
 	DropRetentionPolicy(database, name string) error
 	DropSubscription(database, rp, name string) error
 	DropUser(name string) error
+	MergeableShardGroups(ids []uint64) ([]meta.ShardGroupInfo, error)
+	MergeShardGroups(ids []uint64) error
+	UnmergeShardGroups(groups []meta.ShardGroupInfo) error
 	RetentionPolicy(database, name string) (rpi *meta.RetentionPolicyInfo, err error)
 	SetAdminPrivilege(username string, admin bool) error
+	SetMeasurementQuota(database, name, key string, n int) error
 	SetPrivilege(username, database string, p influxql.Privilege) error
 	ShardGroupsByTimeRange(database, policy string, min, max time.Time) (a []meta.ShardGroupInfo, err error)
+	ShardGroupSplits(id uint64, n int) ([]meta.ShardGroupInfo, error)
+	SplitShardGroup(id uint64, n int) ([]meta.ShardGroupInfo, error)
 	UpdateRetentionPolicy(database, name string, rpu *meta.RetentionPolicyUpdate, makeDefault bool) error
 	UpdateUser(name, password string) error
 	UserPrivilege(username, database string) (*influxql.Privilege, error)
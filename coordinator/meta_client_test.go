@@ -27,11 +27,17 @@ type MetaClient struct {
 	DropSubscriptionFn                  func(database, rp, name string) error
 	DropShardFn                         func(id uint64) error
 	DropUserFn                          func(name string) error
+	MergeableShardGroupsFn              func(ids []uint64) ([]meta.ShardGroupInfo, error)
+	MergeShardGroupsFn                  func(ids []uint64) error
+	UnmergeShardGroupsFn                func(groups []meta.ShardGroupInfo) error
 	MetaNodesFn                         func() ([]meta.NodeInfo, error)
 	RetentionPolicyFn                   func(database, name string) (rpi *meta.RetentionPolicyInfo, err error)
 	SetAdminPrivilegeFn                 func(username string, admin bool) error
+	SetMeasurementQuotaFn               func(database, name, key string, n int) error
 	SetPrivilegeFn                      func(username, database string, p influxql.Privilege) error
 	ShardGroupsByTimeRangeFn            func(database, policy string, min, max time.Time) (a []meta.ShardGroupInfo, err error)
+	ShardGroupSplitsFn                  func(id uint64, n int) ([]meta.ShardGroupInfo, error)
+	SplitShardGroupFn                   func(id uint64, n int) ([]meta.ShardGroupInfo, error)
 	UpdateRetentionPolicyFn             func(database, name string, rpu *meta.RetentionPolicyUpdate, makeDefault bool) error
 	UpdateUserFn                        func(name, password string) error
 	UserPrivilegeFn                     func(username, database string) (*influxql.Privilege, error)
@@ -111,6 +117,18 @@ func (c *MetaClient) DropUser(name string) error {
 	return c.DropUserFn(name)
 }
 
+func (c *MetaClient) MergeableShardGroups(ids []uint64) ([]meta.ShardGroupInfo, error) {
+	return c.MergeableShardGroupsFn(ids)
+}
+
+func (c *MetaClient) MergeShardGroups(ids []uint64) error {
+	return c.MergeShardGroupsFn(ids)
+}
+
+func (c *MetaClient) UnmergeShardGroups(groups []meta.ShardGroupInfo) error {
+	return c.UnmergeShardGroupsFn(groups)
+}
+
 func (c *MetaClient) MetaNodes() ([]meta.NodeInfo, error) {
 	return c.MetaNodesFn()
 }
@@ -123,6 +141,10 @@ func (c *MetaClient) SetAdminPrivilege(username string, admin bool) error {
 	return c.SetAdminPrivilegeFn(username, admin)
 }
 
+func (c *MetaClient) SetMeasurementQuota(database, name, key string, n int) error {
+	return c.SetMeasurementQuotaFn(database, name, key, n)
+}
+
 func (c *MetaClient) SetPrivilege(username, database string, p influxql.Privilege) error {
 	return c.SetPrivilegeFn(username, database, p)
 }
@@ -131,6 +153,14 @@ func (c *MetaClient) ShardGroupsByTimeRange(database, policy string, min, max ti
 	return c.ShardGroupsByTimeRangeFn(database, policy, min, max)
 }
 
+func (c *MetaClient) ShardGroupSplits(id uint64, n int) ([]meta.ShardGroupInfo, error) {
+	return c.ShardGroupSplitsFn(id, n)
+}
+
+func (c *MetaClient) SplitShardGroup(id uint64, n int) ([]meta.ShardGroupInfo, error) {
+	return c.SplitShardGroupFn(id, n)
+}
+
 func (c *MetaClient) UpdateRetentionPolicy(database, name string, rpu *meta.RetentionPolicyUpdate, makeDefault bool) error {
 	return c.UpdateRetentionPolicyFn(database, name, rpu, makeDefault)
 }
@@ -154,7 +184,7 @@ func (c *MetaClient) Users() []meta.UserInfo {
 // DefaultMetaClientDatabaseFn returns a single database (db0) with a retention policy.
 func DefaultMetaClientDatabaseFn(name string) *meta.DatabaseInfo {
 	return &meta.DatabaseInfo{
-		Name: DefaultDatabase,
+		Name:                   DefaultDatabase,
 		DefaultRetentionPolicy: DefaultRetentionPolicy,
 	}
 }
@@ -359,6 +359,12 @@ func (w *PointsWriter) writeToShard(shard *meta.ShardInfo, database, retentionPo
 		return err
 	}
 
+	// Retrying a write rejected by a full cache would only wait for room again.
+	if err == tsdb.ErrCacheFull {
+		atomic.AddInt64(&w.stats.WriteErr, 1)
+		return err
+	}
+
 	// If we've written to shard that should exist on the current node, but the store has
 	// not actually created this shard, tell it to create it and retry the write
 	if err == tsdb.ErrShardNotFound {
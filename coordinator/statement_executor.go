@@ -7,6 +7,7 @@ import (
 	"io"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/influxdata/influxdb"
@@ -66,6 +67,11 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx influx
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
 		}
 		err = e.executeAlterRetentionPolicyStatement(stmt)
+	case *influxql.AlterMeasurementStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeAlterMeasurementStatement(stmt, ctx.Database)
 	case *influxql.CreateContinuousQueryStatement:
 		if ctx.ReadOnly {
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
@@ -113,6 +119,11 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx influx
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
 		}
 		err = e.executeDropSeriesStatement(stmt, ctx.Database)
+	case *influxql.DropInactiveSeriesStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeDropInactiveSeriesStatement(stmt, ctx.Database)
 	case *influxql.DropRetentionPolicyStatement:
 		if ctx.ReadOnly {
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
@@ -123,6 +134,16 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx influx
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
 		}
 		err = e.executeDropShardStatement(stmt)
+	case *influxql.SplitShardStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeSplitShardStatement(stmt)
+	case *influxql.MergeShardsStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeMergeShardsStatement(stmt)
 	case *influxql.DropSubscriptionStatement:
 		if ctx.ReadOnly {
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
@@ -159,12 +180,20 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx influx
 		rows, err = e.executeShowDatabasesStatement(stmt, &ctx)
 	case *influxql.ShowDiagnosticsStatement:
 		rows, err = e.executeShowDiagnosticsStatement(stmt)
+	case *influxql.ShowFieldKeyCardinalityStatement:
+		rows, err = e.executeShowFieldKeyCardinalityStatement(stmt)
 	case *influxql.ShowGrantsForUserStatement:
 		rows, err = e.executeShowGrantsForUserStatement(stmt)
+	case *influxql.ShowInactiveSeriesStatement:
+		rows, err = e.executeShowInactiveSeriesStatement(stmt)
+	case *influxql.ShowMeasurementCardinalityStatement:
+		rows, err = e.executeShowMeasurementCardinalityStatement(stmt)
 	case *influxql.ShowMeasurementsStatement:
 		return e.executeShowMeasurementsStatement(stmt, &ctx)
 	case *influxql.ShowRetentionPoliciesStatement:
 		rows, err = e.executeShowRetentionPoliciesStatement(stmt)
+	case *influxql.ShowSeriesCardinalityStatement:
+		rows, err = e.executeShowSeriesCardinalityStatement(stmt)
 	case *influxql.ShowShardsStatement:
 		rows, err = e.executeShowShardsStatement(stmt)
 	case *influxql.ShowShardGroupsStatement:
@@ -175,6 +204,8 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx influx
 		rows, err = e.executeShowSubscriptionsStatement(stmt)
 	case *influxql.ShowTagValuesStatement:
 		return e.executeShowTagValues(stmt, &ctx)
+	case *influxql.ShowTagValuesCardinalityStatement:
+		rows, err = e.executeShowTagValuesCardinalityStatement(stmt)
 	case *influxql.ShowUsersStatement:
 		rows, err = e.executeShowUsersStatement(stmt)
 	case *influxql.SetPasswordUserStatement:
@@ -206,6 +237,13 @@ func (e *StatementExecutor) executeAlterRetentionPolicyStatement(stmt *influxql.
 		ReplicaN:           stmt.Replication,
 		ShardGroupDuration: stmt.ShardGroupDuration,
 	}
+	if stmt.Downsample != nil {
+		rpu.SetDownsample(meta.DownsamplePolicy{
+			After:     stmt.Downsample.After,
+			Interval:  stmt.Downsample.Interval,
+			Functions: stmt.Downsample.Functions,
+		})
+	}
 
 	// Update the retention policy.
 	if err := e.MetaClient.UpdateRetentionPolicy(stmt.Database, stmt.Name, rpu, stmt.Default); err != nil {
@@ -214,6 +252,44 @@ func (e *StatementExecutor) executeAlterRetentionPolicyStatement(stmt *influxql.
 	return nil
 }
 
+func (e *StatementExecutor) executeAlterMeasurementStatement(stmt *influxql.AlterMeasurementStatement, database string) error {
+	if stmt.Database != "" {
+		database = stmt.Database
+	}
+	if database == "" {
+		return ErrDatabaseNameRequired
+	}
+
+	// Update the quota in the Meta Store.
+	if err := e.MetaClient.SetMeasurementQuota(database, stmt.Name, stmt.Key, stmt.Max); err != nil {
+		return err
+	}
+
+	// Locally apply the quotas of the database.
+	dbi := e.MetaClient.Database(database)
+	if dbi == nil {
+		return influxdb.ErrDatabaseNotFound(database)
+	}
+	e.TSDBStore.SetMeasurementQuotas(database, MeasurementQuotas(dbi))
+	return nil
+}
+
+// MeasurementQuotas returns the measurement quotas of a database keyed by measurement.
+func MeasurementQuotas(dbi *meta.DatabaseInfo) map[string]tsdb.MeasurementQuota {
+	quotas := make(map[string]tsdb.MeasurementQuota, len(dbi.MeasurementQuotas))
+	for _, mqi := range dbi.MeasurementQuotas {
+		q := tsdb.MeasurementQuota{MaxSeries: mqi.MaxSeries}
+		if len(mqi.TagQuotas) > 0 {
+			q.MaxTagValues = make(map[string]int, len(mqi.TagQuotas))
+			for _, tqi := range mqi.TagQuotas {
+				q.MaxTagValues[tqi.Key] = tqi.MaxValues
+			}
+		}
+		quotas[mqi.Name] = q
+	}
+	return quotas
+}
+
 func (e *StatementExecutor) executeCreateContinuousQueryStatement(q *influxql.CreateContinuousQueryStatement) error {
 	// Verify that retention policies exist.
 	var err error
@@ -286,6 +362,13 @@ func (e *StatementExecutor) executeCreateRetentionPolicyStatement(stmt *influxql
 		ReplicaN:           &stmt.Replication,
 		ShardGroupDuration: stmt.ShardGroupDuration,
 	}
+	if stmt.Downsample != nil && stmt.Downsample.After > 0 {
+		spec.Downsample = &meta.DownsamplePolicy{
+			After:     stmt.Downsample.After,
+			Interval:  stmt.Downsample.Interval,
+			Functions: stmt.Downsample.Functions,
+		}
+	}
 
 	// Create new retention policy.
 	_, err := e.MetaClient.CreateRetentionPolicy(stmt.Database, &spec, stmt.Default)
@@ -361,6 +444,21 @@ func (e *StatementExecutor) executeDropSeriesStatement(stmt *influxql.DropSeries
 	return e.TSDBStore.DeleteSeries(database, stmt.Sources, stmt.Condition)
 }
 
+func (e *StatementExecutor) executeDropInactiveSeriesStatement(stmt *influxql.DropInactiveSeriesStatement, database string) error {
+	if dbi := e.MetaClient.Database(database); dbi == nil {
+		return influxql.ErrDatabaseNotFound(database)
+	}
+
+	cond, min, max, err := splitLastWriteCondition(stmt.Condition)
+	if err != nil {
+		return err
+	}
+
+	// Locally drop the series.
+	_, err = e.TSDBStore.DeleteInactiveSeries(database, stmt.Sources, cond, min, max)
+	return err
+}
+
 func (e *StatementExecutor) executeDropShardStatement(stmt *influxql.DropShardStatement) error {
 	// Locally delete the shard.
 	if err := e.TSDBStore.DeleteShard(stmt.ID); err != nil {
@@ -371,6 +469,88 @@ func (e *StatementExecutor) executeDropShardStatement(stmt *influxql.DropShardSt
 	return e.MetaClient.DropShard(stmt.ID)
 }
 
+func (e *StatementExecutor) executeSplitShardStatement(stmt *influxql.SplitShardStatement) error {
+	groups, err := e.MetaClient.ShardGroupSplits(stmt.ID, stmt.N)
+	if err != nil {
+		return err
+	}
+
+	var splits []tsdb.ShardSplit
+	for _, g := range groups[1:] {
+		splits = append(splits, tsdb.ShardSplit{StartTime: g.StartTime, EndTime: g.EndTime})
+	}
+
+	// The shard is read-only while its data is copied, and the split is only committed
+	// to the Meta Store, which sends new writes to the new shards, once it is copied.
+	var committed []meta.ShardGroupInfo
+	err = e.TSDBStore.SplitShard(stmt.ID, groups[0].StartTime, groups[0].EndTime, splits, func() ([]uint64, error) {
+		a, err := e.MetaClient.SplitShardGroup(stmt.ID, stmt.N)
+		if err != nil {
+			return nil, err
+		}
+		committed = a
+		return meta.SplitShardIDs(stmt.ID, groups, committed)
+	})
+	if err != nil && committed != nil {
+		// Merge the shard groups again so the shard keeps its time range.
+		ids := make([]uint64, len(committed))
+		for i, g := range committed {
+			ids[i] = g.Shards[0].ID
+		}
+		if merr := e.MetaClient.MergeShardGroups(ids); merr != nil {
+			return fmt.Errorf("%s, and undoing the split failed: %s", err, merr)
+		}
+	}
+	return err
+}
+
+func (e *StatementExecutor) executeMergeShardsStatement(stmt *influxql.MergeShardsStatement) error {
+	groups, err := e.MetaClient.MergeableShardGroups(stmt.IDs)
+	if err != nil {
+		return err
+	}
+
+	// Locally rewrite each shard of the first shard group with the data of the
+	// shards at the same position in the other shard groups.  The shards are
+	// read-only while their data is copied, and the first shard group is extended
+	// in the Meta Store, which removes the others, once the data of a shard is copied.
+	var committed bool
+	commit := func() error {
+		if committed {
+			return nil
+		} else if err := e.MetaClient.MergeShardGroups(stmt.IDs); err != nil {
+			return err
+		}
+		committed = true
+		return nil
+	}
+
+	for i, sh := range groups[0].Shards {
+		var ids []uint64
+		for _, g := range groups[1:] {
+			ids = append(ids, g.Shards[i].ID)
+		}
+		if err := e.TSDBStore.MergeShards(sh.ID, ids, commit); err != nil {
+			if committed {
+				// Restore the shard groups so the shards keep their time ranges.
+				if merr := e.MetaClient.UnmergeShardGroups(groups); merr != nil {
+					return fmt.Errorf("%s, and undoing the merge failed: %s", err, merr)
+				}
+			}
+			return err
+		}
+	}
+
+	for _, g := range groups[1:] {
+		for _, sh := range g.Shards {
+			if err := e.TSDBStore.DeleteShard(sh.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (e *StatementExecutor) executeDropRetentionPolicyStatement(stmt *influxql.DropRetentionPolicyStatement) error {
 	dbi := e.MetaClient.Database(stmt.Database)
 	if dbi == nil {
@@ -683,7 +863,18 @@ func (e *StatementExecutor) executeShowMeasurementsStatement(q *influxql.ShowMea
 		return ErrDatabaseNameRequired
 	}
 
-	names, err := e.TSDBStore.MeasurementNames(q.Database, q.Condition)
+	var names [][]byte
+	var err error
+	if influxql.HasTimeExpr(q.Condition) {
+		// Only read the shards that overlap the time range.
+		var shardIDs []uint64
+		cond := influxql.Reduce(q.Condition, &influxql.NowValuer{Now: time.Now().UTC()})
+		if shardIDs, err = e.shardIDsByTimeRange(q.Database, cond); err == nil {
+			names, err = e.TSDBStore.MeasurementNamesInShards(shardIDs, cond)
+		}
+	} else {
+		names, err = e.TSDBStore.MeasurementNames(q.Database, q.Condition)
+	}
 	if err != nil || len(names) == 0 {
 		return ctx.Send(&influxql.Result{
 			StatementID: ctx.StatementID,
@@ -856,7 +1047,18 @@ func (e *StatementExecutor) executeShowTagValues(q *influxql.ShowTagValuesStatem
 		return ErrDatabaseNameRequired
 	}
 
-	tagValues, err := e.TSDBStore.TagValues(q.Database, q.Condition)
+	var tagValues []tsdb.TagValues
+	var err error
+	if influxql.HasTimeExpr(q.Condition) {
+		// Only read the shards that overlap the time range.
+		var shardIDs []uint64
+		cond := influxql.Reduce(q.Condition, &influxql.NowValuer{Now: time.Now().UTC()})
+		if shardIDs, err = e.shardIDsByTimeRange(q.Database, cond); err == nil {
+			tagValues, err = e.TSDBStore.TagValuesInShards(shardIDs, cond)
+		}
+	} else {
+		tagValues, err = e.TSDBStore.TagValues(q.Database, q.Condition)
+	}
 	if err != nil {
 		return ctx.Send(&influxql.Result{
 			StatementID: ctx.StatementID,
@@ -913,6 +1115,242 @@ func (e *StatementExecutor) executeShowTagValues(q *influxql.ShowTagValuesStatem
 	return nil
 }
 
+// shardIDsByTimeRange returns the IDs of the shards in every retention policy of the
+// database whose shard groups overlap the time range of the condition.
+func (e *StatementExecutor) shardIDsByTimeRange(database string, cond influxql.Expr) ([]uint64, error) {
+	di := e.MetaClient.Database(database)
+	if di == nil {
+		return nil, influxdb.ErrDatabaseNotFound(database)
+	}
+
+	tmin, tmax, err := influxql.TimeRange(cond)
+	if err != nil {
+		return nil, err
+	}
+	if tmin.IsZero() {
+		tmin = time.Unix(0, influxql.MinTime).UTC()
+	}
+	if tmax.IsZero() {
+		tmax = time.Unix(0, influxql.MaxTime).UTC()
+	}
+
+	var shardIDs []uint64
+	for _, rpi := range di.RetentionPolicies {
+		groups, err := e.MetaClient.ShardGroupsByTimeRange(database, rpi.Name, tmin, tmax)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range groups {
+			for _, si := range g.Shards {
+				shardIDs = append(shardIDs, si.ID)
+			}
+		}
+	}
+	return shardIDs, nil
+}
+
+func (e *StatementExecutor) executeShowSeriesCardinalityStatement(q *influxql.ShowSeriesCardinalityStatement) (models.Rows, error) {
+	if q.Database == "" {
+		return nil, ErrDatabaseNameRequired
+	}
+
+	// The sketches can't be filtered so a condition requires an exact count.
+	if !q.Exact && q.Condition == nil {
+		n, err := e.TSDBStore.SeriesCardinality(q.Database)
+		if err != nil {
+			return nil, err
+		}
+		return cardinalityEstimationRows(n), nil
+	}
+
+	counts, err := e.TSDBStore.SeriesCardinalityByMeasurement(q.Database, q.Condition)
+	if err != nil {
+		return nil, err
+	}
+	return measurementCountRows(counts), nil
+}
+
+func (e *StatementExecutor) executeShowInactiveSeriesStatement(q *influxql.ShowInactiveSeriesStatement) (models.Rows, error) {
+	if q.Database == "" {
+		return nil, ErrDatabaseNameRequired
+	} else if dbi := e.MetaClient.Database(q.Database); dbi == nil {
+		return nil, influxql.ErrDatabaseNotFound(q.Database)
+	}
+
+	cond, min, max, err := splitLastWriteCondition(q.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := e.TSDBStore.InactiveSeries(q.Database, q.Sources, cond, min, max)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply the offset and limit.
+	if q.Offset > 0 {
+		if q.Offset >= len(series) {
+			series = nil
+		} else {
+			series = series[q.Offset:]
+		}
+	}
+	if q.Limit > 0 && q.Limit < len(series) {
+		series = series[:q.Limit]
+	}
+
+	row := &models.Row{Columns: []string{"key", "last_write"}}
+	for _, s := range series {
+		var lastWrite interface{}
+		if s.HasValues {
+			lastWrite = time.Unix(0, s.LastWrite).UTC().Format(time.RFC3339Nano)
+		}
+		row.Values = append(row.Values, []interface{}{s.Key, lastWrite})
+	}
+	return []*models.Row{row}, nil
+}
+
+// splitLastWriteCondition returns the range of last write times in the condition of
+// SHOW or DROP INACTIVE SERIES and the condition without the last_write comparisons.
+func splitLastWriteCondition(cond influxql.Expr) (influxql.Expr, int64, int64, error) {
+	if influxql.HasTimeExpr(cond) {
+		return nil, 0, 0, errors.New("time is not supported in the WHERE clause, use last_write")
+	}
+
+	// Convert "now()" to current time.
+	cond = influxql.Reduce(cond, &influxql.NowValuer{Now: time.Now().UTC()})
+
+	if ok, err := hasLastWriteExpr(cond); err != nil {
+		return nil, 0, 0, err
+	} else if !ok {
+		return nil, 0, 0, errors.New("last_write condition required in the WHERE clause")
+	}
+
+	// Determine the range from the last_write comparisons as if they were on time.
+	timeCond := influxql.RewriteExpr(influxql.CloneExpr(cond), func(e influxql.Expr) influxql.Expr {
+		if isLastWriteRef(e) {
+			return &influxql.VarRef{Val: "time"}
+		}
+		return e
+	})
+	min, max, err := influxql.TimeRangeAsEpochNano(timeCond)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	cond = influxql.RewriteExpr(influxql.CloneExpr(cond), func(e influxql.Expr) influxql.Expr {
+		if e, ok := e.(*influxql.BinaryExpr); ok && (isLastWriteRef(e.LHS) || isLastWriteRef(e.RHS)) {
+			return nil
+		}
+		return e
+	})
+	return cond, min, max, nil
+}
+
+// hasLastWriteExpr returns true if expr compares last_write.  It returns an error if
+// last_write is used within an OR condition.
+func hasLastWriteExpr(expr influxql.Expr) (bool, error) {
+	switch expr := expr.(type) {
+	case *influxql.BinaryExpr:
+		if expr.Op == influxql.AND || expr.Op == influxql.OR {
+			lhs, err := hasLastWriteExpr(expr.LHS)
+			if err != nil {
+				return false, err
+			}
+			rhs, err := hasLastWriteExpr(expr.RHS)
+			if err != nil {
+				return false, err
+			}
+			if expr.Op == influxql.OR && (lhs || rhs) {
+				return false, errors.New("last_write is not supported in OR conditions")
+			}
+			return lhs || rhs, nil
+		}
+		return isLastWriteRef(expr.LHS) || isLastWriteRef(expr.RHS), nil
+	case *influxql.ParenExpr:
+		return hasLastWriteExpr(expr.Expr)
+	default:
+		return false, nil
+	}
+}
+
+// isLastWriteRef returns true if expr is a reference to last_write.
+func isLastWriteRef(expr influxql.Expr) bool {
+	ref, ok := expr.(*influxql.VarRef)
+	return ok && strings.ToLower(ref.Val) == "last_write"
+}
+
+func (e *StatementExecutor) executeShowMeasurementCardinalityStatement(q *influxql.ShowMeasurementCardinalityStatement) (models.Rows, error) {
+	if q.Database == "" {
+		return nil, ErrDatabaseNameRequired
+	}
+
+	// The sketches can't be filtered so a condition requires an exact count.
+	if !q.Exact && q.Condition == nil {
+		n, err := e.TSDBStore.MeasurementsCardinality(q.Database)
+		if err != nil {
+			return nil, err
+		}
+		return cardinalityEstimationRows(n), nil
+	}
+
+	names, err := e.TSDBStore.MeasurementNames(q.Database, q.Condition)
+	if err != nil {
+		return nil, err
+	}
+	return []*models.Row{{
+		Columns: []string{"count"},
+		Values:  [][]interface{}{{len(names)}},
+	}}, nil
+}
+
+func (e *StatementExecutor) executeShowTagValuesCardinalityStatement(q *influxql.ShowTagValuesCardinalityStatement) (models.Rows, error) {
+	if q.Database == "" {
+		return nil, ErrDatabaseNameRequired
+	}
+
+	// There are no sketches of tag values so they are always counted exactly.
+	counts, err := e.TSDBStore.TagValuesCardinalityByMeasurement(q.Database, q.Condition)
+	if err != nil {
+		return nil, err
+	}
+	return measurementCountRows(counts), nil
+}
+
+func (e *StatementExecutor) executeShowFieldKeyCardinalityStatement(q *influxql.ShowFieldKeyCardinalityStatement) (models.Rows, error) {
+	if q.Database == "" {
+		return nil, ErrDatabaseNameRequired
+	}
+
+	// There are no sketches of field keys so they are always counted exactly.
+	counts, err := e.TSDBStore.FieldKeysCardinalityByMeasurement(q.Database, q.Condition)
+	if err != nil {
+		return nil, err
+	}
+	return measurementCountRows(counts), nil
+}
+
+// cardinalityEstimationRows returns the result of an estimated cardinality.
+func cardinalityEstimationRows(n int64) models.Rows {
+	return []*models.Row{{
+		Columns: []string{"cardinality estimation"},
+		Values:  [][]interface{}{{n}},
+	}}
+}
+
+// measurementCountRows returns a row with the count of each measurement.
+func measurementCountRows(counts []tsdb.MeasurementCount) models.Rows {
+	rows := make([]*models.Row, 0, len(counts))
+	for _, c := range counts {
+		rows = append(rows, &models.Row{
+			Name:    c.Measurement,
+			Columns: []string{"count"},
+			Values:  [][]interface{}{{c.N}},
+		})
+	}
+	return rows
+}
+
 func (e *StatementExecutor) executeShowUsersStatement(q *influxql.ShowUsersStatement) (models.Rows, error) {
 	row := &models.Row{Columns: []string{"user", "admin"}}
 	for _, ui := range e.MetaClient.Users() {
@@ -1093,9 +1531,30 @@ func (e *StatementExecutor) NormalizeStatement(stmt influxql.Statement, defaultD
 			if node.Database == "" {
 				node.Database = defaultDatabase
 			}
+		case *influxql.ShowInactiveSeriesStatement:
+			if node.Database == "" {
+				node.Database = defaultDatabase
+			}
+		case *influxql.ShowSeriesCardinalityStatement:
+			if node.Database == "" {
+				node.Database = defaultDatabase
+			}
+		case *influxql.ShowMeasurementCardinalityStatement:
+			if node.Database == "" {
+				node.Database = defaultDatabase
+			}
+		case *influxql.ShowTagValuesCardinalityStatement:
+			if node.Database == "" {
+				node.Database = defaultDatabase
+			}
+		case *influxql.ShowFieldKeyCardinalityStatement:
+			if node.Database == "" {
+				node.Database = defaultDatabase
+			}
 		case *influxql.Measurement:
 			switch stmt.(type) {
-			case *influxql.DropSeriesStatement, *influxql.DeleteSeriesStatement:
+			case *influxql.DropSeriesStatement, *influxql.DeleteSeriesStatement,
+				*influxql.DropInactiveSeriesStatement, *influxql.ShowInactiveSeriesStatement:
 			// DB and RP not supported by these statements so don't rewrite into invalid
 			// statements
 			default:
@@ -1161,8 +1620,24 @@ type TSDBStore interface {
 	DeleteSeries(database string, sources []influxql.Source, condition influxql.Expr) error
 	DeleteShard(id uint64) error
 
+	SplitShard(id uint64, start, end time.Time, splits []tsdb.ShardSplit, commit func() ([]uint64, error)) error
+	MergeShards(id uint64, ids []uint64, commit func() error) error
+
+	SetMeasurementQuotas(database string, quotas map[string]tsdb.MeasurementQuota)
+
 	MeasurementNames(database string, cond influxql.Expr) ([][]byte, error)
 	TagValues(database string, cond influxql.Expr) ([]tsdb.TagValues, error)
+	MeasurementNamesInShards(shardIDs []uint64, cond influxql.Expr) ([][]byte, error)
+	TagValuesInShards(shardIDs []uint64, cond influxql.Expr) ([]tsdb.TagValues, error)
+
+	InactiveSeries(database string, sources []influxql.Source, cond influxql.Expr, min, max int64) ([]tsdb.SeriesLastWrite, error)
+	DeleteInactiveSeries(database string, sources []influxql.Source, cond influxql.Expr, min, max int64) ([]tsdb.SeriesLastWrite, error)
+
+	SeriesCardinality(database string) (int64, error)
+	MeasurementsCardinality(database string) (int64, error)
+	SeriesCardinalityByMeasurement(database string, cond influxql.Expr) ([]tsdb.MeasurementCount, error)
+	TagValuesCardinalityByMeasurement(database string, cond influxql.Expr) ([]tsdb.MeasurementCount, error)
+	FieldKeysCardinalityByMeasurement(database string, cond influxql.Expr) ([]tsdb.MeasurementCount, error)
 }
 
 var _ TSDBStore = LocalTSDBStore{}
@@ -3,6 +3,7 @@ package coordinator_test
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"reflect"
@@ -244,6 +245,377 @@ func TestQueryExecutor_ExecuteQuery_ShowDatabases(t *testing.T) {
 	}
 }
 
+// Ensure splitting a shard moves its data into the shards of the new shard groups.
+func TestQueryExecutor_ExecuteQuery_SplitShard(t *testing.T) {
+	e := NewQueryExecutor()
+
+	t0 := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	e.MetaClient.ShardGroupSplitsFn = func(id uint64, n int) ([]meta.ShardGroupInfo, error) {
+		if id != 2 || n != 3 {
+			t.Fatalf("unexpected split: id=%d n=%d", id, n)
+		}
+		return []meta.ShardGroupInfo{
+			{StartTime: t0, EndTime: t0.Add(time.Hour)},
+			{StartTime: t0.Add(time.Hour), EndTime: t0.Add(2 * time.Hour)},
+			{StartTime: t0.Add(2 * time.Hour), EndTime: t0.Add(3 * time.Hour)},
+		}, nil
+	}
+
+	var committed bool
+	e.MetaClient.SplitShardGroupFn = func(id uint64, n int) ([]meta.ShardGroupInfo, error) {
+		committed = true
+		return []meta.ShardGroupInfo{
+			{ID: 1, StartTime: t0, EndTime: t0.Add(time.Hour), Shards: []meta.ShardInfo{{ID: 2}}},
+			{ID: 3, StartTime: t0.Add(time.Hour), EndTime: t0.Add(2 * time.Hour), Shards: []meta.ShardInfo{{ID: 4}}},
+			{ID: 4, StartTime: t0.Add(2 * time.Hour), EndTime: t0.Add(3 * time.Hour), Shards: []meta.ShardInfo{{ID: 5}}},
+		}, nil
+	}
+
+	var called bool
+	e.TSDBStore.SplitShardFn = func(id uint64, start, end time.Time, splits []tsdb.ShardSplit, commit func() ([]uint64, error)) error {
+		called = true
+		if id != 2 || !start.Equal(t0) || !end.Equal(t0.Add(time.Hour)) {
+			t.Fatalf("unexpected shard: id=%d start=%s end=%s", id, start, end)
+		}
+		exp := []tsdb.ShardSplit{
+			{StartTime: t0.Add(time.Hour), EndTime: t0.Add(2 * time.Hour)},
+			{StartTime: t0.Add(2 * time.Hour), EndTime: t0.Add(3 * time.Hour)},
+		}
+		if !reflect.DeepEqual(splits, exp) {
+			t.Fatalf("unexpected splits: exp %s, got %s", spew.Sdump(exp), spew.Sdump(splits))
+		}
+
+		// The split is only committed once the data is copied.
+		if committed {
+			t.Fatal("split committed before the data was copied")
+		}
+		ids, err := commit()
+		if err != nil {
+			t.Fatal(err)
+		} else if exp := []uint64{4, 5}; !reflect.DeepEqual(ids, exp) {
+			t.Fatalf("unexpected shard ids: exp %v, got %v", exp, ids)
+		}
+		return nil
+	}
+
+	if res := <-e.ExecuteQuery(`SPLIT SHARD 2 INTO 3`, "", 0); res.Err != nil {
+		t.Fatal(res.Err)
+	} else if !called {
+		t.Fatal("expected shard to be split")
+	}
+
+	// A split that fails after it is committed is undone.
+	var merged []uint64
+	e.MetaClient.MergeShardGroupsFn = func(ids []uint64) error {
+		merged = ids
+		return nil
+	}
+	e.TSDBStore.SplitShardFn = func(id uint64, start, end time.Time, splits []tsdb.ShardSplit, commit func() ([]uint64, error)) error {
+		if _, err := commit(); err != nil {
+			t.Fatal(err)
+		}
+		return errors.New("marker")
+	}
+
+	if res := <-e.ExecuteQuery(`SPLIT SHARD 2 INTO 3`, "", 0); res.Err == nil || res.Err.Error() != "marker" {
+		t.Fatalf("unexpected error: %v", res.Err)
+	} else if exp := []uint64{2, 4, 5}; !reflect.DeepEqual(merged, exp) {
+		t.Fatalf("unexpected merged shards: exp %v, got %v", exp, merged)
+	}
+}
+
+// Ensure merging shards rewrites the first shard before the merge is committed and
+// the others are removed, and that a failed merge is undone.
+func TestQueryExecutor_ExecuteQuery_MergeShards(t *testing.T) {
+	e := NewQueryExecutor()
+
+	t0 := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	e.MetaClient.MergeableShardGroupsFn = func(ids []uint64) ([]meta.ShardGroupInfo, error) {
+		if !reflect.DeepEqual(ids, []uint64{5, 2, 4}) {
+			t.Fatalf("unexpected shard ids: %v", ids)
+		}
+		return []meta.ShardGroupInfo{
+			{ID: 1, StartTime: t0, EndTime: t0.Add(time.Hour), Shards: []meta.ShardInfo{{ID: 2}}},
+			{ID: 3, StartTime: t0.Add(time.Hour), EndTime: t0.Add(2 * time.Hour), Shards: []meta.ShardInfo{{ID: 4}}},
+			{ID: 4, StartTime: t0.Add(2 * time.Hour), EndTime: t0.Add(3 * time.Hour), Shards: []meta.ShardInfo{{ID: 5}}},
+		}, nil
+	}
+
+	var calls []string
+	e.TSDBStore.MergeShardsFn = func(id uint64, ids []uint64, commit func() error) error {
+		if id != 2 || !reflect.DeepEqual(ids, []uint64{4, 5}) {
+			t.Fatalf("unexpected merge: id=%d ids=%v", id, ids)
+		}
+		calls = append(calls, "merge")
+		if err := commit(); err != nil {
+			t.Fatal(err)
+		}
+		calls = append(calls, "replace")
+		return nil
+	}
+	e.MetaClient.MergeShardGroupsFn = func(ids []uint64) error {
+		calls = append(calls, "meta")
+		return nil
+	}
+	e.TSDBStore.DeleteShardFn = func(id uint64) error {
+		calls = append(calls, fmt.Sprintf("delete %d", id))
+		return nil
+	}
+
+	if res := <-e.ExecuteQuery(`MERGE SHARDS 5, 2, 4`, "", 0); res.Err != nil {
+		t.Fatal(res.Err)
+	} else if exp := []string{"merge", "meta", "replace", "delete 4", "delete 5"}; !reflect.DeepEqual(calls, exp) {
+		t.Fatalf("unexpected calls: exp %v, got %v", exp, calls)
+	}
+
+	// A merge that fails after it is committed is undone and no shard is removed.
+	calls = nil
+	e.TSDBStore.MergeShardsFn = func(id uint64, ids []uint64, commit func() error) error {
+		if err := commit(); err != nil {
+			t.Fatal(err)
+		}
+		return errors.New("marker")
+	}
+	var unmerged []meta.ShardGroupInfo
+	e.MetaClient.UnmergeShardGroupsFn = func(groups []meta.ShardGroupInfo) error {
+		unmerged = groups
+		return nil
+	}
+
+	if res := <-e.ExecuteQuery(`MERGE SHARDS 5, 2, 4`, "", 0); res.Err == nil || res.Err.Error() != "marker" {
+		t.Fatalf("unexpected error: %v", res.Err)
+	} else if exp := []string{"meta"}; !reflect.DeepEqual(calls, exp) {
+		t.Fatalf("unexpected calls: exp %v, got %v", exp, calls)
+	} else if len(unmerged) != 3 || unmerged[0].ID != 1 || !unmerged[0].EndTime.Equal(t0.Add(time.Hour)) {
+		t.Fatalf("unexpected unmerged shard groups: %s", spew.Sdump(unmerged))
+	}
+}
+
+// Ensure altering a measurement stores the quota and applies it to the local store.
+func TestQueryExecutor_ExecuteQuery_AlterMeasurement(t *testing.T) {
+	e := NewQueryExecutor()
+
+	e.MetaClient.SetMeasurementQuotaFn = func(database, name, key string, n int) error {
+		if database != "db1" || name != "cpu" || key != "host" || n != 10 {
+			t.Fatalf("unexpected quota: database=%s name=%s key=%s n=%d", database, name, key, n)
+		}
+		return nil
+	}
+	e.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{
+			Name: name,
+			MeasurementQuotas: []meta.MeasurementQuotaInfo{
+				{Name: "cpu", MaxSeries: 100, TagQuotas: []meta.TagQuotaInfo{{Key: "host", MaxValues: 10}}},
+			},
+		}
+	}
+
+	var called bool
+	e.TSDBStore.SetMeasurementQuotasFn = func(database string, quotas map[string]tsdb.MeasurementQuota) {
+		called = true
+		exp := map[string]tsdb.MeasurementQuota{
+			"cpu": {MaxSeries: 100, MaxTagValues: map[string]int{"host": 10}},
+		}
+		if database != "db1" || !reflect.DeepEqual(quotas, exp) {
+			t.Fatalf("unexpected quotas: database=%s %s", database, spew.Sdump(quotas))
+		}
+	}
+
+	if res := <-e.ExecuteQuery(`ALTER MEASUREMENT cpu ON db1 SET MAX VALUES 10 WITH KEY = host`, "db0", 0); res.Err != nil {
+		t.Fatal(res.Err)
+	} else if !called {
+		t.Fatal("expected quotas to be applied")
+	}
+
+	if res := <-e.ExecuteQuery(`ALTER MEASUREMENT cpu SET MAX SERIES 100`, "", 0); res.Err != coordinator.ErrDatabaseNameRequired {
+		t.Fatalf("unexpected error: %v", res.Err)
+	}
+}
+
+// Ensure cardinality statements use the sketches unless the count must be exact.
+func TestQueryExecutor_ExecuteQuery_ShowCardinality(t *testing.T) {
+	e := NewQueryExecutor()
+
+	e.TSDBStore.SeriesCardinalityFn = func(database string) (int64, error) {
+		if database != "db0" {
+			t.Fatalf("unexpected database: %s", database)
+		}
+		return 10, nil
+	}
+	e.TSDBStore.SeriesCardinalityByMeasurementFn = func(database string, cond influxql.Expr) ([]tsdb.MeasurementCount, error) {
+		if database != "db1" {
+			t.Fatalf("unexpected database: %s", database)
+		} else if exp := `(_name = 'cpu') AND (host = 'serverA')`; cond.String() != exp {
+			t.Fatalf("unexpected condition: %s", cond)
+		}
+		return []tsdb.MeasurementCount{{Measurement: "cpu", N: 2}}, nil
+	}
+	e.TSDBStore.MeasurementNamesFn = func(database string, cond influxql.Expr) ([][]byte, error) {
+		return [][]byte{[]byte("cpu"), []byte("mem")}, nil
+	}
+
+	for _, tt := range []struct {
+		q   string
+		exp []*models.Row
+	}{
+		{
+			q:   `SHOW SERIES CARDINALITY`,
+			exp: []*models.Row{{Columns: []string{"cardinality estimation"}, Values: [][]interface{}{{int64(10)}}}},
+		},
+		{
+			q:   `SHOW SERIES CARDINALITY ON db1 FROM cpu WHERE host = 'serverA'`,
+			exp: []*models.Row{{Name: "cpu", Columns: []string{"count"}, Values: [][]interface{}{{2}}}},
+		},
+		{
+			q:   `SHOW MEASUREMENT EXACT CARDINALITY`,
+			exp: []*models.Row{{Columns: []string{"count"}, Values: [][]interface{}{{2}}}},
+		},
+	} {
+		if res := <-e.ExecuteQuery(tt.q, "db0", 0); res.Err != nil {
+			t.Fatalf("%s: %s", tt.q, res.Err)
+		} else if !reflect.DeepEqual(res.Series, models.Rows(tt.exp)) {
+			t.Fatalf("%s: unexpected rows: %s", tt.q, spew.Sdump(res.Series))
+		}
+	}
+}
+
+// Ensure SHOW MEASUREMENTS and SHOW TAG VALUES only read the shards overlapping the time range.
+func TestQueryExecutor_ExecuteQuery_ShowTimeRange(t *testing.T) {
+	e := NewQueryExecutor()
+
+	e.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{
+			Name:                   DefaultDatabase,
+			DefaultRetentionPolicy: DefaultRetentionPolicy,
+			RetentionPolicies:      []meta.RetentionPolicyInfo{{Name: DefaultRetentionPolicy}},
+		}
+	}
+	e.MetaClient.ShardGroupsByTimeRangeFn = func(database, policy string, min, max time.Time) (a []meta.ShardGroupInfo, err error) {
+		if database != "db0" || policy != "rp0" {
+			t.Fatalf("unexpected retention policy: %s.%s", database, policy)
+		} else if exp := time.Unix(0, 10); !min.Equal(exp) {
+			t.Fatalf("unexpected min time: %s", min)
+		} else if exp := time.Unix(0, influxql.MaxTime); !max.Equal(exp) {
+			t.Fatalf("unexpected max time: %s", max)
+		}
+		return []meta.ShardGroupInfo{
+			{ID: 1, Shards: []meta.ShardInfo{{ID: 100}}},
+			{ID: 2, Shards: []meta.ShardInfo{{ID: 200}}},
+		}, nil
+	}
+	e.TSDBStore.MeasurementNamesInShardsFn = func(shardIDs []uint64, cond influxql.Expr) ([][]byte, error) {
+		if !reflect.DeepEqual(shardIDs, []uint64{100, 200}) {
+			t.Fatalf("unexpected shard ids: %v", shardIDs)
+		} else if exp := `time >= 10 AND host = 'serverA'`; cond.String() != exp {
+			t.Fatalf("unexpected condition: %s", cond)
+		}
+		return [][]byte{[]byte("cpu")}, nil
+	}
+	e.TSDBStore.TagValuesInShardsFn = func(shardIDs []uint64, cond influxql.Expr) ([]tsdb.TagValues, error) {
+		if !reflect.DeepEqual(shardIDs, []uint64{100, 200}) {
+			t.Fatalf("unexpected shard ids: %v", shardIDs)
+		}
+		return []tsdb.TagValues{{Measurement: "cpu", Values: []tsdb.KeyValue{{Key: "host", Value: "serverA"}}}}, nil
+	}
+
+	for _, tt := range []struct {
+		q   string
+		exp []*models.Row
+	}{
+		{
+			q:   `SHOW MEASUREMENTS WHERE time >= 10 AND host = 'serverA'`,
+			exp: []*models.Row{{Name: "measurements", Columns: []string{"name"}, Values: [][]interface{}{{"cpu"}}}},
+		},
+		{
+			q:   `SHOW TAG VALUES WITH KEY = host WHERE time >= 10`,
+			exp: []*models.Row{{Name: "cpu", Columns: []string{"key", "value"}, Values: [][]interface{}{{"host", "serverA"}}}},
+		},
+	} {
+		if res := <-e.ExecuteQuery(tt.q, "db0", 0); res.Err != nil {
+			t.Fatalf("%s: %s", tt.q, res.Err)
+		} else if !reflect.DeepEqual(res.Series, models.Rows(tt.exp)) {
+			t.Fatalf("%s: unexpected rows: %s", tt.q, spew.Sdump(res.Series))
+		}
+	}
+}
+
+// Ensure SHOW and DROP INACTIVE SERIES pass the last write range and the remaining
+// condition to the store.
+func TestQueryExecutor_ExecuteQuery_InactiveSeries(t *testing.T) {
+	e := NewQueryExecutor()
+	e.MetaClient.DatabaseFn = DefaultMetaClientDatabaseFn
+
+	check := func(database string, sources []influxql.Source, cond influxql.Expr, min, max int64) {
+		if database != "db0" {
+			t.Fatalf("unexpected database: %s", database)
+		} else if exp := `cpu`; influxql.Sources(sources).String() != exp {
+			t.Fatalf("unexpected sources: %s", influxql.Sources(sources))
+		} else if exp := `host = 'serverA'`; cond.String() != exp {
+			t.Fatalf("unexpected condition: %s", cond)
+		} else if min != influxql.MinTime || max != 9 {
+			t.Fatalf("unexpected range: %d-%d", min, max)
+		}
+	}
+	series := []tsdb.SeriesLastWrite{
+		{Key: "cpu,host=serverA,region=east", LastWrite: 5, HasValues: true},
+		{Key: "cpu,host=serverA,region=west"},
+	}
+	e.TSDBStore.InactiveSeriesFn = func(database string, sources []influxql.Source, cond influxql.Expr, min, max int64) ([]tsdb.SeriesLastWrite, error) {
+		check(database, sources, cond, min, max)
+		return series, nil
+	}
+	var deleted bool
+	e.TSDBStore.DeleteInactiveSeriesFn = func(database string, sources []influxql.Source, cond influxql.Expr, min, max int64) ([]tsdb.SeriesLastWrite, error) {
+		check(database, sources, cond, min, max)
+		deleted = true
+		return series, nil
+	}
+
+	for _, tt := range []struct {
+		q   string
+		exp []*models.Row
+	}{
+		{
+			q: `SHOW INACTIVE SERIES FROM cpu WHERE last_write < 10 AND host = 'serverA'`,
+			exp: []*models.Row{{Columns: []string{"key", "last_write"}, Values: [][]interface{}{
+				{"cpu,host=serverA,region=east", "1970-01-01T00:00:00.000000005Z"},
+				{"cpu,host=serverA,region=west", nil},
+			}}},
+		},
+		{
+			q: `SHOW INACTIVE SERIES FROM cpu WHERE host = 'serverA' AND 10 > last_write LIMIT 1 OFFSET 1`,
+			exp: []*models.Row{{Columns: []string{"key", "last_write"}, Values: [][]interface{}{
+				{"cpu,host=serverA,region=west", nil},
+			}}},
+		},
+	} {
+		if res := <-e.ExecuteQuery(tt.q, "db0", 0); res.Err != nil {
+			t.Fatalf("%s: %s", tt.q, res.Err)
+		} else if !reflect.DeepEqual(res.Series, models.Rows(tt.exp)) {
+			t.Fatalf("%s: unexpected rows: %s", tt.q, spew.Sdump(res.Series))
+		}
+	}
+
+	if res := <-e.ExecuteQuery(`DROP INACTIVE SERIES FROM cpu WHERE last_write < 10 AND host = 'serverA'`, "db0", 0); res.Err != nil {
+		t.Fatal(res.Err)
+	} else if !deleted {
+		t.Fatal("expected inactive series to be deleted")
+	}
+
+	for _, tt := range []struct {
+		q   string
+		err string
+	}{
+		{q: `SHOW INACTIVE SERIES WHERE host = 'serverA'`, err: `last_write condition required in the WHERE clause`},
+		{q: `SHOW INACTIVE SERIES WHERE last_write < 10 OR host = 'serverA'`, err: `last_write is not supported in OR conditions`},
+		{q: `DROP INACTIVE SERIES WHERE time < 10`, err: `time is not supported in the WHERE clause, use last_write`},
+	} {
+		if res := <-e.ExecuteQuery(tt.q, "db0", 0); res.Err == nil || res.Err.Error() != tt.err {
+			t.Fatalf("%s: unexpected error: %v", tt.q, res.Err)
+		}
+	}
+}
+
 // QueryExecutor is a test wrapper for coordinator.QueryExecutor.
 type QueryExecutor struct {
 	*influxql.QueryExecutor
@@ -310,7 +682,21 @@ type TSDBStore struct {
 	DeleteRetentionPolicyFn func(database, name string) error
 	DeleteShardFn           func(id uint64) error
 	DeleteSeriesFn          func(database string, sources []influxql.Source, condition influxql.Expr) error
-	ShardGroupFn            func(ids []uint64) tsdb.ShardGroup
+	SplitShardFn            func(id uint64, start, end time.Time, splits []tsdb.ShardSplit, commit func() ([]uint64, error)) error
+	MergeShardsFn           func(id uint64, ids []uint64, commit func() error) error
+	SetMeasurementQuotasFn  func(database string, quotas map[string]tsdb.MeasurementQuota)
+
+	SeriesCardinalityFn                 func(database string) (int64, error)
+	MeasurementsCardinalityFn           func(database string) (int64, error)
+	MeasurementNamesFn                  func(database string, cond influxql.Expr) ([][]byte, error)
+	MeasurementNamesInShardsFn          func(shardIDs []uint64, cond influxql.Expr) ([][]byte, error)
+	TagValuesInShardsFn                 func(shardIDs []uint64, cond influxql.Expr) ([]tsdb.TagValues, error)
+	InactiveSeriesFn                    func(database string, sources []influxql.Source, cond influxql.Expr, min, max int64) ([]tsdb.SeriesLastWrite, error)
+	DeleteInactiveSeriesFn              func(database string, sources []influxql.Source, cond influxql.Expr, min, max int64) ([]tsdb.SeriesLastWrite, error)
+	SeriesCardinalityByMeasurementFn    func(database string, cond influxql.Expr) ([]tsdb.MeasurementCount, error)
+	TagValuesCardinalityByMeasurementFn func(database string, cond influxql.Expr) ([]tsdb.MeasurementCount, error)
+	FieldKeysCardinalityByMeasurementFn func(database string, cond influxql.Expr) ([]tsdb.MeasurementCount, error)
+	ShardGroupFn                        func(ids []uint64) tsdb.ShardGroup
 }
 
 func (s *TSDBStore) CreateShard(database, policy string, shardID uint64, enabled bool) error {
@@ -352,6 +738,18 @@ func (s *TSDBStore) DeleteSeries(database string, sources []influxql.Source, con
 	return s.DeleteSeriesFn(database, sources, condition)
 }
 
+func (s *TSDBStore) SplitShard(id uint64, start, end time.Time, splits []tsdb.ShardSplit, commit func() ([]uint64, error)) error {
+	return s.SplitShardFn(id, start, end, splits, commit)
+}
+
+func (s *TSDBStore) MergeShards(id uint64, ids []uint64, commit func() error) error {
+	return s.MergeShardsFn(id, ids, commit)
+}
+
+func (s *TSDBStore) SetMeasurementQuotas(database string, quotas map[string]tsdb.MeasurementQuota) {
+	s.SetMeasurementQuotasFn(database, quotas)
+}
+
 func (s *TSDBStore) ShardGroup(ids []uint64) tsdb.ShardGroup {
 	return s.ShardGroupFn(ids)
 }
@@ -361,13 +759,52 @@ func (s *TSDBStore) Measurements(database string, cond influxql.Expr) ([]string,
 }
 
 func (s *TSDBStore) MeasurementNames(database string, cond influxql.Expr) ([][]byte, error) {
-	return nil, nil
+	if s.MeasurementNamesFn == nil {
+		return nil, nil
+	}
+	return s.MeasurementNamesFn(database, cond)
 }
 
 func (s *TSDBStore) TagValues(database string, cond influxql.Expr) ([]tsdb.TagValues, error) {
 	return nil, nil
 }
 
+func (s *TSDBStore) MeasurementNamesInShards(shardIDs []uint64, cond influxql.Expr) ([][]byte, error) {
+	return s.MeasurementNamesInShardsFn(shardIDs, cond)
+}
+
+func (s *TSDBStore) TagValuesInShards(shardIDs []uint64, cond influxql.Expr) ([]tsdb.TagValues, error) {
+	return s.TagValuesInShardsFn(shardIDs, cond)
+}
+
+func (s *TSDBStore) InactiveSeries(database string, sources []influxql.Source, cond influxql.Expr, min, max int64) ([]tsdb.SeriesLastWrite, error) {
+	return s.InactiveSeriesFn(database, sources, cond, min, max)
+}
+
+func (s *TSDBStore) DeleteInactiveSeries(database string, sources []influxql.Source, cond influxql.Expr, min, max int64) ([]tsdb.SeriesLastWrite, error) {
+	return s.DeleteInactiveSeriesFn(database, sources, cond, min, max)
+}
+
+func (s *TSDBStore) SeriesCardinality(database string) (int64, error) {
+	return s.SeriesCardinalityFn(database)
+}
+
+func (s *TSDBStore) MeasurementsCardinality(database string) (int64, error) {
+	return s.MeasurementsCardinalityFn(database)
+}
+
+func (s *TSDBStore) SeriesCardinalityByMeasurement(database string, cond influxql.Expr) ([]tsdb.MeasurementCount, error) {
+	return s.SeriesCardinalityByMeasurementFn(database, cond)
+}
+
+func (s *TSDBStore) TagValuesCardinalityByMeasurement(database string, cond influxql.Expr) ([]tsdb.MeasurementCount, error) {
+	return s.TagValuesCardinalityByMeasurementFn(database, cond)
+}
+
+func (s *TSDBStore) FieldKeysCardinalityByMeasurement(database string, cond influxql.Expr) ([]tsdb.MeasurementCount, error) {
+	return s.FieldKeysCardinalityByMeasurementFn(database, cond)
+}
+
 type MockShard struct {
 	Measurements      []string
 	FieldDimensionsFn func(measurements []string) (fields map[string]influxql.DataType, dimensions map[string]struct{}, err error)
@@ -38,6 +38,8 @@ const (
 	Tag = 7
 	// AnyField means the data type is any field.
 	AnyField = 8
+	// Unsigned means the data type is an unsigned integer.
+	Unsigned = 9
 )
 
 var (
@@ -53,6 +55,8 @@ func InspectDataType(v interface{}) DataType {
 		return Float
 	case int64, int32, int:
 		return Integer
+	case uint64:
+		return Unsigned
 	case string:
 		return String
 	case bool:
@@ -80,9 +84,17 @@ func InspectDataTypes(a []interface{}) []DataType {
 //
 // NOTE: This is not the same as using the `<` or `>` operator because the
 // integers used decrease with higher precedence, but Unknown is the lowest
-// precedence at the zero value.
+// precedence at the zero value. Unsigned was added after the other types so
+// it is ranked explicitly between Integer and String.
 func (d DataType) LessThan(other DataType) bool {
-	return d == Unknown || (other != Unknown && other < d)
+	if d == Unknown {
+		return true
+	} else if d == Unsigned {
+		return other != Unknown && other <= Integer
+	} else if other == Unsigned {
+		return d >= String
+	}
+	return other != Unknown && other < d
 }
 
 // String returns the human-readable string representation of the DataType.
@@ -92,6 +104,8 @@ func (d DataType) String() string {
 		return "float"
 	case Integer:
 		return "integer"
+	case Unsigned:
+		return "unsigned"
 	case String:
 		return "string"
 	case Boolean:
@@ -119,46 +133,55 @@ type Node interface {
 func (*Query) node()     {}
 func (Statements) node() {}
 
-func (*AlterRetentionPolicyStatement) node()  {}
-func (*CreateContinuousQueryStatement) node() {}
-func (*CreateDatabaseStatement) node()        {}
-func (*CreateRetentionPolicyStatement) node() {}
-func (*CreateSubscriptionStatement) node()    {}
-func (*CreateUserStatement) node()            {}
-func (*Distinct) node()                       {}
-func (*DeleteSeriesStatement) node()          {}
-func (*DeleteStatement) node()                {}
-func (*DropContinuousQueryStatement) node()   {}
-func (*DropDatabaseStatement) node()          {}
-func (*DropMeasurementStatement) node()       {}
-func (*DropRetentionPolicyStatement) node()   {}
-func (*DropSeriesStatement) node()            {}
-func (*DropShardStatement) node()             {}
-func (*DropSubscriptionStatement) node()      {}
-func (*DropUserStatement) node()              {}
-func (*GrantStatement) node()                 {}
-func (*GrantAdminStatement) node()            {}
-func (*KillQueryStatement) node()             {}
-func (*RevokeStatement) node()                {}
-func (*RevokeAdminStatement) node()           {}
-func (*SelectStatement) node()                {}
-func (*SetPasswordUserStatement) node()       {}
-func (*ShowContinuousQueriesStatement) node() {}
-func (*ShowGrantsForUserStatement) node()     {}
-func (*ShowDatabasesStatement) node()         {}
-func (*ShowFieldKeysStatement) node()         {}
-func (*ShowRetentionPoliciesStatement) node() {}
-func (*ShowMeasurementsStatement) node()      {}
-func (*ShowQueriesStatement) node()           {}
-func (*ShowSeriesStatement) node()            {}
-func (*ShowShardGroupsStatement) node()       {}
-func (*ShowShardsStatement) node()            {}
-func (*ShowStatsStatement) node()             {}
-func (*ShowSubscriptionsStatement) node()     {}
-func (*ShowDiagnosticsStatement) node()       {}
-func (*ShowTagKeysStatement) node()           {}
-func (*ShowTagValuesStatement) node()         {}
-func (*ShowUsersStatement) node()             {}
+func (*AlterRetentionPolicyStatement) node()       {}
+func (*AlterMeasurementStatement) node()           {}
+func (*CreateContinuousQueryStatement) node()      {}
+func (*CreateDatabaseStatement) node()             {}
+func (*CreateRetentionPolicyStatement) node()      {}
+func (*CreateSubscriptionStatement) node()         {}
+func (*CreateUserStatement) node()                 {}
+func (*Distinct) node()                            {}
+func (*DeleteSeriesStatement) node()               {}
+func (*DeleteStatement) node()                     {}
+func (*DropContinuousQueryStatement) node()        {}
+func (*DropDatabaseStatement) node()               {}
+func (*DropMeasurementStatement) node()            {}
+func (*DropRetentionPolicyStatement) node()        {}
+func (*DropSeriesStatement) node()                 {}
+func (*DropInactiveSeriesStatement) node()         {}
+func (*DropShardStatement) node()                  {}
+func (*SplitShardStatement) node()                 {}
+func (*MergeShardsStatement) node()                {}
+func (*DropSubscriptionStatement) node()           {}
+func (*DropUserStatement) node()                   {}
+func (*GrantStatement) node()                      {}
+func (*GrantAdminStatement) node()                 {}
+func (*KillQueryStatement) node()                  {}
+func (*RevokeStatement) node()                     {}
+func (*RevokeAdminStatement) node()                {}
+func (*SelectStatement) node()                     {}
+func (*SetPasswordUserStatement) node()            {}
+func (*ShowContinuousQueriesStatement) node()      {}
+func (*ShowGrantsForUserStatement) node()          {}
+func (*ShowDatabasesStatement) node()              {}
+func (*ShowFieldKeysStatement) node()              {}
+func (*ShowFieldKeyCardinalityStatement) node()    {}
+func (*ShowRetentionPoliciesStatement) node()      {}
+func (*ShowMeasurementsStatement) node()           {}
+func (*ShowMeasurementCardinalityStatement) node() {}
+func (*ShowQueriesStatement) node()                {}
+func (*ShowSeriesStatement) node()                 {}
+func (*ShowSeriesCardinalityStatement) node()      {}
+func (*ShowInactiveSeriesStatement) node()         {}
+func (*ShowShardGroupsStatement) node()            {}
+func (*ShowShardsStatement) node()                 {}
+func (*ShowStatsStatement) node()                  {}
+func (*ShowSubscriptionsStatement) node()          {}
+func (*ShowDiagnosticsStatement) node()            {}
+func (*ShowTagKeysStatement) node()                {}
+func (*ShowTagValuesStatement) node()              {}
+func (*ShowTagValuesCardinalityStatement) node()   {}
+func (*ShowUsersStatement) node()                  {}
 
 func (*BinaryExpr) node()      {}
 func (*BooleanLiteral) node()  {}
@@ -240,45 +263,54 @@ type ExecutionPrivilege struct {
 // ExecutionPrivileges is a list of privileges required to execute a statement.
 type ExecutionPrivileges []ExecutionPrivilege
 
-func (*AlterRetentionPolicyStatement) stmt()  {}
-func (*CreateContinuousQueryStatement) stmt() {}
-func (*CreateDatabaseStatement) stmt()        {}
-func (*CreateRetentionPolicyStatement) stmt() {}
-func (*CreateSubscriptionStatement) stmt()    {}
-func (*CreateUserStatement) stmt()            {}
-func (*DeleteSeriesStatement) stmt()          {}
-func (*DeleteStatement) stmt()                {}
-func (*DropContinuousQueryStatement) stmt()   {}
-func (*DropDatabaseStatement) stmt()          {}
-func (*DropMeasurementStatement) stmt()       {}
-func (*DropRetentionPolicyStatement) stmt()   {}
-func (*DropSeriesStatement) stmt()            {}
-func (*DropSubscriptionStatement) stmt()      {}
-func (*DropUserStatement) stmt()              {}
-func (*GrantStatement) stmt()                 {}
-func (*GrantAdminStatement) stmt()            {}
-func (*KillQueryStatement) stmt()             {}
-func (*ShowContinuousQueriesStatement) stmt() {}
-func (*ShowGrantsForUserStatement) stmt()     {}
-func (*ShowDatabasesStatement) stmt()         {}
-func (*ShowFieldKeysStatement) stmt()         {}
-func (*ShowMeasurementsStatement) stmt()      {}
-func (*ShowQueriesStatement) stmt()           {}
-func (*ShowRetentionPoliciesStatement) stmt() {}
-func (*ShowSeriesStatement) stmt()            {}
-func (*ShowShardGroupsStatement) stmt()       {}
-func (*ShowShardsStatement) stmt()            {}
-func (*ShowStatsStatement) stmt()             {}
-func (*DropShardStatement) stmt()             {}
-func (*ShowSubscriptionsStatement) stmt()     {}
-func (*ShowDiagnosticsStatement) stmt()       {}
-func (*ShowTagKeysStatement) stmt()           {}
-func (*ShowTagValuesStatement) stmt()         {}
-func (*ShowUsersStatement) stmt()             {}
-func (*RevokeStatement) stmt()                {}
-func (*RevokeAdminStatement) stmt()           {}
-func (*SelectStatement) stmt()                {}
-func (*SetPasswordUserStatement) stmt()       {}
+func (*AlterRetentionPolicyStatement) stmt()       {}
+func (*AlterMeasurementStatement) stmt()           {}
+func (*CreateContinuousQueryStatement) stmt()      {}
+func (*CreateDatabaseStatement) stmt()             {}
+func (*CreateRetentionPolicyStatement) stmt()      {}
+func (*CreateSubscriptionStatement) stmt()         {}
+func (*CreateUserStatement) stmt()                 {}
+func (*DeleteSeriesStatement) stmt()               {}
+func (*DeleteStatement) stmt()                     {}
+func (*DropContinuousQueryStatement) stmt()        {}
+func (*DropDatabaseStatement) stmt()               {}
+func (*DropMeasurementStatement) stmt()            {}
+func (*DropRetentionPolicyStatement) stmt()        {}
+func (*DropSeriesStatement) stmt()                 {}
+func (*DropInactiveSeriesStatement) stmt()         {}
+func (*DropSubscriptionStatement) stmt()           {}
+func (*DropUserStatement) stmt()                   {}
+func (*GrantStatement) stmt()                      {}
+func (*GrantAdminStatement) stmt()                 {}
+func (*KillQueryStatement) stmt()                  {}
+func (*ShowContinuousQueriesStatement) stmt()      {}
+func (*ShowGrantsForUserStatement) stmt()          {}
+func (*ShowDatabasesStatement) stmt()              {}
+func (*ShowFieldKeysStatement) stmt()              {}
+func (*ShowFieldKeyCardinalityStatement) stmt()    {}
+func (*ShowMeasurementsStatement) stmt()           {}
+func (*ShowMeasurementCardinalityStatement) stmt() {}
+func (*ShowQueriesStatement) stmt()                {}
+func (*ShowRetentionPoliciesStatement) stmt()      {}
+func (*ShowSeriesStatement) stmt()                 {}
+func (*ShowSeriesCardinalityStatement) stmt()      {}
+func (*ShowInactiveSeriesStatement) stmt()         {}
+func (*ShowShardGroupsStatement) stmt()            {}
+func (*ShowShardsStatement) stmt()                 {}
+func (*ShowStatsStatement) stmt()                  {}
+func (*DropShardStatement) stmt()                  {}
+func (*SplitShardStatement) stmt()                 {}
+func (*MergeShardsStatement) stmt()                {}
+func (*ShowSubscriptionsStatement) stmt()          {}
+func (*ShowDiagnosticsStatement) stmt()            {}
+func (*ShowTagKeysStatement) stmt()                {}
+func (*ShowTagValuesStatement) stmt()              {}
+func (*ShowTagValuesCardinalityStatement) stmt()   {}
+func (*ShowUsersStatement) stmt()                  {}
+func (*RevokeStatement) stmt()                     {}
+func (*RevokeAdminStatement) stmt()                {}
+func (*SelectStatement) stmt()                     {}
+func (*SetPasswordUserStatement) stmt()            {}
 
 // Expr represents an expression that can be evaluated to a value.
 type Expr interface {
@@ -855,6 +887,9 @@ type CreateRetentionPolicyStatement struct {
 
 	// Shard Duration.
 	ShardGroupDuration time.Duration
+
+	// How the shards of this policy are downsampled, if at all.
+	Downsample *DownsamplePolicy
 }
 
 // String returns a string representation of the create retention policy.
@@ -872,6 +907,10 @@ func (s *CreateRetentionPolicyStatement) String() string {
 		_, _ = buf.WriteString(" SHARD DURATION ")
 		_, _ = buf.WriteString(FormatDuration(s.ShardGroupDuration))
 	}
+	if s.Downsample != nil {
+		_, _ = buf.WriteString(" ")
+		_, _ = buf.WriteString(s.Downsample.String())
+	}
 	if s.Default {
 		_, _ = buf.WriteString(" DEFAULT")
 	}
@@ -907,6 +946,10 @@ type AlterRetentionPolicyStatement struct {
 
 	// Duration of the Shard.
 	ShardGroupDuration *time.Duration
+
+	// How the shards of this policy are downsampled.  A policy with an After
+	// of zero disables downsampling.
+	Downsample *DownsamplePolicy
 }
 
 // String returns a string representation of the alter retention policy statement.
@@ -932,6 +975,11 @@ func (s *AlterRetentionPolicyStatement) String() string {
 		_, _ = buf.WriteString(FormatDuration(*s.ShardGroupDuration))
 	}
 
+	if s.Downsample != nil {
+		_, _ = buf.WriteString(" ")
+		_, _ = buf.WriteString(s.Downsample.String())
+	}
+
 	if s.Default {
 		_, _ = buf.WriteString(" DEFAULT")
 	}
@@ -949,6 +997,90 @@ func (s *AlterRetentionPolicyStatement) DefaultDatabase() string {
 	return s.Database
 }
 
+// AlterMeasurementStatement represents a command to set a cardinality quota of a
+// measurement.
+type AlterMeasurementStatement struct {
+	// Name of the measurement.
+	Name string
+
+	// Name of the database the measurement belongs to.
+	Database string
+
+	// Tag key whose number of values is limited.  If empty, the number of series
+	// of the measurement is limited.
+	Key string
+
+	// Maximum number of series or tag values.  Zero removes the quota.
+	Max int
+}
+
+// String returns a string representation of the alter measurement statement.
+func (s *AlterMeasurementStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("ALTER MEASUREMENT ")
+	_, _ = buf.WriteString(QuoteIdent(s.Name))
+	if s.Database != "" {
+		_, _ = buf.WriteString(" ON ")
+		_, _ = buf.WriteString(QuoteIdent(s.Database))
+	}
+
+	if s.Key == "" {
+		_, _ = buf.WriteString(" SET MAX SERIES ")
+		_, _ = buf.WriteString(strconv.Itoa(s.Max))
+	} else {
+		_, _ = buf.WriteString(" SET MAX VALUES ")
+		_, _ = buf.WriteString(strconv.Itoa(s.Max))
+		_, _ = buf.WriteString(" WITH KEY = ")
+		_, _ = buf.WriteString(QuoteIdent(s.Key))
+	}
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege required to execute an AlterMeasurementStatement.
+func (s *AlterMeasurementStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
+}
+
+// DefaultDatabase returns the default database from the statement.
+func (s *AlterMeasurementStatement) DefaultDatabase() string {
+	return s.Database
+}
+
+// DownsamplePolicy represents the DOWNSAMPLE clause of a retention policy statement.
+// Once all of the data in a shard is older than After, the shard is rewritten at a
+// resolution of Interval using the aggregate Functions.
+type DownsamplePolicy struct {
+	// Duration after which data is downsampled.  Zero disables downsampling.
+	After time.Duration
+
+	// Resolution the data is downsampled to.
+	Interval time.Duration
+
+	// Aggregate functions applied to each field.
+	Functions []string
+}
+
+// String returns a string representation of the downsample clause.
+func (d *DownsamplePolicy) String() string {
+	if d.After == 0 {
+		return "DOWNSAMPLE INF"
+	}
+
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("DOWNSAMPLE ")
+	_, _ = buf.WriteString(FormatDuration(d.After))
+	_, _ = buf.WriteString(" EVERY ")
+	_, _ = buf.WriteString(FormatDuration(d.Interval))
+	_, _ = buf.WriteString(" WITH ")
+	for i, fn := range d.Functions {
+		if i > 0 {
+			_, _ = buf.WriteString(", ")
+		}
+		_, _ = buf.WriteString(QuoteIdent(fn))
+	}
+	return buf.String()
+}
+
 // FillOption represents different options for filling aggregate windows.
 type FillOption int
 
@@ -1268,10 +1400,11 @@ func (s *SelectStatement) RewriteFields(m FieldMapper) (*SelectStatement, error)
 					continue
 				}
 
-				// All types that can expand wildcards support float and integer.
+				// All types that can expand wildcards support float, integer, and unsigned.
 				supportedTypes := map[DataType]struct{}{
-					Float:   struct{}{},
-					Integer: struct{}{},
+					Float:    struct{}{},
+					Integer:  struct{}{},
+					Unsigned: struct{}{},
 				}
 
 				// Add additional types for certain functions.
@@ -2674,6 +2807,106 @@ func (s *ShowSeriesStatement) DefaultDatabase() string {
 	return s.Database
 }
 
+// ShowSeriesCardinalityStatement represents a command for counting the series in
+// the database.
+type ShowSeriesCardinalityStatement struct {
+	// Database to query. If blank, use the default database.
+	Database string
+
+	// Count the series exactly instead of estimating the count.
+	Exact bool
+
+	// Measurement(s) the series are counted for.
+	Sources Sources
+
+	// An expression evaluated on a series name or tag.
+	Condition Expr
+}
+
+// String returns a string representation of the statement.
+func (s *ShowSeriesCardinalityStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("SHOW SERIES")
+	if s.Exact {
+		_, _ = buf.WriteString(" EXACT")
+	}
+	_, _ = buf.WriteString(" CARDINALITY")
+	writeOnFromWhereClauses(&buf, s.Database, s.Sources, s.Condition)
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege required to execute a ShowSeriesCardinalityStatement.
+func (s *ShowSeriesCardinalityStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: false, Name: "", Privilege: ReadPrivilege}}, nil
+}
+
+// DefaultDatabase returns the default database from the statement.
+func (s *ShowSeriesCardinalityStatement) DefaultDatabase() string {
+	return s.Database
+}
+
+// writeOnFromWhereClauses writes the ON, FROM and WHERE clauses of a statement.
+func writeOnFromWhereClauses(buf *bytes.Buffer, database string, sources Sources, condition Expr) {
+	if database != "" {
+		_, _ = buf.WriteString(" ON ")
+		_, _ = buf.WriteString(QuoteIdent(database))
+	}
+	if sources != nil {
+		_, _ = buf.WriteString(" FROM ")
+		_, _ = buf.WriteString(sources.String())
+	}
+	if condition != nil {
+		_, _ = buf.WriteString(" WHERE ")
+		_, _ = buf.WriteString(condition.String())
+	}
+}
+
+// ShowInactiveSeriesStatement represents a command for listing the series whose last
+// write matches the last_write expressions of the condition.
+type ShowInactiveSeriesStatement struct {
+	// Database to query. If blank, use the default database.
+	Database string
+
+	// Measurement(s) the series are listed for.
+	Sources Sources
+
+	// An expression evaluated on a series name, tags or last_write.
+	Condition Expr
+
+	// Maximum number of rows to be returned.
+	// Unlimited if zero.
+	Limit int
+
+	// Returns rows starting at an offset from the first row.
+	Offset int
+}
+
+// String returns a string representation of the show inactive series statement.
+func (s *ShowInactiveSeriesStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("SHOW INACTIVE SERIES")
+	writeOnFromWhereClauses(&buf, s.Database, s.Sources, s.Condition)
+	if s.Limit > 0 {
+		_, _ = buf.WriteString(" LIMIT ")
+		_, _ = buf.WriteString(strconv.Itoa(s.Limit))
+	}
+	if s.Offset > 0 {
+		_, _ = buf.WriteString(" OFFSET ")
+		_, _ = buf.WriteString(strconv.Itoa(s.Offset))
+	}
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege required to execute a ShowInactiveSeriesStatement.
+func (s *ShowInactiveSeriesStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: false, Name: "", Privilege: ReadPrivilege}}, nil
+}
+
+// DefaultDatabase returns the default database from the statement.
+func (s *ShowInactiveSeriesStatement) DefaultDatabase() string {
+	return s.Database
+}
+
 // DropSeriesStatement represents a command for removing a series from the database.
 type DropSeriesStatement struct {
 	// Data source that fields are extracted from (optional)
@@ -2705,6 +2938,38 @@ func (s DropSeriesStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
 	return ExecutionPrivileges{{Admin: false, Name: "", Privilege: WritePrivilege}}, nil
 }
 
+// DropInactiveSeriesStatement represents a command for removing the series whose last
+// write matches the last_write expressions of the condition.
+type DropInactiveSeriesStatement struct {
+	// Data source that fields are extracted from (optional)
+	Sources Sources
+
+	// An expression evaluated on a series name, tags or last_write.
+	Condition Expr
+}
+
+// String returns a string representation of the drop inactive series statement.
+func (s *DropInactiveSeriesStatement) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("DROP INACTIVE SERIES")
+
+	if s.Sources != nil {
+		buf.WriteString(" FROM ")
+		buf.WriteString(s.Sources.String())
+	}
+	if s.Condition != nil {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(s.Condition.String())
+	}
+
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege required to execute a DropInactiveSeriesStatement.
+func (s DropInactiveSeriesStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: false, Name: "", Privilege: WritePrivilege}}, nil
+}
+
 // DeleteSeriesStatement represents a command for deleting all or part of a series from a database.
 type DeleteSeriesStatement struct {
 	// Data source that fields are extracted from (optional)
@@ -2757,6 +3022,58 @@ func (s *DropShardStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
 	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
 }
 
+// SplitShardStatement represents a command for splitting the shard group of a
+// shard into shard groups with shorter time ranges.
+type SplitShardStatement struct {
+	// ID of the shard to be split.
+	ID uint64
+
+	// Number of shard groups to split the shard group into.
+	N int
+}
+
+// String returns a string representation of the split shard statement.
+func (s *SplitShardStatement) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("SPLIT SHARD ")
+	buf.WriteString(strconv.FormatUint(s.ID, 10))
+	buf.WriteString(" INTO ")
+	buf.WriteString(strconv.Itoa(s.N))
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege required to execute a
+// SplitShardStatement.
+func (s *SplitShardStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
+}
+
+// MergeShardsStatement represents a command for merging the shard groups of shards
+// into a single shard group.
+type MergeShardsStatement struct {
+	// IDs of the shards to be merged.
+	IDs []uint64
+}
+
+// String returns a string representation of the merge shards statement.
+func (s *MergeShardsStatement) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("MERGE SHARDS ")
+	for i, id := range s.IDs {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(strconv.FormatUint(id, 10))
+	}
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege required to execute a
+// MergeShardsStatement.
+func (s *MergeShardsStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
+}
+
 // ShowContinuousQueriesStatement represents a command for listing continuous queries.
 type ShowContinuousQueriesStatement struct{}
 
@@ -2971,6 +3288,44 @@ func (s *ShowMeasurementsStatement) DefaultDatabase() string {
 	return s.Database
 }
 
+// ShowMeasurementCardinalityStatement represents a command for counting the
+// measurements in the database.
+type ShowMeasurementCardinalityStatement struct {
+	// Database to query. If blank, use the default database.
+	Database string
+
+	// Count the measurements exactly instead of estimating the count.
+	Exact bool
+
+	// Measurement(s) to count.
+	Sources Sources
+
+	// An expression evaluated on a series name or tag.
+	Condition Expr
+}
+
+// String returns a string representation of the statement.
+func (s *ShowMeasurementCardinalityStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("SHOW MEASUREMENT")
+	if s.Exact {
+		_, _ = buf.WriteString(" EXACT")
+	}
+	_, _ = buf.WriteString(" CARDINALITY")
+	writeOnFromWhereClauses(&buf, s.Database, s.Sources, s.Condition)
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege required to execute a ShowMeasurementCardinalityStatement.
+func (s *ShowMeasurementCardinalityStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: false, Name: "", Privilege: ReadPrivilege}}, nil
+}
+
+// DefaultDatabase returns the default database from the statement.
+func (s *ShowMeasurementCardinalityStatement) DefaultDatabase() string {
+	return s.Database
+}
+
 // DropMeasurementStatement represents a command to drop a measurement.
 type DropMeasurementStatement struct {
 	// Name of the measurement to be dropped.
@@ -3329,6 +3684,61 @@ func (s *ShowTagValuesStatement) DefaultDatabase() string {
 	return s.Database
 }
 
+// ShowTagValuesCardinalityStatement represents a command for counting tag values.
+type ShowTagValuesCardinalityStatement struct {
+	// Database to query. If blank, use the default database.
+	Database string
+
+	// Count the tag values exactly instead of estimating the count.
+	Exact bool
+
+	// Measurement(s) the tag values are counted for.
+	Sources Sources
+
+	// Operation to use when selecting tag key(s).
+	Op Token
+
+	// Literal to compare the tag key(s) with.
+	TagKeyExpr Literal
+
+	// An expression evaluated on a series name or tag.
+	Condition Expr
+}
+
+// String returns a string representation of the statement.
+func (s *ShowTagValuesCardinalityStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("SHOW TAG VALUES")
+	if s.Exact {
+		_, _ = buf.WriteString(" EXACT")
+	}
+	_, _ = buf.WriteString(" CARDINALITY")
+	writeOnFromWhereClauses(&buf, s.Database, s.Sources, nil)
+	_, _ = buf.WriteString(" WITH KEY ")
+	_, _ = buf.WriteString(s.Op.String())
+	_, _ = buf.WriteString(" ")
+	if lit, ok := s.TagKeyExpr.(*StringLiteral); ok {
+		_, _ = buf.WriteString(QuoteIdent(lit.Val))
+	} else {
+		_, _ = buf.WriteString(s.TagKeyExpr.String())
+	}
+	if s.Condition != nil {
+		_, _ = buf.WriteString(" WHERE ")
+		_, _ = buf.WriteString(s.Condition.String())
+	}
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege required to execute a ShowTagValuesCardinalityStatement.
+func (s *ShowTagValuesCardinalityStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: false, Name: "", Privilege: ReadPrivilege}}, nil
+}
+
+// DefaultDatabase returns the default database from the statement.
+func (s *ShowTagValuesCardinalityStatement) DefaultDatabase() string {
+	return s.Database
+}
+
 // ShowUsersStatement represents a command for listing users.
 type ShowUsersStatement struct{}
 
@@ -3400,6 +3810,43 @@ func (s *ShowFieldKeysStatement) DefaultDatabase() string {
 	return s.Database
 }
 
+// ShowFieldKeyCardinalityStatement represents a command for counting field keys.
+type ShowFieldKeyCardinalityStatement struct {
+	// Database to query. If blank, use the default database.
+	Database string
+
+	// Count the field keys exactly instead of estimating the count.
+	Exact bool
+
+	// Measurement(s) the field keys are counted for.
+	Sources Sources
+
+	// An expression evaluated on a series name or tag.
+	Condition Expr
+}
+
+// String returns a string representation of the statement.
+func (s *ShowFieldKeyCardinalityStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("SHOW FIELD KEY")
+	if s.Exact {
+		_, _ = buf.WriteString(" EXACT")
+	}
+	_, _ = buf.WriteString(" CARDINALITY")
+	writeOnFromWhereClauses(&buf, s.Database, s.Sources, s.Condition)
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege required to execute a ShowFieldKeyCardinalityStatement.
+func (s *ShowFieldKeyCardinalityStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: false, Name: "", Privilege: ReadPrivilege}}, nil
+}
+
+// DefaultDatabase returns the default database from the statement.
+func (s *ShowFieldKeyCardinalityStatement) DefaultDatabase() string {
+	return s.Database
+}
+
 // Fields represents a list of fields.
 type Fields []*Field
 
@@ -4204,6 +4651,10 @@ func Walk(v Visitor, node Node) {
 		Walk(v, n.Sources)
 		Walk(v, n.Condition)
 
+	case *DropInactiveSeriesStatement:
+		Walk(v, n.Sources)
+		Walk(v, n.Condition)
+
 	case *Field:
 		Walk(v, n.Expr)
 
@@ -4230,6 +4681,26 @@ func Walk(v Visitor, node Node) {
 		Walk(v, n.Sources)
 		Walk(v, n.Condition)
 
+	case *ShowSeriesCardinalityStatement:
+		Walk(v, n.Sources)
+		Walk(v, n.Condition)
+
+	case *ShowInactiveSeriesStatement:
+		Walk(v, n.Sources)
+		Walk(v, n.Condition)
+
+	case *ShowMeasurementCardinalityStatement:
+		Walk(v, n.Sources)
+		Walk(v, n.Condition)
+
+	case *ShowTagValuesCardinalityStatement:
+		Walk(v, n.Sources)
+		Walk(v, n.Condition)
+
+	case *ShowFieldKeyCardinalityStatement:
+		Walk(v, n.Sources)
+		Walk(v, n.Condition)
+
 	case *ShowTagKeysStatement:
 		Walk(v, n.Sources)
 		Walk(v, n.Condition)
@@ -4412,6 +4883,88 @@ func Eval(expr Expr, m map[string]interface{}) interface{} {
 	}
 }
 
+// evalUnsignedBinaryExpr evaluates op against two unsigned values. If rhs
+// is not a uint64, comparisons are false and arithmetic returns nil.
+func evalUnsignedBinaryExpr(op Token, lhs uint64, v interface{}) interface{} {
+	rhs, ok := v.(uint64)
+	switch op {
+	case EQ:
+		return ok && (lhs == rhs)
+	case NEQ:
+		return ok && (lhs != rhs)
+	case LT:
+		return ok && (lhs < rhs)
+	case LTE:
+		return ok && (lhs <= rhs)
+	case GT:
+		return ok && (lhs > rhs)
+	case GTE:
+		return ok && (lhs >= rhs)
+	case ADD:
+		if !ok {
+			return nil
+		}
+		return lhs + rhs
+	case SUB:
+		if !ok {
+			return nil
+		}
+		return lhs - rhs
+	case MUL:
+		if !ok {
+			return nil
+		}
+		return lhs * rhs
+	case DIV:
+		if !ok {
+			return nil
+		} else if rhs == 0 {
+			return uint64(0)
+		}
+		return lhs / rhs
+	case MOD:
+		if !ok {
+			return nil
+		} else if rhs == 0 {
+			return uint64(0)
+		}
+		return lhs % rhs
+	case BITWISE_AND:
+		if !ok {
+			return nil
+		}
+		return lhs & rhs
+	case BITWISE_OR:
+		if !ok {
+			return nil
+		}
+		return lhs | rhs
+	case BITWISE_XOR:
+		if !ok {
+			return nil
+		}
+		return lhs ^ rhs
+	}
+	return nil
+}
+
+// evalNegativeUnsignedBinaryExpr evaluates op between an unsigned value and a
+// negative integer. The unsigned value is always the greater of the two so
+// comparisons can be answered without a cast. Arithmetic returns nil.
+func evalNegativeUnsignedBinaryExpr(op Token, unsignedLHS bool) interface{} {
+	switch op {
+	case EQ:
+		return false
+	case NEQ:
+		return true
+	case LT, LTE:
+		return !unsignedLHS
+	case GT, GTE:
+		return unsignedLHS
+	}
+	return nil
+}
+
 func evalBinaryExpr(expr *BinaryExpr, m map[string]interface{}) interface{} {
 	lhs := Eval(expr.LHS, m)
 	rhs := Eval(expr.RHS, m)
@@ -4449,12 +5002,14 @@ func evalBinaryExpr(expr *BinaryExpr, m map[string]interface{}) interface{} {
 			return ok && (lhs != rhs)
 		}
 	case float64:
-		// Try the rhs as a float64 or int64
+		// Try the rhs as a float64, int64 or uint64
 		rhsf, ok := rhs.(float64)
 		if !ok {
-			var rhsi int64
-			if rhsi, ok = rhs.(int64); ok {
-				rhsf = float64(rhsi)
+			switch val := rhs.(type) {
+			case int64:
+				rhsf, ok = float64(val), true
+			case uint64:
+				rhsf, ok = float64(val), true
 			}
 		}
 
@@ -4533,6 +5088,13 @@ func evalBinaryExpr(expr *BinaryExpr, m map[string]interface{}) interface{} {
 			case MOD:
 				return math.Mod(lhs, rhs)
 			}
+		} else if rhsu, ok := rhs.(uint64); ok {
+			// A non-negative integer can be compared against an unsigned
+			// value without losing precision.
+			if lhs >= 0 {
+				return evalUnsignedBinaryExpr(expr.Op, uint64(lhs), rhsu)
+			}
+			return evalNegativeUnsignedBinaryExpr(expr.Op, false)
 		} else {
 			rhs, ok := rhs.(int64)
 			switch expr.Op {
@@ -4594,6 +5156,26 @@ func evalBinaryExpr(expr *BinaryExpr, m map[string]interface{}) interface{} {
 				return lhs ^ rhs
 			}
 		}
+	case uint64:
+		switch rhs := rhs.(type) {
+		case float64:
+			return evalBinaryExpr(&BinaryExpr{
+				Op:  expr.Op,
+				LHS: &NumberLiteral{Val: float64(lhs)},
+				RHS: &NumberLiteral{Val: rhs},
+			}, nil)
+		case int64:
+			// Integer literals are parsed as int64 so a non-negative
+			// value is treated as an unsigned value.
+			if rhs >= 0 {
+				return evalUnsignedBinaryExpr(expr.Op, lhs, uint64(rhs))
+			}
+			return evalNegativeUnsignedBinaryExpr(expr.Op, true)
+		case uint64:
+			return evalUnsignedBinaryExpr(expr.Op, lhs, rhs)
+		default:
+			return evalUnsignedBinaryExpr(expr.Op, lhs, nil)
+		}
 	case string:
 		switch expr.Op {
 		case EQ:
@@ -4702,10 +5284,10 @@ func EvalType(expr Expr, sources Sources, typmap TypeMapper) DataType {
 		lhs := EvalType(expr.LHS, sources, typmap)
 		rhs := EvalType(expr.RHS, sources, typmap)
 		if lhs != Unknown && rhs != Unknown {
-			if lhs < rhs {
-				return lhs
-			} else {
+			if lhs.LessThan(rhs) {
 				return rhs
+			} else {
+				return lhs
 			}
 		} else if lhs != Unknown {
 			return lhs
@@ -4729,7 +5311,7 @@ func FieldDimensions(sources Sources, m FieldMapper) (fields map[string]DataType
 			}
 
 			for k, typ := range f {
-				if _, ok := fields[k]; typ != Unknown && (!ok || typ < fields[k]) {
+				if _, ok := fields[k]; typ != Unknown && (!ok || fields[k].LessThan(typ)) {
 					fields[k] = typ
 				}
 			}
@@ -4741,7 +5323,7 @@ func FieldDimensions(sources Sources, m FieldMapper) (fields map[string]DataType
 				k := f.Name()
 				typ := EvalType(f.Expr, src.Statement.Sources, m)
 
-				if _, ok := fields[k]; typ != Unknown && (!ok || typ < fields[k]) {
+				if _, ok := fields[k]; typ != Unknown && (!ok || fields[k].LessThan(typ)) {
 					fields[k] = typ
 				}
 			}
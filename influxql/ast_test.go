@@ -1153,6 +1153,11 @@ func TestEval(t *testing.T) {
 		{in: `foo <> 'bar'`, out: true, data: map[string]interface{}{"foo": "xxx"}},
 		{in: `foo =~ /b.*/`, out: true, data: map[string]interface{}{"foo": "bar"}},
 		{in: `foo !~ /b.*/`, out: false, data: map[string]interface{}{"foo": "bar"}},
+		{in: `foo + 2`, out: uint64(6), data: map[string]interface{}{"foo": uint64(4)}},
+		{in: `foo > 2`, out: true, data: map[string]interface{}{"foo": uint64(4)}},
+		{in: `foo > -1`, out: true, data: map[string]interface{}{"foo": uint64(0)}},
+		{in: `foo / 2`, out: uint64(2), data: map[string]interface{}{"foo": uint64(4)}},
+		{in: `foo * 1.5`, out: float64(6), data: map[string]interface{}{"foo": uint64(4)}},
 		{in: `foo > 2 OR bar > 3`, out: true, data: map[string]interface{}{"foo": float64(4)}},
 		{in: `foo > 2 OR bar > 3`, out: true, data: map[string]interface{}{"bar": float64(4)}},
 	} {
@@ -79,6 +79,41 @@ func TestCallIterator_Count_Integer(t *testing.T) {
 	}
 }
 
+// Ensure that an unsigned iterator can be created for a count() call.
+func TestCallIterator_Count_Unsigned(t *testing.T) {
+	itr, _ := influxql.NewCallIterator(
+		&UnsignedIterator{Points: []influxql.UnsignedPoint{
+			{Name: "cpu", Time: 0, Value: 15, Tags: ParseTags("region=us-east,host=hostA")},
+			{Name: "cpu", Time: 2, Value: 10, Tags: ParseTags("region=us-east,host=hostA")},
+			{Name: "cpu", Time: 1, Value: 10, Tags: ParseTags("region=us-west,host=hostA")},
+			{Name: "cpu", Time: 5, Value: 20, Tags: ParseTags("region=us-east,host=hostA")},
+
+			{Name: "cpu", Time: 1, Value: 11, Tags: ParseTags("region=us-west,host=hostB")},
+			{Name: "cpu", Time: 23, Value: 8, Tags: ParseTags("region=us-west,host=hostB")},
+			{Name: "mem", Time: 23, Value: 10, Tags: ParseTags("region=us-west,host=hostB")},
+		}},
+		influxql.IteratorOptions{
+			Expr:       MustParseExpr(`count("value")`),
+			Dimensions: []string{"host"},
+			Interval:   influxql.Interval{Duration: 5 * time.Nanosecond},
+			Ordered:    true,
+			Ascending:  true,
+		},
+	)
+
+	if a, err := Iterators([]influxql.Iterator{itr}).ReadAll(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if !deep.Equal(a, [][]influxql.Point{
+		{&influxql.IntegerPoint{Name: "cpu", Time: 0, Value: 3, Tags: ParseTags("host=hostA"), Aggregated: 3}},
+		{&influxql.IntegerPoint{Name: "cpu", Time: 5, Value: 1, Tags: ParseTags("host=hostA"), Aggregated: 1}},
+		{&influxql.IntegerPoint{Name: "cpu", Time: 0, Value: 1, Tags: ParseTags("host=hostB"), Aggregated: 1}},
+		{&influxql.IntegerPoint{Name: "cpu", Time: 20, Value: 1, Tags: ParseTags("host=hostB"), Aggregated: 1}},
+		{&influxql.IntegerPoint{Name: "mem", Time: 20, Value: 1, Tags: ParseTags("host=hostB"), Aggregated: 1}},
+	}) {
+		t.Fatalf("unexpected points: %s", spew.Sdump(a))
+	}
+}
+
 // Ensure that a string iterator can be created for a count() call.
 func TestCallIterator_Count_String(t *testing.T) {
 	itr, _ := influxql.NewCallIterator(
@@ -217,6 +252,40 @@ func TestCallIterator_Min_Integer(t *testing.T) {
 	}
 }
 
+// Ensure that an unsigned iterator can be created for a min() call.
+func TestCallIterator_Min_Unsigned(t *testing.T) {
+	itr, _ := influxql.NewCallIterator(
+		&UnsignedIterator{Points: []influxql.UnsignedPoint{
+			{Time: 0, Value: 15, Tags: ParseTags("region=us-east,host=hostA")},
+			{Time: 2, Value: 10, Tags: ParseTags("region=us-east,host=hostA")},
+			{Time: 4, Value: 12, Tags: ParseTags("region=us-east,host=hostA")},
+			{Time: 1, Value: 10, Tags: ParseTags("region=us-west,host=hostA")},
+			{Time: 5, Value: 20, Tags: ParseTags("region=us-east,host=hostA")},
+
+			{Time: 1, Value: 11, Tags: ParseTags("region=us-west,host=hostB")},
+			{Time: 23, Value: 8, Tags: ParseTags("region=us-west,host=hostB")},
+		}},
+		influxql.IteratorOptions{
+			Expr:       MustParseExpr(`min("value")`),
+			Dimensions: []string{"host"},
+			Interval:   influxql.Interval{Duration: 5 * time.Nanosecond},
+			Ordered:    true,
+			Ascending:  true,
+		},
+	)
+
+	if a, err := Iterators([]influxql.Iterator{itr}).ReadAll(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if !deep.Equal(a, [][]influxql.Point{
+		{&influxql.UnsignedPoint{Time: 1, Value: 10, Tags: ParseTags("host=hostA"), Aggregated: 4}},
+		{&influxql.UnsignedPoint{Time: 5, Value: 20, Tags: ParseTags("host=hostA"), Aggregated: 1}},
+		{&influxql.UnsignedPoint{Time: 1, Value: 11, Tags: ParseTags("host=hostB"), Aggregated: 1}},
+		{&influxql.UnsignedPoint{Time: 23, Value: 8, Tags: ParseTags("host=hostB"), Aggregated: 1}},
+	}) {
+		t.Fatalf("unexpected points: %s", spew.Sdump(a))
+	}
+}
+
 // Ensure that a boolean iterator can be created for a min() call.
 func TestCallIterator_Min_Boolean(t *testing.T) {
 	itr, _ := influxql.NewCallIterator(
@@ -316,6 +385,39 @@ func TestCallIterator_Max_Integer(t *testing.T) {
 	}
 }
 
+// Ensure that an unsigned iterator can be created for a max() call.
+func TestCallIterator_Max_Unsigned(t *testing.T) {
+	itr, _ := influxql.NewCallIterator(
+		&UnsignedIterator{Points: []influxql.UnsignedPoint{
+			{Time: 0, Value: 15, Tags: ParseTags("region=us-east,host=hostA")},
+			{Time: 2, Value: 10, Tags: ParseTags("region=us-east,host=hostA")},
+			{Time: 1, Value: 10, Tags: ParseTags("region=us-west,host=hostA")},
+			{Time: 5, Value: 20, Tags: ParseTags("region=us-east,host=hostA")},
+
+			{Time: 1, Value: 11, Tags: ParseTags("region=us-west,host=hostB")},
+			{Time: 23, Value: 8, Tags: ParseTags("region=us-west,host=hostB")},
+		}},
+		influxql.IteratorOptions{
+			Expr:       MustParseExpr(`max("value")`),
+			Dimensions: []string{"host"},
+			Interval:   influxql.Interval{Duration: 5 * time.Nanosecond},
+			Ordered:    true,
+			Ascending:  true,
+		},
+	)
+
+	if a, err := Iterators([]influxql.Iterator{itr}).ReadAll(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if !deep.Equal(a, [][]influxql.Point{
+		{&influxql.UnsignedPoint{Time: 0, Value: 15, Tags: ParseTags("host=hostA"), Aggregated: 3}},
+		{&influxql.UnsignedPoint{Time: 5, Value: 20, Tags: ParseTags("host=hostA"), Aggregated: 1}},
+		{&influxql.UnsignedPoint{Time: 1, Value: 11, Tags: ParseTags("host=hostB"), Aggregated: 1}},
+		{&influxql.UnsignedPoint{Time: 23, Value: 8, Tags: ParseTags("host=hostB"), Aggregated: 1}},
+	}) {
+		t.Fatalf("unexpected points: %s", spew.Sdump(a))
+	}
+}
+
 // Ensure that a boolean iterator can be created for a max() call.
 func TestCallIterator_Max_Boolean(t *testing.T) {
 	itr, _ := influxql.NewCallIterator(
@@ -415,6 +517,39 @@ func TestCallIterator_Sum_Integer(t *testing.T) {
 	}
 }
 
+// Ensure that an unsigned iterator can be created for a sum() call.
+func TestCallIterator_Sum_Unsigned(t *testing.T) {
+	itr, _ := influxql.NewCallIterator(
+		&UnsignedIterator{Points: []influxql.UnsignedPoint{
+			{Time: 0, Value: 15, Tags: ParseTags("region=us-east,host=hostA")},
+			{Time: 2, Value: 10, Tags: ParseTags("region=us-east,host=hostA")},
+			{Time: 1, Value: 10, Tags: ParseTags("region=us-west,host=hostA")},
+			{Time: 5, Value: 20, Tags: ParseTags("region=us-east,host=hostA")},
+
+			{Time: 1, Value: 11, Tags: ParseTags("region=us-west,host=hostB")},
+			{Time: 23, Value: 8, Tags: ParseTags("region=us-west,host=hostB")},
+		}},
+		influxql.IteratorOptions{
+			Expr:       MustParseExpr(`sum("value")`),
+			Dimensions: []string{"host"},
+			Interval:   influxql.Interval{Duration: 5 * time.Nanosecond},
+			Ordered:    true,
+			Ascending:  true,
+		},
+	)
+
+	if a, err := Iterators([]influxql.Iterator{itr}).ReadAll(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if !deep.Equal(a, [][]influxql.Point{
+		{&influxql.UnsignedPoint{Time: 0, Value: 35, Tags: ParseTags("host=hostA"), Aggregated: 3}},
+		{&influxql.UnsignedPoint{Time: 5, Value: 20, Tags: ParseTags("host=hostA"), Aggregated: 1}},
+		{&influxql.UnsignedPoint{Time: 0, Value: 11, Tags: ParseTags("host=hostB"), Aggregated: 1}},
+		{&influxql.UnsignedPoint{Time: 20, Value: 8, Tags: ParseTags("host=hostB"), Aggregated: 1}},
+	}) {
+		t.Fatalf("unexpected points: %s", spew.Sdump(a))
+	}
+}
+
 // Ensure that a float iterator can be created for a first() call.
 func TestCallIterator_First_Float(t *testing.T) {
 	itr, _ := influxql.NewCallIterator(
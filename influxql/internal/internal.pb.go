@@ -46,6 +46,7 @@ type Point struct {
 	IntegerValue     *int64         `protobuf:"varint,8,opt,name=IntegerValue" json:"IntegerValue,omitempty"`
 	StringValue      *string        `protobuf:"bytes,9,opt,name=StringValue" json:"StringValue,omitempty"`
 	BooleanValue     *bool          `protobuf:"varint,10,opt,name=BooleanValue" json:"BooleanValue,omitempty"`
+	UnsignedValue    *uint64        `protobuf:"varint,12,opt,name=UnsignedValue" json:"UnsignedValue,omitempty"`
 	Stats            *IteratorStats `protobuf:"bytes,11,opt,name=Stats" json:"Stats,omitempty"`
 	XXX_unrecognized []byte         `json:"-"`
 }
@@ -125,6 +126,13 @@ func (m *Point) GetBooleanValue() bool {
 	return false
 }
 
+func (m *Point) GetUnsignedValue() uint64 {
+	if m != nil && m.UnsignedValue != nil {
+		return *m.UnsignedValue
+	}
+	return 0
+}
+
 func (m *Point) GetStats() *IteratorStats {
 	if m != nil {
 		return m.Stats
@@ -138,6 +146,7 @@ type Aux struct {
 	IntegerValue     *int64   `protobuf:"varint,3,opt,name=IntegerValue" json:"IntegerValue,omitempty"`
 	StringValue      *string  `protobuf:"bytes,4,opt,name=StringValue" json:"StringValue,omitempty"`
 	BooleanValue     *bool    `protobuf:"varint,5,opt,name=BooleanValue" json:"BooleanValue,omitempty"`
+	UnsignedValue    *uint64  `protobuf:"varint,6,opt,name=UnsignedValue" json:"UnsignedValue,omitempty"`
 	XXX_unrecognized []byte   `json:"-"`
 }
 
@@ -181,6 +190,13 @@ func (m *Aux) GetBooleanValue() bool {
 	return false
 }
 
+func (m *Aux) GetUnsignedValue() uint64 {
+	if m != nil && m.UnsignedValue != nil {
+		return *m.UnsignedValue
+	}
+	return 0
+}
+
 type IteratorOptions struct {
 	Expr             *string        `protobuf:"bytes,1,opt,name=Expr" json:"Expr,omitempty"`
 	Aux              []string       `protobuf:"bytes,2,rep,name=Aux" json:"Aux,omitempty"`
@@ -513,52 +529,53 @@ func init() {
 func init() { proto.RegisterFile("internal/internal.proto", fileDescriptorInternal) }
 
 var fileDescriptorInternal = []byte{
-	// 737 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xdd, 0x6a, 0xdb, 0x4a,
-	0x10, 0x46, 0x92, 0xe5, 0x58, 0xeb, 0xf8, 0x24, 0x67, 0x4f, 0x7e, 0x96, 0xc3, 0xe1, 0x54, 0xe8,
-	0x4a, 0x50, 0xea, 0x40, 0x6e, 0x0b, 0x05, 0xa7, 0x49, 0x8a, 0x21, 0x71, 0xc2, 0x2a, 0xe4, 0x7e,
-	0x6b, 0x8d, 0xc5, 0x82, 0x2c, 0xb9, 0xab, 0x55, 0x71, 0x1e, 0xa5, 0xcf, 0xd0, 0x87, 0xe9, 0xab,
-	0xf4, 0x11, 0xca, 0xce, 0x4a, 0x96, 0x92, 0x42, 0x73, 0xa5, 0xf9, 0xbe, 0x99, 0x1d, 0xed, 0xcc,
-	0x37, 0x3b, 0xe4, 0x54, 0x16, 0x1a, 0x54, 0x21, 0xf2, 0xb3, 0xd6, 0x98, 0x6e, 0x54, 0xa9, 0x4b,
-	0x3a, 0x92, 0xc5, 0x2a, 0xaf, 0xb7, 0x5f, 0xf2, 0xe8, 0x87, 0x4b, 0xfc, 0xfb, 0x52, 0x16, 0x9a,
-	0x52, 0x32, 0x58, 0x88, 0x35, 0x30, 0x27, 0x74, 0xe3, 0x80, 0xa3, 0x6d, 0xb8, 0x07, 0x91, 0x55,
-	0xcc, 0xb5, 0x9c, 0xb1, 0x91, 0x93, 0x6b, 0x60, 0x5e, 0xe8, 0xc6, 0x1e, 0x47, 0x9b, 0x1e, 0x12,
-	0x6f, 0x21, 0x73, 0x36, 0x08, 0xdd, 0x78, 0xc4, 0x8d, 0x49, 0xdf, 0x10, 0x6f, 0x56, 0x6f, 0x99,
-	0x1f, 0x7a, 0xf1, 0xf8, 0x7c, 0x32, 0x6d, 0xff, 0x37, 0x9d, 0xd5, 0x5b, 0x6e, 0x3c, 0xf4, 0x7f,
-	0x42, 0x66, 0x59, 0xa6, 0x20, 0x13, 0x1a, 0x52, 0x36, 0x0c, 0x9d, 0x78, 0xc2, 0x7b, 0x8c, 0xf1,
-	0x5f, 0xe7, 0xa5, 0xd0, 0x8f, 0x22, 0xaf, 0x81, 0xed, 0x85, 0x4e, 0xec, 0xf0, 0x1e, 0x43, 0x23,
-	0xb2, 0x3f, 0x2f, 0x34, 0x64, 0xa0, 0x6c, 0xc4, 0x28, 0x74, 0x62, 0x8f, 0x3f, 0xe3, 0x68, 0x48,
-	0xc6, 0x89, 0x56, 0xb2, 0xc8, 0x6c, 0x48, 0x10, 0x3a, 0x71, 0xc0, 0xfb, 0x94, 0xc9, 0x72, 0x51,
-	0x96, 0x39, 0x88, 0xc2, 0x86, 0x90, 0xd0, 0x89, 0x47, 0xfc, 0x19, 0x47, 0xdf, 0x11, 0x3f, 0xd1,
-	0x42, 0x57, 0x6c, 0x1c, 0x3a, 0xf1, 0xf8, 0xfc, 0xb4, 0x2b, 0x66, 0xae, 0x41, 0x09, 0x5d, 0x2a,
-	0x74, 0x73, 0x1b, 0x15, 0x7d, 0x77, 0xb0, 0x74, 0xfa, 0x2f, 0x19, 0x5d, 0x0a, 0x2d, 0x1e, 0x9e,
-	0x36, 0xb6, 0xa7, 0x3e, 0xdf, 0xe1, 0x17, 0xc5, 0xb9, 0xaf, 0x16, 0xe7, 0xbd, 0x5e, 0xdc, 0xe0,
-	0xf5, 0xe2, 0xfc, 0xdf, 0x8b, 0x8b, 0x7e, 0x0e, 0xc8, 0x41, 0x5b, 0xc6, 0xdd, 0x46, 0xcb, 0xb2,
-	0x40, 0x85, 0xaf, 0xb6, 0x1b, 0xc5, 0x1c, 0x4c, 0x89, 0xb6, 0x51, 0xd8, 0xe8, 0xe9, 0x86, 0x5e,
-	0x1c, 0x58, 0x01, 0x63, 0x32, 0xbc, 0x96, 0x90, 0xa7, 0x15, 0xfb, 0x1b, 0x45, 0x3e, 0xec, 0xfa,
-	0xf2, 0x28, 0x14, 0x87, 0x15, 0x6f, 0xfc, 0xf4, 0x8c, 0xec, 0x25, 0x65, 0xad, 0x96, 0x50, 0x31,
-	0x0f, 0x43, 0x8f, 0xbb, 0xd0, 0x5b, 0x10, 0x55, 0xad, 0x60, 0x0d, 0x85, 0xe6, 0x6d, 0x14, 0x9d,
-	0x92, 0x91, 0x29, 0x55, 0x7d, 0x15, 0x39, 0xd6, 0x35, 0x3e, 0xa7, 0xbd, 0xa6, 0x37, 0x1e, 0xbe,
-	0x8b, 0x31, 0xed, 0xbc, 0x94, 0x6b, 0x28, 0x2a, 0x73, 0x7d, 0x9c, 0xb9, 0x80, 0xf7, 0x18, 0xca,
-	0xc8, 0xde, 0x27, 0x55, 0xd6, 0x9b, 0x8b, 0x27, 0xf6, 0x0f, 0x3a, 0x5b, 0x68, 0x4a, 0xbd, 0x96,
-	0x79, 0x8e, 0xf3, 0xe7, 0x73, 0xb4, 0xe9, 0x7f, 0x24, 0x30, 0xdf, 0xfe, 0xe0, 0x75, 0x84, 0xf1,
-	0x7e, 0x2c, 0x8b, 0x54, 0x9a, 0x56, 0xe1, 0xd0, 0x05, 0xbc, 0x23, 0x8c, 0x37, 0xd1, 0x42, 0x69,
-	0x7c, 0x21, 0x01, 0xaa, 0xd6, 0x11, 0xe6, 0x1e, 0x57, 0x45, 0x8a, 0x3e, 0x82, 0xbe, 0x16, 0x9a,
-	0x61, 0xb9, 0x29, 0x97, 0x02, 0x93, 0x1e, 0x63, 0xd2, 0x1d, 0x36, 0x39, 0x67, 0xd5, 0x12, 0x8a,
-	0x54, 0x16, 0x19, 0xce, 0xe0, 0x88, 0x77, 0x04, 0x3d, 0x22, 0xfe, 0x8d, 0x5c, 0x4b, 0xcd, 0xf6,
-	0x31, 0xa3, 0x05, 0xf4, 0x84, 0x0c, 0xef, 0x56, 0xab, 0x0a, 0x34, 0x9b, 0x20, 0xdd, 0x20, 0xc3,
-	0x27, 0x36, 0xfc, 0x2f, 0xcb, 0x5b, 0x64, 0x6e, 0x96, 0x34, 0x07, 0x0e, 0xec, 0xcd, 0x92, 0xee,
-	0xc4, 0x25, 0xa4, 0xf5, 0x06, 0xd8, 0x21, 0xfe, 0xba, 0x41, 0xa6, 0xe7, 0xb7, 0x62, 0x9b, 0x80,
-	0x92, 0x50, 0x2d, 0x18, 0xc5, 0x43, 0x3d, 0xc6, 0x64, 0xbc, 0x53, 0x29, 0x28, 0x48, 0xd9, 0x11,
-	0x1e, 0x6c, 0x61, 0xf4, 0x9e, 0xec, 0xf7, 0x54, 0xaf, 0xe8, 0x5b, 0xe2, 0xcf, 0x35, 0xac, 0x2b,
-	0xe6, 0xfc, 0x69, 0x38, 0x6c, 0x4c, 0xf4, 0xcd, 0x21, 0xe3, 0x1e, 0xdd, 0xbe, 0xb2, 0xcf, 0xa2,
-	0x82, 0x66, 0x5e, 0x77, 0x98, 0xc6, 0xe4, 0x80, 0x83, 0x86, 0xc2, 0x74, 0xf1, 0xbe, 0xcc, 0xe5,
-	0xf2, 0x09, 0x9f, 0x5a, 0xc0, 0x5f, 0xd2, 0xbb, 0xdd, 0xe7, 0xd9, 0x89, 0xc7, 0xdd, 0x77, 0x44,
-	0x7c, 0x0e, 0x19, 0x6c, 0x9b, 0x97, 0x65, 0x81, 0xf9, 0xdf, 0xbc, 0x7a, 0x10, 0x2a, 0x03, 0xdd,
-	0xbc, 0xa7, 0x1d, 0x8e, 0x3e, 0x74, 0x63, 0x8b, 0xf7, 0xaa, 0x95, 0x15, 0xd4, 0xc1, 0xe6, 0xec,
-	0x70, 0x4f, 0x1c, 0xb7, 0x2f, 0x4e, 0x34, 0x23, 0x93, 0x67, 0x1b, 0x05, 0x55, 0x69, 0x1a, 0xec,
-	0x34, 0xaa, 0x34, 0xdd, 0x3d, 0x21, 0x43, 0xdc, 0xda, 0x8b, 0x36, 0x85, 0x45, 0xd1, 0x94, 0x0c,
-	0xed, 0xe3, 0x33, 0x0f, 0xf6, 0x51, 0xe4, 0xcd, 0x36, 0x37, 0x26, 0x2e, 0x6e, 0xb3, 0x8c, 0x5c,
-	0x3b, 0xeb, 0xc6, 0xfe, 0x15, 0x00, 0x00, 0xff, 0xff, 0xca, 0x3e, 0x5e, 0x08, 0x22, 0x06, 0x00,
-	0x00,
+	// 754 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x5d, 0x6f, 0xeb, 0x44,
+	0x10, 0x95, 0xed, 0x38, 0x8d, 0x37, 0x0d, 0x0d, 0xcb, 0xbd, 0xdc, 0x15, 0x42, 0x60, 0x59, 0x3c,
+	0x58, 0x42, 0xe4, 0x4a, 0x7d, 0x45, 0x42, 0x4a, 0x69, 0x8b, 0x22, 0xb5, 0x69, 0xb5, 0x2e, 0x7d,
+	0x5f, 0xe2, 0x89, 0xb5, 0x92, 0xb3, 0x0e, 0xeb, 0x35, 0x4a, 0x7f, 0x0a, 0x3f, 0x8b, 0x7f, 0xc2,
+	0x0b, 0xef, 0x68, 0x67, 0xed, 0xd8, 0xa9, 0x10, 0xbd, 0x4f, 0x99, 0x73, 0x66, 0xf6, 0xeb, 0xcc,
+	0x99, 0x98, 0x7c, 0x90, 0xca, 0x80, 0x56, 0xa2, 0xfc, 0xd8, 0x05, 0x8b, 0xbd, 0xae, 0x4c, 0x45,
+	0x27, 0x52, 0x6d, 0xcb, 0xe6, 0xf0, 0x7b, 0x99, 0xfc, 0xe3, 0x93, 0xf0, 0xb1, 0x92, 0xca, 0x50,
+	0x4a, 0x46, 0x6b, 0xb1, 0x03, 0xe6, 0xc5, 0x7e, 0x1a, 0x71, 0x8c, 0x2d, 0xf7, 0x24, 0x8a, 0x9a,
+	0xf9, 0x8e, 0xb3, 0x31, 0x72, 0x72, 0x07, 0x2c, 0x88, 0xfd, 0x34, 0xe0, 0x18, 0xd3, 0x39, 0x09,
+	0xd6, 0xb2, 0x64, 0xa3, 0xd8, 0x4f, 0x27, 0xdc, 0x86, 0xf4, 0x5b, 0x12, 0x2c, 0x9b, 0x03, 0x0b,
+	0xe3, 0x20, 0x9d, 0x5e, 0xce, 0x16, 0xdd, 0x79, 0x8b, 0x65, 0x73, 0xe0, 0x36, 0x43, 0xbf, 0x21,
+	0x64, 0x59, 0x14, 0x1a, 0x0a, 0x61, 0x20, 0x67, 0xe3, 0xd8, 0x4b, 0x67, 0x7c, 0xc0, 0xd8, 0xfc,
+	0x6d, 0x59, 0x09, 0xf3, 0x2c, 0xca, 0x06, 0xd8, 0x59, 0xec, 0xa5, 0x1e, 0x1f, 0x30, 0x34, 0x21,
+	0xe7, 0x2b, 0x65, 0xa0, 0x00, 0xed, 0x2a, 0x26, 0xb1, 0x97, 0x06, 0xfc, 0x84, 0xa3, 0x31, 0x99,
+	0x66, 0x46, 0x4b, 0x55, 0xb8, 0x92, 0x28, 0xf6, 0xd2, 0x88, 0x0f, 0x29, 0xbb, 0xcb, 0x55, 0x55,
+	0x95, 0x20, 0x94, 0x2b, 0x21, 0xb1, 0x97, 0x4e, 0xf8, 0x09, 0x47, 0xbf, 0x23, 0xb3, 0x5f, 0x55,
+	0x2d, 0x0b, 0x05, 0xb9, 0x2b, 0x3a, 0x8f, 0xbd, 0x74, 0xc4, 0x4f, 0x49, 0xfa, 0x03, 0x09, 0x33,
+	0x23, 0x4c, 0xcd, 0xa6, 0xb1, 0x97, 0x4e, 0x2f, 0x3f, 0xf4, 0x4f, 0x5e, 0x19, 0xd0, 0xc2, 0x54,
+	0x1a, 0xd3, 0xdc, 0x55, 0x25, 0x7f, 0x79, 0x28, 0x10, 0xfd, 0x8a, 0x4c, 0xae, 0x85, 0x11, 0x4f,
+	0x2f, 0x7b, 0xa7, 0x7c, 0xc8, 0x8f, 0xf8, 0x95, 0x04, 0xfe, 0x9b, 0x12, 0x04, 0x6f, 0x4b, 0x30,
+	0x7a, 0x5b, 0x82, 0xf0, 0x53, 0x24, 0x18, 0xff, 0x87, 0x04, 0xc9, 0xdf, 0x23, 0x72, 0xd1, 0x3d,
+	0xf6, 0x61, 0x6f, 0x64, 0xa5, 0xd0, 0x2d, 0x37, 0x87, 0xbd, 0x66, 0x1e, 0x1e, 0x8c, 0x31, 0x9d,
+	0x3b, 0x6f, 0xf8, 0x71, 0x90, 0x46, 0xce, 0x0c, 0x29, 0x19, 0xdf, 0x4a, 0x28, 0xf3, 0x9a, 0x7d,
+	0x8e, 0x86, 0x99, 0xf7, 0xea, 0x3d, 0x0b, 0xcd, 0x61, 0xcb, 0xdb, 0x3c, 0xfd, 0x48, 0xce, 0xb2,
+	0xaa, 0xd1, 0x1b, 0xa8, 0x59, 0x80, 0xa5, 0xef, 0xfb, 0xd2, 0x7b, 0x10, 0x75, 0xa3, 0x61, 0x07,
+	0xca, 0xf0, 0xae, 0x8a, 0x2e, 0xc8, 0xc4, 0x0a, 0xa2, 0xff, 0x10, 0x25, 0xbe, 0x7e, 0x7a, 0x49,
+	0x07, 0xad, 0x69, 0x33, 0xfc, 0x58, 0x63, 0x45, 0xbf, 0x96, 0x3b, 0x50, 0xb5, 0xbd, 0x3e, 0xfa,
+	0x37, 0xe2, 0x03, 0x86, 0x32, 0x72, 0xf6, 0x8b, 0xae, 0x9a, 0xfd, 0xd5, 0x0b, 0xfb, 0x02, 0x93,
+	0x1d, 0xb4, 0x4f, 0xbd, 0x95, 0x65, 0x89, 0xda, 0x84, 0x1c, 0x63, 0xfa, 0x35, 0x89, 0xec, 0xef,
+	0xd0, 0xc4, 0x3d, 0x61, 0xb3, 0x3f, 0x57, 0x2a, 0x97, 0x56, 0x2a, 0x34, 0x70, 0xc4, 0x7b, 0xc2,
+	0x66, 0x33, 0x23, 0xb4, 0xc1, 0x69, 0x8b, 0xb0, 0xb7, 0x3d, 0x61, 0xef, 0x71, 0xa3, 0x72, 0xcc,
+	0x11, 0xcc, 0x75, 0xd0, 0x5a, 0xea, 0xae, 0xda, 0x08, 0xdc, 0xf4, 0x3d, 0x6e, 0x7a, 0xc4, 0x76,
+	0xcf, 0x65, 0xbd, 0x01, 0x95, 0x4b, 0x55, 0xa0, 0x53, 0x27, 0xbc, 0x27, 0xe8, 0x3b, 0x12, 0xde,
+	0xc9, 0x9d, 0x34, 0xe8, 0xf0, 0x80, 0x3b, 0x40, 0xbf, 0x24, 0xe3, 0x87, 0xed, 0xb6, 0x06, 0xc3,
+	0x66, 0x48, 0xb7, 0xc8, 0xf2, 0x99, 0x2b, 0xff, 0xcc, 0xf1, 0x0e, 0xd9, 0x9b, 0x65, 0xed, 0x82,
+	0x0b, 0x77, 0xb3, 0xac, 0x5f, 0x71, 0x0d, 0x79, 0xb3, 0x07, 0x36, 0xc7, 0xa3, 0x5b, 0x64, 0x35,
+	0xbf, 0x17, 0x87, 0x0c, 0xb4, 0x84, 0x7a, 0xcd, 0x28, 0x2e, 0x1a, 0x30, 0x76, 0xc7, 0x07, 0x9d,
+	0x83, 0x86, 0x9c, 0xbd, 0xc3, 0x85, 0x1d, 0x4c, 0x7e, 0x24, 0xe7, 0x83, 0xae, 0xd7, 0xf4, 0x7b,
+	0x12, 0xae, 0x0c, 0xec, 0x6a, 0xe6, 0xfd, 0x9f, 0x39, 0x5c, 0x4d, 0xf2, 0xa7, 0x47, 0xa6, 0x03,
+	0xba, 0x9b, 0xc5, 0xdf, 0x44, 0x0d, 0xad, 0x5f, 0x8f, 0x98, 0xa6, 0xe4, 0x82, 0x83, 0x01, 0x65,
+	0x55, 0x7c, 0xac, 0x4a, 0xb9, 0x79, 0xc1, 0x81, 0x8c, 0xf8, 0x6b, 0xfa, 0xf8, 0x3f, 0x1a, 0x38,
+	0xc7, 0xdb, 0xd8, 0x0a, 0xcb, 0xa1, 0x80, 0x43, 0x3b, 0x7f, 0x0e, 0xd8, 0xf3, 0x56, 0xf5, 0x93,
+	0xd0, 0x05, 0x98, 0x76, 0xea, 0x8e, 0x38, 0xf9, 0xa9, 0xb7, 0x2d, 0xde, 0xab, 0xd1, 0xae, 0xa1,
+	0x1e, 0x8a, 0x73, 0xc4, 0x83, 0xe6, 0xf8, 0xc3, 0xe6, 0x24, 0x4b, 0x32, 0x3b, 0xf9, 0xdf, 0xc1,
+	0xae, 0xb4, 0x02, 0x7b, 0x6d, 0x57, 0x1c, 0xb4, 0x5b, 0xe0, 0x17, 0x60, 0xdd, 0x6d, 0xe1, 0x50,
+	0xb2, 0x20, 0x63, 0x37, 0x7c, 0x76, 0x60, 0x9f, 0x45, 0xd9, 0x7e, 0x19, 0x6c, 0x88, 0x1f, 0x01,
+	0xfb, 0x97, 0xe5, 0x3b, 0xaf, 0xdb, 0xf8, 0xdf, 0x01, 0x00, 0xcd, 0x79, 0x50, 0xb8, 0x6e, 0x06,
+	0x00, 0x00,
 }
@@ -40,6 +40,8 @@ func newFloatIterators(itrs []Iterator) []FloatIterator {
 
 		case IntegerIterator:
 			a = append(a, &integerFloatCastIterator{input: itr})
+		case UnsignedIterator:
+			a = append(a, &unsignedFloatCastIterator{input: itr})
 
 		default:
 			itr.Close()
@@ -959,6 +961,8 @@ func (itr *floatChanIterator) setBuf(name string, tags Tags, time int64, value i
 
 	case int64:
 		itr.buf.points[itr.buf.i] = FloatPoint{Name: name, Tags: tags, Time: time, Value: float64(v)}
+	case uint64:
+		itr.buf.points[itr.buf.i] = FloatPoint{Name: name, Tags: tags, Time: time, Value: float64(v)}
 
 	default:
 		itr.buf.points[itr.buf.i] = FloatPoint{Name: name, Tags: tags, Time: time, Nil: true}
@@ -1837,17 +1841,17 @@ func (itr *floatIntegerExprIterator) next() (a, b *FloatPoint, err error) {
 // least one of the points will be non-nil.
 type floatIntegerExprFunc func(a, b float64) int64
 
-// floatReduceStringIterator executes a reducer for every interval and buffers the result.
-type floatReduceStringIterator struct {
+// floatReduceUnsignedIterator executes a reducer for every interval and buffers the result.
+type floatReduceUnsignedIterator struct {
 	input  *bufFloatIterator
-	create func() (FloatPointAggregator, StringPointEmitter)
+	create func() (FloatPointAggregator, UnsignedPointEmitter)
 	dims   []string
 	opt    IteratorOptions
-	points []StringPoint
+	points []UnsignedPoint
 }
 
-func newFloatReduceStringIterator(input FloatIterator, opt IteratorOptions, createFn func() (FloatPointAggregator, StringPointEmitter)) *floatReduceStringIterator {
-	return &floatReduceStringIterator{
+func newFloatReduceUnsignedIterator(input FloatIterator, opt IteratorOptions, createFn func() (FloatPointAggregator, UnsignedPointEmitter)) *floatReduceUnsignedIterator {
+	return &floatReduceUnsignedIterator{
 		input:  newBufFloatIterator(input),
 		create: createFn,
 		dims:   opt.GetDimensions(),
@@ -1856,13 +1860,13 @@ func newFloatReduceStringIterator(input FloatIterator, opt IteratorOptions, crea
 }
 
 // Stats returns stats from the input iterator.
-func (itr *floatReduceStringIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *floatReduceUnsignedIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *floatReduceStringIterator) Close() error { return itr.input.Close() }
+func (itr *floatReduceUnsignedIterator) Close() error { return itr.input.Close() }
 
 // Next returns the minimum value for the next available interval.
-func (itr *floatReduceStringIterator) Next() (*StringPoint, error) {
+func (itr *floatReduceUnsignedIterator) Next() (*UnsignedPoint, error) {
 	// Calculate next window if we have no more points.
 	if len(itr.points) == 0 {
 		var err error
@@ -1878,17 +1882,17 @@ func (itr *floatReduceStringIterator) Next() (*StringPoint, error) {
 	return p, nil
 }
 
-// floatReduceStringPoint stores the reduced data for a name/tag combination.
-type floatReduceStringPoint struct {
+// floatReduceUnsignedPoint stores the reduced data for a name/tag combination.
+type floatReduceUnsignedPoint struct {
 	Name       string
 	Tags       Tags
 	Aggregator FloatPointAggregator
-	Emitter    StringPointEmitter
+	Emitter    UnsignedPointEmitter
 }
 
 // reduce executes fn once for every point in the next window.
 // The previous value for the dimension is passed to fn.
-func (itr *floatReduceStringIterator) reduce() ([]StringPoint, error) {
+func (itr *floatReduceUnsignedIterator) reduce() ([]UnsignedPoint, error) {
 	// Calculate next window.
 	var (
 		startTime, endTime int64
@@ -1913,7 +1917,7 @@ func (itr *floatReduceStringIterator) reduce() ([]StringPoint, error) {
 	}
 
 	// Create points by tags.
-	m := make(map[string]*floatReduceStringPoint)
+	m := make(map[string]*floatReduceUnsignedPoint)
 	for {
 		// Read next point.
 		curr, err := itr.input.NextInWindow(startTime, endTime)
@@ -1946,7 +1950,7 @@ func (itr *floatReduceStringIterator) reduce() ([]StringPoint, error) {
 		rp := m[id]
 		if rp == nil {
 			aggregator, emitter := itr.create()
-			rp = &floatReduceStringPoint{
+			rp = &floatReduceUnsignedPoint{
 				Name:       curr.Name,
 				Tags:       tags,
 				Aggregator: aggregator,
@@ -1969,7 +1973,7 @@ func (itr *floatReduceStringIterator) reduce() ([]StringPoint, error) {
 	// Assume the points are already sorted until proven otherwise.
 	sortedByTime := true
 	// Emit the points for each name & tag combination.
-	a := make([]StringPoint, 0, len(m))
+	a := make([]UnsignedPoint, 0, len(m))
 	for _, k := range keys {
 		rp := m[k]
 		points := rp.Emitter.Emit()
@@ -1988,41 +1992,41 @@ func (itr *floatReduceStringIterator) reduce() ([]StringPoint, error) {
 
 	// Points may be out of order. Perform a stable sort by time if requested.
 	if !sortedByTime && itr.opt.Ordered {
-		sort.Stable(sort.Reverse(stringPointsByTime(a)))
+		sort.Stable(sort.Reverse(unsignedPointsByTime(a)))
 	}
 
 	return a, nil
 }
 
-// floatStreamStringIterator streams inputs into the iterator and emits points gradually.
-type floatStreamStringIterator struct {
+// floatStreamUnsignedIterator streams inputs into the iterator and emits points gradually.
+type floatStreamUnsignedIterator struct {
 	input  *bufFloatIterator
-	create func() (FloatPointAggregator, StringPointEmitter)
+	create func() (FloatPointAggregator, UnsignedPointEmitter)
 	dims   []string
 	opt    IteratorOptions
-	m      map[string]*floatReduceStringPoint
-	points []StringPoint
+	m      map[string]*floatReduceUnsignedPoint
+	points []UnsignedPoint
 }
 
-// newFloatStreamStringIterator returns a new instance of floatStreamStringIterator.
-func newFloatStreamStringIterator(input FloatIterator, createFn func() (FloatPointAggregator, StringPointEmitter), opt IteratorOptions) *floatStreamStringIterator {
-	return &floatStreamStringIterator{
+// newFloatStreamUnsignedIterator returns a new instance of floatStreamUnsignedIterator.
+func newFloatStreamUnsignedIterator(input FloatIterator, createFn func() (FloatPointAggregator, UnsignedPointEmitter), opt IteratorOptions) *floatStreamUnsignedIterator {
+	return &floatStreamUnsignedIterator{
 		input:  newBufFloatIterator(input),
 		create: createFn,
 		dims:   opt.GetDimensions(),
 		opt:    opt,
-		m:      make(map[string]*floatReduceStringPoint),
+		m:      make(map[string]*floatReduceUnsignedPoint),
 	}
 }
 
 // Stats returns stats from the input iterator.
-func (itr *floatStreamStringIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *floatStreamUnsignedIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *floatStreamStringIterator) Close() error { return itr.input.Close() }
+func (itr *floatStreamUnsignedIterator) Close() error { return itr.input.Close() }
 
 // Next returns the next value for the stream iterator.
-func (itr *floatStreamStringIterator) Next() (*StringPoint, error) {
+func (itr *floatStreamUnsignedIterator) Next() (*UnsignedPoint, error) {
 	// Calculate next window if we have no more points.
 	if len(itr.points) == 0 {
 		var err error
@@ -2040,13 +2044,13 @@ func (itr *floatStreamStringIterator) Next() (*StringPoint, error) {
 
 // reduce creates and manages aggregators for every point from the input.
 // After aggregating a point, it always tries to emit a value using the emitter.
-func (itr *floatStreamStringIterator) reduce() ([]StringPoint, error) {
+func (itr *floatStreamUnsignedIterator) reduce() ([]UnsignedPoint, error) {
 	for {
 		// Read next point.
 		curr, err := itr.input.Next()
 		if curr == nil {
 			// Close all of the aggregators to flush any remaining points to emit.
-			var points []StringPoint
+			var points []UnsignedPoint
 			for _, rp := range itr.m {
 				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
 					if err := aggregator.Close(); err != nil {
@@ -2085,7 +2089,7 @@ func (itr *floatStreamStringIterator) reduce() ([]StringPoint, error) {
 		rp := itr.m[id]
 		if rp == nil {
 			aggregator, emitter := itr.create()
-			rp = &floatReduceStringPoint{
+			rp = &floatReduceUnsignedPoint{
 				Name:       curr.Name,
 				Tags:       tags,
 				Aggregator: aggregator,
@@ -2109,17 +2113,17 @@ func (itr *floatStreamStringIterator) reduce() ([]StringPoint, error) {
 	}
 }
 
-// floatStringExprIterator executes a function to modify an existing point
+// floatUnsignedExprIterator executes a function to modify an existing point
 // for every output of the input iterator.
-type floatStringExprIterator struct {
+type floatUnsignedExprIterator struct {
 	left      *bufFloatIterator
 	right     *bufFloatIterator
-	fn        floatStringExprFunc
+	fn        floatUnsignedExprFunc
 	points    []FloatPoint // must be size 2
 	storePrev bool
 }
 
-func newFloatStringExprIterator(left, right FloatIterator, opt IteratorOptions, fn func(a, b float64) string) *floatStringExprIterator {
+func newFloatUnsignedExprIterator(left, right FloatIterator, opt IteratorOptions, fn func(a, b float64) uint64) *floatUnsignedExprIterator {
 	var points []FloatPoint
 	switch opt.Fill {
 	case NullFill, PreviousFill:
@@ -2128,7 +2132,7 @@ func newFloatStringExprIterator(left, right FloatIterator, opt IteratorOptions,
 		value := castToFloat(opt.FillValue)
 		points = []FloatPoint{{Value: value}, {Value: value}}
 	}
-	return &floatStringExprIterator{
+	return &floatUnsignedExprIterator{
 		left:      newBufFloatIterator(left),
 		right:     newBufFloatIterator(right),
 		points:    points,
@@ -2137,19 +2141,19 @@ func newFloatStringExprIterator(left, right FloatIterator, opt IteratorOptions,
 	}
 }
 
-func (itr *floatStringExprIterator) Stats() IteratorStats {
+func (itr *floatUnsignedExprIterator) Stats() IteratorStats {
 	stats := itr.left.Stats()
 	stats.Add(itr.right.Stats())
 	return stats
 }
 
-func (itr *floatStringExprIterator) Close() error {
+func (itr *floatUnsignedExprIterator) Close() error {
 	itr.left.Close()
 	itr.right.Close()
 	return nil
 }
 
-func (itr *floatStringExprIterator) Next() (*StringPoint, error) {
+func (itr *floatUnsignedExprIterator) Next() (*UnsignedPoint, error) {
 	for {
 		a, b, err := itr.next()
 		if err != nil || (a == nil && b == nil) {
@@ -2190,7 +2194,7 @@ func (itr *floatStringExprIterator) Next() (*StringPoint, error) {
 			itr.points[0], itr.points[1] = *a, *b
 		}
 
-		p := &StringPoint{
+		p := &UnsignedPoint{
 			Name:       a.Name,
 			Tags:       a.Tags,
 			Time:       a.Time,
@@ -2207,7 +2211,7 @@ func (itr *floatStringExprIterator) Next() (*StringPoint, error) {
 
 // next returns the next points within each iterator. If the iterators are
 // uneven, it organizes them so only matching points are returned.
-func (itr *floatStringExprIterator) next() (a, b *FloatPoint, err error) {
+func (itr *floatUnsignedExprIterator) next() (a, b *FloatPoint, err error) {
 	// Retrieve the next value for both the left and right.
 	a, err = itr.left.Next()
 	if err != nil {
@@ -2247,23 +2251,23 @@ func (itr *floatStringExprIterator) next() (a, b *FloatPoint, err error) {
 	return a, b, nil
 }
 
-// floatStringExprFunc creates or modifies a point by combining two
+// floatUnsignedExprFunc creates or modifies a point by combining two
 // points. The point passed in may be modified and returned rather than
 // allocating a new point if possible. One of the points may be nil, but at
 // least one of the points will be non-nil.
-type floatStringExprFunc func(a, b float64) string
+type floatUnsignedExprFunc func(a, b float64) uint64
 
-// floatReduceBooleanIterator executes a reducer for every interval and buffers the result.
-type floatReduceBooleanIterator struct {
+// floatReduceStringIterator executes a reducer for every interval and buffers the result.
+type floatReduceStringIterator struct {
 	input  *bufFloatIterator
-	create func() (FloatPointAggregator, BooleanPointEmitter)
+	create func() (FloatPointAggregator, StringPointEmitter)
 	dims   []string
 	opt    IteratorOptions
-	points []BooleanPoint
+	points []StringPoint
 }
 
-func newFloatReduceBooleanIterator(input FloatIterator, opt IteratorOptions, createFn func() (FloatPointAggregator, BooleanPointEmitter)) *floatReduceBooleanIterator {
-	return &floatReduceBooleanIterator{
+func newFloatReduceStringIterator(input FloatIterator, opt IteratorOptions, createFn func() (FloatPointAggregator, StringPointEmitter)) *floatReduceStringIterator {
+	return &floatReduceStringIterator{
 		input:  newBufFloatIterator(input),
 		create: createFn,
 		dims:   opt.GetDimensions(),
@@ -2272,13 +2276,13 @@ func newFloatReduceBooleanIterator(input FloatIterator, opt IteratorOptions, cre
 }
 
 // Stats returns stats from the input iterator.
-func (itr *floatReduceBooleanIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *floatReduceStringIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *floatReduceBooleanIterator) Close() error { return itr.input.Close() }
+func (itr *floatReduceStringIterator) Close() error { return itr.input.Close() }
 
 // Next returns the minimum value for the next available interval.
-func (itr *floatReduceBooleanIterator) Next() (*BooleanPoint, error) {
+func (itr *floatReduceStringIterator) Next() (*StringPoint, error) {
 	// Calculate next window if we have no more points.
 	if len(itr.points) == 0 {
 		var err error
@@ -2294,17 +2298,17 @@ func (itr *floatReduceBooleanIterator) Next() (*BooleanPoint, error) {
 	return p, nil
 }
 
-// floatReduceBooleanPoint stores the reduced data for a name/tag combination.
-type floatReduceBooleanPoint struct {
+// floatReduceStringPoint stores the reduced data for a name/tag combination.
+type floatReduceStringPoint struct {
 	Name       string
 	Tags       Tags
 	Aggregator FloatPointAggregator
-	Emitter    BooleanPointEmitter
+	Emitter    StringPointEmitter
 }
 
 // reduce executes fn once for every point in the next window.
 // The previous value for the dimension is passed to fn.
-func (itr *floatReduceBooleanIterator) reduce() ([]BooleanPoint, error) {
+func (itr *floatReduceStringIterator) reduce() ([]StringPoint, error) {
 	// Calculate next window.
 	var (
 		startTime, endTime int64
@@ -2329,7 +2333,7 @@ func (itr *floatReduceBooleanIterator) reduce() ([]BooleanPoint, error) {
 	}
 
 	// Create points by tags.
-	m := make(map[string]*floatReduceBooleanPoint)
+	m := make(map[string]*floatReduceStringPoint)
 	for {
 		// Read next point.
 		curr, err := itr.input.NextInWindow(startTime, endTime)
@@ -2362,7 +2366,7 @@ func (itr *floatReduceBooleanIterator) reduce() ([]BooleanPoint, error) {
 		rp := m[id]
 		if rp == nil {
 			aggregator, emitter := itr.create()
-			rp = &floatReduceBooleanPoint{
+			rp = &floatReduceStringPoint{
 				Name:       curr.Name,
 				Tags:       tags,
 				Aggregator: aggregator,
@@ -2385,7 +2389,7 @@ func (itr *floatReduceBooleanIterator) reduce() ([]BooleanPoint, error) {
 	// Assume the points are already sorted until proven otherwise.
 	sortedByTime := true
 	// Emit the points for each name & tag combination.
-	a := make([]BooleanPoint, 0, len(m))
+	a := make([]StringPoint, 0, len(m))
 	for _, k := range keys {
 		rp := m[k]
 		points := rp.Emitter.Emit()
@@ -2404,41 +2408,41 @@ func (itr *floatReduceBooleanIterator) reduce() ([]BooleanPoint, error) {
 
 	// Points may be out of order. Perform a stable sort by time if requested.
 	if !sortedByTime && itr.opt.Ordered {
-		sort.Stable(sort.Reverse(booleanPointsByTime(a)))
+		sort.Stable(sort.Reverse(stringPointsByTime(a)))
 	}
 
 	return a, nil
 }
 
-// floatStreamBooleanIterator streams inputs into the iterator and emits points gradually.
-type floatStreamBooleanIterator struct {
+// floatStreamStringIterator streams inputs into the iterator and emits points gradually.
+type floatStreamStringIterator struct {
 	input  *bufFloatIterator
-	create func() (FloatPointAggregator, BooleanPointEmitter)
+	create func() (FloatPointAggregator, StringPointEmitter)
 	dims   []string
 	opt    IteratorOptions
-	m      map[string]*floatReduceBooleanPoint
-	points []BooleanPoint
+	m      map[string]*floatReduceStringPoint
+	points []StringPoint
 }
 
-// newFloatStreamBooleanIterator returns a new instance of floatStreamBooleanIterator.
-func newFloatStreamBooleanIterator(input FloatIterator, createFn func() (FloatPointAggregator, BooleanPointEmitter), opt IteratorOptions) *floatStreamBooleanIterator {
-	return &floatStreamBooleanIterator{
+// newFloatStreamStringIterator returns a new instance of floatStreamStringIterator.
+func newFloatStreamStringIterator(input FloatIterator, createFn func() (FloatPointAggregator, StringPointEmitter), opt IteratorOptions) *floatStreamStringIterator {
+	return &floatStreamStringIterator{
 		input:  newBufFloatIterator(input),
 		create: createFn,
 		dims:   opt.GetDimensions(),
 		opt:    opt,
-		m:      make(map[string]*floatReduceBooleanPoint),
+		m:      make(map[string]*floatReduceStringPoint),
 	}
 }
 
 // Stats returns stats from the input iterator.
-func (itr *floatStreamBooleanIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *floatStreamStringIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *floatStreamBooleanIterator) Close() error { return itr.input.Close() }
+func (itr *floatStreamStringIterator) Close() error { return itr.input.Close() }
 
 // Next returns the next value for the stream iterator.
-func (itr *floatStreamBooleanIterator) Next() (*BooleanPoint, error) {
+func (itr *floatStreamStringIterator) Next() (*StringPoint, error) {
 	// Calculate next window if we have no more points.
 	if len(itr.points) == 0 {
 		var err error
@@ -2456,13 +2460,13 @@ func (itr *floatStreamBooleanIterator) Next() (*BooleanPoint, error) {
 
 // reduce creates and manages aggregators for every point from the input.
 // After aggregating a point, it always tries to emit a value using the emitter.
-func (itr *floatStreamBooleanIterator) reduce() ([]BooleanPoint, error) {
+func (itr *floatStreamStringIterator) reduce() ([]StringPoint, error) {
 	for {
 		// Read next point.
 		curr, err := itr.input.Next()
 		if curr == nil {
 			// Close all of the aggregators to flush any remaining points to emit.
-			var points []BooleanPoint
+			var points []StringPoint
 			for _, rp := range itr.m {
 				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
 					if err := aggregator.Close(); err != nil {
@@ -2501,7 +2505,7 @@ func (itr *floatStreamBooleanIterator) reduce() ([]BooleanPoint, error) {
 		rp := itr.m[id]
 		if rp == nil {
 			aggregator, emitter := itr.create()
-			rp = &floatReduceBooleanPoint{
+			rp = &floatReduceStringPoint{
 				Name:       curr.Name,
 				Tags:       tags,
 				Aggregator: aggregator,
@@ -2525,17 +2529,17 @@ func (itr *floatStreamBooleanIterator) reduce() ([]BooleanPoint, error) {
 	}
 }
 
-// floatBooleanExprIterator executes a function to modify an existing point
+// floatStringExprIterator executes a function to modify an existing point
 // for every output of the input iterator.
-type floatBooleanExprIterator struct {
+type floatStringExprIterator struct {
 	left      *bufFloatIterator
 	right     *bufFloatIterator
-	fn        floatBooleanExprFunc
+	fn        floatStringExprFunc
 	points    []FloatPoint // must be size 2
 	storePrev bool
 }
 
-func newFloatBooleanExprIterator(left, right FloatIterator, opt IteratorOptions, fn func(a, b float64) bool) *floatBooleanExprIterator {
+func newFloatStringExprIterator(left, right FloatIterator, opt IteratorOptions, fn func(a, b float64) string) *floatStringExprIterator {
 	var points []FloatPoint
 	switch opt.Fill {
 	case NullFill, PreviousFill:
@@ -2544,7 +2548,7 @@ func newFloatBooleanExprIterator(left, right FloatIterator, opt IteratorOptions,
 		value := castToFloat(opt.FillValue)
 		points = []FloatPoint{{Value: value}, {Value: value}}
 	}
-	return &floatBooleanExprIterator{
+	return &floatStringExprIterator{
 		left:      newBufFloatIterator(left),
 		right:     newBufFloatIterator(right),
 		points:    points,
@@ -2553,19 +2557,19 @@ func newFloatBooleanExprIterator(left, right FloatIterator, opt IteratorOptions,
 	}
 }
 
-func (itr *floatBooleanExprIterator) Stats() IteratorStats {
+func (itr *floatStringExprIterator) Stats() IteratorStats {
 	stats := itr.left.Stats()
 	stats.Add(itr.right.Stats())
 	return stats
 }
 
-func (itr *floatBooleanExprIterator) Close() error {
+func (itr *floatStringExprIterator) Close() error {
 	itr.left.Close()
 	itr.right.Close()
 	return nil
 }
 
-func (itr *floatBooleanExprIterator) Next() (*BooleanPoint, error) {
+func (itr *floatStringExprIterator) Next() (*StringPoint, error) {
 	for {
 		a, b, err := itr.next()
 		if err != nil || (a == nil && b == nil) {
@@ -2606,7 +2610,7 @@ func (itr *floatBooleanExprIterator) Next() (*BooleanPoint, error) {
 			itr.points[0], itr.points[1] = *a, *b
 		}
 
-		p := &BooleanPoint{
+		p := &StringPoint{
 			Name:       a.Name,
 			Tags:       a.Tags,
 			Time:       a.Time,
@@ -2623,7 +2627,7 @@ func (itr *floatBooleanExprIterator) Next() (*BooleanPoint, error) {
 
 // next returns the next points within each iterator. If the iterators are
 // uneven, it organizes them so only matching points are returned.
-func (itr *floatBooleanExprIterator) next() (a, b *FloatPoint, err error) {
+func (itr *floatStringExprIterator) next() (a, b *FloatPoint, err error) {
 	// Retrieve the next value for both the left and right.
 	a, err = itr.left.Next()
 	if err != nil {
@@ -2663,1277 +2667,6252 @@ func (itr *floatBooleanExprIterator) next() (a, b *FloatPoint, err error) {
 	return a, b, nil
 }
 
-// floatBooleanExprFunc creates or modifies a point by combining two
+// floatStringExprFunc creates or modifies a point by combining two
 // points. The point passed in may be modified and returned rather than
 // allocating a new point if possible. One of the points may be nil, but at
 // least one of the points will be non-nil.
-type floatBooleanExprFunc func(a, b float64) bool
+type floatStringExprFunc func(a, b float64) string
 
-// floatTransformIterator executes a function to modify an existing point for every
-// output of the input iterator.
-type floatTransformIterator struct {
-	input FloatIterator
-	fn    floatTransformFunc
+// floatReduceBooleanIterator executes a reducer for every interval and buffers the result.
+type floatReduceBooleanIterator struct {
+	input  *bufFloatIterator
+	create func() (FloatPointAggregator, BooleanPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	points []BooleanPoint
 }
 
-// Stats returns stats from the input iterator.
-func (itr *floatTransformIterator) Stats() IteratorStats { return itr.input.Stats() }
-
-// Close closes the iterator and all child iterators.
-func (itr *floatTransformIterator) Close() error { return itr.input.Close() }
-
-// Next returns the minimum value for the next available interval.
-func (itr *floatTransformIterator) Next() (*FloatPoint, error) {
-	p, err := itr.input.Next()
-	if err != nil {
-		return nil, err
-	} else if p != nil {
-		p = itr.fn(p)
+func newFloatReduceBooleanIterator(input FloatIterator, opt IteratorOptions, createFn func() (FloatPointAggregator, BooleanPointEmitter)) *floatReduceBooleanIterator {
+	return &floatReduceBooleanIterator{
+		input:  newBufFloatIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
 	}
-	return p, nil
-}
-
-// floatTransformFunc creates or modifies a point.
-// The point passed in may be modified and returned rather than allocating a
-// new point if possible.
-type floatTransformFunc func(p *FloatPoint) *FloatPoint
-
-// floatBoolTransformIterator executes a function to modify an existing point for every
-// output of the input iterator.
-type floatBoolTransformIterator struct {
-	input FloatIterator
-	fn    floatBoolTransformFunc
 }
 
 // Stats returns stats from the input iterator.
-func (itr *floatBoolTransformIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *floatReduceBooleanIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *floatBoolTransformIterator) Close() error { return itr.input.Close() }
+func (itr *floatReduceBooleanIterator) Close() error { return itr.input.Close() }
 
 // Next returns the minimum value for the next available interval.
-func (itr *floatBoolTransformIterator) Next() (*BooleanPoint, error) {
-	p, err := itr.input.Next()
-	if err != nil {
-		return nil, err
-	} else if p != nil {
-		return itr.fn(p), nil
+func (itr *floatReduceBooleanIterator) Next() (*BooleanPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
+		}
 	}
-	return nil, nil
-}
-
-// floatBoolTransformFunc creates or modifies a point.
-// The point passed in may be modified and returned rather than allocating a
-// new point if possible.
-type floatBoolTransformFunc func(p *FloatPoint) *BooleanPoint
 
-// floatDedupeIterator only outputs unique points.
-// This differs from the DistinctIterator in that it compares all aux fields too.
-// This iterator is relatively inefficient and should only be used on small
-// datasets such as meta query results.
-type floatDedupeIterator struct {
-	input FloatIterator
-	m     map[string]struct{} // lookup of points already sent
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
 }
 
-type floatIteratorMapper struct {
-	e      *Emitter
-	buf    []interface{}
-	driver IteratorMap   // which iterator to use for the primary value, can be nil
-	fields []IteratorMap // which iterator to use for an aux field
-	point  FloatPoint
+// floatReduceBooleanPoint stores the reduced data for a name/tag combination.
+type floatReduceBooleanPoint struct {
+	Name       string
+	Tags       Tags
+	Aggregator FloatPointAggregator
+	Emitter    BooleanPointEmitter
 }
 
-func newFloatIteratorMapper(itrs []Iterator, driver IteratorMap, fields []IteratorMap, opt IteratorOptions) *floatIteratorMapper {
-	e := NewEmitter(itrs, opt.Ascending, 0)
-	e.OmitTime = true
-	return &floatIteratorMapper{
-		e:      e,
-		buf:    make([]interface{}, len(itrs)),
-		driver: driver,
-		fields: fields,
-		point: FloatPoint{
-			Aux: make([]interface{}, len(fields)),
-		},
-	}
-}
+// reduce executes fn once for every point in the next window.
+// The previous value for the dimension is passed to fn.
+func (itr *floatReduceBooleanIterator) reduce() ([]BooleanPoint, error) {
+	// Calculate next window.
+	var (
+		startTime, endTime int64
+		window             struct {
+			name string
+			tags string
+		}
+	)
+	for {
+		p, err := itr.input.Next()
+		if err != nil || p == nil {
+			return nil, err
+		} else if p.Nil {
+			continue
+		}
 
-func (itr *floatIteratorMapper) Next() (*FloatPoint, error) {
-	t, name, tags, err := itr.e.loadBuf()
-	if err != nil || t == ZeroTime {
-		return nil, err
+		// Unread the point so it can be processed.
+		itr.input.unread(p)
+		startTime, endTime = itr.opt.Window(p.Time)
+		window.name, window.tags = p.Name, p.Tags.Subset(itr.opt.Dimensions).ID()
+		break
 	}
-	itr.point.Time = t
-	itr.point.Name = name
-	itr.point.Tags = tags
 
-	itr.e.readInto(t, name, tags, itr.buf)
-	if itr.driver != nil {
-		if v := itr.driver.Value(tags, itr.buf); v != nil {
-			if v, ok := v.(float64); ok {
-				itr.point.Value = v
-				itr.point.Nil = false
-			} else {
-				itr.point.Value = 0
-				itr.point.Nil = true
-			}
-		} else {
-			itr.point.Value = 0
-			itr.point.Nil = true
+	// Create points by tags.
+	m := make(map[string]*floatReduceBooleanPoint)
+	for {
+		// Read next point.
+		curr, err := itr.input.NextInWindow(startTime, endTime)
+		if err != nil {
+			return nil, err
+		} else if curr == nil {
+			break
+		} else if curr.Nil {
+			continue
+		} else if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
 		}
-	}
-	for i, f := range itr.fields {
-		itr.point.Aux[i] = f.Value(tags, itr.buf)
-	}
-	return &itr.point, nil
-}
-
-func (itr *floatIteratorMapper) Stats() IteratorStats {
-	stats := IteratorStats{}
-	for _, itr := range itr.e.itrs {
-		stats.Add(itr.Stats())
-	}
-	return stats
-}
 
-func (itr *floatIteratorMapper) Close() error {
-	return itr.e.Close()
-}
+		// Ensure this point is within the same final window.
+		if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		} else if tags := curr.Tags.Subset(itr.opt.Dimensions); tags.ID() != window.tags {
+			itr.input.unread(curr)
+			break
+		}
 
-type floatFilterIterator struct {
-	input FloatIterator
-	cond  Expr
-	opt   IteratorOptions
-	m     map[string]interface{}
-}
+		// Retrieve the tags on this point for this level of the query.
+		// This may be different than the bucket dimensions.
+		tags := curr.Tags.Subset(itr.dims)
+		id := tags.ID()
 
-func newFloatFilterIterator(input FloatIterator, cond Expr, opt IteratorOptions) FloatIterator {
-	// Strip out time conditions from the WHERE clause.
-	// TODO(jsternberg): This should really be done for us when creating the IteratorOptions struct.
-	n := RewriteFunc(CloneExpr(cond), func(n Node) Node {
-		switch n := n.(type) {
-		case *BinaryExpr:
-			if n.LHS.String() == "time" {
-				return &BooleanLiteral{Val: true}
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &floatReduceBooleanPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
 			}
+			m[id] = rp
 		}
-		return n
-	})
-
-	cond, _ = n.(Expr)
-	if cond == nil {
-		return input
-	} else if n, ok := cond.(*BooleanLiteral); ok && n.Val {
-		return input
+		rp.Aggregator.AggregateFloat(curr)
 	}
 
-	return &floatFilterIterator{
-		input: input,
-		cond:  cond,
-		opt:   opt,
-		m:     make(map[string]interface{}),
+	// Reverse sort points by name & tag if our output is supposed to be ordered.
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	if len(keys) > 1 && itr.opt.Ordered {
+		sort.Sort(reverseStringSlice(keys))
 	}
-}
-
-func (itr *floatFilterIterator) Stats() IteratorStats { return itr.input.Stats() }
-func (itr *floatFilterIterator) Close() error         { return itr.input.Close() }
-
-func (itr *floatFilterIterator) Next() (*FloatPoint, error) {
-	for {
-		p, err := itr.input.Next()
-		if err != nil || p == nil {
-			return nil, err
-		}
 
-		for i, ref := range itr.opt.Aux {
-			itr.m[ref.Val] = p.Aux[i]
-		}
-		for k, v := range p.Tags.KeyValues() {
-			itr.m[k] = v
+	// Assume the points are already sorted until proven otherwise.
+	sortedByTime := true
+	// Emit the points for each name & tag combination.
+	a := make([]BooleanPoint, 0, len(m))
+	for _, k := range keys {
+		rp := m[k]
+		points := rp.Emitter.Emit()
+		for i := len(points) - 1; i >= 0; i-- {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+			// Set the points time to the interval time if the reducer didn't provide one.
+			if points[i].Time == ZeroTime {
+				points[i].Time = startTime
+			} else {
+				sortedByTime = false
+			}
+			a = append(a, points[i])
 		}
+	}
 
-		if !EvalBool(itr.cond, itr.m) {
-			continue
-		}
-		return p, nil
+	// Points may be out of order. Perform a stable sort by time if requested.
+	if !sortedByTime && itr.opt.Ordered {
+		sort.Stable(sort.Reverse(booleanPointsByTime(a)))
 	}
+
+	return a, nil
 }
 
-// newFloatDedupeIterator returns a new instance of floatDedupeIterator.
-func newFloatDedupeIterator(input FloatIterator) *floatDedupeIterator {
-	return &floatDedupeIterator{
-		input: input,
-		m:     make(map[string]struct{}),
+// floatStreamBooleanIterator streams inputs into the iterator and emits points gradually.
+type floatStreamBooleanIterator struct {
+	input  *bufFloatIterator
+	create func() (FloatPointAggregator, BooleanPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	m      map[string]*floatReduceBooleanPoint
+	points []BooleanPoint
+}
+
+// newFloatStreamBooleanIterator returns a new instance of floatStreamBooleanIterator.
+func newFloatStreamBooleanIterator(input FloatIterator, createFn func() (FloatPointAggregator, BooleanPointEmitter), opt IteratorOptions) *floatStreamBooleanIterator {
+	return &floatStreamBooleanIterator{
+		input:  newBufFloatIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+		m:      make(map[string]*floatReduceBooleanPoint),
 	}
 }
 
 // Stats returns stats from the input iterator.
-func (itr *floatDedupeIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *floatStreamBooleanIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *floatDedupeIterator) Close() error { return itr.input.Close() }
-
-// Next returns the next unique point from the input iterator.
-func (itr *floatDedupeIterator) Next() (*FloatPoint, error) {
-	for {
-		// Read next point.
-		p, err := itr.input.Next()
-		if p == nil || err != nil {
-			return nil, err
-		}
+func (itr *floatStreamBooleanIterator) Close() error { return itr.input.Close() }
 
-		// Serialize to bytes to store in lookup.
-		buf, err := proto.Marshal(encodeFloatPoint(p))
-		if err != nil {
+// Next returns the next value for the stream iterator.
+func (itr *floatStreamBooleanIterator) Next() (*BooleanPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
 			return nil, err
 		}
-
-		// If the point has already been output then move to the next point.
-		if _, ok := itr.m[string(buf)]; ok {
-			continue
-		}
-
-		// Otherwise mark it as emitted and return point.
-		itr.m[string(buf)] = struct{}{}
-		return p, nil
 	}
-}
 
-// floatReaderIterator represents an iterator that streams from a reader.
-type floatReaderIterator struct {
-	r   io.Reader
-	dec *FloatPointDecoder
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
 }
 
-// newFloatReaderIterator returns a new instance of floatReaderIterator.
-func newFloatReaderIterator(r io.Reader, stats IteratorStats) *floatReaderIterator {
-	dec := NewFloatPointDecoder(r)
-	dec.stats = stats
+// reduce creates and manages aggregators for every point from the input.
+// After aggregating a point, it always tries to emit a value using the emitter.
+func (itr *floatStreamBooleanIterator) reduce() ([]BooleanPoint, error) {
+	for {
+		// Read next point.
+		curr, err := itr.input.Next()
+		if curr == nil {
+			// Close all of the aggregators to flush any remaining points to emit.
+			var points []BooleanPoint
+			for _, rp := range itr.m {
+				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
+					if err := aggregator.Close(); err != nil {
+						return nil, err
+					}
 
-	return &floatReaderIterator{
-		r:   r,
-		dec: dec,
-	}
-}
+					pts := rp.Emitter.Emit()
+					if len(pts) == 0 {
+						continue
+					}
 
-// Stats returns stats about points processed.
-func (itr *floatReaderIterator) Stats() IteratorStats { return itr.dec.stats }
+					for i := range pts {
+						pts[i].Name = rp.Name
+						pts[i].Tags = rp.Tags
+					}
+					points = append(points, pts...)
+				}
+			}
+
+			// Eliminate the aggregators and emitters.
+			itr.m = nil
+			return points, nil
+		} else if err != nil {
+			return nil, err
+		} else if curr.Nil {
+			continue
+		}
+		tags := curr.Tags.Subset(itr.dims)
+
+		id := curr.Name
+		if len(tags.m) > 0 {
+			id += "\x00" + tags.ID()
+		}
+
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := itr.m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &floatReduceBooleanPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			itr.m[id] = rp
+		}
+		rp.Aggregator.AggregateFloat(curr)
+
+		// Attempt to emit points from the aggregator.
+		points := rp.Emitter.Emit()
+		if len(points) == 0 {
+			continue
+		}
+
+		for i := range points {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+		}
+		return points, nil
+	}
+}
+
+// floatBooleanExprIterator executes a function to modify an existing point
+// for every output of the input iterator.
+type floatBooleanExprIterator struct {
+	left      *bufFloatIterator
+	right     *bufFloatIterator
+	fn        floatBooleanExprFunc
+	points    []FloatPoint // must be size 2
+	storePrev bool
+}
+
+func newFloatBooleanExprIterator(left, right FloatIterator, opt IteratorOptions, fn func(a, b float64) bool) *floatBooleanExprIterator {
+	var points []FloatPoint
+	switch opt.Fill {
+	case NullFill, PreviousFill:
+		points = []FloatPoint{{Nil: true}, {Nil: true}}
+	case NumberFill:
+		value := castToFloat(opt.FillValue)
+		points = []FloatPoint{{Value: value}, {Value: value}}
+	}
+	return &floatBooleanExprIterator{
+		left:      newBufFloatIterator(left),
+		right:     newBufFloatIterator(right),
+		points:    points,
+		fn:        fn,
+		storePrev: opt.Fill == PreviousFill,
+	}
+}
+
+func (itr *floatBooleanExprIterator) Stats() IteratorStats {
+	stats := itr.left.Stats()
+	stats.Add(itr.right.Stats())
+	return stats
+}
+
+func (itr *floatBooleanExprIterator) Close() error {
+	itr.left.Close()
+	itr.right.Close()
+	return nil
+}
+
+func (itr *floatBooleanExprIterator) Next() (*BooleanPoint, error) {
+	for {
+		a, b, err := itr.next()
+		if err != nil || (a == nil && b == nil) {
+			return nil, err
+		}
+
+		// If any of these are nil and we are using fill(none), skip these points.
+		if (a == nil || a.Nil || b == nil || b.Nil) && itr.points == nil {
+			continue
+		}
+
+		// If one of the two points is nil, we need to fill it with a fake nil
+		// point that has the same name, tags, and time as the other point.
+		// There should never be a time when both of these are nil.
+		if a == nil {
+			p := *b
+			a = &p
+			a.Value = 0
+			a.Nil = true
+		} else if b == nil {
+			p := *a
+			b = &p
+			b.Value = 0
+			b.Nil = true
+		}
+
+		// If a value is nil, use the fill values if the fill value is non-nil.
+		if a.Nil && !itr.points[0].Nil {
+			a.Value = itr.points[0].Value
+			a.Nil = false
+		}
+		if b.Nil && !itr.points[1].Nil {
+			b.Value = itr.points[1].Value
+			b.Nil = false
+		}
+
+		if itr.storePrev {
+			itr.points[0], itr.points[1] = *a, *b
+		}
+
+		p := &BooleanPoint{
+			Name:       a.Name,
+			Tags:       a.Tags,
+			Time:       a.Time,
+			Nil:        a.Nil || b.Nil,
+			Aggregated: a.Aggregated,
+		}
+		if !p.Nil {
+			p.Value = itr.fn(a.Value, b.Value)
+		}
+		return p, nil
+
+	}
+}
+
+// next returns the next points within each iterator. If the iterators are
+// uneven, it organizes them so only matching points are returned.
+func (itr *floatBooleanExprIterator) next() (a, b *FloatPoint, err error) {
+	// Retrieve the next value for both the left and right.
+	a, err = itr.left.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err = itr.right.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If we have a point from both, make sure that they match each other.
+	if a != nil && b != nil {
+		if a.Name > b.Name {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Name < b.Name {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+
+		if ltags, rtags := a.Tags.ID(), b.Tags.ID(); ltags > rtags {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if ltags < rtags {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+
+		if a.Time > b.Time {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Time < b.Time {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+	}
+	return a, b, nil
+}
+
+// floatBooleanExprFunc creates or modifies a point by combining two
+// points. The point passed in may be modified and returned rather than
+// allocating a new point if possible. One of the points may be nil, but at
+// least one of the points will be non-nil.
+type floatBooleanExprFunc func(a, b float64) bool
+
+// floatTransformIterator executes a function to modify an existing point for every
+// output of the input iterator.
+type floatTransformIterator struct {
+	input FloatIterator
+	fn    floatTransformFunc
+}
+
+// Stats returns stats from the input iterator.
+func (itr *floatTransformIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *floatTransformIterator) Close() error { return itr.input.Close() }
+
+// Next returns the minimum value for the next available interval.
+func (itr *floatTransformIterator) Next() (*FloatPoint, error) {
+	p, err := itr.input.Next()
+	if err != nil {
+		return nil, err
+	} else if p != nil {
+		p = itr.fn(p)
+	}
+	return p, nil
+}
+
+// floatTransformFunc creates or modifies a point.
+// The point passed in may be modified and returned rather than allocating a
+// new point if possible.
+type floatTransformFunc func(p *FloatPoint) *FloatPoint
+
+// floatBoolTransformIterator executes a function to modify an existing point for every
+// output of the input iterator.
+type floatBoolTransformIterator struct {
+	input FloatIterator
+	fn    floatBoolTransformFunc
+}
+
+// Stats returns stats from the input iterator.
+func (itr *floatBoolTransformIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *floatBoolTransformIterator) Close() error { return itr.input.Close() }
+
+// Next returns the minimum value for the next available interval.
+func (itr *floatBoolTransformIterator) Next() (*BooleanPoint, error) {
+	p, err := itr.input.Next()
+	if err != nil {
+		return nil, err
+	} else if p != nil {
+		return itr.fn(p), nil
+	}
+	return nil, nil
+}
+
+// floatBoolTransformFunc creates or modifies a point.
+// The point passed in may be modified and returned rather than allocating a
+// new point if possible.
+type floatBoolTransformFunc func(p *FloatPoint) *BooleanPoint
+
+// floatDedupeIterator only outputs unique points.
+// This differs from the DistinctIterator in that it compares all aux fields too.
+// This iterator is relatively inefficient and should only be used on small
+// datasets such as meta query results.
+type floatDedupeIterator struct {
+	input FloatIterator
+	m     map[string]struct{} // lookup of points already sent
+}
+
+type floatIteratorMapper struct {
+	e      *Emitter
+	buf    []interface{}
+	driver IteratorMap   // which iterator to use for the primary value, can be nil
+	fields []IteratorMap // which iterator to use for an aux field
+	point  FloatPoint
+}
+
+func newFloatIteratorMapper(itrs []Iterator, driver IteratorMap, fields []IteratorMap, opt IteratorOptions) *floatIteratorMapper {
+	e := NewEmitter(itrs, opt.Ascending, 0)
+	e.OmitTime = true
+	return &floatIteratorMapper{
+		e:      e,
+		buf:    make([]interface{}, len(itrs)),
+		driver: driver,
+		fields: fields,
+		point: FloatPoint{
+			Aux: make([]interface{}, len(fields)),
+		},
+	}
+}
+
+func (itr *floatIteratorMapper) Next() (*FloatPoint, error) {
+	t, name, tags, err := itr.e.loadBuf()
+	if err != nil || t == ZeroTime {
+		return nil, err
+	}
+	itr.point.Time = t
+	itr.point.Name = name
+	itr.point.Tags = tags
+
+	itr.e.readInto(t, name, tags, itr.buf)
+	if itr.driver != nil {
+		if v := itr.driver.Value(tags, itr.buf); v != nil {
+			if v, ok := v.(float64); ok {
+				itr.point.Value = v
+				itr.point.Nil = false
+			} else {
+				itr.point.Value = 0
+				itr.point.Nil = true
+			}
+		} else {
+			itr.point.Value = 0
+			itr.point.Nil = true
+		}
+	}
+	for i, f := range itr.fields {
+		itr.point.Aux[i] = f.Value(tags, itr.buf)
+	}
+	return &itr.point, nil
+}
+
+func (itr *floatIteratorMapper) Stats() IteratorStats {
+	stats := IteratorStats{}
+	for _, itr := range itr.e.itrs {
+		stats.Add(itr.Stats())
+	}
+	return stats
+}
+
+func (itr *floatIteratorMapper) Close() error {
+	return itr.e.Close()
+}
+
+type floatFilterIterator struct {
+	input FloatIterator
+	cond  Expr
+	opt   IteratorOptions
+	m     map[string]interface{}
+}
+
+func newFloatFilterIterator(input FloatIterator, cond Expr, opt IteratorOptions) FloatIterator {
+	// Strip out time conditions from the WHERE clause.
+	// TODO(jsternberg): This should really be done for us when creating the IteratorOptions struct.
+	n := RewriteFunc(CloneExpr(cond), func(n Node) Node {
+		switch n := n.(type) {
+		case *BinaryExpr:
+			if n.LHS.String() == "time" {
+				return &BooleanLiteral{Val: true}
+			}
+		}
+		return n
+	})
+
+	cond, _ = n.(Expr)
+	if cond == nil {
+		return input
+	} else if n, ok := cond.(*BooleanLiteral); ok && n.Val {
+		return input
+	}
+
+	return &floatFilterIterator{
+		input: input,
+		cond:  cond,
+		opt:   opt,
+		m:     make(map[string]interface{}),
+	}
+}
+
+func (itr *floatFilterIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *floatFilterIterator) Close() error         { return itr.input.Close() }
+
+func (itr *floatFilterIterator) Next() (*FloatPoint, error) {
+	for {
+		p, err := itr.input.Next()
+		if err != nil || p == nil {
+			return nil, err
+		}
+
+		for i, ref := range itr.opt.Aux {
+			itr.m[ref.Val] = p.Aux[i]
+		}
+		for k, v := range p.Tags.KeyValues() {
+			itr.m[k] = v
+		}
+
+		if !EvalBool(itr.cond, itr.m) {
+			continue
+		}
+		return p, nil
+	}
+}
+
+// newFloatDedupeIterator returns a new instance of floatDedupeIterator.
+func newFloatDedupeIterator(input FloatIterator) *floatDedupeIterator {
+	return &floatDedupeIterator{
+		input: input,
+		m:     make(map[string]struct{}),
+	}
+}
+
+// Stats returns stats from the input iterator.
+func (itr *floatDedupeIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *floatDedupeIterator) Close() error { return itr.input.Close() }
+
+// Next returns the next unique point from the input iterator.
+func (itr *floatDedupeIterator) Next() (*FloatPoint, error) {
+	for {
+		// Read next point.
+		p, err := itr.input.Next()
+		if p == nil || err != nil {
+			return nil, err
+		}
+
+		// Serialize to bytes to store in lookup.
+		buf, err := proto.Marshal(encodeFloatPoint(p))
+		if err != nil {
+			return nil, err
+		}
+
+		// If the point has already been output then move to the next point.
+		if _, ok := itr.m[string(buf)]; ok {
+			continue
+		}
+
+		// Otherwise mark it as emitted and return point.
+		itr.m[string(buf)] = struct{}{}
+		return p, nil
+	}
+}
+
+// floatReaderIterator represents an iterator that streams from a reader.
+type floatReaderIterator struct {
+	r   io.Reader
+	dec *FloatPointDecoder
+}
+
+// newFloatReaderIterator returns a new instance of floatReaderIterator.
+func newFloatReaderIterator(r io.Reader, stats IteratorStats) *floatReaderIterator {
+	dec := NewFloatPointDecoder(r)
+	dec.stats = stats
+
+	return &floatReaderIterator{
+		r:   r,
+		dec: dec,
+	}
+}
+
+// Stats returns stats about points processed.
+func (itr *floatReaderIterator) Stats() IteratorStats { return itr.dec.stats }
+
+// Close closes the underlying reader, if applicable.
+func (itr *floatReaderIterator) Close() error {
+	if r, ok := itr.r.(io.ReadCloser); ok {
+		return r.Close()
+	}
+	return nil
+}
+
+// Next returns the next point from the iterator.
+func (itr *floatReaderIterator) Next() (*FloatPoint, error) {
+	// OPTIMIZE(benbjohnson): Reuse point on iterator.
+
+	// Unmarshal next point.
+	p := &FloatPoint{}
+	if err := itr.dec.DecodeFloatPoint(p); err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// IntegerIterator represents a stream of integer points.
+type IntegerIterator interface {
+	Iterator
+	Next() (*IntegerPoint, error)
+}
+
+// newIntegerIterators converts a slice of Iterator to a slice of IntegerIterator.
+// Drop and closes any iterator in itrs that is not a IntegerIterator and cannot
+// be cast to a IntegerIterator.
+func newIntegerIterators(itrs []Iterator) []IntegerIterator {
+	a := make([]IntegerIterator, 0, len(itrs))
+	for _, itr := range itrs {
+		switch itr := itr.(type) {
+		case IntegerIterator:
+			a = append(a, itr)
+
+		default:
+			itr.Close()
+		}
+	}
+	return a
+}
+
+// bufIntegerIterator represents a buffered IntegerIterator.
+type bufIntegerIterator struct {
+	itr IntegerIterator
+	buf *IntegerPoint
+}
+
+// newBufIntegerIterator returns a buffered IntegerIterator.
+func newBufIntegerIterator(itr IntegerIterator) *bufIntegerIterator {
+	return &bufIntegerIterator{itr: itr}
+}
+
+// Stats returns statistics from the input iterator.
+func (itr *bufIntegerIterator) Stats() IteratorStats { return itr.itr.Stats() }
+
+// Close closes the underlying iterator.
+func (itr *bufIntegerIterator) Close() error { return itr.itr.Close() }
+
+// peek returns the next point without removing it from the iterator.
+func (itr *bufIntegerIterator) peek() (*IntegerPoint, error) {
+	p, err := itr.Next()
+	if err != nil {
+		return nil, err
+	}
+	itr.unread(p)
+	return p, nil
+}
+
+// peekTime returns the time of the next point.
+// Returns zero time if no more points available.
+func (itr *bufIntegerIterator) peekTime() (int64, error) {
+	p, err := itr.peek()
+	if p == nil || err != nil {
+		return ZeroTime, err
+	}
+	return p.Time, nil
+}
+
+// Next returns the current buffer, if exists, or calls the underlying iterator.
+func (itr *bufIntegerIterator) Next() (*IntegerPoint, error) {
+	buf := itr.buf
+	if buf != nil {
+		itr.buf = nil
+		return buf, nil
+	}
+	return itr.itr.Next()
+}
+
+// NextInWindow returns the next value if it is between [startTime, endTime).
+// If the next value is outside the range then it is moved to the buffer.
+func (itr *bufIntegerIterator) NextInWindow(startTime, endTime int64) (*IntegerPoint, error) {
+	v, err := itr.Next()
+	if v == nil || err != nil {
+		return nil, err
+	} else if t := v.Time; t >= endTime || t < startTime {
+		itr.unread(v)
+		return nil, nil
+	}
+	return v, nil
+}
+
+// unread sets v to the buffer. It is read on the next call to Next().
+func (itr *bufIntegerIterator) unread(v *IntegerPoint) { itr.buf = v }
+
+// integerMergeIterator represents an iterator that combines multiple integer iterators.
+type integerMergeIterator struct {
+	inputs []IntegerIterator
+	heap   *integerMergeHeap
+	init   bool
+
+	// Current iterator and window.
+	curr   *integerMergeHeapItem
+	window struct {
+		name      string
+		tags      string
+		startTime int64
+		endTime   int64
+	}
+}
+
+// newIntegerMergeIterator returns a new instance of integerMergeIterator.
+func newIntegerMergeIterator(inputs []IntegerIterator, opt IteratorOptions) *integerMergeIterator {
+	itr := &integerMergeIterator{
+		inputs: inputs,
+		heap: &integerMergeHeap{
+			items: make([]*integerMergeHeapItem, 0, len(inputs)),
+			opt:   opt,
+		},
+	}
+
+	// Initialize heap items.
+	for _, input := range inputs {
+		// Wrap in buffer, ignore any inputs without anymore points.
+		bufInput := newBufIntegerIterator(input)
+
+		// Append to the heap.
+		itr.heap.items = append(itr.heap.items, &integerMergeHeapItem{itr: bufInput})
+	}
+
+	return itr
+}
+
+// Stats returns an aggregation of stats from the underlying iterators.
+func (itr *integerMergeIterator) Stats() IteratorStats {
+	var stats IteratorStats
+	for _, input := range itr.inputs {
+		stats.Add(input.Stats())
+	}
+	return stats
+}
+
+// Close closes the underlying iterators.
+func (itr *integerMergeIterator) Close() error {
+	for _, input := range itr.inputs {
+		input.Close()
+	}
+	itr.curr = nil
+	itr.inputs = nil
+	itr.heap.items = nil
+	return nil
+}
+
+// Next returns the next point from the iterator.
+func (itr *integerMergeIterator) Next() (*IntegerPoint, error) {
+	// Initialize the heap. This needs to be done lazily on the first call to this iterator
+	// so that iterator initialization done through the Select() call returns quickly.
+	// Queries can only be interrupted after the Select() call completes so any operations
+	// done during iterator creation cannot be interrupted, which is why we do it here
+	// instead so an interrupt can happen while initializing the heap.
+	if !itr.init {
+		items := itr.heap.items
+		itr.heap.items = make([]*integerMergeHeapItem, 0, len(items))
+		for _, item := range items {
+			if p, err := item.itr.peek(); err != nil {
+				return nil, err
+			} else if p == nil {
+				continue
+			}
+			itr.heap.items = append(itr.heap.items, item)
+		}
+		heap.Init(itr.heap)
+		itr.init = true
+	}
+
+	for {
+		// Retrieve the next iterator if we don't have one.
+		if itr.curr == nil {
+			if len(itr.heap.items) == 0 {
+				return nil, nil
+			}
+			itr.curr = heap.Pop(itr.heap).(*integerMergeHeapItem)
+
+			// Read point and set current window.
+			p, err := itr.curr.itr.Next()
+			if err != nil {
+				return nil, err
+			}
+			tags := p.Tags.Subset(itr.heap.opt.Dimensions)
+			itr.window.name, itr.window.tags = p.Name, tags.ID()
+			itr.window.startTime, itr.window.endTime = itr.heap.opt.Window(p.Time)
+			return p, nil
+		}
+
+		// Read the next point from the current iterator.
+		p, err := itr.curr.itr.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		// If there are no more points then remove iterator from heap and find next.
+		if p == nil {
+			itr.curr = nil
+			continue
+		}
+
+		// Check if the point is inside of our current window.
+		inWindow := true
+		if window := itr.window; window.name != p.Name {
+			inWindow = false
+		} else if tags := p.Tags.Subset(itr.heap.opt.Dimensions); window.tags != tags.ID() {
+			inWindow = false
+		} else if opt := itr.heap.opt; opt.Ascending && p.Time >= window.endTime {
+			inWindow = false
+		} else if !opt.Ascending && p.Time < window.startTime {
+			inWindow = false
+		}
+
+		// If it's outside our window then push iterator back on the heap and find new iterator.
+		if !inWindow {
+			itr.curr.itr.unread(p)
+			heap.Push(itr.heap, itr.curr)
+			itr.curr = nil
+			continue
+		}
+
+		return p, nil
+	}
+}
+
+// integerMergeHeap represents a heap of integerMergeHeapItems.
+// Items are sorted by their next window and then by name/tags.
+type integerMergeHeap struct {
+	opt   IteratorOptions
+	items []*integerMergeHeapItem
+}
+
+func (h *integerMergeHeap) Len() int      { return len(h.items) }
+func (h *integerMergeHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *integerMergeHeap) Less(i, j int) bool {
+	x, err := h.items[i].itr.peek()
+	if err != nil {
+		return true
+	}
+	y, err := h.items[j].itr.peek()
+	if err != nil {
+		return false
+	}
+
+	if h.opt.Ascending {
+		if x.Name != y.Name {
+			return x.Name < y.Name
+		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); xTags.ID() != yTags.ID() {
+			return xTags.ID() < yTags.ID()
+		}
+	} else {
+		if x.Name != y.Name {
+			return x.Name > y.Name
+		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); xTags.ID() != yTags.ID() {
+			return xTags.ID() > yTags.ID()
+		}
+	}
+
+	xt, _ := h.opt.Window(x.Time)
+	yt, _ := h.opt.Window(y.Time)
+
+	if h.opt.Ascending {
+		return xt < yt
+	}
+	return xt > yt
+}
+
+func (h *integerMergeHeap) Push(x interface{}) {
+	h.items = append(h.items, x.(*integerMergeHeapItem))
+}
+
+func (h *integerMergeHeap) Pop() interface{} {
+	old := h.items
+	n := len(old)
+	item := old[n-1]
+	h.items = old[0 : n-1]
+	return item
+}
+
+type integerMergeHeapItem struct {
+	itr *bufIntegerIterator
+}
+
+// integerSortedMergeIterator is an iterator that sorts and merges multiple iterators into one.
+type integerSortedMergeIterator struct {
+	inputs []IntegerIterator
+	heap   *integerSortedMergeHeap
+	init   bool
+}
+
+// newIntegerSortedMergeIterator returns an instance of integerSortedMergeIterator.
+func newIntegerSortedMergeIterator(inputs []IntegerIterator, opt IteratorOptions) Iterator {
+	itr := &integerSortedMergeIterator{
+		inputs: inputs,
+		heap: &integerSortedMergeHeap{
+			items: make([]*integerSortedMergeHeapItem, 0, len(inputs)),
+			opt:   opt,
+		},
+	}
+
+	// Initialize heap items.
+	for _, input := range inputs {
+		// Append to the heap.
+		itr.heap.items = append(itr.heap.items, &integerSortedMergeHeapItem{itr: input})
+	}
+
+	return itr
+}
+
+// Stats returns an aggregation of stats from the underlying iterators.
+func (itr *integerSortedMergeIterator) Stats() IteratorStats {
+	var stats IteratorStats
+	for _, input := range itr.inputs {
+		stats.Add(input.Stats())
+	}
+	return stats
+}
+
+// Close closes the underlying iterators.
+func (itr *integerSortedMergeIterator) Close() error {
+	for _, input := range itr.inputs {
+		input.Close()
+	}
+	return nil
+}
+
+// Next returns the next points from the iterator.
+func (itr *integerSortedMergeIterator) Next() (*IntegerPoint, error) { return itr.pop() }
+
+// pop returns the next point from the heap.
+// Reads the next point from item's cursor and puts it back on the heap.
+func (itr *integerSortedMergeIterator) pop() (*IntegerPoint, error) {
+	// Initialize the heap. See the MergeIterator to see why this has to be done lazily.
+	if !itr.init {
+		items := itr.heap.items
+		itr.heap.items = make([]*integerSortedMergeHeapItem, 0, len(items))
+		for _, item := range items {
+			var err error
+			if item.point, err = item.itr.Next(); err != nil {
+				return nil, err
+			} else if item.point == nil {
+				continue
+			}
+			itr.heap.items = append(itr.heap.items, item)
+		}
+		heap.Init(itr.heap)
+		itr.init = true
+	}
+
+	if len(itr.heap.items) == 0 {
+		return nil, nil
+	}
+
+	// Read the next item from the heap.
+	item := heap.Pop(itr.heap).(*integerSortedMergeHeapItem)
+	if item.err != nil {
+		return nil, item.err
+	} else if item.point == nil {
+		return nil, nil
+	}
+
+	// Copy the point for return.
+	p := item.point.Clone()
+
+	// Read the next item from the cursor. Push back to heap if one exists.
+	if item.point, item.err = item.itr.Next(); item.point != nil {
+		heap.Push(itr.heap, item)
+	}
+
+	return p, nil
+}
+
+// integerSortedMergeHeap represents a heap of integerSortedMergeHeapItems.
+type integerSortedMergeHeap struct {
+	opt   IteratorOptions
+	items []*integerSortedMergeHeapItem
+}
+
+func (h *integerSortedMergeHeap) Len() int      { return len(h.items) }
+func (h *integerSortedMergeHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *integerSortedMergeHeap) Less(i, j int) bool {
+	x, y := h.items[i].point, h.items[j].point
+
+	if h.opt.Ascending {
+		if x.Name != y.Name {
+			return x.Name < y.Name
+		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); !xTags.Equals(&yTags) {
+			return xTags.ID() < yTags.ID()
+		}
+		return x.Time < y.Time
+	}
+
+	if x.Name != y.Name {
+		return x.Name > y.Name
+	} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); !xTags.Equals(&yTags) {
+		return xTags.ID() > yTags.ID()
+	}
+	return x.Time > y.Time
+}
+
+func (h *integerSortedMergeHeap) Push(x interface{}) {
+	h.items = append(h.items, x.(*integerSortedMergeHeapItem))
+}
+
+func (h *integerSortedMergeHeap) Pop() interface{} {
+	old := h.items
+	n := len(old)
+	item := old[n-1]
+	h.items = old[0 : n-1]
+	return item
+}
+
+type integerSortedMergeHeapItem struct {
+	point *IntegerPoint
+	err   error
+	itr   IntegerIterator
+}
+
+// integerParallelIterator represents an iterator that pulls data in a separate goroutine.
+type integerParallelIterator struct {
+	input IntegerIterator
+	ch    chan integerPointError
+
+	once    sync.Once
+	closing chan struct{}
+	wg      sync.WaitGroup
+}
+
+// newIntegerParallelIterator returns a new instance of integerParallelIterator.
+func newIntegerParallelIterator(input IntegerIterator) *integerParallelIterator {
+	itr := &integerParallelIterator{
+		input:   input,
+		ch:      make(chan integerPointError, 256),
+		closing: make(chan struct{}),
+	}
+	itr.wg.Add(1)
+	go itr.monitor()
+	return itr
+}
+
+// Stats returns stats from the underlying iterator.
+func (itr *integerParallelIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the underlying iterators.
+func (itr *integerParallelIterator) Close() error {
+	itr.once.Do(func() { close(itr.closing) })
+	itr.wg.Wait()
+	return itr.input.Close()
+}
+
+// Next returns the next point from the iterator.
+func (itr *integerParallelIterator) Next() (*IntegerPoint, error) {
+	v, ok := <-itr.ch
+	if !ok {
+		return nil, io.EOF
+	}
+	return v.point, v.err
+}
+
+// monitor runs in a separate goroutine and actively pulls the next point.
+func (itr *integerParallelIterator) monitor() {
+	defer close(itr.ch)
+	defer itr.wg.Done()
+
+	for {
+		// Read next point.
+		p, err := itr.input.Next()
+		if p != nil {
+			p = p.Clone()
+		}
+
+		select {
+		case <-itr.closing:
+			return
+		case itr.ch <- integerPointError{point: p, err: err}:
+		}
+	}
+}
+
+type integerPointError struct {
+	point *IntegerPoint
+	err   error
+}
+
+// integerLimitIterator represents an iterator that limits points per group.
+type integerLimitIterator struct {
+	input IntegerIterator
+	opt   IteratorOptions
+	n     int
+
+	prev struct {
+		name string
+		tags Tags
+	}
+}
+
+// newIntegerLimitIterator returns a new instance of integerLimitIterator.
+func newIntegerLimitIterator(input IntegerIterator, opt IteratorOptions) *integerLimitIterator {
+	return &integerLimitIterator{
+		input: input,
+		opt:   opt,
+	}
+}
+
+// Stats returns stats from the underlying iterator.
+func (itr *integerLimitIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the underlying iterators.
+func (itr *integerLimitIterator) Close() error { return itr.input.Close() }
+
+// Next returns the next point from the iterator.
+func (itr *integerLimitIterator) Next() (*IntegerPoint, error) {
+	for {
+		p, err := itr.input.Next()
+		if p == nil || err != nil {
+			return nil, err
+		}
+
+		// Reset window and counter if a new window is encountered.
+		if p.Name != itr.prev.name || !p.Tags.Equals(&itr.prev.tags) {
+			itr.prev.name = p.Name
+			itr.prev.tags = p.Tags
+			itr.n = 0
+		}
+
+		// Increment counter.
+		itr.n++
+
+		// Read next point if not beyond the offset.
+		if itr.n <= itr.opt.Offset {
+			continue
+		}
+
+		// Read next point if we're beyond the limit.
+		if itr.opt.Limit > 0 && (itr.n-itr.opt.Offset) > itr.opt.Limit {
+			continue
+		}
+
+		return p, nil
+	}
+}
+
+type integerFillIterator struct {
+	input     *bufIntegerIterator
+	prev      IntegerPoint
+	startTime int64
+	endTime   int64
+	auxFields []interface{}
+	init      bool
+	opt       IteratorOptions
+
+	window struct {
+		name   string
+		tags   Tags
+		time   int64
+		offset int64
+	}
+}
+
+func newIntegerFillIterator(input IntegerIterator, expr Expr, opt IteratorOptions) *integerFillIterator {
+	if opt.Fill == NullFill {
+		if expr, ok := expr.(*Call); ok && expr.Name == "count" {
+			opt.Fill = NumberFill
+			opt.FillValue = int64(0)
+		}
+	}
+
+	var startTime, endTime int64
+	if opt.Ascending {
+		startTime, _ = opt.Window(opt.StartTime)
+		endTime, _ = opt.Window(opt.EndTime)
+	} else {
+		startTime, _ = opt.Window(opt.EndTime)
+		endTime, _ = opt.Window(opt.StartTime)
+	}
+
+	var auxFields []interface{}
+	if len(opt.Aux) > 0 {
+		auxFields = make([]interface{}, len(opt.Aux))
+	}
+
+	return &integerFillIterator{
+		input:     newBufIntegerIterator(input),
+		prev:      IntegerPoint{Nil: true},
+		startTime: startTime,
+		endTime:   endTime,
+		auxFields: auxFields,
+		opt:       opt,
+	}
+}
+
+func (itr *integerFillIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *integerFillIterator) Close() error         { return itr.input.Close() }
+
+func (itr *integerFillIterator) Next() (*IntegerPoint, error) {
+	if !itr.init {
+		p, err := itr.input.peek()
+		if p == nil || err != nil {
+			return nil, err
+		}
+		itr.window.name, itr.window.tags = p.Name, p.Tags
+		itr.window.time = itr.startTime
+		if itr.opt.Location != nil {
+			_, itr.window.offset = itr.opt.Zone(itr.window.time)
+		}
+		itr.init = true
+	}
+
+	p, err := itr.input.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the next point is outside of our window or is nil.
+	for p == nil || p.Name != itr.window.name || p.Tags.ID() != itr.window.tags.ID() {
+		// If we are inside of an interval, unread the point and continue below to
+		// constructing a new point.
+		if itr.opt.Ascending {
+			if itr.window.time <= itr.endTime {
+				itr.input.unread(p)
+				p = nil
+				break
+			}
+		} else {
+			if itr.window.time >= itr.endTime {
+				itr.input.unread(p)
+				p = nil
+				break
+			}
+		}
+
+		// We are *not* in a current interval. If there is no next point,
+		// we are at the end of all intervals.
+		if p == nil {
+			return nil, nil
+		}
+
+		// Set the new interval.
+		itr.window.name, itr.window.tags = p.Name, p.Tags
+		itr.window.time = itr.startTime
+		if itr.opt.Location != nil {
+			_, itr.window.offset = itr.opt.Zone(itr.window.time)
+		}
+		itr.prev = IntegerPoint{Nil: true}
+		break
+	}
+
+	// Check if the point is our next expected point.
+	if p == nil || (itr.opt.Ascending && p.Time > itr.window.time) || (!itr.opt.Ascending && p.Time < itr.window.time) {
+		if p != nil {
+			itr.input.unread(p)
+		}
+
+		p = &IntegerPoint{
+			Name: itr.window.name,
+			Tags: itr.window.tags,
+			Time: itr.window.time,
+			Aux:  itr.auxFields,
+		}
+
+		switch itr.opt.Fill {
+		case LinearFill:
+			if !itr.prev.Nil {
+				next, err := itr.input.peek()
+				if err != nil {
+					return nil, err
+				} else if next != nil && next.Name == itr.window.name && next.Tags.ID() == itr.window.tags.ID() {
+					interval := int64(itr.opt.Interval.Duration)
+					start := itr.window.time / interval
+					p.Value = linearInteger(start, itr.prev.Time/interval, next.Time/interval, itr.prev.Value, next.Value)
+				} else {
+					p.Nil = true
+				}
+			} else {
+				p.Nil = true
+			}
+
+		case NullFill:
+			p.Nil = true
+		case NumberFill:
+			p.Value = castToInteger(itr.opt.FillValue)
+		case PreviousFill:
+			if !itr.prev.Nil {
+				p.Value = itr.prev.Value
+				p.Nil = itr.prev.Nil
+			} else {
+				p.Nil = true
+			}
+		}
+	} else {
+		itr.prev = *p
+	}
+
+	// Advance the expected time. Do not advance to a new window here
+	// as there may be lingering points with the same timestamp in the previous
+	// window.
+	if itr.opt.Ascending {
+		itr.window.time += int64(itr.opt.Interval.Duration)
+	} else {
+		itr.window.time -= int64(itr.opt.Interval.Duration)
+	}
+
+	// Check to see if we have passed over an offset change and adjust the time
+	// to account for this new offset.
+	if itr.opt.Location != nil {
+		if _, offset := itr.opt.Zone(itr.window.time); offset != itr.window.offset {
+			diff := itr.window.offset - offset
+			if abs(diff) < int64(itr.opt.Interval.Duration) {
+				itr.window.time += diff
+			}
+			itr.window.offset = offset
+		}
+	}
+	return p, nil
+}
+
+// integerIntervalIterator represents a integer implementation of IntervalIterator.
+type integerIntervalIterator struct {
+	input IntegerIterator
+	opt   IteratorOptions
+}
+
+func newIntegerIntervalIterator(input IntegerIterator, opt IteratorOptions) *integerIntervalIterator {
+	return &integerIntervalIterator{input: input, opt: opt}
+}
+
+func (itr *integerIntervalIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *integerIntervalIterator) Close() error         { return itr.input.Close() }
+
+func (itr *integerIntervalIterator) Next() (*IntegerPoint, error) {
+	p, err := itr.input.Next()
+	if p == nil || err != nil {
+		return nil, err
+	}
+	p.Time, _ = itr.opt.Window(p.Time)
+	// If we see the minimum allowable time, set the time to zero so we don't
+	// break the default returned time for aggregate queries without times.
+	if p.Time == MinTime {
+		p.Time = 0
+	}
+	return p, nil
+}
+
+// integerInterruptIterator represents a integer implementation of InterruptIterator.
+type integerInterruptIterator struct {
+	input   IntegerIterator
+	closing <-chan struct{}
+	count   int
+}
+
+func newIntegerInterruptIterator(input IntegerIterator, closing <-chan struct{}) *integerInterruptIterator {
+	return &integerInterruptIterator{input: input, closing: closing}
+}
+
+func (itr *integerInterruptIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *integerInterruptIterator) Close() error         { return itr.input.Close() }
+
+func (itr *integerInterruptIterator) Next() (*IntegerPoint, error) {
+	// Only check if the channel is closed every N points. This
+	// intentionally checks on both 0 and N so that if the iterator
+	// has been interrupted before the first point is emitted it will
+	// not emit any points.
+	if itr.count&0xFF == 0xFF {
+		select {
+		case <-itr.closing:
+			return nil, itr.Close()
+		default:
+			// Reset iterator count to zero and fall through to emit the next point.
+			itr.count = 0
+		}
+	}
+
+	// Increment the counter for every point read.
+	itr.count++
+	return itr.input.Next()
+}
+
+// integerCloseInterruptIterator represents a integer implementation of CloseInterruptIterator.
+type integerCloseInterruptIterator struct {
+	input   IntegerIterator
+	closing <-chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+func newIntegerCloseInterruptIterator(input IntegerIterator, closing <-chan struct{}) *integerCloseInterruptIterator {
+	itr := &integerCloseInterruptIterator{
+		input:   input,
+		closing: closing,
+		done:    make(chan struct{}),
+	}
+	go itr.monitor()
+	return itr
+}
+
+func (itr *integerCloseInterruptIterator) monitor() {
+	select {
+	case <-itr.closing:
+		itr.Close()
+	case <-itr.done:
+	}
+}
+
+func (itr *integerCloseInterruptIterator) Stats() IteratorStats {
+	return itr.input.Stats()
+}
+
+func (itr *integerCloseInterruptIterator) Close() error {
+	itr.once.Do(func() {
+		close(itr.done)
+		itr.input.Close()
+	})
+	return nil
+}
+
+func (itr *integerCloseInterruptIterator) Next() (*IntegerPoint, error) {
+	p, err := itr.input.Next()
+	if err != nil {
+		// Check if the iterator was closed.
+		select {
+		case <-itr.done:
+			return nil, nil
+		default:
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// auxIntegerPoint represents a combination of a point and an error for the AuxIterator.
+type auxIntegerPoint struct {
+	point *IntegerPoint
+	err   error
+}
+
+// integerAuxIterator represents a integer implementation of AuxIterator.
+type integerAuxIterator struct {
+	input      *bufIntegerIterator
+	output     chan auxIntegerPoint
+	fields     *auxIteratorFields
+	background bool
+}
+
+func newIntegerAuxIterator(input IntegerIterator, opt IteratorOptions) *integerAuxIterator {
+	return &integerAuxIterator{
+		input:  newBufIntegerIterator(input),
+		output: make(chan auxIntegerPoint, 1),
+		fields: newAuxIteratorFields(opt),
+	}
+}
+
+func (itr *integerAuxIterator) Background() {
+	itr.background = true
+	itr.Start()
+	go DrainIterator(itr)
+}
+
+func (itr *integerAuxIterator) Start()               { go itr.stream() }
+func (itr *integerAuxIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *integerAuxIterator) Close() error         { return itr.input.Close() }
+func (itr *integerAuxIterator) Next() (*IntegerPoint, error) {
+	p := <-itr.output
+	return p.point, p.err
+}
+func (itr *integerAuxIterator) Iterator(name string, typ DataType) Iterator {
+	return itr.fields.iterator(name, typ)
+}
+
+func (itr *integerAuxIterator) stream() {
+	for {
+		// Read next point.
+		p, err := itr.input.Next()
+		if err != nil {
+			itr.output <- auxIntegerPoint{err: err}
+			itr.fields.sendError(err)
+			break
+		} else if p == nil {
+			break
+		}
+
+		// Send point to output and to each field iterator.
+		itr.output <- auxIntegerPoint{point: p}
+		if ok := itr.fields.send(p); !ok && itr.background {
+			break
+		}
+	}
+
+	close(itr.output)
+	itr.fields.close()
+}
+
+// integerChanIterator represents a new instance of integerChanIterator.
+type integerChanIterator struct {
+	buf struct {
+		i      int
+		filled bool
+		points [2]IntegerPoint
+	}
+	err  error
+	cond *sync.Cond
+	done bool
+}
+
+func (itr *integerChanIterator) Stats() IteratorStats { return IteratorStats{} }
+
+func (itr *integerChanIterator) Close() error {
+	itr.cond.L.Lock()
+	// Mark the channel iterator as done and signal all waiting goroutines to start again.
+	itr.done = true
+	itr.cond.Broadcast()
+	// Do not defer the unlock so we don't create an unnecessary allocation.
+	itr.cond.L.Unlock()
+	return nil
+}
+
+func (itr *integerChanIterator) setBuf(name string, tags Tags, time int64, value interface{}) bool {
+	itr.cond.L.Lock()
+	defer itr.cond.L.Unlock()
+
+	// Wait for either the iterator to be done (so we don't have to set the value)
+	// or for the buffer to have been read and ready for another write.
+	for !itr.done && itr.buf.filled {
+		itr.cond.Wait()
+	}
+
+	// Do not set the value and return false to signal that the iterator is closed.
+	// Do this after the above wait as the above for loop may have exited because
+	// the iterator was closed.
+	if itr.done {
+		return false
+	}
+
+	switch v := value.(type) {
+	case int64:
+		itr.buf.points[itr.buf.i] = IntegerPoint{Name: name, Tags: tags, Time: time, Value: v}
+
+	default:
+		itr.buf.points[itr.buf.i] = IntegerPoint{Name: name, Tags: tags, Time: time, Nil: true}
+	}
+	itr.buf.filled = true
+
+	// Signal to all waiting goroutines that a new value is ready to read.
+	itr.cond.Signal()
+	return true
+}
+
+func (itr *integerChanIterator) setErr(err error) {
+	itr.cond.L.Lock()
+	defer itr.cond.L.Unlock()
+	itr.err = err
+
+	// Signal to all waiting goroutines that a new value is ready to read.
+	itr.cond.Signal()
+}
+
+func (itr *integerChanIterator) Next() (*IntegerPoint, error) {
+	itr.cond.L.Lock()
+	defer itr.cond.L.Unlock()
+
+	// Check for an error and return one if there.
+	if itr.err != nil {
+		return nil, itr.err
+	}
+
+	// Wait until either a value is available in the buffer or
+	// the iterator is closed.
+	for !itr.done && !itr.buf.filled {
+		itr.cond.Wait()
+	}
+
+	// Return nil once the channel is done and the buffer is empty.
+	if itr.done && !itr.buf.filled {
+		return nil, nil
+	}
+
+	// Always read from the buffer if it exists, even if the iterator
+	// is closed. This prevents the last value from being truncated by
+	// the parent iterator.
+	p := &itr.buf.points[itr.buf.i]
+	itr.buf.i = (itr.buf.i + 1) % len(itr.buf.points)
+	itr.buf.filled = false
+	itr.cond.Signal()
+	return p, nil
+}
+
+// integerReduceFloatIterator executes a reducer for every interval and buffers the result.
+type integerReduceFloatIterator struct {
+	input  *bufIntegerIterator
+	create func() (IntegerPointAggregator, FloatPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	points []FloatPoint
+}
+
+func newIntegerReduceFloatIterator(input IntegerIterator, opt IteratorOptions, createFn func() (IntegerPointAggregator, FloatPointEmitter)) *integerReduceFloatIterator {
+	return &integerReduceFloatIterator{
+		input:  newBufIntegerIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+	}
+}
+
+// Stats returns stats from the input iterator.
+func (itr *integerReduceFloatIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *integerReduceFloatIterator) Close() error { return itr.input.Close() }
+
+// Next returns the minimum value for the next available interval.
+func (itr *integerReduceFloatIterator) Next() (*FloatPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
+		}
+	}
+
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
+}
+
+// integerReduceFloatPoint stores the reduced data for a name/tag combination.
+type integerReduceFloatPoint struct {
+	Name       string
+	Tags       Tags
+	Aggregator IntegerPointAggregator
+	Emitter    FloatPointEmitter
+}
+
+// reduce executes fn once for every point in the next window.
+// The previous value for the dimension is passed to fn.
+func (itr *integerReduceFloatIterator) reduce() ([]FloatPoint, error) {
+	// Calculate next window.
+	var (
+		startTime, endTime int64
+		window             struct {
+			name string
+			tags string
+		}
+	)
+	for {
+		p, err := itr.input.Next()
+		if err != nil || p == nil {
+			return nil, err
+		} else if p.Nil {
+			continue
+		}
+
+		// Unread the point so it can be processed.
+		itr.input.unread(p)
+		startTime, endTime = itr.opt.Window(p.Time)
+		window.name, window.tags = p.Name, p.Tags.Subset(itr.opt.Dimensions).ID()
+		break
+	}
+
+	// Create points by tags.
+	m := make(map[string]*integerReduceFloatPoint)
+	for {
+		// Read next point.
+		curr, err := itr.input.NextInWindow(startTime, endTime)
+		if err != nil {
+			return nil, err
+		} else if curr == nil {
+			break
+		} else if curr.Nil {
+			continue
+		} else if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		}
+
+		// Ensure this point is within the same final window.
+		if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		} else if tags := curr.Tags.Subset(itr.opt.Dimensions); tags.ID() != window.tags {
+			itr.input.unread(curr)
+			break
+		}
+
+		// Retrieve the tags on this point for this level of the query.
+		// This may be different than the bucket dimensions.
+		tags := curr.Tags.Subset(itr.dims)
+		id := tags.ID()
+
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &integerReduceFloatPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			m[id] = rp
+		}
+		rp.Aggregator.AggregateInteger(curr)
+	}
+
+	// Reverse sort points by name & tag if our output is supposed to be ordered.
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	if len(keys) > 1 && itr.opt.Ordered {
+		sort.Sort(reverseStringSlice(keys))
+	}
+
+	// Assume the points are already sorted until proven otherwise.
+	sortedByTime := true
+	// Emit the points for each name & tag combination.
+	a := make([]FloatPoint, 0, len(m))
+	for _, k := range keys {
+		rp := m[k]
+		points := rp.Emitter.Emit()
+		for i := len(points) - 1; i >= 0; i-- {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+			// Set the points time to the interval time if the reducer didn't provide one.
+			if points[i].Time == ZeroTime {
+				points[i].Time = startTime
+			} else {
+				sortedByTime = false
+			}
+			a = append(a, points[i])
+		}
+	}
+
+	// Points may be out of order. Perform a stable sort by time if requested.
+	if !sortedByTime && itr.opt.Ordered {
+		sort.Stable(sort.Reverse(floatPointsByTime(a)))
+	}
+
+	return a, nil
+}
+
+// integerStreamFloatIterator streams inputs into the iterator and emits points gradually.
+type integerStreamFloatIterator struct {
+	input  *bufIntegerIterator
+	create func() (IntegerPointAggregator, FloatPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	m      map[string]*integerReduceFloatPoint
+	points []FloatPoint
+}
+
+// newIntegerStreamFloatIterator returns a new instance of integerStreamFloatIterator.
+func newIntegerStreamFloatIterator(input IntegerIterator, createFn func() (IntegerPointAggregator, FloatPointEmitter), opt IteratorOptions) *integerStreamFloatIterator {
+	return &integerStreamFloatIterator{
+		input:  newBufIntegerIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+		m:      make(map[string]*integerReduceFloatPoint),
+	}
+}
+
+// Stats returns stats from the input iterator.
+func (itr *integerStreamFloatIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *integerStreamFloatIterator) Close() error { return itr.input.Close() }
+
+// Next returns the next value for the stream iterator.
+func (itr *integerStreamFloatIterator) Next() (*FloatPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
+		}
+	}
+
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
+}
+
+// reduce creates and manages aggregators for every point from the input.
+// After aggregating a point, it always tries to emit a value using the emitter.
+func (itr *integerStreamFloatIterator) reduce() ([]FloatPoint, error) {
+	for {
+		// Read next point.
+		curr, err := itr.input.Next()
+		if curr == nil {
+			// Close all of the aggregators to flush any remaining points to emit.
+			var points []FloatPoint
+			for _, rp := range itr.m {
+				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
+					if err := aggregator.Close(); err != nil {
+						return nil, err
+					}
+
+					pts := rp.Emitter.Emit()
+					if len(pts) == 0 {
+						continue
+					}
+
+					for i := range pts {
+						pts[i].Name = rp.Name
+						pts[i].Tags = rp.Tags
+					}
+					points = append(points, pts...)
+				}
+			}
+
+			// Eliminate the aggregators and emitters.
+			itr.m = nil
+			return points, nil
+		} else if err != nil {
+			return nil, err
+		} else if curr.Nil {
+			continue
+		}
+		tags := curr.Tags.Subset(itr.dims)
+
+		id := curr.Name
+		if len(tags.m) > 0 {
+			id += "\x00" + tags.ID()
+		}
+
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := itr.m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &integerReduceFloatPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			itr.m[id] = rp
+		}
+		rp.Aggregator.AggregateInteger(curr)
+
+		// Attempt to emit points from the aggregator.
+		points := rp.Emitter.Emit()
+		if len(points) == 0 {
+			continue
+		}
+
+		for i := range points {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+		}
+		return points, nil
+	}
+}
+
+// integerFloatExprIterator executes a function to modify an existing point
+// for every output of the input iterator.
+type integerFloatExprIterator struct {
+	left      *bufIntegerIterator
+	right     *bufIntegerIterator
+	fn        integerFloatExprFunc
+	points    []IntegerPoint // must be size 2
+	storePrev bool
+}
+
+func newIntegerFloatExprIterator(left, right IntegerIterator, opt IteratorOptions, fn func(a, b int64) float64) *integerFloatExprIterator {
+	var points []IntegerPoint
+	switch opt.Fill {
+	case NullFill, PreviousFill:
+		points = []IntegerPoint{{Nil: true}, {Nil: true}}
+	case NumberFill:
+		value := castToInteger(opt.FillValue)
+		points = []IntegerPoint{{Value: value}, {Value: value}}
+	}
+	return &integerFloatExprIterator{
+		left:      newBufIntegerIterator(left),
+		right:     newBufIntegerIterator(right),
+		points:    points,
+		fn:        fn,
+		storePrev: opt.Fill == PreviousFill,
+	}
+}
+
+func (itr *integerFloatExprIterator) Stats() IteratorStats {
+	stats := itr.left.Stats()
+	stats.Add(itr.right.Stats())
+	return stats
+}
+
+func (itr *integerFloatExprIterator) Close() error {
+	itr.left.Close()
+	itr.right.Close()
+	return nil
+}
+
+func (itr *integerFloatExprIterator) Next() (*FloatPoint, error) {
+	for {
+		a, b, err := itr.next()
+		if err != nil || (a == nil && b == nil) {
+			return nil, err
+		}
+
+		// If any of these are nil and we are using fill(none), skip these points.
+		if (a == nil || a.Nil || b == nil || b.Nil) && itr.points == nil {
+			continue
+		}
+
+		// If one of the two points is nil, we need to fill it with a fake nil
+		// point that has the same name, tags, and time as the other point.
+		// There should never be a time when both of these are nil.
+		if a == nil {
+			p := *b
+			a = &p
+			a.Value = 0
+			a.Nil = true
+		} else if b == nil {
+			p := *a
+			b = &p
+			b.Value = 0
+			b.Nil = true
+		}
+
+		// If a value is nil, use the fill values if the fill value is non-nil.
+		if a.Nil && !itr.points[0].Nil {
+			a.Value = itr.points[0].Value
+			a.Nil = false
+		}
+		if b.Nil && !itr.points[1].Nil {
+			b.Value = itr.points[1].Value
+			b.Nil = false
+		}
+
+		if itr.storePrev {
+			itr.points[0], itr.points[1] = *a, *b
+		}
+
+		p := &FloatPoint{
+			Name:       a.Name,
+			Tags:       a.Tags,
+			Time:       a.Time,
+			Nil:        a.Nil || b.Nil,
+			Aggregated: a.Aggregated,
+		}
+		if !p.Nil {
+			p.Value = itr.fn(a.Value, b.Value)
+		}
+		return p, nil
+
+	}
+}
+
+// next returns the next points within each iterator. If the iterators are
+// uneven, it organizes them so only matching points are returned.
+func (itr *integerFloatExprIterator) next() (a, b *IntegerPoint, err error) {
+	// Retrieve the next value for both the left and right.
+	a, err = itr.left.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err = itr.right.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If we have a point from both, make sure that they match each other.
+	if a != nil && b != nil {
+		if a.Name > b.Name {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Name < b.Name {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+
+		if ltags, rtags := a.Tags.ID(), b.Tags.ID(); ltags > rtags {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if ltags < rtags {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+
+		if a.Time > b.Time {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Time < b.Time {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+	}
+	return a, b, nil
+}
+
+// integerFloatExprFunc creates or modifies a point by combining two
+// points. The point passed in may be modified and returned rather than
+// allocating a new point if possible. One of the points may be nil, but at
+// least one of the points will be non-nil.
+type integerFloatExprFunc func(a, b int64) float64
+
+// integerReduceIntegerIterator executes a reducer for every interval and buffers the result.
+type integerReduceIntegerIterator struct {
+	input  *bufIntegerIterator
+	create func() (IntegerPointAggregator, IntegerPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	points []IntegerPoint
+}
+
+func newIntegerReduceIntegerIterator(input IntegerIterator, opt IteratorOptions, createFn func() (IntegerPointAggregator, IntegerPointEmitter)) *integerReduceIntegerIterator {
+	return &integerReduceIntegerIterator{
+		input:  newBufIntegerIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+	}
+}
+
+// Stats returns stats from the input iterator.
+func (itr *integerReduceIntegerIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *integerReduceIntegerIterator) Close() error { return itr.input.Close() }
+
+// Next returns the minimum value for the next available interval.
+func (itr *integerReduceIntegerIterator) Next() (*IntegerPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
+		}
+	}
+
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
+}
+
+// integerReduceIntegerPoint stores the reduced data for a name/tag combination.
+type integerReduceIntegerPoint struct {
+	Name       string
+	Tags       Tags
+	Aggregator IntegerPointAggregator
+	Emitter    IntegerPointEmitter
+}
+
+// reduce executes fn once for every point in the next window.
+// The previous value for the dimension is passed to fn.
+func (itr *integerReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
+	// Calculate next window.
+	var (
+		startTime, endTime int64
+		window             struct {
+			name string
+			tags string
+		}
+	)
+	for {
+		p, err := itr.input.Next()
+		if err != nil || p == nil {
+			return nil, err
+		} else if p.Nil {
+			continue
+		}
+
+		// Unread the point so it can be processed.
+		itr.input.unread(p)
+		startTime, endTime = itr.opt.Window(p.Time)
+		window.name, window.tags = p.Name, p.Tags.Subset(itr.opt.Dimensions).ID()
+		break
+	}
+
+	// Create points by tags.
+	m := make(map[string]*integerReduceIntegerPoint)
+	for {
+		// Read next point.
+		curr, err := itr.input.NextInWindow(startTime, endTime)
+		if err != nil {
+			return nil, err
+		} else if curr == nil {
+			break
+		} else if curr.Nil {
+			continue
+		} else if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		}
+
+		// Ensure this point is within the same final window.
+		if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		} else if tags := curr.Tags.Subset(itr.opt.Dimensions); tags.ID() != window.tags {
+			itr.input.unread(curr)
+			break
+		}
+
+		// Retrieve the tags on this point for this level of the query.
+		// This may be different than the bucket dimensions.
+		tags := curr.Tags.Subset(itr.dims)
+		id := tags.ID()
+
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &integerReduceIntegerPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			m[id] = rp
+		}
+		rp.Aggregator.AggregateInteger(curr)
+	}
+
+	// Reverse sort points by name & tag if our output is supposed to be ordered.
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	if len(keys) > 1 && itr.opt.Ordered {
+		sort.Sort(reverseStringSlice(keys))
+	}
+
+	// Assume the points are already sorted until proven otherwise.
+	sortedByTime := true
+	// Emit the points for each name & tag combination.
+	a := make([]IntegerPoint, 0, len(m))
+	for _, k := range keys {
+		rp := m[k]
+		points := rp.Emitter.Emit()
+		for i := len(points) - 1; i >= 0; i-- {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+			// Set the points time to the interval time if the reducer didn't provide one.
+			if points[i].Time == ZeroTime {
+				points[i].Time = startTime
+			} else {
+				sortedByTime = false
+			}
+			a = append(a, points[i])
+		}
+	}
+
+	// Points may be out of order. Perform a stable sort by time if requested.
+	if !sortedByTime && itr.opt.Ordered {
+		sort.Stable(sort.Reverse(integerPointsByTime(a)))
+	}
+
+	return a, nil
+}
+
+// integerStreamIntegerIterator streams inputs into the iterator and emits points gradually.
+type integerStreamIntegerIterator struct {
+	input  *bufIntegerIterator
+	create func() (IntegerPointAggregator, IntegerPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	m      map[string]*integerReduceIntegerPoint
+	points []IntegerPoint
+}
+
+// newIntegerStreamIntegerIterator returns a new instance of integerStreamIntegerIterator.
+func newIntegerStreamIntegerIterator(input IntegerIterator, createFn func() (IntegerPointAggregator, IntegerPointEmitter), opt IteratorOptions) *integerStreamIntegerIterator {
+	return &integerStreamIntegerIterator{
+		input:  newBufIntegerIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+		m:      make(map[string]*integerReduceIntegerPoint),
+	}
+}
+
+// Stats returns stats from the input iterator.
+func (itr *integerStreamIntegerIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *integerStreamIntegerIterator) Close() error { return itr.input.Close() }
+
+// Next returns the next value for the stream iterator.
+func (itr *integerStreamIntegerIterator) Next() (*IntegerPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
+		}
+	}
+
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
+}
+
+// reduce creates and manages aggregators for every point from the input.
+// After aggregating a point, it always tries to emit a value using the emitter.
+func (itr *integerStreamIntegerIterator) reduce() ([]IntegerPoint, error) {
+	for {
+		// Read next point.
+		curr, err := itr.input.Next()
+		if curr == nil {
+			// Close all of the aggregators to flush any remaining points to emit.
+			var points []IntegerPoint
+			for _, rp := range itr.m {
+				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
+					if err := aggregator.Close(); err != nil {
+						return nil, err
+					}
+
+					pts := rp.Emitter.Emit()
+					if len(pts) == 0 {
+						continue
+					}
+
+					for i := range pts {
+						pts[i].Name = rp.Name
+						pts[i].Tags = rp.Tags
+					}
+					points = append(points, pts...)
+				}
+			}
+
+			// Eliminate the aggregators and emitters.
+			itr.m = nil
+			return points, nil
+		} else if err != nil {
+			return nil, err
+		} else if curr.Nil {
+			continue
+		}
+		tags := curr.Tags.Subset(itr.dims)
+
+		id := curr.Name
+		if len(tags.m) > 0 {
+			id += "\x00" + tags.ID()
+		}
+
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := itr.m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &integerReduceIntegerPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			itr.m[id] = rp
+		}
+		rp.Aggregator.AggregateInteger(curr)
+
+		// Attempt to emit points from the aggregator.
+		points := rp.Emitter.Emit()
+		if len(points) == 0 {
+			continue
+		}
+
+		for i := range points {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+		}
+		return points, nil
+	}
+}
+
+// integerExprIterator executes a function to modify an existing point
+// for every output of the input iterator.
+type integerExprIterator struct {
+	left      *bufIntegerIterator
+	right     *bufIntegerIterator
+	fn        integerExprFunc
+	points    []IntegerPoint // must be size 2
+	storePrev bool
+}
+
+func newIntegerExprIterator(left, right IntegerIterator, opt IteratorOptions, fn func(a, b int64) int64) *integerExprIterator {
+	var points []IntegerPoint
+	switch opt.Fill {
+	case NullFill, PreviousFill:
+		points = []IntegerPoint{{Nil: true}, {Nil: true}}
+	case NumberFill:
+		value := castToInteger(opt.FillValue)
+		points = []IntegerPoint{{Value: value}, {Value: value}}
+	}
+	return &integerExprIterator{
+		left:      newBufIntegerIterator(left),
+		right:     newBufIntegerIterator(right),
+		points:    points,
+		fn:        fn,
+		storePrev: opt.Fill == PreviousFill,
+	}
+}
+
+func (itr *integerExprIterator) Stats() IteratorStats {
+	stats := itr.left.Stats()
+	stats.Add(itr.right.Stats())
+	return stats
+}
+
+func (itr *integerExprIterator) Close() error {
+	itr.left.Close()
+	itr.right.Close()
+	return nil
+}
+
+func (itr *integerExprIterator) Next() (*IntegerPoint, error) {
+	for {
+		a, b, err := itr.next()
+		if err != nil || (a == nil && b == nil) {
+			return nil, err
+		}
+
+		// If any of these are nil and we are using fill(none), skip these points.
+		if (a == nil || a.Nil || b == nil || b.Nil) && itr.points == nil {
+			continue
+		}
+
+		// If one of the two points is nil, we need to fill it with a fake nil
+		// point that has the same name, tags, and time as the other point.
+		// There should never be a time when both of these are nil.
+		if a == nil {
+			p := *b
+			a = &p
+			a.Value = 0
+			a.Nil = true
+		} else if b == nil {
+			p := *a
+			b = &p
+			b.Value = 0
+			b.Nil = true
+		}
+
+		// If a value is nil, use the fill values if the fill value is non-nil.
+		if a.Nil && !itr.points[0].Nil {
+			a.Value = itr.points[0].Value
+			a.Nil = false
+		}
+		if b.Nil && !itr.points[1].Nil {
+			b.Value = itr.points[1].Value
+			b.Nil = false
+		}
+
+		if itr.storePrev {
+			itr.points[0], itr.points[1] = *a, *b
+		}
+
+		if a.Nil {
+			return a, nil
+		} else if b.Nil {
+			return b, nil
+		}
+		a.Value = itr.fn(a.Value, b.Value)
+		return a, nil
+
+	}
+}
+
+// next returns the next points within each iterator. If the iterators are
+// uneven, it organizes them so only matching points are returned.
+func (itr *integerExprIterator) next() (a, b *IntegerPoint, err error) {
+	// Retrieve the next value for both the left and right.
+	a, err = itr.left.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err = itr.right.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If we have a point from both, make sure that they match each other.
+	if a != nil && b != nil {
+		if a.Name > b.Name {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Name < b.Name {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+
+		if ltags, rtags := a.Tags.ID(), b.Tags.ID(); ltags > rtags {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if ltags < rtags {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+
+		if a.Time > b.Time {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Time < b.Time {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+	}
+	return a, b, nil
+}
+
+// integerExprFunc creates or modifies a point by combining two
+// points. The point passed in may be modified and returned rather than
+// allocating a new point if possible. One of the points may be nil, but at
+// least one of the points will be non-nil.
+type integerExprFunc func(a, b int64) int64
+
+// integerReduceUnsignedIterator executes a reducer for every interval and buffers the result.
+type integerReduceUnsignedIterator struct {
+	input  *bufIntegerIterator
+	create func() (IntegerPointAggregator, UnsignedPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	points []UnsignedPoint
+}
+
+func newIntegerReduceUnsignedIterator(input IntegerIterator, opt IteratorOptions, createFn func() (IntegerPointAggregator, UnsignedPointEmitter)) *integerReduceUnsignedIterator {
+	return &integerReduceUnsignedIterator{
+		input:  newBufIntegerIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+	}
+}
+
+// Stats returns stats from the input iterator.
+func (itr *integerReduceUnsignedIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *integerReduceUnsignedIterator) Close() error { return itr.input.Close() }
+
+// Next returns the minimum value for the next available interval.
+func (itr *integerReduceUnsignedIterator) Next() (*UnsignedPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
+		}
+	}
+
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
+}
+
+// integerReduceUnsignedPoint stores the reduced data for a name/tag combination.
+type integerReduceUnsignedPoint struct {
+	Name       string
+	Tags       Tags
+	Aggregator IntegerPointAggregator
+	Emitter    UnsignedPointEmitter
+}
+
+// reduce executes fn once for every point in the next window.
+// The previous value for the dimension is passed to fn.
+func (itr *integerReduceUnsignedIterator) reduce() ([]UnsignedPoint, error) {
+	// Calculate next window.
+	var (
+		startTime, endTime int64
+		window             struct {
+			name string
+			tags string
+		}
+	)
+	for {
+		p, err := itr.input.Next()
+		if err != nil || p == nil {
+			return nil, err
+		} else if p.Nil {
+			continue
+		}
+
+		// Unread the point so it can be processed.
+		itr.input.unread(p)
+		startTime, endTime = itr.opt.Window(p.Time)
+		window.name, window.tags = p.Name, p.Tags.Subset(itr.opt.Dimensions).ID()
+		break
+	}
+
+	// Create points by tags.
+	m := make(map[string]*integerReduceUnsignedPoint)
+	for {
+		// Read next point.
+		curr, err := itr.input.NextInWindow(startTime, endTime)
+		if err != nil {
+			return nil, err
+		} else if curr == nil {
+			break
+		} else if curr.Nil {
+			continue
+		} else if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		}
+
+		// Ensure this point is within the same final window.
+		if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		} else if tags := curr.Tags.Subset(itr.opt.Dimensions); tags.ID() != window.tags {
+			itr.input.unread(curr)
+			break
+		}
+
+		// Retrieve the tags on this point for this level of the query.
+		// This may be different than the bucket dimensions.
+		tags := curr.Tags.Subset(itr.dims)
+		id := tags.ID()
+
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &integerReduceUnsignedPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			m[id] = rp
+		}
+		rp.Aggregator.AggregateInteger(curr)
+	}
+
+	// Reverse sort points by name & tag if our output is supposed to be ordered.
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	if len(keys) > 1 && itr.opt.Ordered {
+		sort.Sort(reverseStringSlice(keys))
+	}
+
+	// Assume the points are already sorted until proven otherwise.
+	sortedByTime := true
+	// Emit the points for each name & tag combination.
+	a := make([]UnsignedPoint, 0, len(m))
+	for _, k := range keys {
+		rp := m[k]
+		points := rp.Emitter.Emit()
+		for i := len(points) - 1; i >= 0; i-- {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+			// Set the points time to the interval time if the reducer didn't provide one.
+			if points[i].Time == ZeroTime {
+				points[i].Time = startTime
+			} else {
+				sortedByTime = false
+			}
+			a = append(a, points[i])
+		}
+	}
+
+	// Points may be out of order. Perform a stable sort by time if requested.
+	if !sortedByTime && itr.opt.Ordered {
+		sort.Stable(sort.Reverse(unsignedPointsByTime(a)))
+	}
+
+	return a, nil
+}
+
+// integerStreamUnsignedIterator streams inputs into the iterator and emits points gradually.
+type integerStreamUnsignedIterator struct {
+	input  *bufIntegerIterator
+	create func() (IntegerPointAggregator, UnsignedPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	m      map[string]*integerReduceUnsignedPoint
+	points []UnsignedPoint
+}
+
+// newIntegerStreamUnsignedIterator returns a new instance of integerStreamUnsignedIterator.
+func newIntegerStreamUnsignedIterator(input IntegerIterator, createFn func() (IntegerPointAggregator, UnsignedPointEmitter), opt IteratorOptions) *integerStreamUnsignedIterator {
+	return &integerStreamUnsignedIterator{
+		input:  newBufIntegerIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+		m:      make(map[string]*integerReduceUnsignedPoint),
+	}
+}
+
+// Stats returns stats from the input iterator.
+func (itr *integerStreamUnsignedIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *integerStreamUnsignedIterator) Close() error { return itr.input.Close() }
+
+// Next returns the next value for the stream iterator.
+func (itr *integerStreamUnsignedIterator) Next() (*UnsignedPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
+		}
+	}
+
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
+}
+
+// reduce creates and manages aggregators for every point from the input.
+// After aggregating a point, it always tries to emit a value using the emitter.
+func (itr *integerStreamUnsignedIterator) reduce() ([]UnsignedPoint, error) {
+	for {
+		// Read next point.
+		curr, err := itr.input.Next()
+		if curr == nil {
+			// Close all of the aggregators to flush any remaining points to emit.
+			var points []UnsignedPoint
+			for _, rp := range itr.m {
+				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
+					if err := aggregator.Close(); err != nil {
+						return nil, err
+					}
+
+					pts := rp.Emitter.Emit()
+					if len(pts) == 0 {
+						continue
+					}
+
+					for i := range pts {
+						pts[i].Name = rp.Name
+						pts[i].Tags = rp.Tags
+					}
+					points = append(points, pts...)
+				}
+			}
+
+			// Eliminate the aggregators and emitters.
+			itr.m = nil
+			return points, nil
+		} else if err != nil {
+			return nil, err
+		} else if curr.Nil {
+			continue
+		}
+		tags := curr.Tags.Subset(itr.dims)
+
+		id := curr.Name
+		if len(tags.m) > 0 {
+			id += "\x00" + tags.ID()
+		}
+
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := itr.m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &integerReduceUnsignedPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			itr.m[id] = rp
+		}
+		rp.Aggregator.AggregateInteger(curr)
+
+		// Attempt to emit points from the aggregator.
+		points := rp.Emitter.Emit()
+		if len(points) == 0 {
+			continue
+		}
+
+		for i := range points {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+		}
+		return points, nil
+	}
+}
+
+// integerUnsignedExprIterator executes a function to modify an existing point
+// for every output of the input iterator.
+type integerUnsignedExprIterator struct {
+	left      *bufIntegerIterator
+	right     *bufIntegerIterator
+	fn        integerUnsignedExprFunc
+	points    []IntegerPoint // must be size 2
+	storePrev bool
+}
+
+func newIntegerUnsignedExprIterator(left, right IntegerIterator, opt IteratorOptions, fn func(a, b int64) uint64) *integerUnsignedExprIterator {
+	var points []IntegerPoint
+	switch opt.Fill {
+	case NullFill, PreviousFill:
+		points = []IntegerPoint{{Nil: true}, {Nil: true}}
+	case NumberFill:
+		value := castToInteger(opt.FillValue)
+		points = []IntegerPoint{{Value: value}, {Value: value}}
+	}
+	return &integerUnsignedExprIterator{
+		left:      newBufIntegerIterator(left),
+		right:     newBufIntegerIterator(right),
+		points:    points,
+		fn:        fn,
+		storePrev: opt.Fill == PreviousFill,
+	}
+}
+
+func (itr *integerUnsignedExprIterator) Stats() IteratorStats {
+	stats := itr.left.Stats()
+	stats.Add(itr.right.Stats())
+	return stats
+}
+
+func (itr *integerUnsignedExprIterator) Close() error {
+	itr.left.Close()
+	itr.right.Close()
+	return nil
+}
+
+func (itr *integerUnsignedExprIterator) Next() (*UnsignedPoint, error) {
+	for {
+		a, b, err := itr.next()
+		if err != nil || (a == nil && b == nil) {
+			return nil, err
+		}
+
+		// If any of these are nil and we are using fill(none), skip these points.
+		if (a == nil || a.Nil || b == nil || b.Nil) && itr.points == nil {
+			continue
+		}
+
+		// If one of the two points is nil, we need to fill it with a fake nil
+		// point that has the same name, tags, and time as the other point.
+		// There should never be a time when both of these are nil.
+		if a == nil {
+			p := *b
+			a = &p
+			a.Value = 0
+			a.Nil = true
+		} else if b == nil {
+			p := *a
+			b = &p
+			b.Value = 0
+			b.Nil = true
+		}
+
+		// If a value is nil, use the fill values if the fill value is non-nil.
+		if a.Nil && !itr.points[0].Nil {
+			a.Value = itr.points[0].Value
+			a.Nil = false
+		}
+		if b.Nil && !itr.points[1].Nil {
+			b.Value = itr.points[1].Value
+			b.Nil = false
+		}
+
+		if itr.storePrev {
+			itr.points[0], itr.points[1] = *a, *b
+		}
+
+		p := &UnsignedPoint{
+			Name:       a.Name,
+			Tags:       a.Tags,
+			Time:       a.Time,
+			Nil:        a.Nil || b.Nil,
+			Aggregated: a.Aggregated,
+		}
+		if !p.Nil {
+			p.Value = itr.fn(a.Value, b.Value)
+		}
+		return p, nil
+
+	}
+}
+
+// next returns the next points within each iterator. If the iterators are
+// uneven, it organizes them so only matching points are returned.
+func (itr *integerUnsignedExprIterator) next() (a, b *IntegerPoint, err error) {
+	// Retrieve the next value for both the left and right.
+	a, err = itr.left.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err = itr.right.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If we have a point from both, make sure that they match each other.
+	if a != nil && b != nil {
+		if a.Name > b.Name {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Name < b.Name {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+
+		if ltags, rtags := a.Tags.ID(), b.Tags.ID(); ltags > rtags {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if ltags < rtags {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+
+		if a.Time > b.Time {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Time < b.Time {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+	}
+	return a, b, nil
+}
+
+// integerUnsignedExprFunc creates or modifies a point by combining two
+// points. The point passed in may be modified and returned rather than
+// allocating a new point if possible. One of the points may be nil, but at
+// least one of the points will be non-nil.
+type integerUnsignedExprFunc func(a, b int64) uint64
+
+// integerReduceStringIterator executes a reducer for every interval and buffers the result.
+type integerReduceStringIterator struct {
+	input  *bufIntegerIterator
+	create func() (IntegerPointAggregator, StringPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	points []StringPoint
+}
+
+func newIntegerReduceStringIterator(input IntegerIterator, opt IteratorOptions, createFn func() (IntegerPointAggregator, StringPointEmitter)) *integerReduceStringIterator {
+	return &integerReduceStringIterator{
+		input:  newBufIntegerIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+	}
+}
+
+// Stats returns stats from the input iterator.
+func (itr *integerReduceStringIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *integerReduceStringIterator) Close() error { return itr.input.Close() }
+
+// Next returns the minimum value for the next available interval.
+func (itr *integerReduceStringIterator) Next() (*StringPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
+		}
+	}
+
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
+}
+
+// integerReduceStringPoint stores the reduced data for a name/tag combination.
+type integerReduceStringPoint struct {
+	Name       string
+	Tags       Tags
+	Aggregator IntegerPointAggregator
+	Emitter    StringPointEmitter
+}
+
+// reduce executes fn once for every point in the next window.
+// The previous value for the dimension is passed to fn.
+func (itr *integerReduceStringIterator) reduce() ([]StringPoint, error) {
+	// Calculate next window.
+	var (
+		startTime, endTime int64
+		window             struct {
+			name string
+			tags string
+		}
+	)
+	for {
+		p, err := itr.input.Next()
+		if err != nil || p == nil {
+			return nil, err
+		} else if p.Nil {
+			continue
+		}
+
+		// Unread the point so it can be processed.
+		itr.input.unread(p)
+		startTime, endTime = itr.opt.Window(p.Time)
+		window.name, window.tags = p.Name, p.Tags.Subset(itr.opt.Dimensions).ID()
+		break
+	}
+
+	// Create points by tags.
+	m := make(map[string]*integerReduceStringPoint)
+	for {
+		// Read next point.
+		curr, err := itr.input.NextInWindow(startTime, endTime)
+		if err != nil {
+			return nil, err
+		} else if curr == nil {
+			break
+		} else if curr.Nil {
+			continue
+		} else if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		}
+
+		// Ensure this point is within the same final window.
+		if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		} else if tags := curr.Tags.Subset(itr.opt.Dimensions); tags.ID() != window.tags {
+			itr.input.unread(curr)
+			break
+		}
+
+		// Retrieve the tags on this point for this level of the query.
+		// This may be different than the bucket dimensions.
+		tags := curr.Tags.Subset(itr.dims)
+		id := tags.ID()
+
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &integerReduceStringPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			m[id] = rp
+		}
+		rp.Aggregator.AggregateInteger(curr)
+	}
+
+	// Reverse sort points by name & tag if our output is supposed to be ordered.
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	if len(keys) > 1 && itr.opt.Ordered {
+		sort.Sort(reverseStringSlice(keys))
+	}
+
+	// Assume the points are already sorted until proven otherwise.
+	sortedByTime := true
+	// Emit the points for each name & tag combination.
+	a := make([]StringPoint, 0, len(m))
+	for _, k := range keys {
+		rp := m[k]
+		points := rp.Emitter.Emit()
+		for i := len(points) - 1; i >= 0; i-- {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+			// Set the points time to the interval time if the reducer didn't provide one.
+			if points[i].Time == ZeroTime {
+				points[i].Time = startTime
+			} else {
+				sortedByTime = false
+			}
+			a = append(a, points[i])
+		}
+	}
+
+	// Points may be out of order. Perform a stable sort by time if requested.
+	if !sortedByTime && itr.opt.Ordered {
+		sort.Stable(sort.Reverse(stringPointsByTime(a)))
+	}
+
+	return a, nil
+}
+
+// integerStreamStringIterator streams inputs into the iterator and emits points gradually.
+type integerStreamStringIterator struct {
+	input  *bufIntegerIterator
+	create func() (IntegerPointAggregator, StringPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	m      map[string]*integerReduceStringPoint
+	points []StringPoint
+}
+
+// newIntegerStreamStringIterator returns a new instance of integerStreamStringIterator.
+func newIntegerStreamStringIterator(input IntegerIterator, createFn func() (IntegerPointAggregator, StringPointEmitter), opt IteratorOptions) *integerStreamStringIterator {
+	return &integerStreamStringIterator{
+		input:  newBufIntegerIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+		m:      make(map[string]*integerReduceStringPoint),
+	}
+}
+
+// Stats returns stats from the input iterator.
+func (itr *integerStreamStringIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *integerStreamStringIterator) Close() error { return itr.input.Close() }
+
+// Next returns the next value for the stream iterator.
+func (itr *integerStreamStringIterator) Next() (*StringPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
+		}
+	}
+
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
+}
+
+// reduce creates and manages aggregators for every point from the input.
+// After aggregating a point, it always tries to emit a value using the emitter.
+func (itr *integerStreamStringIterator) reduce() ([]StringPoint, error) {
+	for {
+		// Read next point.
+		curr, err := itr.input.Next()
+		if curr == nil {
+			// Close all of the aggregators to flush any remaining points to emit.
+			var points []StringPoint
+			for _, rp := range itr.m {
+				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
+					if err := aggregator.Close(); err != nil {
+						return nil, err
+					}
+
+					pts := rp.Emitter.Emit()
+					if len(pts) == 0 {
+						continue
+					}
+
+					for i := range pts {
+						pts[i].Name = rp.Name
+						pts[i].Tags = rp.Tags
+					}
+					points = append(points, pts...)
+				}
+			}
+
+			// Eliminate the aggregators and emitters.
+			itr.m = nil
+			return points, nil
+		} else if err != nil {
+			return nil, err
+		} else if curr.Nil {
+			continue
+		}
+		tags := curr.Tags.Subset(itr.dims)
+
+		id := curr.Name
+		if len(tags.m) > 0 {
+			id += "\x00" + tags.ID()
+		}
+
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := itr.m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &integerReduceStringPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			itr.m[id] = rp
+		}
+		rp.Aggregator.AggregateInteger(curr)
+
+		// Attempt to emit points from the aggregator.
+		points := rp.Emitter.Emit()
+		if len(points) == 0 {
+			continue
+		}
+
+		for i := range points {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+		}
+		return points, nil
+	}
+}
+
+// integerStringExprIterator executes a function to modify an existing point
+// for every output of the input iterator.
+type integerStringExprIterator struct {
+	left      *bufIntegerIterator
+	right     *bufIntegerIterator
+	fn        integerStringExprFunc
+	points    []IntegerPoint // must be size 2
+	storePrev bool
+}
+
+func newIntegerStringExprIterator(left, right IntegerIterator, opt IteratorOptions, fn func(a, b int64) string) *integerStringExprIterator {
+	var points []IntegerPoint
+	switch opt.Fill {
+	case NullFill, PreviousFill:
+		points = []IntegerPoint{{Nil: true}, {Nil: true}}
+	case NumberFill:
+		value := castToInteger(opt.FillValue)
+		points = []IntegerPoint{{Value: value}, {Value: value}}
+	}
+	return &integerStringExprIterator{
+		left:      newBufIntegerIterator(left),
+		right:     newBufIntegerIterator(right),
+		points:    points,
+		fn:        fn,
+		storePrev: opt.Fill == PreviousFill,
+	}
+}
+
+func (itr *integerStringExprIterator) Stats() IteratorStats {
+	stats := itr.left.Stats()
+	stats.Add(itr.right.Stats())
+	return stats
+}
+
+func (itr *integerStringExprIterator) Close() error {
+	itr.left.Close()
+	itr.right.Close()
+	return nil
+}
+
+func (itr *integerStringExprIterator) Next() (*StringPoint, error) {
+	for {
+		a, b, err := itr.next()
+		if err != nil || (a == nil && b == nil) {
+			return nil, err
+		}
+
+		// If any of these are nil and we are using fill(none), skip these points.
+		if (a == nil || a.Nil || b == nil || b.Nil) && itr.points == nil {
+			continue
+		}
+
+		// If one of the two points is nil, we need to fill it with a fake nil
+		// point that has the same name, tags, and time as the other point.
+		// There should never be a time when both of these are nil.
+		if a == nil {
+			p := *b
+			a = &p
+			a.Value = 0
+			a.Nil = true
+		} else if b == nil {
+			p := *a
+			b = &p
+			b.Value = 0
+			b.Nil = true
+		}
+
+		// If a value is nil, use the fill values if the fill value is non-nil.
+		if a.Nil && !itr.points[0].Nil {
+			a.Value = itr.points[0].Value
+			a.Nil = false
+		}
+		if b.Nil && !itr.points[1].Nil {
+			b.Value = itr.points[1].Value
+			b.Nil = false
+		}
+
+		if itr.storePrev {
+			itr.points[0], itr.points[1] = *a, *b
+		}
+
+		p := &StringPoint{
+			Name:       a.Name,
+			Tags:       a.Tags,
+			Time:       a.Time,
+			Nil:        a.Nil || b.Nil,
+			Aggregated: a.Aggregated,
+		}
+		if !p.Nil {
+			p.Value = itr.fn(a.Value, b.Value)
+		}
+		return p, nil
+
+	}
+}
+
+// next returns the next points within each iterator. If the iterators are
+// uneven, it organizes them so only matching points are returned.
+func (itr *integerStringExprIterator) next() (a, b *IntegerPoint, err error) {
+	// Retrieve the next value for both the left and right.
+	a, err = itr.left.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err = itr.right.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If we have a point from both, make sure that they match each other.
+	if a != nil && b != nil {
+		if a.Name > b.Name {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Name < b.Name {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+
+		if ltags, rtags := a.Tags.ID(), b.Tags.ID(); ltags > rtags {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if ltags < rtags {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+
+		if a.Time > b.Time {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Time < b.Time {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+	}
+	return a, b, nil
+}
+
+// integerStringExprFunc creates or modifies a point by combining two
+// points. The point passed in may be modified and returned rather than
+// allocating a new point if possible. One of the points may be nil, but at
+// least one of the points will be non-nil.
+type integerStringExprFunc func(a, b int64) string
+
+// integerReduceBooleanIterator executes a reducer for every interval and buffers the result.
+type integerReduceBooleanIterator struct {
+	input  *bufIntegerIterator
+	create func() (IntegerPointAggregator, BooleanPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	points []BooleanPoint
+}
+
+func newIntegerReduceBooleanIterator(input IntegerIterator, opt IteratorOptions, createFn func() (IntegerPointAggregator, BooleanPointEmitter)) *integerReduceBooleanIterator {
+	return &integerReduceBooleanIterator{
+		input:  newBufIntegerIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+	}
+}
+
+// Stats returns stats from the input iterator.
+func (itr *integerReduceBooleanIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *integerReduceBooleanIterator) Close() error { return itr.input.Close() }
+
+// Next returns the minimum value for the next available interval.
+func (itr *integerReduceBooleanIterator) Next() (*BooleanPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
+		}
+	}
+
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
+}
+
+// integerReduceBooleanPoint stores the reduced data for a name/tag combination.
+type integerReduceBooleanPoint struct {
+	Name       string
+	Tags       Tags
+	Aggregator IntegerPointAggregator
+	Emitter    BooleanPointEmitter
+}
+
+// reduce executes fn once for every point in the next window.
+// The previous value for the dimension is passed to fn.
+func (itr *integerReduceBooleanIterator) reduce() ([]BooleanPoint, error) {
+	// Calculate next window.
+	var (
+		startTime, endTime int64
+		window             struct {
+			name string
+			tags string
+		}
+	)
+	for {
+		p, err := itr.input.Next()
+		if err != nil || p == nil {
+			return nil, err
+		} else if p.Nil {
+			continue
+		}
+
+		// Unread the point so it can be processed.
+		itr.input.unread(p)
+		startTime, endTime = itr.opt.Window(p.Time)
+		window.name, window.tags = p.Name, p.Tags.Subset(itr.opt.Dimensions).ID()
+		break
+	}
+
+	// Create points by tags.
+	m := make(map[string]*integerReduceBooleanPoint)
+	for {
+		// Read next point.
+		curr, err := itr.input.NextInWindow(startTime, endTime)
+		if err != nil {
+			return nil, err
+		} else if curr == nil {
+			break
+		} else if curr.Nil {
+			continue
+		} else if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		}
+
+		// Ensure this point is within the same final window.
+		if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		} else if tags := curr.Tags.Subset(itr.opt.Dimensions); tags.ID() != window.tags {
+			itr.input.unread(curr)
+			break
+		}
+
+		// Retrieve the tags on this point for this level of the query.
+		// This may be different than the bucket dimensions.
+		tags := curr.Tags.Subset(itr.dims)
+		id := tags.ID()
+
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &integerReduceBooleanPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			m[id] = rp
+		}
+		rp.Aggregator.AggregateInteger(curr)
+	}
+
+	// Reverse sort points by name & tag if our output is supposed to be ordered.
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	if len(keys) > 1 && itr.opt.Ordered {
+		sort.Sort(reverseStringSlice(keys))
+	}
+
+	// Assume the points are already sorted until proven otherwise.
+	sortedByTime := true
+	// Emit the points for each name & tag combination.
+	a := make([]BooleanPoint, 0, len(m))
+	for _, k := range keys {
+		rp := m[k]
+		points := rp.Emitter.Emit()
+		for i := len(points) - 1; i >= 0; i-- {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+			// Set the points time to the interval time if the reducer didn't provide one.
+			if points[i].Time == ZeroTime {
+				points[i].Time = startTime
+			} else {
+				sortedByTime = false
+			}
+			a = append(a, points[i])
+		}
+	}
+
+	// Points may be out of order. Perform a stable sort by time if requested.
+	if !sortedByTime && itr.opt.Ordered {
+		sort.Stable(sort.Reverse(booleanPointsByTime(a)))
+	}
+
+	return a, nil
+}
+
+// integerStreamBooleanIterator streams inputs into the iterator and emits points gradually.
+type integerStreamBooleanIterator struct {
+	input  *bufIntegerIterator
+	create func() (IntegerPointAggregator, BooleanPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	m      map[string]*integerReduceBooleanPoint
+	points []BooleanPoint
+}
+
+// newIntegerStreamBooleanIterator returns a new instance of integerStreamBooleanIterator.
+func newIntegerStreamBooleanIterator(input IntegerIterator, createFn func() (IntegerPointAggregator, BooleanPointEmitter), opt IteratorOptions) *integerStreamBooleanIterator {
+	return &integerStreamBooleanIterator{
+		input:  newBufIntegerIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+		m:      make(map[string]*integerReduceBooleanPoint),
+	}
+}
+
+// Stats returns stats from the input iterator.
+func (itr *integerStreamBooleanIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *integerStreamBooleanIterator) Close() error { return itr.input.Close() }
+
+// Next returns the next value for the stream iterator.
+func (itr *integerStreamBooleanIterator) Next() (*BooleanPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
+		}
+	}
+
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
+}
+
+// reduce creates and manages aggregators for every point from the input.
+// After aggregating a point, it always tries to emit a value using the emitter.
+func (itr *integerStreamBooleanIterator) reduce() ([]BooleanPoint, error) {
+	for {
+		// Read next point.
+		curr, err := itr.input.Next()
+		if curr == nil {
+			// Close all of the aggregators to flush any remaining points to emit.
+			var points []BooleanPoint
+			for _, rp := range itr.m {
+				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
+					if err := aggregator.Close(); err != nil {
+						return nil, err
+					}
+
+					pts := rp.Emitter.Emit()
+					if len(pts) == 0 {
+						continue
+					}
+
+					for i := range pts {
+						pts[i].Name = rp.Name
+						pts[i].Tags = rp.Tags
+					}
+					points = append(points, pts...)
+				}
+			}
+
+			// Eliminate the aggregators and emitters.
+			itr.m = nil
+			return points, nil
+		} else if err != nil {
+			return nil, err
+		} else if curr.Nil {
+			continue
+		}
+		tags := curr.Tags.Subset(itr.dims)
+
+		id := curr.Name
+		if len(tags.m) > 0 {
+			id += "\x00" + tags.ID()
+		}
+
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := itr.m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &integerReduceBooleanPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			itr.m[id] = rp
+		}
+		rp.Aggregator.AggregateInteger(curr)
+
+		// Attempt to emit points from the aggregator.
+		points := rp.Emitter.Emit()
+		if len(points) == 0 {
+			continue
+		}
+
+		for i := range points {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+		}
+		return points, nil
+	}
+}
+
+// integerBooleanExprIterator executes a function to modify an existing point
+// for every output of the input iterator.
+type integerBooleanExprIterator struct {
+	left      *bufIntegerIterator
+	right     *bufIntegerIterator
+	fn        integerBooleanExprFunc
+	points    []IntegerPoint // must be size 2
+	storePrev bool
+}
+
+func newIntegerBooleanExprIterator(left, right IntegerIterator, opt IteratorOptions, fn func(a, b int64) bool) *integerBooleanExprIterator {
+	var points []IntegerPoint
+	switch opt.Fill {
+	case NullFill, PreviousFill:
+		points = []IntegerPoint{{Nil: true}, {Nil: true}}
+	case NumberFill:
+		value := castToInteger(opt.FillValue)
+		points = []IntegerPoint{{Value: value}, {Value: value}}
+	}
+	return &integerBooleanExprIterator{
+		left:      newBufIntegerIterator(left),
+		right:     newBufIntegerIterator(right),
+		points:    points,
+		fn:        fn,
+		storePrev: opt.Fill == PreviousFill,
+	}
+}
+
+func (itr *integerBooleanExprIterator) Stats() IteratorStats {
+	stats := itr.left.Stats()
+	stats.Add(itr.right.Stats())
+	return stats
+}
+
+func (itr *integerBooleanExprIterator) Close() error {
+	itr.left.Close()
+	itr.right.Close()
+	return nil
+}
+
+func (itr *integerBooleanExprIterator) Next() (*BooleanPoint, error) {
+	for {
+		a, b, err := itr.next()
+		if err != nil || (a == nil && b == nil) {
+			return nil, err
+		}
+
+		// If any of these are nil and we are using fill(none), skip these points.
+		if (a == nil || a.Nil || b == nil || b.Nil) && itr.points == nil {
+			continue
+		}
+
+		// If one of the two points is nil, we need to fill it with a fake nil
+		// point that has the same name, tags, and time as the other point.
+		// There should never be a time when both of these are nil.
+		if a == nil {
+			p := *b
+			a = &p
+			a.Value = 0
+			a.Nil = true
+		} else if b == nil {
+			p := *a
+			b = &p
+			b.Value = 0
+			b.Nil = true
+		}
+
+		// If a value is nil, use the fill values if the fill value is non-nil.
+		if a.Nil && !itr.points[0].Nil {
+			a.Value = itr.points[0].Value
+			a.Nil = false
+		}
+		if b.Nil && !itr.points[1].Nil {
+			b.Value = itr.points[1].Value
+			b.Nil = false
+		}
+
+		if itr.storePrev {
+			itr.points[0], itr.points[1] = *a, *b
+		}
+
+		p := &BooleanPoint{
+			Name:       a.Name,
+			Tags:       a.Tags,
+			Time:       a.Time,
+			Nil:        a.Nil || b.Nil,
+			Aggregated: a.Aggregated,
+		}
+		if !p.Nil {
+			p.Value = itr.fn(a.Value, b.Value)
+		}
+		return p, nil
+
+	}
+}
+
+// next returns the next points within each iterator. If the iterators are
+// uneven, it organizes them so only matching points are returned.
+func (itr *integerBooleanExprIterator) next() (a, b *IntegerPoint, err error) {
+	// Retrieve the next value for both the left and right.
+	a, err = itr.left.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err = itr.right.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If we have a point from both, make sure that they match each other.
+	if a != nil && b != nil {
+		if a.Name > b.Name {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Name < b.Name {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+
+		if ltags, rtags := a.Tags.ID(), b.Tags.ID(); ltags > rtags {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if ltags < rtags {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+
+		if a.Time > b.Time {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Time < b.Time {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+	}
+	return a, b, nil
+}
+
+// integerBooleanExprFunc creates or modifies a point by combining two
+// points. The point passed in may be modified and returned rather than
+// allocating a new point if possible. One of the points may be nil, but at
+// least one of the points will be non-nil.
+type integerBooleanExprFunc func(a, b int64) bool
+
+// integerTransformIterator executes a function to modify an existing point for every
+// output of the input iterator.
+type integerTransformIterator struct {
+	input IntegerIterator
+	fn    integerTransformFunc
+}
+
+// Stats returns stats from the input iterator.
+func (itr *integerTransformIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *integerTransformIterator) Close() error { return itr.input.Close() }
+
+// Next returns the minimum value for the next available interval.
+func (itr *integerTransformIterator) Next() (*IntegerPoint, error) {
+	p, err := itr.input.Next()
+	if err != nil {
+		return nil, err
+	} else if p != nil {
+		p = itr.fn(p)
+	}
+	return p, nil
+}
+
+// integerTransformFunc creates or modifies a point.
+// The point passed in may be modified and returned rather than allocating a
+// new point if possible.
+type integerTransformFunc func(p *IntegerPoint) *IntegerPoint
+
+// integerBoolTransformIterator executes a function to modify an existing point for every
+// output of the input iterator.
+type integerBoolTransformIterator struct {
+	input IntegerIterator
+	fn    integerBoolTransformFunc
+}
+
+// Stats returns stats from the input iterator.
+func (itr *integerBoolTransformIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *integerBoolTransformIterator) Close() error { return itr.input.Close() }
+
+// Next returns the minimum value for the next available interval.
+func (itr *integerBoolTransformIterator) Next() (*BooleanPoint, error) {
+	p, err := itr.input.Next()
+	if err != nil {
+		return nil, err
+	} else if p != nil {
+		return itr.fn(p), nil
+	}
+	return nil, nil
+}
+
+// integerBoolTransformFunc creates or modifies a point.
+// The point passed in may be modified and returned rather than allocating a
+// new point if possible.
+type integerBoolTransformFunc func(p *IntegerPoint) *BooleanPoint
+
+// integerDedupeIterator only outputs unique points.
+// This differs from the DistinctIterator in that it compares all aux fields too.
+// This iterator is relatively inefficient and should only be used on small
+// datasets such as meta query results.
+type integerDedupeIterator struct {
+	input IntegerIterator
+	m     map[string]struct{} // lookup of points already sent
+}
+
+type integerIteratorMapper struct {
+	e      *Emitter
+	buf    []interface{}
+	driver IteratorMap   // which iterator to use for the primary value, can be nil
+	fields []IteratorMap // which iterator to use for an aux field
+	point  IntegerPoint
+}
+
+func newIntegerIteratorMapper(itrs []Iterator, driver IteratorMap, fields []IteratorMap, opt IteratorOptions) *integerIteratorMapper {
+	e := NewEmitter(itrs, opt.Ascending, 0)
+	e.OmitTime = true
+	return &integerIteratorMapper{
+		e:      e,
+		buf:    make([]interface{}, len(itrs)),
+		driver: driver,
+		fields: fields,
+		point: IntegerPoint{
+			Aux: make([]interface{}, len(fields)),
+		},
+	}
+}
+
+func (itr *integerIteratorMapper) Next() (*IntegerPoint, error) {
+	t, name, tags, err := itr.e.loadBuf()
+	if err != nil || t == ZeroTime {
+		return nil, err
+	}
+	itr.point.Time = t
+	itr.point.Name = name
+	itr.point.Tags = tags
+
+	itr.e.readInto(t, name, tags, itr.buf)
+	if itr.driver != nil {
+		if v := itr.driver.Value(tags, itr.buf); v != nil {
+			if v, ok := v.(int64); ok {
+				itr.point.Value = v
+				itr.point.Nil = false
+			} else {
+				itr.point.Value = 0
+				itr.point.Nil = true
+			}
+		} else {
+			itr.point.Value = 0
+			itr.point.Nil = true
+		}
+	}
+	for i, f := range itr.fields {
+		itr.point.Aux[i] = f.Value(tags, itr.buf)
+	}
+	return &itr.point, nil
+}
+
+func (itr *integerIteratorMapper) Stats() IteratorStats {
+	stats := IteratorStats{}
+	for _, itr := range itr.e.itrs {
+		stats.Add(itr.Stats())
+	}
+	return stats
+}
+
+func (itr *integerIteratorMapper) Close() error {
+	return itr.e.Close()
+}
+
+type integerFilterIterator struct {
+	input IntegerIterator
+	cond  Expr
+	opt   IteratorOptions
+	m     map[string]interface{}
+}
+
+func newIntegerFilterIterator(input IntegerIterator, cond Expr, opt IteratorOptions) IntegerIterator {
+	// Strip out time conditions from the WHERE clause.
+	// TODO(jsternberg): This should really be done for us when creating the IteratorOptions struct.
+	n := RewriteFunc(CloneExpr(cond), func(n Node) Node {
+		switch n := n.(type) {
+		case *BinaryExpr:
+			if n.LHS.String() == "time" {
+				return &BooleanLiteral{Val: true}
+			}
+		}
+		return n
+	})
+
+	cond, _ = n.(Expr)
+	if cond == nil {
+		return input
+	} else if n, ok := cond.(*BooleanLiteral); ok && n.Val {
+		return input
+	}
+
+	return &integerFilterIterator{
+		input: input,
+		cond:  cond,
+		opt:   opt,
+		m:     make(map[string]interface{}),
+	}
+}
+
+func (itr *integerFilterIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *integerFilterIterator) Close() error         { return itr.input.Close() }
+
+func (itr *integerFilterIterator) Next() (*IntegerPoint, error) {
+	for {
+		p, err := itr.input.Next()
+		if err != nil || p == nil {
+			return nil, err
+		}
+
+		for i, ref := range itr.opt.Aux {
+			itr.m[ref.Val] = p.Aux[i]
+		}
+		for k, v := range p.Tags.KeyValues() {
+			itr.m[k] = v
+		}
+
+		if !EvalBool(itr.cond, itr.m) {
+			continue
+		}
+		return p, nil
+	}
+}
+
+// newIntegerDedupeIterator returns a new instance of integerDedupeIterator.
+func newIntegerDedupeIterator(input IntegerIterator) *integerDedupeIterator {
+	return &integerDedupeIterator{
+		input: input,
+		m:     make(map[string]struct{}),
+	}
+}
+
+// Stats returns stats from the input iterator.
+func (itr *integerDedupeIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *integerDedupeIterator) Close() error { return itr.input.Close() }
+
+// Next returns the next unique point from the input iterator.
+func (itr *integerDedupeIterator) Next() (*IntegerPoint, error) {
+	for {
+		// Read next point.
+		p, err := itr.input.Next()
+		if p == nil || err != nil {
+			return nil, err
+		}
+
+		// Serialize to bytes to store in lookup.
+		buf, err := proto.Marshal(encodeIntegerPoint(p))
+		if err != nil {
+			return nil, err
+		}
+
+		// If the point has already been output then move to the next point.
+		if _, ok := itr.m[string(buf)]; ok {
+			continue
+		}
+
+		// Otherwise mark it as emitted and return point.
+		itr.m[string(buf)] = struct{}{}
+		return p, nil
+	}
+}
+
+// integerReaderIterator represents an iterator that streams from a reader.
+type integerReaderIterator struct {
+	r   io.Reader
+	dec *IntegerPointDecoder
+}
+
+// newIntegerReaderIterator returns a new instance of integerReaderIterator.
+func newIntegerReaderIterator(r io.Reader, stats IteratorStats) *integerReaderIterator {
+	dec := NewIntegerPointDecoder(r)
+	dec.stats = stats
+
+	return &integerReaderIterator{
+		r:   r,
+		dec: dec,
+	}
+}
+
+// Stats returns stats about points processed.
+func (itr *integerReaderIterator) Stats() IteratorStats { return itr.dec.stats }
 
 // Close closes the underlying reader, if applicable.
-func (itr *floatReaderIterator) Close() error {
+func (itr *integerReaderIterator) Close() error {
 	if r, ok := itr.r.(io.ReadCloser); ok {
 		return r.Close()
 	}
 	return nil
 }
 
-// Next returns the next point from the iterator.
-func (itr *floatReaderIterator) Next() (*FloatPoint, error) {
-	// OPTIMIZE(benbjohnson): Reuse point on iterator.
+// Next returns the next point from the iterator.
+func (itr *integerReaderIterator) Next() (*IntegerPoint, error) {
+	// OPTIMIZE(benbjohnson): Reuse point on iterator.
+
+	// Unmarshal next point.
+	p := &IntegerPoint{}
+	if err := itr.dec.DecodeIntegerPoint(p); err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// UnsignedIterator represents a stream of unsigned points.
+type UnsignedIterator interface {
+	Iterator
+	Next() (*UnsignedPoint, error)
+}
+
+// newUnsignedIterators converts a slice of Iterator to a slice of UnsignedIterator.
+// Drop and closes any iterator in itrs that is not a UnsignedIterator and cannot
+// be cast to a UnsignedIterator.
+func newUnsignedIterators(itrs []Iterator) []UnsignedIterator {
+	a := make([]UnsignedIterator, 0, len(itrs))
+	for _, itr := range itrs {
+		switch itr := itr.(type) {
+		case UnsignedIterator:
+			a = append(a, itr)
+
+		default:
+			itr.Close()
+		}
+	}
+	return a
+}
+
+// bufUnsignedIterator represents a buffered UnsignedIterator.
+type bufUnsignedIterator struct {
+	itr UnsignedIterator
+	buf *UnsignedPoint
+}
+
+// newBufUnsignedIterator returns a buffered UnsignedIterator.
+func newBufUnsignedIterator(itr UnsignedIterator) *bufUnsignedIterator {
+	return &bufUnsignedIterator{itr: itr}
+}
+
+// Stats returns statistics from the input iterator.
+func (itr *bufUnsignedIterator) Stats() IteratorStats { return itr.itr.Stats() }
+
+// Close closes the underlying iterator.
+func (itr *bufUnsignedIterator) Close() error { return itr.itr.Close() }
+
+// peek returns the next point without removing it from the iterator.
+func (itr *bufUnsignedIterator) peek() (*UnsignedPoint, error) {
+	p, err := itr.Next()
+	if err != nil {
+		return nil, err
+	}
+	itr.unread(p)
+	return p, nil
+}
+
+// peekTime returns the time of the next point.
+// Returns zero time if no more points available.
+func (itr *bufUnsignedIterator) peekTime() (int64, error) {
+	p, err := itr.peek()
+	if p == nil || err != nil {
+		return ZeroTime, err
+	}
+	return p.Time, nil
+}
+
+// Next returns the current buffer, if exists, or calls the underlying iterator.
+func (itr *bufUnsignedIterator) Next() (*UnsignedPoint, error) {
+	buf := itr.buf
+	if buf != nil {
+		itr.buf = nil
+		return buf, nil
+	}
+	return itr.itr.Next()
+}
+
+// NextInWindow returns the next value if it is between [startTime, endTime).
+// If the next value is outside the range then it is moved to the buffer.
+func (itr *bufUnsignedIterator) NextInWindow(startTime, endTime int64) (*UnsignedPoint, error) {
+	v, err := itr.Next()
+	if v == nil || err != nil {
+		return nil, err
+	} else if t := v.Time; t >= endTime || t < startTime {
+		itr.unread(v)
+		return nil, nil
+	}
+	return v, nil
+}
+
+// unread sets v to the buffer. It is read on the next call to Next().
+func (itr *bufUnsignedIterator) unread(v *UnsignedPoint) { itr.buf = v }
+
+// unsignedMergeIterator represents an iterator that combines multiple unsigned iterators.
+type unsignedMergeIterator struct {
+	inputs []UnsignedIterator
+	heap   *unsignedMergeHeap
+	init   bool
+
+	// Current iterator and window.
+	curr   *unsignedMergeHeapItem
+	window struct {
+		name      string
+		tags      string
+		startTime int64
+		endTime   int64
+	}
+}
+
+// newUnsignedMergeIterator returns a new instance of unsignedMergeIterator.
+func newUnsignedMergeIterator(inputs []UnsignedIterator, opt IteratorOptions) *unsignedMergeIterator {
+	itr := &unsignedMergeIterator{
+		inputs: inputs,
+		heap: &unsignedMergeHeap{
+			items: make([]*unsignedMergeHeapItem, 0, len(inputs)),
+			opt:   opt,
+		},
+	}
+
+	// Initialize heap items.
+	for _, input := range inputs {
+		// Wrap in buffer, ignore any inputs without anymore points.
+		bufInput := newBufUnsignedIterator(input)
+
+		// Append to the heap.
+		itr.heap.items = append(itr.heap.items, &unsignedMergeHeapItem{itr: bufInput})
+	}
+
+	return itr
+}
+
+// Stats returns an aggregation of stats from the underlying iterators.
+func (itr *unsignedMergeIterator) Stats() IteratorStats {
+	var stats IteratorStats
+	for _, input := range itr.inputs {
+		stats.Add(input.Stats())
+	}
+	return stats
+}
+
+// Close closes the underlying iterators.
+func (itr *unsignedMergeIterator) Close() error {
+	for _, input := range itr.inputs {
+		input.Close()
+	}
+	itr.curr = nil
+	itr.inputs = nil
+	itr.heap.items = nil
+	return nil
+}
+
+// Next returns the next point from the iterator.
+func (itr *unsignedMergeIterator) Next() (*UnsignedPoint, error) {
+	// Initialize the heap. This needs to be done lazily on the first call to this iterator
+	// so that iterator initialization done through the Select() call returns quickly.
+	// Queries can only be interrupted after the Select() call completes so any operations
+	// done during iterator creation cannot be interrupted, which is why we do it here
+	// instead so an interrupt can happen while initializing the heap.
+	if !itr.init {
+		items := itr.heap.items
+		itr.heap.items = make([]*unsignedMergeHeapItem, 0, len(items))
+		for _, item := range items {
+			if p, err := item.itr.peek(); err != nil {
+				return nil, err
+			} else if p == nil {
+				continue
+			}
+			itr.heap.items = append(itr.heap.items, item)
+		}
+		heap.Init(itr.heap)
+		itr.init = true
+	}
+
+	for {
+		// Retrieve the next iterator if we don't have one.
+		if itr.curr == nil {
+			if len(itr.heap.items) == 0 {
+				return nil, nil
+			}
+			itr.curr = heap.Pop(itr.heap).(*unsignedMergeHeapItem)
+
+			// Read point and set current window.
+			p, err := itr.curr.itr.Next()
+			if err != nil {
+				return nil, err
+			}
+			tags := p.Tags.Subset(itr.heap.opt.Dimensions)
+			itr.window.name, itr.window.tags = p.Name, tags.ID()
+			itr.window.startTime, itr.window.endTime = itr.heap.opt.Window(p.Time)
+			return p, nil
+		}
+
+		// Read the next point from the current iterator.
+		p, err := itr.curr.itr.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		// If there are no more points then remove iterator from heap and find next.
+		if p == nil {
+			itr.curr = nil
+			continue
+		}
+
+		// Check if the point is inside of our current window.
+		inWindow := true
+		if window := itr.window; window.name != p.Name {
+			inWindow = false
+		} else if tags := p.Tags.Subset(itr.heap.opt.Dimensions); window.tags != tags.ID() {
+			inWindow = false
+		} else if opt := itr.heap.opt; opt.Ascending && p.Time >= window.endTime {
+			inWindow = false
+		} else if !opt.Ascending && p.Time < window.startTime {
+			inWindow = false
+		}
+
+		// If it's outside our window then push iterator back on the heap and find new iterator.
+		if !inWindow {
+			itr.curr.itr.unread(p)
+			heap.Push(itr.heap, itr.curr)
+			itr.curr = nil
+			continue
+		}
+
+		return p, nil
+	}
+}
+
+// unsignedMergeHeap represents a heap of unsignedMergeHeapItems.
+// Items are sorted by their next window and then by name/tags.
+type unsignedMergeHeap struct {
+	opt   IteratorOptions
+	items []*unsignedMergeHeapItem
+}
+
+func (h *unsignedMergeHeap) Len() int      { return len(h.items) }
+func (h *unsignedMergeHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *unsignedMergeHeap) Less(i, j int) bool {
+	x, err := h.items[i].itr.peek()
+	if err != nil {
+		return true
+	}
+	y, err := h.items[j].itr.peek()
+	if err != nil {
+		return false
+	}
+
+	if h.opt.Ascending {
+		if x.Name != y.Name {
+			return x.Name < y.Name
+		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); xTags.ID() != yTags.ID() {
+			return xTags.ID() < yTags.ID()
+		}
+	} else {
+		if x.Name != y.Name {
+			return x.Name > y.Name
+		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); xTags.ID() != yTags.ID() {
+			return xTags.ID() > yTags.ID()
+		}
+	}
+
+	xt, _ := h.opt.Window(x.Time)
+	yt, _ := h.opt.Window(y.Time)
+
+	if h.opt.Ascending {
+		return xt < yt
+	}
+	return xt > yt
+}
+
+func (h *unsignedMergeHeap) Push(x interface{}) {
+	h.items = append(h.items, x.(*unsignedMergeHeapItem))
+}
+
+func (h *unsignedMergeHeap) Pop() interface{} {
+	old := h.items
+	n := len(old)
+	item := old[n-1]
+	h.items = old[0 : n-1]
+	return item
+}
+
+type unsignedMergeHeapItem struct {
+	itr *bufUnsignedIterator
+}
+
+// unsignedSortedMergeIterator is an iterator that sorts and merges multiple iterators into one.
+type unsignedSortedMergeIterator struct {
+	inputs []UnsignedIterator
+	heap   *unsignedSortedMergeHeap
+	init   bool
+}
+
+// newUnsignedSortedMergeIterator returns an instance of unsignedSortedMergeIterator.
+func newUnsignedSortedMergeIterator(inputs []UnsignedIterator, opt IteratorOptions) Iterator {
+	itr := &unsignedSortedMergeIterator{
+		inputs: inputs,
+		heap: &unsignedSortedMergeHeap{
+			items: make([]*unsignedSortedMergeHeapItem, 0, len(inputs)),
+			opt:   opt,
+		},
+	}
+
+	// Initialize heap items.
+	for _, input := range inputs {
+		// Append to the heap.
+		itr.heap.items = append(itr.heap.items, &unsignedSortedMergeHeapItem{itr: input})
+	}
+
+	return itr
+}
+
+// Stats returns an aggregation of stats from the underlying iterators.
+func (itr *unsignedSortedMergeIterator) Stats() IteratorStats {
+	var stats IteratorStats
+	for _, input := range itr.inputs {
+		stats.Add(input.Stats())
+	}
+	return stats
+}
+
+// Close closes the underlying iterators.
+func (itr *unsignedSortedMergeIterator) Close() error {
+	for _, input := range itr.inputs {
+		input.Close()
+	}
+	return nil
+}
+
+// Next returns the next points from the iterator.
+func (itr *unsignedSortedMergeIterator) Next() (*UnsignedPoint, error) { return itr.pop() }
+
+// pop returns the next point from the heap.
+// Reads the next point from item's cursor and puts it back on the heap.
+func (itr *unsignedSortedMergeIterator) pop() (*UnsignedPoint, error) {
+	// Initialize the heap. See the MergeIterator to see why this has to be done lazily.
+	if !itr.init {
+		items := itr.heap.items
+		itr.heap.items = make([]*unsignedSortedMergeHeapItem, 0, len(items))
+		for _, item := range items {
+			var err error
+			if item.point, err = item.itr.Next(); err != nil {
+				return nil, err
+			} else if item.point == nil {
+				continue
+			}
+			itr.heap.items = append(itr.heap.items, item)
+		}
+		heap.Init(itr.heap)
+		itr.init = true
+	}
+
+	if len(itr.heap.items) == 0 {
+		return nil, nil
+	}
+
+	// Read the next item from the heap.
+	item := heap.Pop(itr.heap).(*unsignedSortedMergeHeapItem)
+	if item.err != nil {
+		return nil, item.err
+	} else if item.point == nil {
+		return nil, nil
+	}
+
+	// Copy the point for return.
+	p := item.point.Clone()
+
+	// Read the next item from the cursor. Push back to heap if one exists.
+	if item.point, item.err = item.itr.Next(); item.point != nil {
+		heap.Push(itr.heap, item)
+	}
+
+	return p, nil
+}
+
+// unsignedSortedMergeHeap represents a heap of unsignedSortedMergeHeapItems.
+type unsignedSortedMergeHeap struct {
+	opt   IteratorOptions
+	items []*unsignedSortedMergeHeapItem
+}
+
+func (h *unsignedSortedMergeHeap) Len() int      { return len(h.items) }
+func (h *unsignedSortedMergeHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *unsignedSortedMergeHeap) Less(i, j int) bool {
+	x, y := h.items[i].point, h.items[j].point
+
+	if h.opt.Ascending {
+		if x.Name != y.Name {
+			return x.Name < y.Name
+		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); !xTags.Equals(&yTags) {
+			return xTags.ID() < yTags.ID()
+		}
+		return x.Time < y.Time
+	}
+
+	if x.Name != y.Name {
+		return x.Name > y.Name
+	} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); !xTags.Equals(&yTags) {
+		return xTags.ID() > yTags.ID()
+	}
+	return x.Time > y.Time
+}
+
+func (h *unsignedSortedMergeHeap) Push(x interface{}) {
+	h.items = append(h.items, x.(*unsignedSortedMergeHeapItem))
+}
+
+func (h *unsignedSortedMergeHeap) Pop() interface{} {
+	old := h.items
+	n := len(old)
+	item := old[n-1]
+	h.items = old[0 : n-1]
+	return item
+}
+
+type unsignedSortedMergeHeapItem struct {
+	point *UnsignedPoint
+	err   error
+	itr   UnsignedIterator
+}
+
+// unsignedParallelIterator represents an iterator that pulls data in a separate goroutine.
+type unsignedParallelIterator struct {
+	input UnsignedIterator
+	ch    chan unsignedPointError
+
+	once    sync.Once
+	closing chan struct{}
+	wg      sync.WaitGroup
+}
+
+// newUnsignedParallelIterator returns a new instance of unsignedParallelIterator.
+func newUnsignedParallelIterator(input UnsignedIterator) *unsignedParallelIterator {
+	itr := &unsignedParallelIterator{
+		input:   input,
+		ch:      make(chan unsignedPointError, 256),
+		closing: make(chan struct{}),
+	}
+	itr.wg.Add(1)
+	go itr.monitor()
+	return itr
+}
+
+// Stats returns stats from the underlying iterator.
+func (itr *unsignedParallelIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the underlying iterators.
+func (itr *unsignedParallelIterator) Close() error {
+	itr.once.Do(func() { close(itr.closing) })
+	itr.wg.Wait()
+	return itr.input.Close()
+}
+
+// Next returns the next point from the iterator.
+func (itr *unsignedParallelIterator) Next() (*UnsignedPoint, error) {
+	v, ok := <-itr.ch
+	if !ok {
+		return nil, io.EOF
+	}
+	return v.point, v.err
+}
+
+// monitor runs in a separate goroutine and actively pulls the next point.
+func (itr *unsignedParallelIterator) monitor() {
+	defer close(itr.ch)
+	defer itr.wg.Done()
+
+	for {
+		// Read next point.
+		p, err := itr.input.Next()
+		if p != nil {
+			p = p.Clone()
+		}
+
+		select {
+		case <-itr.closing:
+			return
+		case itr.ch <- unsignedPointError{point: p, err: err}:
+		}
+	}
+}
+
+type unsignedPointError struct {
+	point *UnsignedPoint
+	err   error
+}
+
+// unsignedLimitIterator represents an iterator that limits points per group.
+type unsignedLimitIterator struct {
+	input UnsignedIterator
+	opt   IteratorOptions
+	n     int
+
+	prev struct {
+		name string
+		tags Tags
+	}
+}
+
+// newUnsignedLimitIterator returns a new instance of unsignedLimitIterator.
+func newUnsignedLimitIterator(input UnsignedIterator, opt IteratorOptions) *unsignedLimitIterator {
+	return &unsignedLimitIterator{
+		input: input,
+		opt:   opt,
+	}
+}
+
+// Stats returns stats from the underlying iterator.
+func (itr *unsignedLimitIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the underlying iterators.
+func (itr *unsignedLimitIterator) Close() error { return itr.input.Close() }
+
+// Next returns the next point from the iterator.
+func (itr *unsignedLimitIterator) Next() (*UnsignedPoint, error) {
+	for {
+		p, err := itr.input.Next()
+		if p == nil || err != nil {
+			return nil, err
+		}
+
+		// Reset window and counter if a new window is encountered.
+		if p.Name != itr.prev.name || !p.Tags.Equals(&itr.prev.tags) {
+			itr.prev.name = p.Name
+			itr.prev.tags = p.Tags
+			itr.n = 0
+		}
+
+		// Increment counter.
+		itr.n++
+
+		// Read next point if not beyond the offset.
+		if itr.n <= itr.opt.Offset {
+			continue
+		}
+
+		// Read next point if we're beyond the limit.
+		if itr.opt.Limit > 0 && (itr.n-itr.opt.Offset) > itr.opt.Limit {
+			continue
+		}
+
+		return p, nil
+	}
+}
+
+type unsignedFillIterator struct {
+	input     *bufUnsignedIterator
+	prev      UnsignedPoint
+	startTime int64
+	endTime   int64
+	auxFields []interface{}
+	init      bool
+	opt       IteratorOptions
+
+	window struct {
+		name   string
+		tags   Tags
+		time   int64
+		offset int64
+	}
+}
+
+func newUnsignedFillIterator(input UnsignedIterator, expr Expr, opt IteratorOptions) *unsignedFillIterator {
+	if opt.Fill == NullFill {
+		if expr, ok := expr.(*Call); ok && expr.Name == "count" {
+			opt.Fill = NumberFill
+			opt.FillValue = uint64(0)
+		}
+	}
+
+	var startTime, endTime int64
+	if opt.Ascending {
+		startTime, _ = opt.Window(opt.StartTime)
+		endTime, _ = opt.Window(opt.EndTime)
+	} else {
+		startTime, _ = opt.Window(opt.EndTime)
+		endTime, _ = opt.Window(opt.StartTime)
+	}
+
+	var auxFields []interface{}
+	if len(opt.Aux) > 0 {
+		auxFields = make([]interface{}, len(opt.Aux))
+	}
+
+	return &unsignedFillIterator{
+		input:     newBufUnsignedIterator(input),
+		prev:      UnsignedPoint{Nil: true},
+		startTime: startTime,
+		endTime:   endTime,
+		auxFields: auxFields,
+		opt:       opt,
+	}
+}
+
+func (itr *unsignedFillIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *unsignedFillIterator) Close() error         { return itr.input.Close() }
+
+func (itr *unsignedFillIterator) Next() (*UnsignedPoint, error) {
+	if !itr.init {
+		p, err := itr.input.peek()
+		if p == nil || err != nil {
+			return nil, err
+		}
+		itr.window.name, itr.window.tags = p.Name, p.Tags
+		itr.window.time = itr.startTime
+		if itr.opt.Location != nil {
+			_, itr.window.offset = itr.opt.Zone(itr.window.time)
+		}
+		itr.init = true
+	}
+
+	p, err := itr.input.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the next point is outside of our window or is nil.
+	for p == nil || p.Name != itr.window.name || p.Tags.ID() != itr.window.tags.ID() {
+		// If we are inside of an interval, unread the point and continue below to
+		// constructing a new point.
+		if itr.opt.Ascending {
+			if itr.window.time <= itr.endTime {
+				itr.input.unread(p)
+				p = nil
+				break
+			}
+		} else {
+			if itr.window.time >= itr.endTime {
+				itr.input.unread(p)
+				p = nil
+				break
+			}
+		}
+
+		// We are *not* in a current interval. If there is no next point,
+		// we are at the end of all intervals.
+		if p == nil {
+			return nil, nil
+		}
+
+		// Set the new interval.
+		itr.window.name, itr.window.tags = p.Name, p.Tags
+		itr.window.time = itr.startTime
+		if itr.opt.Location != nil {
+			_, itr.window.offset = itr.opt.Zone(itr.window.time)
+		}
+		itr.prev = UnsignedPoint{Nil: true}
+		break
+	}
+
+	// Check if the point is our next expected point.
+	if p == nil || (itr.opt.Ascending && p.Time > itr.window.time) || (!itr.opt.Ascending && p.Time < itr.window.time) {
+		if p != nil {
+			itr.input.unread(p)
+		}
+
+		p = &UnsignedPoint{
+			Name: itr.window.name,
+			Tags: itr.window.tags,
+			Time: itr.window.time,
+			Aux:  itr.auxFields,
+		}
+
+		switch itr.opt.Fill {
+		case LinearFill:
+			fallthrough
+		case NullFill:
+			p.Nil = true
+		case NumberFill:
+			p.Value = castToUnsigned(itr.opt.FillValue)
+		case PreviousFill:
+			if !itr.prev.Nil {
+				p.Value = itr.prev.Value
+				p.Nil = itr.prev.Nil
+			} else {
+				p.Nil = true
+			}
+		}
+	} else {
+		itr.prev = *p
+	}
+
+	// Advance the expected time. Do not advance to a new window here
+	// as there may be lingering points with the same timestamp in the previous
+	// window.
+	if itr.opt.Ascending {
+		itr.window.time += int64(itr.opt.Interval.Duration)
+	} else {
+		itr.window.time -= int64(itr.opt.Interval.Duration)
+	}
+
+	// Check to see if we have passed over an offset change and adjust the time
+	// to account for this new offset.
+	if itr.opt.Location != nil {
+		if _, offset := itr.opt.Zone(itr.window.time); offset != itr.window.offset {
+			diff := itr.window.offset - offset
+			if abs(diff) < int64(itr.opt.Interval.Duration) {
+				itr.window.time += diff
+			}
+			itr.window.offset = offset
+		}
+	}
+	return p, nil
+}
+
+// unsignedIntervalIterator represents a unsigned implementation of IntervalIterator.
+type unsignedIntervalIterator struct {
+	input UnsignedIterator
+	opt   IteratorOptions
+}
+
+func newUnsignedIntervalIterator(input UnsignedIterator, opt IteratorOptions) *unsignedIntervalIterator {
+	return &unsignedIntervalIterator{input: input, opt: opt}
+}
+
+func (itr *unsignedIntervalIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *unsignedIntervalIterator) Close() error         { return itr.input.Close() }
+
+func (itr *unsignedIntervalIterator) Next() (*UnsignedPoint, error) {
+	p, err := itr.input.Next()
+	if p == nil || err != nil {
+		return nil, err
+	}
+	p.Time, _ = itr.opt.Window(p.Time)
+	// If we see the minimum allowable time, set the time to zero so we don't
+	// break the default returned time for aggregate queries without times.
+	if p.Time == MinTime {
+		p.Time = 0
+	}
+	return p, nil
+}
+
+// unsignedInterruptIterator represents a unsigned implementation of InterruptIterator.
+type unsignedInterruptIterator struct {
+	input   UnsignedIterator
+	closing <-chan struct{}
+	count   int
+}
+
+func newUnsignedInterruptIterator(input UnsignedIterator, closing <-chan struct{}) *unsignedInterruptIterator {
+	return &unsignedInterruptIterator{input: input, closing: closing}
+}
+
+func (itr *unsignedInterruptIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *unsignedInterruptIterator) Close() error         { return itr.input.Close() }
+
+func (itr *unsignedInterruptIterator) Next() (*UnsignedPoint, error) {
+	// Only check if the channel is closed every N points. This
+	// intentionally checks on both 0 and N so that if the iterator
+	// has been interrupted before the first point is emitted it will
+	// not emit any points.
+	if itr.count&0xFF == 0xFF {
+		select {
+		case <-itr.closing:
+			return nil, itr.Close()
+		default:
+			// Reset iterator count to zero and fall through to emit the next point.
+			itr.count = 0
+		}
+	}
+
+	// Increment the counter for every point read.
+	itr.count++
+	return itr.input.Next()
+}
+
+// unsignedCloseInterruptIterator represents a unsigned implementation of CloseInterruptIterator.
+type unsignedCloseInterruptIterator struct {
+	input   UnsignedIterator
+	closing <-chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+func newUnsignedCloseInterruptIterator(input UnsignedIterator, closing <-chan struct{}) *unsignedCloseInterruptIterator {
+	itr := &unsignedCloseInterruptIterator{
+		input:   input,
+		closing: closing,
+		done:    make(chan struct{}),
+	}
+	go itr.monitor()
+	return itr
+}
+
+func (itr *unsignedCloseInterruptIterator) monitor() {
+	select {
+	case <-itr.closing:
+		itr.Close()
+	case <-itr.done:
+	}
+}
+
+func (itr *unsignedCloseInterruptIterator) Stats() IteratorStats {
+	return itr.input.Stats()
+}
+
+func (itr *unsignedCloseInterruptIterator) Close() error {
+	itr.once.Do(func() {
+		close(itr.done)
+		itr.input.Close()
+	})
+	return nil
+}
+
+func (itr *unsignedCloseInterruptIterator) Next() (*UnsignedPoint, error) {
+	p, err := itr.input.Next()
+	if err != nil {
+		// Check if the iterator was closed.
+		select {
+		case <-itr.done:
+			return nil, nil
+		default:
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// auxUnsignedPoint represents a combination of a point and an error for the AuxIterator.
+type auxUnsignedPoint struct {
+	point *UnsignedPoint
+	err   error
+}
+
+// unsignedAuxIterator represents a unsigned implementation of AuxIterator.
+type unsignedAuxIterator struct {
+	input      *bufUnsignedIterator
+	output     chan auxUnsignedPoint
+	fields     *auxIteratorFields
+	background bool
+}
+
+func newUnsignedAuxIterator(input UnsignedIterator, opt IteratorOptions) *unsignedAuxIterator {
+	return &unsignedAuxIterator{
+		input:  newBufUnsignedIterator(input),
+		output: make(chan auxUnsignedPoint, 1),
+		fields: newAuxIteratorFields(opt),
+	}
+}
+
+func (itr *unsignedAuxIterator) Background() {
+	itr.background = true
+	itr.Start()
+	go DrainIterator(itr)
+}
+
+func (itr *unsignedAuxIterator) Start()               { go itr.stream() }
+func (itr *unsignedAuxIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *unsignedAuxIterator) Close() error         { return itr.input.Close() }
+func (itr *unsignedAuxIterator) Next() (*UnsignedPoint, error) {
+	p := <-itr.output
+	return p.point, p.err
+}
+func (itr *unsignedAuxIterator) Iterator(name string, typ DataType) Iterator {
+	return itr.fields.iterator(name, typ)
+}
+
+func (itr *unsignedAuxIterator) stream() {
+	for {
+		// Read next point.
+		p, err := itr.input.Next()
+		if err != nil {
+			itr.output <- auxUnsignedPoint{err: err}
+			itr.fields.sendError(err)
+			break
+		} else if p == nil {
+			break
+		}
+
+		// Send point to output and to each field iterator.
+		itr.output <- auxUnsignedPoint{point: p}
+		if ok := itr.fields.send(p); !ok && itr.background {
+			break
+		}
+	}
+
+	close(itr.output)
+	itr.fields.close()
+}
+
+// unsignedChanIterator represents a new instance of unsignedChanIterator.
+type unsignedChanIterator struct {
+	buf struct {
+		i      int
+		filled bool
+		points [2]UnsignedPoint
+	}
+	err  error
+	cond *sync.Cond
+	done bool
+}
+
+func (itr *unsignedChanIterator) Stats() IteratorStats { return IteratorStats{} }
+
+func (itr *unsignedChanIterator) Close() error {
+	itr.cond.L.Lock()
+	// Mark the channel iterator as done and signal all waiting goroutines to start again.
+	itr.done = true
+	itr.cond.Broadcast()
+	// Do not defer the unlock so we don't create an unnecessary allocation.
+	itr.cond.L.Unlock()
+	return nil
+}
+
+func (itr *unsignedChanIterator) setBuf(name string, tags Tags, time int64, value interface{}) bool {
+	itr.cond.L.Lock()
+	defer itr.cond.L.Unlock()
 
-	// Unmarshal next point.
-	p := &FloatPoint{}
-	if err := itr.dec.DecodeFloatPoint(p); err == io.EOF {
+	// Wait for either the iterator to be done (so we don't have to set the value)
+	// or for the buffer to have been read and ready for another write.
+	for !itr.done && itr.buf.filled {
+		itr.cond.Wait()
+	}
+
+	// Do not set the value and return false to signal that the iterator is closed.
+	// Do this after the above wait as the above for loop may have exited because
+	// the iterator was closed.
+	if itr.done {
+		return false
+	}
+
+	switch v := value.(type) {
+	case uint64:
+		itr.buf.points[itr.buf.i] = UnsignedPoint{Name: name, Tags: tags, Time: time, Value: v}
+
+	default:
+		itr.buf.points[itr.buf.i] = UnsignedPoint{Name: name, Tags: tags, Time: time, Nil: true}
+	}
+	itr.buf.filled = true
+
+	// Signal to all waiting goroutines that a new value is ready to read.
+	itr.cond.Signal()
+	return true
+}
+
+func (itr *unsignedChanIterator) setErr(err error) {
+	itr.cond.L.Lock()
+	defer itr.cond.L.Unlock()
+	itr.err = err
+
+	// Signal to all waiting goroutines that a new value is ready to read.
+	itr.cond.Signal()
+}
+
+func (itr *unsignedChanIterator) Next() (*UnsignedPoint, error) {
+	itr.cond.L.Lock()
+	defer itr.cond.L.Unlock()
+
+	// Check for an error and return one if there.
+	if itr.err != nil {
+		return nil, itr.err
+	}
+
+	// Wait until either a value is available in the buffer or
+	// the iterator is closed.
+	for !itr.done && !itr.buf.filled {
+		itr.cond.Wait()
+	}
+
+	// Return nil once the channel is done and the buffer is empty.
+	if itr.done && !itr.buf.filled {
 		return nil, nil
-	} else if err != nil {
-		return nil, err
 	}
+
+	// Always read from the buffer if it exists, even if the iterator
+	// is closed. This prevents the last value from being truncated by
+	// the parent iterator.
+	p := &itr.buf.points[itr.buf.i]
+	itr.buf.i = (itr.buf.i + 1) % len(itr.buf.points)
+	itr.buf.filled = false
+	itr.cond.Signal()
 	return p, nil
 }
 
-// IntegerIterator represents a stream of integer points.
-type IntegerIterator interface {
-	Iterator
-	Next() (*IntegerPoint, error)
+// unsignedReduceFloatIterator executes a reducer for every interval and buffers the result.
+type unsignedReduceFloatIterator struct {
+	input  *bufUnsignedIterator
+	create func() (UnsignedPointAggregator, FloatPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	points []FloatPoint
 }
 
-// newIntegerIterators converts a slice of Iterator to a slice of IntegerIterator.
-// Drop and closes any iterator in itrs that is not a IntegerIterator and cannot
-// be cast to a IntegerIterator.
-func newIntegerIterators(itrs []Iterator) []IntegerIterator {
-	a := make([]IntegerIterator, 0, len(itrs))
-	for _, itr := range itrs {
-		switch itr := itr.(type) {
-		case IntegerIterator:
-			a = append(a, itr)
+func newUnsignedReduceFloatIterator(input UnsignedIterator, opt IteratorOptions, createFn func() (UnsignedPointAggregator, FloatPointEmitter)) *unsignedReduceFloatIterator {
+	return &unsignedReduceFloatIterator{
+		input:  newBufUnsignedIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+	}
+}
 
-		default:
-			itr.Close()
+// Stats returns stats from the input iterator.
+func (itr *unsignedReduceFloatIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *unsignedReduceFloatIterator) Close() error { return itr.input.Close() }
+
+// Next returns the minimum value for the next available interval.
+func (itr *unsignedReduceFloatIterator) Next() (*FloatPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
 		}
 	}
-	return a
-}
 
-// bufIntegerIterator represents a buffered IntegerIterator.
-type bufIntegerIterator struct {
-	itr IntegerIterator
-	buf *IntegerPoint
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
 }
 
-// newBufIntegerIterator returns a buffered IntegerIterator.
-func newBufIntegerIterator(itr IntegerIterator) *bufIntegerIterator {
-	return &bufIntegerIterator{itr: itr}
+// unsignedReduceFloatPoint stores the reduced data for a name/tag combination.
+type unsignedReduceFloatPoint struct {
+	Name       string
+	Tags       Tags
+	Aggregator UnsignedPointAggregator
+	Emitter    FloatPointEmitter
 }
 
-// Stats returns statistics from the input iterator.
-func (itr *bufIntegerIterator) Stats() IteratorStats { return itr.itr.Stats() }
+// reduce executes fn once for every point in the next window.
+// The previous value for the dimension is passed to fn.
+func (itr *unsignedReduceFloatIterator) reduce() ([]FloatPoint, error) {
+	// Calculate next window.
+	var (
+		startTime, endTime int64
+		window             struct {
+			name string
+			tags string
+		}
+	)
+	for {
+		p, err := itr.input.Next()
+		if err != nil || p == nil {
+			return nil, err
+		} else if p.Nil {
+			continue
+		}
 
-// Close closes the underlying iterator.
-func (itr *bufIntegerIterator) Close() error { return itr.itr.Close() }
+		// Unread the point so it can be processed.
+		itr.input.unread(p)
+		startTime, endTime = itr.opt.Window(p.Time)
+		window.name, window.tags = p.Name, p.Tags.Subset(itr.opt.Dimensions).ID()
+		break
+	}
 
-// peek returns the next point without removing it from the iterator.
-func (itr *bufIntegerIterator) peek() (*IntegerPoint, error) {
-	p, err := itr.Next()
-	if err != nil {
-		return nil, err
+	// Create points by tags.
+	m := make(map[string]*unsignedReduceFloatPoint)
+	for {
+		// Read next point.
+		curr, err := itr.input.NextInWindow(startTime, endTime)
+		if err != nil {
+			return nil, err
+		} else if curr == nil {
+			break
+		} else if curr.Nil {
+			continue
+		} else if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		}
+
+		// Ensure this point is within the same final window.
+		if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		} else if tags := curr.Tags.Subset(itr.opt.Dimensions); tags.ID() != window.tags {
+			itr.input.unread(curr)
+			break
+		}
+
+		// Retrieve the tags on this point for this level of the query.
+		// This may be different than the bucket dimensions.
+		tags := curr.Tags.Subset(itr.dims)
+		id := tags.ID()
+
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &unsignedReduceFloatPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			m[id] = rp
+		}
+		rp.Aggregator.AggregateUnsigned(curr)
+	}
+
+	// Reverse sort points by name & tag if our output is supposed to be ordered.
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	if len(keys) > 1 && itr.opt.Ordered {
+		sort.Sort(reverseStringSlice(keys))
+	}
+
+	// Assume the points are already sorted until proven otherwise.
+	sortedByTime := true
+	// Emit the points for each name & tag combination.
+	a := make([]FloatPoint, 0, len(m))
+	for _, k := range keys {
+		rp := m[k]
+		points := rp.Emitter.Emit()
+		for i := len(points) - 1; i >= 0; i-- {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+			// Set the points time to the interval time if the reducer didn't provide one.
+			if points[i].Time == ZeroTime {
+				points[i].Time = startTime
+			} else {
+				sortedByTime = false
+			}
+			a = append(a, points[i])
+		}
 	}
-	itr.unread(p)
-	return p, nil
-}
 
-// peekTime returns the time of the next point.
-// Returns zero time if no more points available.
-func (itr *bufIntegerIterator) peekTime() (int64, error) {
-	p, err := itr.peek()
-	if p == nil || err != nil {
-		return ZeroTime, err
+	// Points may be out of order. Perform a stable sort by time if requested.
+	if !sortedByTime && itr.opt.Ordered {
+		sort.Stable(sort.Reverse(floatPointsByTime(a)))
 	}
-	return p.Time, nil
+
+	return a, nil
 }
 
-// Next returns the current buffer, if exists, or calls the underlying iterator.
-func (itr *bufIntegerIterator) Next() (*IntegerPoint, error) {
-	buf := itr.buf
-	if buf != nil {
-		itr.buf = nil
-		return buf, nil
-	}
-	return itr.itr.Next()
+// unsignedStreamFloatIterator streams inputs into the iterator and emits points gradually.
+type unsignedStreamFloatIterator struct {
+	input  *bufUnsignedIterator
+	create func() (UnsignedPointAggregator, FloatPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	m      map[string]*unsignedReduceFloatPoint
+	points []FloatPoint
 }
 
-// NextInWindow returns the next value if it is between [startTime, endTime).
-// If the next value is outside the range then it is moved to the buffer.
-func (itr *bufIntegerIterator) NextInWindow(startTime, endTime int64) (*IntegerPoint, error) {
-	v, err := itr.Next()
-	if v == nil || err != nil {
-		return nil, err
-	} else if t := v.Time; t >= endTime || t < startTime {
-		itr.unread(v)
-		return nil, nil
+// newUnsignedStreamFloatIterator returns a new instance of unsignedStreamFloatIterator.
+func newUnsignedStreamFloatIterator(input UnsignedIterator, createFn func() (UnsignedPointAggregator, FloatPointEmitter), opt IteratorOptions) *unsignedStreamFloatIterator {
+	return &unsignedStreamFloatIterator{
+		input:  newBufUnsignedIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+		m:      make(map[string]*unsignedReduceFloatPoint),
 	}
-	return v, nil
 }
 
-// unread sets v to the buffer. It is read on the next call to Next().
-func (itr *bufIntegerIterator) unread(v *IntegerPoint) { itr.buf = v }
+// Stats returns stats from the input iterator.
+func (itr *unsignedStreamFloatIterator) Stats() IteratorStats { return itr.input.Stats() }
 
-// integerMergeIterator represents an iterator that combines multiple integer iterators.
-type integerMergeIterator struct {
-	inputs []IntegerIterator
-	heap   *integerMergeHeap
-	init   bool
+// Close closes the iterator and all child iterators.
+func (itr *unsignedStreamFloatIterator) Close() error { return itr.input.Close() }
 
-	// Current iterator and window.
-	curr   *integerMergeHeapItem
-	window struct {
-		name      string
-		tags      string
-		startTime int64
-		endTime   int64
+// Next returns the next value for the stream iterator.
+func (itr *unsignedStreamFloatIterator) Next() (*FloatPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
+		}
 	}
+
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
 }
 
-// newIntegerMergeIterator returns a new instance of integerMergeIterator.
-func newIntegerMergeIterator(inputs []IntegerIterator, opt IteratorOptions) *integerMergeIterator {
-	itr := &integerMergeIterator{
-		inputs: inputs,
-		heap: &integerMergeHeap{
-			items: make([]*integerMergeHeapItem, 0, len(inputs)),
-			opt:   opt,
-		},
-	}
+// reduce creates and manages aggregators for every point from the input.
+// After aggregating a point, it always tries to emit a value using the emitter.
+func (itr *unsignedStreamFloatIterator) reduce() ([]FloatPoint, error) {
+	for {
+		// Read next point.
+		curr, err := itr.input.Next()
+		if curr == nil {
+			// Close all of the aggregators to flush any remaining points to emit.
+			var points []FloatPoint
+			for _, rp := range itr.m {
+				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
+					if err := aggregator.Close(); err != nil {
+						return nil, err
+					}
 
-	// Initialize heap items.
-	for _, input := range inputs {
-		// Wrap in buffer, ignore any inputs without anymore points.
-		bufInput := newBufIntegerIterator(input)
+					pts := rp.Emitter.Emit()
+					if len(pts) == 0 {
+						continue
+					}
 
-		// Append to the heap.
-		itr.heap.items = append(itr.heap.items, &integerMergeHeapItem{itr: bufInput})
+					for i := range pts {
+						pts[i].Name = rp.Name
+						pts[i].Tags = rp.Tags
+					}
+					points = append(points, pts...)
+				}
+			}
+
+			// Eliminate the aggregators and emitters.
+			itr.m = nil
+			return points, nil
+		} else if err != nil {
+			return nil, err
+		} else if curr.Nil {
+			continue
+		}
+		tags := curr.Tags.Subset(itr.dims)
+
+		id := curr.Name
+		if len(tags.m) > 0 {
+			id += "\x00" + tags.ID()
+		}
+
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := itr.m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &unsignedReduceFloatPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			itr.m[id] = rp
+		}
+		rp.Aggregator.AggregateUnsigned(curr)
+
+		// Attempt to emit points from the aggregator.
+		points := rp.Emitter.Emit()
+		if len(points) == 0 {
+			continue
+		}
+
+		for i := range points {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+		}
+		return points, nil
 	}
+}
 
-	return itr
+// unsignedFloatExprIterator executes a function to modify an existing point
+// for every output of the input iterator.
+type unsignedFloatExprIterator struct {
+	left      *bufUnsignedIterator
+	right     *bufUnsignedIterator
+	fn        unsignedFloatExprFunc
+	points    []UnsignedPoint // must be size 2
+	storePrev bool
 }
 
-// Stats returns an aggregation of stats from the underlying iterators.
-func (itr *integerMergeIterator) Stats() IteratorStats {
-	var stats IteratorStats
-	for _, input := range itr.inputs {
-		stats.Add(input.Stats())
+func newUnsignedFloatExprIterator(left, right UnsignedIterator, opt IteratorOptions, fn func(a, b uint64) float64) *unsignedFloatExprIterator {
+	var points []UnsignedPoint
+	switch opt.Fill {
+	case NullFill, PreviousFill:
+		points = []UnsignedPoint{{Nil: true}, {Nil: true}}
+	case NumberFill:
+		value := castToUnsigned(opt.FillValue)
+		points = []UnsignedPoint{{Value: value}, {Value: value}}
+	}
+	return &unsignedFloatExprIterator{
+		left:      newBufUnsignedIterator(left),
+		right:     newBufUnsignedIterator(right),
+		points:    points,
+		fn:        fn,
+		storePrev: opt.Fill == PreviousFill,
 	}
+}
+
+func (itr *unsignedFloatExprIterator) Stats() IteratorStats {
+	stats := itr.left.Stats()
+	stats.Add(itr.right.Stats())
 	return stats
 }
 
-// Close closes the underlying iterators.
-func (itr *integerMergeIterator) Close() error {
-	for _, input := range itr.inputs {
-		input.Close()
-	}
-	itr.curr = nil
-	itr.inputs = nil
-	itr.heap.items = nil
+func (itr *unsignedFloatExprIterator) Close() error {
+	itr.left.Close()
+	itr.right.Close()
 	return nil
 }
 
-// Next returns the next point from the iterator.
-func (itr *integerMergeIterator) Next() (*IntegerPoint, error) {
-	// Initialize the heap. This needs to be done lazily on the first call to this iterator
-	// so that iterator initialization done through the Select() call returns quickly.
-	// Queries can only be interrupted after the Select() call completes so any operations
-	// done during iterator creation cannot be interrupted, which is why we do it here
-	// instead so an interrupt can happen while initializing the heap.
-	if !itr.init {
-		items := itr.heap.items
-		itr.heap.items = make([]*integerMergeHeapItem, 0, len(items))
-		for _, item := range items {
-			if p, err := item.itr.peek(); err != nil {
-				return nil, err
-			} else if p == nil {
-				continue
-			}
-			itr.heap.items = append(itr.heap.items, item)
-		}
-		heap.Init(itr.heap)
-		itr.init = true
-	}
-
+func (itr *unsignedFloatExprIterator) Next() (*FloatPoint, error) {
 	for {
-		// Retrieve the next iterator if we don't have one.
-		if itr.curr == nil {
-			if len(itr.heap.items) == 0 {
-				return nil, nil
-			}
-			itr.curr = heap.Pop(itr.heap).(*integerMergeHeapItem)
-
-			// Read point and set current window.
-			p, err := itr.curr.itr.Next()
-			if err != nil {
-				return nil, err
-			}
-			tags := p.Tags.Subset(itr.heap.opt.Dimensions)
-			itr.window.name, itr.window.tags = p.Name, tags.ID()
-			itr.window.startTime, itr.window.endTime = itr.heap.opt.Window(p.Time)
-			return p, nil
-		}
-
-		// Read the next point from the current iterator.
-		p, err := itr.curr.itr.Next()
-		if err != nil {
+		a, b, err := itr.next()
+		if err != nil || (a == nil && b == nil) {
 			return nil, err
 		}
 
-		// If there are no more points then remove iterator from heap and find next.
-		if p == nil {
-			itr.curr = nil
+		// If any of these are nil and we are using fill(none), skip these points.
+		if (a == nil || a.Nil || b == nil || b.Nil) && itr.points == nil {
 			continue
 		}
 
-		// Check if the point is inside of our current window.
-		inWindow := true
-		if window := itr.window; window.name != p.Name {
-			inWindow = false
-		} else if tags := p.Tags.Subset(itr.heap.opt.Dimensions); window.tags != tags.ID() {
-			inWindow = false
-		} else if opt := itr.heap.opt; opt.Ascending && p.Time >= window.endTime {
-			inWindow = false
-		} else if !opt.Ascending && p.Time < window.startTime {
-			inWindow = false
+		// If one of the two points is nil, we need to fill it with a fake nil
+		// point that has the same name, tags, and time as the other point.
+		// There should never be a time when both of these are nil.
+		if a == nil {
+			p := *b
+			a = &p
+			a.Value = 0
+			a.Nil = true
+		} else if b == nil {
+			p := *a
+			b = &p
+			b.Value = 0
+			b.Nil = true
+		}
+
+		// If a value is nil, use the fill values if the fill value is non-nil.
+		if a.Nil && !itr.points[0].Nil {
+			a.Value = itr.points[0].Value
+			a.Nil = false
+		}
+		if b.Nil && !itr.points[1].Nil {
+			b.Value = itr.points[1].Value
+			b.Nil = false
 		}
 
-		// If it's outside our window then push iterator back on the heap and find new iterator.
-		if !inWindow {
-			itr.curr.itr.unread(p)
-			heap.Push(itr.heap, itr.curr)
-			itr.curr = nil
-			continue
+		if itr.storePrev {
+			itr.points[0], itr.points[1] = *a, *b
 		}
 
+		p := &FloatPoint{
+			Name:       a.Name,
+			Tags:       a.Tags,
+			Time:       a.Time,
+			Nil:        a.Nil || b.Nil,
+			Aggregated: a.Aggregated,
+		}
+		if !p.Nil {
+			p.Value = itr.fn(a.Value, b.Value)
+		}
 		return p, nil
-	}
-}
 
-// integerMergeHeap represents a heap of integerMergeHeapItems.
-// Items are sorted by their next window and then by name/tags.
-type integerMergeHeap struct {
-	opt   IteratorOptions
-	items []*integerMergeHeapItem
+	}
 }
 
-func (h *integerMergeHeap) Len() int      { return len(h.items) }
-func (h *integerMergeHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
-func (h *integerMergeHeap) Less(i, j int) bool {
-	x, err := h.items[i].itr.peek()
+// next returns the next points within each iterator. If the iterators are
+// uneven, it organizes them so only matching points are returned.
+func (itr *unsignedFloatExprIterator) next() (a, b *UnsignedPoint, err error) {
+	// Retrieve the next value for both the left and right.
+	a, err = itr.left.Next()
 	if err != nil {
-		return true
+		return nil, nil, err
 	}
-	y, err := h.items[j].itr.peek()
+	b, err = itr.right.Next()
 	if err != nil {
-		return false
+		return nil, nil, err
 	}
 
-	if h.opt.Ascending {
-		if x.Name != y.Name {
-			return x.Name < y.Name
-		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); xTags.ID() != yTags.ID() {
-			return xTags.ID() < yTags.ID()
-		}
-	} else {
-		if x.Name != y.Name {
-			return x.Name > y.Name
-		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); xTags.ID() != yTags.ID() {
-			return xTags.ID() > yTags.ID()
+	// If we have a point from both, make sure that they match each other.
+	if a != nil && b != nil {
+		if a.Name > b.Name {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Name < b.Name {
+			itr.right.unread(b)
+			return a, nil, nil
 		}
-	}
 
-	xt, _ := h.opt.Window(x.Time)
-	yt, _ := h.opt.Window(y.Time)
+		if ltags, rtags := a.Tags.ID(), b.Tags.ID(); ltags > rtags {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if ltags < rtags {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
 
-	if h.opt.Ascending {
-		return xt < yt
+		if a.Time > b.Time {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Time < b.Time {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
 	}
-	return xt > yt
+	return a, b, nil
 }
 
-func (h *integerMergeHeap) Push(x interface{}) {
-	h.items = append(h.items, x.(*integerMergeHeapItem))
-}
+// unsignedFloatExprFunc creates or modifies a point by combining two
+// points. The point passed in may be modified and returned rather than
+// allocating a new point if possible. One of the points may be nil, but at
+// least one of the points will be non-nil.
+type unsignedFloatExprFunc func(a, b uint64) float64
 
-func (h *integerMergeHeap) Pop() interface{} {
-	old := h.items
-	n := len(old)
-	item := old[n-1]
-	h.items = old[0 : n-1]
-	return item
+// unsignedReduceIntegerIterator executes a reducer for every interval and buffers the result.
+type unsignedReduceIntegerIterator struct {
+	input  *bufUnsignedIterator
+	create func() (UnsignedPointAggregator, IntegerPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	points []IntegerPoint
 }
 
-type integerMergeHeapItem struct {
-	itr *bufIntegerIterator
+func newUnsignedReduceIntegerIterator(input UnsignedIterator, opt IteratorOptions, createFn func() (UnsignedPointAggregator, IntegerPointEmitter)) *unsignedReduceIntegerIterator {
+	return &unsignedReduceIntegerIterator{
+		input:  newBufUnsignedIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+	}
 }
 
-// integerSortedMergeIterator is an iterator that sorts and merges multiple iterators into one.
-type integerSortedMergeIterator struct {
-	inputs []IntegerIterator
-	heap   *integerSortedMergeHeap
-	init   bool
-}
+// Stats returns stats from the input iterator.
+func (itr *unsignedReduceIntegerIterator) Stats() IteratorStats { return itr.input.Stats() }
 
-// newIntegerSortedMergeIterator returns an instance of integerSortedMergeIterator.
-func newIntegerSortedMergeIterator(inputs []IntegerIterator, opt IteratorOptions) Iterator {
-	itr := &integerSortedMergeIterator{
-		inputs: inputs,
-		heap: &integerSortedMergeHeap{
-			items: make([]*integerSortedMergeHeapItem, 0, len(inputs)),
-			opt:   opt,
-		},
-	}
+// Close closes the iterator and all child iterators.
+func (itr *unsignedReduceIntegerIterator) Close() error { return itr.input.Close() }
 
-	// Initialize heap items.
-	for _, input := range inputs {
-		// Append to the heap.
-		itr.heap.items = append(itr.heap.items, &integerSortedMergeHeapItem{itr: input})
+// Next returns the minimum value for the next available interval.
+func (itr *unsignedReduceIntegerIterator) Next() (*IntegerPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
+		}
 	}
 
-	return itr
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
 }
 
-// Stats returns an aggregation of stats from the underlying iterators.
-func (itr *integerSortedMergeIterator) Stats() IteratorStats {
-	var stats IteratorStats
-	for _, input := range itr.inputs {
-		stats.Add(input.Stats())
-	}
-	return stats
+// unsignedReduceIntegerPoint stores the reduced data for a name/tag combination.
+type unsignedReduceIntegerPoint struct {
+	Name       string
+	Tags       Tags
+	Aggregator UnsignedPointAggregator
+	Emitter    IntegerPointEmitter
 }
 
-// Close closes the underlying iterators.
-func (itr *integerSortedMergeIterator) Close() error {
-	for _, input := range itr.inputs {
-		input.Close()
+// reduce executes fn once for every point in the next window.
+// The previous value for the dimension is passed to fn.
+func (itr *unsignedReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
+	// Calculate next window.
+	var (
+		startTime, endTime int64
+		window             struct {
+			name string
+			tags string
+		}
+	)
+	for {
+		p, err := itr.input.Next()
+		if err != nil || p == nil {
+			return nil, err
+		} else if p.Nil {
+			continue
+		}
+
+		// Unread the point so it can be processed.
+		itr.input.unread(p)
+		startTime, endTime = itr.opt.Window(p.Time)
+		window.name, window.tags = p.Name, p.Tags.Subset(itr.opt.Dimensions).ID()
+		break
 	}
-	return nil
-}
 
-// Next returns the next points from the iterator.
-func (itr *integerSortedMergeIterator) Next() (*IntegerPoint, error) { return itr.pop() }
+	// Create points by tags.
+	m := make(map[string]*unsignedReduceIntegerPoint)
+	for {
+		// Read next point.
+		curr, err := itr.input.NextInWindow(startTime, endTime)
+		if err != nil {
+			return nil, err
+		} else if curr == nil {
+			break
+		} else if curr.Nil {
+			continue
+		} else if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		}
 
-// pop returns the next point from the heap.
-// Reads the next point from item's cursor and puts it back on the heap.
-func (itr *integerSortedMergeIterator) pop() (*IntegerPoint, error) {
-	// Initialize the heap. See the MergeIterator to see why this has to be done lazily.
-	if !itr.init {
-		items := itr.heap.items
-		itr.heap.items = make([]*integerSortedMergeHeapItem, 0, len(items))
-		for _, item := range items {
-			var err error
-			if item.point, err = item.itr.Next(); err != nil {
-				return nil, err
-			} else if item.point == nil {
-				continue
+		// Ensure this point is within the same final window.
+		if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		} else if tags := curr.Tags.Subset(itr.opt.Dimensions); tags.ID() != window.tags {
+			itr.input.unread(curr)
+			break
+		}
+
+		// Retrieve the tags on this point for this level of the query.
+		// This may be different than the bucket dimensions.
+		tags := curr.Tags.Subset(itr.dims)
+		id := tags.ID()
+
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &unsignedReduceIntegerPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			m[id] = rp
+		}
+		rp.Aggregator.AggregateUnsigned(curr)
+	}
+
+	// Reverse sort points by name & tag if our output is supposed to be ordered.
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	if len(keys) > 1 && itr.opt.Ordered {
+		sort.Sort(reverseStringSlice(keys))
+	}
+
+	// Assume the points are already sorted until proven otherwise.
+	sortedByTime := true
+	// Emit the points for each name & tag combination.
+	a := make([]IntegerPoint, 0, len(m))
+	for _, k := range keys {
+		rp := m[k]
+		points := rp.Emitter.Emit()
+		for i := len(points) - 1; i >= 0; i-- {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+			// Set the points time to the interval time if the reducer didn't provide one.
+			if points[i].Time == ZeroTime {
+				points[i].Time = startTime
+			} else {
+				sortedByTime = false
 			}
-			itr.heap.items = append(itr.heap.items, item)
+			a = append(a, points[i])
 		}
-		heap.Init(itr.heap)
-		itr.init = true
 	}
 
-	if len(itr.heap.items) == 0 {
-		return nil, nil
+	// Points may be out of order. Perform a stable sort by time if requested.
+	if !sortedByTime && itr.opt.Ordered {
+		sort.Stable(sort.Reverse(integerPointsByTime(a)))
 	}
 
-	// Read the next item from the heap.
-	item := heap.Pop(itr.heap).(*integerSortedMergeHeapItem)
-	if item.err != nil {
-		return nil, item.err
-	} else if item.point == nil {
-		return nil, nil
-	}
+	return a, nil
+}
 
-	// Copy the point for return.
-	p := item.point.Clone()
+// unsignedStreamIntegerIterator streams inputs into the iterator and emits points gradually.
+type unsignedStreamIntegerIterator struct {
+	input  *bufUnsignedIterator
+	create func() (UnsignedPointAggregator, IntegerPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	m      map[string]*unsignedReduceIntegerPoint
+	points []IntegerPoint
+}
 
-	// Read the next item from the cursor. Push back to heap if one exists.
-	if item.point, item.err = item.itr.Next(); item.point != nil {
-		heap.Push(itr.heap, item)
+// newUnsignedStreamIntegerIterator returns a new instance of unsignedStreamIntegerIterator.
+func newUnsignedStreamIntegerIterator(input UnsignedIterator, createFn func() (UnsignedPointAggregator, IntegerPointEmitter), opt IteratorOptions) *unsignedStreamIntegerIterator {
+	return &unsignedStreamIntegerIterator{
+		input:  newBufUnsignedIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+		m:      make(map[string]*unsignedReduceIntegerPoint),
 	}
-
-	return p, nil
 }
 
-// integerSortedMergeHeap represents a heap of integerSortedMergeHeapItems.
-type integerSortedMergeHeap struct {
-	opt   IteratorOptions
-	items []*integerSortedMergeHeapItem
-}
+// Stats returns stats from the input iterator.
+func (itr *unsignedStreamIntegerIterator) Stats() IteratorStats { return itr.input.Stats() }
 
-func (h *integerSortedMergeHeap) Len() int      { return len(h.items) }
-func (h *integerSortedMergeHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
-func (h *integerSortedMergeHeap) Less(i, j int) bool {
-	x, y := h.items[i].point, h.items[j].point
+// Close closes the iterator and all child iterators.
+func (itr *unsignedStreamIntegerIterator) Close() error { return itr.input.Close() }
 
-	if h.opt.Ascending {
-		if x.Name != y.Name {
-			return x.Name < y.Name
-		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); !xTags.Equals(&yTags) {
-			return xTags.ID() < yTags.ID()
+// Next returns the next value for the stream iterator.
+func (itr *unsignedStreamIntegerIterator) Next() (*IntegerPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
 		}
-		return x.Time < y.Time
-	}
-
-	if x.Name != y.Name {
-		return x.Name > y.Name
-	} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); !xTags.Equals(&yTags) {
-		return xTags.ID() > yTags.ID()
 	}
-	return x.Time > y.Time
-}
-
-func (h *integerSortedMergeHeap) Push(x interface{}) {
-	h.items = append(h.items, x.(*integerSortedMergeHeapItem))
-}
-
-func (h *integerSortedMergeHeap) Pop() interface{} {
-	old := h.items
-	n := len(old)
-	item := old[n-1]
-	h.items = old[0 : n-1]
-	return item
-}
 
-type integerSortedMergeHeapItem struct {
-	point *IntegerPoint
-	err   error
-	itr   IntegerIterator
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
 }
 
-// integerParallelIterator represents an iterator that pulls data in a separate goroutine.
-type integerParallelIterator struct {
-	input IntegerIterator
-	ch    chan integerPointError
-
-	once    sync.Once
-	closing chan struct{}
-	wg      sync.WaitGroup
-}
+// reduce creates and manages aggregators for every point from the input.
+// After aggregating a point, it always tries to emit a value using the emitter.
+func (itr *unsignedStreamIntegerIterator) reduce() ([]IntegerPoint, error) {
+	for {
+		// Read next point.
+		curr, err := itr.input.Next()
+		if curr == nil {
+			// Close all of the aggregators to flush any remaining points to emit.
+			var points []IntegerPoint
+			for _, rp := range itr.m {
+				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
+					if err := aggregator.Close(); err != nil {
+						return nil, err
+					}
 
-// newIntegerParallelIterator returns a new instance of integerParallelIterator.
-func newIntegerParallelIterator(input IntegerIterator) *integerParallelIterator {
-	itr := &integerParallelIterator{
-		input:   input,
-		ch:      make(chan integerPointError, 256),
-		closing: make(chan struct{}),
-	}
-	itr.wg.Add(1)
-	go itr.monitor()
-	return itr
-}
+					pts := rp.Emitter.Emit()
+					if len(pts) == 0 {
+						continue
+					}
 
-// Stats returns stats from the underlying iterator.
-func (itr *integerParallelIterator) Stats() IteratorStats { return itr.input.Stats() }
+					for i := range pts {
+						pts[i].Name = rp.Name
+						pts[i].Tags = rp.Tags
+					}
+					points = append(points, pts...)
+				}
+			}
 
-// Close closes the underlying iterators.
-func (itr *integerParallelIterator) Close() error {
-	itr.once.Do(func() { close(itr.closing) })
-	itr.wg.Wait()
-	return itr.input.Close()
-}
+			// Eliminate the aggregators and emitters.
+			itr.m = nil
+			return points, nil
+		} else if err != nil {
+			return nil, err
+		} else if curr.Nil {
+			continue
+		}
+		tags := curr.Tags.Subset(itr.dims)
 
-// Next returns the next point from the iterator.
-func (itr *integerParallelIterator) Next() (*IntegerPoint, error) {
-	v, ok := <-itr.ch
-	if !ok {
-		return nil, io.EOF
-	}
-	return v.point, v.err
-}
+		id := curr.Name
+		if len(tags.m) > 0 {
+			id += "\x00" + tags.ID()
+		}
 
-// monitor runs in a separate goroutine and actively pulls the next point.
-func (itr *integerParallelIterator) monitor() {
-	defer close(itr.ch)
-	defer itr.wg.Done()
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := itr.m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &unsignedReduceIntegerPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			itr.m[id] = rp
+		}
+		rp.Aggregator.AggregateUnsigned(curr)
 
-	for {
-		// Read next point.
-		p, err := itr.input.Next()
-		if p != nil {
-			p = p.Clone()
+		// Attempt to emit points from the aggregator.
+		points := rp.Emitter.Emit()
+		if len(points) == 0 {
+			continue
 		}
 
-		select {
-		case <-itr.closing:
-			return
-		case itr.ch <- integerPointError{point: p, err: err}:
+		for i := range points {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
 		}
+		return points, nil
 	}
 }
 
-type integerPointError struct {
-	point *IntegerPoint
-	err   error
+// unsignedIntegerExprIterator executes a function to modify an existing point
+// for every output of the input iterator.
+type unsignedIntegerExprIterator struct {
+	left      *bufUnsignedIterator
+	right     *bufUnsignedIterator
+	fn        unsignedIntegerExprFunc
+	points    []UnsignedPoint // must be size 2
+	storePrev bool
 }
 
-// integerLimitIterator represents an iterator that limits points per group.
-type integerLimitIterator struct {
-	input IntegerIterator
-	opt   IteratorOptions
-	n     int
-
-	prev struct {
-		name string
-		tags Tags
+func newUnsignedIntegerExprIterator(left, right UnsignedIterator, opt IteratorOptions, fn func(a, b uint64) int64) *unsignedIntegerExprIterator {
+	var points []UnsignedPoint
+	switch opt.Fill {
+	case NullFill, PreviousFill:
+		points = []UnsignedPoint{{Nil: true}, {Nil: true}}
+	case NumberFill:
+		value := castToUnsigned(opt.FillValue)
+		points = []UnsignedPoint{{Value: value}, {Value: value}}
 	}
-}
-
-// newIntegerLimitIterator returns a new instance of integerLimitIterator.
-func newIntegerLimitIterator(input IntegerIterator, opt IteratorOptions) *integerLimitIterator {
-	return &integerLimitIterator{
-		input: input,
-		opt:   opt,
+	return &unsignedIntegerExprIterator{
+		left:      newBufUnsignedIterator(left),
+		right:     newBufUnsignedIterator(right),
+		points:    points,
+		fn:        fn,
+		storePrev: opt.Fill == PreviousFill,
 	}
 }
 
-// Stats returns stats from the underlying iterator.
-func (itr *integerLimitIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *unsignedIntegerExprIterator) Stats() IteratorStats {
+	stats := itr.left.Stats()
+	stats.Add(itr.right.Stats())
+	return stats
+}
 
-// Close closes the underlying iterators.
-func (itr *integerLimitIterator) Close() error { return itr.input.Close() }
+func (itr *unsignedIntegerExprIterator) Close() error {
+	itr.left.Close()
+	itr.right.Close()
+	return nil
+}
 
-// Next returns the next point from the iterator.
-func (itr *integerLimitIterator) Next() (*IntegerPoint, error) {
+func (itr *unsignedIntegerExprIterator) Next() (*IntegerPoint, error) {
 	for {
-		p, err := itr.input.Next()
-		if p == nil || err != nil {
+		a, b, err := itr.next()
+		if err != nil || (a == nil && b == nil) {
 			return nil, err
 		}
 
-		// Reset window and counter if a new window is encountered.
-		if p.Name != itr.prev.name || !p.Tags.Equals(&itr.prev.tags) {
-			itr.prev.name = p.Name
-			itr.prev.tags = p.Tags
-			itr.n = 0
+		// If any of these are nil and we are using fill(none), skip these points.
+		if (a == nil || a.Nil || b == nil || b.Nil) && itr.points == nil {
+			continue
+		}
+
+		// If one of the two points is nil, we need to fill it with a fake nil
+		// point that has the same name, tags, and time as the other point.
+		// There should never be a time when both of these are nil.
+		if a == nil {
+			p := *b
+			a = &p
+			a.Value = 0
+			a.Nil = true
+		} else if b == nil {
+			p := *a
+			b = &p
+			b.Value = 0
+			b.Nil = true
+		}
+
+		// If a value is nil, use the fill values if the fill value is non-nil.
+		if a.Nil && !itr.points[0].Nil {
+			a.Value = itr.points[0].Value
+			a.Nil = false
 		}
-
-		// Increment counter.
-		itr.n++
-
-		// Read next point if not beyond the offset.
-		if itr.n <= itr.opt.Offset {
-			continue
+		if b.Nil && !itr.points[1].Nil {
+			b.Value = itr.points[1].Value
+			b.Nil = false
 		}
 
-		// Read next point if we're beyond the limit.
-		if itr.opt.Limit > 0 && (itr.n-itr.opt.Offset) > itr.opt.Limit {
-			continue
+		if itr.storePrev {
+			itr.points[0], itr.points[1] = *a, *b
 		}
 
+		p := &IntegerPoint{
+			Name:       a.Name,
+			Tags:       a.Tags,
+			Time:       a.Time,
+			Nil:        a.Nil || b.Nil,
+			Aggregated: a.Aggregated,
+		}
+		if !p.Nil {
+			p.Value = itr.fn(a.Value, b.Value)
+		}
 		return p, nil
+
 	}
 }
 
-type integerFillIterator struct {
-	input     *bufIntegerIterator
-	prev      IntegerPoint
-	startTime int64
-	endTime   int64
-	auxFields []interface{}
-	init      bool
-	opt       IteratorOptions
-
-	window struct {
-		name   string
-		tags   Tags
-		time   int64
-		offset int64
+// next returns the next points within each iterator. If the iterators are
+// uneven, it organizes them so only matching points are returned.
+func (itr *unsignedIntegerExprIterator) next() (a, b *UnsignedPoint, err error) {
+	// Retrieve the next value for both the left and right.
+	a, err = itr.left.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err = itr.right.Next()
+	if err != nil {
+		return nil, nil, err
 	}
-}
 
-func newIntegerFillIterator(input IntegerIterator, expr Expr, opt IteratorOptions) *integerFillIterator {
-	if opt.Fill == NullFill {
-		if expr, ok := expr.(*Call); ok && expr.Name == "count" {
-			opt.Fill = NumberFill
-			opt.FillValue = int64(0)
+	// If we have a point from both, make sure that they match each other.
+	if a != nil && b != nil {
+		if a.Name > b.Name {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Name < b.Name {
+			itr.right.unread(b)
+			return a, nil, nil
 		}
-	}
 
-	var startTime, endTime int64
-	if opt.Ascending {
-		startTime, _ = opt.Window(opt.StartTime)
-		endTime, _ = opt.Window(opt.EndTime)
-	} else {
-		startTime, _ = opt.Window(opt.EndTime)
-		endTime, _ = opt.Window(opt.StartTime)
-	}
+		if ltags, rtags := a.Tags.ID(), b.Tags.ID(); ltags > rtags {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if ltags < rtags {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
 
-	var auxFields []interface{}
-	if len(opt.Aux) > 0 {
-		auxFields = make([]interface{}, len(opt.Aux))
+		if a.Time > b.Time {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Time < b.Time {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
 	}
+	return a, b, nil
+}
 
-	return &integerFillIterator{
-		input:     newBufIntegerIterator(input),
-		prev:      IntegerPoint{Nil: true},
-		startTime: startTime,
-		endTime:   endTime,
-		auxFields: auxFields,
-		opt:       opt,
+// unsignedIntegerExprFunc creates or modifies a point by combining two
+// points. The point passed in may be modified and returned rather than
+// allocating a new point if possible. One of the points may be nil, but at
+// least one of the points will be non-nil.
+type unsignedIntegerExprFunc func(a, b uint64) int64
+
+// unsignedReduceUnsignedIterator executes a reducer for every interval and buffers the result.
+type unsignedReduceUnsignedIterator struct {
+	input  *bufUnsignedIterator
+	create func() (UnsignedPointAggregator, UnsignedPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	points []UnsignedPoint
+}
+
+func newUnsignedReduceUnsignedIterator(input UnsignedIterator, opt IteratorOptions, createFn func() (UnsignedPointAggregator, UnsignedPointEmitter)) *unsignedReduceUnsignedIterator {
+	return &unsignedReduceUnsignedIterator{
+		input:  newBufUnsignedIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
 	}
 }
 
-func (itr *integerFillIterator) Stats() IteratorStats { return itr.input.Stats() }
-func (itr *integerFillIterator) Close() error         { return itr.input.Close() }
+// Stats returns stats from the input iterator.
+func (itr *unsignedReduceUnsignedIterator) Stats() IteratorStats { return itr.input.Stats() }
 
-func (itr *integerFillIterator) Next() (*IntegerPoint, error) {
-	if !itr.init {
-		p, err := itr.input.peek()
-		if p == nil || err != nil {
+// Close closes the iterator and all child iterators.
+func (itr *unsignedReduceUnsignedIterator) Close() error { return itr.input.Close() }
+
+// Next returns the minimum value for the next available interval.
+func (itr *unsignedReduceUnsignedIterator) Next() (*UnsignedPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
 			return nil, err
 		}
-		itr.window.name, itr.window.tags = p.Name, p.Tags
-		itr.window.time = itr.startTime
-		if itr.opt.Location != nil {
-			_, itr.window.offset = itr.opt.Zone(itr.window.time)
-		}
-		itr.init = true
 	}
 
-	p, err := itr.input.Next()
-	if err != nil {
-		return nil, err
-	}
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
+}
 
-	// Check if the next point is outside of our window or is nil.
-	for p == nil || p.Name != itr.window.name || p.Tags.ID() != itr.window.tags.ID() {
-		// If we are inside of an interval, unread the point and continue below to
-		// constructing a new point.
-		if itr.opt.Ascending {
-			if itr.window.time <= itr.endTime {
-				itr.input.unread(p)
-				p = nil
-				break
-			}
-		} else {
-			if itr.window.time >= itr.endTime {
-				itr.input.unread(p)
-				p = nil
-				break
-			}
-		}
+// unsignedReduceUnsignedPoint stores the reduced data for a name/tag combination.
+type unsignedReduceUnsignedPoint struct {
+	Name       string
+	Tags       Tags
+	Aggregator UnsignedPointAggregator
+	Emitter    UnsignedPointEmitter
+}
 
-		// We are *not* in a current interval. If there is no next point,
-		// we are at the end of all intervals.
-		if p == nil {
-			return nil, nil
+// reduce executes fn once for every point in the next window.
+// The previous value for the dimension is passed to fn.
+func (itr *unsignedReduceUnsignedIterator) reduce() ([]UnsignedPoint, error) {
+	// Calculate next window.
+	var (
+		startTime, endTime int64
+		window             struct {
+			name string
+			tags string
 		}
-
-		// Set the new interval.
-		itr.window.name, itr.window.tags = p.Name, p.Tags
-		itr.window.time = itr.startTime
-		if itr.opt.Location != nil {
-			_, itr.window.offset = itr.opt.Zone(itr.window.time)
+	)
+	for {
+		p, err := itr.input.Next()
+		if err != nil || p == nil {
+			return nil, err
+		} else if p.Nil {
+			continue
 		}
-		itr.prev = IntegerPoint{Nil: true}
+
+		// Unread the point so it can be processed.
+		itr.input.unread(p)
+		startTime, endTime = itr.opt.Window(p.Time)
+		window.name, window.tags = p.Name, p.Tags.Subset(itr.opt.Dimensions).ID()
 		break
 	}
 
-	// Check if the point is our next expected point.
-	if p == nil || (itr.opt.Ascending && p.Time > itr.window.time) || (!itr.opt.Ascending && p.Time < itr.window.time) {
-		if p != nil {
-			itr.input.unread(p)
-		}
-
-		p = &IntegerPoint{
-			Name: itr.window.name,
-			Tags: itr.window.tags,
-			Time: itr.window.time,
-			Aux:  itr.auxFields,
+	// Create points by tags.
+	m := make(map[string]*unsignedReduceUnsignedPoint)
+	for {
+		// Read next point.
+		curr, err := itr.input.NextInWindow(startTime, endTime)
+		if err != nil {
+			return nil, err
+		} else if curr == nil {
+			break
+		} else if curr.Nil {
+			continue
+		} else if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
 		}
 
-		switch itr.opt.Fill {
-		case LinearFill:
-			if !itr.prev.Nil {
-				next, err := itr.input.peek()
-				if err != nil {
-					return nil, err
-				} else if next != nil && next.Name == itr.window.name && next.Tags.ID() == itr.window.tags.ID() {
-					interval := int64(itr.opt.Interval.Duration)
-					start := itr.window.time / interval
-					p.Value = linearInteger(start, itr.prev.Time/interval, next.Time/interval, itr.prev.Value, next.Value)
-				} else {
-					p.Nil = true
-				}
-			} else {
-				p.Nil = true
-			}
-
-		case NullFill:
-			p.Nil = true
-		case NumberFill:
-			p.Value = castToInteger(itr.opt.FillValue)
-		case PreviousFill:
-			if !itr.prev.Nil {
-				p.Value = itr.prev.Value
-				p.Nil = itr.prev.Nil
-			} else {
-				p.Nil = true
-			}
+		// Ensure this point is within the same final window.
+		if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		} else if tags := curr.Tags.Subset(itr.opt.Dimensions); tags.ID() != window.tags {
+			itr.input.unread(curr)
+			break
 		}
-	} else {
-		itr.prev = *p
-	}
 
-	// Advance the expected time. Do not advance to a new window here
-	// as there may be lingering points with the same timestamp in the previous
-	// window.
-	if itr.opt.Ascending {
-		itr.window.time += int64(itr.opt.Interval.Duration)
-	} else {
-		itr.window.time -= int64(itr.opt.Interval.Duration)
-	}
+		// Retrieve the tags on this point for this level of the query.
+		// This may be different than the bucket dimensions.
+		tags := curr.Tags.Subset(itr.dims)
+		id := tags.ID()
 
-	// Check to see if we have passed over an offset change and adjust the time
-	// to account for this new offset.
-	if itr.opt.Location != nil {
-		if _, offset := itr.opt.Zone(itr.window.time); offset != itr.window.offset {
-			diff := itr.window.offset - offset
-			if abs(diff) < int64(itr.opt.Interval.Duration) {
-				itr.window.time += diff
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &unsignedReduceUnsignedPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
 			}
-			itr.window.offset = offset
+			m[id] = rp
 		}
+		rp.Aggregator.AggregateUnsigned(curr)
 	}
-	return p, nil
-}
-
-// integerIntervalIterator represents a integer implementation of IntervalIterator.
-type integerIntervalIterator struct {
-	input IntegerIterator
-	opt   IteratorOptions
-}
-
-func newIntegerIntervalIterator(input IntegerIterator, opt IteratorOptions) *integerIntervalIterator {
-	return &integerIntervalIterator{input: input, opt: opt}
-}
-
-func (itr *integerIntervalIterator) Stats() IteratorStats { return itr.input.Stats() }
-func (itr *integerIntervalIterator) Close() error         { return itr.input.Close() }
 
-func (itr *integerIntervalIterator) Next() (*IntegerPoint, error) {
-	p, err := itr.input.Next()
-	if p == nil || err != nil {
-		return nil, err
+	// Reverse sort points by name & tag if our output is supposed to be ordered.
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
-	p.Time, _ = itr.opt.Window(p.Time)
-	// If we see the minimum allowable time, set the time to zero so we don't
-	// break the default returned time for aggregate queries without times.
-	if p.Time == MinTime {
-		p.Time = 0
+	if len(keys) > 1 && itr.opt.Ordered {
+		sort.Sort(reverseStringSlice(keys))
 	}
-	return p, nil
-}
-
-// integerInterruptIterator represents a integer implementation of InterruptIterator.
-type integerInterruptIterator struct {
-	input   IntegerIterator
-	closing <-chan struct{}
-	count   int
-}
-
-func newIntegerInterruptIterator(input IntegerIterator, closing <-chan struct{}) *integerInterruptIterator {
-	return &integerInterruptIterator{input: input, closing: closing}
-}
-
-func (itr *integerInterruptIterator) Stats() IteratorStats { return itr.input.Stats() }
-func (itr *integerInterruptIterator) Close() error         { return itr.input.Close() }
 
-func (itr *integerInterruptIterator) Next() (*IntegerPoint, error) {
-	// Only check if the channel is closed every N points. This
-	// intentionally checks on both 0 and N so that if the iterator
-	// has been interrupted before the first point is emitted it will
-	// not emit any points.
-	if itr.count&0xFF == 0xFF {
-		select {
-		case <-itr.closing:
-			return nil, itr.Close()
-		default:
-			// Reset iterator count to zero and fall through to emit the next point.
-			itr.count = 0
+	// Assume the points are already sorted until proven otherwise.
+	sortedByTime := true
+	// Emit the points for each name & tag combination.
+	a := make([]UnsignedPoint, 0, len(m))
+	for _, k := range keys {
+		rp := m[k]
+		points := rp.Emitter.Emit()
+		for i := len(points) - 1; i >= 0; i-- {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+			// Set the points time to the interval time if the reducer didn't provide one.
+			if points[i].Time == ZeroTime {
+				points[i].Time = startTime
+			} else {
+				sortedByTime = false
+			}
+			a = append(a, points[i])
 		}
 	}
 
-	// Increment the counter for every point read.
-	itr.count++
-	return itr.input.Next()
-}
+	// Points may be out of order. Perform a stable sort by time if requested.
+	if !sortedByTime && itr.opt.Ordered {
+		sort.Stable(sort.Reverse(unsignedPointsByTime(a)))
+	}
 
-// integerCloseInterruptIterator represents a integer implementation of CloseInterruptIterator.
-type integerCloseInterruptIterator struct {
-	input   IntegerIterator
-	closing <-chan struct{}
-	done    chan struct{}
-	once    sync.Once
+	return a, nil
 }
 
-func newIntegerCloseInterruptIterator(input IntegerIterator, closing <-chan struct{}) *integerCloseInterruptIterator {
-	itr := &integerCloseInterruptIterator{
-		input:   input,
-		closing: closing,
-		done:    make(chan struct{}),
-	}
-	go itr.monitor()
-	return itr
+// unsignedStreamUnsignedIterator streams inputs into the iterator and emits points gradually.
+type unsignedStreamUnsignedIterator struct {
+	input  *bufUnsignedIterator
+	create func() (UnsignedPointAggregator, UnsignedPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	m      map[string]*unsignedReduceUnsignedPoint
+	points []UnsignedPoint
 }
 
-func (itr *integerCloseInterruptIterator) monitor() {
-	select {
-	case <-itr.closing:
-		itr.Close()
-	case <-itr.done:
+// newUnsignedStreamUnsignedIterator returns a new instance of unsignedStreamUnsignedIterator.
+func newUnsignedStreamUnsignedIterator(input UnsignedIterator, createFn func() (UnsignedPointAggregator, UnsignedPointEmitter), opt IteratorOptions) *unsignedStreamUnsignedIterator {
+	return &unsignedStreamUnsignedIterator{
+		input:  newBufUnsignedIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+		m:      make(map[string]*unsignedReduceUnsignedPoint),
 	}
 }
 
-func (itr *integerCloseInterruptIterator) Stats() IteratorStats {
-	return itr.input.Stats()
-}
+// Stats returns stats from the input iterator.
+func (itr *unsignedStreamUnsignedIterator) Stats() IteratorStats { return itr.input.Stats() }
 
-func (itr *integerCloseInterruptIterator) Close() error {
-	itr.once.Do(func() {
-		close(itr.done)
-		itr.input.Close()
-	})
-	return nil
-}
+// Close closes the iterator and all child iterators.
+func (itr *unsignedStreamUnsignedIterator) Close() error { return itr.input.Close() }
 
-func (itr *integerCloseInterruptIterator) Next() (*IntegerPoint, error) {
-	p, err := itr.input.Next()
-	if err != nil {
-		// Check if the iterator was closed.
-		select {
-		case <-itr.done:
-			return nil, nil
-		default:
+// Next returns the next value for the stream iterator.
+func (itr *unsignedStreamUnsignedIterator) Next() (*UnsignedPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
 			return nil, err
 		}
 	}
+
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
 	return p, nil
 }
 
-// auxIntegerPoint represents a combination of a point and an error for the AuxIterator.
-type auxIntegerPoint struct {
-	point *IntegerPoint
-	err   error
-}
+// reduce creates and manages aggregators for every point from the input.
+// After aggregating a point, it always tries to emit a value using the emitter.
+func (itr *unsignedStreamUnsignedIterator) reduce() ([]UnsignedPoint, error) {
+	for {
+		// Read next point.
+		curr, err := itr.input.Next()
+		if curr == nil {
+			// Close all of the aggregators to flush any remaining points to emit.
+			var points []UnsignedPoint
+			for _, rp := range itr.m {
+				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
+					if err := aggregator.Close(); err != nil {
+						return nil, err
+					}
 
-// integerAuxIterator represents a integer implementation of AuxIterator.
-type integerAuxIterator struct {
-	input      *bufIntegerIterator
-	output     chan auxIntegerPoint
-	fields     *auxIteratorFields
-	background bool
-}
+					pts := rp.Emitter.Emit()
+					if len(pts) == 0 {
+						continue
+					}
 
-func newIntegerAuxIterator(input IntegerIterator, opt IteratorOptions) *integerAuxIterator {
-	return &integerAuxIterator{
-		input:  newBufIntegerIterator(input),
-		output: make(chan auxIntegerPoint, 1),
-		fields: newAuxIteratorFields(opt),
-	}
-}
+					for i := range pts {
+						pts[i].Name = rp.Name
+						pts[i].Tags = rp.Tags
+					}
+					points = append(points, pts...)
+				}
+			}
 
-func (itr *integerAuxIterator) Background() {
-	itr.background = true
-	itr.Start()
-	go DrainIterator(itr)
-}
+			// Eliminate the aggregators and emitters.
+			itr.m = nil
+			return points, nil
+		} else if err != nil {
+			return nil, err
+		} else if curr.Nil {
+			continue
+		}
+		tags := curr.Tags.Subset(itr.dims)
 
-func (itr *integerAuxIterator) Start()               { go itr.stream() }
-func (itr *integerAuxIterator) Stats() IteratorStats { return itr.input.Stats() }
-func (itr *integerAuxIterator) Close() error         { return itr.input.Close() }
-func (itr *integerAuxIterator) Next() (*IntegerPoint, error) {
-	p := <-itr.output
-	return p.point, p.err
-}
-func (itr *integerAuxIterator) Iterator(name string, typ DataType) Iterator {
-	return itr.fields.iterator(name, typ)
-}
+		id := curr.Name
+		if len(tags.m) > 0 {
+			id += "\x00" + tags.ID()
+		}
+
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := itr.m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &unsignedReduceUnsignedPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			itr.m[id] = rp
+		}
+		rp.Aggregator.AggregateUnsigned(curr)
 
-func (itr *integerAuxIterator) stream() {
-	for {
-		// Read next point.
-		p, err := itr.input.Next()
-		if err != nil {
-			itr.output <- auxIntegerPoint{err: err}
-			itr.fields.sendError(err)
-			break
-		} else if p == nil {
-			break
+		// Attempt to emit points from the aggregator.
+		points := rp.Emitter.Emit()
+		if len(points) == 0 {
+			continue
 		}
 
-		// Send point to output and to each field iterator.
-		itr.output <- auxIntegerPoint{point: p}
-		if ok := itr.fields.send(p); !ok && itr.background {
-			break
+		for i := range points {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
 		}
+		return points, nil
 	}
+}
 
-	close(itr.output)
-	itr.fields.close()
+// unsignedExprIterator executes a function to modify an existing point
+// for every output of the input iterator.
+type unsignedExprIterator struct {
+	left      *bufUnsignedIterator
+	right     *bufUnsignedIterator
+	fn        unsignedExprFunc
+	points    []UnsignedPoint // must be size 2
+	storePrev bool
 }
 
-// integerChanIterator represents a new instance of integerChanIterator.
-type integerChanIterator struct {
-	buf struct {
-		i      int
-		filled bool
-		points [2]IntegerPoint
+func newUnsignedExprIterator(left, right UnsignedIterator, opt IteratorOptions, fn func(a, b uint64) uint64) *unsignedExprIterator {
+	var points []UnsignedPoint
+	switch opt.Fill {
+	case NullFill, PreviousFill:
+		points = []UnsignedPoint{{Nil: true}, {Nil: true}}
+	case NumberFill:
+		value := castToUnsigned(opt.FillValue)
+		points = []UnsignedPoint{{Value: value}, {Value: value}}
+	}
+	return &unsignedExprIterator{
+		left:      newBufUnsignedIterator(left),
+		right:     newBufUnsignedIterator(right),
+		points:    points,
+		fn:        fn,
+		storePrev: opt.Fill == PreviousFill,
 	}
-	err  error
-	cond *sync.Cond
-	done bool
 }
 
-func (itr *integerChanIterator) Stats() IteratorStats { return IteratorStats{} }
+func (itr *unsignedExprIterator) Stats() IteratorStats {
+	stats := itr.left.Stats()
+	stats.Add(itr.right.Stats())
+	return stats
+}
 
-func (itr *integerChanIterator) Close() error {
-	itr.cond.L.Lock()
-	// Mark the channel iterator as done and signal all waiting goroutines to start again.
-	itr.done = true
-	itr.cond.Broadcast()
-	// Do not defer the unlock so we don't create an unnecessary allocation.
-	itr.cond.L.Unlock()
+func (itr *unsignedExprIterator) Close() error {
+	itr.left.Close()
+	itr.right.Close()
 	return nil
 }
 
-func (itr *integerChanIterator) setBuf(name string, tags Tags, time int64, value interface{}) bool {
-	itr.cond.L.Lock()
-	defer itr.cond.L.Unlock()
-
-	// Wait for either the iterator to be done (so we don't have to set the value)
-	// or for the buffer to have been read and ready for another write.
-	for !itr.done && itr.buf.filled {
-		itr.cond.Wait()
-	}
+func (itr *unsignedExprIterator) Next() (*UnsignedPoint, error) {
+	for {
+		a, b, err := itr.next()
+		if err != nil || (a == nil && b == nil) {
+			return nil, err
+		}
 
-	// Do not set the value and return false to signal that the iterator is closed.
-	// Do this after the above wait as the above for loop may have exited because
-	// the iterator was closed.
-	if itr.done {
-		return false
-	}
+		// If any of these are nil and we are using fill(none), skip these points.
+		if (a == nil || a.Nil || b == nil || b.Nil) && itr.points == nil {
+			continue
+		}
 
-	switch v := value.(type) {
-	case int64:
-		itr.buf.points[itr.buf.i] = IntegerPoint{Name: name, Tags: tags, Time: time, Value: v}
+		// If one of the two points is nil, we need to fill it with a fake nil
+		// point that has the same name, tags, and time as the other point.
+		// There should never be a time when both of these are nil.
+		if a == nil {
+			p := *b
+			a = &p
+			a.Value = 0
+			a.Nil = true
+		} else if b == nil {
+			p := *a
+			b = &p
+			b.Value = 0
+			b.Nil = true
+		}
 
-	default:
-		itr.buf.points[itr.buf.i] = IntegerPoint{Name: name, Tags: tags, Time: time, Nil: true}
-	}
-	itr.buf.filled = true
+		// If a value is nil, use the fill values if the fill value is non-nil.
+		if a.Nil && !itr.points[0].Nil {
+			a.Value = itr.points[0].Value
+			a.Nil = false
+		}
+		if b.Nil && !itr.points[1].Nil {
+			b.Value = itr.points[1].Value
+			b.Nil = false
+		}
 
-	// Signal to all waiting goroutines that a new value is ready to read.
-	itr.cond.Signal()
-	return true
-}
+		if itr.storePrev {
+			itr.points[0], itr.points[1] = *a, *b
+		}
 
-func (itr *integerChanIterator) setErr(err error) {
-	itr.cond.L.Lock()
-	defer itr.cond.L.Unlock()
-	itr.err = err
+		if a.Nil {
+			return a, nil
+		} else if b.Nil {
+			return b, nil
+		}
+		a.Value = itr.fn(a.Value, b.Value)
+		return a, nil
 
-	// Signal to all waiting goroutines that a new value is ready to read.
-	itr.cond.Signal()
+	}
 }
 
-func (itr *integerChanIterator) Next() (*IntegerPoint, error) {
-	itr.cond.L.Lock()
-	defer itr.cond.L.Unlock()
-
-	// Check for an error and return one if there.
-	if itr.err != nil {
-		return nil, itr.err
+// next returns the next points within each iterator. If the iterators are
+// uneven, it organizes them so only matching points are returned.
+func (itr *unsignedExprIterator) next() (a, b *UnsignedPoint, err error) {
+	// Retrieve the next value for both the left and right.
+	a, err = itr.left.Next()
+	if err != nil {
+		return nil, nil, err
 	}
-
-	// Wait until either a value is available in the buffer or
-	// the iterator is closed.
-	for !itr.done && !itr.buf.filled {
-		itr.cond.Wait()
+	b, err = itr.right.Next()
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Return nil once the channel is done and the buffer is empty.
-	if itr.done && !itr.buf.filled {
-		return nil, nil
-	}
+	// If we have a point from both, make sure that they match each other.
+	if a != nil && b != nil {
+		if a.Name > b.Name {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Name < b.Name {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
 
-	// Always read from the buffer if it exists, even if the iterator
-	// is closed. This prevents the last value from being truncated by
-	// the parent iterator.
-	p := &itr.buf.points[itr.buf.i]
-	itr.buf.i = (itr.buf.i + 1) % len(itr.buf.points)
-	itr.buf.filled = false
-	itr.cond.Signal()
-	return p, nil
+		if ltags, rtags := a.Tags.ID(), b.Tags.ID(); ltags > rtags {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if ltags < rtags {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+
+		if a.Time > b.Time {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Time < b.Time {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+	}
+	return a, b, nil
 }
 
-// integerReduceFloatIterator executes a reducer for every interval and buffers the result.
-type integerReduceFloatIterator struct {
-	input  *bufIntegerIterator
-	create func() (IntegerPointAggregator, FloatPointEmitter)
+// unsignedExprFunc creates or modifies a point by combining two
+// points. The point passed in may be modified and returned rather than
+// allocating a new point if possible. One of the points may be nil, but at
+// least one of the points will be non-nil.
+type unsignedExprFunc func(a, b uint64) uint64
+
+// unsignedReduceStringIterator executes a reducer for every interval and buffers the result.
+type unsignedReduceStringIterator struct {
+	input  *bufUnsignedIterator
+	create func() (UnsignedPointAggregator, StringPointEmitter)
 	dims   []string
 	opt    IteratorOptions
-	points []FloatPoint
+	points []StringPoint
 }
 
-func newIntegerReduceFloatIterator(input IntegerIterator, opt IteratorOptions, createFn func() (IntegerPointAggregator, FloatPointEmitter)) *integerReduceFloatIterator {
-	return &integerReduceFloatIterator{
-		input:  newBufIntegerIterator(input),
+func newUnsignedReduceStringIterator(input UnsignedIterator, opt IteratorOptions, createFn func() (UnsignedPointAggregator, StringPointEmitter)) *unsignedReduceStringIterator {
+	return &unsignedReduceStringIterator{
+		input:  newBufUnsignedIterator(input),
 		create: createFn,
 		dims:   opt.GetDimensions(),
 		opt:    opt,
@@ -3941,13 +8920,13 @@ func newIntegerReduceFloatIterator(input IntegerIterator, opt IteratorOptions, c
 }
 
 // Stats returns stats from the input iterator.
-func (itr *integerReduceFloatIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *unsignedReduceStringIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *integerReduceFloatIterator) Close() error { return itr.input.Close() }
+func (itr *unsignedReduceStringIterator) Close() error { return itr.input.Close() }
 
 // Next returns the minimum value for the next available interval.
-func (itr *integerReduceFloatIterator) Next() (*FloatPoint, error) {
+func (itr *unsignedReduceStringIterator) Next() (*StringPoint, error) {
 	// Calculate next window if we have no more points.
 	if len(itr.points) == 0 {
 		var err error
@@ -3963,17 +8942,17 @@ func (itr *integerReduceFloatIterator) Next() (*FloatPoint, error) {
 	return p, nil
 }
 
-// integerReduceFloatPoint stores the reduced data for a name/tag combination.
-type integerReduceFloatPoint struct {
+// unsignedReduceStringPoint stores the reduced data for a name/tag combination.
+type unsignedReduceStringPoint struct {
 	Name       string
 	Tags       Tags
-	Aggregator IntegerPointAggregator
-	Emitter    FloatPointEmitter
+	Aggregator UnsignedPointAggregator
+	Emitter    StringPointEmitter
 }
 
 // reduce executes fn once for every point in the next window.
 // The previous value for the dimension is passed to fn.
-func (itr *integerReduceFloatIterator) reduce() ([]FloatPoint, error) {
+func (itr *unsignedReduceStringIterator) reduce() ([]StringPoint, error) {
 	// Calculate next window.
 	var (
 		startTime, endTime int64
@@ -3998,7 +8977,7 @@ func (itr *integerReduceFloatIterator) reduce() ([]FloatPoint, error) {
 	}
 
 	// Create points by tags.
-	m := make(map[string]*integerReduceFloatPoint)
+	m := make(map[string]*unsignedReduceStringPoint)
 	for {
 		// Read next point.
 		curr, err := itr.input.NextInWindow(startTime, endTime)
@@ -4031,7 +9010,7 @@ func (itr *integerReduceFloatIterator) reduce() ([]FloatPoint, error) {
 		rp := m[id]
 		if rp == nil {
 			aggregator, emitter := itr.create()
-			rp = &integerReduceFloatPoint{
+			rp = &unsignedReduceStringPoint{
 				Name:       curr.Name,
 				Tags:       tags,
 				Aggregator: aggregator,
@@ -4039,7 +9018,7 @@ func (itr *integerReduceFloatIterator) reduce() ([]FloatPoint, error) {
 			}
 			m[id] = rp
 		}
-		rp.Aggregator.AggregateInteger(curr)
+		rp.Aggregator.AggregateUnsigned(curr)
 	}
 
 	// Reverse sort points by name & tag if our output is supposed to be ordered.
@@ -4054,7 +9033,7 @@ func (itr *integerReduceFloatIterator) reduce() ([]FloatPoint, error) {
 	// Assume the points are already sorted until proven otherwise.
 	sortedByTime := true
 	// Emit the points for each name & tag combination.
-	a := make([]FloatPoint, 0, len(m))
+	a := make([]StringPoint, 0, len(m))
 	for _, k := range keys {
 		rp := m[k]
 		points := rp.Emitter.Emit()
@@ -4073,41 +9052,41 @@ func (itr *integerReduceFloatIterator) reduce() ([]FloatPoint, error) {
 
 	// Points may be out of order. Perform a stable sort by time if requested.
 	if !sortedByTime && itr.opt.Ordered {
-		sort.Stable(sort.Reverse(floatPointsByTime(a)))
+		sort.Stable(sort.Reverse(stringPointsByTime(a)))
 	}
 
 	return a, nil
 }
 
-// integerStreamFloatIterator streams inputs into the iterator and emits points gradually.
-type integerStreamFloatIterator struct {
-	input  *bufIntegerIterator
-	create func() (IntegerPointAggregator, FloatPointEmitter)
+// unsignedStreamStringIterator streams inputs into the iterator and emits points gradually.
+type unsignedStreamStringIterator struct {
+	input  *bufUnsignedIterator
+	create func() (UnsignedPointAggregator, StringPointEmitter)
 	dims   []string
 	opt    IteratorOptions
-	m      map[string]*integerReduceFloatPoint
-	points []FloatPoint
+	m      map[string]*unsignedReduceStringPoint
+	points []StringPoint
 }
 
-// newIntegerStreamFloatIterator returns a new instance of integerStreamFloatIterator.
-func newIntegerStreamFloatIterator(input IntegerIterator, createFn func() (IntegerPointAggregator, FloatPointEmitter), opt IteratorOptions) *integerStreamFloatIterator {
-	return &integerStreamFloatIterator{
-		input:  newBufIntegerIterator(input),
+// newUnsignedStreamStringIterator returns a new instance of unsignedStreamStringIterator.
+func newUnsignedStreamStringIterator(input UnsignedIterator, createFn func() (UnsignedPointAggregator, StringPointEmitter), opt IteratorOptions) *unsignedStreamStringIterator {
+	return &unsignedStreamStringIterator{
+		input:  newBufUnsignedIterator(input),
 		create: createFn,
 		dims:   opt.GetDimensions(),
 		opt:    opt,
-		m:      make(map[string]*integerReduceFloatPoint),
+		m:      make(map[string]*unsignedReduceStringPoint),
 	}
 }
 
 // Stats returns stats from the input iterator.
-func (itr *integerStreamFloatIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *unsignedStreamStringIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *integerStreamFloatIterator) Close() error { return itr.input.Close() }
+func (itr *unsignedStreamStringIterator) Close() error { return itr.input.Close() }
 
 // Next returns the next value for the stream iterator.
-func (itr *integerStreamFloatIterator) Next() (*FloatPoint, error) {
+func (itr *unsignedStreamStringIterator) Next() (*StringPoint, error) {
 	// Calculate next window if we have no more points.
 	if len(itr.points) == 0 {
 		var err error
@@ -4125,13 +9104,13 @@ func (itr *integerStreamFloatIterator) Next() (*FloatPoint, error) {
 
 // reduce creates and manages aggregators for every point from the input.
 // After aggregating a point, it always tries to emit a value using the emitter.
-func (itr *integerStreamFloatIterator) reduce() ([]FloatPoint, error) {
+func (itr *unsignedStreamStringIterator) reduce() ([]StringPoint, error) {
 	for {
 		// Read next point.
 		curr, err := itr.input.Next()
 		if curr == nil {
 			// Close all of the aggregators to flush any remaining points to emit.
-			var points []FloatPoint
+			var points []StringPoint
 			for _, rp := range itr.m {
 				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
 					if err := aggregator.Close(); err != nil {
@@ -4170,7 +9149,7 @@ func (itr *integerStreamFloatIterator) reduce() ([]FloatPoint, error) {
 		rp := itr.m[id]
 		if rp == nil {
 			aggregator, emitter := itr.create()
-			rp = &integerReduceFloatPoint{
+			rp = &unsignedReduceStringPoint{
 				Name:       curr.Name,
 				Tags:       tags,
 				Aggregator: aggregator,
@@ -4178,7 +9157,7 @@ func (itr *integerStreamFloatIterator) reduce() ([]FloatPoint, error) {
 			}
 			itr.m[id] = rp
 		}
-		rp.Aggregator.AggregateInteger(curr)
+		rp.Aggregator.AggregateUnsigned(curr)
 
 		// Attempt to emit points from the aggregator.
 		points := rp.Emitter.Emit()
@@ -4194,47 +9173,47 @@ func (itr *integerStreamFloatIterator) reduce() ([]FloatPoint, error) {
 	}
 }
 
-// integerFloatExprIterator executes a function to modify an existing point
+// unsignedStringExprIterator executes a function to modify an existing point
 // for every output of the input iterator.
-type integerFloatExprIterator struct {
-	left      *bufIntegerIterator
-	right     *bufIntegerIterator
-	fn        integerFloatExprFunc
-	points    []IntegerPoint // must be size 2
+type unsignedStringExprIterator struct {
+	left      *bufUnsignedIterator
+	right     *bufUnsignedIterator
+	fn        unsignedStringExprFunc
+	points    []UnsignedPoint // must be size 2
 	storePrev bool
 }
 
-func newIntegerFloatExprIterator(left, right IntegerIterator, opt IteratorOptions, fn func(a, b int64) float64) *integerFloatExprIterator {
-	var points []IntegerPoint
+func newUnsignedStringExprIterator(left, right UnsignedIterator, opt IteratorOptions, fn func(a, b uint64) string) *unsignedStringExprIterator {
+	var points []UnsignedPoint
 	switch opt.Fill {
 	case NullFill, PreviousFill:
-		points = []IntegerPoint{{Nil: true}, {Nil: true}}
-	case NumberFill:
-		value := castToInteger(opt.FillValue)
-		points = []IntegerPoint{{Value: value}, {Value: value}}
-	}
-	return &integerFloatExprIterator{
-		left:      newBufIntegerIterator(left),
-		right:     newBufIntegerIterator(right),
+		points = []UnsignedPoint{{Nil: true}, {Nil: true}}
+	case NumberFill:
+		value := castToUnsigned(opt.FillValue)
+		points = []UnsignedPoint{{Value: value}, {Value: value}}
+	}
+	return &unsignedStringExprIterator{
+		left:      newBufUnsignedIterator(left),
+		right:     newBufUnsignedIterator(right),
 		points:    points,
 		fn:        fn,
 		storePrev: opt.Fill == PreviousFill,
 	}
 }
 
-func (itr *integerFloatExprIterator) Stats() IteratorStats {
+func (itr *unsignedStringExprIterator) Stats() IteratorStats {
 	stats := itr.left.Stats()
 	stats.Add(itr.right.Stats())
 	return stats
 }
 
-func (itr *integerFloatExprIterator) Close() error {
+func (itr *unsignedStringExprIterator) Close() error {
 	itr.left.Close()
 	itr.right.Close()
 	return nil
 }
 
-func (itr *integerFloatExprIterator) Next() (*FloatPoint, error) {
+func (itr *unsignedStringExprIterator) Next() (*StringPoint, error) {
 	for {
 		a, b, err := itr.next()
 		if err != nil || (a == nil && b == nil) {
@@ -4275,7 +9254,7 @@ func (itr *integerFloatExprIterator) Next() (*FloatPoint, error) {
 			itr.points[0], itr.points[1] = *a, *b
 		}
 
-		p := &FloatPoint{
+		p := &StringPoint{
 			Name:       a.Name,
 			Tags:       a.Tags,
 			Time:       a.Time,
@@ -4292,7 +9271,7 @@ func (itr *integerFloatExprIterator) Next() (*FloatPoint, error) {
 
 // next returns the next points within each iterator. If the iterators are
 // uneven, it organizes them so only matching points are returned.
-func (itr *integerFloatExprIterator) next() (a, b *IntegerPoint, err error) {
+func (itr *unsignedStringExprIterator) next() (a, b *UnsignedPoint, err error) {
 	// Retrieve the next value for both the left and right.
 	a, err = itr.left.Next()
 	if err != nil {
@@ -4332,24 +9311,24 @@ func (itr *integerFloatExprIterator) next() (a, b *IntegerPoint, err error) {
 	return a, b, nil
 }
 
-// integerFloatExprFunc creates or modifies a point by combining two
+// unsignedStringExprFunc creates or modifies a point by combining two
 // points. The point passed in may be modified and returned rather than
 // allocating a new point if possible. One of the points may be nil, but at
 // least one of the points will be non-nil.
-type integerFloatExprFunc func(a, b int64) float64
+type unsignedStringExprFunc func(a, b uint64) string
 
-// integerReduceIntegerIterator executes a reducer for every interval and buffers the result.
-type integerReduceIntegerIterator struct {
-	input  *bufIntegerIterator
-	create func() (IntegerPointAggregator, IntegerPointEmitter)
+// unsignedReduceBooleanIterator executes a reducer for every interval and buffers the result.
+type unsignedReduceBooleanIterator struct {
+	input  *bufUnsignedIterator
+	create func() (UnsignedPointAggregator, BooleanPointEmitter)
 	dims   []string
 	opt    IteratorOptions
-	points []IntegerPoint
+	points []BooleanPoint
 }
 
-func newIntegerReduceIntegerIterator(input IntegerIterator, opt IteratorOptions, createFn func() (IntegerPointAggregator, IntegerPointEmitter)) *integerReduceIntegerIterator {
-	return &integerReduceIntegerIterator{
-		input:  newBufIntegerIterator(input),
+func newUnsignedReduceBooleanIterator(input UnsignedIterator, opt IteratorOptions, createFn func() (UnsignedPointAggregator, BooleanPointEmitter)) *unsignedReduceBooleanIterator {
+	return &unsignedReduceBooleanIterator{
+		input:  newBufUnsignedIterator(input),
 		create: createFn,
 		dims:   opt.GetDimensions(),
 		opt:    opt,
@@ -4357,13 +9336,13 @@ func newIntegerReduceIntegerIterator(input IntegerIterator, opt IteratorOptions,
 }
 
 // Stats returns stats from the input iterator.
-func (itr *integerReduceIntegerIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *unsignedReduceBooleanIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *integerReduceIntegerIterator) Close() error { return itr.input.Close() }
+func (itr *unsignedReduceBooleanIterator) Close() error { return itr.input.Close() }
 
 // Next returns the minimum value for the next available interval.
-func (itr *integerReduceIntegerIterator) Next() (*IntegerPoint, error) {
+func (itr *unsignedReduceBooleanIterator) Next() (*BooleanPoint, error) {
 	// Calculate next window if we have no more points.
 	if len(itr.points) == 0 {
 		var err error
@@ -4379,17 +9358,17 @@ func (itr *integerReduceIntegerIterator) Next() (*IntegerPoint, error) {
 	return p, nil
 }
 
-// integerReduceIntegerPoint stores the reduced data for a name/tag combination.
-type integerReduceIntegerPoint struct {
+// unsignedReduceBooleanPoint stores the reduced data for a name/tag combination.
+type unsignedReduceBooleanPoint struct {
 	Name       string
 	Tags       Tags
-	Aggregator IntegerPointAggregator
-	Emitter    IntegerPointEmitter
+	Aggregator UnsignedPointAggregator
+	Emitter    BooleanPointEmitter
 }
 
 // reduce executes fn once for every point in the next window.
 // The previous value for the dimension is passed to fn.
-func (itr *integerReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
+func (itr *unsignedReduceBooleanIterator) reduce() ([]BooleanPoint, error) {
 	// Calculate next window.
 	var (
 		startTime, endTime int64
@@ -4414,7 +9393,7 @@ func (itr *integerReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
 	}
 
 	// Create points by tags.
-	m := make(map[string]*integerReduceIntegerPoint)
+	m := make(map[string]*unsignedReduceBooleanPoint)
 	for {
 		// Read next point.
 		curr, err := itr.input.NextInWindow(startTime, endTime)
@@ -4447,7 +9426,7 @@ func (itr *integerReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
 		rp := m[id]
 		if rp == nil {
 			aggregator, emitter := itr.create()
-			rp = &integerReduceIntegerPoint{
+			rp = &unsignedReduceBooleanPoint{
 				Name:       curr.Name,
 				Tags:       tags,
 				Aggregator: aggregator,
@@ -4455,7 +9434,7 @@ func (itr *integerReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
 			}
 			m[id] = rp
 		}
-		rp.Aggregator.AggregateInteger(curr)
+		rp.Aggregator.AggregateUnsigned(curr)
 	}
 
 	// Reverse sort points by name & tag if our output is supposed to be ordered.
@@ -4470,7 +9449,7 @@ func (itr *integerReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
 	// Assume the points are already sorted until proven otherwise.
 	sortedByTime := true
 	// Emit the points for each name & tag combination.
-	a := make([]IntegerPoint, 0, len(m))
+	a := make([]BooleanPoint, 0, len(m))
 	for _, k := range keys {
 		rp := m[k]
 		points := rp.Emitter.Emit()
@@ -4489,41 +9468,41 @@ func (itr *integerReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
 
 	// Points may be out of order. Perform a stable sort by time if requested.
 	if !sortedByTime && itr.opt.Ordered {
-		sort.Stable(sort.Reverse(integerPointsByTime(a)))
+		sort.Stable(sort.Reverse(booleanPointsByTime(a)))
 	}
 
 	return a, nil
 }
 
-// integerStreamIntegerIterator streams inputs into the iterator and emits points gradually.
-type integerStreamIntegerIterator struct {
-	input  *bufIntegerIterator
-	create func() (IntegerPointAggregator, IntegerPointEmitter)
+// unsignedStreamBooleanIterator streams inputs into the iterator and emits points gradually.
+type unsignedStreamBooleanIterator struct {
+	input  *bufUnsignedIterator
+	create func() (UnsignedPointAggregator, BooleanPointEmitter)
 	dims   []string
 	opt    IteratorOptions
-	m      map[string]*integerReduceIntegerPoint
-	points []IntegerPoint
+	m      map[string]*unsignedReduceBooleanPoint
+	points []BooleanPoint
 }
 
-// newIntegerStreamIntegerIterator returns a new instance of integerStreamIntegerIterator.
-func newIntegerStreamIntegerIterator(input IntegerIterator, createFn func() (IntegerPointAggregator, IntegerPointEmitter), opt IteratorOptions) *integerStreamIntegerIterator {
-	return &integerStreamIntegerIterator{
-		input:  newBufIntegerIterator(input),
+// newUnsignedStreamBooleanIterator returns a new instance of unsignedStreamBooleanIterator.
+func newUnsignedStreamBooleanIterator(input UnsignedIterator, createFn func() (UnsignedPointAggregator, BooleanPointEmitter), opt IteratorOptions) *unsignedStreamBooleanIterator {
+	return &unsignedStreamBooleanIterator{
+		input:  newBufUnsignedIterator(input),
 		create: createFn,
 		dims:   opt.GetDimensions(),
 		opt:    opt,
-		m:      make(map[string]*integerReduceIntegerPoint),
+		m:      make(map[string]*unsignedReduceBooleanPoint),
 	}
 }
 
 // Stats returns stats from the input iterator.
-func (itr *integerStreamIntegerIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *unsignedStreamBooleanIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *integerStreamIntegerIterator) Close() error { return itr.input.Close() }
+func (itr *unsignedStreamBooleanIterator) Close() error { return itr.input.Close() }
 
 // Next returns the next value for the stream iterator.
-func (itr *integerStreamIntegerIterator) Next() (*IntegerPoint, error) {
+func (itr *unsignedStreamBooleanIterator) Next() (*BooleanPoint, error) {
 	// Calculate next window if we have no more points.
 	if len(itr.points) == 0 {
 		var err error
@@ -4541,13 +9520,13 @@ func (itr *integerStreamIntegerIterator) Next() (*IntegerPoint, error) {
 
 // reduce creates and manages aggregators for every point from the input.
 // After aggregating a point, it always tries to emit a value using the emitter.
-func (itr *integerStreamIntegerIterator) reduce() ([]IntegerPoint, error) {
+func (itr *unsignedStreamBooleanIterator) reduce() ([]BooleanPoint, error) {
 	for {
 		// Read next point.
 		curr, err := itr.input.Next()
 		if curr == nil {
 			// Close all of the aggregators to flush any remaining points to emit.
-			var points []IntegerPoint
+			var points []BooleanPoint
 			for _, rp := range itr.m {
 				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
 					if err := aggregator.Close(); err != nil {
@@ -4586,7 +9565,7 @@ func (itr *integerStreamIntegerIterator) reduce() ([]IntegerPoint, error) {
 		rp := itr.m[id]
 		if rp == nil {
 			aggregator, emitter := itr.create()
-			rp = &integerReduceIntegerPoint{
+			rp = &unsignedReduceBooleanPoint{
 				Name:       curr.Name,
 				Tags:       tags,
 				Aggregator: aggregator,
@@ -4594,7 +9573,7 @@ func (itr *integerStreamIntegerIterator) reduce() ([]IntegerPoint, error) {
 			}
 			itr.m[id] = rp
 		}
-		rp.Aggregator.AggregateInteger(curr)
+		rp.Aggregator.AggregateUnsigned(curr)
 
 		// Attempt to emit points from the aggregator.
 		points := rp.Emitter.Emit()
@@ -4610,47 +9589,47 @@ func (itr *integerStreamIntegerIterator) reduce() ([]IntegerPoint, error) {
 	}
 }
 
-// integerExprIterator executes a function to modify an existing point
+// unsignedBooleanExprIterator executes a function to modify an existing point
 // for every output of the input iterator.
-type integerExprIterator struct {
-	left      *bufIntegerIterator
-	right     *bufIntegerIterator
-	fn        integerExprFunc
-	points    []IntegerPoint // must be size 2
+type unsignedBooleanExprIterator struct {
+	left      *bufUnsignedIterator
+	right     *bufUnsignedIterator
+	fn        unsignedBooleanExprFunc
+	points    []UnsignedPoint // must be size 2
 	storePrev bool
 }
 
-func newIntegerExprIterator(left, right IntegerIterator, opt IteratorOptions, fn func(a, b int64) int64) *integerExprIterator {
-	var points []IntegerPoint
+func newUnsignedBooleanExprIterator(left, right UnsignedIterator, opt IteratorOptions, fn func(a, b uint64) bool) *unsignedBooleanExprIterator {
+	var points []UnsignedPoint
 	switch opt.Fill {
 	case NullFill, PreviousFill:
-		points = []IntegerPoint{{Nil: true}, {Nil: true}}
+		points = []UnsignedPoint{{Nil: true}, {Nil: true}}
 	case NumberFill:
-		value := castToInteger(opt.FillValue)
-		points = []IntegerPoint{{Value: value}, {Value: value}}
+		value := castToUnsigned(opt.FillValue)
+		points = []UnsignedPoint{{Value: value}, {Value: value}}
 	}
-	return &integerExprIterator{
-		left:      newBufIntegerIterator(left),
-		right:     newBufIntegerIterator(right),
+	return &unsignedBooleanExprIterator{
+		left:      newBufUnsignedIterator(left),
+		right:     newBufUnsignedIterator(right),
 		points:    points,
 		fn:        fn,
 		storePrev: opt.Fill == PreviousFill,
 	}
 }
 
-func (itr *integerExprIterator) Stats() IteratorStats {
+func (itr *unsignedBooleanExprIterator) Stats() IteratorStats {
 	stats := itr.left.Stats()
 	stats.Add(itr.right.Stats())
 	return stats
 }
 
-func (itr *integerExprIterator) Close() error {
+func (itr *unsignedBooleanExprIterator) Close() error {
 	itr.left.Close()
 	itr.right.Close()
 	return nil
 }
 
-func (itr *integerExprIterator) Next() (*IntegerPoint, error) {
+func (itr *unsignedBooleanExprIterator) Next() (*BooleanPoint, error) {
 	for {
 		a, b, err := itr.next()
 		if err != nil || (a == nil && b == nil) {
@@ -4691,2147 +9670,2151 @@ func (itr *integerExprIterator) Next() (*IntegerPoint, error) {
 			itr.points[0], itr.points[1] = *a, *b
 		}
 
-		if a.Nil {
-			return a, nil
-		} else if b.Nil {
-			return b, nil
-		}
-		a.Value = itr.fn(a.Value, b.Value)
-		return a, nil
-
-	}
-}
-
-// next returns the next points within each iterator. If the iterators are
-// uneven, it organizes them so only matching points are returned.
-func (itr *integerExprIterator) next() (a, b *IntegerPoint, err error) {
-	// Retrieve the next value for both the left and right.
-	a, err = itr.left.Next()
-	if err != nil {
-		return nil, nil, err
-	}
-	b, err = itr.right.Next()
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// If we have a point from both, make sure that they match each other.
-	if a != nil && b != nil {
-		if a.Name > b.Name {
-			itr.left.unread(a)
-			return nil, b, nil
-		} else if a.Name < b.Name {
-			itr.right.unread(b)
-			return a, nil, nil
-		}
-
-		if ltags, rtags := a.Tags.ID(), b.Tags.ID(); ltags > rtags {
-			itr.left.unread(a)
-			return nil, b, nil
-		} else if ltags < rtags {
-			itr.right.unread(b)
-			return a, nil, nil
-		}
-
-		if a.Time > b.Time {
-			itr.left.unread(a)
-			return nil, b, nil
-		} else if a.Time < b.Time {
-			itr.right.unread(b)
-			return a, nil, nil
-		}
-	}
-	return a, b, nil
-}
-
-// integerExprFunc creates or modifies a point by combining two
-// points. The point passed in may be modified and returned rather than
-// allocating a new point if possible. One of the points may be nil, but at
-// least one of the points will be non-nil.
-type integerExprFunc func(a, b int64) int64
-
-// integerReduceStringIterator executes a reducer for every interval and buffers the result.
-type integerReduceStringIterator struct {
-	input  *bufIntegerIterator
-	create func() (IntegerPointAggregator, StringPointEmitter)
-	dims   []string
-	opt    IteratorOptions
-	points []StringPoint
-}
-
-func newIntegerReduceStringIterator(input IntegerIterator, opt IteratorOptions, createFn func() (IntegerPointAggregator, StringPointEmitter)) *integerReduceStringIterator {
-	return &integerReduceStringIterator{
-		input:  newBufIntegerIterator(input),
-		create: createFn,
-		dims:   opt.GetDimensions(),
-		opt:    opt,
-	}
-}
-
-// Stats returns stats from the input iterator.
-func (itr *integerReduceStringIterator) Stats() IteratorStats { return itr.input.Stats() }
-
-// Close closes the iterator and all child iterators.
-func (itr *integerReduceStringIterator) Close() error { return itr.input.Close() }
-
-// Next returns the minimum value for the next available interval.
-func (itr *integerReduceStringIterator) Next() (*StringPoint, error) {
-	// Calculate next window if we have no more points.
-	if len(itr.points) == 0 {
-		var err error
-		itr.points, err = itr.reduce()
-		if len(itr.points) == 0 {
-			return nil, err
-		}
-	}
-
-	// Pop next point off the stack.
-	p := &itr.points[len(itr.points)-1]
-	itr.points = itr.points[:len(itr.points)-1]
-	return p, nil
-}
-
-// integerReduceStringPoint stores the reduced data for a name/tag combination.
-type integerReduceStringPoint struct {
-	Name       string
-	Tags       Tags
-	Aggregator IntegerPointAggregator
-	Emitter    StringPointEmitter
-}
-
-// reduce executes fn once for every point in the next window.
-// The previous value for the dimension is passed to fn.
-func (itr *integerReduceStringIterator) reduce() ([]StringPoint, error) {
-	// Calculate next window.
-	var (
-		startTime, endTime int64
-		window             struct {
-			name string
-			tags string
-		}
-	)
-	for {
-		p, err := itr.input.Next()
-		if err != nil || p == nil {
-			return nil, err
-		} else if p.Nil {
-			continue
-		}
-
-		// Unread the point so it can be processed.
-		itr.input.unread(p)
-		startTime, endTime = itr.opt.Window(p.Time)
-		window.name, window.tags = p.Name, p.Tags.Subset(itr.opt.Dimensions).ID()
-		break
-	}
-
-	// Create points by tags.
-	m := make(map[string]*integerReduceStringPoint)
-	for {
-		// Read next point.
-		curr, err := itr.input.NextInWindow(startTime, endTime)
-		if err != nil {
-			return nil, err
-		} else if curr == nil {
-			break
-		} else if curr.Nil {
-			continue
-		} else if curr.Name != window.name {
-			itr.input.unread(curr)
-			break
-		}
-
-		// Ensure this point is within the same final window.
-		if curr.Name != window.name {
-			itr.input.unread(curr)
-			break
-		} else if tags := curr.Tags.Subset(itr.opt.Dimensions); tags.ID() != window.tags {
-			itr.input.unread(curr)
-			break
+		p := &BooleanPoint{
+			Name:       a.Name,
+			Tags:       a.Tags,
+			Time:       a.Time,
+			Nil:        a.Nil || b.Nil,
+			Aggregated: a.Aggregated,
 		}
-
-		// Retrieve the tags on this point for this level of the query.
-		// This may be different than the bucket dimensions.
-		tags := curr.Tags.Subset(itr.dims)
-		id := tags.ID()
-
-		// Retrieve the aggregator for this name/tag combination or create one.
-		rp := m[id]
-		if rp == nil {
-			aggregator, emitter := itr.create()
-			rp = &integerReduceStringPoint{
-				Name:       curr.Name,
-				Tags:       tags,
-				Aggregator: aggregator,
-				Emitter:    emitter,
-			}
-			m[id] = rp
+		if !p.Nil {
+			p.Value = itr.fn(a.Value, b.Value)
 		}
-		rp.Aggregator.AggregateInteger(curr)
+		return p, nil
+
 	}
+}
 
-	// Reverse sort points by name & tag if our output is supposed to be ordered.
-	keys := make([]string, 0, len(m))
-	for k := range m {
-		keys = append(keys, k)
+// next returns the next points within each iterator. If the iterators are
+// uneven, it organizes them so only matching points are returned.
+func (itr *unsignedBooleanExprIterator) next() (a, b *UnsignedPoint, err error) {
+	// Retrieve the next value for both the left and right.
+	a, err = itr.left.Next()
+	if err != nil {
+		return nil, nil, err
 	}
-	if len(keys) > 1 && itr.opt.Ordered {
-		sort.Sort(reverseStringSlice(keys))
+	b, err = itr.right.Next()
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Assume the points are already sorted until proven otherwise.
-	sortedByTime := true
-	// Emit the points for each name & tag combination.
-	a := make([]StringPoint, 0, len(m))
-	for _, k := range keys {
-		rp := m[k]
-		points := rp.Emitter.Emit()
-		for i := len(points) - 1; i >= 0; i-- {
-			points[i].Name = rp.Name
-			points[i].Tags = rp.Tags
-			// Set the points time to the interval time if the reducer didn't provide one.
-			if points[i].Time == ZeroTime {
-				points[i].Time = startTime
-			} else {
-				sortedByTime = false
-			}
-			a = append(a, points[i])
+	// If we have a point from both, make sure that they match each other.
+	if a != nil && b != nil {
+		if a.Name > b.Name {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Name < b.Name {
+			itr.right.unread(b)
+			return a, nil, nil
 		}
-	}
 
-	// Points may be out of order. Perform a stable sort by time if requested.
-	if !sortedByTime && itr.opt.Ordered {
-		sort.Stable(sort.Reverse(stringPointsByTime(a)))
-	}
+		if ltags, rtags := a.Tags.ID(), b.Tags.ID(); ltags > rtags {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if ltags < rtags {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
 
-	return a, nil
+		if a.Time > b.Time {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Time < b.Time {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+	}
+	return a, b, nil
 }
 
-// integerStreamStringIterator streams inputs into the iterator and emits points gradually.
-type integerStreamStringIterator struct {
-	input  *bufIntegerIterator
-	create func() (IntegerPointAggregator, StringPointEmitter)
-	dims   []string
-	opt    IteratorOptions
-	m      map[string]*integerReduceStringPoint
-	points []StringPoint
+// unsignedBooleanExprFunc creates or modifies a point by combining two
+// points. The point passed in may be modified and returned rather than
+// allocating a new point if possible. One of the points may be nil, but at
+// least one of the points will be non-nil.
+type unsignedBooleanExprFunc func(a, b uint64) bool
+
+// unsignedTransformIterator executes a function to modify an existing point for every
+// output of the input iterator.
+type unsignedTransformIterator struct {
+	input UnsignedIterator
+	fn    unsignedTransformFunc
 }
 
-// newIntegerStreamStringIterator returns a new instance of integerStreamStringIterator.
-func newIntegerStreamStringIterator(input IntegerIterator, createFn func() (IntegerPointAggregator, StringPointEmitter), opt IteratorOptions) *integerStreamStringIterator {
-	return &integerStreamStringIterator{
-		input:  newBufIntegerIterator(input),
-		create: createFn,
-		dims:   opt.GetDimensions(),
-		opt:    opt,
-		m:      make(map[string]*integerReduceStringPoint),
+// Stats returns stats from the input iterator.
+func (itr *unsignedTransformIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *unsignedTransformIterator) Close() error { return itr.input.Close() }
+
+// Next returns the minimum value for the next available interval.
+func (itr *unsignedTransformIterator) Next() (*UnsignedPoint, error) {
+	p, err := itr.input.Next()
+	if err != nil {
+		return nil, err
+	} else if p != nil {
+		p = itr.fn(p)
 	}
+	return p, nil
+}
+
+// unsignedTransformFunc creates or modifies a point.
+// The point passed in may be modified and returned rather than allocating a
+// new point if possible.
+type unsignedTransformFunc func(p *UnsignedPoint) *UnsignedPoint
+
+// unsignedBoolTransformIterator executes a function to modify an existing point for every
+// output of the input iterator.
+type unsignedBoolTransformIterator struct {
+	input UnsignedIterator
+	fn    unsignedBoolTransformFunc
 }
 
 // Stats returns stats from the input iterator.
-func (itr *integerStreamStringIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *unsignedBoolTransformIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *integerStreamStringIterator) Close() error { return itr.input.Close() }
+func (itr *unsignedBoolTransformIterator) Close() error { return itr.input.Close() }
 
-// Next returns the next value for the stream iterator.
-func (itr *integerStreamStringIterator) Next() (*StringPoint, error) {
-	// Calculate next window if we have no more points.
-	if len(itr.points) == 0 {
-		var err error
-		itr.points, err = itr.reduce()
-		if len(itr.points) == 0 {
-			return nil, err
-		}
+// Next returns the minimum value for the next available interval.
+func (itr *unsignedBoolTransformIterator) Next() (*BooleanPoint, error) {
+	p, err := itr.input.Next()
+	if err != nil {
+		return nil, err
+	} else if p != nil {
+		return itr.fn(p), nil
 	}
+	return nil, nil
+}
 
-	// Pop next point off the stack.
-	p := &itr.points[len(itr.points)-1]
-	itr.points = itr.points[:len(itr.points)-1]
-	return p, nil
+// unsignedBoolTransformFunc creates or modifies a point.
+// The point passed in may be modified and returned rather than allocating a
+// new point if possible.
+type unsignedBoolTransformFunc func(p *UnsignedPoint) *BooleanPoint
+
+// unsignedDedupeIterator only outputs unique points.
+// This differs from the DistinctIterator in that it compares all aux fields too.
+// This iterator is relatively inefficient and should only be used on small
+// datasets such as meta query results.
+type unsignedDedupeIterator struct {
+	input UnsignedIterator
+	m     map[string]struct{} // lookup of points already sent
 }
 
-// reduce creates and manages aggregators for every point from the input.
-// After aggregating a point, it always tries to emit a value using the emitter.
-func (itr *integerStreamStringIterator) reduce() ([]StringPoint, error) {
-	for {
-		// Read next point.
-		curr, err := itr.input.Next()
-		if curr == nil {
-			// Close all of the aggregators to flush any remaining points to emit.
-			var points []StringPoint
-			for _, rp := range itr.m {
-				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
-					if err := aggregator.Close(); err != nil {
-						return nil, err
-					}
+type unsignedIteratorMapper struct {
+	e      *Emitter
+	buf    []interface{}
+	driver IteratorMap   // which iterator to use for the primary value, can be nil
+	fields []IteratorMap // which iterator to use for an aux field
+	point  UnsignedPoint
+}
 
-					pts := rp.Emitter.Emit()
-					if len(pts) == 0 {
-						continue
-					}
+func newUnsignedIteratorMapper(itrs []Iterator, driver IteratorMap, fields []IteratorMap, opt IteratorOptions) *unsignedIteratorMapper {
+	e := NewEmitter(itrs, opt.Ascending, 0)
+	e.OmitTime = true
+	return &unsignedIteratorMapper{
+		e:      e,
+		buf:    make([]interface{}, len(itrs)),
+		driver: driver,
+		fields: fields,
+		point: UnsignedPoint{
+			Aux: make([]interface{}, len(fields)),
+		},
+	}
+}
 
-					for i := range pts {
-						pts[i].Name = rp.Name
-						pts[i].Tags = rp.Tags
-					}
-					points = append(points, pts...)
-				}
-			}
+func (itr *unsignedIteratorMapper) Next() (*UnsignedPoint, error) {
+	t, name, tags, err := itr.e.loadBuf()
+	if err != nil || t == ZeroTime {
+		return nil, err
+	}
+	itr.point.Time = t
+	itr.point.Name = name
+	itr.point.Tags = tags
 
-			// Eliminate the aggregators and emitters.
-			itr.m = nil
-			return points, nil
-		} else if err != nil {
-			return nil, err
-		} else if curr.Nil {
-			continue
+	itr.e.readInto(t, name, tags, itr.buf)
+	if itr.driver != nil {
+		if v := itr.driver.Value(tags, itr.buf); v != nil {
+			if v, ok := v.(uint64); ok {
+				itr.point.Value = v
+				itr.point.Nil = false
+			} else {
+				itr.point.Value = 0
+				itr.point.Nil = true
+			}
+		} else {
+			itr.point.Value = 0
+			itr.point.Nil = true
 		}
-		tags := curr.Tags.Subset(itr.dims)
+	}
+	for i, f := range itr.fields {
+		itr.point.Aux[i] = f.Value(tags, itr.buf)
+	}
+	return &itr.point, nil
+}
 
-		id := curr.Name
-		if len(tags.m) > 0 {
-			id += "\x00" + tags.ID()
-		}
+func (itr *unsignedIteratorMapper) Stats() IteratorStats {
+	stats := IteratorStats{}
+	for _, itr := range itr.e.itrs {
+		stats.Add(itr.Stats())
+	}
+	return stats
+}
 
-		// Retrieve the aggregator for this name/tag combination or create one.
-		rp := itr.m[id]
-		if rp == nil {
-			aggregator, emitter := itr.create()
-			rp = &integerReduceStringPoint{
-				Name:       curr.Name,
-				Tags:       tags,
-				Aggregator: aggregator,
-				Emitter:    emitter,
+func (itr *unsignedIteratorMapper) Close() error {
+	return itr.e.Close()
+}
+
+type unsignedFilterIterator struct {
+	input UnsignedIterator
+	cond  Expr
+	opt   IteratorOptions
+	m     map[string]interface{}
+}
+
+func newUnsignedFilterIterator(input UnsignedIterator, cond Expr, opt IteratorOptions) UnsignedIterator {
+	// Strip out time conditions from the WHERE clause.
+	// TODO(jsternberg): This should really be done for us when creating the IteratorOptions struct.
+	n := RewriteFunc(CloneExpr(cond), func(n Node) Node {
+		switch n := n.(type) {
+		case *BinaryExpr:
+			if n.LHS.String() == "time" {
+				return &BooleanLiteral{Val: true}
 			}
-			itr.m[id] = rp
 		}
-		rp.Aggregator.AggregateInteger(curr)
+		return n
+	})
+
+	cond, _ = n.(Expr)
+	if cond == nil {
+		return input
+	} else if n, ok := cond.(*BooleanLiteral); ok && n.Val {
+		return input
+	}
+
+	return &unsignedFilterIterator{
+		input: input,
+		cond:  cond,
+		opt:   opt,
+		m:     make(map[string]interface{}),
+	}
+}
+
+func (itr *unsignedFilterIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *unsignedFilterIterator) Close() error         { return itr.input.Close() }
+
+func (itr *unsignedFilterIterator) Next() (*UnsignedPoint, error) {
+	for {
+		p, err := itr.input.Next()
+		if err != nil || p == nil {
+			return nil, err
+		}
 
-		// Attempt to emit points from the aggregator.
-		points := rp.Emitter.Emit()
-		if len(points) == 0 {
-			continue
+		for i, ref := range itr.opt.Aux {
+			itr.m[ref.Val] = p.Aux[i]
+		}
+		for k, v := range p.Tags.KeyValues() {
+			itr.m[k] = v
 		}
 
-		for i := range points {
-			points[i].Name = rp.Name
-			points[i].Tags = rp.Tags
+		if !EvalBool(itr.cond, itr.m) {
+			continue
 		}
-		return points, nil
+		return p, nil
 	}
 }
 
-// integerStringExprIterator executes a function to modify an existing point
-// for every output of the input iterator.
-type integerStringExprIterator struct {
-	left      *bufIntegerIterator
-	right     *bufIntegerIterator
-	fn        integerStringExprFunc
-	points    []IntegerPoint // must be size 2
-	storePrev bool
-}
-
-func newIntegerStringExprIterator(left, right IntegerIterator, opt IteratorOptions, fn func(a, b int64) string) *integerStringExprIterator {
-	var points []IntegerPoint
-	switch opt.Fill {
-	case NullFill, PreviousFill:
-		points = []IntegerPoint{{Nil: true}, {Nil: true}}
-	case NumberFill:
-		value := castToInteger(opt.FillValue)
-		points = []IntegerPoint{{Value: value}, {Value: value}}
-	}
-	return &integerStringExprIterator{
-		left:      newBufIntegerIterator(left),
-		right:     newBufIntegerIterator(right),
-		points:    points,
-		fn:        fn,
-		storePrev: opt.Fill == PreviousFill,
+// newUnsignedDedupeIterator returns a new instance of unsignedDedupeIterator.
+func newUnsignedDedupeIterator(input UnsignedIterator) *unsignedDedupeIterator {
+	return &unsignedDedupeIterator{
+		input: input,
+		m:     make(map[string]struct{}),
 	}
 }
 
-func (itr *integerStringExprIterator) Stats() IteratorStats {
-	stats := itr.left.Stats()
-	stats.Add(itr.right.Stats())
-	return stats
-}
+// Stats returns stats from the input iterator.
+func (itr *unsignedDedupeIterator) Stats() IteratorStats { return itr.input.Stats() }
 
-func (itr *integerStringExprIterator) Close() error {
-	itr.left.Close()
-	itr.right.Close()
-	return nil
-}
+// Close closes the iterator and all child iterators.
+func (itr *unsignedDedupeIterator) Close() error { return itr.input.Close() }
 
-func (itr *integerStringExprIterator) Next() (*StringPoint, error) {
+// Next returns the next unique point from the input iterator.
+func (itr *unsignedDedupeIterator) Next() (*UnsignedPoint, error) {
 	for {
-		a, b, err := itr.next()
-		if err != nil || (a == nil && b == nil) {
+		// Read next point.
+		p, err := itr.input.Next()
+		if p == nil || err != nil {
 			return nil, err
 		}
 
-		// If any of these are nil and we are using fill(none), skip these points.
-		if (a == nil || a.Nil || b == nil || b.Nil) && itr.points == nil {
-			continue
+		// Serialize to bytes to store in lookup.
+		buf, err := proto.Marshal(encodeUnsignedPoint(p))
+		if err != nil {
+			return nil, err
 		}
 
-		// If one of the two points is nil, we need to fill it with a fake nil
-		// point that has the same name, tags, and time as the other point.
-		// There should never be a time when both of these are nil.
-		if a == nil {
-			p := *b
-			a = &p
-			a.Value = 0
-			a.Nil = true
-		} else if b == nil {
-			p := *a
-			b = &p
-			b.Value = 0
-			b.Nil = true
+		// If the point has already been output then move to the next point.
+		if _, ok := itr.m[string(buf)]; ok {
+			continue
 		}
 
-		// If a value is nil, use the fill values if the fill value is non-nil.
-		if a.Nil && !itr.points[0].Nil {
-			a.Value = itr.points[0].Value
-			a.Nil = false
-		}
-		if b.Nil && !itr.points[1].Nil {
-			b.Value = itr.points[1].Value
-			b.Nil = false
-		}
+		// Otherwise mark it as emitted and return point.
+		itr.m[string(buf)] = struct{}{}
+		return p, nil
+	}
+}
 
-		if itr.storePrev {
-			itr.points[0], itr.points[1] = *a, *b
-		}
+// unsignedReaderIterator represents an iterator that streams from a reader.
+type unsignedReaderIterator struct {
+	r   io.Reader
+	dec *UnsignedPointDecoder
+}
 
-		p := &StringPoint{
-			Name:       a.Name,
-			Tags:       a.Tags,
-			Time:       a.Time,
-			Nil:        a.Nil || b.Nil,
-			Aggregated: a.Aggregated,
-		}
-		if !p.Nil {
-			p.Value = itr.fn(a.Value, b.Value)
-		}
-		return p, nil
+// newUnsignedReaderIterator returns a new instance of unsignedReaderIterator.
+func newUnsignedReaderIterator(r io.Reader, stats IteratorStats) *unsignedReaderIterator {
+	dec := NewUnsignedPointDecoder(r)
+	dec.stats = stats
 
+	return &unsignedReaderIterator{
+		r:   r,
+		dec: dec,
 	}
 }
 
-// next returns the next points within each iterator. If the iterators are
-// uneven, it organizes them so only matching points are returned.
-func (itr *integerStringExprIterator) next() (a, b *IntegerPoint, err error) {
-	// Retrieve the next value for both the left and right.
-	a, err = itr.left.Next()
-	if err != nil {
-		return nil, nil, err
+// Stats returns stats about points processed.
+func (itr *unsignedReaderIterator) Stats() IteratorStats { return itr.dec.stats }
+
+// Close closes the underlying reader, if applicable.
+func (itr *unsignedReaderIterator) Close() error {
+	if r, ok := itr.r.(io.ReadCloser); ok {
+		return r.Close()
 	}
-	b, err = itr.right.Next()
-	if err != nil {
-		return nil, nil, err
+	return nil
+}
+
+// Next returns the next point from the iterator.
+func (itr *unsignedReaderIterator) Next() (*UnsignedPoint, error) {
+	// OPTIMIZE(benbjohnson): Reuse point on iterator.
+
+	// Unmarshal next point.
+	p := &UnsignedPoint{}
+	if err := itr.dec.DecodeUnsignedPoint(p); err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
 	}
+	return p, nil
+}
 
-	// If we have a point from both, make sure that they match each other.
-	if a != nil && b != nil {
-		if a.Name > b.Name {
-			itr.left.unread(a)
-			return nil, b, nil
-		} else if a.Name < b.Name {
-			itr.right.unread(b)
-			return a, nil, nil
-		}
+// StringIterator represents a stream of string points.
+type StringIterator interface {
+	Iterator
+	Next() (*StringPoint, error)
+}
 
-		if ltags, rtags := a.Tags.ID(), b.Tags.ID(); ltags > rtags {
-			itr.left.unread(a)
-			return nil, b, nil
-		} else if ltags < rtags {
-			itr.right.unread(b)
-			return a, nil, nil
-		}
+// newStringIterators converts a slice of Iterator to a slice of StringIterator.
+// Drop and closes any iterator in itrs that is not a StringIterator and cannot
+// be cast to a StringIterator.
+func newStringIterators(itrs []Iterator) []StringIterator {
+	a := make([]StringIterator, 0, len(itrs))
+	for _, itr := range itrs {
+		switch itr := itr.(type) {
+		case StringIterator:
+			a = append(a, itr)
 
-		if a.Time > b.Time {
-			itr.left.unread(a)
-			return nil, b, nil
-		} else if a.Time < b.Time {
-			itr.right.unread(b)
-			return a, nil, nil
+		default:
+			itr.Close()
 		}
 	}
-	return a, b, nil
+	return a
 }
 
-// integerStringExprFunc creates or modifies a point by combining two
-// points. The point passed in may be modified and returned rather than
-// allocating a new point if possible. One of the points may be nil, but at
-// least one of the points will be non-nil.
-type integerStringExprFunc func(a, b int64) string
-
-// integerReduceBooleanIterator executes a reducer for every interval and buffers the result.
-type integerReduceBooleanIterator struct {
-	input  *bufIntegerIterator
-	create func() (IntegerPointAggregator, BooleanPointEmitter)
-	dims   []string
-	opt    IteratorOptions
-	points []BooleanPoint
+// bufStringIterator represents a buffered StringIterator.
+type bufStringIterator struct {
+	itr StringIterator
+	buf *StringPoint
 }
 
-func newIntegerReduceBooleanIterator(input IntegerIterator, opt IteratorOptions, createFn func() (IntegerPointAggregator, BooleanPointEmitter)) *integerReduceBooleanIterator {
-	return &integerReduceBooleanIterator{
-		input:  newBufIntegerIterator(input),
-		create: createFn,
-		dims:   opt.GetDimensions(),
-		opt:    opt,
-	}
+// newBufStringIterator returns a buffered StringIterator.
+func newBufStringIterator(itr StringIterator) *bufStringIterator {
+	return &bufStringIterator{itr: itr}
 }
 
-// Stats returns stats from the input iterator.
-func (itr *integerReduceBooleanIterator) Stats() IteratorStats { return itr.input.Stats() }
+// Stats returns statistics from the input iterator.
+func (itr *bufStringIterator) Stats() IteratorStats { return itr.itr.Stats() }
 
-// Close closes the iterator and all child iterators.
-func (itr *integerReduceBooleanIterator) Close() error { return itr.input.Close() }
+// Close closes the underlying iterator.
+func (itr *bufStringIterator) Close() error { return itr.itr.Close() }
 
-// Next returns the minimum value for the next available interval.
-func (itr *integerReduceBooleanIterator) Next() (*BooleanPoint, error) {
-	// Calculate next window if we have no more points.
-	if len(itr.points) == 0 {
-		var err error
-		itr.points, err = itr.reduce()
-		if len(itr.points) == 0 {
-			return nil, err
-		}
+// peek returns the next point without removing it from the iterator.
+func (itr *bufStringIterator) peek() (*StringPoint, error) {
+	p, err := itr.Next()
+	if err != nil {
+		return nil, err
 	}
-
-	// Pop next point off the stack.
-	p := &itr.points[len(itr.points)-1]
-	itr.points = itr.points[:len(itr.points)-1]
+	itr.unread(p)
 	return p, nil
 }
 
-// integerReduceBooleanPoint stores the reduced data for a name/tag combination.
-type integerReduceBooleanPoint struct {
-	Name       string
-	Tags       Tags
-	Aggregator IntegerPointAggregator
-	Emitter    BooleanPointEmitter
+// peekTime returns the time of the next point.
+// Returns zero time if no more points available.
+func (itr *bufStringIterator) peekTime() (int64, error) {
+	p, err := itr.peek()
+	if p == nil || err != nil {
+		return ZeroTime, err
+	}
+	return p.Time, nil
+}
+
+// Next returns the current buffer, if exists, or calls the underlying iterator.
+func (itr *bufStringIterator) Next() (*StringPoint, error) {
+	buf := itr.buf
+	if buf != nil {
+		itr.buf = nil
+		return buf, nil
+	}
+	return itr.itr.Next()
 }
 
-// reduce executes fn once for every point in the next window.
-// The previous value for the dimension is passed to fn.
-func (itr *integerReduceBooleanIterator) reduce() ([]BooleanPoint, error) {
-	// Calculate next window.
-	var (
-		startTime, endTime int64
-		window             struct {
-			name string
-			tags string
-		}
-	)
-	for {
-		p, err := itr.input.Next()
-		if err != nil || p == nil {
-			return nil, err
-		} else if p.Nil {
-			continue
-		}
-
-		// Unread the point so it can be processed.
-		itr.input.unread(p)
-		startTime, endTime = itr.opt.Window(p.Time)
-		window.name, window.tags = p.Name, p.Tags.Subset(itr.opt.Dimensions).ID()
-		break
+// NextInWindow returns the next value if it is between [startTime, endTime).
+// If the next value is outside the range then it is moved to the buffer.
+func (itr *bufStringIterator) NextInWindow(startTime, endTime int64) (*StringPoint, error) {
+	v, err := itr.Next()
+	if v == nil || err != nil {
+		return nil, err
+	} else if t := v.Time; t >= endTime || t < startTime {
+		itr.unread(v)
+		return nil, nil
 	}
+	return v, nil
+}
 
-	// Create points by tags.
-	m := make(map[string]*integerReduceBooleanPoint)
-	for {
-		// Read next point.
-		curr, err := itr.input.NextInWindow(startTime, endTime)
-		if err != nil {
-			return nil, err
-		} else if curr == nil {
-			break
-		} else if curr.Nil {
-			continue
-		} else if curr.Name != window.name {
-			itr.input.unread(curr)
-			break
-		}
-
-		// Ensure this point is within the same final window.
-		if curr.Name != window.name {
-			itr.input.unread(curr)
-			break
-		} else if tags := curr.Tags.Subset(itr.opt.Dimensions); tags.ID() != window.tags {
-			itr.input.unread(curr)
-			break
-		}
+// unread sets v to the buffer. It is read on the next call to Next().
+func (itr *bufStringIterator) unread(v *StringPoint) { itr.buf = v }
 
-		// Retrieve the tags on this point for this level of the query.
-		// This may be different than the bucket dimensions.
-		tags := curr.Tags.Subset(itr.dims)
-		id := tags.ID()
+// stringMergeIterator represents an iterator that combines multiple string iterators.
+type stringMergeIterator struct {
+	inputs []StringIterator
+	heap   *stringMergeHeap
+	init   bool
 
-		// Retrieve the aggregator for this name/tag combination or create one.
-		rp := m[id]
-		if rp == nil {
-			aggregator, emitter := itr.create()
-			rp = &integerReduceBooleanPoint{
-				Name:       curr.Name,
-				Tags:       tags,
-				Aggregator: aggregator,
-				Emitter:    emitter,
-			}
-			m[id] = rp
-		}
-		rp.Aggregator.AggregateInteger(curr)
+	// Current iterator and window.
+	curr   *stringMergeHeapItem
+	window struct {
+		name      string
+		tags      string
+		startTime int64
+		endTime   int64
 	}
+}
 
-	// Reverse sort points by name & tag if our output is supposed to be ordered.
-	keys := make([]string, 0, len(m))
-	for k := range m {
-		keys = append(keys, k)
-	}
-	if len(keys) > 1 && itr.opt.Ordered {
-		sort.Sort(reverseStringSlice(keys))
+// newStringMergeIterator returns a new instance of stringMergeIterator.
+func newStringMergeIterator(inputs []StringIterator, opt IteratorOptions) *stringMergeIterator {
+	itr := &stringMergeIterator{
+		inputs: inputs,
+		heap: &stringMergeHeap{
+			items: make([]*stringMergeHeapItem, 0, len(inputs)),
+			opt:   opt,
+		},
 	}
 
-	// Assume the points are already sorted until proven otherwise.
-	sortedByTime := true
-	// Emit the points for each name & tag combination.
-	a := make([]BooleanPoint, 0, len(m))
-	for _, k := range keys {
-		rp := m[k]
-		points := rp.Emitter.Emit()
-		for i := len(points) - 1; i >= 0; i-- {
-			points[i].Name = rp.Name
-			points[i].Tags = rp.Tags
-			// Set the points time to the interval time if the reducer didn't provide one.
-			if points[i].Time == ZeroTime {
-				points[i].Time = startTime
-			} else {
-				sortedByTime = false
-			}
-			a = append(a, points[i])
-		}
-	}
+	// Initialize heap items.
+	for _, input := range inputs {
+		// Wrap in buffer, ignore any inputs without anymore points.
+		bufInput := newBufStringIterator(input)
 
-	// Points may be out of order. Perform a stable sort by time if requested.
-	if !sortedByTime && itr.opt.Ordered {
-		sort.Stable(sort.Reverse(booleanPointsByTime(a)))
+		// Append to the heap.
+		itr.heap.items = append(itr.heap.items, &stringMergeHeapItem{itr: bufInput})
 	}
 
-	return a, nil
+	return itr
 }
 
-// integerStreamBooleanIterator streams inputs into the iterator and emits points gradually.
-type integerStreamBooleanIterator struct {
-	input  *bufIntegerIterator
-	create func() (IntegerPointAggregator, BooleanPointEmitter)
-	dims   []string
-	opt    IteratorOptions
-	m      map[string]*integerReduceBooleanPoint
-	points []BooleanPoint
+// Stats returns an aggregation of stats from the underlying iterators.
+func (itr *stringMergeIterator) Stats() IteratorStats {
+	var stats IteratorStats
+	for _, input := range itr.inputs {
+		stats.Add(input.Stats())
+	}
+	return stats
 }
 
-// newIntegerStreamBooleanIterator returns a new instance of integerStreamBooleanIterator.
-func newIntegerStreamBooleanIterator(input IntegerIterator, createFn func() (IntegerPointAggregator, BooleanPointEmitter), opt IteratorOptions) *integerStreamBooleanIterator {
-	return &integerStreamBooleanIterator{
-		input:  newBufIntegerIterator(input),
-		create: createFn,
-		dims:   opt.GetDimensions(),
-		opt:    opt,
-		m:      make(map[string]*integerReduceBooleanPoint),
+// Close closes the underlying iterators.
+func (itr *stringMergeIterator) Close() error {
+	for _, input := range itr.inputs {
+		input.Close()
 	}
+	itr.curr = nil
+	itr.inputs = nil
+	itr.heap.items = nil
+	return nil
 }
 
-// Stats returns stats from the input iterator.
-func (itr *integerStreamBooleanIterator) Stats() IteratorStats { return itr.input.Stats() }
-
-// Close closes the iterator and all child iterators.
-func (itr *integerStreamBooleanIterator) Close() error { return itr.input.Close() }
-
-// Next returns the next value for the stream iterator.
-func (itr *integerStreamBooleanIterator) Next() (*BooleanPoint, error) {
-	// Calculate next window if we have no more points.
-	if len(itr.points) == 0 {
-		var err error
-		itr.points, err = itr.reduce()
-		if len(itr.points) == 0 {
-			return nil, err
+// Next returns the next point from the iterator.
+func (itr *stringMergeIterator) Next() (*StringPoint, error) {
+	// Initialize the heap. This needs to be done lazily on the first call to this iterator
+	// so that iterator initialization done through the Select() call returns quickly.
+	// Queries can only be interrupted after the Select() call completes so any operations
+	// done during iterator creation cannot be interrupted, which is why we do it here
+	// instead so an interrupt can happen while initializing the heap.
+	if !itr.init {
+		items := itr.heap.items
+		itr.heap.items = make([]*stringMergeHeapItem, 0, len(items))
+		for _, item := range items {
+			if p, err := item.itr.peek(); err != nil {
+				return nil, err
+			} else if p == nil {
+				continue
+			}
+			itr.heap.items = append(itr.heap.items, item)
 		}
+		heap.Init(itr.heap)
+		itr.init = true
 	}
 
-	// Pop next point off the stack.
-	p := &itr.points[len(itr.points)-1]
-	itr.points = itr.points[:len(itr.points)-1]
-	return p, nil
-}
-
-// reduce creates and manages aggregators for every point from the input.
-// After aggregating a point, it always tries to emit a value using the emitter.
-func (itr *integerStreamBooleanIterator) reduce() ([]BooleanPoint, error) {
 	for {
-		// Read next point.
-		curr, err := itr.input.Next()
-		if curr == nil {
-			// Close all of the aggregators to flush any remaining points to emit.
-			var points []BooleanPoint
-			for _, rp := range itr.m {
-				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
-					if err := aggregator.Close(); err != nil {
-						return nil, err
-					}
-
-					pts := rp.Emitter.Emit()
-					if len(pts) == 0 {
-						continue
-					}
+		// Retrieve the next iterator if we don't have one.
+		if itr.curr == nil {
+			if len(itr.heap.items) == 0 {
+				return nil, nil
+			}
+			itr.curr = heap.Pop(itr.heap).(*stringMergeHeapItem)
 
-					for i := range pts {
-						pts[i].Name = rp.Name
-						pts[i].Tags = rp.Tags
-					}
-					points = append(points, pts...)
-				}
+			// Read point and set current window.
+			p, err := itr.curr.itr.Next()
+			if err != nil {
+				return nil, err
 			}
+			tags := p.Tags.Subset(itr.heap.opt.Dimensions)
+			itr.window.name, itr.window.tags = p.Name, tags.ID()
+			itr.window.startTime, itr.window.endTime = itr.heap.opt.Window(p.Time)
+			return p, nil
+		}
 
-			// Eliminate the aggregators and emitters.
-			itr.m = nil
-			return points, nil
-		} else if err != nil {
+		// Read the next point from the current iterator.
+		p, err := itr.curr.itr.Next()
+		if err != nil {
 			return nil, err
-		} else if curr.Nil {
-			continue
 		}
-		tags := curr.Tags.Subset(itr.dims)
 
-		id := curr.Name
-		if len(tags.m) > 0 {
-			id += "\x00" + tags.ID()
+		// If there are no more points then remove iterator from heap and find next.
+		if p == nil {
+			itr.curr = nil
+			continue
 		}
 
-		// Retrieve the aggregator for this name/tag combination or create one.
-		rp := itr.m[id]
-		if rp == nil {
-			aggregator, emitter := itr.create()
-			rp = &integerReduceBooleanPoint{
-				Name:       curr.Name,
-				Tags:       tags,
-				Aggregator: aggregator,
-				Emitter:    emitter,
-			}
-			itr.m[id] = rp
+		// Check if the point is inside of our current window.
+		inWindow := true
+		if window := itr.window; window.name != p.Name {
+			inWindow = false
+		} else if tags := p.Tags.Subset(itr.heap.opt.Dimensions); window.tags != tags.ID() {
+			inWindow = false
+		} else if opt := itr.heap.opt; opt.Ascending && p.Time >= window.endTime {
+			inWindow = false
+		} else if !opt.Ascending && p.Time < window.startTime {
+			inWindow = false
 		}
-		rp.Aggregator.AggregateInteger(curr)
 
-		// Attempt to emit points from the aggregator.
-		points := rp.Emitter.Emit()
-		if len(points) == 0 {
+		// If it's outside our window then push iterator back on the heap and find new iterator.
+		if !inWindow {
+			itr.curr.itr.unread(p)
+			heap.Push(itr.heap, itr.curr)
+			itr.curr = nil
 			continue
 		}
 
-		for i := range points {
-			points[i].Name = rp.Name
-			points[i].Tags = rp.Tags
-		}
-		return points, nil
+		return p, nil
 	}
 }
 
-// integerBooleanExprIterator executes a function to modify an existing point
-// for every output of the input iterator.
-type integerBooleanExprIterator struct {
-	left      *bufIntegerIterator
-	right     *bufIntegerIterator
-	fn        integerBooleanExprFunc
-	points    []IntegerPoint // must be size 2
-	storePrev bool
+// stringMergeHeap represents a heap of stringMergeHeapItems.
+// Items are sorted by their next window and then by name/tags.
+type stringMergeHeap struct {
+	opt   IteratorOptions
+	items []*stringMergeHeapItem
 }
 
-func newIntegerBooleanExprIterator(left, right IntegerIterator, opt IteratorOptions, fn func(a, b int64) bool) *integerBooleanExprIterator {
-	var points []IntegerPoint
-	switch opt.Fill {
-	case NullFill, PreviousFill:
-		points = []IntegerPoint{{Nil: true}, {Nil: true}}
-	case NumberFill:
-		value := castToInteger(opt.FillValue)
-		points = []IntegerPoint{{Value: value}, {Value: value}}
+func (h *stringMergeHeap) Len() int      { return len(h.items) }
+func (h *stringMergeHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *stringMergeHeap) Less(i, j int) bool {
+	x, err := h.items[i].itr.peek()
+	if err != nil {
+		return true
 	}
-	return &integerBooleanExprIterator{
-		left:      newBufIntegerIterator(left),
-		right:     newBufIntegerIterator(right),
-		points:    points,
-		fn:        fn,
-		storePrev: opt.Fill == PreviousFill,
+	y, err := h.items[j].itr.peek()
+	if err != nil {
+		return false
 	}
-}
 
-func (itr *integerBooleanExprIterator) Stats() IteratorStats {
-	stats := itr.left.Stats()
-	stats.Add(itr.right.Stats())
-	return stats
+	if h.opt.Ascending {
+		if x.Name != y.Name {
+			return x.Name < y.Name
+		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); xTags.ID() != yTags.ID() {
+			return xTags.ID() < yTags.ID()
+		}
+	} else {
+		if x.Name != y.Name {
+			return x.Name > y.Name
+		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); xTags.ID() != yTags.ID() {
+			return xTags.ID() > yTags.ID()
+		}
+	}
+
+	xt, _ := h.opt.Window(x.Time)
+	yt, _ := h.opt.Window(y.Time)
+
+	if h.opt.Ascending {
+		return xt < yt
+	}
+	return xt > yt
 }
 
-func (itr *integerBooleanExprIterator) Close() error {
-	itr.left.Close()
-	itr.right.Close()
-	return nil
+func (h *stringMergeHeap) Push(x interface{}) {
+	h.items = append(h.items, x.(*stringMergeHeapItem))
 }
 
-func (itr *integerBooleanExprIterator) Next() (*BooleanPoint, error) {
-	for {
-		a, b, err := itr.next()
-		if err != nil || (a == nil && b == nil) {
-			return nil, err
-		}
+func (h *stringMergeHeap) Pop() interface{} {
+	old := h.items
+	n := len(old)
+	item := old[n-1]
+	h.items = old[0 : n-1]
+	return item
+}
 
-		// If any of these are nil and we are using fill(none), skip these points.
-		if (a == nil || a.Nil || b == nil || b.Nil) && itr.points == nil {
-			continue
-		}
+type stringMergeHeapItem struct {
+	itr *bufStringIterator
+}
 
-		// If one of the two points is nil, we need to fill it with a fake nil
-		// point that has the same name, tags, and time as the other point.
-		// There should never be a time when both of these are nil.
-		if a == nil {
-			p := *b
-			a = &p
-			a.Value = 0
-			a.Nil = true
-		} else if b == nil {
-			p := *a
-			b = &p
-			b.Value = 0
-			b.Nil = true
-		}
+// stringSortedMergeIterator is an iterator that sorts and merges multiple iterators into one.
+type stringSortedMergeIterator struct {
+	inputs []StringIterator
+	heap   *stringSortedMergeHeap
+	init   bool
+}
 
-		// If a value is nil, use the fill values if the fill value is non-nil.
-		if a.Nil && !itr.points[0].Nil {
-			a.Value = itr.points[0].Value
-			a.Nil = false
-		}
-		if b.Nil && !itr.points[1].Nil {
-			b.Value = itr.points[1].Value
-			b.Nil = false
-		}
+// newStringSortedMergeIterator returns an instance of stringSortedMergeIterator.
+func newStringSortedMergeIterator(inputs []StringIterator, opt IteratorOptions) Iterator {
+	itr := &stringSortedMergeIterator{
+		inputs: inputs,
+		heap: &stringSortedMergeHeap{
+			items: make([]*stringSortedMergeHeapItem, 0, len(inputs)),
+			opt:   opt,
+		},
+	}
 
-		if itr.storePrev {
-			itr.points[0], itr.points[1] = *a, *b
-		}
+	// Initialize heap items.
+	for _, input := range inputs {
+		// Append to the heap.
+		itr.heap.items = append(itr.heap.items, &stringSortedMergeHeapItem{itr: input})
+	}
 
-		p := &BooleanPoint{
-			Name:       a.Name,
-			Tags:       a.Tags,
-			Time:       a.Time,
-			Nil:        a.Nil || b.Nil,
-			Aggregated: a.Aggregated,
-		}
-		if !p.Nil {
-			p.Value = itr.fn(a.Value, b.Value)
-		}
-		return p, nil
+	return itr
+}
 
+// Stats returns an aggregation of stats from the underlying iterators.
+func (itr *stringSortedMergeIterator) Stats() IteratorStats {
+	var stats IteratorStats
+	for _, input := range itr.inputs {
+		stats.Add(input.Stats())
 	}
+	return stats
 }
 
-// next returns the next points within each iterator. If the iterators are
-// uneven, it organizes them so only matching points are returned.
-func (itr *integerBooleanExprIterator) next() (a, b *IntegerPoint, err error) {
-	// Retrieve the next value for both the left and right.
-	a, err = itr.left.Next()
-	if err != nil {
-		return nil, nil, err
-	}
-	b, err = itr.right.Next()
-	if err != nil {
-		return nil, nil, err
+// Close closes the underlying iterators.
+func (itr *stringSortedMergeIterator) Close() error {
+	for _, input := range itr.inputs {
+		input.Close()
 	}
+	return nil
+}
 
-	// If we have a point from both, make sure that they match each other.
-	if a != nil && b != nil {
-		if a.Name > b.Name {
-			itr.left.unread(a)
-			return nil, b, nil
-		} else if a.Name < b.Name {
-			itr.right.unread(b)
-			return a, nil, nil
-		}
-
-		if ltags, rtags := a.Tags.ID(), b.Tags.ID(); ltags > rtags {
-			itr.left.unread(a)
-			return nil, b, nil
-		} else if ltags < rtags {
-			itr.right.unread(b)
-			return a, nil, nil
-		}
+// Next returns the next points from the iterator.
+func (itr *stringSortedMergeIterator) Next() (*StringPoint, error) { return itr.pop() }
 
-		if a.Time > b.Time {
-			itr.left.unread(a)
-			return nil, b, nil
-		} else if a.Time < b.Time {
-			itr.right.unread(b)
-			return a, nil, nil
+// pop returns the next point from the heap.
+// Reads the next point from item's cursor and puts it back on the heap.
+func (itr *stringSortedMergeIterator) pop() (*StringPoint, error) {
+	// Initialize the heap. See the MergeIterator to see why this has to be done lazily.
+	if !itr.init {
+		items := itr.heap.items
+		itr.heap.items = make([]*stringSortedMergeHeapItem, 0, len(items))
+		for _, item := range items {
+			var err error
+			if item.point, err = item.itr.Next(); err != nil {
+				return nil, err
+			} else if item.point == nil {
+				continue
+			}
+			itr.heap.items = append(itr.heap.items, item)
 		}
+		heap.Init(itr.heap)
+		itr.init = true
 	}
-	return a, b, nil
-}
-
-// integerBooleanExprFunc creates or modifies a point by combining two
-// points. The point passed in may be modified and returned rather than
-// allocating a new point if possible. One of the points may be nil, but at
-// least one of the points will be non-nil.
-type integerBooleanExprFunc func(a, b int64) bool
 
-// integerTransformIterator executes a function to modify an existing point for every
-// output of the input iterator.
-type integerTransformIterator struct {
-	input IntegerIterator
-	fn    integerTransformFunc
-}
+	if len(itr.heap.items) == 0 {
+		return nil, nil
+	}
 
-// Stats returns stats from the input iterator.
-func (itr *integerTransformIterator) Stats() IteratorStats { return itr.input.Stats() }
+	// Read the next item from the heap.
+	item := heap.Pop(itr.heap).(*stringSortedMergeHeapItem)
+	if item.err != nil {
+		return nil, item.err
+	} else if item.point == nil {
+		return nil, nil
+	}
 
-// Close closes the iterator and all child iterators.
-func (itr *integerTransformIterator) Close() error { return itr.input.Close() }
+	// Copy the point for return.
+	p := item.point.Clone()
 
-// Next returns the minimum value for the next available interval.
-func (itr *integerTransformIterator) Next() (*IntegerPoint, error) {
-	p, err := itr.input.Next()
-	if err != nil {
-		return nil, err
-	} else if p != nil {
-		p = itr.fn(p)
+	// Read the next item from the cursor. Push back to heap if one exists.
+	if item.point, item.err = item.itr.Next(); item.point != nil {
+		heap.Push(itr.heap, item)
 	}
+
 	return p, nil
 }
 
-// integerTransformFunc creates or modifies a point.
-// The point passed in may be modified and returned rather than allocating a
-// new point if possible.
-type integerTransformFunc func(p *IntegerPoint) *IntegerPoint
-
-// integerBoolTransformIterator executes a function to modify an existing point for every
-// output of the input iterator.
-type integerBoolTransformIterator struct {
-	input IntegerIterator
-	fn    integerBoolTransformFunc
+// stringSortedMergeHeap represents a heap of stringSortedMergeHeapItems.
+type stringSortedMergeHeap struct {
+	opt   IteratorOptions
+	items []*stringSortedMergeHeapItem
 }
 
-// Stats returns stats from the input iterator.
-func (itr *integerBoolTransformIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (h *stringSortedMergeHeap) Len() int      { return len(h.items) }
+func (h *stringSortedMergeHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *stringSortedMergeHeap) Less(i, j int) bool {
+	x, y := h.items[i].point, h.items[j].point
 
-// Close closes the iterator and all child iterators.
-func (itr *integerBoolTransformIterator) Close() error { return itr.input.Close() }
+	if h.opt.Ascending {
+		if x.Name != y.Name {
+			return x.Name < y.Name
+		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); !xTags.Equals(&yTags) {
+			return xTags.ID() < yTags.ID()
+		}
+		return x.Time < y.Time
+	}
 
-// Next returns the minimum value for the next available interval.
-func (itr *integerBoolTransformIterator) Next() (*BooleanPoint, error) {
-	p, err := itr.input.Next()
-	if err != nil {
-		return nil, err
-	} else if p != nil {
-		return itr.fn(p), nil
+	if x.Name != y.Name {
+		return x.Name > y.Name
+	} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); !xTags.Equals(&yTags) {
+		return xTags.ID() > yTags.ID()
 	}
-	return nil, nil
+	return x.Time > y.Time
 }
 
-// integerBoolTransformFunc creates or modifies a point.
-// The point passed in may be modified and returned rather than allocating a
-// new point if possible.
-type integerBoolTransformFunc func(p *IntegerPoint) *BooleanPoint
-
-// integerDedupeIterator only outputs unique points.
-// This differs from the DistinctIterator in that it compares all aux fields too.
-// This iterator is relatively inefficient and should only be used on small
-// datasets such as meta query results.
-type integerDedupeIterator struct {
-	input IntegerIterator
-	m     map[string]struct{} // lookup of points already sent
+func (h *stringSortedMergeHeap) Push(x interface{}) {
+	h.items = append(h.items, x.(*stringSortedMergeHeapItem))
 }
 
-type integerIteratorMapper struct {
-	e      *Emitter
-	buf    []interface{}
-	driver IteratorMap   // which iterator to use for the primary value, can be nil
-	fields []IteratorMap // which iterator to use for an aux field
-	point  IntegerPoint
+func (h *stringSortedMergeHeap) Pop() interface{} {
+	old := h.items
+	n := len(old)
+	item := old[n-1]
+	h.items = old[0 : n-1]
+	return item
 }
 
-func newIntegerIteratorMapper(itrs []Iterator, driver IteratorMap, fields []IteratorMap, opt IteratorOptions) *integerIteratorMapper {
-	e := NewEmitter(itrs, opt.Ascending, 0)
-	e.OmitTime = true
-	return &integerIteratorMapper{
-		e:      e,
-		buf:    make([]interface{}, len(itrs)),
-		driver: driver,
-		fields: fields,
-		point: IntegerPoint{
-			Aux: make([]interface{}, len(fields)),
-		},
-	}
+type stringSortedMergeHeapItem struct {
+	point *StringPoint
+	err   error
+	itr   StringIterator
 }
 
-func (itr *integerIteratorMapper) Next() (*IntegerPoint, error) {
-	t, name, tags, err := itr.e.loadBuf()
-	if err != nil || t == ZeroTime {
-		return nil, err
-	}
-	itr.point.Time = t
-	itr.point.Name = name
-	itr.point.Tags = tags
+// stringParallelIterator represents an iterator that pulls data in a separate goroutine.
+type stringParallelIterator struct {
+	input StringIterator
+	ch    chan stringPointError
 
-	itr.e.readInto(t, name, tags, itr.buf)
-	if itr.driver != nil {
-		if v := itr.driver.Value(tags, itr.buf); v != nil {
-			if v, ok := v.(int64); ok {
-				itr.point.Value = v
-				itr.point.Nil = false
-			} else {
-				itr.point.Value = 0
-				itr.point.Nil = true
-			}
-		} else {
-			itr.point.Value = 0
-			itr.point.Nil = true
-		}
-	}
-	for i, f := range itr.fields {
-		itr.point.Aux[i] = f.Value(tags, itr.buf)
-	}
-	return &itr.point, nil
+	once    sync.Once
+	closing chan struct{}
+	wg      sync.WaitGroup
 }
 
-func (itr *integerIteratorMapper) Stats() IteratorStats {
-	stats := IteratorStats{}
-	for _, itr := range itr.e.itrs {
-		stats.Add(itr.Stats())
+// newStringParallelIterator returns a new instance of stringParallelIterator.
+func newStringParallelIterator(input StringIterator) *stringParallelIterator {
+	itr := &stringParallelIterator{
+		input:   input,
+		ch:      make(chan stringPointError, 256),
+		closing: make(chan struct{}),
 	}
-	return stats
+	itr.wg.Add(1)
+	go itr.monitor()
+	return itr
 }
 
-func (itr *integerIteratorMapper) Close() error {
-	return itr.e.Close()
-}
+// Stats returns stats from the underlying iterator.
+func (itr *stringParallelIterator) Stats() IteratorStats { return itr.input.Stats() }
 
-type integerFilterIterator struct {
-	input IntegerIterator
-	cond  Expr
-	opt   IteratorOptions
-	m     map[string]interface{}
+// Close closes the underlying iterators.
+func (itr *stringParallelIterator) Close() error {
+	itr.once.Do(func() { close(itr.closing) })
+	itr.wg.Wait()
+	return itr.input.Close()
 }
 
-func newIntegerFilterIterator(input IntegerIterator, cond Expr, opt IteratorOptions) IntegerIterator {
-	// Strip out time conditions from the WHERE clause.
-	// TODO(jsternberg): This should really be done for us when creating the IteratorOptions struct.
-	n := RewriteFunc(CloneExpr(cond), func(n Node) Node {
-		switch n := n.(type) {
-		case *BinaryExpr:
-			if n.LHS.String() == "time" {
-				return &BooleanLiteral{Val: true}
-			}
-		}
-		return n
-	})
-
-	cond, _ = n.(Expr)
-	if cond == nil {
-		return input
-	} else if n, ok := cond.(*BooleanLiteral); ok && n.Val {
-		return input
-	}
-
-	return &integerFilterIterator{
-		input: input,
-		cond:  cond,
-		opt:   opt,
-		m:     make(map[string]interface{}),
+// Next returns the next point from the iterator.
+func (itr *stringParallelIterator) Next() (*StringPoint, error) {
+	v, ok := <-itr.ch
+	if !ok {
+		return nil, io.EOF
 	}
+	return v.point, v.err
 }
 
-func (itr *integerFilterIterator) Stats() IteratorStats { return itr.input.Stats() }
-func (itr *integerFilterIterator) Close() error         { return itr.input.Close() }
+// monitor runs in a separate goroutine and actively pulls the next point.
+func (itr *stringParallelIterator) monitor() {
+	defer close(itr.ch)
+	defer itr.wg.Done()
 
-func (itr *integerFilterIterator) Next() (*IntegerPoint, error) {
 	for {
+		// Read next point.
 		p, err := itr.input.Next()
-		if err != nil || p == nil {
-			return nil, err
+		if p != nil {
+			p = p.Clone()
+		}
+
+		select {
+		case <-itr.closing:
+			return
+		case itr.ch <- stringPointError{point: p, err: err}:
 		}
+	}
+}
+
+type stringPointError struct {
+	point *StringPoint
+	err   error
+}
 
-		for i, ref := range itr.opt.Aux {
-			itr.m[ref.Val] = p.Aux[i]
-		}
-		for k, v := range p.Tags.KeyValues() {
-			itr.m[k] = v
-		}
+// stringLimitIterator represents an iterator that limits points per group.
+type stringLimitIterator struct {
+	input StringIterator
+	opt   IteratorOptions
+	n     int
 
-		if !EvalBool(itr.cond, itr.m) {
-			continue
-		}
-		return p, nil
+	prev struct {
+		name string
+		tags Tags
 	}
 }
 
-// newIntegerDedupeIterator returns a new instance of integerDedupeIterator.
-func newIntegerDedupeIterator(input IntegerIterator) *integerDedupeIterator {
-	return &integerDedupeIterator{
+// newStringLimitIterator returns a new instance of stringLimitIterator.
+func newStringLimitIterator(input StringIterator, opt IteratorOptions) *stringLimitIterator {
+	return &stringLimitIterator{
 		input: input,
-		m:     make(map[string]struct{}),
+		opt:   opt,
 	}
 }
 
-// Stats returns stats from the input iterator.
-func (itr *integerDedupeIterator) Stats() IteratorStats { return itr.input.Stats() }
+// Stats returns stats from the underlying iterator.
+func (itr *stringLimitIterator) Stats() IteratorStats { return itr.input.Stats() }
 
-// Close closes the iterator and all child iterators.
-func (itr *integerDedupeIterator) Close() error { return itr.input.Close() }
+// Close closes the underlying iterators.
+func (itr *stringLimitIterator) Close() error { return itr.input.Close() }
 
-// Next returns the next unique point from the input iterator.
-func (itr *integerDedupeIterator) Next() (*IntegerPoint, error) {
+// Next returns the next point from the iterator.
+func (itr *stringLimitIterator) Next() (*StringPoint, error) {
 	for {
-		// Read next point.
 		p, err := itr.input.Next()
 		if p == nil || err != nil {
 			return nil, err
 		}
 
-		// Serialize to bytes to store in lookup.
-		buf, err := proto.Marshal(encodeIntegerPoint(p))
-		if err != nil {
-			return nil, err
+		// Reset window and counter if a new window is encountered.
+		if p.Name != itr.prev.name || !p.Tags.Equals(&itr.prev.tags) {
+			itr.prev.name = p.Name
+			itr.prev.tags = p.Tags
+			itr.n = 0
 		}
 
-		// If the point has already been output then move to the next point.
-		if _, ok := itr.m[string(buf)]; ok {
+		// Increment counter.
+		itr.n++
+
+		// Read next point if not beyond the offset.
+		if itr.n <= itr.opt.Offset {
+			continue
+		}
+
+		// Read next point if we're beyond the limit.
+		if itr.opt.Limit > 0 && (itr.n-itr.opt.Offset) > itr.opt.Limit {
 			continue
 		}
 
-		// Otherwise mark it as emitted and return point.
-		itr.m[string(buf)] = struct{}{}
 		return p, nil
 	}
 }
 
-// integerReaderIterator represents an iterator that streams from a reader.
-type integerReaderIterator struct {
-	r   io.Reader
-	dec *IntegerPointDecoder
+type stringFillIterator struct {
+	input     *bufStringIterator
+	prev      StringPoint
+	startTime int64
+	endTime   int64
+	auxFields []interface{}
+	init      bool
+	opt       IteratorOptions
+
+	window struct {
+		name   string
+		tags   Tags
+		time   int64
+		offset int64
+	}
 }
 
-// newIntegerReaderIterator returns a new instance of integerReaderIterator.
-func newIntegerReaderIterator(r io.Reader, stats IteratorStats) *integerReaderIterator {
-	dec := NewIntegerPointDecoder(r)
-	dec.stats = stats
+func newStringFillIterator(input StringIterator, expr Expr, opt IteratorOptions) *stringFillIterator {
+	if opt.Fill == NullFill {
+		if expr, ok := expr.(*Call); ok && expr.Name == "count" {
+			opt.Fill = NumberFill
+			opt.FillValue = ""
+		}
+	}
 
-	return &integerReaderIterator{
-		r:   r,
-		dec: dec,
+	var startTime, endTime int64
+	if opt.Ascending {
+		startTime, _ = opt.Window(opt.StartTime)
+		endTime, _ = opt.Window(opt.EndTime)
+	} else {
+		startTime, _ = opt.Window(opt.EndTime)
+		endTime, _ = opt.Window(opt.StartTime)
 	}
-}
 
-// Stats returns stats about points processed.
-func (itr *integerReaderIterator) Stats() IteratorStats { return itr.dec.stats }
+	var auxFields []interface{}
+	if len(opt.Aux) > 0 {
+		auxFields = make([]interface{}, len(opt.Aux))
+	}
 
-// Close closes the underlying reader, if applicable.
-func (itr *integerReaderIterator) Close() error {
-	if r, ok := itr.r.(io.ReadCloser); ok {
-		return r.Close()
+	return &stringFillIterator{
+		input:     newBufStringIterator(input),
+		prev:      StringPoint{Nil: true},
+		startTime: startTime,
+		endTime:   endTime,
+		auxFields: auxFields,
+		opt:       opt,
 	}
-	return nil
 }
 
-// Next returns the next point from the iterator.
-func (itr *integerReaderIterator) Next() (*IntegerPoint, error) {
-	// OPTIMIZE(benbjohnson): Reuse point on iterator.
+func (itr *stringFillIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *stringFillIterator) Close() error         { return itr.input.Close() }
 
-	// Unmarshal next point.
-	p := &IntegerPoint{}
-	if err := itr.dec.DecodeIntegerPoint(p); err == io.EOF {
-		return nil, nil
-	} else if err != nil {
+func (itr *stringFillIterator) Next() (*StringPoint, error) {
+	if !itr.init {
+		p, err := itr.input.peek()
+		if p == nil || err != nil {
+			return nil, err
+		}
+		itr.window.name, itr.window.tags = p.Name, p.Tags
+		itr.window.time = itr.startTime
+		if itr.opt.Location != nil {
+			_, itr.window.offset = itr.opt.Zone(itr.window.time)
+		}
+		itr.init = true
+	}
+
+	p, err := itr.input.Next()
+	if err != nil {
 		return nil, err
 	}
-	return p, nil
-}
 
-// StringIterator represents a stream of string points.
-type StringIterator interface {
-	Iterator
-	Next() (*StringPoint, error)
-}
+	// Check if the next point is outside of our window or is nil.
+	for p == nil || p.Name != itr.window.name || p.Tags.ID() != itr.window.tags.ID() {
+		// If we are inside of an interval, unread the point and continue below to
+		// constructing a new point.
+		if itr.opt.Ascending {
+			if itr.window.time <= itr.endTime {
+				itr.input.unread(p)
+				p = nil
+				break
+			}
+		} else {
+			if itr.window.time >= itr.endTime {
+				itr.input.unread(p)
+				p = nil
+				break
+			}
+		}
 
-// newStringIterators converts a slice of Iterator to a slice of StringIterator.
-// Drop and closes any iterator in itrs that is not a StringIterator and cannot
-// be cast to a StringIterator.
-func newStringIterators(itrs []Iterator) []StringIterator {
-	a := make([]StringIterator, 0, len(itrs))
-	for _, itr := range itrs {
-		switch itr := itr.(type) {
-		case StringIterator:
-			a = append(a, itr)
+		// We are *not* in a current interval. If there is no next point,
+		// we are at the end of all intervals.
+		if p == nil {
+			return nil, nil
+		}
 
-		default:
-			itr.Close()
+		// Set the new interval.
+		itr.window.name, itr.window.tags = p.Name, p.Tags
+		itr.window.time = itr.startTime
+		if itr.opt.Location != nil {
+			_, itr.window.offset = itr.opt.Zone(itr.window.time)
 		}
+		itr.prev = StringPoint{Nil: true}
+		break
 	}
-	return a
-}
 
-// bufStringIterator represents a buffered StringIterator.
-type bufStringIterator struct {
-	itr StringIterator
-	buf *StringPoint
-}
+	// Check if the point is our next expected point.
+	if p == nil || (itr.opt.Ascending && p.Time > itr.window.time) || (!itr.opt.Ascending && p.Time < itr.window.time) {
+		if p != nil {
+			itr.input.unread(p)
+		}
 
-// newBufStringIterator returns a buffered StringIterator.
-func newBufStringIterator(itr StringIterator) *bufStringIterator {
-	return &bufStringIterator{itr: itr}
-}
+		p = &StringPoint{
+			Name: itr.window.name,
+			Tags: itr.window.tags,
+			Time: itr.window.time,
+			Aux:  itr.auxFields,
+		}
 
-// Stats returns statistics from the input iterator.
-func (itr *bufStringIterator) Stats() IteratorStats { return itr.itr.Stats() }
+		switch itr.opt.Fill {
+		case LinearFill:
+			fallthrough
+		case NullFill:
+			p.Nil = true
+		case NumberFill:
+			p.Value = castToString(itr.opt.FillValue)
+		case PreviousFill:
+			if !itr.prev.Nil {
+				p.Value = itr.prev.Value
+				p.Nil = itr.prev.Nil
+			} else {
+				p.Nil = true
+			}
+		}
+	} else {
+		itr.prev = *p
+	}
 
-// Close closes the underlying iterator.
-func (itr *bufStringIterator) Close() error { return itr.itr.Close() }
+	// Advance the expected time. Do not advance to a new window here
+	// as there may be lingering points with the same timestamp in the previous
+	// window.
+	if itr.opt.Ascending {
+		itr.window.time += int64(itr.opt.Interval.Duration)
+	} else {
+		itr.window.time -= int64(itr.opt.Interval.Duration)
+	}
 
-// peek returns the next point without removing it from the iterator.
-func (itr *bufStringIterator) peek() (*StringPoint, error) {
-	p, err := itr.Next()
-	if err != nil {
-		return nil, err
+	// Check to see if we have passed over an offset change and adjust the time
+	// to account for this new offset.
+	if itr.opt.Location != nil {
+		if _, offset := itr.opt.Zone(itr.window.time); offset != itr.window.offset {
+			diff := itr.window.offset - offset
+			if abs(diff) < int64(itr.opt.Interval.Duration) {
+				itr.window.time += diff
+			}
+			itr.window.offset = offset
+		}
 	}
-	itr.unread(p)
 	return p, nil
 }
 
-// peekTime returns the time of the next point.
-// Returns zero time if no more points available.
-func (itr *bufStringIterator) peekTime() (int64, error) {
-	p, err := itr.peek()
-	if p == nil || err != nil {
-		return ZeroTime, err
-	}
-	return p.Time, nil
+// stringIntervalIterator represents a string implementation of IntervalIterator.
+type stringIntervalIterator struct {
+	input StringIterator
+	opt   IteratorOptions
 }
 
-// Next returns the current buffer, if exists, or calls the underlying iterator.
-func (itr *bufStringIterator) Next() (*StringPoint, error) {
-	buf := itr.buf
-	if buf != nil {
-		itr.buf = nil
-		return buf, nil
-	}
-	return itr.itr.Next()
+func newStringIntervalIterator(input StringIterator, opt IteratorOptions) *stringIntervalIterator {
+	return &stringIntervalIterator{input: input, opt: opt}
 }
 
-// NextInWindow returns the next value if it is between [startTime, endTime).
-// If the next value is outside the range then it is moved to the buffer.
-func (itr *bufStringIterator) NextInWindow(startTime, endTime int64) (*StringPoint, error) {
-	v, err := itr.Next()
-	if v == nil || err != nil {
+func (itr *stringIntervalIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *stringIntervalIterator) Close() error         { return itr.input.Close() }
+
+func (itr *stringIntervalIterator) Next() (*StringPoint, error) {
+	p, err := itr.input.Next()
+	if p == nil || err != nil {
 		return nil, err
-	} else if t := v.Time; t >= endTime || t < startTime {
-		itr.unread(v)
-		return nil, nil
 	}
-	return v, nil
+	p.Time, _ = itr.opt.Window(p.Time)
+	// If we see the minimum allowable time, set the time to zero so we don't
+	// break the default returned time for aggregate queries without times.
+	if p.Time == MinTime {
+		p.Time = 0
+	}
+	return p, nil
 }
 
-// unread sets v to the buffer. It is read on the next call to Next().
-func (itr *bufStringIterator) unread(v *StringPoint) { itr.buf = v }
-
-// stringMergeIterator represents an iterator that combines multiple string iterators.
-type stringMergeIterator struct {
-	inputs []StringIterator
-	heap   *stringMergeHeap
-	init   bool
+// stringInterruptIterator represents a string implementation of InterruptIterator.
+type stringInterruptIterator struct {
+	input   StringIterator
+	closing <-chan struct{}
+	count   int
+}
 
-	// Current iterator and window.
-	curr   *stringMergeHeapItem
-	window struct {
-		name      string
-		tags      string
-		startTime int64
-		endTime   int64
-	}
+func newStringInterruptIterator(input StringIterator, closing <-chan struct{}) *stringInterruptIterator {
+	return &stringInterruptIterator{input: input, closing: closing}
 }
 
-// newStringMergeIterator returns a new instance of stringMergeIterator.
-func newStringMergeIterator(inputs []StringIterator, opt IteratorOptions) *stringMergeIterator {
-	itr := &stringMergeIterator{
-		inputs: inputs,
-		heap: &stringMergeHeap{
-			items: make([]*stringMergeHeapItem, 0, len(inputs)),
-			opt:   opt,
-		},
+func (itr *stringInterruptIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *stringInterruptIterator) Close() error         { return itr.input.Close() }
+
+func (itr *stringInterruptIterator) Next() (*StringPoint, error) {
+	// Only check if the channel is closed every N points. This
+	// intentionally checks on both 0 and N so that if the iterator
+	// has been interrupted before the first point is emitted it will
+	// not emit any points.
+	if itr.count&0xFF == 0xFF {
+		select {
+		case <-itr.closing:
+			return nil, itr.Close()
+		default:
+			// Reset iterator count to zero and fall through to emit the next point.
+			itr.count = 0
+		}
 	}
 
-	// Initialize heap items.
-	for _, input := range inputs {
-		// Wrap in buffer, ignore any inputs without anymore points.
-		bufInput := newBufStringIterator(input)
+	// Increment the counter for every point read.
+	itr.count++
+	return itr.input.Next()
+}
 
-		// Append to the heap.
-		itr.heap.items = append(itr.heap.items, &stringMergeHeapItem{itr: bufInput})
-	}
+// stringCloseInterruptIterator represents a string implementation of CloseInterruptIterator.
+type stringCloseInterruptIterator struct {
+	input   StringIterator
+	closing <-chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
 
+func newStringCloseInterruptIterator(input StringIterator, closing <-chan struct{}) *stringCloseInterruptIterator {
+	itr := &stringCloseInterruptIterator{
+		input:   input,
+		closing: closing,
+		done:    make(chan struct{}),
+	}
+	go itr.monitor()
 	return itr
 }
 
-// Stats returns an aggregation of stats from the underlying iterators.
-func (itr *stringMergeIterator) Stats() IteratorStats {
-	var stats IteratorStats
-	for _, input := range itr.inputs {
-		stats.Add(input.Stats())
+func (itr *stringCloseInterruptIterator) monitor() {
+	select {
+	case <-itr.closing:
+		itr.Close()
+	case <-itr.done:
 	}
-	return stats
 }
 
-// Close closes the underlying iterators.
-func (itr *stringMergeIterator) Close() error {
-	for _, input := range itr.inputs {
-		input.Close()
-	}
-	itr.curr = nil
-	itr.inputs = nil
-	itr.heap.items = nil
+func (itr *stringCloseInterruptIterator) Stats() IteratorStats {
+	return itr.input.Stats()
+}
+
+func (itr *stringCloseInterruptIterator) Close() error {
+	itr.once.Do(func() {
+		close(itr.done)
+		itr.input.Close()
+	})
 	return nil
 }
 
-// Next returns the next point from the iterator.
-func (itr *stringMergeIterator) Next() (*StringPoint, error) {
-	// Initialize the heap. This needs to be done lazily on the first call to this iterator
-	// so that iterator initialization done through the Select() call returns quickly.
-	// Queries can only be interrupted after the Select() call completes so any operations
-	// done during iterator creation cannot be interrupted, which is why we do it here
-	// instead so an interrupt can happen while initializing the heap.
-	if !itr.init {
-		items := itr.heap.items
-		itr.heap.items = make([]*stringMergeHeapItem, 0, len(items))
-		for _, item := range items {
-			if p, err := item.itr.peek(); err != nil {
-				return nil, err
-			} else if p == nil {
-				continue
-			}
-			itr.heap.items = append(itr.heap.items, item)
+func (itr *stringCloseInterruptIterator) Next() (*StringPoint, error) {
+	p, err := itr.input.Next()
+	if err != nil {
+		// Check if the iterator was closed.
+		select {
+		case <-itr.done:
+			return nil, nil
+		default:
+			return nil, err
 		}
-		heap.Init(itr.heap)
-		itr.init = true
 	}
+	return p, nil
+}
 
-	for {
-		// Retrieve the next iterator if we don't have one.
-		if itr.curr == nil {
-			if len(itr.heap.items) == 0 {
-				return nil, nil
-			}
-			itr.curr = heap.Pop(itr.heap).(*stringMergeHeapItem)
+// auxStringPoint represents a combination of a point and an error for the AuxIterator.
+type auxStringPoint struct {
+	point *StringPoint
+	err   error
+}
 
-			// Read point and set current window.
-			p, err := itr.curr.itr.Next()
-			if err != nil {
-				return nil, err
-			}
-			tags := p.Tags.Subset(itr.heap.opt.Dimensions)
-			itr.window.name, itr.window.tags = p.Name, tags.ID()
-			itr.window.startTime, itr.window.endTime = itr.heap.opt.Window(p.Time)
-			return p, nil
-		}
+// stringAuxIterator represents a string implementation of AuxIterator.
+type stringAuxIterator struct {
+	input      *bufStringIterator
+	output     chan auxStringPoint
+	fields     *auxIteratorFields
+	background bool
+}
 
-		// Read the next point from the current iterator.
-		p, err := itr.curr.itr.Next()
-		if err != nil {
-			return nil, err
-		}
+func newStringAuxIterator(input StringIterator, opt IteratorOptions) *stringAuxIterator {
+	return &stringAuxIterator{
+		input:  newBufStringIterator(input),
+		output: make(chan auxStringPoint, 1),
+		fields: newAuxIteratorFields(opt),
+	}
+}
 
-		// If there are no more points then remove iterator from heap and find next.
-		if p == nil {
-			itr.curr = nil
-			continue
-		}
+func (itr *stringAuxIterator) Background() {
+	itr.background = true
+	itr.Start()
+	go DrainIterator(itr)
+}
 
-		// Check if the point is inside of our current window.
-		inWindow := true
-		if window := itr.window; window.name != p.Name {
-			inWindow = false
-		} else if tags := p.Tags.Subset(itr.heap.opt.Dimensions); window.tags != tags.ID() {
-			inWindow = false
-		} else if opt := itr.heap.opt; opt.Ascending && p.Time >= window.endTime {
-			inWindow = false
-		} else if !opt.Ascending && p.Time < window.startTime {
-			inWindow = false
+func (itr *stringAuxIterator) Start()               { go itr.stream() }
+func (itr *stringAuxIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *stringAuxIterator) Close() error         { return itr.input.Close() }
+func (itr *stringAuxIterator) Next() (*StringPoint, error) {
+	p := <-itr.output
+	return p.point, p.err
+}
+func (itr *stringAuxIterator) Iterator(name string, typ DataType) Iterator {
+	return itr.fields.iterator(name, typ)
+}
+
+func (itr *stringAuxIterator) stream() {
+	for {
+		// Read next point.
+		p, err := itr.input.Next()
+		if err != nil {
+			itr.output <- auxStringPoint{err: err}
+			itr.fields.sendError(err)
+			break
+		} else if p == nil {
+			break
 		}
 
-		// If it's outside our window then push iterator back on the heap and find new iterator.
-		if !inWindow {
-			itr.curr.itr.unread(p)
-			heap.Push(itr.heap, itr.curr)
-			itr.curr = nil
-			continue
+		// Send point to output and to each field iterator.
+		itr.output <- auxStringPoint{point: p}
+		if ok := itr.fields.send(p); !ok && itr.background {
+			break
 		}
+	}
 
-		return p, nil
+	close(itr.output)
+	itr.fields.close()
+}
+
+// stringChanIterator represents a new instance of stringChanIterator.
+type stringChanIterator struct {
+	buf struct {
+		i      int
+		filled bool
+		points [2]StringPoint
 	}
+	err  error
+	cond *sync.Cond
+	done bool
 }
 
-// stringMergeHeap represents a heap of stringMergeHeapItems.
-// Items are sorted by their next window and then by name/tags.
-type stringMergeHeap struct {
-	opt   IteratorOptions
-	items []*stringMergeHeapItem
+func (itr *stringChanIterator) Stats() IteratorStats { return IteratorStats{} }
+
+func (itr *stringChanIterator) Close() error {
+	itr.cond.L.Lock()
+	// Mark the channel iterator as done and signal all waiting goroutines to start again.
+	itr.done = true
+	itr.cond.Broadcast()
+	// Do not defer the unlock so we don't create an unnecessary allocation.
+	itr.cond.L.Unlock()
+	return nil
 }
 
-func (h *stringMergeHeap) Len() int      { return len(h.items) }
-func (h *stringMergeHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
-func (h *stringMergeHeap) Less(i, j int) bool {
-	x, err := h.items[i].itr.peek()
-	if err != nil {
-		return true
-	}
-	y, err := h.items[j].itr.peek()
-	if err != nil {
-		return false
+func (itr *stringChanIterator) setBuf(name string, tags Tags, time int64, value interface{}) bool {
+	itr.cond.L.Lock()
+	defer itr.cond.L.Unlock()
+
+	// Wait for either the iterator to be done (so we don't have to set the value)
+	// or for the buffer to have been read and ready for another write.
+	for !itr.done && itr.buf.filled {
+		itr.cond.Wait()
 	}
 
-	if h.opt.Ascending {
-		if x.Name != y.Name {
-			return x.Name < y.Name
-		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); xTags.ID() != yTags.ID() {
-			return xTags.ID() < yTags.ID()
-		}
-	} else {
-		if x.Name != y.Name {
-			return x.Name > y.Name
-		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); xTags.ID() != yTags.ID() {
-			return xTags.ID() > yTags.ID()
-		}
+	// Do not set the value and return false to signal that the iterator is closed.
+	// Do this after the above wait as the above for loop may have exited because
+	// the iterator was closed.
+	if itr.done {
+		return false
 	}
 
-	xt, _ := h.opt.Window(x.Time)
-	yt, _ := h.opt.Window(y.Time)
+	switch v := value.(type) {
+	case string:
+		itr.buf.points[itr.buf.i] = StringPoint{Name: name, Tags: tags, Time: time, Value: v}
 
-	if h.opt.Ascending {
-		return xt < yt
+	default:
+		itr.buf.points[itr.buf.i] = StringPoint{Name: name, Tags: tags, Time: time, Nil: true}
 	}
-	return xt > yt
-}
+	itr.buf.filled = true
 
-func (h *stringMergeHeap) Push(x interface{}) {
-	h.items = append(h.items, x.(*stringMergeHeapItem))
+	// Signal to all waiting goroutines that a new value is ready to read.
+	itr.cond.Signal()
+	return true
 }
 
-func (h *stringMergeHeap) Pop() interface{} {
-	old := h.items
-	n := len(old)
-	item := old[n-1]
-	h.items = old[0 : n-1]
-	return item
-}
+func (itr *stringChanIterator) setErr(err error) {
+	itr.cond.L.Lock()
+	defer itr.cond.L.Unlock()
+	itr.err = err
 
-type stringMergeHeapItem struct {
-	itr *bufStringIterator
+	// Signal to all waiting goroutines that a new value is ready to read.
+	itr.cond.Signal()
 }
 
-// stringSortedMergeIterator is an iterator that sorts and merges multiple iterators into one.
-type stringSortedMergeIterator struct {
-	inputs []StringIterator
-	heap   *stringSortedMergeHeap
-	init   bool
-}
+func (itr *stringChanIterator) Next() (*StringPoint, error) {
+	itr.cond.L.Lock()
+	defer itr.cond.L.Unlock()
 
-// newStringSortedMergeIterator returns an instance of stringSortedMergeIterator.
-func newStringSortedMergeIterator(inputs []StringIterator, opt IteratorOptions) Iterator {
-	itr := &stringSortedMergeIterator{
-		inputs: inputs,
-		heap: &stringSortedMergeHeap{
-			items: make([]*stringSortedMergeHeapItem, 0, len(inputs)),
-			opt:   opt,
-		},
+	// Check for an error and return one if there.
+	if itr.err != nil {
+		return nil, itr.err
 	}
 
-	// Initialize heap items.
-	for _, input := range inputs {
-		// Append to the heap.
-		itr.heap.items = append(itr.heap.items, &stringSortedMergeHeapItem{itr: input})
+	// Wait until either a value is available in the buffer or
+	// the iterator is closed.
+	for !itr.done && !itr.buf.filled {
+		itr.cond.Wait()
 	}
 
-	return itr
+	// Return nil once the channel is done and the buffer is empty.
+	if itr.done && !itr.buf.filled {
+		return nil, nil
+	}
+
+	// Always read from the buffer if it exists, even if the iterator
+	// is closed. This prevents the last value from being truncated by
+	// the parent iterator.
+	p := &itr.buf.points[itr.buf.i]
+	itr.buf.i = (itr.buf.i + 1) % len(itr.buf.points)
+	itr.buf.filled = false
+	itr.cond.Signal()
+	return p, nil
 }
 
-// Stats returns an aggregation of stats from the underlying iterators.
-func (itr *stringSortedMergeIterator) Stats() IteratorStats {
-	var stats IteratorStats
-	for _, input := range itr.inputs {
-		stats.Add(input.Stats())
+// stringReduceFloatIterator executes a reducer for every interval and buffers the result.
+type stringReduceFloatIterator struct {
+	input  *bufStringIterator
+	create func() (StringPointAggregator, FloatPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	points []FloatPoint
+}
+
+func newStringReduceFloatIterator(input StringIterator, opt IteratorOptions, createFn func() (StringPointAggregator, FloatPointEmitter)) *stringReduceFloatIterator {
+	return &stringReduceFloatIterator{
+		input:  newBufStringIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
 	}
-	return stats
 }
 
-// Close closes the underlying iterators.
-func (itr *stringSortedMergeIterator) Close() error {
-	for _, input := range itr.inputs {
-		input.Close()
+// Stats returns stats from the input iterator.
+func (itr *stringReduceFloatIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *stringReduceFloatIterator) Close() error { return itr.input.Close() }
+
+// Next returns the minimum value for the next available interval.
+func (itr *stringReduceFloatIterator) Next() (*FloatPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
+		}
 	}
-	return nil
+
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
+}
+
+// stringReduceFloatPoint stores the reduced data for a name/tag combination.
+type stringReduceFloatPoint struct {
+	Name       string
+	Tags       Tags
+	Aggregator StringPointAggregator
+	Emitter    FloatPointEmitter
 }
 
-// Next returns the next points from the iterator.
-func (itr *stringSortedMergeIterator) Next() (*StringPoint, error) { return itr.pop() }
+// reduce executes fn once for every point in the next window.
+// The previous value for the dimension is passed to fn.
+func (itr *stringReduceFloatIterator) reduce() ([]FloatPoint, error) {
+	// Calculate next window.
+	var (
+		startTime, endTime int64
+		window             struct {
+			name string
+			tags string
+		}
+	)
+	for {
+		p, err := itr.input.Next()
+		if err != nil || p == nil {
+			return nil, err
+		} else if p.Nil {
+			continue
+		}
+
+		// Unread the point so it can be processed.
+		itr.input.unread(p)
+		startTime, endTime = itr.opt.Window(p.Time)
+		window.name, window.tags = p.Name, p.Tags.Subset(itr.opt.Dimensions).ID()
+		break
+	}
+
+	// Create points by tags.
+	m := make(map[string]*stringReduceFloatPoint)
+	for {
+		// Read next point.
+		curr, err := itr.input.NextInWindow(startTime, endTime)
+		if err != nil {
+			return nil, err
+		} else if curr == nil {
+			break
+		} else if curr.Nil {
+			continue
+		} else if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		}
+
+		// Ensure this point is within the same final window.
+		if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		} else if tags := curr.Tags.Subset(itr.opt.Dimensions); tags.ID() != window.tags {
+			itr.input.unread(curr)
+			break
+		}
+
+		// Retrieve the tags on this point for this level of the query.
+		// This may be different than the bucket dimensions.
+		tags := curr.Tags.Subset(itr.dims)
+		id := tags.ID()
 
-// pop returns the next point from the heap.
-// Reads the next point from item's cursor and puts it back on the heap.
-func (itr *stringSortedMergeIterator) pop() (*StringPoint, error) {
-	// Initialize the heap. See the MergeIterator to see why this has to be done lazily.
-	if !itr.init {
-		items := itr.heap.items
-		itr.heap.items = make([]*stringSortedMergeHeapItem, 0, len(items))
-		for _, item := range items {
-			var err error
-			if item.point, err = item.itr.Next(); err != nil {
-				return nil, err
-			} else if item.point == nil {
-				continue
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &stringReduceFloatPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
 			}
-			itr.heap.items = append(itr.heap.items, item)
+			m[id] = rp
 		}
-		heap.Init(itr.heap)
-		itr.init = true
-	}
-
-	if len(itr.heap.items) == 0 {
-		return nil, nil
+		rp.Aggregator.AggregateString(curr)
 	}
 
-	// Read the next item from the heap.
-	item := heap.Pop(itr.heap).(*stringSortedMergeHeapItem)
-	if item.err != nil {
-		return nil, item.err
-	} else if item.point == nil {
-		return nil, nil
+	// Reverse sort points by name & tag if our output is supposed to be ordered.
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
-
-	// Copy the point for return.
-	p := item.point.Clone()
-
-	// Read the next item from the cursor. Push back to heap if one exists.
-	if item.point, item.err = item.itr.Next(); item.point != nil {
-		heap.Push(itr.heap, item)
+	if len(keys) > 1 && itr.opt.Ordered {
+		sort.Sort(reverseStringSlice(keys))
 	}
 
-	return p, nil
-}
-
-// stringSortedMergeHeap represents a heap of stringSortedMergeHeapItems.
-type stringSortedMergeHeap struct {
-	opt   IteratorOptions
-	items []*stringSortedMergeHeapItem
-}
-
-func (h *stringSortedMergeHeap) Len() int      { return len(h.items) }
-func (h *stringSortedMergeHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
-func (h *stringSortedMergeHeap) Less(i, j int) bool {
-	x, y := h.items[i].point, h.items[j].point
-
-	if h.opt.Ascending {
-		if x.Name != y.Name {
-			return x.Name < y.Name
-		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); !xTags.Equals(&yTags) {
-			return xTags.ID() < yTags.ID()
+	// Assume the points are already sorted until proven otherwise.
+	sortedByTime := true
+	// Emit the points for each name & tag combination.
+	a := make([]FloatPoint, 0, len(m))
+	for _, k := range keys {
+		rp := m[k]
+		points := rp.Emitter.Emit()
+		for i := len(points) - 1; i >= 0; i-- {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+			// Set the points time to the interval time if the reducer didn't provide one.
+			if points[i].Time == ZeroTime {
+				points[i].Time = startTime
+			} else {
+				sortedByTime = false
+			}
+			a = append(a, points[i])
 		}
-		return x.Time < y.Time
 	}
 
-	if x.Name != y.Name {
-		return x.Name > y.Name
-	} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); !xTags.Equals(&yTags) {
-		return xTags.ID() > yTags.ID()
+	// Points may be out of order. Perform a stable sort by time if requested.
+	if !sortedByTime && itr.opt.Ordered {
+		sort.Stable(sort.Reverse(floatPointsByTime(a)))
 	}
-	return x.Time > y.Time
-}
-
-func (h *stringSortedMergeHeap) Push(x interface{}) {
-	h.items = append(h.items, x.(*stringSortedMergeHeapItem))
-}
-
-func (h *stringSortedMergeHeap) Pop() interface{} {
-	old := h.items
-	n := len(old)
-	item := old[n-1]
-	h.items = old[0 : n-1]
-	return item
-}
-
-type stringSortedMergeHeapItem struct {
-	point *StringPoint
-	err   error
-	itr   StringIterator
-}
-
-// stringParallelIterator represents an iterator that pulls data in a separate goroutine.
-type stringParallelIterator struct {
-	input StringIterator
-	ch    chan stringPointError
-
-	once    sync.Once
-	closing chan struct{}
-	wg      sync.WaitGroup
-}
 
-// newStringParallelIterator returns a new instance of stringParallelIterator.
-func newStringParallelIterator(input StringIterator) *stringParallelIterator {
-	itr := &stringParallelIterator{
-		input:   input,
-		ch:      make(chan stringPointError, 256),
-		closing: make(chan struct{}),
-	}
-	itr.wg.Add(1)
-	go itr.monitor()
-	return itr
+	return a, nil
 }
 
-// Stats returns stats from the underlying iterator.
-func (itr *stringParallelIterator) Stats() IteratorStats { return itr.input.Stats() }
-
-// Close closes the underlying iterators.
-func (itr *stringParallelIterator) Close() error {
-	itr.once.Do(func() { close(itr.closing) })
-	itr.wg.Wait()
-	return itr.input.Close()
+// stringStreamFloatIterator streams inputs into the iterator and emits points gradually.
+type stringStreamFloatIterator struct {
+	input  *bufStringIterator
+	create func() (StringPointAggregator, FloatPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	m      map[string]*stringReduceFloatPoint
+	points []FloatPoint
 }
 
-// Next returns the next point from the iterator.
-func (itr *stringParallelIterator) Next() (*StringPoint, error) {
-	v, ok := <-itr.ch
-	if !ok {
-		return nil, io.EOF
+// newStringStreamFloatIterator returns a new instance of stringStreamFloatIterator.
+func newStringStreamFloatIterator(input StringIterator, createFn func() (StringPointAggregator, FloatPointEmitter), opt IteratorOptions) *stringStreamFloatIterator {
+	return &stringStreamFloatIterator{
+		input:  newBufStringIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+		m:      make(map[string]*stringReduceFloatPoint),
 	}
-	return v.point, v.err
 }
 
-// monitor runs in a separate goroutine and actively pulls the next point.
-func (itr *stringParallelIterator) monitor() {
-	defer close(itr.ch)
-	defer itr.wg.Done()
+// Stats returns stats from the input iterator.
+func (itr *stringStreamFloatIterator) Stats() IteratorStats { return itr.input.Stats() }
 
-	for {
-		// Read next point.
-		p, err := itr.input.Next()
-		if p != nil {
-			p = p.Clone()
-		}
+// Close closes the iterator and all child iterators.
+func (itr *stringStreamFloatIterator) Close() error { return itr.input.Close() }
 
-		select {
-		case <-itr.closing:
-			return
-		case itr.ch <- stringPointError{point: p, err: err}:
+// Next returns the next value for the stream iterator.
+func (itr *stringStreamFloatIterator) Next() (*FloatPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
 		}
 	}
-}
-
-type stringPointError struct {
-	point *StringPoint
-	err   error
-}
-
-// stringLimitIterator represents an iterator that limits points per group.
-type stringLimitIterator struct {
-	input StringIterator
-	opt   IteratorOptions
-	n     int
 
-	prev struct {
-		name string
-		tags Tags
-	}
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
 }
 
-// newStringLimitIterator returns a new instance of stringLimitIterator.
-func newStringLimitIterator(input StringIterator, opt IteratorOptions) *stringLimitIterator {
-	return &stringLimitIterator{
-		input: input,
-		opt:   opt,
-	}
-}
+// reduce creates and manages aggregators for every point from the input.
+// After aggregating a point, it always tries to emit a value using the emitter.
+func (itr *stringStreamFloatIterator) reduce() ([]FloatPoint, error) {
+	for {
+		// Read next point.
+		curr, err := itr.input.Next()
+		if curr == nil {
+			// Close all of the aggregators to flush any remaining points to emit.
+			var points []FloatPoint
+			for _, rp := range itr.m {
+				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
+					if err := aggregator.Close(); err != nil {
+						return nil, err
+					}
 
-// Stats returns stats from the underlying iterator.
-func (itr *stringLimitIterator) Stats() IteratorStats { return itr.input.Stats() }
+					pts := rp.Emitter.Emit()
+					if len(pts) == 0 {
+						continue
+					}
 
-// Close closes the underlying iterators.
-func (itr *stringLimitIterator) Close() error { return itr.input.Close() }
+					for i := range pts {
+						pts[i].Name = rp.Name
+						pts[i].Tags = rp.Tags
+					}
+					points = append(points, pts...)
+				}
+			}
 
-// Next returns the next point from the iterator.
-func (itr *stringLimitIterator) Next() (*StringPoint, error) {
-	for {
-		p, err := itr.input.Next()
-		if p == nil || err != nil {
+			// Eliminate the aggregators and emitters.
+			itr.m = nil
+			return points, nil
+		} else if err != nil {
 			return nil, err
+		} else if curr.Nil {
+			continue
 		}
+		tags := curr.Tags.Subset(itr.dims)
 
-		// Reset window and counter if a new window is encountered.
-		if p.Name != itr.prev.name || !p.Tags.Equals(&itr.prev.tags) {
-			itr.prev.name = p.Name
-			itr.prev.tags = p.Tags
-			itr.n = 0
+		id := curr.Name
+		if len(tags.m) > 0 {
+			id += "\x00" + tags.ID()
 		}
 
-		// Increment counter.
-		itr.n++
-
-		// Read next point if not beyond the offset.
-		if itr.n <= itr.opt.Offset {
-			continue
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := itr.m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &stringReduceFloatPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			itr.m[id] = rp
 		}
+		rp.Aggregator.AggregateString(curr)
 
-		// Read next point if we're beyond the limit.
-		if itr.opt.Limit > 0 && (itr.n-itr.opt.Offset) > itr.opt.Limit {
+		// Attempt to emit points from the aggregator.
+		points := rp.Emitter.Emit()
+		if len(points) == 0 {
 			continue
 		}
 
-		return p, nil
+		for i := range points {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+		}
+		return points, nil
 	}
 }
 
-type stringFillIterator struct {
-	input     *bufStringIterator
-	prev      StringPoint
-	startTime int64
-	endTime   int64
-	auxFields []interface{}
-	init      bool
-	opt       IteratorOptions
-
-	window struct {
-		name   string
-		tags   Tags
-		time   int64
-		offset int64
-	}
+// stringFloatExprIterator executes a function to modify an existing point
+// for every output of the input iterator.
+type stringFloatExprIterator struct {
+	left      *bufStringIterator
+	right     *bufStringIterator
+	fn        stringFloatExprFunc
+	points    []StringPoint // must be size 2
+	storePrev bool
 }
 
-func newStringFillIterator(input StringIterator, expr Expr, opt IteratorOptions) *stringFillIterator {
-	if opt.Fill == NullFill {
-		if expr, ok := expr.(*Call); ok && expr.Name == "count" {
-			opt.Fill = NumberFill
-			opt.FillValue = ""
-		}
-	}
-
-	var startTime, endTime int64
-	if opt.Ascending {
-		startTime, _ = opt.Window(opt.StartTime)
-		endTime, _ = opt.Window(opt.EndTime)
-	} else {
-		startTime, _ = opt.Window(opt.EndTime)
-		endTime, _ = opt.Window(opt.StartTime)
+func newStringFloatExprIterator(left, right StringIterator, opt IteratorOptions, fn func(a, b string) float64) *stringFloatExprIterator {
+	var points []StringPoint
+	switch opt.Fill {
+	case NullFill, PreviousFill:
+		points = []StringPoint{{Nil: true}, {Nil: true}}
+	case NumberFill:
+		value := castToString(opt.FillValue)
+		points = []StringPoint{{Value: value}, {Value: value}}
 	}
-
-	var auxFields []interface{}
-	if len(opt.Aux) > 0 {
-		auxFields = make([]interface{}, len(opt.Aux))
+	return &stringFloatExprIterator{
+		left:      newBufStringIterator(left),
+		right:     newBufStringIterator(right),
+		points:    points,
+		fn:        fn,
+		storePrev: opt.Fill == PreviousFill,
 	}
+}
 
-	return &stringFillIterator{
-		input:     newBufStringIterator(input),
-		prev:      StringPoint{Nil: true},
-		startTime: startTime,
-		endTime:   endTime,
-		auxFields: auxFields,
-		opt:       opt,
-	}
+func (itr *stringFloatExprIterator) Stats() IteratorStats {
+	stats := itr.left.Stats()
+	stats.Add(itr.right.Stats())
+	return stats
 }
 
-func (itr *stringFillIterator) Stats() IteratorStats { return itr.input.Stats() }
-func (itr *stringFillIterator) Close() error         { return itr.input.Close() }
+func (itr *stringFloatExprIterator) Close() error {
+	itr.left.Close()
+	itr.right.Close()
+	return nil
+}
 
-func (itr *stringFillIterator) Next() (*StringPoint, error) {
-	if !itr.init {
-		p, err := itr.input.peek()
-		if p == nil || err != nil {
+func (itr *stringFloatExprIterator) Next() (*FloatPoint, error) {
+	for {
+		a, b, err := itr.next()
+		if err != nil || (a == nil && b == nil) {
 			return nil, err
 		}
-		itr.window.name, itr.window.tags = p.Name, p.Tags
-		itr.window.time = itr.startTime
-		if itr.opt.Location != nil {
-			_, itr.window.offset = itr.opt.Zone(itr.window.time)
-		}
-		itr.init = true
-	}
-
-	p, err := itr.input.Next()
-	if err != nil {
-		return nil, err
-	}
 
-	// Check if the next point is outside of our window or is nil.
-	for p == nil || p.Name != itr.window.name || p.Tags.ID() != itr.window.tags.ID() {
-		// If we are inside of an interval, unread the point and continue below to
-		// constructing a new point.
-		if itr.opt.Ascending {
-			if itr.window.time <= itr.endTime {
-				itr.input.unread(p)
-				p = nil
-				break
-			}
-		} else {
-			if itr.window.time >= itr.endTime {
-				itr.input.unread(p)
-				p = nil
-				break
-			}
+		// If any of these are nil and we are using fill(none), skip these points.
+		if (a == nil || a.Nil || b == nil || b.Nil) && itr.points == nil {
+			continue
 		}
 
-		// We are *not* in a current interval. If there is no next point,
-		// we are at the end of all intervals.
-		if p == nil {
-			return nil, nil
+		// If one of the two points is nil, we need to fill it with a fake nil
+		// point that has the same name, tags, and time as the other point.
+		// There should never be a time when both of these are nil.
+		if a == nil {
+			p := *b
+			a = &p
+			a.Value = ""
+			a.Nil = true
+		} else if b == nil {
+			p := *a
+			b = &p
+			b.Value = ""
+			b.Nil = true
 		}
 
-		// Set the new interval.
-		itr.window.name, itr.window.tags = p.Name, p.Tags
-		itr.window.time = itr.startTime
-		if itr.opt.Location != nil {
-			_, itr.window.offset = itr.opt.Zone(itr.window.time)
+		// If a value is nil, use the fill values if the fill value is non-nil.
+		if a.Nil && !itr.points[0].Nil {
+			a.Value = itr.points[0].Value
+			a.Nil = false
+		}
+		if b.Nil && !itr.points[1].Nil {
+			b.Value = itr.points[1].Value
+			b.Nil = false
 		}
-		itr.prev = StringPoint{Nil: true}
-		break
-	}
 
-	// Check if the point is our next expected point.
-	if p == nil || (itr.opt.Ascending && p.Time > itr.window.time) || (!itr.opt.Ascending && p.Time < itr.window.time) {
-		if p != nil {
-			itr.input.unread(p)
+		if itr.storePrev {
+			itr.points[0], itr.points[1] = *a, *b
 		}
 
-		p = &StringPoint{
-			Name: itr.window.name,
-			Tags: itr.window.tags,
-			Time: itr.window.time,
-			Aux:  itr.auxFields,
+		p := &FloatPoint{
+			Name:       a.Name,
+			Tags:       a.Tags,
+			Time:       a.Time,
+			Nil:        a.Nil || b.Nil,
+			Aggregated: a.Aggregated,
+		}
+		if !p.Nil {
+			p.Value = itr.fn(a.Value, b.Value)
 		}
+		return p, nil
 
-		switch itr.opt.Fill {
-		case LinearFill:
-			fallthrough
-		case NullFill:
-			p.Nil = true
-		case NumberFill:
-			p.Value = castToString(itr.opt.FillValue)
-		case PreviousFill:
-			if !itr.prev.Nil {
-				p.Value = itr.prev.Value
-				p.Nil = itr.prev.Nil
-			} else {
-				p.Nil = true
-			}
+	}
+}
+
+// next returns the next points within each iterator. If the iterators are
+// uneven, it organizes them so only matching points are returned.
+func (itr *stringFloatExprIterator) next() (a, b *StringPoint, err error) {
+	// Retrieve the next value for both the left and right.
+	a, err = itr.left.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err = itr.right.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If we have a point from both, make sure that they match each other.
+	if a != nil && b != nil {
+		if a.Name > b.Name {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Name < b.Name {
+			itr.right.unread(b)
+			return a, nil, nil
 		}
-	} else {
-		itr.prev = *p
-	}
 
-	// Advance the expected time. Do not advance to a new window here
-	// as there may be lingering points with the same timestamp in the previous
-	// window.
-	if itr.opt.Ascending {
-		itr.window.time += int64(itr.opt.Interval.Duration)
-	} else {
-		itr.window.time -= int64(itr.opt.Interval.Duration)
-	}
+		if ltags, rtags := a.Tags.ID(), b.Tags.ID(); ltags > rtags {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if ltags < rtags {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
 
-	// Check to see if we have passed over an offset change and adjust the time
-	// to account for this new offset.
-	if itr.opt.Location != nil {
-		if _, offset := itr.opt.Zone(itr.window.time); offset != itr.window.offset {
-			diff := itr.window.offset - offset
-			if abs(diff) < int64(itr.opt.Interval.Duration) {
-				itr.window.time += diff
-			}
-			itr.window.offset = offset
+		if a.Time > b.Time {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Time < b.Time {
+			itr.right.unread(b)
+			return a, nil, nil
 		}
 	}
-	return p, nil
+	return a, b, nil
 }
 
-// stringIntervalIterator represents a string implementation of IntervalIterator.
-type stringIntervalIterator struct {
-	input StringIterator
-	opt   IteratorOptions
+// stringFloatExprFunc creates or modifies a point by combining two
+// points. The point passed in may be modified and returned rather than
+// allocating a new point if possible. One of the points may be nil, but at
+// least one of the points will be non-nil.
+type stringFloatExprFunc func(a, b string) float64
+
+// stringReduceIntegerIterator executes a reducer for every interval and buffers the result.
+type stringReduceIntegerIterator struct {
+	input  *bufStringIterator
+	create func() (StringPointAggregator, IntegerPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	points []IntegerPoint
 }
 
-func newStringIntervalIterator(input StringIterator, opt IteratorOptions) *stringIntervalIterator {
-	return &stringIntervalIterator{input: input, opt: opt}
+func newStringReduceIntegerIterator(input StringIterator, opt IteratorOptions, createFn func() (StringPointAggregator, IntegerPointEmitter)) *stringReduceIntegerIterator {
+	return &stringReduceIntegerIterator{
+		input:  newBufStringIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+	}
 }
 
-func (itr *stringIntervalIterator) Stats() IteratorStats { return itr.input.Stats() }
-func (itr *stringIntervalIterator) Close() error         { return itr.input.Close() }
+// Stats returns stats from the input iterator.
+func (itr *stringReduceIntegerIterator) Stats() IteratorStats { return itr.input.Stats() }
 
-func (itr *stringIntervalIterator) Next() (*StringPoint, error) {
-	p, err := itr.input.Next()
-	if p == nil || err != nil {
-		return nil, err
-	}
-	p.Time, _ = itr.opt.Window(p.Time)
-	// If we see the minimum allowable time, set the time to zero so we don't
-	// break the default returned time for aggregate queries without times.
-	if p.Time == MinTime {
-		p.Time = 0
+// Close closes the iterator and all child iterators.
+func (itr *stringReduceIntegerIterator) Close() error { return itr.input.Close() }
+
+// Next returns the minimum value for the next available interval.
+func (itr *stringReduceIntegerIterator) Next() (*IntegerPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
+		}
 	}
+
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
 	return p, nil
 }
 
-// stringInterruptIterator represents a string implementation of InterruptIterator.
-type stringInterruptIterator struct {
-	input   StringIterator
-	closing <-chan struct{}
-	count   int
+// stringReduceIntegerPoint stores the reduced data for a name/tag combination.
+type stringReduceIntegerPoint struct {
+	Name       string
+	Tags       Tags
+	Aggregator StringPointAggregator
+	Emitter    IntegerPointEmitter
 }
 
-func newStringInterruptIterator(input StringIterator, closing <-chan struct{}) *stringInterruptIterator {
-	return &stringInterruptIterator{input: input, closing: closing}
-}
+// reduce executes fn once for every point in the next window.
+// The previous value for the dimension is passed to fn.
+func (itr *stringReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
+	// Calculate next window.
+	var (
+		startTime, endTime int64
+		window             struct {
+			name string
+			tags string
+		}
+	)
+	for {
+		p, err := itr.input.Next()
+		if err != nil || p == nil {
+			return nil, err
+		} else if p.Nil {
+			continue
+		}
 
-func (itr *stringInterruptIterator) Stats() IteratorStats { return itr.input.Stats() }
-func (itr *stringInterruptIterator) Close() error         { return itr.input.Close() }
+		// Unread the point so it can be processed.
+		itr.input.unread(p)
+		startTime, endTime = itr.opt.Window(p.Time)
+		window.name, window.tags = p.Name, p.Tags.Subset(itr.opt.Dimensions).ID()
+		break
+	}
 
-func (itr *stringInterruptIterator) Next() (*StringPoint, error) {
-	// Only check if the channel is closed every N points. This
-	// intentionally checks on both 0 and N so that if the iterator
-	// has been interrupted before the first point is emitted it will
-	// not emit any points.
-	if itr.count&0xFF == 0xFF {
-		select {
-		case <-itr.closing:
-			return nil, itr.Close()
-		default:
-			// Reset iterator count to zero and fall through to emit the next point.
-			itr.count = 0
+	// Create points by tags.
+	m := make(map[string]*stringReduceIntegerPoint)
+	for {
+		// Read next point.
+		curr, err := itr.input.NextInWindow(startTime, endTime)
+		if err != nil {
+			return nil, err
+		} else if curr == nil {
+			break
+		} else if curr.Nil {
+			continue
+		} else if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
 		}
-	}
 
-	// Increment the counter for every point read.
-	itr.count++
-	return itr.input.Next()
-}
+		// Ensure this point is within the same final window.
+		if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		} else if tags := curr.Tags.Subset(itr.opt.Dimensions); tags.ID() != window.tags {
+			itr.input.unread(curr)
+			break
+		}
 
-// stringCloseInterruptIterator represents a string implementation of CloseInterruptIterator.
-type stringCloseInterruptIterator struct {
-	input   StringIterator
-	closing <-chan struct{}
-	done    chan struct{}
-	once    sync.Once
-}
+		// Retrieve the tags on this point for this level of the query.
+		// This may be different than the bucket dimensions.
+		tags := curr.Tags.Subset(itr.dims)
+		id := tags.ID()
 
-func newStringCloseInterruptIterator(input StringIterator, closing <-chan struct{}) *stringCloseInterruptIterator {
-	itr := &stringCloseInterruptIterator{
-		input:   input,
-		closing: closing,
-		done:    make(chan struct{}),
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &stringReduceIntegerPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			m[id] = rp
+		}
+		rp.Aggregator.AggregateString(curr)
 	}
-	go itr.monitor()
-	return itr
-}
 
-func (itr *stringCloseInterruptIterator) monitor() {
-	select {
-	case <-itr.closing:
-		itr.Close()
-	case <-itr.done:
+	// Reverse sort points by name & tag if our output is supposed to be ordered.
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	if len(keys) > 1 && itr.opt.Ordered {
+		sort.Sort(reverseStringSlice(keys))
 	}
-}
-
-func (itr *stringCloseInterruptIterator) Stats() IteratorStats {
-	return itr.input.Stats()
-}
-
-func (itr *stringCloseInterruptIterator) Close() error {
-	itr.once.Do(func() {
-		close(itr.done)
-		itr.input.Close()
-	})
-	return nil
-}
 
-func (itr *stringCloseInterruptIterator) Next() (*StringPoint, error) {
-	p, err := itr.input.Next()
-	if err != nil {
-		// Check if the iterator was closed.
-		select {
-		case <-itr.done:
-			return nil, nil
-		default:
-			return nil, err
+	// Assume the points are already sorted until proven otherwise.
+	sortedByTime := true
+	// Emit the points for each name & tag combination.
+	a := make([]IntegerPoint, 0, len(m))
+	for _, k := range keys {
+		rp := m[k]
+		points := rp.Emitter.Emit()
+		for i := len(points) - 1; i >= 0; i-- {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+			// Set the points time to the interval time if the reducer didn't provide one.
+			if points[i].Time == ZeroTime {
+				points[i].Time = startTime
+			} else {
+				sortedByTime = false
+			}
+			a = append(a, points[i])
 		}
 	}
-	return p, nil
-}
 
-// auxStringPoint represents a combination of a point and an error for the AuxIterator.
-type auxStringPoint struct {
-	point *StringPoint
-	err   error
+	// Points may be out of order. Perform a stable sort by time if requested.
+	if !sortedByTime && itr.opt.Ordered {
+		sort.Stable(sort.Reverse(integerPointsByTime(a)))
+	}
+
+	return a, nil
 }
 
-// stringAuxIterator represents a string implementation of AuxIterator.
-type stringAuxIterator struct {
-	input      *bufStringIterator
-	output     chan auxStringPoint
-	fields     *auxIteratorFields
-	background bool
+// stringStreamIntegerIterator streams inputs into the iterator and emits points gradually.
+type stringStreamIntegerIterator struct {
+	input  *bufStringIterator
+	create func() (StringPointAggregator, IntegerPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	m      map[string]*stringReduceIntegerPoint
+	points []IntegerPoint
 }
 
-func newStringAuxIterator(input StringIterator, opt IteratorOptions) *stringAuxIterator {
-	return &stringAuxIterator{
+// newStringStreamIntegerIterator returns a new instance of stringStreamIntegerIterator.
+func newStringStreamIntegerIterator(input StringIterator, createFn func() (StringPointAggregator, IntegerPointEmitter), opt IteratorOptions) *stringStreamIntegerIterator {
+	return &stringStreamIntegerIterator{
 		input:  newBufStringIterator(input),
-		output: make(chan auxStringPoint, 1),
-		fields: newAuxIteratorFields(opt),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+		m:      make(map[string]*stringReduceIntegerPoint),
 	}
 }
 
-func (itr *stringAuxIterator) Background() {
-	itr.background = true
-	itr.Start()
-	go DrainIterator(itr)
-}
+// Stats returns stats from the input iterator.
+func (itr *stringStreamIntegerIterator) Stats() IteratorStats { return itr.input.Stats() }
 
-func (itr *stringAuxIterator) Start()               { go itr.stream() }
-func (itr *stringAuxIterator) Stats() IteratorStats { return itr.input.Stats() }
-func (itr *stringAuxIterator) Close() error         { return itr.input.Close() }
-func (itr *stringAuxIterator) Next() (*StringPoint, error) {
-	p := <-itr.output
-	return p.point, p.err
-}
-func (itr *stringAuxIterator) Iterator(name string, typ DataType) Iterator {
-	return itr.fields.iterator(name, typ)
+// Close closes the iterator and all child iterators.
+func (itr *stringStreamIntegerIterator) Close() error { return itr.input.Close() }
+
+// Next returns the next value for the stream iterator.
+func (itr *stringStreamIntegerIterator) Next() (*IntegerPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
+		}
+	}
+
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
+	return p, nil
 }
 
-func (itr *stringAuxIterator) stream() {
+// reduce creates and manages aggregators for every point from the input.
+// After aggregating a point, it always tries to emit a value using the emitter.
+func (itr *stringStreamIntegerIterator) reduce() ([]IntegerPoint, error) {
 	for {
 		// Read next point.
-		p, err := itr.input.Next()
-		if err != nil {
-			itr.output <- auxStringPoint{err: err}
-			itr.fields.sendError(err)
-			break
-		} else if p == nil {
-			break
+		curr, err := itr.input.Next()
+		if curr == nil {
+			// Close all of the aggregators to flush any remaining points to emit.
+			var points []IntegerPoint
+			for _, rp := range itr.m {
+				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
+					if err := aggregator.Close(); err != nil {
+						return nil, err
+					}
+
+					pts := rp.Emitter.Emit()
+					if len(pts) == 0 {
+						continue
+					}
+
+					for i := range pts {
+						pts[i].Name = rp.Name
+						pts[i].Tags = rp.Tags
+					}
+					points = append(points, pts...)
+				}
+			}
+
+			// Eliminate the aggregators and emitters.
+			itr.m = nil
+			return points, nil
+		} else if err != nil {
+			return nil, err
+		} else if curr.Nil {
+			continue
 		}
+		tags := curr.Tags.Subset(itr.dims)
 
-		// Send point to output and to each field iterator.
-		itr.output <- auxStringPoint{point: p}
-		if ok := itr.fields.send(p); !ok && itr.background {
-			break
+		id := curr.Name
+		if len(tags.m) > 0 {
+			id += "\x00" + tags.ID()
+		}
+
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := itr.m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &stringReduceIntegerPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			itr.m[id] = rp
+		}
+		rp.Aggregator.AggregateString(curr)
+
+		// Attempt to emit points from the aggregator.
+		points := rp.Emitter.Emit()
+		if len(points) == 0 {
+			continue
+		}
+
+		for i := range points {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
 		}
+		return points, nil
 	}
+}
 
-	close(itr.output)
-	itr.fields.close()
+// stringIntegerExprIterator executes a function to modify an existing point
+// for every output of the input iterator.
+type stringIntegerExprIterator struct {
+	left      *bufStringIterator
+	right     *bufStringIterator
+	fn        stringIntegerExprFunc
+	points    []StringPoint // must be size 2
+	storePrev bool
 }
 
-// stringChanIterator represents a new instance of stringChanIterator.
-type stringChanIterator struct {
-	buf struct {
-		i      int
-		filled bool
-		points [2]StringPoint
+func newStringIntegerExprIterator(left, right StringIterator, opt IteratorOptions, fn func(a, b string) int64) *stringIntegerExprIterator {
+	var points []StringPoint
+	switch opt.Fill {
+	case NullFill, PreviousFill:
+		points = []StringPoint{{Nil: true}, {Nil: true}}
+	case NumberFill:
+		value := castToString(opt.FillValue)
+		points = []StringPoint{{Value: value}, {Value: value}}
+	}
+	return &stringIntegerExprIterator{
+		left:      newBufStringIterator(left),
+		right:     newBufStringIterator(right),
+		points:    points,
+		fn:        fn,
+		storePrev: opt.Fill == PreviousFill,
 	}
-	err  error
-	cond *sync.Cond
-	done bool
 }
 
-func (itr *stringChanIterator) Stats() IteratorStats { return IteratorStats{} }
+func (itr *stringIntegerExprIterator) Stats() IteratorStats {
+	stats := itr.left.Stats()
+	stats.Add(itr.right.Stats())
+	return stats
+}
 
-func (itr *stringChanIterator) Close() error {
-	itr.cond.L.Lock()
-	// Mark the channel iterator as done and signal all waiting goroutines to start again.
-	itr.done = true
-	itr.cond.Broadcast()
-	// Do not defer the unlock so we don't create an unnecessary allocation.
-	itr.cond.L.Unlock()
+func (itr *stringIntegerExprIterator) Close() error {
+	itr.left.Close()
+	itr.right.Close()
 	return nil
 }
 
-func (itr *stringChanIterator) setBuf(name string, tags Tags, time int64, value interface{}) bool {
-	itr.cond.L.Lock()
-	defer itr.cond.L.Unlock()
-
-	// Wait for either the iterator to be done (so we don't have to set the value)
-	// or for the buffer to have been read and ready for another write.
-	for !itr.done && itr.buf.filled {
-		itr.cond.Wait()
-	}
-
-	// Do not set the value and return false to signal that the iterator is closed.
-	// Do this after the above wait as the above for loop may have exited because
-	// the iterator was closed.
-	if itr.done {
-		return false
-	}
-
-	switch v := value.(type) {
-	case string:
-		itr.buf.points[itr.buf.i] = StringPoint{Name: name, Tags: tags, Time: time, Value: v}
+func (itr *stringIntegerExprIterator) Next() (*IntegerPoint, error) {
+	for {
+		a, b, err := itr.next()
+		if err != nil || (a == nil && b == nil) {
+			return nil, err
+		}
 
-	default:
-		itr.buf.points[itr.buf.i] = StringPoint{Name: name, Tags: tags, Time: time, Nil: true}
-	}
-	itr.buf.filled = true
+		// If any of these are nil and we are using fill(none), skip these points.
+		if (a == nil || a.Nil || b == nil || b.Nil) && itr.points == nil {
+			continue
+		}
 
-	// Signal to all waiting goroutines that a new value is ready to read.
-	itr.cond.Signal()
-	return true
-}
+		// If one of the two points is nil, we need to fill it with a fake nil
+		// point that has the same name, tags, and time as the other point.
+		// There should never be a time when both of these are nil.
+		if a == nil {
+			p := *b
+			a = &p
+			a.Value = ""
+			a.Nil = true
+		} else if b == nil {
+			p := *a
+			b = &p
+			b.Value = ""
+			b.Nil = true
+		}
 
-func (itr *stringChanIterator) setErr(err error) {
-	itr.cond.L.Lock()
-	defer itr.cond.L.Unlock()
-	itr.err = err
+		// If a value is nil, use the fill values if the fill value is non-nil.
+		if a.Nil && !itr.points[0].Nil {
+			a.Value = itr.points[0].Value
+			a.Nil = false
+		}
+		if b.Nil && !itr.points[1].Nil {
+			b.Value = itr.points[1].Value
+			b.Nil = false
+		}
 
-	// Signal to all waiting goroutines that a new value is ready to read.
-	itr.cond.Signal()
-}
+		if itr.storePrev {
+			itr.points[0], itr.points[1] = *a, *b
+		}
 
-func (itr *stringChanIterator) Next() (*StringPoint, error) {
-	itr.cond.L.Lock()
-	defer itr.cond.L.Unlock()
+		p := &IntegerPoint{
+			Name:       a.Name,
+			Tags:       a.Tags,
+			Time:       a.Time,
+			Nil:        a.Nil || b.Nil,
+			Aggregated: a.Aggregated,
+		}
+		if !p.Nil {
+			p.Value = itr.fn(a.Value, b.Value)
+		}
+		return p, nil
 
-	// Check for an error and return one if there.
-	if itr.err != nil {
-		return nil, itr.err
 	}
+}
 
-	// Wait until either a value is available in the buffer or
-	// the iterator is closed.
-	for !itr.done && !itr.buf.filled {
-		itr.cond.Wait()
+// next returns the next points within each iterator. If the iterators are
+// uneven, it organizes them so only matching points are returned.
+func (itr *stringIntegerExprIterator) next() (a, b *StringPoint, err error) {
+	// Retrieve the next value for both the left and right.
+	a, err = itr.left.Next()
+	if err != nil {
+		return nil, nil, err
 	}
-
-	// Return nil once the channel is done and the buffer is empty.
-	if itr.done && !itr.buf.filled {
-		return nil, nil
+	b, err = itr.right.Next()
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Always read from the buffer if it exists, even if the iterator
-	// is closed. This prevents the last value from being truncated by
-	// the parent iterator.
-	p := &itr.buf.points[itr.buf.i]
-	itr.buf.i = (itr.buf.i + 1) % len(itr.buf.points)
-	itr.buf.filled = false
-	itr.cond.Signal()
-	return p, nil
+	// If we have a point from both, make sure that they match each other.
+	if a != nil && b != nil {
+		if a.Name > b.Name {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Name < b.Name {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+
+		if ltags, rtags := a.Tags.ID(), b.Tags.ID(); ltags > rtags {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if ltags < rtags {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+
+		if a.Time > b.Time {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Time < b.Time {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+	}
+	return a, b, nil
 }
 
-// stringReduceFloatIterator executes a reducer for every interval and buffers the result.
-type stringReduceFloatIterator struct {
+// stringIntegerExprFunc creates or modifies a point by combining two
+// points. The point passed in may be modified and returned rather than
+// allocating a new point if possible. One of the points may be nil, but at
+// least one of the points will be non-nil.
+type stringIntegerExprFunc func(a, b string) int64
+
+// stringReduceUnsignedIterator executes a reducer for every interval and buffers the result.
+type stringReduceUnsignedIterator struct {
 	input  *bufStringIterator
-	create func() (StringPointAggregator, FloatPointEmitter)
+	create func() (StringPointAggregator, UnsignedPointEmitter)
 	dims   []string
 	opt    IteratorOptions
-	points []FloatPoint
+	points []UnsignedPoint
 }
 
-func newStringReduceFloatIterator(input StringIterator, opt IteratorOptions, createFn func() (StringPointAggregator, FloatPointEmitter)) *stringReduceFloatIterator {
-	return &stringReduceFloatIterator{
+func newStringReduceUnsignedIterator(input StringIterator, opt IteratorOptions, createFn func() (StringPointAggregator, UnsignedPointEmitter)) *stringReduceUnsignedIterator {
+	return &stringReduceUnsignedIterator{
 		input:  newBufStringIterator(input),
 		create: createFn,
 		dims:   opt.GetDimensions(),
@@ -6840,13 +11823,13 @@ func newStringReduceFloatIterator(input StringIterator, opt IteratorOptions, cre
 }
 
 // Stats returns stats from the input iterator.
-func (itr *stringReduceFloatIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *stringReduceUnsignedIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *stringReduceFloatIterator) Close() error { return itr.input.Close() }
+func (itr *stringReduceUnsignedIterator) Close() error { return itr.input.Close() }
 
 // Next returns the minimum value for the next available interval.
-func (itr *stringReduceFloatIterator) Next() (*FloatPoint, error) {
+func (itr *stringReduceUnsignedIterator) Next() (*UnsignedPoint, error) {
 	// Calculate next window if we have no more points.
 	if len(itr.points) == 0 {
 		var err error
@@ -6862,17 +11845,17 @@ func (itr *stringReduceFloatIterator) Next() (*FloatPoint, error) {
 	return p, nil
 }
 
-// stringReduceFloatPoint stores the reduced data for a name/tag combination.
-type stringReduceFloatPoint struct {
+// stringReduceUnsignedPoint stores the reduced data for a name/tag combination.
+type stringReduceUnsignedPoint struct {
 	Name       string
 	Tags       Tags
 	Aggregator StringPointAggregator
-	Emitter    FloatPointEmitter
+	Emitter    UnsignedPointEmitter
 }
 
 // reduce executes fn once for every point in the next window.
 // The previous value for the dimension is passed to fn.
-func (itr *stringReduceFloatIterator) reduce() ([]FloatPoint, error) {
+func (itr *stringReduceUnsignedIterator) reduce() ([]UnsignedPoint, error) {
 	// Calculate next window.
 	var (
 		startTime, endTime int64
@@ -6897,7 +11880,7 @@ func (itr *stringReduceFloatIterator) reduce() ([]FloatPoint, error) {
 	}
 
 	// Create points by tags.
-	m := make(map[string]*stringReduceFloatPoint)
+	m := make(map[string]*stringReduceUnsignedPoint)
 	for {
 		// Read next point.
 		curr, err := itr.input.NextInWindow(startTime, endTime)
@@ -6930,7 +11913,7 @@ func (itr *stringReduceFloatIterator) reduce() ([]FloatPoint, error) {
 		rp := m[id]
 		if rp == nil {
 			aggregator, emitter := itr.create()
-			rp = &stringReduceFloatPoint{
+			rp = &stringReduceUnsignedPoint{
 				Name:       curr.Name,
 				Tags:       tags,
 				Aggregator: aggregator,
@@ -6953,7 +11936,7 @@ func (itr *stringReduceFloatIterator) reduce() ([]FloatPoint, error) {
 	// Assume the points are already sorted until proven otherwise.
 	sortedByTime := true
 	// Emit the points for each name & tag combination.
-	a := make([]FloatPoint, 0, len(m))
+	a := make([]UnsignedPoint, 0, len(m))
 	for _, k := range keys {
 		rp := m[k]
 		points := rp.Emitter.Emit()
@@ -6972,41 +11955,41 @@ func (itr *stringReduceFloatIterator) reduce() ([]FloatPoint, error) {
 
 	// Points may be out of order. Perform a stable sort by time if requested.
 	if !sortedByTime && itr.opt.Ordered {
-		sort.Stable(sort.Reverse(floatPointsByTime(a)))
+		sort.Stable(sort.Reverse(unsignedPointsByTime(a)))
 	}
 
 	return a, nil
 }
 
-// stringStreamFloatIterator streams inputs into the iterator and emits points gradually.
-type stringStreamFloatIterator struct {
+// stringStreamUnsignedIterator streams inputs into the iterator and emits points gradually.
+type stringStreamUnsignedIterator struct {
 	input  *bufStringIterator
-	create func() (StringPointAggregator, FloatPointEmitter)
+	create func() (StringPointAggregator, UnsignedPointEmitter)
 	dims   []string
 	opt    IteratorOptions
-	m      map[string]*stringReduceFloatPoint
-	points []FloatPoint
+	m      map[string]*stringReduceUnsignedPoint
+	points []UnsignedPoint
 }
 
-// newStringStreamFloatIterator returns a new instance of stringStreamFloatIterator.
-func newStringStreamFloatIterator(input StringIterator, createFn func() (StringPointAggregator, FloatPointEmitter), opt IteratorOptions) *stringStreamFloatIterator {
-	return &stringStreamFloatIterator{
+// newStringStreamUnsignedIterator returns a new instance of stringStreamUnsignedIterator.
+func newStringStreamUnsignedIterator(input StringIterator, createFn func() (StringPointAggregator, UnsignedPointEmitter), opt IteratorOptions) *stringStreamUnsignedIterator {
+	return &stringStreamUnsignedIterator{
 		input:  newBufStringIterator(input),
 		create: createFn,
 		dims:   opt.GetDimensions(),
 		opt:    opt,
-		m:      make(map[string]*stringReduceFloatPoint),
+		m:      make(map[string]*stringReduceUnsignedPoint),
 	}
 }
 
 // Stats returns stats from the input iterator.
-func (itr *stringStreamFloatIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *stringStreamUnsignedIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *stringStreamFloatIterator) Close() error { return itr.input.Close() }
+func (itr *stringStreamUnsignedIterator) Close() error { return itr.input.Close() }
 
 // Next returns the next value for the stream iterator.
-func (itr *stringStreamFloatIterator) Next() (*FloatPoint, error) {
+func (itr *stringStreamUnsignedIterator) Next() (*UnsignedPoint, error) {
 	// Calculate next window if we have no more points.
 	if len(itr.points) == 0 {
 		var err error
@@ -7024,13 +12007,13 @@ func (itr *stringStreamFloatIterator) Next() (*FloatPoint, error) {
 
 // reduce creates and manages aggregators for every point from the input.
 // After aggregating a point, it always tries to emit a value using the emitter.
-func (itr *stringStreamFloatIterator) reduce() ([]FloatPoint, error) {
+func (itr *stringStreamUnsignedIterator) reduce() ([]UnsignedPoint, error) {
 	for {
 		// Read next point.
 		curr, err := itr.input.Next()
 		if curr == nil {
 			// Close all of the aggregators to flush any remaining points to emit.
-			var points []FloatPoint
+			var points []UnsignedPoint
 			for _, rp := range itr.m {
 				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
 					if err := aggregator.Close(); err != nil {
@@ -7069,7 +12052,7 @@ func (itr *stringStreamFloatIterator) reduce() ([]FloatPoint, error) {
 		rp := itr.m[id]
 		if rp == nil {
 			aggregator, emitter := itr.create()
-			rp = &stringReduceFloatPoint{
+			rp = &stringReduceUnsignedPoint{
 				Name:       curr.Name,
 				Tags:       tags,
 				Aggregator: aggregator,
@@ -7093,17 +12076,17 @@ func (itr *stringStreamFloatIterator) reduce() ([]FloatPoint, error) {
 	}
 }
 
-// stringFloatExprIterator executes a function to modify an existing point
+// stringUnsignedExprIterator executes a function to modify an existing point
 // for every output of the input iterator.
-type stringFloatExprIterator struct {
+type stringUnsignedExprIterator struct {
 	left      *bufStringIterator
 	right     *bufStringIterator
-	fn        stringFloatExprFunc
+	fn        stringUnsignedExprFunc
 	points    []StringPoint // must be size 2
 	storePrev bool
 }
 
-func newStringFloatExprIterator(left, right StringIterator, opt IteratorOptions, fn func(a, b string) float64) *stringFloatExprIterator {
+func newStringUnsignedExprIterator(left, right StringIterator, opt IteratorOptions, fn func(a, b string) uint64) *stringUnsignedExprIterator {
 	var points []StringPoint
 	switch opt.Fill {
 	case NullFill, PreviousFill:
@@ -7112,7 +12095,7 @@ func newStringFloatExprIterator(left, right StringIterator, opt IteratorOptions,
 		value := castToString(opt.FillValue)
 		points = []StringPoint{{Value: value}, {Value: value}}
 	}
-	return &stringFloatExprIterator{
+	return &stringUnsignedExprIterator{
 		left:      newBufStringIterator(left),
 		right:     newBufStringIterator(right),
 		points:    points,
@@ -7121,19 +12104,19 @@ func newStringFloatExprIterator(left, right StringIterator, opt IteratorOptions,
 	}
 }
 
-func (itr *stringFloatExprIterator) Stats() IteratorStats {
+func (itr *stringUnsignedExprIterator) Stats() IteratorStats {
 	stats := itr.left.Stats()
 	stats.Add(itr.right.Stats())
 	return stats
 }
 
-func (itr *stringFloatExprIterator) Close() error {
+func (itr *stringUnsignedExprIterator) Close() error {
 	itr.left.Close()
 	itr.right.Close()
 	return nil
 }
 
-func (itr *stringFloatExprIterator) Next() (*FloatPoint, error) {
+func (itr *stringUnsignedExprIterator) Next() (*UnsignedPoint, error) {
 	for {
 		a, b, err := itr.next()
 		if err != nil || (a == nil && b == nil) {
@@ -7174,7 +12157,7 @@ func (itr *stringFloatExprIterator) Next() (*FloatPoint, error) {
 			itr.points[0], itr.points[1] = *a, *b
 		}
 
-		p := &FloatPoint{
+		p := &UnsignedPoint{
 			Name:       a.Name,
 			Tags:       a.Tags,
 			Time:       a.Time,
@@ -7191,7 +12174,7 @@ func (itr *stringFloatExprIterator) Next() (*FloatPoint, error) {
 
 // next returns the next points within each iterator. If the iterators are
 // uneven, it organizes them so only matching points are returned.
-func (itr *stringFloatExprIterator) next() (a, b *StringPoint, err error) {
+func (itr *stringUnsignedExprIterator) next() (a, b *StringPoint, err error) {
 	// Retrieve the next value for both the left and right.
 	a, err = itr.left.Next()
 	if err != nil {
@@ -7231,23 +12214,23 @@ func (itr *stringFloatExprIterator) next() (a, b *StringPoint, err error) {
 	return a, b, nil
 }
 
-// stringFloatExprFunc creates or modifies a point by combining two
+// stringUnsignedExprFunc creates or modifies a point by combining two
 // points. The point passed in may be modified and returned rather than
 // allocating a new point if possible. One of the points may be nil, but at
 // least one of the points will be non-nil.
-type stringFloatExprFunc func(a, b string) float64
+type stringUnsignedExprFunc func(a, b string) uint64
 
-// stringReduceIntegerIterator executes a reducer for every interval and buffers the result.
-type stringReduceIntegerIterator struct {
+// stringReduceStringIterator executes a reducer for every interval and buffers the result.
+type stringReduceStringIterator struct {
 	input  *bufStringIterator
-	create func() (StringPointAggregator, IntegerPointEmitter)
+	create func() (StringPointAggregator, StringPointEmitter)
 	dims   []string
 	opt    IteratorOptions
-	points []IntegerPoint
+	points []StringPoint
 }
 
-func newStringReduceIntegerIterator(input StringIterator, opt IteratorOptions, createFn func() (StringPointAggregator, IntegerPointEmitter)) *stringReduceIntegerIterator {
-	return &stringReduceIntegerIterator{
+func newStringReduceStringIterator(input StringIterator, opt IteratorOptions, createFn func() (StringPointAggregator, StringPointEmitter)) *stringReduceStringIterator {
+	return &stringReduceStringIterator{
 		input:  newBufStringIterator(input),
 		create: createFn,
 		dims:   opt.GetDimensions(),
@@ -7256,13 +12239,13 @@ func newStringReduceIntegerIterator(input StringIterator, opt IteratorOptions, c
 }
 
 // Stats returns stats from the input iterator.
-func (itr *stringReduceIntegerIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *stringReduceStringIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *stringReduceIntegerIterator) Close() error { return itr.input.Close() }
+func (itr *stringReduceStringIterator) Close() error { return itr.input.Close() }
 
 // Next returns the minimum value for the next available interval.
-func (itr *stringReduceIntegerIterator) Next() (*IntegerPoint, error) {
+func (itr *stringReduceStringIterator) Next() (*StringPoint, error) {
 	// Calculate next window if we have no more points.
 	if len(itr.points) == 0 {
 		var err error
@@ -7278,17 +12261,17 @@ func (itr *stringReduceIntegerIterator) Next() (*IntegerPoint, error) {
 	return p, nil
 }
 
-// stringReduceIntegerPoint stores the reduced data for a name/tag combination.
-type stringReduceIntegerPoint struct {
+// stringReduceStringPoint stores the reduced data for a name/tag combination.
+type stringReduceStringPoint struct {
 	Name       string
 	Tags       Tags
 	Aggregator StringPointAggregator
-	Emitter    IntegerPointEmitter
+	Emitter    StringPointEmitter
 }
 
 // reduce executes fn once for every point in the next window.
 // The previous value for the dimension is passed to fn.
-func (itr *stringReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
+func (itr *stringReduceStringIterator) reduce() ([]StringPoint, error) {
 	// Calculate next window.
 	var (
 		startTime, endTime int64
@@ -7313,7 +12296,7 @@ func (itr *stringReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
 	}
 
 	// Create points by tags.
-	m := make(map[string]*stringReduceIntegerPoint)
+	m := make(map[string]*stringReduceStringPoint)
 	for {
 		// Read next point.
 		curr, err := itr.input.NextInWindow(startTime, endTime)
@@ -7346,7 +12329,7 @@ func (itr *stringReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
 		rp := m[id]
 		if rp == nil {
 			aggregator, emitter := itr.create()
-			rp = &stringReduceIntegerPoint{
+			rp = &stringReduceStringPoint{
 				Name:       curr.Name,
 				Tags:       tags,
 				Aggregator: aggregator,
@@ -7369,7 +12352,7 @@ func (itr *stringReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
 	// Assume the points are already sorted until proven otherwise.
 	sortedByTime := true
 	// Emit the points for each name & tag combination.
-	a := make([]IntegerPoint, 0, len(m))
+	a := make([]StringPoint, 0, len(m))
 	for _, k := range keys {
 		rp := m[k]
 		points := rp.Emitter.Emit()
@@ -7388,41 +12371,41 @@ func (itr *stringReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
 
 	// Points may be out of order. Perform a stable sort by time if requested.
 	if !sortedByTime && itr.opt.Ordered {
-		sort.Stable(sort.Reverse(integerPointsByTime(a)))
+		sort.Stable(sort.Reverse(stringPointsByTime(a)))
 	}
 
 	return a, nil
 }
 
-// stringStreamIntegerIterator streams inputs into the iterator and emits points gradually.
-type stringStreamIntegerIterator struct {
+// stringStreamStringIterator streams inputs into the iterator and emits points gradually.
+type stringStreamStringIterator struct {
 	input  *bufStringIterator
-	create func() (StringPointAggregator, IntegerPointEmitter)
+	create func() (StringPointAggregator, StringPointEmitter)
 	dims   []string
 	opt    IteratorOptions
-	m      map[string]*stringReduceIntegerPoint
-	points []IntegerPoint
+	m      map[string]*stringReduceStringPoint
+	points []StringPoint
 }
 
-// newStringStreamIntegerIterator returns a new instance of stringStreamIntegerIterator.
-func newStringStreamIntegerIterator(input StringIterator, createFn func() (StringPointAggregator, IntegerPointEmitter), opt IteratorOptions) *stringStreamIntegerIterator {
-	return &stringStreamIntegerIterator{
+// newStringStreamStringIterator returns a new instance of stringStreamStringIterator.
+func newStringStreamStringIterator(input StringIterator, createFn func() (StringPointAggregator, StringPointEmitter), opt IteratorOptions) *stringStreamStringIterator {
+	return &stringStreamStringIterator{
 		input:  newBufStringIterator(input),
 		create: createFn,
 		dims:   opt.GetDimensions(),
 		opt:    opt,
-		m:      make(map[string]*stringReduceIntegerPoint),
+		m:      make(map[string]*stringReduceStringPoint),
 	}
 }
 
 // Stats returns stats from the input iterator.
-func (itr *stringStreamIntegerIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *stringStreamStringIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *stringStreamIntegerIterator) Close() error { return itr.input.Close() }
+func (itr *stringStreamStringIterator) Close() error { return itr.input.Close() }
 
 // Next returns the next value for the stream iterator.
-func (itr *stringStreamIntegerIterator) Next() (*IntegerPoint, error) {
+func (itr *stringStreamStringIterator) Next() (*StringPoint, error) {
 	// Calculate next window if we have no more points.
 	if len(itr.points) == 0 {
 		var err error
@@ -7440,13 +12423,13 @@ func (itr *stringStreamIntegerIterator) Next() (*IntegerPoint, error) {
 
 // reduce creates and manages aggregators for every point from the input.
 // After aggregating a point, it always tries to emit a value using the emitter.
-func (itr *stringStreamIntegerIterator) reduce() ([]IntegerPoint, error) {
+func (itr *stringStreamStringIterator) reduce() ([]StringPoint, error) {
 	for {
 		// Read next point.
 		curr, err := itr.input.Next()
 		if curr == nil {
 			// Close all of the aggregators to flush any remaining points to emit.
-			var points []IntegerPoint
+			var points []StringPoint
 			for _, rp := range itr.m {
 				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
 					if err := aggregator.Close(); err != nil {
@@ -7485,7 +12468,7 @@ func (itr *stringStreamIntegerIterator) reduce() ([]IntegerPoint, error) {
 		rp := itr.m[id]
 		if rp == nil {
 			aggregator, emitter := itr.create()
-			rp = &stringReduceIntegerPoint{
+			rp = &stringReduceStringPoint{
 				Name:       curr.Name,
 				Tags:       tags,
 				Aggregator: aggregator,
@@ -7509,17 +12492,17 @@ func (itr *stringStreamIntegerIterator) reduce() ([]IntegerPoint, error) {
 	}
 }
 
-// stringIntegerExprIterator executes a function to modify an existing point
+// stringExprIterator executes a function to modify an existing point
 // for every output of the input iterator.
-type stringIntegerExprIterator struct {
+type stringExprIterator struct {
 	left      *bufStringIterator
 	right     *bufStringIterator
-	fn        stringIntegerExprFunc
+	fn        stringExprFunc
 	points    []StringPoint // must be size 2
 	storePrev bool
 }
 
-func newStringIntegerExprIterator(left, right StringIterator, opt IteratorOptions, fn func(a, b string) int64) *stringIntegerExprIterator {
+func newStringExprIterator(left, right StringIterator, opt IteratorOptions, fn func(a, b string) string) *stringExprIterator {
 	var points []StringPoint
 	switch opt.Fill {
 	case NullFill, PreviousFill:
@@ -7528,7 +12511,7 @@ func newStringIntegerExprIterator(left, right StringIterator, opt IteratorOption
 		value := castToString(opt.FillValue)
 		points = []StringPoint{{Value: value}, {Value: value}}
 	}
-	return &stringIntegerExprIterator{
+	return &stringExprIterator{
 		left:      newBufStringIterator(left),
 		right:     newBufStringIterator(right),
 		points:    points,
@@ -7537,19 +12520,19 @@ func newStringIntegerExprIterator(left, right StringIterator, opt IteratorOption
 	}
 }
 
-func (itr *stringIntegerExprIterator) Stats() IteratorStats {
+func (itr *stringExprIterator) Stats() IteratorStats {
 	stats := itr.left.Stats()
 	stats.Add(itr.right.Stats())
 	return stats
 }
 
-func (itr *stringIntegerExprIterator) Close() error {
+func (itr *stringExprIterator) Close() error {
 	itr.left.Close()
 	itr.right.Close()
 	return nil
 }
 
-func (itr *stringIntegerExprIterator) Next() (*IntegerPoint, error) {
+func (itr *stringExprIterator) Next() (*StringPoint, error) {
 	for {
 		a, b, err := itr.next()
 		if err != nil || (a == nil && b == nil) {
@@ -7590,24 +12573,20 @@ func (itr *stringIntegerExprIterator) Next() (*IntegerPoint, error) {
 			itr.points[0], itr.points[1] = *a, *b
 		}
 
-		p := &IntegerPoint{
-			Name:       a.Name,
-			Tags:       a.Tags,
-			Time:       a.Time,
-			Nil:        a.Nil || b.Nil,
-			Aggregated: a.Aggregated,
-		}
-		if !p.Nil {
-			p.Value = itr.fn(a.Value, b.Value)
+		if a.Nil {
+			return a, nil
+		} else if b.Nil {
+			return b, nil
 		}
-		return p, nil
+		a.Value = itr.fn(a.Value, b.Value)
+		return a, nil
 
 	}
 }
 
 // next returns the next points within each iterator. If the iterators are
 // uneven, it organizes them so only matching points are returned.
-func (itr *stringIntegerExprIterator) next() (a, b *StringPoint, err error) {
+func (itr *stringExprIterator) next() (a, b *StringPoint, err error) {
 	// Retrieve the next value for both the left and right.
 	a, err = itr.left.Next()
 	if err != nil {
@@ -7647,23 +12626,23 @@ func (itr *stringIntegerExprIterator) next() (a, b *StringPoint, err error) {
 	return a, b, nil
 }
 
-// stringIntegerExprFunc creates or modifies a point by combining two
+// stringExprFunc creates or modifies a point by combining two
 // points. The point passed in may be modified and returned rather than
 // allocating a new point if possible. One of the points may be nil, but at
 // least one of the points will be non-nil.
-type stringIntegerExprFunc func(a, b string) int64
+type stringExprFunc func(a, b string) string
 
-// stringReduceStringIterator executes a reducer for every interval and buffers the result.
-type stringReduceStringIterator struct {
+// stringReduceBooleanIterator executes a reducer for every interval and buffers the result.
+type stringReduceBooleanIterator struct {
 	input  *bufStringIterator
-	create func() (StringPointAggregator, StringPointEmitter)
+	create func() (StringPointAggregator, BooleanPointEmitter)
 	dims   []string
 	opt    IteratorOptions
-	points []StringPoint
+	points []BooleanPoint
 }
 
-func newStringReduceStringIterator(input StringIterator, opt IteratorOptions, createFn func() (StringPointAggregator, StringPointEmitter)) *stringReduceStringIterator {
-	return &stringReduceStringIterator{
+func newStringReduceBooleanIterator(input StringIterator, opt IteratorOptions, createFn func() (StringPointAggregator, BooleanPointEmitter)) *stringReduceBooleanIterator {
+	return &stringReduceBooleanIterator{
 		input:  newBufStringIterator(input),
 		create: createFn,
 		dims:   opt.GetDimensions(),
@@ -7672,13 +12651,13 @@ func newStringReduceStringIterator(input StringIterator, opt IteratorOptions, cr
 }
 
 // Stats returns stats from the input iterator.
-func (itr *stringReduceStringIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *stringReduceBooleanIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *stringReduceStringIterator) Close() error { return itr.input.Close() }
+func (itr *stringReduceBooleanIterator) Close() error { return itr.input.Close() }
 
 // Next returns the minimum value for the next available interval.
-func (itr *stringReduceStringIterator) Next() (*StringPoint, error) {
+func (itr *stringReduceBooleanIterator) Next() (*BooleanPoint, error) {
 	// Calculate next window if we have no more points.
 	if len(itr.points) == 0 {
 		var err error
@@ -7694,17 +12673,17 @@ func (itr *stringReduceStringIterator) Next() (*StringPoint, error) {
 	return p, nil
 }
 
-// stringReduceStringPoint stores the reduced data for a name/tag combination.
-type stringReduceStringPoint struct {
+// stringReduceBooleanPoint stores the reduced data for a name/tag combination.
+type stringReduceBooleanPoint struct {
 	Name       string
 	Tags       Tags
 	Aggregator StringPointAggregator
-	Emitter    StringPointEmitter
+	Emitter    BooleanPointEmitter
 }
 
 // reduce executes fn once for every point in the next window.
 // The previous value for the dimension is passed to fn.
-func (itr *stringReduceStringIterator) reduce() ([]StringPoint, error) {
+func (itr *stringReduceBooleanIterator) reduce() ([]BooleanPoint, error) {
 	// Calculate next window.
 	var (
 		startTime, endTime int64
@@ -7729,7 +12708,7 @@ func (itr *stringReduceStringIterator) reduce() ([]StringPoint, error) {
 	}
 
 	// Create points by tags.
-	m := make(map[string]*stringReduceStringPoint)
+	m := make(map[string]*stringReduceBooleanPoint)
 	for {
 		// Read next point.
 		curr, err := itr.input.NextInWindow(startTime, endTime)
@@ -7762,7 +12741,7 @@ func (itr *stringReduceStringIterator) reduce() ([]StringPoint, error) {
 		rp := m[id]
 		if rp == nil {
 			aggregator, emitter := itr.create()
-			rp = &stringReduceStringPoint{
+			rp = &stringReduceBooleanPoint{
 				Name:       curr.Name,
 				Tags:       tags,
 				Aggregator: aggregator,
@@ -7785,7 +12764,7 @@ func (itr *stringReduceStringIterator) reduce() ([]StringPoint, error) {
 	// Assume the points are already sorted until proven otherwise.
 	sortedByTime := true
 	// Emit the points for each name & tag combination.
-	a := make([]StringPoint, 0, len(m))
+	a := make([]BooleanPoint, 0, len(m))
 	for _, k := range keys {
 		rp := m[k]
 		points := rp.Emitter.Emit()
@@ -7804,41 +12783,41 @@ func (itr *stringReduceStringIterator) reduce() ([]StringPoint, error) {
 
 	// Points may be out of order. Perform a stable sort by time if requested.
 	if !sortedByTime && itr.opt.Ordered {
-		sort.Stable(sort.Reverse(stringPointsByTime(a)))
+		sort.Stable(sort.Reverse(booleanPointsByTime(a)))
 	}
 
 	return a, nil
 }
 
-// stringStreamStringIterator streams inputs into the iterator and emits points gradually.
-type stringStreamStringIterator struct {
+// stringStreamBooleanIterator streams inputs into the iterator and emits points gradually.
+type stringStreamBooleanIterator struct {
 	input  *bufStringIterator
-	create func() (StringPointAggregator, StringPointEmitter)
+	create func() (StringPointAggregator, BooleanPointEmitter)
 	dims   []string
 	opt    IteratorOptions
-	m      map[string]*stringReduceStringPoint
-	points []StringPoint
+	m      map[string]*stringReduceBooleanPoint
+	points []BooleanPoint
 }
 
-// newStringStreamStringIterator returns a new instance of stringStreamStringIterator.
-func newStringStreamStringIterator(input StringIterator, createFn func() (StringPointAggregator, StringPointEmitter), opt IteratorOptions) *stringStreamStringIterator {
-	return &stringStreamStringIterator{
+// newStringStreamBooleanIterator returns a new instance of stringStreamBooleanIterator.
+func newStringStreamBooleanIterator(input StringIterator, createFn func() (StringPointAggregator, BooleanPointEmitter), opt IteratorOptions) *stringStreamBooleanIterator {
+	return &stringStreamBooleanIterator{
 		input:  newBufStringIterator(input),
 		create: createFn,
 		dims:   opt.GetDimensions(),
 		opt:    opt,
-		m:      make(map[string]*stringReduceStringPoint),
+		m:      make(map[string]*stringReduceBooleanPoint),
 	}
 }
 
 // Stats returns stats from the input iterator.
-func (itr *stringStreamStringIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *stringStreamBooleanIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *stringStreamStringIterator) Close() error { return itr.input.Close() }
+func (itr *stringStreamBooleanIterator) Close() error { return itr.input.Close() }
 
 // Next returns the next value for the stream iterator.
-func (itr *stringStreamStringIterator) Next() (*StringPoint, error) {
+func (itr *stringStreamBooleanIterator) Next() (*BooleanPoint, error) {
 	// Calculate next window if we have no more points.
 	if len(itr.points) == 0 {
 		var err error
@@ -7856,13 +12835,13 @@ func (itr *stringStreamStringIterator) Next() (*StringPoint, error) {
 
 // reduce creates and manages aggregators for every point from the input.
 // After aggregating a point, it always tries to emit a value using the emitter.
-func (itr *stringStreamStringIterator) reduce() ([]StringPoint, error) {
+func (itr *stringStreamBooleanIterator) reduce() ([]BooleanPoint, error) {
 	for {
 		// Read next point.
 		curr, err := itr.input.Next()
 		if curr == nil {
 			// Close all of the aggregators to flush any remaining points to emit.
-			var points []StringPoint
+			var points []BooleanPoint
 			for _, rp := range itr.m {
 				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
 					if err := aggregator.Close(); err != nil {
@@ -7901,7 +12880,7 @@ func (itr *stringStreamStringIterator) reduce() ([]StringPoint, error) {
 		rp := itr.m[id]
 		if rp == nil {
 			aggregator, emitter := itr.create()
-			rp = &stringReduceStringPoint{
+			rp = &stringReduceBooleanPoint{
 				Name:       curr.Name,
 				Tags:       tags,
 				Aggregator: aggregator,
@@ -7925,17 +12904,17 @@ func (itr *stringStreamStringIterator) reduce() ([]StringPoint, error) {
 	}
 }
 
-// stringExprIterator executes a function to modify an existing point
+// stringBooleanExprIterator executes a function to modify an existing point
 // for every output of the input iterator.
-type stringExprIterator struct {
+type stringBooleanExprIterator struct {
 	left      *bufStringIterator
 	right     *bufStringIterator
-	fn        stringExprFunc
+	fn        stringBooleanExprFunc
 	points    []StringPoint // must be size 2
 	storePrev bool
 }
 
-func newStringExprIterator(left, right StringIterator, opt IteratorOptions, fn func(a, b string) string) *stringExprIterator {
+func newStringBooleanExprIterator(left, right StringIterator, opt IteratorOptions, fn func(a, b string) bool) *stringBooleanExprIterator {
 	var points []StringPoint
 	switch opt.Fill {
 	case NullFill, PreviousFill:
@@ -7944,7 +12923,7 @@ func newStringExprIterator(left, right StringIterator, opt IteratorOptions, fn f
 		value := castToString(opt.FillValue)
 		points = []StringPoint{{Value: value}, {Value: value}}
 	}
-	return &stringExprIterator{
+	return &stringBooleanExprIterator{
 		left:      newBufStringIterator(left),
 		right:     newBufStringIterator(right),
 		points:    points,
@@ -7953,19 +12932,19 @@ func newStringExprIterator(left, right StringIterator, opt IteratorOptions, fn f
 	}
 }
 
-func (itr *stringExprIterator) Stats() IteratorStats {
+func (itr *stringBooleanExprIterator) Stats() IteratorStats {
 	stats := itr.left.Stats()
 	stats.Add(itr.right.Stats())
 	return stats
 }
 
-func (itr *stringExprIterator) Close() error {
+func (itr *stringBooleanExprIterator) Close() error {
 	itr.left.Close()
 	itr.right.Close()
 	return nil
 }
 
-func (itr *stringExprIterator) Next() (*StringPoint, error) {
+func (itr *stringBooleanExprIterator) Next() (*BooleanPoint, error) {
 	for {
 		a, b, err := itr.next()
 		if err != nil || (a == nil && b == nil) {
@@ -8006,20 +12985,24 @@ func (itr *stringExprIterator) Next() (*StringPoint, error) {
 			itr.points[0], itr.points[1] = *a, *b
 		}
 
-		if a.Nil {
-			return a, nil
-		} else if b.Nil {
-			return b, nil
+		p := &BooleanPoint{
+			Name:       a.Name,
+			Tags:       a.Tags,
+			Time:       a.Time,
+			Nil:        a.Nil || b.Nil,
+			Aggregated: a.Aggregated,
 		}
-		a.Value = itr.fn(a.Value, b.Value)
-		return a, nil
+		if !p.Nil {
+			p.Value = itr.fn(a.Value, b.Value)
+		}
+		return p, nil
 
 	}
 }
 
 // next returns the next points within each iterator. If the iterators are
 // uneven, it organizes them so only matching points are returned.
-func (itr *stringExprIterator) next() (a, b *StringPoint, err error) {
+func (itr *stringBooleanExprIterator) next() (a, b *StringPoint, err error) {
 	// Retrieve the next value for both the left and right.
 	a, err = itr.left.Next()
 	if err != nil {
@@ -8059,914 +13042,815 @@ func (itr *stringExprIterator) next() (a, b *StringPoint, err error) {
 	return a, b, nil
 }
 
-// stringExprFunc creates or modifies a point by combining two
+// stringBooleanExprFunc creates or modifies a point by combining two
 // points. The point passed in may be modified and returned rather than
 // allocating a new point if possible. One of the points may be nil, but at
 // least one of the points will be non-nil.
-type stringExprFunc func(a, b string) string
-
-// stringReduceBooleanIterator executes a reducer for every interval and buffers the result.
-type stringReduceBooleanIterator struct {
-	input  *bufStringIterator
-	create func() (StringPointAggregator, BooleanPointEmitter)
-	dims   []string
-	opt    IteratorOptions
-	points []BooleanPoint
-}
+type stringBooleanExprFunc func(a, b string) bool
 
-func newStringReduceBooleanIterator(input StringIterator, opt IteratorOptions, createFn func() (StringPointAggregator, BooleanPointEmitter)) *stringReduceBooleanIterator {
-	return &stringReduceBooleanIterator{
-		input:  newBufStringIterator(input),
-		create: createFn,
-		dims:   opt.GetDimensions(),
-		opt:    opt,
-	}
+// stringTransformIterator executes a function to modify an existing point for every
+// output of the input iterator.
+type stringTransformIterator struct {
+	input StringIterator
+	fn    stringTransformFunc
 }
 
 // Stats returns stats from the input iterator.
-func (itr *stringReduceBooleanIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *stringTransformIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *stringReduceBooleanIterator) Close() error { return itr.input.Close() }
+func (itr *stringTransformIterator) Close() error { return itr.input.Close() }
 
 // Next returns the minimum value for the next available interval.
-func (itr *stringReduceBooleanIterator) Next() (*BooleanPoint, error) {
-	// Calculate next window if we have no more points.
-	if len(itr.points) == 0 {
-		var err error
-		itr.points, err = itr.reduce()
-		if len(itr.points) == 0 {
-			return nil, err
-		}
+func (itr *stringTransformIterator) Next() (*StringPoint, error) {
+	p, err := itr.input.Next()
+	if err != nil {
+		return nil, err
+	} else if p != nil {
+		p = itr.fn(p)
 	}
-
-	// Pop next point off the stack.
-	p := &itr.points[len(itr.points)-1]
-	itr.points = itr.points[:len(itr.points)-1]
 	return p, nil
 }
 
-// stringReduceBooleanPoint stores the reduced data for a name/tag combination.
-type stringReduceBooleanPoint struct {
-	Name       string
-	Tags       Tags
-	Aggregator StringPointAggregator
-	Emitter    BooleanPointEmitter
+// stringTransformFunc creates or modifies a point.
+// The point passed in may be modified and returned rather than allocating a
+// new point if possible.
+type stringTransformFunc func(p *StringPoint) *StringPoint
+
+// stringBoolTransformIterator executes a function to modify an existing point for every
+// output of the input iterator.
+type stringBoolTransformIterator struct {
+	input StringIterator
+	fn    stringBoolTransformFunc
 }
 
-// reduce executes fn once for every point in the next window.
-// The previous value for the dimension is passed to fn.
-func (itr *stringReduceBooleanIterator) reduce() ([]BooleanPoint, error) {
-	// Calculate next window.
-	var (
-		startTime, endTime int64
-		window             struct {
-			name string
-			tags string
-		}
-	)
-	for {
-		p, err := itr.input.Next()
-		if err != nil || p == nil {
-			return nil, err
-		} else if p.Nil {
-			continue
-		}
+// Stats returns stats from the input iterator.
+func (itr *stringBoolTransformIterator) Stats() IteratorStats { return itr.input.Stats() }
 
-		// Unread the point so it can be processed.
-		itr.input.unread(p)
-		startTime, endTime = itr.opt.Window(p.Time)
-		window.name, window.tags = p.Name, p.Tags.Subset(itr.opt.Dimensions).ID()
-		break
-	}
+// Close closes the iterator and all child iterators.
+func (itr *stringBoolTransformIterator) Close() error { return itr.input.Close() }
 
-	// Create points by tags.
-	m := make(map[string]*stringReduceBooleanPoint)
-	for {
-		// Read next point.
-		curr, err := itr.input.NextInWindow(startTime, endTime)
-		if err != nil {
-			return nil, err
-		} else if curr == nil {
-			break
-		} else if curr.Nil {
-			continue
-		} else if curr.Name != window.name {
-			itr.input.unread(curr)
-			break
-		}
+// Next returns the minimum value for the next available interval.
+func (itr *stringBoolTransformIterator) Next() (*BooleanPoint, error) {
+	p, err := itr.input.Next()
+	if err != nil {
+		return nil, err
+	} else if p != nil {
+		return itr.fn(p), nil
+	}
+	return nil, nil
+}
 
-		// Ensure this point is within the same final window.
-		if curr.Name != window.name {
-			itr.input.unread(curr)
-			break
-		} else if tags := curr.Tags.Subset(itr.opt.Dimensions); tags.ID() != window.tags {
-			itr.input.unread(curr)
-			break
-		}
+// stringBoolTransformFunc creates or modifies a point.
+// The point passed in may be modified and returned rather than allocating a
+// new point if possible.
+type stringBoolTransformFunc func(p *StringPoint) *BooleanPoint
 
-		// Retrieve the tags on this point for this level of the query.
-		// This may be different than the bucket dimensions.
-		tags := curr.Tags.Subset(itr.dims)
-		id := tags.ID()
+// stringDedupeIterator only outputs unique points.
+// This differs from the DistinctIterator in that it compares all aux fields too.
+// This iterator is relatively inefficient and should only be used on small
+// datasets such as meta query results.
+type stringDedupeIterator struct {
+	input StringIterator
+	m     map[string]struct{} // lookup of points already sent
+}
 
-		// Retrieve the aggregator for this name/tag combination or create one.
-		rp := m[id]
-		if rp == nil {
-			aggregator, emitter := itr.create()
-			rp = &stringReduceBooleanPoint{
-				Name:       curr.Name,
-				Tags:       tags,
-				Aggregator: aggregator,
-				Emitter:    emitter,
-			}
-			m[id] = rp
-		}
-		rp.Aggregator.AggregateString(curr)
-	}
+type stringIteratorMapper struct {
+	e      *Emitter
+	buf    []interface{}
+	driver IteratorMap   // which iterator to use for the primary value, can be nil
+	fields []IteratorMap // which iterator to use for an aux field
+	point  StringPoint
+}
 
-	// Reverse sort points by name & tag if our output is supposed to be ordered.
-	keys := make([]string, 0, len(m))
-	for k := range m {
-		keys = append(keys, k)
-	}
-	if len(keys) > 1 && itr.opt.Ordered {
-		sort.Sort(reverseStringSlice(keys))
+func newStringIteratorMapper(itrs []Iterator, driver IteratorMap, fields []IteratorMap, opt IteratorOptions) *stringIteratorMapper {
+	e := NewEmitter(itrs, opt.Ascending, 0)
+	e.OmitTime = true
+	return &stringIteratorMapper{
+		e:      e,
+		buf:    make([]interface{}, len(itrs)),
+		driver: driver,
+		fields: fields,
+		point: StringPoint{
+			Aux: make([]interface{}, len(fields)),
+		},
 	}
+}
 
-	// Assume the points are already sorted until proven otherwise.
-	sortedByTime := true
-	// Emit the points for each name & tag combination.
-	a := make([]BooleanPoint, 0, len(m))
-	for _, k := range keys {
-		rp := m[k]
-		points := rp.Emitter.Emit()
-		for i := len(points) - 1; i >= 0; i-- {
-			points[i].Name = rp.Name
-			points[i].Tags = rp.Tags
-			// Set the points time to the interval time if the reducer didn't provide one.
-			if points[i].Time == ZeroTime {
-				points[i].Time = startTime
+func (itr *stringIteratorMapper) Next() (*StringPoint, error) {
+	t, name, tags, err := itr.e.loadBuf()
+	if err != nil || t == ZeroTime {
+		return nil, err
+	}
+	itr.point.Time = t
+	itr.point.Name = name
+	itr.point.Tags = tags
+
+	itr.e.readInto(t, name, tags, itr.buf)
+	if itr.driver != nil {
+		if v := itr.driver.Value(tags, itr.buf); v != nil {
+			if v, ok := v.(string); ok {
+				itr.point.Value = v
+				itr.point.Nil = false
 			} else {
-				sortedByTime = false
+				itr.point.Value = ""
+				itr.point.Nil = true
 			}
-			a = append(a, points[i])
+		} else {
+			itr.point.Value = ""
+			itr.point.Nil = true
 		}
 	}
-
-	// Points may be out of order. Perform a stable sort by time if requested.
-	if !sortedByTime && itr.opt.Ordered {
-		sort.Stable(sort.Reverse(booleanPointsByTime(a)))
+	for i, f := range itr.fields {
+		itr.point.Aux[i] = f.Value(tags, itr.buf)
 	}
-
-	return a, nil
-}
-
-// stringStreamBooleanIterator streams inputs into the iterator and emits points gradually.
-type stringStreamBooleanIterator struct {
-	input  *bufStringIterator
-	create func() (StringPointAggregator, BooleanPointEmitter)
-	dims   []string
-	opt    IteratorOptions
-	m      map[string]*stringReduceBooleanPoint
-	points []BooleanPoint
+	return &itr.point, nil
 }
 
-// newStringStreamBooleanIterator returns a new instance of stringStreamBooleanIterator.
-func newStringStreamBooleanIterator(input StringIterator, createFn func() (StringPointAggregator, BooleanPointEmitter), opt IteratorOptions) *stringStreamBooleanIterator {
-	return &stringStreamBooleanIterator{
-		input:  newBufStringIterator(input),
-		create: createFn,
-		dims:   opt.GetDimensions(),
-		opt:    opt,
-		m:      make(map[string]*stringReduceBooleanPoint),
+func (itr *stringIteratorMapper) Stats() IteratorStats {
+	stats := IteratorStats{}
+	for _, itr := range itr.e.itrs {
+		stats.Add(itr.Stats())
 	}
+	return stats
 }
 
-// Stats returns stats from the input iterator.
-func (itr *stringStreamBooleanIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *stringIteratorMapper) Close() error {
+	return itr.e.Close()
+}
 
-// Close closes the iterator and all child iterators.
-func (itr *stringStreamBooleanIterator) Close() error { return itr.input.Close() }
+type stringFilterIterator struct {
+	input StringIterator
+	cond  Expr
+	opt   IteratorOptions
+	m     map[string]interface{}
+}
 
-// Next returns the next value for the stream iterator.
-func (itr *stringStreamBooleanIterator) Next() (*BooleanPoint, error) {
-	// Calculate next window if we have no more points.
-	if len(itr.points) == 0 {
-		var err error
-		itr.points, err = itr.reduce()
-		if len(itr.points) == 0 {
-			return nil, err
+func newStringFilterIterator(input StringIterator, cond Expr, opt IteratorOptions) StringIterator {
+	// Strip out time conditions from the WHERE clause.
+	// TODO(jsternberg): This should really be done for us when creating the IteratorOptions struct.
+	n := RewriteFunc(CloneExpr(cond), func(n Node) Node {
+		switch n := n.(type) {
+		case *BinaryExpr:
+			if n.LHS.String() == "time" {
+				return &BooleanLiteral{Val: true}
+			}
 		}
+		return n
+	})
+
+	cond, _ = n.(Expr)
+	if cond == nil {
+		return input
+	} else if n, ok := cond.(*BooleanLiteral); ok && n.Val {
+		return input
 	}
 
-	// Pop next point off the stack.
-	p := &itr.points[len(itr.points)-1]
-	itr.points = itr.points[:len(itr.points)-1]
-	return p, nil
+	return &stringFilterIterator{
+		input: input,
+		cond:  cond,
+		opt:   opt,
+		m:     make(map[string]interface{}),
+	}
 }
 
-// reduce creates and manages aggregators for every point from the input.
-// After aggregating a point, it always tries to emit a value using the emitter.
-func (itr *stringStreamBooleanIterator) reduce() ([]BooleanPoint, error) {
-	for {
-		// Read next point.
-		curr, err := itr.input.Next()
-		if curr == nil {
-			// Close all of the aggregators to flush any remaining points to emit.
-			var points []BooleanPoint
-			for _, rp := range itr.m {
-				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
-					if err := aggregator.Close(); err != nil {
-						return nil, err
-					}
-
-					pts := rp.Emitter.Emit()
-					if len(pts) == 0 {
-						continue
-					}
-
-					for i := range pts {
-						pts[i].Name = rp.Name
-						pts[i].Tags = rp.Tags
-					}
-					points = append(points, pts...)
-				}
-			}
+func (itr *stringFilterIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *stringFilterIterator) Close() error         { return itr.input.Close() }
 
-			// Eliminate the aggregators and emitters.
-			itr.m = nil
-			return points, nil
-		} else if err != nil {
+func (itr *stringFilterIterator) Next() (*StringPoint, error) {
+	for {
+		p, err := itr.input.Next()
+		if err != nil || p == nil {
 			return nil, err
-		} else if curr.Nil {
-			continue
 		}
-		tags := curr.Tags.Subset(itr.dims)
 
-		id := curr.Name
-		if len(tags.m) > 0 {
-			id += "\x00" + tags.ID()
+		for i, ref := range itr.opt.Aux {
+			itr.m[ref.Val] = p.Aux[i]
 		}
-
-		// Retrieve the aggregator for this name/tag combination or create one.
-		rp := itr.m[id]
-		if rp == nil {
-			aggregator, emitter := itr.create()
-			rp = &stringReduceBooleanPoint{
-				Name:       curr.Name,
-				Tags:       tags,
-				Aggregator: aggregator,
-				Emitter:    emitter,
-			}
-			itr.m[id] = rp
+		for k, v := range p.Tags.KeyValues() {
+			itr.m[k] = v
 		}
-		rp.Aggregator.AggregateString(curr)
 
-		// Attempt to emit points from the aggregator.
-		points := rp.Emitter.Emit()
-		if len(points) == 0 {
+		if !EvalBool(itr.cond, itr.m) {
 			continue
 		}
-
-		for i := range points {
-			points[i].Name = rp.Name
-			points[i].Tags = rp.Tags
-		}
-		return points, nil
+		return p, nil
 	}
 }
 
-// stringBooleanExprIterator executes a function to modify an existing point
-// for every output of the input iterator.
-type stringBooleanExprIterator struct {
-	left      *bufStringIterator
-	right     *bufStringIterator
-	fn        stringBooleanExprFunc
-	points    []StringPoint // must be size 2
-	storePrev bool
-}
-
-func newStringBooleanExprIterator(left, right StringIterator, opt IteratorOptions, fn func(a, b string) bool) *stringBooleanExprIterator {
-	var points []StringPoint
-	switch opt.Fill {
-	case NullFill, PreviousFill:
-		points = []StringPoint{{Nil: true}, {Nil: true}}
-	case NumberFill:
-		value := castToString(opt.FillValue)
-		points = []StringPoint{{Value: value}, {Value: value}}
-	}
-	return &stringBooleanExprIterator{
-		left:      newBufStringIterator(left),
-		right:     newBufStringIterator(right),
-		points:    points,
-		fn:        fn,
-		storePrev: opt.Fill == PreviousFill,
+// newStringDedupeIterator returns a new instance of stringDedupeIterator.
+func newStringDedupeIterator(input StringIterator) *stringDedupeIterator {
+	return &stringDedupeIterator{
+		input: input,
+		m:     make(map[string]struct{}),
 	}
 }
 
-func (itr *stringBooleanExprIterator) Stats() IteratorStats {
-	stats := itr.left.Stats()
-	stats.Add(itr.right.Stats())
-	return stats
-}
-
-func (itr *stringBooleanExprIterator) Close() error {
-	itr.left.Close()
-	itr.right.Close()
-	return nil
-}
-
-func (itr *stringBooleanExprIterator) Next() (*BooleanPoint, error) {
-	for {
-		a, b, err := itr.next()
-		if err != nil || (a == nil && b == nil) {
-			return nil, err
-		}
-
-		// If any of these are nil and we are using fill(none), skip these points.
-		if (a == nil || a.Nil || b == nil || b.Nil) && itr.points == nil {
-			continue
-		}
-
-		// If one of the two points is nil, we need to fill it with a fake nil
-		// point that has the same name, tags, and time as the other point.
-		// There should never be a time when both of these are nil.
-		if a == nil {
-			p := *b
-			a = &p
-			a.Value = ""
-			a.Nil = true
-		} else if b == nil {
-			p := *a
-			b = &p
-			b.Value = ""
-			b.Nil = true
-		}
+// Stats returns stats from the input iterator.
+func (itr *stringDedupeIterator) Stats() IteratorStats { return itr.input.Stats() }
 
-		// If a value is nil, use the fill values if the fill value is non-nil.
-		if a.Nil && !itr.points[0].Nil {
-			a.Value = itr.points[0].Value
-			a.Nil = false
-		}
-		if b.Nil && !itr.points[1].Nil {
-			b.Value = itr.points[1].Value
-			b.Nil = false
-		}
+// Close closes the iterator and all child iterators.
+func (itr *stringDedupeIterator) Close() error { return itr.input.Close() }
 
-		if itr.storePrev {
-			itr.points[0], itr.points[1] = *a, *b
+// Next returns the next unique point from the input iterator.
+func (itr *stringDedupeIterator) Next() (*StringPoint, error) {
+	for {
+		// Read next point.
+		p, err := itr.input.Next()
+		if p == nil || err != nil {
+			return nil, err
 		}
 
-		p := &BooleanPoint{
-			Name:       a.Name,
-			Tags:       a.Tags,
-			Time:       a.Time,
-			Nil:        a.Nil || b.Nil,
-			Aggregated: a.Aggregated,
+		// Serialize to bytes to store in lookup.
+		buf, err := proto.Marshal(encodeStringPoint(p))
+		if err != nil {
+			return nil, err
 		}
-		if !p.Nil {
-			p.Value = itr.fn(a.Value, b.Value)
+
+		// If the point has already been output then move to the next point.
+		if _, ok := itr.m[string(buf)]; ok {
+			continue
 		}
+
+		// Otherwise mark it as emitted and return point.
+		itr.m[string(buf)] = struct{}{}
 		return p, nil
+	}
+}
+
+// stringReaderIterator represents an iterator that streams from a reader.
+type stringReaderIterator struct {
+	r   io.Reader
+	dec *StringPointDecoder
+}
 
+// newStringReaderIterator returns a new instance of stringReaderIterator.
+func newStringReaderIterator(r io.Reader, stats IteratorStats) *stringReaderIterator {
+	dec := NewStringPointDecoder(r)
+	dec.stats = stats
+
+	return &stringReaderIterator{
+		r:   r,
+		dec: dec,
 	}
 }
 
-// next returns the next points within each iterator. If the iterators are
-// uneven, it organizes them so only matching points are returned.
-func (itr *stringBooleanExprIterator) next() (a, b *StringPoint, err error) {
-	// Retrieve the next value for both the left and right.
-	a, err = itr.left.Next()
-	if err != nil {
-		return nil, nil, err
+// Stats returns stats about points processed.
+func (itr *stringReaderIterator) Stats() IteratorStats { return itr.dec.stats }
+
+// Close closes the underlying reader, if applicable.
+func (itr *stringReaderIterator) Close() error {
+	if r, ok := itr.r.(io.ReadCloser); ok {
+		return r.Close()
 	}
-	b, err = itr.right.Next()
-	if err != nil {
-		return nil, nil, err
+	return nil
+}
+
+// Next returns the next point from the iterator.
+func (itr *stringReaderIterator) Next() (*StringPoint, error) {
+	// OPTIMIZE(benbjohnson): Reuse point on iterator.
+
+	// Unmarshal next point.
+	p := &StringPoint{}
+	if err := itr.dec.DecodeStringPoint(p); err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
 	}
+	return p, nil
+}
 
-	// If we have a point from both, make sure that they match each other.
-	if a != nil && b != nil {
-		if a.Name > b.Name {
-			itr.left.unread(a)
-			return nil, b, nil
-		} else if a.Name < b.Name {
-			itr.right.unread(b)
-			return a, nil, nil
-		}
+// BooleanIterator represents a stream of boolean points.
+type BooleanIterator interface {
+	Iterator
+	Next() (*BooleanPoint, error)
+}
 
-		if ltags, rtags := a.Tags.ID(), b.Tags.ID(); ltags > rtags {
-			itr.left.unread(a)
-			return nil, b, nil
-		} else if ltags < rtags {
-			itr.right.unread(b)
-			return a, nil, nil
-		}
+// newBooleanIterators converts a slice of Iterator to a slice of BooleanIterator.
+// Drop and closes any iterator in itrs that is not a BooleanIterator and cannot
+// be cast to a BooleanIterator.
+func newBooleanIterators(itrs []Iterator) []BooleanIterator {
+	a := make([]BooleanIterator, 0, len(itrs))
+	for _, itr := range itrs {
+		switch itr := itr.(type) {
+		case BooleanIterator:
+			a = append(a, itr)
 
-		if a.Time > b.Time {
-			itr.left.unread(a)
-			return nil, b, nil
-		} else if a.Time < b.Time {
-			itr.right.unread(b)
-			return a, nil, nil
+		default:
+			itr.Close()
 		}
 	}
-	return a, b, nil
+	return a
 }
 
-// stringBooleanExprFunc creates or modifies a point by combining two
-// points. The point passed in may be modified and returned rather than
-// allocating a new point if possible. One of the points may be nil, but at
-// least one of the points will be non-nil.
-type stringBooleanExprFunc func(a, b string) bool
+// bufBooleanIterator represents a buffered BooleanIterator.
+type bufBooleanIterator struct {
+	itr BooleanIterator
+	buf *BooleanPoint
+}
 
-// stringTransformIterator executes a function to modify an existing point for every
-// output of the input iterator.
-type stringTransformIterator struct {
-	input StringIterator
-	fn    stringTransformFunc
+// newBufBooleanIterator returns a buffered BooleanIterator.
+func newBufBooleanIterator(itr BooleanIterator) *bufBooleanIterator {
+	return &bufBooleanIterator{itr: itr}
 }
 
-// Stats returns stats from the input iterator.
-func (itr *stringTransformIterator) Stats() IteratorStats { return itr.input.Stats() }
+// Stats returns statistics from the input iterator.
+func (itr *bufBooleanIterator) Stats() IteratorStats { return itr.itr.Stats() }
 
-// Close closes the iterator and all child iterators.
-func (itr *stringTransformIterator) Close() error { return itr.input.Close() }
+// Close closes the underlying iterator.
+func (itr *bufBooleanIterator) Close() error { return itr.itr.Close() }
 
-// Next returns the minimum value for the next available interval.
-func (itr *stringTransformIterator) Next() (*StringPoint, error) {
-	p, err := itr.input.Next()
+// peek returns the next point without removing it from the iterator.
+func (itr *bufBooleanIterator) peek() (*BooleanPoint, error) {
+	p, err := itr.Next()
 	if err != nil {
 		return nil, err
-	} else if p != nil {
-		p = itr.fn(p)
 	}
+	itr.unread(p)
 	return p, nil
 }
 
-// stringTransformFunc creates or modifies a point.
-// The point passed in may be modified and returned rather than allocating a
-// new point if possible.
-type stringTransformFunc func(p *StringPoint) *StringPoint
-
-// stringBoolTransformIterator executes a function to modify an existing point for every
-// output of the input iterator.
-type stringBoolTransformIterator struct {
-	input StringIterator
-	fn    stringBoolTransformFunc
+// peekTime returns the time of the next point.
+// Returns zero time if no more points available.
+func (itr *bufBooleanIterator) peekTime() (int64, error) {
+	p, err := itr.peek()
+	if p == nil || err != nil {
+		return ZeroTime, err
+	}
+	return p.Time, nil
 }
 
-// Stats returns stats from the input iterator.
-func (itr *stringBoolTransformIterator) Stats() IteratorStats { return itr.input.Stats() }
-
-// Close closes the iterator and all child iterators.
-func (itr *stringBoolTransformIterator) Close() error { return itr.input.Close() }
+// Next returns the current buffer, if exists, or calls the underlying iterator.
+func (itr *bufBooleanIterator) Next() (*BooleanPoint, error) {
+	buf := itr.buf
+	if buf != nil {
+		itr.buf = nil
+		return buf, nil
+	}
+	return itr.itr.Next()
+}
 
-// Next returns the minimum value for the next available interval.
-func (itr *stringBoolTransformIterator) Next() (*BooleanPoint, error) {
-	p, err := itr.input.Next()
-	if err != nil {
+// NextInWindow returns the next value if it is between [startTime, endTime).
+// If the next value is outside the range then it is moved to the buffer.
+func (itr *bufBooleanIterator) NextInWindow(startTime, endTime int64) (*BooleanPoint, error) {
+	v, err := itr.Next()
+	if v == nil || err != nil {
 		return nil, err
-	} else if p != nil {
-		return itr.fn(p), nil
+	} else if t := v.Time; t >= endTime || t < startTime {
+		itr.unread(v)
+		return nil, nil
 	}
-	return nil, nil
+	return v, nil
 }
 
-// stringBoolTransformFunc creates or modifies a point.
-// The point passed in may be modified and returned rather than allocating a
-// new point if possible.
-type stringBoolTransformFunc func(p *StringPoint) *BooleanPoint
+// unread sets v to the buffer. It is read on the next call to Next().
+func (itr *bufBooleanIterator) unread(v *BooleanPoint) { itr.buf = v }
 
-// stringDedupeIterator only outputs unique points.
-// This differs from the DistinctIterator in that it compares all aux fields too.
-// This iterator is relatively inefficient and should only be used on small
-// datasets such as meta query results.
-type stringDedupeIterator struct {
-	input StringIterator
-	m     map[string]struct{} // lookup of points already sent
-}
+// booleanMergeIterator represents an iterator that combines multiple boolean iterators.
+type booleanMergeIterator struct {
+	inputs []BooleanIterator
+	heap   *booleanMergeHeap
+	init   bool
 
-type stringIteratorMapper struct {
-	e      *Emitter
-	buf    []interface{}
-	driver IteratorMap   // which iterator to use for the primary value, can be nil
-	fields []IteratorMap // which iterator to use for an aux field
-	point  StringPoint
+	// Current iterator and window.
+	curr   *booleanMergeHeapItem
+	window struct {
+		name      string
+		tags      string
+		startTime int64
+		endTime   int64
+	}
 }
 
-func newStringIteratorMapper(itrs []Iterator, driver IteratorMap, fields []IteratorMap, opt IteratorOptions) *stringIteratorMapper {
-	e := NewEmitter(itrs, opt.Ascending, 0)
-	e.OmitTime = true
-	return &stringIteratorMapper{
-		e:      e,
-		buf:    make([]interface{}, len(itrs)),
-		driver: driver,
-		fields: fields,
-		point: StringPoint{
-			Aux: make([]interface{}, len(fields)),
+// newBooleanMergeIterator returns a new instance of booleanMergeIterator.
+func newBooleanMergeIterator(inputs []BooleanIterator, opt IteratorOptions) *booleanMergeIterator {
+	itr := &booleanMergeIterator{
+		inputs: inputs,
+		heap: &booleanMergeHeap{
+			items: make([]*booleanMergeHeapItem, 0, len(inputs)),
+			opt:   opt,
 		},
 	}
-}
 
-func (itr *stringIteratorMapper) Next() (*StringPoint, error) {
-	t, name, tags, err := itr.e.loadBuf()
-	if err != nil || t == ZeroTime {
-		return nil, err
-	}
-	itr.point.Time = t
-	itr.point.Name = name
-	itr.point.Tags = tags
+	// Initialize heap items.
+	for _, input := range inputs {
+		// Wrap in buffer, ignore any inputs without anymore points.
+		bufInput := newBufBooleanIterator(input)
 
-	itr.e.readInto(t, name, tags, itr.buf)
-	if itr.driver != nil {
-		if v := itr.driver.Value(tags, itr.buf); v != nil {
-			if v, ok := v.(string); ok {
-				itr.point.Value = v
-				itr.point.Nil = false
-			} else {
-				itr.point.Value = ""
-				itr.point.Nil = true
-			}
-		} else {
-			itr.point.Value = ""
-			itr.point.Nil = true
-		}
-	}
-	for i, f := range itr.fields {
-		itr.point.Aux[i] = f.Value(tags, itr.buf)
+		// Append to the heap.
+		itr.heap.items = append(itr.heap.items, &booleanMergeHeapItem{itr: bufInput})
 	}
-	return &itr.point, nil
+
+	return itr
 }
 
-func (itr *stringIteratorMapper) Stats() IteratorStats {
-	stats := IteratorStats{}
-	for _, itr := range itr.e.itrs {
-		stats.Add(itr.Stats())
+// Stats returns an aggregation of stats from the underlying iterators.
+func (itr *booleanMergeIterator) Stats() IteratorStats {
+	var stats IteratorStats
+	for _, input := range itr.inputs {
+		stats.Add(input.Stats())
 	}
 	return stats
 }
 
-func (itr *stringIteratorMapper) Close() error {
-	return itr.e.Close()
-}
-
-type stringFilterIterator struct {
-	input StringIterator
-	cond  Expr
-	opt   IteratorOptions
-	m     map[string]interface{}
+// Close closes the underlying iterators.
+func (itr *booleanMergeIterator) Close() error {
+	for _, input := range itr.inputs {
+		input.Close()
+	}
+	itr.curr = nil
+	itr.inputs = nil
+	itr.heap.items = nil
+	return nil
 }
 
-func newStringFilterIterator(input StringIterator, cond Expr, opt IteratorOptions) StringIterator {
-	// Strip out time conditions from the WHERE clause.
-	// TODO(jsternberg): This should really be done for us when creating the IteratorOptions struct.
-	n := RewriteFunc(CloneExpr(cond), func(n Node) Node {
-		switch n := n.(type) {
-		case *BinaryExpr:
-			if n.LHS.String() == "time" {
-				return &BooleanLiteral{Val: true}
+// Next returns the next point from the iterator.
+func (itr *booleanMergeIterator) Next() (*BooleanPoint, error) {
+	// Initialize the heap. This needs to be done lazily on the first call to this iterator
+	// so that iterator initialization done through the Select() call returns quickly.
+	// Queries can only be interrupted after the Select() call completes so any operations
+	// done during iterator creation cannot be interrupted, which is why we do it here
+	// instead so an interrupt can happen while initializing the heap.
+	if !itr.init {
+		items := itr.heap.items
+		itr.heap.items = make([]*booleanMergeHeapItem, 0, len(items))
+		for _, item := range items {
+			if p, err := item.itr.peek(); err != nil {
+				return nil, err
+			} else if p == nil {
+				continue
 			}
+			itr.heap.items = append(itr.heap.items, item)
 		}
-		return n
-	})
-
-	cond, _ = n.(Expr)
-	if cond == nil {
-		return input
-	} else if n, ok := cond.(*BooleanLiteral); ok && n.Val {
-		return input
+		heap.Init(itr.heap)
+		itr.init = true
 	}
 
-	return &stringFilterIterator{
-		input: input,
-		cond:  cond,
-		opt:   opt,
-		m:     make(map[string]interface{}),
-	}
-}
+	for {
+		// Retrieve the next iterator if we don't have one.
+		if itr.curr == nil {
+			if len(itr.heap.items) == 0 {
+				return nil, nil
+			}
+			itr.curr = heap.Pop(itr.heap).(*booleanMergeHeapItem)
 
-func (itr *stringFilterIterator) Stats() IteratorStats { return itr.input.Stats() }
-func (itr *stringFilterIterator) Close() error         { return itr.input.Close() }
+			// Read point and set current window.
+			p, err := itr.curr.itr.Next()
+			if err != nil {
+				return nil, err
+			}
+			tags := p.Tags.Subset(itr.heap.opt.Dimensions)
+			itr.window.name, itr.window.tags = p.Name, tags.ID()
+			itr.window.startTime, itr.window.endTime = itr.heap.opt.Window(p.Time)
+			return p, nil
+		}
 
-func (itr *stringFilterIterator) Next() (*StringPoint, error) {
-	for {
-		p, err := itr.input.Next()
-		if err != nil || p == nil {
+		// Read the next point from the current iterator.
+		p, err := itr.curr.itr.Next()
+		if err != nil {
 			return nil, err
 		}
 
-		for i, ref := range itr.opt.Aux {
-			itr.m[ref.Val] = p.Aux[i]
+		// If there are no more points then remove iterator from heap and find next.
+		if p == nil {
+			itr.curr = nil
+			continue
 		}
-		for k, v := range p.Tags.KeyValues() {
-			itr.m[k] = v
+
+		// Check if the point is inside of our current window.
+		inWindow := true
+		if window := itr.window; window.name != p.Name {
+			inWindow = false
+		} else if tags := p.Tags.Subset(itr.heap.opt.Dimensions); window.tags != tags.ID() {
+			inWindow = false
+		} else if opt := itr.heap.opt; opt.Ascending && p.Time >= window.endTime {
+			inWindow = false
+		} else if !opt.Ascending && p.Time < window.startTime {
+			inWindow = false
 		}
 
-		if !EvalBool(itr.cond, itr.m) {
+		// If it's outside our window then push iterator back on the heap and find new iterator.
+		if !inWindow {
+			itr.curr.itr.unread(p)
+			heap.Push(itr.heap, itr.curr)
+			itr.curr = nil
 			continue
 		}
+
 		return p, nil
 	}
 }
 
-// newStringDedupeIterator returns a new instance of stringDedupeIterator.
-func newStringDedupeIterator(input StringIterator) *stringDedupeIterator {
-	return &stringDedupeIterator{
-		input: input,
-		m:     make(map[string]struct{}),
+// booleanMergeHeap represents a heap of booleanMergeHeapItems.
+// Items are sorted by their next window and then by name/tags.
+type booleanMergeHeap struct {
+	opt   IteratorOptions
+	items []*booleanMergeHeapItem
+}
+
+func (h *booleanMergeHeap) Len() int      { return len(h.items) }
+func (h *booleanMergeHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *booleanMergeHeap) Less(i, j int) bool {
+	x, err := h.items[i].itr.peek()
+	if err != nil {
+		return true
+	}
+	y, err := h.items[j].itr.peek()
+	if err != nil {
+		return false
+	}
+
+	if h.opt.Ascending {
+		if x.Name != y.Name {
+			return x.Name < y.Name
+		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); xTags.ID() != yTags.ID() {
+			return xTags.ID() < yTags.ID()
+		}
+	} else {
+		if x.Name != y.Name {
+			return x.Name > y.Name
+		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); xTags.ID() != yTags.ID() {
+			return xTags.ID() > yTags.ID()
+		}
+	}
+
+	xt, _ := h.opt.Window(x.Time)
+	yt, _ := h.opt.Window(y.Time)
+
+	if h.opt.Ascending {
+		return xt < yt
 	}
+	return xt > yt
 }
 
-// Stats returns stats from the input iterator.
-func (itr *stringDedupeIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (h *booleanMergeHeap) Push(x interface{}) {
+	h.items = append(h.items, x.(*booleanMergeHeapItem))
+}
 
-// Close closes the iterator and all child iterators.
-func (itr *stringDedupeIterator) Close() error { return itr.input.Close() }
+func (h *booleanMergeHeap) Pop() interface{} {
+	old := h.items
+	n := len(old)
+	item := old[n-1]
+	h.items = old[0 : n-1]
+	return item
+}
 
-// Next returns the next unique point from the input iterator.
-func (itr *stringDedupeIterator) Next() (*StringPoint, error) {
-	for {
-		// Read next point.
-		p, err := itr.input.Next()
-		if p == nil || err != nil {
-			return nil, err
-		}
+type booleanMergeHeapItem struct {
+	itr *bufBooleanIterator
+}
 
-		// Serialize to bytes to store in lookup.
-		buf, err := proto.Marshal(encodeStringPoint(p))
-		if err != nil {
-			return nil, err
-		}
+// booleanSortedMergeIterator is an iterator that sorts and merges multiple iterators into one.
+type booleanSortedMergeIterator struct {
+	inputs []BooleanIterator
+	heap   *booleanSortedMergeHeap
+	init   bool
+}
+
+// newBooleanSortedMergeIterator returns an instance of booleanSortedMergeIterator.
+func newBooleanSortedMergeIterator(inputs []BooleanIterator, opt IteratorOptions) Iterator {
+	itr := &booleanSortedMergeIterator{
+		inputs: inputs,
+		heap: &booleanSortedMergeHeap{
+			items: make([]*booleanSortedMergeHeapItem, 0, len(inputs)),
+			opt:   opt,
+		},
+	}
+
+	// Initialize heap items.
+	for _, input := range inputs {
+		// Append to the heap.
+		itr.heap.items = append(itr.heap.items, &booleanSortedMergeHeapItem{itr: input})
+	}
 
-		// If the point has already been output then move to the next point.
-		if _, ok := itr.m[string(buf)]; ok {
-			continue
-		}
+	return itr
+}
 
-		// Otherwise mark it as emitted and return point.
-		itr.m[string(buf)] = struct{}{}
-		return p, nil
+// Stats returns an aggregation of stats from the underlying iterators.
+func (itr *booleanSortedMergeIterator) Stats() IteratorStats {
+	var stats IteratorStats
+	for _, input := range itr.inputs {
+		stats.Add(input.Stats())
 	}
+	return stats
 }
 
-// stringReaderIterator represents an iterator that streams from a reader.
-type stringReaderIterator struct {
-	r   io.Reader
-	dec *StringPointDecoder
+// Close closes the underlying iterators.
+func (itr *booleanSortedMergeIterator) Close() error {
+	for _, input := range itr.inputs {
+		input.Close()
+	}
+	return nil
 }
 
-// newStringReaderIterator returns a new instance of stringReaderIterator.
-func newStringReaderIterator(r io.Reader, stats IteratorStats) *stringReaderIterator {
-	dec := NewStringPointDecoder(r)
-	dec.stats = stats
+// Next returns the next points from the iterator.
+func (itr *booleanSortedMergeIterator) Next() (*BooleanPoint, error) { return itr.pop() }
 
-	return &stringReaderIterator{
-		r:   r,
-		dec: dec,
+// pop returns the next point from the heap.
+// Reads the next point from item's cursor and puts it back on the heap.
+func (itr *booleanSortedMergeIterator) pop() (*BooleanPoint, error) {
+	// Initialize the heap. See the MergeIterator to see why this has to be done lazily.
+	if !itr.init {
+		items := itr.heap.items
+		itr.heap.items = make([]*booleanSortedMergeHeapItem, 0, len(items))
+		for _, item := range items {
+			var err error
+			if item.point, err = item.itr.Next(); err != nil {
+				return nil, err
+			} else if item.point == nil {
+				continue
+			}
+			itr.heap.items = append(itr.heap.items, item)
+		}
+		heap.Init(itr.heap)
+		itr.init = true
 	}
-}
 
-// Stats returns stats about points processed.
-func (itr *stringReaderIterator) Stats() IteratorStats { return itr.dec.stats }
+	if len(itr.heap.items) == 0 {
+		return nil, nil
+	}
 
-// Close closes the underlying reader, if applicable.
-func (itr *stringReaderIterator) Close() error {
-	if r, ok := itr.r.(io.ReadCloser); ok {
-		return r.Close()
+	// Read the next item from the heap.
+	item := heap.Pop(itr.heap).(*booleanSortedMergeHeapItem)
+	if item.err != nil {
+		return nil, item.err
+	} else if item.point == nil {
+		return nil, nil
 	}
-	return nil
-}
 
-// Next returns the next point from the iterator.
-func (itr *stringReaderIterator) Next() (*StringPoint, error) {
-	// OPTIMIZE(benbjohnson): Reuse point on iterator.
+	// Copy the point for return.
+	p := item.point.Clone()
 
-	// Unmarshal next point.
-	p := &StringPoint{}
-	if err := itr.dec.DecodeStringPoint(p); err == io.EOF {
-		return nil, nil
-	} else if err != nil {
-		return nil, err
+	// Read the next item from the cursor. Push back to heap if one exists.
+	if item.point, item.err = item.itr.Next(); item.point != nil {
+		heap.Push(itr.heap, item)
 	}
+
 	return p, nil
 }
 
-// BooleanIterator represents a stream of boolean points.
-type BooleanIterator interface {
-	Iterator
-	Next() (*BooleanPoint, error)
+// booleanSortedMergeHeap represents a heap of booleanSortedMergeHeapItems.
+type booleanSortedMergeHeap struct {
+	opt   IteratorOptions
+	items []*booleanSortedMergeHeapItem
 }
 
-// newBooleanIterators converts a slice of Iterator to a slice of BooleanIterator.
-// Drop and closes any iterator in itrs that is not a BooleanIterator and cannot
-// be cast to a BooleanIterator.
-func newBooleanIterators(itrs []Iterator) []BooleanIterator {
-	a := make([]BooleanIterator, 0, len(itrs))
-	for _, itr := range itrs {
-		switch itr := itr.(type) {
-		case BooleanIterator:
-			a = append(a, itr)
+func (h *booleanSortedMergeHeap) Len() int      { return len(h.items) }
+func (h *booleanSortedMergeHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *booleanSortedMergeHeap) Less(i, j int) bool {
+	x, y := h.items[i].point, h.items[j].point
 
-		default:
-			itr.Close()
+	if h.opt.Ascending {
+		if x.Name != y.Name {
+			return x.Name < y.Name
+		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); !xTags.Equals(&yTags) {
+			return xTags.ID() < yTags.ID()
 		}
+		return x.Time < y.Time
 	}
-	return a
-}
 
-// bufBooleanIterator represents a buffered BooleanIterator.
-type bufBooleanIterator struct {
-	itr BooleanIterator
-	buf *BooleanPoint
+	if x.Name != y.Name {
+		return x.Name > y.Name
+	} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); !xTags.Equals(&yTags) {
+		return xTags.ID() > yTags.ID()
+	}
+	return x.Time > y.Time
 }
 
-// newBufBooleanIterator returns a buffered BooleanIterator.
-func newBufBooleanIterator(itr BooleanIterator) *bufBooleanIterator {
-	return &bufBooleanIterator{itr: itr}
+func (h *booleanSortedMergeHeap) Push(x interface{}) {
+	h.items = append(h.items, x.(*booleanSortedMergeHeapItem))
 }
 
-// Stats returns statistics from the input iterator.
-func (itr *bufBooleanIterator) Stats() IteratorStats { return itr.itr.Stats() }
-
-// Close closes the underlying iterator.
-func (itr *bufBooleanIterator) Close() error { return itr.itr.Close() }
-
-// peek returns the next point without removing it from the iterator.
-func (itr *bufBooleanIterator) peek() (*BooleanPoint, error) {
-	p, err := itr.Next()
-	if err != nil {
-		return nil, err
-	}
-	itr.unread(p)
-	return p, nil
+func (h *booleanSortedMergeHeap) Pop() interface{} {
+	old := h.items
+	n := len(old)
+	item := old[n-1]
+	h.items = old[0 : n-1]
+	return item
 }
 
-// peekTime returns the time of the next point.
-// Returns zero time if no more points available.
-func (itr *bufBooleanIterator) peekTime() (int64, error) {
-	p, err := itr.peek()
-	if p == nil || err != nil {
-		return ZeroTime, err
-	}
-	return p.Time, nil
+type booleanSortedMergeHeapItem struct {
+	point *BooleanPoint
+	err   error
+	itr   BooleanIterator
 }
 
-// Next returns the current buffer, if exists, or calls the underlying iterator.
-func (itr *bufBooleanIterator) Next() (*BooleanPoint, error) {
-	buf := itr.buf
-	if buf != nil {
-		itr.buf = nil
-		return buf, nil
-	}
-	return itr.itr.Next()
+// booleanParallelIterator represents an iterator that pulls data in a separate goroutine.
+type booleanParallelIterator struct {
+	input BooleanIterator
+	ch    chan booleanPointError
+
+	once    sync.Once
+	closing chan struct{}
+	wg      sync.WaitGroup
 }
 
-// NextInWindow returns the next value if it is between [startTime, endTime).
-// If the next value is outside the range then it is moved to the buffer.
-func (itr *bufBooleanIterator) NextInWindow(startTime, endTime int64) (*BooleanPoint, error) {
-	v, err := itr.Next()
-	if v == nil || err != nil {
-		return nil, err
-	} else if t := v.Time; t >= endTime || t < startTime {
-		itr.unread(v)
-		return nil, nil
+// newBooleanParallelIterator returns a new instance of booleanParallelIterator.
+func newBooleanParallelIterator(input BooleanIterator) *booleanParallelIterator {
+	itr := &booleanParallelIterator{
+		input:   input,
+		ch:      make(chan booleanPointError, 256),
+		closing: make(chan struct{}),
 	}
-	return v, nil
+	itr.wg.Add(1)
+	go itr.monitor()
+	return itr
 }
 
-// unread sets v to the buffer. It is read on the next call to Next().
-func (itr *bufBooleanIterator) unread(v *BooleanPoint) { itr.buf = v }
+// Stats returns stats from the underlying iterator.
+func (itr *booleanParallelIterator) Stats() IteratorStats { return itr.input.Stats() }
 
-// booleanMergeIterator represents an iterator that combines multiple boolean iterators.
-type booleanMergeIterator struct {
-	inputs []BooleanIterator
-	heap   *booleanMergeHeap
-	init   bool
+// Close closes the underlying iterators.
+func (itr *booleanParallelIterator) Close() error {
+	itr.once.Do(func() { close(itr.closing) })
+	itr.wg.Wait()
+	return itr.input.Close()
+}
 
-	// Current iterator and window.
-	curr   *booleanMergeHeapItem
-	window struct {
-		name      string
-		tags      string
-		startTime int64
-		endTime   int64
+// Next returns the next point from the iterator.
+func (itr *booleanParallelIterator) Next() (*BooleanPoint, error) {
+	v, ok := <-itr.ch
+	if !ok {
+		return nil, io.EOF
 	}
+	return v.point, v.err
 }
 
-// newBooleanMergeIterator returns a new instance of booleanMergeIterator.
-func newBooleanMergeIterator(inputs []BooleanIterator, opt IteratorOptions) *booleanMergeIterator {
-	itr := &booleanMergeIterator{
-		inputs: inputs,
-		heap: &booleanMergeHeap{
-			items: make([]*booleanMergeHeapItem, 0, len(inputs)),
-			opt:   opt,
-		},
-	}
+// monitor runs in a separate goroutine and actively pulls the next point.
+func (itr *booleanParallelIterator) monitor() {
+	defer close(itr.ch)
+	defer itr.wg.Done()
 
-	// Initialize heap items.
-	for _, input := range inputs {
-		// Wrap in buffer, ignore any inputs without anymore points.
-		bufInput := newBufBooleanIterator(input)
+	for {
+		// Read next point.
+		p, err := itr.input.Next()
+		if p != nil {
+			p = p.Clone()
+		}
 
-		// Append to the heap.
-		itr.heap.items = append(itr.heap.items, &booleanMergeHeapItem{itr: bufInput})
+		select {
+		case <-itr.closing:
+			return
+		case itr.ch <- booleanPointError{point: p, err: err}:
+		}
 	}
-
-	return itr
 }
 
-// Stats returns an aggregation of stats from the underlying iterators.
-func (itr *booleanMergeIterator) Stats() IteratorStats {
-	var stats IteratorStats
-	for _, input := range itr.inputs {
-		stats.Add(input.Stats())
-	}
-	return stats
+type booleanPointError struct {
+	point *BooleanPoint
+	err   error
 }
 
-// Close closes the underlying iterators.
-func (itr *booleanMergeIterator) Close() error {
-	for _, input := range itr.inputs {
-		input.Close()
+// booleanLimitIterator represents an iterator that limits points per group.
+type booleanLimitIterator struct {
+	input BooleanIterator
+	opt   IteratorOptions
+	n     int
+
+	prev struct {
+		name string
+		tags Tags
 	}
-	itr.curr = nil
-	itr.inputs = nil
-	itr.heap.items = nil
-	return nil
 }
 
-// Next returns the next point from the iterator.
-func (itr *booleanMergeIterator) Next() (*BooleanPoint, error) {
-	// Initialize the heap. This needs to be done lazily on the first call to this iterator
-	// so that iterator initialization done through the Select() call returns quickly.
-	// Queries can only be interrupted after the Select() call completes so any operations
-	// done during iterator creation cannot be interrupted, which is why we do it here
-	// instead so an interrupt can happen while initializing the heap.
-	if !itr.init {
-		items := itr.heap.items
-		itr.heap.items = make([]*booleanMergeHeapItem, 0, len(items))
-		for _, item := range items {
-			if p, err := item.itr.peek(); err != nil {
-				return nil, err
-			} else if p == nil {
-				continue
-			}
-			itr.heap.items = append(itr.heap.items, item)
-		}
-		heap.Init(itr.heap)
-		itr.init = true
+// newBooleanLimitIterator returns a new instance of booleanLimitIterator.
+func newBooleanLimitIterator(input BooleanIterator, opt IteratorOptions) *booleanLimitIterator {
+	return &booleanLimitIterator{
+		input: input,
+		opt:   opt,
 	}
+}
 
-	for {
-		// Retrieve the next iterator if we don't have one.
-		if itr.curr == nil {
-			if len(itr.heap.items) == 0 {
-				return nil, nil
-			}
-			itr.curr = heap.Pop(itr.heap).(*booleanMergeHeapItem)
+// Stats returns stats from the underlying iterator.
+func (itr *booleanLimitIterator) Stats() IteratorStats { return itr.input.Stats() }
 
-			// Read point and set current window.
-			p, err := itr.curr.itr.Next()
-			if err != nil {
-				return nil, err
-			}
-			tags := p.Tags.Subset(itr.heap.opt.Dimensions)
-			itr.window.name, itr.window.tags = p.Name, tags.ID()
-			itr.window.startTime, itr.window.endTime = itr.heap.opt.Window(p.Time)
-			return p, nil
-		}
+// Close closes the underlying iterators.
+func (itr *booleanLimitIterator) Close() error { return itr.input.Close() }
 
-		// Read the next point from the current iterator.
-		p, err := itr.curr.itr.Next()
-		if err != nil {
+// Next returns the next point from the iterator.
+func (itr *booleanLimitIterator) Next() (*BooleanPoint, error) {
+	for {
+		p, err := itr.input.Next()
+		if p == nil || err != nil {
 			return nil, err
 		}
 
-		// If there are no more points then remove iterator from heap and find next.
-		if p == nil {
-			itr.curr = nil
-			continue
+		// Reset window and counter if a new window is encountered.
+		if p.Name != itr.prev.name || !p.Tags.Equals(&itr.prev.tags) {
+			itr.prev.name = p.Name
+			itr.prev.tags = p.Tags
+			itr.n = 0
 		}
 
-		// Check if the point is inside of our current window.
-		inWindow := true
-		if window := itr.window; window.name != p.Name {
-			inWindow = false
-		} else if tags := p.Tags.Subset(itr.heap.opt.Dimensions); window.tags != tags.ID() {
-			inWindow = false
-		} else if opt := itr.heap.opt; opt.Ascending && p.Time >= window.endTime {
-			inWindow = false
-		} else if !opt.Ascending && p.Time < window.startTime {
-			inWindow = false
+		// Increment counter.
+		itr.n++
+
+		// Read next point if not beyond the offset.
+		if itr.n <= itr.opt.Offset {
+			continue
 		}
 
-		// If it's outside our window then push iterator back on the heap and find new iterator.
-		if !inWindow {
-			itr.curr.itr.unread(p)
-			heap.Push(itr.heap, itr.curr)
-			itr.curr = nil
+		// Read next point if we're beyond the limit.
+		if itr.opt.Limit > 0 && (itr.n-itr.opt.Offset) > itr.opt.Limit {
 			continue
 		}
 
@@ -8974,763 +13858,862 @@ func (itr *booleanMergeIterator) Next() (*BooleanPoint, error) {
 	}
 }
 
-// booleanMergeHeap represents a heap of booleanMergeHeapItems.
-// Items are sorted by their next window and then by name/tags.
-type booleanMergeHeap struct {
-	opt   IteratorOptions
-	items []*booleanMergeHeapItem
-}
+type booleanFillIterator struct {
+	input     *bufBooleanIterator
+	prev      BooleanPoint
+	startTime int64
+	endTime   int64
+	auxFields []interface{}
+	init      bool
+	opt       IteratorOptions
 
-func (h *booleanMergeHeap) Len() int      { return len(h.items) }
-func (h *booleanMergeHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
-func (h *booleanMergeHeap) Less(i, j int) bool {
-	x, err := h.items[i].itr.peek()
-	if err != nil {
-		return true
-	}
-	y, err := h.items[j].itr.peek()
-	if err != nil {
-		return false
+	window struct {
+		name   string
+		tags   Tags
+		time   int64
+		offset int64
 	}
+}
 
-	if h.opt.Ascending {
-		if x.Name != y.Name {
-			return x.Name < y.Name
-		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); xTags.ID() != yTags.ID() {
-			return xTags.ID() < yTags.ID()
-		}
-	} else {
-		if x.Name != y.Name {
-			return x.Name > y.Name
-		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); xTags.ID() != yTags.ID() {
-			return xTags.ID() > yTags.ID()
+func newBooleanFillIterator(input BooleanIterator, expr Expr, opt IteratorOptions) *booleanFillIterator {
+	if opt.Fill == NullFill {
+		if expr, ok := expr.(*Call); ok && expr.Name == "count" {
+			opt.Fill = NumberFill
+			opt.FillValue = false
 		}
 	}
 
-	xt, _ := h.opt.Window(x.Time)
-	yt, _ := h.opt.Window(y.Time)
-
-	if h.opt.Ascending {
-		return xt < yt
+	var startTime, endTime int64
+	if opt.Ascending {
+		startTime, _ = opt.Window(opt.StartTime)
+		endTime, _ = opt.Window(opt.EndTime)
+	} else {
+		startTime, _ = opt.Window(opt.EndTime)
+		endTime, _ = opt.Window(opt.StartTime)
 	}
-	return xt > yt
-}
 
-func (h *booleanMergeHeap) Push(x interface{}) {
-	h.items = append(h.items, x.(*booleanMergeHeapItem))
-}
-
-func (h *booleanMergeHeap) Pop() interface{} {
-	old := h.items
-	n := len(old)
-	item := old[n-1]
-	h.items = old[0 : n-1]
-	return item
-}
+	var auxFields []interface{}
+	if len(opt.Aux) > 0 {
+		auxFields = make([]interface{}, len(opt.Aux))
+	}
 
-type booleanMergeHeapItem struct {
-	itr *bufBooleanIterator
+	return &booleanFillIterator{
+		input:     newBufBooleanIterator(input),
+		prev:      BooleanPoint{Nil: true},
+		startTime: startTime,
+		endTime:   endTime,
+		auxFields: auxFields,
+		opt:       opt,
+	}
 }
 
-// booleanSortedMergeIterator is an iterator that sorts and merges multiple iterators into one.
-type booleanSortedMergeIterator struct {
-	inputs []BooleanIterator
-	heap   *booleanSortedMergeHeap
-	init   bool
-}
+func (itr *booleanFillIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *booleanFillIterator) Close() error         { return itr.input.Close() }
 
-// newBooleanSortedMergeIterator returns an instance of booleanSortedMergeIterator.
-func newBooleanSortedMergeIterator(inputs []BooleanIterator, opt IteratorOptions) Iterator {
-	itr := &booleanSortedMergeIterator{
-		inputs: inputs,
-		heap: &booleanSortedMergeHeap{
-			items: make([]*booleanSortedMergeHeapItem, 0, len(inputs)),
-			opt:   opt,
-		},
+func (itr *booleanFillIterator) Next() (*BooleanPoint, error) {
+	if !itr.init {
+		p, err := itr.input.peek()
+		if p == nil || err != nil {
+			return nil, err
+		}
+		itr.window.name, itr.window.tags = p.Name, p.Tags
+		itr.window.time = itr.startTime
+		if itr.opt.Location != nil {
+			_, itr.window.offset = itr.opt.Zone(itr.window.time)
+		}
+		itr.init = true
 	}
 
-	// Initialize heap items.
-	for _, input := range inputs {
-		// Append to the heap.
-		itr.heap.items = append(itr.heap.items, &booleanSortedMergeHeapItem{itr: input})
+	p, err := itr.input.Next()
+	if err != nil {
+		return nil, err
 	}
 
-	return itr
-}
+	// Check if the next point is outside of our window or is nil.
+	for p == nil || p.Name != itr.window.name || p.Tags.ID() != itr.window.tags.ID() {
+		// If we are inside of an interval, unread the point and continue below to
+		// constructing a new point.
+		if itr.opt.Ascending {
+			if itr.window.time <= itr.endTime {
+				itr.input.unread(p)
+				p = nil
+				break
+			}
+		} else {
+			if itr.window.time >= itr.endTime {
+				itr.input.unread(p)
+				p = nil
+				break
+			}
+		}
+
+		// We are *not* in a current interval. If there is no next point,
+		// we are at the end of all intervals.
+		if p == nil {
+			return nil, nil
+		}
 
-// Stats returns an aggregation of stats from the underlying iterators.
-func (itr *booleanSortedMergeIterator) Stats() IteratorStats {
-	var stats IteratorStats
-	for _, input := range itr.inputs {
-		stats.Add(input.Stats())
+		// Set the new interval.
+		itr.window.name, itr.window.tags = p.Name, p.Tags
+		itr.window.time = itr.startTime
+		if itr.opt.Location != nil {
+			_, itr.window.offset = itr.opt.Zone(itr.window.time)
+		}
+		itr.prev = BooleanPoint{Nil: true}
+		break
 	}
-	return stats
-}
 
-// Close closes the underlying iterators.
-func (itr *booleanSortedMergeIterator) Close() error {
-	for _, input := range itr.inputs {
-		input.Close()
-	}
-	return nil
-}
+	// Check if the point is our next expected point.
+	if p == nil || (itr.opt.Ascending && p.Time > itr.window.time) || (!itr.opt.Ascending && p.Time < itr.window.time) {
+		if p != nil {
+			itr.input.unread(p)
+		}
 
-// Next returns the next points from the iterator.
-func (itr *booleanSortedMergeIterator) Next() (*BooleanPoint, error) { return itr.pop() }
+		p = &BooleanPoint{
+			Name: itr.window.name,
+			Tags: itr.window.tags,
+			Time: itr.window.time,
+			Aux:  itr.auxFields,
+		}
 
-// pop returns the next point from the heap.
-// Reads the next point from item's cursor and puts it back on the heap.
-func (itr *booleanSortedMergeIterator) pop() (*BooleanPoint, error) {
-	// Initialize the heap. See the MergeIterator to see why this has to be done lazily.
-	if !itr.init {
-		items := itr.heap.items
-		itr.heap.items = make([]*booleanSortedMergeHeapItem, 0, len(items))
-		for _, item := range items {
-			var err error
-			if item.point, err = item.itr.Next(); err != nil {
-				return nil, err
-			} else if item.point == nil {
-				continue
+		switch itr.opt.Fill {
+		case LinearFill:
+			fallthrough
+		case NullFill:
+			p.Nil = true
+		case NumberFill:
+			p.Value = castToBoolean(itr.opt.FillValue)
+		case PreviousFill:
+			if !itr.prev.Nil {
+				p.Value = itr.prev.Value
+				p.Nil = itr.prev.Nil
+			} else {
+				p.Nil = true
 			}
-			itr.heap.items = append(itr.heap.items, item)
 		}
-		heap.Init(itr.heap)
-		itr.init = true
-	}
-
-	if len(itr.heap.items) == 0 {
-		return nil, nil
+	} else {
+		itr.prev = *p
 	}
 
-	// Read the next item from the heap.
-	item := heap.Pop(itr.heap).(*booleanSortedMergeHeapItem)
-	if item.err != nil {
-		return nil, item.err
-	} else if item.point == nil {
-		return nil, nil
+	// Advance the expected time. Do not advance to a new window here
+	// as there may be lingering points with the same timestamp in the previous
+	// window.
+	if itr.opt.Ascending {
+		itr.window.time += int64(itr.opt.Interval.Duration)
+	} else {
+		itr.window.time -= int64(itr.opt.Interval.Duration)
 	}
 
-	// Copy the point for return.
-	p := item.point.Clone()
-
-	// Read the next item from the cursor. Push back to heap if one exists.
-	if item.point, item.err = item.itr.Next(); item.point != nil {
-		heap.Push(itr.heap, item)
+	// Check to see if we have passed over an offset change and adjust the time
+	// to account for this new offset.
+	if itr.opt.Location != nil {
+		if _, offset := itr.opt.Zone(itr.window.time); offset != itr.window.offset {
+			diff := itr.window.offset - offset
+			if abs(diff) < int64(itr.opt.Interval.Duration) {
+				itr.window.time += diff
+			}
+			itr.window.offset = offset
+		}
 	}
-
 	return p, nil
 }
 
-// booleanSortedMergeHeap represents a heap of booleanSortedMergeHeapItems.
-type booleanSortedMergeHeap struct {
+// booleanIntervalIterator represents a boolean implementation of IntervalIterator.
+type booleanIntervalIterator struct {
+	input BooleanIterator
 	opt   IteratorOptions
-	items []*booleanSortedMergeHeapItem
 }
 
-func (h *booleanSortedMergeHeap) Len() int      { return len(h.items) }
-func (h *booleanSortedMergeHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
-func (h *booleanSortedMergeHeap) Less(i, j int) bool {
-	x, y := h.items[i].point, h.items[j].point
+func newBooleanIntervalIterator(input BooleanIterator, opt IteratorOptions) *booleanIntervalIterator {
+	return &booleanIntervalIterator{input: input, opt: opt}
+}
 
-	if h.opt.Ascending {
-		if x.Name != y.Name {
-			return x.Name < y.Name
-		} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); !xTags.Equals(&yTags) {
-			return xTags.ID() < yTags.ID()
-		}
-		return x.Time < y.Time
-	}
+func (itr *booleanIntervalIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *booleanIntervalIterator) Close() error         { return itr.input.Close() }
 
-	if x.Name != y.Name {
-		return x.Name > y.Name
-	} else if xTags, yTags := x.Tags.Subset(h.opt.Dimensions), y.Tags.Subset(h.opt.Dimensions); !xTags.Equals(&yTags) {
-		return xTags.ID() > yTags.ID()
+func (itr *booleanIntervalIterator) Next() (*BooleanPoint, error) {
+	p, err := itr.input.Next()
+	if p == nil || err != nil {
+		return nil, err
 	}
-	return x.Time > y.Time
+	p.Time, _ = itr.opt.Window(p.Time)
+	// If we see the minimum allowable time, set the time to zero so we don't
+	// break the default returned time for aggregate queries without times.
+	if p.Time == MinTime {
+		p.Time = 0
+	}
+	return p, nil
 }
 
-func (h *booleanSortedMergeHeap) Push(x interface{}) {
-	h.items = append(h.items, x.(*booleanSortedMergeHeapItem))
+// booleanInterruptIterator represents a boolean implementation of InterruptIterator.
+type booleanInterruptIterator struct {
+	input   BooleanIterator
+	closing <-chan struct{}
+	count   int
 }
 
-func (h *booleanSortedMergeHeap) Pop() interface{} {
-	old := h.items
-	n := len(old)
-	item := old[n-1]
-	h.items = old[0 : n-1]
-	return item
+func newBooleanInterruptIterator(input BooleanIterator, closing <-chan struct{}) *booleanInterruptIterator {
+	return &booleanInterruptIterator{input: input, closing: closing}
 }
 
-type booleanSortedMergeHeapItem struct {
-	point *BooleanPoint
-	err   error
-	itr   BooleanIterator
-}
+func (itr *booleanInterruptIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *booleanInterruptIterator) Close() error         { return itr.input.Close() }
 
-// booleanParallelIterator represents an iterator that pulls data in a separate goroutine.
-type booleanParallelIterator struct {
-	input BooleanIterator
-	ch    chan booleanPointError
+func (itr *booleanInterruptIterator) Next() (*BooleanPoint, error) {
+	// Only check if the channel is closed every N points. This
+	// intentionally checks on both 0 and N so that if the iterator
+	// has been interrupted before the first point is emitted it will
+	// not emit any points.
+	if itr.count&0xFF == 0xFF {
+		select {
+		case <-itr.closing:
+			return nil, itr.Close()
+		default:
+			// Reset iterator count to zero and fall through to emit the next point.
+			itr.count = 0
+		}
+	}
 
+	// Increment the counter for every point read.
+	itr.count++
+	return itr.input.Next()
+}
+
+// booleanCloseInterruptIterator represents a boolean implementation of CloseInterruptIterator.
+type booleanCloseInterruptIterator struct {
+	input   BooleanIterator
+	closing <-chan struct{}
+	done    chan struct{}
 	once    sync.Once
-	closing chan struct{}
-	wg      sync.WaitGroup
 }
 
-// newBooleanParallelIterator returns a new instance of booleanParallelIterator.
-func newBooleanParallelIterator(input BooleanIterator) *booleanParallelIterator {
-	itr := &booleanParallelIterator{
+func newBooleanCloseInterruptIterator(input BooleanIterator, closing <-chan struct{}) *booleanCloseInterruptIterator {
+	itr := &booleanCloseInterruptIterator{
 		input:   input,
-		ch:      make(chan booleanPointError, 256),
-		closing: make(chan struct{}),
+		closing: closing,
+		done:    make(chan struct{}),
 	}
-	itr.wg.Add(1)
 	go itr.monitor()
 	return itr
 }
 
-// Stats returns stats from the underlying iterator.
-func (itr *booleanParallelIterator) Stats() IteratorStats { return itr.input.Stats() }
-
-// Close closes the underlying iterators.
-func (itr *booleanParallelIterator) Close() error {
-	itr.once.Do(func() { close(itr.closing) })
-	itr.wg.Wait()
-	return itr.input.Close()
-}
-
-// Next returns the next point from the iterator.
-func (itr *booleanParallelIterator) Next() (*BooleanPoint, error) {
-	v, ok := <-itr.ch
-	if !ok {
-		return nil, io.EOF
+func (itr *booleanCloseInterruptIterator) monitor() {
+	select {
+	case <-itr.closing:
+		itr.Close()
+	case <-itr.done:
 	}
-	return v.point, v.err
 }
 
-// monitor runs in a separate goroutine and actively pulls the next point.
-func (itr *booleanParallelIterator) monitor() {
-	defer close(itr.ch)
-	defer itr.wg.Done()
+func (itr *booleanCloseInterruptIterator) Stats() IteratorStats {
+	return itr.input.Stats()
+}
 
-	for {
-		// Read next point.
-		p, err := itr.input.Next()
-		if p != nil {
-			p = p.Clone()
-		}
+func (itr *booleanCloseInterruptIterator) Close() error {
+	itr.once.Do(func() {
+		close(itr.done)
+		itr.input.Close()
+	})
+	return nil
+}
 
+func (itr *booleanCloseInterruptIterator) Next() (*BooleanPoint, error) {
+	p, err := itr.input.Next()
+	if err != nil {
+		// Check if the iterator was closed.
 		select {
-		case <-itr.closing:
-			return
-		case itr.ch <- booleanPointError{point: p, err: err}:
+		case <-itr.done:
+			return nil, nil
+		default:
+			return nil, err
 		}
 	}
+	return p, nil
 }
 
-type booleanPointError struct {
+// auxBooleanPoint represents a combination of a point and an error for the AuxIterator.
+type auxBooleanPoint struct {
 	point *BooleanPoint
 	err   error
 }
 
-// booleanLimitIterator represents an iterator that limits points per group.
-type booleanLimitIterator struct {
-	input BooleanIterator
-	opt   IteratorOptions
-	n     int
-
-	prev struct {
-		name string
-		tags Tags
-	}
+// booleanAuxIterator represents a boolean implementation of AuxIterator.
+type booleanAuxIterator struct {
+	input      *bufBooleanIterator
+	output     chan auxBooleanPoint
+	fields     *auxIteratorFields
+	background bool
 }
 
-// newBooleanLimitIterator returns a new instance of booleanLimitIterator.
-func newBooleanLimitIterator(input BooleanIterator, opt IteratorOptions) *booleanLimitIterator {
-	return &booleanLimitIterator{
-		input: input,
-		opt:   opt,
+func newBooleanAuxIterator(input BooleanIterator, opt IteratorOptions) *booleanAuxIterator {
+	return &booleanAuxIterator{
+		input:  newBufBooleanIterator(input),
+		output: make(chan auxBooleanPoint, 1),
+		fields: newAuxIteratorFields(opt),
 	}
 }
 
-// Stats returns stats from the underlying iterator.
-func (itr *booleanLimitIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *booleanAuxIterator) Background() {
+	itr.background = true
+	itr.Start()
+	go DrainIterator(itr)
+}
 
-// Close closes the underlying iterators.
-func (itr *booleanLimitIterator) Close() error { return itr.input.Close() }
+func (itr *booleanAuxIterator) Start()               { go itr.stream() }
+func (itr *booleanAuxIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *booleanAuxIterator) Close() error         { return itr.input.Close() }
+func (itr *booleanAuxIterator) Next() (*BooleanPoint, error) {
+	p := <-itr.output
+	return p.point, p.err
+}
+func (itr *booleanAuxIterator) Iterator(name string, typ DataType) Iterator {
+	return itr.fields.iterator(name, typ)
+}
 
-// Next returns the next point from the iterator.
-func (itr *booleanLimitIterator) Next() (*BooleanPoint, error) {
+func (itr *booleanAuxIterator) stream() {
 	for {
+		// Read next point.
 		p, err := itr.input.Next()
-		if p == nil || err != nil {
-			return nil, err
-		}
-
-		// Reset window and counter if a new window is encountered.
-		if p.Name != itr.prev.name || !p.Tags.Equals(&itr.prev.tags) {
-			itr.prev.name = p.Name
-			itr.prev.tags = p.Tags
-			itr.n = 0
-		}
-
-		// Increment counter.
-		itr.n++
-
-		// Read next point if not beyond the offset.
-		if itr.n <= itr.opt.Offset {
-			continue
+		if err != nil {
+			itr.output <- auxBooleanPoint{err: err}
+			itr.fields.sendError(err)
+			break
+		} else if p == nil {
+			break
 		}
 
-		// Read next point if we're beyond the limit.
-		if itr.opt.Limit > 0 && (itr.n-itr.opt.Offset) > itr.opt.Limit {
-			continue
+		// Send point to output and to each field iterator.
+		itr.output <- auxBooleanPoint{point: p}
+		if ok := itr.fields.send(p); !ok && itr.background {
+			break
 		}
-
-		return p, nil
 	}
-}
-
-type booleanFillIterator struct {
-	input     *bufBooleanIterator
-	prev      BooleanPoint
-	startTime int64
-	endTime   int64
-	auxFields []interface{}
-	init      bool
-	opt       IteratorOptions
 
-	window struct {
-		name   string
-		tags   Tags
-		time   int64
-		offset int64
-	}
+	close(itr.output)
+	itr.fields.close()
 }
 
-func newBooleanFillIterator(input BooleanIterator, expr Expr, opt IteratorOptions) *booleanFillIterator {
-	if opt.Fill == NullFill {
-		if expr, ok := expr.(*Call); ok && expr.Name == "count" {
-			opt.Fill = NumberFill
-			opt.FillValue = false
-		}
-	}
-
-	var startTime, endTime int64
-	if opt.Ascending {
-		startTime, _ = opt.Window(opt.StartTime)
-		endTime, _ = opt.Window(opt.EndTime)
-	} else {
-		startTime, _ = opt.Window(opt.EndTime)
-		endTime, _ = opt.Window(opt.StartTime)
+// booleanChanIterator represents a new instance of booleanChanIterator.
+type booleanChanIterator struct {
+	buf struct {
+		i      int
+		filled bool
+		points [2]BooleanPoint
 	}
+	err  error
+	cond *sync.Cond
+	done bool
+}
 
-	var auxFields []interface{}
-	if len(opt.Aux) > 0 {
-		auxFields = make([]interface{}, len(opt.Aux))
-	}
+func (itr *booleanChanIterator) Stats() IteratorStats { return IteratorStats{} }
 
-	return &booleanFillIterator{
-		input:     newBufBooleanIterator(input),
-		prev:      BooleanPoint{Nil: true},
-		startTime: startTime,
-		endTime:   endTime,
-		auxFields: auxFields,
-		opt:       opt,
-	}
+func (itr *booleanChanIterator) Close() error {
+	itr.cond.L.Lock()
+	// Mark the channel iterator as done and signal all waiting goroutines to start again.
+	itr.done = true
+	itr.cond.Broadcast()
+	// Do not defer the unlock so we don't create an unnecessary allocation.
+	itr.cond.L.Unlock()
+	return nil
 }
 
-func (itr *booleanFillIterator) Stats() IteratorStats { return itr.input.Stats() }
-func (itr *booleanFillIterator) Close() error         { return itr.input.Close() }
+func (itr *booleanChanIterator) setBuf(name string, tags Tags, time int64, value interface{}) bool {
+	itr.cond.L.Lock()
+	defer itr.cond.L.Unlock()
 
-func (itr *booleanFillIterator) Next() (*BooleanPoint, error) {
-	if !itr.init {
-		p, err := itr.input.peek()
-		if p == nil || err != nil {
-			return nil, err
-		}
-		itr.window.name, itr.window.tags = p.Name, p.Tags
-		itr.window.time = itr.startTime
-		if itr.opt.Location != nil {
-			_, itr.window.offset = itr.opt.Zone(itr.window.time)
-		}
-		itr.init = true
+	// Wait for either the iterator to be done (so we don't have to set the value)
+	// or for the buffer to have been read and ready for another write.
+	for !itr.done && itr.buf.filled {
+		itr.cond.Wait()
 	}
 
-	p, err := itr.input.Next()
-	if err != nil {
-		return nil, err
+	// Do not set the value and return false to signal that the iterator is closed.
+	// Do this after the above wait as the above for loop may have exited because
+	// the iterator was closed.
+	if itr.done {
+		return false
 	}
 
-	// Check if the next point is outside of our window or is nil.
-	for p == nil || p.Name != itr.window.name || p.Tags.ID() != itr.window.tags.ID() {
-		// If we are inside of an interval, unread the point and continue below to
-		// constructing a new point.
-		if itr.opt.Ascending {
-			if itr.window.time <= itr.endTime {
-				itr.input.unread(p)
-				p = nil
-				break
-			}
-		} else {
-			if itr.window.time >= itr.endTime {
-				itr.input.unread(p)
-				p = nil
-				break
-			}
-		}
-
-		// We are *not* in a current interval. If there is no next point,
-		// we are at the end of all intervals.
-		if p == nil {
-			return nil, nil
-		}
+	switch v := value.(type) {
+	case bool:
+		itr.buf.points[itr.buf.i] = BooleanPoint{Name: name, Tags: tags, Time: time, Value: v}
 
-		// Set the new interval.
-		itr.window.name, itr.window.tags = p.Name, p.Tags
-		itr.window.time = itr.startTime
-		if itr.opt.Location != nil {
-			_, itr.window.offset = itr.opt.Zone(itr.window.time)
-		}
-		itr.prev = BooleanPoint{Nil: true}
-		break
+	default:
+		itr.buf.points[itr.buf.i] = BooleanPoint{Name: name, Tags: tags, Time: time, Nil: true}
 	}
+	itr.buf.filled = true
 
-	// Check if the point is our next expected point.
-	if p == nil || (itr.opt.Ascending && p.Time > itr.window.time) || (!itr.opt.Ascending && p.Time < itr.window.time) {
-		if p != nil {
-			itr.input.unread(p)
-		}
+	// Signal to all waiting goroutines that a new value is ready to read.
+	itr.cond.Signal()
+	return true
+}
 
-		p = &BooleanPoint{
-			Name: itr.window.name,
-			Tags: itr.window.tags,
-			Time: itr.window.time,
-			Aux:  itr.auxFields,
-		}
+func (itr *booleanChanIterator) setErr(err error) {
+	itr.cond.L.Lock()
+	defer itr.cond.L.Unlock()
+	itr.err = err
 
-		switch itr.opt.Fill {
-		case LinearFill:
-			fallthrough
-		case NullFill:
-			p.Nil = true
-		case NumberFill:
-			p.Value = castToBoolean(itr.opt.FillValue)
-		case PreviousFill:
-			if !itr.prev.Nil {
-				p.Value = itr.prev.Value
-				p.Nil = itr.prev.Nil
-			} else {
-				p.Nil = true
-			}
-		}
-	} else {
-		itr.prev = *p
+	// Signal to all waiting goroutines that a new value is ready to read.
+	itr.cond.Signal()
+}
+
+func (itr *booleanChanIterator) Next() (*BooleanPoint, error) {
+	itr.cond.L.Lock()
+	defer itr.cond.L.Unlock()
+
+	// Check for an error and return one if there.
+	if itr.err != nil {
+		return nil, itr.err
 	}
 
-	// Advance the expected time. Do not advance to a new window here
-	// as there may be lingering points with the same timestamp in the previous
-	// window.
-	if itr.opt.Ascending {
-		itr.window.time += int64(itr.opt.Interval.Duration)
-	} else {
-		itr.window.time -= int64(itr.opt.Interval.Duration)
+	// Wait until either a value is available in the buffer or
+	// the iterator is closed.
+	for !itr.done && !itr.buf.filled {
+		itr.cond.Wait()
 	}
 
-	// Check to see if we have passed over an offset change and adjust the time
-	// to account for this new offset.
-	if itr.opt.Location != nil {
-		if _, offset := itr.opt.Zone(itr.window.time); offset != itr.window.offset {
-			diff := itr.window.offset - offset
-			if abs(diff) < int64(itr.opt.Interval.Duration) {
-				itr.window.time += diff
-			}
-			itr.window.offset = offset
-		}
+	// Return nil once the channel is done and the buffer is empty.
+	if itr.done && !itr.buf.filled {
+		return nil, nil
 	}
+
+	// Always read from the buffer if it exists, even if the iterator
+	// is closed. This prevents the last value from being truncated by
+	// the parent iterator.
+	p := &itr.buf.points[itr.buf.i]
+	itr.buf.i = (itr.buf.i + 1) % len(itr.buf.points)
+	itr.buf.filled = false
+	itr.cond.Signal()
 	return p, nil
 }
 
-// booleanIntervalIterator represents a boolean implementation of IntervalIterator.
-type booleanIntervalIterator struct {
-	input BooleanIterator
-	opt   IteratorOptions
+// booleanReduceFloatIterator executes a reducer for every interval and buffers the result.
+type booleanReduceFloatIterator struct {
+	input  *bufBooleanIterator
+	create func() (BooleanPointAggregator, FloatPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	points []FloatPoint
 }
 
-func newBooleanIntervalIterator(input BooleanIterator, opt IteratorOptions) *booleanIntervalIterator {
-	return &booleanIntervalIterator{input: input, opt: opt}
+func newBooleanReduceFloatIterator(input BooleanIterator, opt IteratorOptions, createFn func() (BooleanPointAggregator, FloatPointEmitter)) *booleanReduceFloatIterator {
+	return &booleanReduceFloatIterator{
+		input:  newBufBooleanIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+	}
 }
 
-func (itr *booleanIntervalIterator) Stats() IteratorStats { return itr.input.Stats() }
-func (itr *booleanIntervalIterator) Close() error         { return itr.input.Close() }
+// Stats returns stats from the input iterator.
+func (itr *booleanReduceFloatIterator) Stats() IteratorStats { return itr.input.Stats() }
 
-func (itr *booleanIntervalIterator) Next() (*BooleanPoint, error) {
-	p, err := itr.input.Next()
-	if p == nil || err != nil {
-		return nil, err
-	}
-	p.Time, _ = itr.opt.Window(p.Time)
-	// If we see the minimum allowable time, set the time to zero so we don't
-	// break the default returned time for aggregate queries without times.
-	if p.Time == MinTime {
-		p.Time = 0
+// Close closes the iterator and all child iterators.
+func (itr *booleanReduceFloatIterator) Close() error { return itr.input.Close() }
+
+// Next returns the minimum value for the next available interval.
+func (itr *booleanReduceFloatIterator) Next() (*FloatPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
+			return nil, err
+		}
 	}
+
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
 	return p, nil
 }
 
-// booleanInterruptIterator represents a boolean implementation of InterruptIterator.
-type booleanInterruptIterator struct {
-	input   BooleanIterator
-	closing <-chan struct{}
-	count   int
+// booleanReduceFloatPoint stores the reduced data for a name/tag combination.
+type booleanReduceFloatPoint struct {
+	Name       string
+	Tags       Tags
+	Aggregator BooleanPointAggregator
+	Emitter    FloatPointEmitter
 }
 
-func newBooleanInterruptIterator(input BooleanIterator, closing <-chan struct{}) *booleanInterruptIterator {
-	return &booleanInterruptIterator{input: input, closing: closing}
-}
+// reduce executes fn once for every point in the next window.
+// The previous value for the dimension is passed to fn.
+func (itr *booleanReduceFloatIterator) reduce() ([]FloatPoint, error) {
+	// Calculate next window.
+	var (
+		startTime, endTime int64
+		window             struct {
+			name string
+			tags string
+		}
+	)
+	for {
+		p, err := itr.input.Next()
+		if err != nil || p == nil {
+			return nil, err
+		} else if p.Nil {
+			continue
+		}
 
-func (itr *booleanInterruptIterator) Stats() IteratorStats { return itr.input.Stats() }
-func (itr *booleanInterruptIterator) Close() error         { return itr.input.Close() }
+		// Unread the point so it can be processed.
+		itr.input.unread(p)
+		startTime, endTime = itr.opt.Window(p.Time)
+		window.name, window.tags = p.Name, p.Tags.Subset(itr.opt.Dimensions).ID()
+		break
+	}
 
-func (itr *booleanInterruptIterator) Next() (*BooleanPoint, error) {
-	// Only check if the channel is closed every N points. This
-	// intentionally checks on both 0 and N so that if the iterator
-	// has been interrupted before the first point is emitted it will
-	// not emit any points.
-	if itr.count&0xFF == 0xFF {
-		select {
-		case <-itr.closing:
-			return nil, itr.Close()
-		default:
-			// Reset iterator count to zero and fall through to emit the next point.
-			itr.count = 0
+	// Create points by tags.
+	m := make(map[string]*booleanReduceFloatPoint)
+	for {
+		// Read next point.
+		curr, err := itr.input.NextInWindow(startTime, endTime)
+		if err != nil {
+			return nil, err
+		} else if curr == nil {
+			break
+		} else if curr.Nil {
+			continue
+		} else if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		}
+
+		// Ensure this point is within the same final window.
+		if curr.Name != window.name {
+			itr.input.unread(curr)
+			break
+		} else if tags := curr.Tags.Subset(itr.opt.Dimensions); tags.ID() != window.tags {
+			itr.input.unread(curr)
+			break
+		}
+
+		// Retrieve the tags on this point for this level of the query.
+		// This may be different than the bucket dimensions.
+		tags := curr.Tags.Subset(itr.dims)
+		id := tags.ID()
+
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &booleanReduceFloatPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			m[id] = rp
 		}
+		rp.Aggregator.AggregateBoolean(curr)
 	}
 
-	// Increment the counter for every point read.
-	itr.count++
-	return itr.input.Next()
-}
+	// Reverse sort points by name & tag if our output is supposed to be ordered.
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	if len(keys) > 1 && itr.opt.Ordered {
+		sort.Sort(reverseStringSlice(keys))
+	}
 
-// booleanCloseInterruptIterator represents a boolean implementation of CloseInterruptIterator.
-type booleanCloseInterruptIterator struct {
-	input   BooleanIterator
-	closing <-chan struct{}
-	done    chan struct{}
-	once    sync.Once
-}
+	// Assume the points are already sorted until proven otherwise.
+	sortedByTime := true
+	// Emit the points for each name & tag combination.
+	a := make([]FloatPoint, 0, len(m))
+	for _, k := range keys {
+		rp := m[k]
+		points := rp.Emitter.Emit()
+		for i := len(points) - 1; i >= 0; i-- {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
+			// Set the points time to the interval time if the reducer didn't provide one.
+			if points[i].Time == ZeroTime {
+				points[i].Time = startTime
+			} else {
+				sortedByTime = false
+			}
+			a = append(a, points[i])
+		}
+	}
 
-func newBooleanCloseInterruptIterator(input BooleanIterator, closing <-chan struct{}) *booleanCloseInterruptIterator {
-	itr := &booleanCloseInterruptIterator{
-		input:   input,
-		closing: closing,
-		done:    make(chan struct{}),
+	// Points may be out of order. Perform a stable sort by time if requested.
+	if !sortedByTime && itr.opt.Ordered {
+		sort.Stable(sort.Reverse(floatPointsByTime(a)))
 	}
-	go itr.monitor()
-	return itr
+
+	return a, nil
 }
 
-func (itr *booleanCloseInterruptIterator) monitor() {
-	select {
-	case <-itr.closing:
-		itr.Close()
-	case <-itr.done:
+// booleanStreamFloatIterator streams inputs into the iterator and emits points gradually.
+type booleanStreamFloatIterator struct {
+	input  *bufBooleanIterator
+	create func() (BooleanPointAggregator, FloatPointEmitter)
+	dims   []string
+	opt    IteratorOptions
+	m      map[string]*booleanReduceFloatPoint
+	points []FloatPoint
+}
+
+// newBooleanStreamFloatIterator returns a new instance of booleanStreamFloatIterator.
+func newBooleanStreamFloatIterator(input BooleanIterator, createFn func() (BooleanPointAggregator, FloatPointEmitter), opt IteratorOptions) *booleanStreamFloatIterator {
+	return &booleanStreamFloatIterator{
+		input:  newBufBooleanIterator(input),
+		create: createFn,
+		dims:   opt.GetDimensions(),
+		opt:    opt,
+		m:      make(map[string]*booleanReduceFloatPoint),
 	}
 }
 
-func (itr *booleanCloseInterruptIterator) Stats() IteratorStats {
-	return itr.input.Stats()
-}
+// Stats returns stats from the input iterator.
+func (itr *booleanStreamFloatIterator) Stats() IteratorStats { return itr.input.Stats() }
 
-func (itr *booleanCloseInterruptIterator) Close() error {
-	itr.once.Do(func() {
-		close(itr.done)
-		itr.input.Close()
-	})
-	return nil
-}
+// Close closes the iterator and all child iterators.
+func (itr *booleanStreamFloatIterator) Close() error { return itr.input.Close() }
 
-func (itr *booleanCloseInterruptIterator) Next() (*BooleanPoint, error) {
-	p, err := itr.input.Next()
-	if err != nil {
-		// Check if the iterator was closed.
-		select {
-		case <-itr.done:
-			return nil, nil
-		default:
+// Next returns the next value for the stream iterator.
+func (itr *booleanStreamFloatIterator) Next() (*FloatPoint, error) {
+	// Calculate next window if we have no more points.
+	if len(itr.points) == 0 {
+		var err error
+		itr.points, err = itr.reduce()
+		if len(itr.points) == 0 {
 			return nil, err
 		}
 	}
+
+	// Pop next point off the stack.
+	p := &itr.points[len(itr.points)-1]
+	itr.points = itr.points[:len(itr.points)-1]
 	return p, nil
 }
 
-// auxBooleanPoint represents a combination of a point and an error for the AuxIterator.
-type auxBooleanPoint struct {
-	point *BooleanPoint
-	err   error
-}
+// reduce creates and manages aggregators for every point from the input.
+// After aggregating a point, it always tries to emit a value using the emitter.
+func (itr *booleanStreamFloatIterator) reduce() ([]FloatPoint, error) {
+	for {
+		// Read next point.
+		curr, err := itr.input.Next()
+		if curr == nil {
+			// Close all of the aggregators to flush any remaining points to emit.
+			var points []FloatPoint
+			for _, rp := range itr.m {
+				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
+					if err := aggregator.Close(); err != nil {
+						return nil, err
+					}
 
-// booleanAuxIterator represents a boolean implementation of AuxIterator.
-type booleanAuxIterator struct {
-	input      *bufBooleanIterator
-	output     chan auxBooleanPoint
-	fields     *auxIteratorFields
-	background bool
-}
+					pts := rp.Emitter.Emit()
+					if len(pts) == 0 {
+						continue
+					}
 
-func newBooleanAuxIterator(input BooleanIterator, opt IteratorOptions) *booleanAuxIterator {
-	return &booleanAuxIterator{
-		input:  newBufBooleanIterator(input),
-		output: make(chan auxBooleanPoint, 1),
-		fields: newAuxIteratorFields(opt),
-	}
-}
+					for i := range pts {
+						pts[i].Name = rp.Name
+						pts[i].Tags = rp.Tags
+					}
+					points = append(points, pts...)
+				}
+			}
 
-func (itr *booleanAuxIterator) Background() {
-	itr.background = true
-	itr.Start()
-	go DrainIterator(itr)
-}
+			// Eliminate the aggregators and emitters.
+			itr.m = nil
+			return points, nil
+		} else if err != nil {
+			return nil, err
+		} else if curr.Nil {
+			continue
+		}
+		tags := curr.Tags.Subset(itr.dims)
 
-func (itr *booleanAuxIterator) Start()               { go itr.stream() }
-func (itr *booleanAuxIterator) Stats() IteratorStats { return itr.input.Stats() }
-func (itr *booleanAuxIterator) Close() error         { return itr.input.Close() }
-func (itr *booleanAuxIterator) Next() (*BooleanPoint, error) {
-	p := <-itr.output
-	return p.point, p.err
-}
-func (itr *booleanAuxIterator) Iterator(name string, typ DataType) Iterator {
-	return itr.fields.iterator(name, typ)
-}
+		id := curr.Name
+		if len(tags.m) > 0 {
+			id += "\x00" + tags.ID()
+		}
 
-func (itr *booleanAuxIterator) stream() {
-	for {
-		// Read next point.
-		p, err := itr.input.Next()
-		if err != nil {
-			itr.output <- auxBooleanPoint{err: err}
-			itr.fields.sendError(err)
-			break
-		} else if p == nil {
-			break
+		// Retrieve the aggregator for this name/tag combination or create one.
+		rp := itr.m[id]
+		if rp == nil {
+			aggregator, emitter := itr.create()
+			rp = &booleanReduceFloatPoint{
+				Name:       curr.Name,
+				Tags:       tags,
+				Aggregator: aggregator,
+				Emitter:    emitter,
+			}
+			itr.m[id] = rp
+		}
+		rp.Aggregator.AggregateBoolean(curr)
+
+		// Attempt to emit points from the aggregator.
+		points := rp.Emitter.Emit()
+		if len(points) == 0 {
+			continue
 		}
 
-		// Send point to output and to each field iterator.
-		itr.output <- auxBooleanPoint{point: p}
-		if ok := itr.fields.send(p); !ok && itr.background {
-			break
+		for i := range points {
+			points[i].Name = rp.Name
+			points[i].Tags = rp.Tags
 		}
+		return points, nil
 	}
+}
 
-	close(itr.output)
-	itr.fields.close()
+// booleanFloatExprIterator executes a function to modify an existing point
+// for every output of the input iterator.
+type booleanFloatExprIterator struct {
+	left      *bufBooleanIterator
+	right     *bufBooleanIterator
+	fn        booleanFloatExprFunc
+	points    []BooleanPoint // must be size 2
+	storePrev bool
 }
 
-// booleanChanIterator represents a new instance of booleanChanIterator.
-type booleanChanIterator struct {
-	buf struct {
-		i      int
-		filled bool
-		points [2]BooleanPoint
+func newBooleanFloatExprIterator(left, right BooleanIterator, opt IteratorOptions, fn func(a, b bool) float64) *booleanFloatExprIterator {
+	var points []BooleanPoint
+	switch opt.Fill {
+	case NullFill, PreviousFill:
+		points = []BooleanPoint{{Nil: true}, {Nil: true}}
+	case NumberFill:
+		value := castToBoolean(opt.FillValue)
+		points = []BooleanPoint{{Value: value}, {Value: value}}
+	}
+	return &booleanFloatExprIterator{
+		left:      newBufBooleanIterator(left),
+		right:     newBufBooleanIterator(right),
+		points:    points,
+		fn:        fn,
+		storePrev: opt.Fill == PreviousFill,
 	}
-	err  error
-	cond *sync.Cond
-	done bool
 }
 
-func (itr *booleanChanIterator) Stats() IteratorStats { return IteratorStats{} }
+func (itr *booleanFloatExprIterator) Stats() IteratorStats {
+	stats := itr.left.Stats()
+	stats.Add(itr.right.Stats())
+	return stats
+}
 
-func (itr *booleanChanIterator) Close() error {
-	itr.cond.L.Lock()
-	// Mark the channel iterator as done and signal all waiting goroutines to start again.
-	itr.done = true
-	itr.cond.Broadcast()
-	// Do not defer the unlock so we don't create an unnecessary allocation.
-	itr.cond.L.Unlock()
+func (itr *booleanFloatExprIterator) Close() error {
+	itr.left.Close()
+	itr.right.Close()
 	return nil
 }
 
-func (itr *booleanChanIterator) setBuf(name string, tags Tags, time int64, value interface{}) bool {
-	itr.cond.L.Lock()
-	defer itr.cond.L.Unlock()
-
-	// Wait for either the iterator to be done (so we don't have to set the value)
-	// or for the buffer to have been read and ready for another write.
-	for !itr.done && itr.buf.filled {
-		itr.cond.Wait()
-	}
-
-	// Do not set the value and return false to signal that the iterator is closed.
-	// Do this after the above wait as the above for loop may have exited because
-	// the iterator was closed.
-	if itr.done {
-		return false
-	}
-
-	switch v := value.(type) {
-	case bool:
-		itr.buf.points[itr.buf.i] = BooleanPoint{Name: name, Tags: tags, Time: time, Value: v}
+func (itr *booleanFloatExprIterator) Next() (*FloatPoint, error) {
+	for {
+		a, b, err := itr.next()
+		if err != nil || (a == nil && b == nil) {
+			return nil, err
+		}
 
-	default:
-		itr.buf.points[itr.buf.i] = BooleanPoint{Name: name, Tags: tags, Time: time, Nil: true}
-	}
-	itr.buf.filled = true
+		// If any of these are nil and we are using fill(none), skip these points.
+		if (a == nil || a.Nil || b == nil || b.Nil) && itr.points == nil {
+			continue
+		}
 
-	// Signal to all waiting goroutines that a new value is ready to read.
-	itr.cond.Signal()
-	return true
-}
+		// If one of the two points is nil, we need to fill it with a fake nil
+		// point that has the same name, tags, and time as the other point.
+		// There should never be a time when both of these are nil.
+		if a == nil {
+			p := *b
+			a = &p
+			a.Value = false
+			a.Nil = true
+		} else if b == nil {
+			p := *a
+			b = &p
+			b.Value = false
+			b.Nil = true
+		}
 
-func (itr *booleanChanIterator) setErr(err error) {
-	itr.cond.L.Lock()
-	defer itr.cond.L.Unlock()
-	itr.err = err
+		// If a value is nil, use the fill values if the fill value is non-nil.
+		if a.Nil && !itr.points[0].Nil {
+			a.Value = itr.points[0].Value
+			a.Nil = false
+		}
+		if b.Nil && !itr.points[1].Nil {
+			b.Value = itr.points[1].Value
+			b.Nil = false
+		}
 
-	// Signal to all waiting goroutines that a new value is ready to read.
-	itr.cond.Signal()
-}
+		if itr.storePrev {
+			itr.points[0], itr.points[1] = *a, *b
+		}
 
-func (itr *booleanChanIterator) Next() (*BooleanPoint, error) {
-	itr.cond.L.Lock()
-	defer itr.cond.L.Unlock()
+		p := &FloatPoint{
+			Name:       a.Name,
+			Tags:       a.Tags,
+			Time:       a.Time,
+			Nil:        a.Nil || b.Nil,
+			Aggregated: a.Aggregated,
+		}
+		if !p.Nil {
+			p.Value = itr.fn(a.Value, b.Value)
+		}
+		return p, nil
 
-	// Check for an error and return one if there.
-	if itr.err != nil {
-		return nil, itr.err
 	}
+}
 
-	// Wait until either a value is available in the buffer or
-	// the iterator is closed.
-	for !itr.done && !itr.buf.filled {
-		itr.cond.Wait()
+// next returns the next points within each iterator. If the iterators are
+// uneven, it organizes them so only matching points are returned.
+func (itr *booleanFloatExprIterator) next() (a, b *BooleanPoint, err error) {
+	// Retrieve the next value for both the left and right.
+	a, err = itr.left.Next()
+	if err != nil {
+		return nil, nil, err
 	}
-
-	// Return nil once the channel is done and the buffer is empty.
-	if itr.done && !itr.buf.filled {
-		return nil, nil
+	b, err = itr.right.Next()
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Always read from the buffer if it exists, even if the iterator
-	// is closed. This prevents the last value from being truncated by
-	// the parent iterator.
-	p := &itr.buf.points[itr.buf.i]
-	itr.buf.i = (itr.buf.i + 1) % len(itr.buf.points)
-	itr.buf.filled = false
-	itr.cond.Signal()
-	return p, nil
+	// If we have a point from both, make sure that they match each other.
+	if a != nil && b != nil {
+		if a.Name > b.Name {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Name < b.Name {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+
+		if ltags, rtags := a.Tags.ID(), b.Tags.ID(); ltags > rtags {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if ltags < rtags {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+
+		if a.Time > b.Time {
+			itr.left.unread(a)
+			return nil, b, nil
+		} else if a.Time < b.Time {
+			itr.right.unread(b)
+			return a, nil, nil
+		}
+	}
+	return a, b, nil
 }
 
-// booleanReduceFloatIterator executes a reducer for every interval and buffers the result.
-type booleanReduceFloatIterator struct {
+// booleanFloatExprFunc creates or modifies a point by combining two
+// points. The point passed in may be modified and returned rather than
+// allocating a new point if possible. One of the points may be nil, but at
+// least one of the points will be non-nil.
+type booleanFloatExprFunc func(a, b bool) float64
+
+// booleanReduceIntegerIterator executes a reducer for every interval and buffers the result.
+type booleanReduceIntegerIterator struct {
 	input  *bufBooleanIterator
-	create func() (BooleanPointAggregator, FloatPointEmitter)
+	create func() (BooleanPointAggregator, IntegerPointEmitter)
 	dims   []string
 	opt    IteratorOptions
-	points []FloatPoint
+	points []IntegerPoint
 }
 
-func newBooleanReduceFloatIterator(input BooleanIterator, opt IteratorOptions, createFn func() (BooleanPointAggregator, FloatPointEmitter)) *booleanReduceFloatIterator {
-	return &booleanReduceFloatIterator{
+func newBooleanReduceIntegerIterator(input BooleanIterator, opt IteratorOptions, createFn func() (BooleanPointAggregator, IntegerPointEmitter)) *booleanReduceIntegerIterator {
+	return &booleanReduceIntegerIterator{
 		input:  newBufBooleanIterator(input),
 		create: createFn,
 		dims:   opt.GetDimensions(),
@@ -9739,13 +14722,13 @@ func newBooleanReduceFloatIterator(input BooleanIterator, opt IteratorOptions, c
 }
 
 // Stats returns stats from the input iterator.
-func (itr *booleanReduceFloatIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *booleanReduceIntegerIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *booleanReduceFloatIterator) Close() error { return itr.input.Close() }
+func (itr *booleanReduceIntegerIterator) Close() error { return itr.input.Close() }
 
 // Next returns the minimum value for the next available interval.
-func (itr *booleanReduceFloatIterator) Next() (*FloatPoint, error) {
+func (itr *booleanReduceIntegerIterator) Next() (*IntegerPoint, error) {
 	// Calculate next window if we have no more points.
 	if len(itr.points) == 0 {
 		var err error
@@ -9761,17 +14744,17 @@ func (itr *booleanReduceFloatIterator) Next() (*FloatPoint, error) {
 	return p, nil
 }
 
-// booleanReduceFloatPoint stores the reduced data for a name/tag combination.
-type booleanReduceFloatPoint struct {
+// booleanReduceIntegerPoint stores the reduced data for a name/tag combination.
+type booleanReduceIntegerPoint struct {
 	Name       string
 	Tags       Tags
 	Aggregator BooleanPointAggregator
-	Emitter    FloatPointEmitter
+	Emitter    IntegerPointEmitter
 }
 
 // reduce executes fn once for every point in the next window.
 // The previous value for the dimension is passed to fn.
-func (itr *booleanReduceFloatIterator) reduce() ([]FloatPoint, error) {
+func (itr *booleanReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
 	// Calculate next window.
 	var (
 		startTime, endTime int64
@@ -9796,7 +14779,7 @@ func (itr *booleanReduceFloatIterator) reduce() ([]FloatPoint, error) {
 	}
 
 	// Create points by tags.
-	m := make(map[string]*booleanReduceFloatPoint)
+	m := make(map[string]*booleanReduceIntegerPoint)
 	for {
 		// Read next point.
 		curr, err := itr.input.NextInWindow(startTime, endTime)
@@ -9829,7 +14812,7 @@ func (itr *booleanReduceFloatIterator) reduce() ([]FloatPoint, error) {
 		rp := m[id]
 		if rp == nil {
 			aggregator, emitter := itr.create()
-			rp = &booleanReduceFloatPoint{
+			rp = &booleanReduceIntegerPoint{
 				Name:       curr.Name,
 				Tags:       tags,
 				Aggregator: aggregator,
@@ -9852,7 +14835,7 @@ func (itr *booleanReduceFloatIterator) reduce() ([]FloatPoint, error) {
 	// Assume the points are already sorted until proven otherwise.
 	sortedByTime := true
 	// Emit the points for each name & tag combination.
-	a := make([]FloatPoint, 0, len(m))
+	a := make([]IntegerPoint, 0, len(m))
 	for _, k := range keys {
 		rp := m[k]
 		points := rp.Emitter.Emit()
@@ -9871,41 +14854,41 @@ func (itr *booleanReduceFloatIterator) reduce() ([]FloatPoint, error) {
 
 	// Points may be out of order. Perform a stable sort by time if requested.
 	if !sortedByTime && itr.opt.Ordered {
-		sort.Stable(sort.Reverse(floatPointsByTime(a)))
+		sort.Stable(sort.Reverse(integerPointsByTime(a)))
 	}
 
 	return a, nil
 }
 
-// booleanStreamFloatIterator streams inputs into the iterator and emits points gradually.
-type booleanStreamFloatIterator struct {
+// booleanStreamIntegerIterator streams inputs into the iterator and emits points gradually.
+type booleanStreamIntegerIterator struct {
 	input  *bufBooleanIterator
-	create func() (BooleanPointAggregator, FloatPointEmitter)
+	create func() (BooleanPointAggregator, IntegerPointEmitter)
 	dims   []string
 	opt    IteratorOptions
-	m      map[string]*booleanReduceFloatPoint
-	points []FloatPoint
+	m      map[string]*booleanReduceIntegerPoint
+	points []IntegerPoint
 }
 
-// newBooleanStreamFloatIterator returns a new instance of booleanStreamFloatIterator.
-func newBooleanStreamFloatIterator(input BooleanIterator, createFn func() (BooleanPointAggregator, FloatPointEmitter), opt IteratorOptions) *booleanStreamFloatIterator {
-	return &booleanStreamFloatIterator{
+// newBooleanStreamIntegerIterator returns a new instance of booleanStreamIntegerIterator.
+func newBooleanStreamIntegerIterator(input BooleanIterator, createFn func() (BooleanPointAggregator, IntegerPointEmitter), opt IteratorOptions) *booleanStreamIntegerIterator {
+	return &booleanStreamIntegerIterator{
 		input:  newBufBooleanIterator(input),
 		create: createFn,
 		dims:   opt.GetDimensions(),
 		opt:    opt,
-		m:      make(map[string]*booleanReduceFloatPoint),
+		m:      make(map[string]*booleanReduceIntegerPoint),
 	}
 }
 
 // Stats returns stats from the input iterator.
-func (itr *booleanStreamFloatIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *booleanStreamIntegerIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *booleanStreamFloatIterator) Close() error { return itr.input.Close() }
+func (itr *booleanStreamIntegerIterator) Close() error { return itr.input.Close() }
 
 // Next returns the next value for the stream iterator.
-func (itr *booleanStreamFloatIterator) Next() (*FloatPoint, error) {
+func (itr *booleanStreamIntegerIterator) Next() (*IntegerPoint, error) {
 	// Calculate next window if we have no more points.
 	if len(itr.points) == 0 {
 		var err error
@@ -9923,13 +14906,13 @@ func (itr *booleanStreamFloatIterator) Next() (*FloatPoint, error) {
 
 // reduce creates and manages aggregators for every point from the input.
 // After aggregating a point, it always tries to emit a value using the emitter.
-func (itr *booleanStreamFloatIterator) reduce() ([]FloatPoint, error) {
+func (itr *booleanStreamIntegerIterator) reduce() ([]IntegerPoint, error) {
 	for {
 		// Read next point.
 		curr, err := itr.input.Next()
 		if curr == nil {
 			// Close all of the aggregators to flush any remaining points to emit.
-			var points []FloatPoint
+			var points []IntegerPoint
 			for _, rp := range itr.m {
 				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
 					if err := aggregator.Close(); err != nil {
@@ -9968,7 +14951,7 @@ func (itr *booleanStreamFloatIterator) reduce() ([]FloatPoint, error) {
 		rp := itr.m[id]
 		if rp == nil {
 			aggregator, emitter := itr.create()
-			rp = &booleanReduceFloatPoint{
+			rp = &booleanReduceIntegerPoint{
 				Name:       curr.Name,
 				Tags:       tags,
 				Aggregator: aggregator,
@@ -9992,17 +14975,17 @@ func (itr *booleanStreamFloatIterator) reduce() ([]FloatPoint, error) {
 	}
 }
 
-// booleanFloatExprIterator executes a function to modify an existing point
+// booleanIntegerExprIterator executes a function to modify an existing point
 // for every output of the input iterator.
-type booleanFloatExprIterator struct {
+type booleanIntegerExprIterator struct {
 	left      *bufBooleanIterator
 	right     *bufBooleanIterator
-	fn        booleanFloatExprFunc
+	fn        booleanIntegerExprFunc
 	points    []BooleanPoint // must be size 2
 	storePrev bool
 }
 
-func newBooleanFloatExprIterator(left, right BooleanIterator, opt IteratorOptions, fn func(a, b bool) float64) *booleanFloatExprIterator {
+func newBooleanIntegerExprIterator(left, right BooleanIterator, opt IteratorOptions, fn func(a, b bool) int64) *booleanIntegerExprIterator {
 	var points []BooleanPoint
 	switch opt.Fill {
 	case NullFill, PreviousFill:
@@ -10011,7 +14994,7 @@ func newBooleanFloatExprIterator(left, right BooleanIterator, opt IteratorOption
 		value := castToBoolean(opt.FillValue)
 		points = []BooleanPoint{{Value: value}, {Value: value}}
 	}
-	return &booleanFloatExprIterator{
+	return &booleanIntegerExprIterator{
 		left:      newBufBooleanIterator(left),
 		right:     newBufBooleanIterator(right),
 		points:    points,
@@ -10020,19 +15003,19 @@ func newBooleanFloatExprIterator(left, right BooleanIterator, opt IteratorOption
 	}
 }
 
-func (itr *booleanFloatExprIterator) Stats() IteratorStats {
+func (itr *booleanIntegerExprIterator) Stats() IteratorStats {
 	stats := itr.left.Stats()
 	stats.Add(itr.right.Stats())
 	return stats
 }
 
-func (itr *booleanFloatExprIterator) Close() error {
+func (itr *booleanIntegerExprIterator) Close() error {
 	itr.left.Close()
 	itr.right.Close()
 	return nil
 }
 
-func (itr *booleanFloatExprIterator) Next() (*FloatPoint, error) {
+func (itr *booleanIntegerExprIterator) Next() (*IntegerPoint, error) {
 	for {
 		a, b, err := itr.next()
 		if err != nil || (a == nil && b == nil) {
@@ -10073,7 +15056,7 @@ func (itr *booleanFloatExprIterator) Next() (*FloatPoint, error) {
 			itr.points[0], itr.points[1] = *a, *b
 		}
 
-		p := &FloatPoint{
+		p := &IntegerPoint{
 			Name:       a.Name,
 			Tags:       a.Tags,
 			Time:       a.Time,
@@ -10090,7 +15073,7 @@ func (itr *booleanFloatExprIterator) Next() (*FloatPoint, error) {
 
 // next returns the next points within each iterator. If the iterators are
 // uneven, it organizes them so only matching points are returned.
-func (itr *booleanFloatExprIterator) next() (a, b *BooleanPoint, err error) {
+func (itr *booleanIntegerExprIterator) next() (a, b *BooleanPoint, err error) {
 	// Retrieve the next value for both the left and right.
 	a, err = itr.left.Next()
 	if err != nil {
@@ -10130,23 +15113,23 @@ func (itr *booleanFloatExprIterator) next() (a, b *BooleanPoint, err error) {
 	return a, b, nil
 }
 
-// booleanFloatExprFunc creates or modifies a point by combining two
+// booleanIntegerExprFunc creates or modifies a point by combining two
 // points. The point passed in may be modified and returned rather than
 // allocating a new point if possible. One of the points may be nil, but at
 // least one of the points will be non-nil.
-type booleanFloatExprFunc func(a, b bool) float64
+type booleanIntegerExprFunc func(a, b bool) int64
 
-// booleanReduceIntegerIterator executes a reducer for every interval and buffers the result.
-type booleanReduceIntegerIterator struct {
+// booleanReduceUnsignedIterator executes a reducer for every interval and buffers the result.
+type booleanReduceUnsignedIterator struct {
 	input  *bufBooleanIterator
-	create func() (BooleanPointAggregator, IntegerPointEmitter)
+	create func() (BooleanPointAggregator, UnsignedPointEmitter)
 	dims   []string
 	opt    IteratorOptions
-	points []IntegerPoint
+	points []UnsignedPoint
 }
 
-func newBooleanReduceIntegerIterator(input BooleanIterator, opt IteratorOptions, createFn func() (BooleanPointAggregator, IntegerPointEmitter)) *booleanReduceIntegerIterator {
-	return &booleanReduceIntegerIterator{
+func newBooleanReduceUnsignedIterator(input BooleanIterator, opt IteratorOptions, createFn func() (BooleanPointAggregator, UnsignedPointEmitter)) *booleanReduceUnsignedIterator {
+	return &booleanReduceUnsignedIterator{
 		input:  newBufBooleanIterator(input),
 		create: createFn,
 		dims:   opt.GetDimensions(),
@@ -10155,13 +15138,13 @@ func newBooleanReduceIntegerIterator(input BooleanIterator, opt IteratorOptions,
 }
 
 // Stats returns stats from the input iterator.
-func (itr *booleanReduceIntegerIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *booleanReduceUnsignedIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *booleanReduceIntegerIterator) Close() error { return itr.input.Close() }
+func (itr *booleanReduceUnsignedIterator) Close() error { return itr.input.Close() }
 
 // Next returns the minimum value for the next available interval.
-func (itr *booleanReduceIntegerIterator) Next() (*IntegerPoint, error) {
+func (itr *booleanReduceUnsignedIterator) Next() (*UnsignedPoint, error) {
 	// Calculate next window if we have no more points.
 	if len(itr.points) == 0 {
 		var err error
@@ -10177,17 +15160,17 @@ func (itr *booleanReduceIntegerIterator) Next() (*IntegerPoint, error) {
 	return p, nil
 }
 
-// booleanReduceIntegerPoint stores the reduced data for a name/tag combination.
-type booleanReduceIntegerPoint struct {
+// booleanReduceUnsignedPoint stores the reduced data for a name/tag combination.
+type booleanReduceUnsignedPoint struct {
 	Name       string
 	Tags       Tags
 	Aggregator BooleanPointAggregator
-	Emitter    IntegerPointEmitter
+	Emitter    UnsignedPointEmitter
 }
 
 // reduce executes fn once for every point in the next window.
 // The previous value for the dimension is passed to fn.
-func (itr *booleanReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
+func (itr *booleanReduceUnsignedIterator) reduce() ([]UnsignedPoint, error) {
 	// Calculate next window.
 	var (
 		startTime, endTime int64
@@ -10212,7 +15195,7 @@ func (itr *booleanReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
 	}
 
 	// Create points by tags.
-	m := make(map[string]*booleanReduceIntegerPoint)
+	m := make(map[string]*booleanReduceUnsignedPoint)
 	for {
 		// Read next point.
 		curr, err := itr.input.NextInWindow(startTime, endTime)
@@ -10245,7 +15228,7 @@ func (itr *booleanReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
 		rp := m[id]
 		if rp == nil {
 			aggregator, emitter := itr.create()
-			rp = &booleanReduceIntegerPoint{
+			rp = &booleanReduceUnsignedPoint{
 				Name:       curr.Name,
 				Tags:       tags,
 				Aggregator: aggregator,
@@ -10268,7 +15251,7 @@ func (itr *booleanReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
 	// Assume the points are already sorted until proven otherwise.
 	sortedByTime := true
 	// Emit the points for each name & tag combination.
-	a := make([]IntegerPoint, 0, len(m))
+	a := make([]UnsignedPoint, 0, len(m))
 	for _, k := range keys {
 		rp := m[k]
 		points := rp.Emitter.Emit()
@@ -10287,41 +15270,41 @@ func (itr *booleanReduceIntegerIterator) reduce() ([]IntegerPoint, error) {
 
 	// Points may be out of order. Perform a stable sort by time if requested.
 	if !sortedByTime && itr.opt.Ordered {
-		sort.Stable(sort.Reverse(integerPointsByTime(a)))
+		sort.Stable(sort.Reverse(unsignedPointsByTime(a)))
 	}
 
 	return a, nil
 }
 
-// booleanStreamIntegerIterator streams inputs into the iterator and emits points gradually.
-type booleanStreamIntegerIterator struct {
+// booleanStreamUnsignedIterator streams inputs into the iterator and emits points gradually.
+type booleanStreamUnsignedIterator struct {
 	input  *bufBooleanIterator
-	create func() (BooleanPointAggregator, IntegerPointEmitter)
+	create func() (BooleanPointAggregator, UnsignedPointEmitter)
 	dims   []string
 	opt    IteratorOptions
-	m      map[string]*booleanReduceIntegerPoint
-	points []IntegerPoint
+	m      map[string]*booleanReduceUnsignedPoint
+	points []UnsignedPoint
 }
 
-// newBooleanStreamIntegerIterator returns a new instance of booleanStreamIntegerIterator.
-func newBooleanStreamIntegerIterator(input BooleanIterator, createFn func() (BooleanPointAggregator, IntegerPointEmitter), opt IteratorOptions) *booleanStreamIntegerIterator {
-	return &booleanStreamIntegerIterator{
+// newBooleanStreamUnsignedIterator returns a new instance of booleanStreamUnsignedIterator.
+func newBooleanStreamUnsignedIterator(input BooleanIterator, createFn func() (BooleanPointAggregator, UnsignedPointEmitter), opt IteratorOptions) *booleanStreamUnsignedIterator {
+	return &booleanStreamUnsignedIterator{
 		input:  newBufBooleanIterator(input),
 		create: createFn,
 		dims:   opt.GetDimensions(),
 		opt:    opt,
-		m:      make(map[string]*booleanReduceIntegerPoint),
+		m:      make(map[string]*booleanReduceUnsignedPoint),
 	}
 }
 
 // Stats returns stats from the input iterator.
-func (itr *booleanStreamIntegerIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *booleanStreamUnsignedIterator) Stats() IteratorStats { return itr.input.Stats() }
 
 // Close closes the iterator and all child iterators.
-func (itr *booleanStreamIntegerIterator) Close() error { return itr.input.Close() }
+func (itr *booleanStreamUnsignedIterator) Close() error { return itr.input.Close() }
 
 // Next returns the next value for the stream iterator.
-func (itr *booleanStreamIntegerIterator) Next() (*IntegerPoint, error) {
+func (itr *booleanStreamUnsignedIterator) Next() (*UnsignedPoint, error) {
 	// Calculate next window if we have no more points.
 	if len(itr.points) == 0 {
 		var err error
@@ -10339,13 +15322,13 @@ func (itr *booleanStreamIntegerIterator) Next() (*IntegerPoint, error) {
 
 // reduce creates and manages aggregators for every point from the input.
 // After aggregating a point, it always tries to emit a value using the emitter.
-func (itr *booleanStreamIntegerIterator) reduce() ([]IntegerPoint, error) {
+func (itr *booleanStreamUnsignedIterator) reduce() ([]UnsignedPoint, error) {
 	for {
 		// Read next point.
 		curr, err := itr.input.Next()
 		if curr == nil {
 			// Close all of the aggregators to flush any remaining points to emit.
-			var points []IntegerPoint
+			var points []UnsignedPoint
 			for _, rp := range itr.m {
 				if aggregator, ok := rp.Aggregator.(io.Closer); ok {
 					if err := aggregator.Close(); err != nil {
@@ -10384,7 +15367,7 @@ func (itr *booleanStreamIntegerIterator) reduce() ([]IntegerPoint, error) {
 		rp := itr.m[id]
 		if rp == nil {
 			aggregator, emitter := itr.create()
-			rp = &booleanReduceIntegerPoint{
+			rp = &booleanReduceUnsignedPoint{
 				Name:       curr.Name,
 				Tags:       tags,
 				Aggregator: aggregator,
@@ -10408,17 +15391,17 @@ func (itr *booleanStreamIntegerIterator) reduce() ([]IntegerPoint, error) {
 	}
 }
 
-// booleanIntegerExprIterator executes a function to modify an existing point
+// booleanUnsignedExprIterator executes a function to modify an existing point
 // for every output of the input iterator.
-type booleanIntegerExprIterator struct {
+type booleanUnsignedExprIterator struct {
 	left      *bufBooleanIterator
 	right     *bufBooleanIterator
-	fn        booleanIntegerExprFunc
+	fn        booleanUnsignedExprFunc
 	points    []BooleanPoint // must be size 2
 	storePrev bool
 }
 
-func newBooleanIntegerExprIterator(left, right BooleanIterator, opt IteratorOptions, fn func(a, b bool) int64) *booleanIntegerExprIterator {
+func newBooleanUnsignedExprIterator(left, right BooleanIterator, opt IteratorOptions, fn func(a, b bool) uint64) *booleanUnsignedExprIterator {
 	var points []BooleanPoint
 	switch opt.Fill {
 	case NullFill, PreviousFill:
@@ -10427,7 +15410,7 @@ func newBooleanIntegerExprIterator(left, right BooleanIterator, opt IteratorOpti
 		value := castToBoolean(opt.FillValue)
 		points = []BooleanPoint{{Value: value}, {Value: value}}
 	}
-	return &booleanIntegerExprIterator{
+	return &booleanUnsignedExprIterator{
 		left:      newBufBooleanIterator(left),
 		right:     newBufBooleanIterator(right),
 		points:    points,
@@ -10436,19 +15419,19 @@ func newBooleanIntegerExprIterator(left, right BooleanIterator, opt IteratorOpti
 	}
 }
 
-func (itr *booleanIntegerExprIterator) Stats() IteratorStats {
+func (itr *booleanUnsignedExprIterator) Stats() IteratorStats {
 	stats := itr.left.Stats()
 	stats.Add(itr.right.Stats())
 	return stats
 }
 
-func (itr *booleanIntegerExprIterator) Close() error {
+func (itr *booleanUnsignedExprIterator) Close() error {
 	itr.left.Close()
 	itr.right.Close()
 	return nil
 }
 
-func (itr *booleanIntegerExprIterator) Next() (*IntegerPoint, error) {
+func (itr *booleanUnsignedExprIterator) Next() (*UnsignedPoint, error) {
 	for {
 		a, b, err := itr.next()
 		if err != nil || (a == nil && b == nil) {
@@ -10489,7 +15472,7 @@ func (itr *booleanIntegerExprIterator) Next() (*IntegerPoint, error) {
 			itr.points[0], itr.points[1] = *a, *b
 		}
 
-		p := &IntegerPoint{
+		p := &UnsignedPoint{
 			Name:       a.Name,
 			Tags:       a.Tags,
 			Time:       a.Time,
@@ -10506,7 +15489,7 @@ func (itr *booleanIntegerExprIterator) Next() (*IntegerPoint, error) {
 
 // next returns the next points within each iterator. If the iterators are
 // uneven, it organizes them so only matching points are returned.
-func (itr *booleanIntegerExprIterator) next() (a, b *BooleanPoint, err error) {
+func (itr *booleanUnsignedExprIterator) next() (a, b *BooleanPoint, err error) {
 	// Retrieve the next value for both the left and right.
 	a, err = itr.left.Next()
 	if err != nil {
@@ -10546,11 +15529,11 @@ func (itr *booleanIntegerExprIterator) next() (a, b *BooleanPoint, err error) {
 	return a, b, nil
 }
 
-// booleanIntegerExprFunc creates or modifies a point by combining two
+// booleanUnsignedExprFunc creates or modifies a point by combining two
 // points. The point passed in may be modified and returned rather than
 // allocating a new point if possible. One of the points may be nil, but at
 // least one of the points will be non-nil.
-type booleanIntegerExprFunc func(a, b bool) int64
+type booleanUnsignedExprFunc func(a, b bool) uint64
 
 // booleanReduceStringIterator executes a reducer for every interval and buffers the result.
 type booleanReduceStringIterator struct {
@@ -11771,6 +16754,49 @@ func (enc *IteratorEncoder) encodeIntegerIterator(itr IntegerIterator) error {
 	return nil
 }
 
+// encodeUnsignedIterator encodes all points from itr to the underlying writer.
+func (enc *IteratorEncoder) encodeUnsignedIterator(itr UnsignedIterator) error {
+	ticker := time.NewTicker(enc.StatsInterval)
+	defer ticker.Stop()
+
+	// Emit initial stats.
+	if err := enc.encodeStats(itr.Stats()); err != nil {
+		return err
+	}
+
+	// Continually stream points from the iterator into the encoder.
+	penc := NewUnsignedPointEncoder(enc.w)
+	for {
+		// Emit stats periodically.
+		select {
+		case <-ticker.C:
+			if err := enc.encodeStats(itr.Stats()); err != nil {
+				return err
+			}
+		default:
+		}
+
+		// Retrieve the next point from the iterator.
+		p, err := itr.Next()
+		if err != nil {
+			return err
+		} else if p == nil {
+			break
+		}
+
+		// Write the point to the point encoder.
+		if err := penc.EncodeUnsignedPoint(p); err != nil {
+			return err
+		}
+	}
+
+	// Emit final stats.
+	if err := enc.encodeStats(itr.Stats()); err != nil {
+		return err
+	}
+	return nil
+}
+
 // encodeStringIterator encodes all points from itr to the underlying writer.
 func (enc *IteratorEncoder) encodeStringIterator(itr StringIterator) error {
 	ticker := time.NewTicker(enc.StatsInterval)
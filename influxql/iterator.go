@@ -72,7 +72,7 @@ func (a Iterators) filterNonNil() []Iterator {
 
 // castType determines what type to cast the set of iterators to.
 // An iterator type is chosen using this hierarchy:
-//   float > integer > string > boolean
+//   float > integer > unsigned > string > boolean
 func (a Iterators) castType() DataType {
 	if len(a) == 0 {
 		return Unknown
@@ -85,11 +85,15 @@ func (a Iterators) castType() DataType {
 			// Once a float iterator is found, short circuit the end.
 			return Float
 		case IntegerIterator:
-			if typ > Integer {
+			if typ.LessThan(Integer) {
 				typ = Integer
 			}
+		case UnsignedIterator:
+			if typ.LessThan(Unsigned) {
+				typ = Unsigned
+			}
 		case StringIterator:
-			if typ > String {
+			if typ.LessThan(String) {
 				typ = String
 			}
 		case BooleanIterator:
@@ -109,6 +113,8 @@ func (a Iterators) cast() interface{} {
 		return newFloatIterators(a)
 	case Integer:
 		return newIntegerIterators(a)
+	case Unsigned:
+		return newUnsignedIterators(a)
 	case String:
 		return newStringIterators(a)
 	case Boolean:
@@ -177,6 +183,8 @@ func NewMergeIterator(inputs []Iterator, opt IteratorOptions) Iterator {
 		return newFloatMergeIterator(inputs, opt)
 	case []IntegerIterator:
 		return newIntegerMergeIterator(inputs, opt)
+	case []UnsignedIterator:
+		return newUnsignedMergeIterator(inputs, opt)
 	case []StringIterator:
 		return newStringMergeIterator(inputs, opt)
 	case []BooleanIterator:
@@ -239,6 +247,8 @@ func NewSortedMergeIterator(inputs []Iterator, opt IteratorOptions) Iterator {
 		return newFloatSortedMergeIterator(inputs, opt)
 	case []IntegerIterator:
 		return newIntegerSortedMergeIterator(inputs, opt)
+	case []UnsignedIterator:
+		return newUnsignedSortedMergeIterator(inputs, opt)
 	case []StringIterator:
 		return newStringSortedMergeIterator(inputs, opt)
 	case []BooleanIterator:
@@ -259,6 +269,8 @@ func newParallelIterator(input Iterator) Iterator {
 		return newFloatParallelIterator(itr)
 	case IntegerIterator:
 		return newIntegerParallelIterator(itr)
+	case UnsignedIterator:
+		return newUnsignedParallelIterator(itr)
 	case StringIterator:
 		return newStringParallelIterator(itr)
 	case BooleanIterator:
@@ -275,6 +287,8 @@ func NewLimitIterator(input Iterator, opt IteratorOptions) Iterator {
 		return newFloatLimitIterator(input, opt)
 	case IntegerIterator:
 		return newIntegerLimitIterator(input, opt)
+	case UnsignedIterator:
+		return newUnsignedLimitIterator(input, opt)
 	case StringIterator:
 		return newStringLimitIterator(input, opt)
 	case BooleanIterator:
@@ -297,6 +311,8 @@ func NewFilterIterator(input Iterator, cond Expr, opt IteratorOptions) Iterator
 		return newFloatFilterIterator(input, cond, opt)
 	case IntegerIterator:
 		return newIntegerFilterIterator(input, cond, opt)
+	case UnsignedIterator:
+		return newUnsignedFilterIterator(input, cond, opt)
 	case StringIterator:
 		return newStringFilterIterator(input, cond, opt)
 	case BooleanIterator:
@@ -319,6 +335,8 @@ func NewDedupeIterator(input Iterator) Iterator {
 		return newFloatDedupeIterator(input)
 	case IntegerIterator:
 		return newIntegerDedupeIterator(input)
+	case UnsignedIterator:
+		return newUnsignedDedupeIterator(input)
 	case StringIterator:
 		return newStringDedupeIterator(input)
 	case BooleanIterator:
@@ -335,6 +353,8 @@ func NewFillIterator(input Iterator, expr Expr, opt IteratorOptions) Iterator {
 		return newFloatFillIterator(input, expr, opt)
 	case IntegerIterator:
 		return newIntegerFillIterator(input, expr, opt)
+	case UnsignedIterator:
+		return newUnsignedFillIterator(input, expr, opt)
 	case StringIterator:
 		return newStringFillIterator(input, expr, opt)
 	case BooleanIterator:
@@ -351,6 +371,8 @@ func NewIntervalIterator(input Iterator, opt IteratorOptions) Iterator {
 		return newFloatIntervalIterator(input, opt)
 	case IntegerIterator:
 		return newIntegerIntervalIterator(input, opt)
+	case UnsignedIterator:
+		return newUnsignedIntervalIterator(input, opt)
 	case StringIterator:
 		return newStringIntervalIterator(input, opt)
 	case BooleanIterator:
@@ -368,6 +390,8 @@ func NewInterruptIterator(input Iterator, closing <-chan struct{}) Iterator {
 		return newFloatInterruptIterator(input, closing)
 	case IntegerIterator:
 		return newIntegerInterruptIterator(input, closing)
+	case UnsignedIterator:
+		return newUnsignedInterruptIterator(input, closing)
 	case StringIterator:
 		return newStringInterruptIterator(input, closing)
 	case BooleanIterator:
@@ -385,6 +409,8 @@ func NewCloseInterruptIterator(input Iterator, closing <-chan struct{}) Iterator
 		return newFloatCloseInterruptIterator(input, closing)
 	case IntegerIterator:
 		return newIntegerCloseInterruptIterator(input, closing)
+	case UnsignedIterator:
+		return newUnsignedCloseInterruptIterator(input, closing)
 	case StringIterator:
 		return newStringCloseInterruptIterator(input, closing)
 	case BooleanIterator:
@@ -416,6 +442,8 @@ func NewAuxIterator(input Iterator, opt IteratorOptions) AuxIterator {
 		return newFloatAuxIterator(input, opt)
 	case IntegerIterator:
 		return newIntegerAuxIterator(input, opt)
+	case UnsignedIterator:
+		return newUnsignedAuxIterator(input, opt)
 	case StringIterator:
 		return newStringAuxIterator(input, opt)
 	case BooleanIterator:
@@ -490,6 +518,10 @@ func (a *auxIteratorFields) iterator(name string, typ DataType) Iterator {
 			itr := &integerChanIterator{cond: sync.NewCond(&sync.Mutex{})}
 			f.append(itr)
 			return itr
+		case Unsigned:
+			itr := &unsignedChanIterator{cond: sync.NewCond(&sync.Mutex{})}
+			f.append(itr)
+			return itr
 		case String, Tag:
 			itr := &stringChanIterator{cond: sync.NewCond(&sync.Mutex{})}
 			f.append(itr)
@@ -526,6 +558,8 @@ func (a *auxIteratorFields) send(p Point) (ok bool) {
 				ok = itr.setBuf(p.name(), tags, p.time(), v) || ok
 			case *integerChanIterator:
 				ok = itr.setBuf(p.name(), tags, p.time(), v) || ok
+			case *unsignedChanIterator:
+				ok = itr.setBuf(p.name(), tags, p.time(), v) || ok
 			case *stringChanIterator:
 				ok = itr.setBuf(p.name(), tags, p.time(), v) || ok
 			case *booleanChanIterator:
@@ -546,6 +580,8 @@ func (a *auxIteratorFields) sendError(err error) {
 				itr.setErr(err)
 			case *integerChanIterator:
 				itr.setErr(err)
+			case *unsignedChanIterator:
+				itr.setErr(err)
 			case *stringChanIterator:
 				itr.setErr(err)
 			case *booleanChanIterator:
@@ -567,6 +603,9 @@ func DrainIterator(itr Iterator) {
 	case IntegerIterator:
 		for p, _ := itr.Next(); p != nil; p, _ = itr.Next() {
 		}
+	case UnsignedIterator:
+		for p, _ := itr.Next(); p != nil; p, _ = itr.Next() {
+		}
 	case StringIterator:
 		for p, _ := itr.Next(); p != nil; p, _ = itr.Next() {
 		}
@@ -594,6 +633,10 @@ func DrainIterators(itrs []Iterator) {
 				if p, _ := itr.Next(); p != nil {
 					hasData = true
 				}
+			case UnsignedIterator:
+				if p, _ := itr.Next(); p != nil {
+					hasData = true
+				}
 			case StringIterator:
 				if p, _ := itr.Next(); p != nil {
 					hasData = true
@@ -621,6 +664,8 @@ func NewReaderIterator(r io.Reader, typ DataType, stats IteratorStats) Iterator
 		return newFloatReaderIterator(r, stats)
 	case Integer:
 		return newIntegerReaderIterator(r, stats)
+	case Unsigned:
+		return newUnsignedReaderIterator(r, stats)
 	case String:
 		return newStringReaderIterator(r, stats)
 	case Boolean:
@@ -1248,6 +1293,35 @@ func (itr *integerFloatTransformIterator) Next() (*FloatPoint, error) {
 // new point if possible.
 type integerFloatTransformFunc func(p *IntegerPoint) *FloatPoint
 
+// unsignedFloatTransformIterator executes a function to modify an existing point for every
+// output of the input iterator.
+type unsignedFloatTransformIterator struct {
+	input UnsignedIterator
+	fn    unsignedFloatTransformFunc
+}
+
+// Stats returns stats from the input iterator.
+func (itr *unsignedFloatTransformIterator) Stats() IteratorStats { return itr.input.Stats() }
+
+// Close closes the iterator and all child iterators.
+func (itr *unsignedFloatTransformIterator) Close() error { return itr.input.Close() }
+
+// Next returns the minimum value for the next available interval.
+func (itr *unsignedFloatTransformIterator) Next() (*FloatPoint, error) {
+	p, err := itr.input.Next()
+	if err != nil {
+		return nil, err
+	} else if p != nil {
+		return itr.fn(p), nil
+	}
+	return nil, nil
+}
+
+// unsignedFloatTransformFunc creates or modifies a point.
+// The point passed in may be modified and returned rather than allocating a
+// new point if possible.
+type unsignedFloatTransformFunc func(p *UnsignedPoint) *FloatPoint
+
 type integerFloatCastIterator struct {
 	input IntegerIterator
 }
@@ -1270,6 +1344,28 @@ func (itr *integerFloatCastIterator) Next() (*FloatPoint, error) {
 	}, nil
 }
 
+type unsignedFloatCastIterator struct {
+	input UnsignedIterator
+}
+
+func (itr *unsignedFloatCastIterator) Stats() IteratorStats { return itr.input.Stats() }
+func (itr *unsignedFloatCastIterator) Close() error         { return itr.input.Close() }
+func (itr *unsignedFloatCastIterator) Next() (*FloatPoint, error) {
+	p, err := itr.input.Next()
+	if p == nil || err != nil {
+		return nil, err
+	}
+
+	return &FloatPoint{
+		Name:  p.Name,
+		Tags:  p.Tags,
+		Time:  p.Time,
+		Nil:   p.Nil,
+		Value: float64(p.Value),
+		Aux:   p.Aux,
+	}, nil
+}
+
 // IteratorStats represents statistics about an iterator.
 // Some statistics are available immediately upon iterator creation while
 // some are derived as the iterator processes data.
@@ -27,6 +27,8 @@ func NewIteratorMapper(itrs []Iterator, driver IteratorMap, fields []IteratorMap
 				return newFloatIteratorMapper(itrs, driver, fields, opt)
 			case IntegerIterator:
 				return newIntegerIteratorMapper(itrs, driver, fields, opt)
+			case UnsignedIterator:
+				return newUnsignedIteratorMapper(itrs, driver, fields, opt)
 			case StringIterator:
 				return newStringIteratorMapper(itrs, driver, fields, opt)
 			case BooleanIterator:
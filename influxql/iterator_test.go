@@ -958,6 +958,12 @@ func (itrs Iterators) Next() ([]influxql.Point, error) {
 				return nil, err
 			}
 			a[i] = ip
+		case influxql.UnsignedIterator:
+			ip, err := itr.Next()
+			if ip == nil || err != nil {
+				return nil, err
+			}
+			a[i] = ip
 		case influxql.StringIterator:
 			sp, err := itr.Next()
 			if sp == nil || err != nil {
@@ -1403,6 +1409,35 @@ func IntegerIterators(inputs []*IntegerIterator) []influxql.Iterator {
 	return itrs
 }
 
+// Test implementation of influxql.UnsignedIterator
+type UnsignedIterator struct {
+	Points []influxql.UnsignedPoint
+	Closed bool
+	stats  influxql.IteratorStats
+}
+
+func (itr *UnsignedIterator) Stats() influxql.IteratorStats { return itr.stats }
+func (itr *UnsignedIterator) Close() error                  { itr.Closed = true; return nil }
+
+// Next returns the next value and shifts it off the beginning of the points slice.
+func (itr *UnsignedIterator) Next() (*influxql.UnsignedPoint, error) {
+	if len(itr.Points) == 0 || itr.Closed {
+		return nil, nil
+	}
+
+	v := &itr.Points[0]
+	itr.Points = itr.Points[1:]
+	return v, nil
+}
+
+func UnsignedIterators(inputs []*UnsignedIterator) []influxql.Iterator {
+	itrs := make([]influxql.Iterator, len(inputs))
+	for i := range itrs {
+		itrs[i] = influxql.Iterator(inputs[i])
+	}
+	return itrs
+}
+
 // Test implementation of influxql.StringIterator
 type StringIterator struct {
 	Points []influxql.StringPoint
@@ -116,8 +116,12 @@ func (p *Parser) ParseStatement() (Statement, error) {
 		return p.parseSetPasswordUserStatement()
 	case KILL:
 		return p.parseKillQueryStatement()
+	case SPLIT:
+		return p.parseSplitShardStatement()
+	case MERGE:
+		return p.parseMergeShardsStatement()
 	default:
-		return nil, newParseError(tokstr(tok, lit), []string{"SELECT", "DELETE", "SHOW", "CREATE", "DROP", "GRANT", "REVOKE", "ALTER", "SET", "KILL"}, pos)
+		return nil, newParseError(tokstr(tok, lit), []string{"SELECT", "DELETE", "SHOW", "CREATE", "DROP", "GRANT", "REVOKE", "ALTER", "SET", "KILL", "SPLIT", "MERGE"}, pos)
 	}
 }
 
@@ -136,8 +140,20 @@ func (p *Parser) parseShowStatement() (Statement, error) {
 		tok, pos, lit := p.scanIgnoreWhitespace()
 		if tok == KEYS {
 			return p.parseShowFieldKeysStatement()
+		} else if tok == KEY {
+			exact, err := p.parseCardinality()
+			if err != nil {
+				return nil, err
+			}
+			return p.parseShowFieldKeyCardinalityStatement(exact)
 		}
-		return nil, newParseError(tokstr(tok, lit), []string{"KEYS"}, pos)
+		return nil, newParseError(tokstr(tok, lit), []string{"KEY", "KEYS"}, pos)
+	case MEASUREMENT:
+		exact, err := p.parseCardinality()
+		if err != nil {
+			return nil, err
+		}
+		return p.parseShowMeasurementCardinalityStatement(exact)
 	case MEASUREMENTS:
 		return p.parseShowMeasurementsStatement()
 	case QUERIES:
@@ -149,6 +165,11 @@ func (p *Parser) parseShowStatement() (Statement, error) {
 		}
 		return nil, newParseError(tokstr(tok, lit), []string{"POLICIES"}, pos)
 	case SERIES:
+		if ok, exact, err := p.parseCardinalityMaybe(); err != nil {
+			return nil, err
+		} else if ok {
+			return p.parseShowSeriesCardinalityStatement(exact)
+		}
 		return p.parseShowSeriesStatement()
 	case SHARD:
 		tok, pos, lit := p.scanIgnoreWhitespace()
@@ -167,6 +188,11 @@ func (p *Parser) parseShowStatement() (Statement, error) {
 		if tok == KEYS {
 			return p.parseShowTagKeysStatement()
 		} else if tok == VALUES {
+			if ok, exact, err := p.parseCardinalityMaybe(); err != nil {
+				return nil, err
+			} else if ok {
+				return p.parseShowTagValuesCardinalityStatement(exact)
+			}
 			return p.parseShowTagValuesStatement()
 		}
 		return nil, newParseError(tokstr(tok, lit), []string{"KEYS", "VALUES"}, pos)
@@ -174,6 +200,13 @@ func (p *Parser) parseShowStatement() (Statement, error) {
 		return p.parseShowUsersStatement()
 	case SUBSCRIPTIONS:
 		return p.parseShowSubscriptionsStatement()
+	case IDENT:
+		if strings.ToUpper(lit) == "INACTIVE" {
+			if tok, pos, lit := p.scanIgnoreWhitespace(); tok != SERIES {
+				return nil, newParseError(tokstr(tok, lit), []string{"SERIES"}, pos)
+			}
+			return p.parseShowInactiveSeriesStatement()
+		}
 	}
 
 	showQueryKeywords := []string{
@@ -181,6 +214,8 @@ func (p *Parser) parseShowStatement() (Statement, error) {
 		"DATABASES",
 		"FIELD",
 		"GRANTS",
+		"INACTIVE",
+		"MEASUREMENT",
 		"MEASUREMENTS",
 		"QUERIES",
 		"RETENTION",
@@ -245,9 +280,15 @@ func (p *Parser) parseDropStatement() (Statement, error) {
 		return p.parseDropSubscriptionStatement()
 	case USER:
 		return p.parseDropUserStatement()
-	default:
-		return nil, newParseError(tokstr(tok, lit), []string{"CONTINUOUS", "MEASUREMENT", "RETENTION", "SERIES", "SHARD", "SUBSCRIPTION", "USER"}, pos)
+	case IDENT:
+		if strings.ToUpper(lit) == "INACTIVE" {
+			if tok, pos, lit := p.scanIgnoreWhitespace(); tok != SERIES {
+				return nil, newParseError(tokstr(tok, lit), []string{"SERIES"}, pos)
+			}
+			return p.parseDropInactiveSeriesStatement()
+		}
 	}
+	return nil, newParseError(tokstr(tok, lit), []string{"CONTINUOUS", "INACTIVE", "MEASUREMENT", "RETENTION", "SERIES", "SHARD", "SUBSCRIPTION", "USER"}, pos)
 }
 
 // parseAlterStatement parses a string and returns an alter statement.
@@ -259,9 +300,11 @@ func (p *Parser) parseAlterStatement() (Statement, error) {
 			return nil, newParseError(tokstr(tok, lit), []string{"POLICY"}, pos)
 		}
 		return p.parseAlterRetentionPolicyStatement()
+	} else if tok == MEASUREMENT {
+		return p.parseAlterMeasurementStatement()
 	}
 
-	return nil, newParseError(tokstr(tok, lit), []string{"RETENTION"}, pos)
+	return nil, newParseError(tokstr(tok, lit), []string{"RETENTION", "MEASUREMENT"}, pos)
 }
 
 // parseSetPasswordUserStatement parses a string and returns a set statement.
@@ -448,6 +491,17 @@ func (p *Parser) parseCreateRetentionPolicyStatement() (*CreateRetentionPolicySt
 		p.unscan()
 	}
 
+	// Parse optional DOWNSAMPLE clause.
+	if tok, _, _ := p.scanIgnoreWhitespace(); tok == DOWNSAMPLE {
+		d, err := p.parseDownsamplePolicy()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Downsample = d
+	} else {
+		p.unscan()
+	}
+
 	// Parse optional DEFAULT token.
 	if tok, _, _ := p.scanIgnoreWhitespace(); tok == DEFAULT {
 		stmt.Default = true
@@ -458,6 +512,58 @@ func (p *Parser) parseCreateRetentionPolicyStatement() (*CreateRetentionPolicySt
 	return stmt, nil
 }
 
+// parseAlterMeasurementStatement parses a string and returns an alter measurement statement.
+// This function assumes the ALTER MEASUREMENT tokens have already been consumed.
+func (p *Parser) parseAlterMeasurementStatement() (*AlterMeasurementStatement, error) {
+	stmt := &AlterMeasurementStatement{}
+
+	// Parse the measurement name.
+	ident, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Name = ident
+
+	// Parse optional ON clause.
+	tok, pos, lit := p.scanIgnoreWhitespace()
+	if tok == ON {
+		if stmt.Database, err = p.parseIdent(); err != nil {
+			return nil, err
+		}
+		tok, pos, lit = p.scanIgnoreWhitespace()
+	}
+
+	// Consume the required SET MAX tokens.
+	if tok != SET {
+		return nil, newParseError(tokstr(tok, lit), []string{"ON", "SET"}, pos)
+	}
+	if tok, pos, lit := p.scanIgnoreWhitespace(); tok != IDENT || strings.ToUpper(lit) != "MAX" {
+		return nil, newParseError(tokstr(tok, lit), []string{"MAX"}, pos)
+	}
+
+	// Parse the limit of either the series or the values of a tag key.
+	tok, pos, lit = p.scanIgnoreWhitespace()
+	switch tok {
+	case SERIES:
+		if stmt.Max, err = p.parseInt(0, math.MaxInt32); err != nil {
+			return nil, err
+		}
+	case VALUES:
+		if stmt.Max, err = p.parseInt(0, math.MaxInt32); err != nil {
+			return nil, err
+		}
+		if err := p.parseTokens([]Token{WITH, KEY, EQ}); err != nil {
+			return nil, err
+		}
+		if stmt.Key, err = p.parseIdent(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, newParseError(tokstr(tok, lit), []string{"SERIES", "VALUES"}, pos)
+	}
+	return stmt, nil
+}
+
 // parseAlterRetentionPolicyStatement parses a string and returns an alter retention policy statement.
 // This function assumes the ALTER RETENTION POLICY tokens have already been consumed.
 func (p *Parser) parseAlterRetentionPolicyStatement() (*AlterRetentionPolicyStatement, error) {
@@ -531,11 +637,17 @@ Loop:
 			} else {
 				return nil, newParseError(tokstr(tok, lit), []string{"DURATION"}, pos)
 			}
+		case DOWNSAMPLE:
+			d, err := p.parseDownsamplePolicy()
+			if err != nil {
+				return nil, err
+			}
+			stmt.Downsample = d
 		case DEFAULT:
 			stmt.Default = true
 		default:
 			if len(found) == 0 {
-				return nil, newParseError(tokstr(tok, lit), []string{"DURATION", "REPLICATION", "SHARD", "DEFAULT"}, pos)
+				return nil, newParseError(tokstr(tok, lit), []string{"DURATION", "REPLICATION", "SHARD", "DOWNSAMPLE", "DEFAULT"}, pos)
 			}
 			p.unscan()
 			break Loop
@@ -546,6 +658,59 @@ Loop:
 	return stmt, nil
 }
 
+// parseDownsamplePolicy parses the DOWNSAMPLE clause of a retention policy statement.
+// This function assumes the DOWNSAMPLE token has already been consumed.
+func (p *Parser) parseDownsamplePolicy() (*DownsamplePolicy, error) {
+	d := &DownsamplePolicy{}
+
+	// DOWNSAMPLE INF disables downsampling.
+	tok, pos, lit := p.scanIgnoreWhitespace()
+	if tok == INF {
+		return d, nil
+	} else if tok != DURATIONVAL {
+		return nil, newParseError(tokstr(tok, lit), []string{"duration", "INF"}, pos)
+	}
+	p.unscan()
+
+	after, err := p.parseDuration()
+	if err != nil {
+		return nil, err
+	}
+	d.After = after
+
+	// Parse the required EVERY token.
+	if tok, pos, lit := p.scanIgnoreWhitespace(); tok != EVERY {
+		return nil, newParseError(tokstr(tok, lit), []string{"EVERY"}, pos)
+	}
+
+	tok, pos, lit = p.scanIgnoreWhitespace()
+	if tok != DURATIONVAL {
+		return nil, newParseError(tokstr(tok, lit), []string{"duration"}, pos)
+	}
+	p.unscan()
+
+	interval, err := p.parseDuration()
+	if err != nil {
+		return nil, err
+	} else if interval == 0 {
+		return nil, &ParseError{Message: "downsample interval must be greater than 0", Pos: pos}
+	}
+	d.Interval = interval
+
+	// Parse the required WITH token followed by the list of functions.
+	if tok, pos, lit := p.scanIgnoreWhitespace(); tok != WITH {
+		return nil, newParseError(tokstr(tok, lit), []string{"WITH"}, pos)
+	}
+
+	fns, err := p.parseIdentList()
+	if err != nil {
+		return nil, err
+	}
+	d.Functions = fns
+
+	return d, nil
+}
+
 // parseInt parses a string representing a base 10 integer and returns the number.
 // It returns an error if the parsed number is outside the range [min, max].
 func (p *Parser) parseInt(min, max int) (int, error) {
@@ -1127,6 +1292,131 @@ func (p *Parser) parseShowSeriesStatement() (*ShowSeriesStatement, error) {
 	return stmt, nil
 }
 
+// parseCardinalityMaybe consumes an optional EXACT keyword followed by the CARDINALITY
+// keyword and returns true if they were found.  Nothing is consumed if the next token is
+// neither.  EXACT and CARDINALITY are only treated as keywords in this position.
+func (p *Parser) parseCardinalityMaybe() (ok, exact bool, err error) {
+	tok, _, lit := p.scanIgnoreWhitespace()
+	if tok == IDENT && strings.ToUpper(lit) == "EXACT" {
+		if tok, pos, lit := p.scanIgnoreWhitespace(); tok != IDENT || strings.ToUpper(lit) != "CARDINALITY" {
+			return false, false, newParseError(tokstr(tok, lit), []string{"CARDINALITY"}, pos)
+		}
+		return true, true, nil
+	} else if tok == IDENT && strings.ToUpper(lit) == "CARDINALITY" {
+		return true, false, nil
+	}
+	p.unscan()
+	return false, false, nil
+}
+
+// parseCardinality parses the required CARDINALITY keyword with an optional EXACT
+// keyword before it and returns true if EXACT was found.
+func (p *Parser) parseCardinality() (bool, error) {
+	ok, exact, err := p.parseCardinalityMaybe()
+	if err != nil {
+		return false, err
+	} else if !ok {
+		tok, pos, lit := p.scanIgnoreWhitespace()
+		return false, newParseError(tokstr(tok, lit), []string{"CARDINALITY", "EXACT"}, pos)
+	}
+	return exact, nil
+}
+
+// parseOnFromClauses parses the optional ON and FROM clauses of a statement.
+func (p *Parser) parseOnFromClauses() (database string, sources Sources, err error) {
+	// Parse optional ON clause.
+	if tok, _, _ := p.scanIgnoreWhitespace(); tok == ON {
+		if database, err = p.parseIdent(); err != nil {
+			return "", nil, err
+		}
+	} else {
+		p.unscan()
+	}
+
+	// Parse optional FROM.
+	if tok, _, _ := p.scanIgnoreWhitespace(); tok == FROM {
+		if sources, err = p.parseSources(false); err != nil {
+			return "", nil, err
+		}
+	} else {
+		p.unscan()
+	}
+	return database, sources, nil
+}
+
+// parseShowSeriesCardinalityStatement parses a string and returns a ShowSeriesCardinalityStatement.
+// This function assumes the "SHOW SERIES [EXACT] CARDINALITY" tokens have already been consumed.
+func (p *Parser) parseShowSeriesCardinalityStatement(exact bool) (*ShowSeriesCardinalityStatement, error) {
+	stmt := &ShowSeriesCardinalityStatement{Exact: exact}
+	var err error
+
+	if stmt.Database, stmt.Sources, err = p.parseOnFromClauses(); err != nil {
+		return nil, err
+	}
+
+	// Parse condition: "WHERE EXPR".
+	if stmt.Condition, err = p.parseCondition(); err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// parseShowMeasurementCardinalityStatement parses a string and returns a ShowMeasurementCardinalityStatement.
+// This function assumes the "SHOW MEASUREMENT [EXACT] CARDINALITY" tokens have already been consumed.
+func (p *Parser) parseShowMeasurementCardinalityStatement(exact bool) (*ShowMeasurementCardinalityStatement, error) {
+	stmt := &ShowMeasurementCardinalityStatement{Exact: exact}
+	var err error
+
+	if stmt.Database, stmt.Sources, err = p.parseOnFromClauses(); err != nil {
+		return nil, err
+	}
+
+	// Parse condition: "WHERE EXPR".
+	if stmt.Condition, err = p.parseCondition(); err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// parseShowTagValuesCardinalityStatement parses a string and returns a ShowTagValuesCardinalityStatement.
+// This function assumes the "SHOW TAG VALUES [EXACT] CARDINALITY" tokens have already been consumed.
+func (p *Parser) parseShowTagValuesCardinalityStatement(exact bool) (*ShowTagValuesCardinalityStatement, error) {
+	stmt := &ShowTagValuesCardinalityStatement{Exact: exact}
+	var err error
+
+	if stmt.Database, stmt.Sources, err = p.parseOnFromClauses(); err != nil {
+		return nil, err
+	}
+
+	// Parse required WITH KEY.
+	if stmt.Op, stmt.TagKeyExpr, err = p.parseTagKeyExpr(); err != nil {
+		return nil, err
+	}
+
+	// Parse condition: "WHERE EXPR".
+	if stmt.Condition, err = p.parseCondition(); err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// parseShowFieldKeyCardinalityStatement parses a string and returns a ShowFieldKeyCardinalityStatement.
+// This function assumes the "SHOW FIELD KEY [EXACT] CARDINALITY" tokens have already been consumed.
+func (p *Parser) parseShowFieldKeyCardinalityStatement(exact bool) (*ShowFieldKeyCardinalityStatement, error) {
+	stmt := &ShowFieldKeyCardinalityStatement{Exact: exact}
+	var err error
+
+	if stmt.Database, stmt.Sources, err = p.parseOnFromClauses(); err != nil {
+		return nil, err
+	}
+
+	// Parse condition: "WHERE EXPR".
+	if stmt.Condition, err = p.parseCondition(); err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
 // parseShowMeasurementsStatement parses a string and returns a ShowSeriesStatement.
 // This function assumes the "SHOW MEASUREMENTS" tokens have already been consumed.
 func (p *Parser) parseShowMeasurementsStatement() (*ShowMeasurementsStatement, error) {
@@ -1462,23 +1752,7 @@ func (p *Parser) parseDropSeriesStatement() (*DropSeriesStatement, error) {
 		// Parse source.
 		if stmt.Sources, err = p.parseSources(false); err != nil {
 			return nil, err
-		}
-
-		var err error
-		WalkFunc(stmt.Sources, func(n Node) {
-			if t, ok := n.(*Measurement); ok {
-				// Don't allow database or retention policy in from clause for delete
-				// statement.  They apply to the selected database across all retention
-				// policies.
-				if t.Database != "" {
-					err = &ParseError{Message: "database not supported"}
-				}
-				if t.RetentionPolicy != "" {
-					err = &ParseError{Message: "retention policy not supported"}
-				}
-			}
-		})
-		if err != nil {
+		} else if err := validateSeriesSources(stmt.Sources); err != nil {
 			return nil, err
 		}
 	} else {
@@ -1498,6 +1772,81 @@ func (p *Parser) parseDropSeriesStatement() (*DropSeriesStatement, error) {
 	return stmt, nil
 }
 
+// validateSeriesSources returns an error if a source has a database or retention
+// policy.
+func validateSeriesSources(sources Sources) error {
+	var err error
+	WalkFunc(sources, func(n Node) {
+		if t, ok := n.(*Measurement); ok {
+			// Don't allow database or retention policy in from clause for delete
+			// statement.  They apply to the selected database across all retention
+			// policies.
+			if t.Database != "" {
+				err = &ParseError{Message: "database not supported"}
+			}
+			if t.RetentionPolicy != "" {
+				err = &ParseError{Message: "retention policy not supported"}
+			}
+		}
+	})
+	return err
+}
+
+// parseShowInactiveSeriesStatement parses a string and returns a ShowInactiveSeriesStatement.
+// This function assumes the "SHOW INACTIVE SERIES" tokens have already been consumed.
+func (p *Parser) parseShowInactiveSeriesStatement() (*ShowInactiveSeriesStatement, error) {
+	stmt := &ShowInactiveSeriesStatement{}
+	var err error
+
+	if stmt.Database, stmt.Sources, err = p.parseOnFromClauses(); err != nil {
+		return nil, err
+	} else if err := validateSeriesSources(stmt.Sources); err != nil {
+		return nil, err
+	}
+
+	// Parse required condition: "WHERE EXPR".
+	if stmt.Condition, err = p.parseRequiredCondition(); err != nil {
+		return nil, err
+	}
+
+	// Parse limit: "LIMIT <n>".
+	if stmt.Limit, err = p.parseOptionalTokenAndInt(LIMIT); err != nil {
+		return nil, err
+	}
+
+	// Parse offset: "OFFSET <n>".
+	if stmt.Offset, err = p.parseOptionalTokenAndInt(OFFSET); err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
+// parseDropInactiveSeriesStatement parses a string and returns a DropInactiveSeriesStatement.
+// This function assumes the "DROP INACTIVE SERIES" tokens have already been consumed.
+func (p *Parser) parseDropInactiveSeriesStatement() (*DropInactiveSeriesStatement, error) {
+	stmt := &DropInactiveSeriesStatement{}
+	var err error
+
+	// Parse optional FROM.
+	if tok, _, _ := p.scanIgnoreWhitespace(); tok == FROM {
+		if stmt.Sources, err = p.parseSources(false); err != nil {
+			return nil, err
+		} else if err := validateSeriesSources(stmt.Sources); err != nil {
+			return nil, err
+		}
+	} else {
+		p.unscan()
+	}
+
+	// Parse required condition: "WHERE EXPR".
+	if stmt.Condition, err = p.parseRequiredCondition(); err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
 // parseDropShardStatement parses a string and returns a
 // DropShardStatement. This function assumes the "DROP SHARD" tokens
 // have already been consumed.
@@ -1512,6 +1861,63 @@ func (p *Parser) parseDropShardStatement() (*DropShardStatement, error) {
 	return stmt, nil
 }
 
+// parseSplitShardStatement parses a string and returns a
+// SplitShardStatement. This function assumes the "SPLIT" token has
+// already been consumed.
+func (p *Parser) parseSplitShardStatement() (*SplitShardStatement, error) {
+	if tok, pos, lit := p.scanIgnoreWhitespace(); tok != SHARD {
+		return nil, newParseError(tokstr(tok, lit), []string{"SHARD"}, pos)
+	}
+
+	var err error
+	stmt := &SplitShardStatement{N: 2}
+
+	// Parse the ID of the shard to be split.
+	if stmt.ID, err = p.parseUInt64(); err != nil {
+		return nil, err
+	}
+
+	// Parse optional INTO clause.
+	if tok, _, _ := p.scanIgnoreWhitespace(); tok == INTO {
+		if stmt.N, err = p.parseInt(2, math.MaxInt32); err != nil {
+			return nil, err
+		}
+	} else {
+		p.unscan()
+	}
+	return stmt, nil
+}
+
+// parseMergeShardsStatement parses a string and returns a
+// MergeShardsStatement. This function assumes the "MERGE" token has
+// already been consumed.
+func (p *Parser) parseMergeShardsStatement() (*MergeShardsStatement, error) {
+	if tok, pos, lit := p.scanIgnoreWhitespace(); tok != SHARDS {
+		return nil, newParseError(tokstr(tok, lit), []string{"SHARDS"}, pos)
+	}
+
+	stmt := &MergeShardsStatement{}
+
+	// Parse the comma-separated IDs of the shards to be merged.
+	for {
+		id, err := p.parseUInt64()
+		if err != nil {
+			return nil, err
+		}
+		stmt.IDs = append(stmt.IDs, id)
+
+		if tok, _, _ := p.scanIgnoreWhitespace(); tok != COMMA {
+			p.unscan()
+			break
+		}
+	}
+
+	if len(stmt.IDs) < 2 {
+		return nil, errors.New("at least 2 shards are required to merge")
+	}
+	return stmt, nil
+}
+
 // parseShowContinuousQueriesStatement parses a string and returns a ShowContinuousQueriesStatement.
 // This function assumes the "SHOW CONTINUOUS" tokens have already been consumed.
 func (p *Parser) parseShowContinuousQueriesStatement() (*ShowContinuousQueriesStatement, error) {
@@ -2128,6 +2534,14 @@ func (p *Parser) parseCondition() (Expr, error) {
 	return expr, nil
 }
 
+// parseRequiredCondition parses the "WHERE" clause of the query, which must exist.
+func (p *Parser) parseRequiredCondition() (Expr, error) {
+	if tok, pos, lit := p.scanIgnoreWhitespace(); tok != WHERE {
+		return nil, newParseError(tokstr(tok, lit), []string{"WHERE"}, pos)
+	}
+	return p.ParseExpr()
+}
+
 // parseDimensions parses the "GROUP BY" clause of the query, if it exists.
 func (p *Parser) parseDimensions() (Dimensions, error) {
 	// If the next token is not GROUP then exit.
@@ -2394,19 +2808,21 @@ func (p *Parser) parseVarRef() (*VarRef, error) {
 				dtype = Float
 			case "integer":
 				dtype = Integer
+			case "unsigned":
+				dtype = Unsigned
 			case "string":
 				dtype = String
 			case "boolean":
 				dtype = Boolean
 			default:
-				return nil, newParseError(tokstr(tok, lit), []string{"float", "integer", "string", "boolean", "field", "tag"}, pos)
+				return nil, newParseError(tokstr(tok, lit), []string{"float", "integer", "unsigned", "string", "boolean", "field", "tag"}, pos)
 			}
 		case FIELD:
 			dtype = AnyField
 		case TAG:
 			dtype = Tag
 		default:
-			return nil, newParseError(tokstr(tok, lit), []string{"float", "integer", "string", "boolean", "field", "tag"}, pos)
+			return nil, newParseError(tokstr(tok, lit), []string{"float", "integer", "unsigned", "string", "boolean", "field", "tag"}, pos)
 		}
 	} else {
 		p.unscan()
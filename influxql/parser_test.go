@@ -1597,6 +1597,32 @@ func TestParser_ParseStatement(t *testing.T) {
 			},
 		},
 
+		// SPLIT SHARD 1
+		{
+			s: `SPLIT SHARD 1`,
+			stmt: &influxql.SplitShardStatement{
+				ID: 1,
+				N:  2,
+			},
+		},
+
+		// SPLIT SHARD 1 INTO 4
+		{
+			s: `SPLIT SHARD 1 INTO 4`,
+			stmt: &influxql.SplitShardStatement{
+				ID: 1,
+				N:  4,
+			},
+		},
+
+		// MERGE SHARDS 1, 2, 3
+		{
+			s: `MERGE SHARDS 1, 2, 3`,
+			stmt: &influxql.MergeShardsStatement{
+				IDs: []uint64{1, 2, 3},
+			},
+		},
+
 		// SHOW RETENTION POLICIES
 		{
 			s:    `SHOW RETENTION POLICIES`,
@@ -1877,6 +1903,74 @@ func TestParser_ParseStatement(t *testing.T) {
 			},
 		},
 
+		// SHOW ... CARDINALITY
+		{
+			s:    `SHOW SERIES CARDINALITY`,
+			stmt: &influxql.ShowSeriesCardinalityStatement{},
+		},
+		{
+			s: `SHOW SERIES EXACT CARDINALITY ON db0 FROM cpu WHERE host = 'serverA'`,
+			stmt: &influxql.ShowSeriesCardinalityStatement{
+				Database: "db0",
+				Exact:    true,
+				Sources:  []influxql.Source{&influxql.Measurement{Name: "cpu"}},
+				Condition: &influxql.BinaryExpr{
+					Op:  influxql.EQ,
+					LHS: &influxql.VarRef{Val: "host"},
+					RHS: &influxql.StringLiteral{Val: "serverA"},
+				},
+			},
+		},
+		{
+			s:    `SHOW MEASUREMENT CARDINALITY ON db0`,
+			stmt: &influxql.ShowMeasurementCardinalityStatement{Database: "db0"},
+		},
+		{
+			s: `SHOW MEASUREMENT EXACT CARDINALITY FROM /[cg]pu/`,
+			stmt: &influxql.ShowMeasurementCardinalityStatement{
+				Exact: true,
+				Sources: []influxql.Source{
+					&influxql.Measurement{
+						Regex: &influxql.RegexLiteral{Val: regexp.MustCompile(`[cg]pu`)},
+					},
+				},
+			},
+		},
+		{
+			s: `SHOW TAG VALUES CARDINALITY WITH KEY = host`,
+			stmt: &influxql.ShowTagValuesCardinalityStatement{
+				Op:         influxql.EQ,
+				TagKeyExpr: &influxql.StringLiteral{Val: "host"},
+			},
+		},
+		{
+			s: `SHOW TAG VALUES EXACT CARDINALITY ON db0 FROM cpu WITH KEY IN (host, region) WHERE region = 'uswest'`,
+			stmt: &influxql.ShowTagValuesCardinalityStatement{
+				Database:   "db0",
+				Exact:      true,
+				Sources:    []influxql.Source{&influxql.Measurement{Name: "cpu"}},
+				Op:         influxql.IN,
+				TagKeyExpr: &influxql.ListLiteral{Vals: []string{"host", "region"}},
+				Condition: &influxql.BinaryExpr{
+					Op:  influxql.EQ,
+					LHS: &influxql.VarRef{Val: "region"},
+					RHS: &influxql.StringLiteral{Val: "uswest"},
+				},
+			},
+		},
+		{
+			s:    `SHOW FIELD KEY CARDINALITY`,
+			stmt: &influxql.ShowFieldKeyCardinalityStatement{},
+		},
+		{
+			s: `SHOW FIELD KEY EXACT CARDINALITY ON db0 FROM cpu`,
+			stmt: &influxql.ShowFieldKeyCardinalityStatement{
+				Database: "db0",
+				Exact:    true,
+				Sources:  []influxql.Source{&influxql.Measurement{Name: "cpu"}},
+			},
+		},
+
 		// DELETE statement
 		{
 			s:    `DELETE FROM src`,
@@ -1931,6 +2025,51 @@ func TestParser_ParseStatement(t *testing.T) {
 			},
 		},
 
+		// DROP INACTIVE SERIES statement
+		{
+			s: `DROP INACTIVE SERIES FROM src WHERE last_write < now() - 7d`,
+			stmt: &influxql.DropInactiveSeriesStatement{
+				Sources: []influxql.Source{&influxql.Measurement{Name: "src"}},
+				Condition: &influxql.BinaryExpr{
+					Op:  influxql.LT,
+					LHS: &influxql.VarRef{Val: "last_write"},
+					RHS: &influxql.BinaryExpr{
+						Op:  influxql.SUB,
+						LHS: &influxql.Call{Name: "now"},
+						RHS: &influxql.DurationLiteral{Val: 7 * 24 * time.Hour},
+					},
+				},
+			},
+		},
+
+		// SHOW INACTIVE SERIES statement
+		{
+			s: `SHOW INACTIVE SERIES ON db0 FROM src WHERE last_write < now() - 7d AND host = 'a' LIMIT 10 OFFSET 5`,
+			stmt: &influxql.ShowInactiveSeriesStatement{
+				Database: "db0",
+				Sources:  []influxql.Source{&influxql.Measurement{Name: "src"}},
+				Condition: &influxql.BinaryExpr{
+					Op: influxql.AND,
+					LHS: &influxql.BinaryExpr{
+						Op:  influxql.LT,
+						LHS: &influxql.VarRef{Val: "last_write"},
+						RHS: &influxql.BinaryExpr{
+							Op:  influxql.SUB,
+							LHS: &influxql.Call{Name: "now"},
+							RHS: &influxql.DurationLiteral{Val: 7 * 24 * time.Hour},
+						},
+					},
+					RHS: &influxql.BinaryExpr{
+						Op:  influxql.EQ,
+						LHS: &influxql.VarRef{Val: "host"},
+						RHS: &influxql.StringLiteral{Val: "a"},
+					},
+				},
+				Limit:  10,
+				Offset: 5,
+			},
+		},
+
 		// SHOW CONTINUOUS QUERIES statement
 		{
 			s:    `SHOW CONTINUOUS QUERIES`,
@@ -2116,14 +2255,14 @@ func TestParser_ParseStatement(t *testing.T) {
 		{
 			s: `CREATE DATABASE testdb`,
 			stmt: &influxql.CreateDatabaseStatement{
-				Name: "testdb",
+				Name:                  "testdb",
 				RetentionPolicyCreate: false,
 			},
 		},
 		{
 			s: `CREATE DATABASE testdb WITH DURATION 24h`,
 			stmt: &influxql.CreateDatabaseStatement{
-				Name: "testdb",
+				Name:                    "testdb",
 				RetentionPolicyCreate:   true,
 				RetentionPolicyDuration: duration(24 * time.Hour),
 			},
@@ -2131,7 +2270,7 @@ func TestParser_ParseStatement(t *testing.T) {
 		{
 			s: `CREATE DATABASE testdb WITH SHARD DURATION 30m`,
 			stmt: &influxql.CreateDatabaseStatement{
-				Name: "testdb",
+				Name:                              "testdb",
 				RetentionPolicyCreate:             true,
 				RetentionPolicyShardGroupDuration: 30 * time.Minute,
 			},
@@ -2139,7 +2278,7 @@ func TestParser_ParseStatement(t *testing.T) {
 		{
 			s: `CREATE DATABASE testdb WITH REPLICATION 2`,
 			stmt: &influxql.CreateDatabaseStatement{
-				Name: "testdb",
+				Name:                       "testdb",
 				RetentionPolicyCreate:      true,
 				RetentionPolicyReplication: intptr(2),
 			},
@@ -2147,7 +2286,7 @@ func TestParser_ParseStatement(t *testing.T) {
 		{
 			s: `CREATE DATABASE testdb WITH NAME test_name`,
 			stmt: &influxql.CreateDatabaseStatement{
-				Name: "testdb",
+				Name:                  "testdb",
 				RetentionPolicyCreate: true,
 				RetentionPolicyName:   "test_name",
 			},
@@ -2155,7 +2294,7 @@ func TestParser_ParseStatement(t *testing.T) {
 		{
 			s: `CREATE DATABASE testdb WITH DURATION 24h REPLICATION 2 NAME test_name`,
 			stmt: &influxql.CreateDatabaseStatement{
-				Name: "testdb",
+				Name:                       "testdb",
 				RetentionPolicyCreate:      true,
 				RetentionPolicyDuration:    duration(24 * time.Hour),
 				RetentionPolicyReplication: intptr(2),
@@ -2165,7 +2304,7 @@ func TestParser_ParseStatement(t *testing.T) {
 		{
 			s: `CREATE DATABASE testdb WITH DURATION 24h REPLICATION 2 SHARD DURATION 10m NAME test_name `,
 			stmt: &influxql.CreateDatabaseStatement{
-				Name: "testdb",
+				Name:                              "testdb",
 				RetentionPolicyCreate:             true,
 				RetentionPolicyDuration:           duration(24 * time.Hour),
 				RetentionPolicyReplication:        intptr(2),
@@ -2414,6 +2553,37 @@ func TestParser_ParseStatement(t *testing.T) {
 			},
 		},
 
+		// CREATE RETENTION POLICY ... DOWNSAMPLE
+		{
+			s: `CREATE RETENTION POLICY policy1 ON testdb DURATION 52w REPLICATION 1 DOWNSAMPLE 7d EVERY 1h WITH mean, max DEFAULT`,
+			stmt: &influxql.CreateRetentionPolicyStatement{
+				Name:        "policy1",
+				Database:    "testdb",
+				Duration:    52 * 7 * 24 * time.Hour,
+				Replication: 1,
+				Downsample: &influxql.DownsamplePolicy{
+					After:     7 * 24 * time.Hour,
+					Interval:  time.Hour,
+					Functions: []string{"mean", "max"},
+				},
+				Default: true,
+			},
+		},
+
+		// ALTER MEASUREMENT
+		{
+			s:    `ALTER MEASUREMENT cpu SET MAX SERIES 100000`,
+			stmt: &influxql.AlterMeasurementStatement{Name: "cpu", Max: 100000},
+		},
+		{
+			s:    `ALTER MEASUREMENT "cpu load" ON testdb SET MAX VALUES 100 WITH KEY = host`,
+			stmt: &influxql.AlterMeasurementStatement{Name: "cpu load", Database: "testdb", Key: "host", Max: 100},
+		},
+		{
+			s:    `ALTER MEASUREMENT cpu ON testdb SET max SERIES 0`,
+			stmt: &influxql.AlterMeasurementStatement{Name: "cpu", Database: "testdb"},
+		},
+
 		// ALTER RETENTION POLICY
 		{
 			s:    `ALTER RETENTION POLICY policy1 ON testdb DURATION 1m REPLICATION 4 DEFAULT`,
@@ -2469,6 +2639,28 @@ func TestParser_ParseStatement(t *testing.T) {
 			s:    `ALTER RETENTION POLICY default ON testdb DURATION 0s REPLICATION 1 SHARD DURATION 0s`,
 			stmt: newAlterRetentionPolicyStatement("default", "testdb", time.Duration(0), 0, 1, false),
 		},
+		// ALTER RETENTION POLICY with DOWNSAMPLE
+		{
+			s: `ALTER RETENTION POLICY policy1 ON testdb DOWNSAMPLE 30d EVERY 5m WITH min`,
+			stmt: &influxql.AlterRetentionPolicyStatement{
+				Name:     "policy1",
+				Database: "testdb",
+				Downsample: &influxql.DownsamplePolicy{
+					After:     30 * 24 * time.Hour,
+					Interval:  5 * time.Minute,
+					Functions: []string{"min"},
+				},
+			},
+		},
+		// ALTER RETENTION POLICY disabling DOWNSAMPLE
+		{
+			s: `ALTER RETENTION POLICY policy1 ON testdb DOWNSAMPLE INF`,
+			stmt: &influxql.AlterRetentionPolicyStatement{
+				Name:       "policy1",
+				Database:   "testdb",
+				Downsample: &influxql.DownsamplePolicy{},
+			},
+		},
 
 		// SHOW STATS
 		{
@@ -2537,10 +2729,10 @@ func TestParser_ParseStatement(t *testing.T) {
 		},
 
 		// Errors
-		{s: ``, err: `found EOF, expected SELECT, DELETE, SHOW, CREATE, DROP, GRANT, REVOKE, ALTER, SET, KILL at line 1, char 1`},
+		{s: ``, err: `found EOF, expected SELECT, DELETE, SHOW, CREATE, DROP, GRANT, REVOKE, ALTER, SET, KILL, SPLIT, MERGE at line 1, char 1`},
 		{s: `SELECT`, err: `found EOF, expected identifier, string, number, bool at line 1, char 8`},
 		{s: `SELECT time FROM myseries`, err: `at least 1 non-time field must be queried`},
-		{s: `blah blah`, err: `found blah, expected SELECT, DELETE, SHOW, CREATE, DROP, GRANT, REVOKE, ALTER, SET, KILL at line 1, char 1`},
+		{s: `blah blah`, err: `found blah, expected SELECT, DELETE, SHOW, CREATE, DROP, GRANT, REVOKE, ALTER, SET, KILL, SPLIT, MERGE at line 1, char 1`},
 		{s: `SELECT field1 X`, err: `found X, expected FROM at line 1, char 15`},
 		{s: `SELECT field1 FROM "series" WHERE X +;`, err: `found ;, expected identifier, string, number, bool at line 1, char 38`},
 		{s: `SELECT field1 FROM myseries GROUP`, err: `found EOF, expected BY at line 1, char 35`},
@@ -2698,12 +2890,22 @@ func TestParser_ParseStatement(t *testing.T) {
 		{s: `DROP SERIES FROM src WHERE`, err: `found EOF, expected identifier, string, number, bool at line 1, char 28`},
 		{s: `DROP SERIES FROM "foo".myseries`, err: `retention policy not supported at line 1, char 1`},
 		{s: `DROP SERIES FROM foo..myseries`, err: `database not supported at line 1, char 1`},
+		{s: `DROP INACTIVE`, err: `found EOF, expected SERIES at line 1, char 15`},
+		{s: `DROP INACTIVE SERIES FROM src`, err: `found EOF, expected WHERE at line 1, char 31`},
+		{s: `DROP INACTIVE SERIES FROM foo..src WHERE last_write < now()`, err: `database not supported at line 1, char 1`},
+		{s: `SHOW INACTIVE SERIES`, err: `found EOF, expected WHERE at line 1, char 22`},
+		{s: `SHOW INACTIVE SERIES FROM "foo".src WHERE last_write < now()`, err: `retention policy not supported at line 1, char 1`},
 		{s: `SHOW CONTINUOUS`, err: `found EOF, expected QUERIES at line 1, char 17`},
 		{s: `SHOW RETENTION`, err: `found EOF, expected POLICIES at line 1, char 16`},
 		{s: `SHOW RETENTION ON`, err: `found ON, expected POLICIES at line 1, char 16`},
 		{s: `SHOW RETENTION POLICIES ON`, err: `found EOF, expected identifier at line 1, char 28`},
 		{s: `SHOW SHARD`, err: `found EOF, expected GROUPS at line 1, char 12`},
-		{s: `SHOW FOO`, err: `found FOO, expected CONTINUOUS, DATABASES, DIAGNOSTICS, FIELD, GRANTS, MEASUREMENTS, QUERIES, RETENTION, SERIES, SHARD, SHARDS, STATS, SUBSCRIPTIONS, TAG, USERS at line 1, char 6`},
+		{s: `SHOW SERIES EXACT`, err: `found EOF, expected CARDINALITY at line 1, char 19`},
+		{s: `SHOW MEASUREMENT`, err: `found EOF, expected CARDINALITY, EXACT at line 1, char 18`},
+		{s: `SHOW FIELD KEY`, err: `found EOF, expected CARDINALITY, EXACT at line 1, char 16`},
+		{s: `SHOW FIELD FOO`, err: `found FOO, expected KEY, KEYS at line 1, char 12`},
+		{s: `SHOW TAG VALUES CARDINALITY`, err: `found EOF, expected WITH at line 1, char 29`},
+		{s: `SHOW FOO`, err: `found FOO, expected CONTINUOUS, DATABASES, DIAGNOSTICS, FIELD, GRANTS, INACTIVE, MEASUREMENT, MEASUREMENTS, QUERIES, RETENTION, SERIES, SHARD, SHARDS, STATS, SUBSCRIPTIONS, TAG, USERS at line 1, char 6`},
 		{s: `SHOW STATS FOR`, err: `found EOF, expected string at line 1, char 16`},
 		{s: `SHOW DIAGNOSTICS FOR`, err: `found EOF, expected string at line 1, char 22`},
 		{s: `SHOW GRANTS`, err: `found EOF, expected FOR at line 1, char 13`},
@@ -2716,7 +2918,7 @@ func TestParser_ParseStatement(t *testing.T) {
 		{s: `CREATE CONTINUOUS QUERY`, err: `found EOF, expected identifier at line 1, char 25`},
 		{s: `CREATE CONTINUOUS QUERY cq ON db RESAMPLE FOR 5s BEGIN SELECT mean(value) INTO cpu_mean FROM cpu GROUP BY time(10s) END`, err: `FOR duration must be >= GROUP BY time duration: must be a minimum of 10s, got 5s`},
 		{s: `CREATE CONTINUOUS QUERY cq ON db RESAMPLE EVERY 10s FOR 5s BEGIN SELECT mean(value) INTO cpu_mean FROM cpu GROUP BY time(5s) END`, err: `FOR duration must be >= GROUP BY time duration: must be a minimum of 10s, got 5s`},
-		{s: `DROP FOO`, err: `found FOO, expected CONTINUOUS, MEASUREMENT, RETENTION, SERIES, SHARD, SUBSCRIPTION, USER at line 1, char 6`},
+		{s: `DROP FOO`, err: `found FOO, expected CONTINUOUS, INACTIVE, MEASUREMENT, RETENTION, SERIES, SHARD, SUBSCRIPTION, USER at line 1, char 6`},
 		{s: `CREATE FOO`, err: `found FOO, expected CONTINUOUS, DATABASE, USER, RETENTION, SUBSCRIPTION at line 1, char 8`},
 		{s: `CREATE DATABASE`, err: `found EOF, expected identifier at line 1, char 17`},
 		{s: `CREATE DATABASE "testdb" WITH`, err: `found EOF, expected DURATION, NAME, REPLICATION, SHARD at line 1, char 31`},
@@ -2781,6 +2983,14 @@ func TestParser_ParseStatement(t *testing.T) {
 		{s: `GRANT ALL TO`, err: `found EOF, expected identifier at line 1, char 14`},
 		{s: `GRANT ALL PRIVILEGES TO`, err: `found EOF, expected identifier at line 1, char 25`},
 		{s: `KILL`, err: `found EOF, expected QUERY at line 1, char 6`},
+		{s: `SPLIT 1`, err: `found 1, expected SHARD at line 1, char 7`},
+		{s: `SPLIT SHARD`, err: `found EOF, expected integer at line 1, char 13`},
+		{s: `SPLIT SHARD 1 INTO`, err: `found EOF, expected integer at line 1, char 20`},
+		{s: `SPLIT SHARD 1 INTO 1`, err: `invalid value 1: must be 2 <= n <= 2147483647 at line 1, char 20`},
+		{s: `MERGE SHARD 1, 2`, err: `found SHARD, expected SHARDS at line 1, char 7`},
+		{s: `MERGE SHARDS`, err: `found EOF, expected integer at line 1, char 14`},
+		{s: `MERGE SHARDS 1,`, err: `found EOF, expected integer at line 1, char 16`},
+		{s: `MERGE SHARDS 1`, err: `at least 2 shards are required to merge`},
 		{s: `KILL QUERY 10s`, err: `found 10s, expected integer at line 1, char 12`},
 		{s: `KILL QUERY 4 ON 'host'`, err: `found host, expected identifier at line 1, char 16`},
 		{s: `REVOKE`, err: `found EOF, expected READ, WRITE, ALL [PRIVILEGES] at line 1, char 8`},
@@ -2828,14 +3038,24 @@ func TestParser_ParseStatement(t *testing.T) {
 		{s: `CREATE RETENTION POLICY policy1 ON testdb DURATION 1h REPLICATION 0`, err: `invalid value 0: must be 1 <= n <= 2147483647 at line 1, char 67`},
 		{s: `CREATE RETENTION POLICY policy1 ON testdb DURATION 1h REPLICATION bad`, err: `found bad, expected integer at line 1, char 67`},
 		{s: `CREATE RETENTION POLICY policy1 ON testdb DURATION 1h REPLICATION 2 SHARD DURATION INF`, err: `invalid duration INF for shard duration at line 1, char 84`},
-		{s: `ALTER`, err: `found EOF, expected RETENTION at line 1, char 7`},
+		{s: `ALTER`, err: `found EOF, expected RETENTION, MEASUREMENT at line 1, char 7`},
+		{s: `ALTER MEASUREMENT`, err: `found EOF, expected identifier at line 1, char 19`},
+		{s: `ALTER MEASUREMENT cpu`, err: `found EOF, expected ON, SET at line 1, char 23`},
+		{s: `ALTER MEASUREMENT cpu SET`, err: `found EOF, expected MAX at line 1, char 27`},
+		{s: `ALTER MEASUREMENT cpu SET MAX`, err: `found EOF, expected SERIES, VALUES at line 1, char 31`},
+		{s: `ALTER MEASUREMENT cpu SET MAX SERIES -1`, err: `found -, expected integer at line 1, char 38`},
+		{s: `ALTER MEASUREMENT cpu SET MAX VALUES 10`, err: `found EOF, expected WITH at line 1, char 40`},
 		{s: `ALTER RETENTION`, err: `found EOF, expected POLICY at line 1, char 17`},
 		{s: `ALTER RETENTION POLICY`, err: `found EOF, expected identifier at line 1, char 24`},
 		{s: `ALTER RETENTION POLICY policy1`, err: `found EOF, expected ON at line 1, char 32`}, {s: `ALTER RETENTION POLICY policy1 ON`, err: `found EOF, expected identifier at line 1, char 35`},
-		{s: `ALTER RETENTION POLICY policy1 ON testdb`, err: `found EOF, expected DURATION, REPLICATION, SHARD, DEFAULT at line 1, char 42`},
+		{s: `ALTER RETENTION POLICY policy1 ON testdb`, err: `found EOF, expected DURATION, REPLICATION, SHARD, DOWNSAMPLE, DEFAULT at line 1, char 42`},
 		{s: `ALTER RETENTION POLICY policy1 ON testdb REPLICATION 1 REPLICATION 2`, err: `found duplicate REPLICATION option at line 1, char 56`},
 		{s: `ALTER RETENTION POLICY policy1 ON testdb DURATION 15251w`, err: `overflowed duration 15251w: choose a smaller duration or INF at line 1, char 51`},
 		{s: `ALTER RETENTION POLICY policy1 ON testdb DURATION INF SHARD DURATION INF`, err: `invalid duration INF for shard duration at line 1, char 70`},
+		{s: `ALTER RETENTION POLICY policy1 ON testdb DOWNSAMPLE`, err: `found EOF, expected duration, INF at line 1, char 53`},
+		{s: `ALTER RETENTION POLICY policy1 ON testdb DOWNSAMPLE 1d`, err: `found EOF, expected EVERY at line 1, char 55`},
+		{s: `ALTER RETENTION POLICY policy1 ON testdb DOWNSAMPLE 1d EVERY 0s WITH mean`, err: `downsample interval must be greater than 0 at line 1, char 62`},
+		{s: `ALTER RETENTION POLICY policy1 ON testdb DOWNSAMPLE 1d EVERY 1h`, err: `found EOF, expected WITH at line 1, char 64`},
 		{s: `SET`, err: `found EOF, expected PASSWORD at line 1, char 5`},
 		{s: `SET PASSWORD`, err: `found EOF, expected FOR at line 1, char 14`},
 		{s: `SET PASSWORD something`, err: `found something, expected FOR at line 1, char 14`},
@@ -2843,7 +3063,7 @@ func TestParser_ParseStatement(t *testing.T) {
 		{s: `SET PASSWORD FOR dejan`, err: `found EOF, expected = at line 1, char 24`},
 		{s: `SET PASSWORD FOR dejan =`, err: `found EOF, expected string at line 1, char 25`},
 		{s: `SET PASSWORD FOR dejan = bla`, err: `found bla, expected string at line 1, char 26`},
-		{s: `$SHOW$DATABASES`, err: `found $SHOW, expected SELECT, DELETE, SHOW, CREATE, DROP, GRANT, REVOKE, ALTER, SET, KILL at line 1, char 1`},
+		{s: `$SHOW$DATABASES`, err: `found $SHOW, expected SELECT, DELETE, SHOW, CREATE, DROP, GRANT, REVOKE, ALTER, SET, KILL, SPLIT, MERGE at line 1, char 1`},
 		{s: `SELECT * FROM cpu WHERE "tagkey" = $$`, err: `empty bound parameter`},
 	}
 
@@ -49,6 +49,8 @@ func (a Points) Clone() []Point {
 			other[i] = p.Clone()
 		case *IntegerPoint:
 			other[i] = p.Clone()
+		case *UnsignedPoint:
+			other[i] = p.Clone()
 		case *StringPoint:
 			other[i] = p.Clone()
 		case *BooleanPoint:
@@ -229,6 +231,10 @@ func encodeAux(aux []interface{}) []*internal.Aux {
 			pb[i] = &internal.Aux{DataType: proto.Int32(Integer), IntegerValue: proto.Int64(v)}
 		case *int64:
 			pb[i] = &internal.Aux{DataType: proto.Int32(Integer)}
+		case uint64:
+			pb[i] = &internal.Aux{DataType: proto.Int32(Unsigned), UnsignedValue: proto.Uint64(v)}
+		case *uint64:
+			pb[i] = &internal.Aux{DataType: proto.Int32(Unsigned)}
 		case string:
 			pb[i] = &internal.Aux{DataType: proto.Int32(String), StringValue: proto.String(v)}
 		case *string:
@@ -264,6 +270,12 @@ func decodeAux(pb []*internal.Aux) []interface{} {
 			} else {
 				aux[i] = (*int64)(nil)
 			}
+		case Unsigned:
+			if pb[i].UnsignedValue != nil {
+				aux[i] = *pb[i].UnsignedValue
+			} else {
+				aux[i] = (*uint64)(nil)
+			}
 		case String:
 			if pb[i].StringValue != nil {
 				aux[i] = *pb[i].StringValue
@@ -335,6 +347,8 @@ func (dec *PointDecoder) DecodePoint(p *Point) error {
 
 		if pb.IntegerValue != nil {
 			*p = decodeIntegerPoint(&pb)
+		} else if pb.UnsignedValue != nil {
+			*p = decodeUnsignedPoint(&pb)
 		} else if pb.StringValue != nil {
 			*p = decodeStringPoint(&pb)
 		} else if pb.BooleanValue != nil {
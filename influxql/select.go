@@ -689,6 +689,8 @@ func buildRHSTransformIterator(lhs Iterator, rhs Literal, op Token, opt Iterator
 			input = lhs
 		case IntegerIterator:
 			input = &integerFloatCastIterator{input: lhs}
+		case UnsignedIterator:
+			input = &unsignedFloatCastIterator{input: lhs}
 		default:
 			return nil, fmt.Errorf("type mismatch on LHS, unable to use %T as a FloatIterator", lhs)
 		}
@@ -734,6 +736,43 @@ func buildRHSTransformIterator(lhs Iterator, rhs Literal, op Token, opt Iterator
 					return nil
 				}
 
+				fp := &FloatPoint{
+					Name: p.Name,
+					Tags: p.Tags,
+					Time: p.Time,
+					Aux:  p.Aux,
+				}
+				if p.Nil {
+					fp.Nil = true
+				} else {
+					fp.Value = fn(p.Value, val)
+				}
+				return fp
+			},
+		}, nil
+	case func(uint64, uint64) float64:
+		input, ok := lhs.(UnsignedIterator)
+		if !ok {
+			return nil, fmt.Errorf("type mismatch on LHS, unable to use %T as an UnsignedIterator", lhs)
+		}
+
+		var val uint64
+		switch rhs := rhs.(type) {
+		case *IntegerLiteral:
+			if rhs.Val < 0 {
+				return nil, fmt.Errorf("unable to use negative integer %d with an unsigned value", rhs.Val)
+			}
+			val = uint64(rhs.Val)
+		default:
+			return nil, fmt.Errorf("type mismatch on RHS, unable to use %T as an IntegerLiteral", rhs)
+		}
+		return &unsignedFloatTransformIterator{
+			input: input,
+			fn: func(p *UnsignedPoint) *FloatPoint {
+				if p == nil {
+					return nil
+				}
+
 				fp := &FloatPoint{
 					Name: p.Name,
 					Tags: p.Tags,
@@ -755,6 +794,8 @@ func buildRHSTransformIterator(lhs Iterator, rhs Literal, op Token, opt Iterator
 			input = lhs
 		case IntegerIterator:
 			input = &integerFloatCastIterator{input: lhs}
+		case UnsignedIterator:
+			input = &unsignedFloatCastIterator{input: lhs}
 		default:
 			return nil, fmt.Errorf("type mismatch on LHS, unable to use %T as a FloatIterator", lhs)
 		}
@@ -817,6 +858,37 @@ func buildRHSTransformIterator(lhs Iterator, rhs Literal, op Token, opt Iterator
 				return p
 			},
 		}, nil
+	case func(uint64, uint64) uint64:
+		var input UnsignedIterator
+		switch lhs := lhs.(type) {
+		case UnsignedIterator:
+			input = lhs
+		default:
+			return nil, fmt.Errorf("type mismatch on LHS, unable to use %T as an UnsignedIterator", lhs)
+		}
+
+		var val uint64
+		switch rhs := rhs.(type) {
+		case *IntegerLiteral:
+			if rhs.Val < 0 {
+				return nil, fmt.Errorf("unable to use negative integer %d with an unsigned value", rhs.Val)
+			}
+			val = uint64(rhs.Val)
+		default:
+			return nil, fmt.Errorf("type mismatch on RHS, unable to use %T as an IntegerLiteral", rhs)
+		}
+		return &unsignedTransformIterator{
+			input: input,
+			fn: func(p *UnsignedPoint) *UnsignedPoint {
+				if p == nil {
+					return nil
+				} else if p.Nil {
+					return p
+				}
+				p.Value = fn(p.Value, val)
+				return p
+			},
+		}, nil
 	case func(int64, int64) bool:
 		var input IntegerIterator
 		switch lhs := lhs.(type) {
@@ -840,6 +912,46 @@ func buildRHSTransformIterator(lhs Iterator, rhs Literal, op Token, opt Iterator
 					return nil
 				}
 
+				bp := &BooleanPoint{
+					Name: p.Name,
+					Tags: p.Tags,
+					Time: p.Time,
+					Aux:  p.Aux,
+				}
+				if p.Nil {
+					bp.Nil = true
+				} else {
+					bp.Value = fn(p.Value, val)
+				}
+				return bp
+			},
+		}, nil
+	case func(uint64, uint64) bool:
+		var input UnsignedIterator
+		switch lhs := lhs.(type) {
+		case UnsignedIterator:
+			input = lhs
+		default:
+			return nil, fmt.Errorf("type mismatch on LHS, unable to use %T as an UnsignedIterator", lhs)
+		}
+
+		var val uint64
+		switch rhs := rhs.(type) {
+		case *IntegerLiteral:
+			if rhs.Val < 0 {
+				return nil, fmt.Errorf("unable to use negative integer %d with an unsigned value", rhs.Val)
+			}
+			val = uint64(rhs.Val)
+		default:
+			return nil, fmt.Errorf("type mismatch on RHS, unable to use %T as an IntegerLiteral", rhs)
+		}
+		return &unsignedBoolTransformIterator{
+			input: input,
+			fn: func(p *UnsignedPoint) *BooleanPoint {
+				if p == nil {
+					return nil
+				}
+
 				bp := &BooleanPoint{
 					Name: p.Name,
 					Tags: p.Tags,
@@ -905,6 +1017,8 @@ func buildLHSTransformIterator(lhs Literal, rhs Iterator, op Token, opt Iterator
 			input = rhs
 		case IntegerIterator:
 			input = &integerFloatCastIterator{input: rhs}
+		case UnsignedIterator:
+			input = &unsignedFloatCastIterator{input: rhs}
 		default:
 			return nil, fmt.Errorf("type mismatch on RHS, unable to use %T as a FloatIterator", rhs)
 		}
@@ -950,6 +1064,43 @@ func buildLHSTransformIterator(lhs Literal, rhs Iterator, op Token, opt Iterator
 					return nil
 				}
 
+				fp := &FloatPoint{
+					Name: p.Name,
+					Tags: p.Tags,
+					Time: p.Time,
+					Aux:  p.Aux,
+				}
+				if p.Nil {
+					fp.Nil = true
+				} else {
+					fp.Value = fn(val, p.Value)
+				}
+				return fp
+			},
+		}, nil
+	case func(uint64, uint64) float64:
+		input, ok := rhs.(UnsignedIterator)
+		if !ok {
+			return nil, fmt.Errorf("type mismatch on RHS, unable to use %T as an UnsignedIterator", lhs)
+		}
+
+		var val uint64
+		switch lhs := lhs.(type) {
+		case *IntegerLiteral:
+			if lhs.Val < 0 {
+				return nil, fmt.Errorf("unable to use negative integer %d with an unsigned value", lhs.Val)
+			}
+			val = uint64(lhs.Val)
+		default:
+			return nil, fmt.Errorf("type mismatch on LHS, unable to use %T as an IntegerLiteral", rhs)
+		}
+		return &unsignedFloatTransformIterator{
+			input: input,
+			fn: func(p *UnsignedPoint) *FloatPoint {
+				if p == nil {
+					return nil
+				}
+
 				fp := &FloatPoint{
 					Name: p.Name,
 					Tags: p.Tags,
@@ -971,6 +1122,8 @@ func buildLHSTransformIterator(lhs Literal, rhs Iterator, op Token, opt Iterator
 			input = rhs
 		case IntegerIterator:
 			input = &integerFloatCastIterator{input: rhs}
+		case UnsignedIterator:
+			input = &unsignedFloatCastIterator{input: rhs}
 		default:
 			return nil, fmt.Errorf("type mismatch on RHS, unable to use %T as a FloatIterator", rhs)
 		}
@@ -1033,6 +1186,37 @@ func buildLHSTransformIterator(lhs Literal, rhs Iterator, op Token, opt Iterator
 				return p
 			},
 		}, nil
+	case func(uint64, uint64) uint64:
+		var input UnsignedIterator
+		switch rhs := rhs.(type) {
+		case UnsignedIterator:
+			input = rhs
+		default:
+			return nil, fmt.Errorf("type mismatch on RHS, unable to use %T as an UnsignedIterator", rhs)
+		}
+
+		var val uint64
+		switch lhs := lhs.(type) {
+		case *IntegerLiteral:
+			if lhs.Val < 0 {
+				return nil, fmt.Errorf("unable to use negative integer %d with an unsigned value", lhs.Val)
+			}
+			val = uint64(lhs.Val)
+		default:
+			return nil, fmt.Errorf("type mismatch on LHS, unable to use %T as an IntegerLiteral", lhs)
+		}
+		return &unsignedTransformIterator{
+			input: input,
+			fn: func(p *UnsignedPoint) *UnsignedPoint {
+				if p == nil {
+					return nil
+				} else if p.Nil {
+					return p
+				}
+				p.Value = fn(val, p.Value)
+				return p
+			},
+		}, nil
 	case func(int64, int64) bool:
 		var input IntegerIterator
 		switch rhs := rhs.(type) {
@@ -1056,6 +1240,46 @@ func buildLHSTransformIterator(lhs Literal, rhs Iterator, op Token, opt Iterator
 					return nil
 				}
 
+				bp := &BooleanPoint{
+					Name: p.Name,
+					Tags: p.Tags,
+					Time: p.Time,
+					Aux:  p.Aux,
+				}
+				if p.Nil {
+					bp.Nil = true
+				} else {
+					bp.Value = fn(val, p.Value)
+				}
+				return bp
+			},
+		}, nil
+	case func(uint64, uint64) bool:
+		var input UnsignedIterator
+		switch rhs := rhs.(type) {
+		case UnsignedIterator:
+			input = rhs
+		default:
+			return nil, fmt.Errorf("type mismatch on RHS, unable to use %T as an UnsignedIterator", rhs)
+		}
+
+		var val uint64
+		switch lhs := lhs.(type) {
+		case *IntegerLiteral:
+			if lhs.Val < 0 {
+				return nil, fmt.Errorf("unable to use negative integer %d with an unsigned value", lhs.Val)
+			}
+			val = uint64(lhs.Val)
+		default:
+			return nil, fmt.Errorf("type mismatch on LHS, unable to use %T as an IntegerLiteral", lhs)
+		}
+		return &unsignedBoolTransformIterator{
+			input: input,
+			fn: func(p *UnsignedPoint) *BooleanPoint {
+				if p == nil {
+					return nil
+				}
+
 				bp := &BooleanPoint{
 					Name: p.Name,
 					Tags: p.Tags,
@@ -1121,6 +1345,8 @@ func buildTransformIterator(lhs Iterator, rhs Iterator, op Token, opt IteratorOp
 			left = lhs
 		case IntegerIterator:
 			left = &integerFloatCastIterator{input: lhs}
+		case UnsignedIterator:
+			left = &unsignedFloatCastIterator{input: lhs}
 		default:
 			return nil, fmt.Errorf("type mismatch on LHS, unable to use %T as a FloatIterator", lhs)
 		}
@@ -1131,6 +1357,8 @@ func buildTransformIterator(lhs Iterator, rhs Iterator, op Token, opt IteratorOp
 			right = rhs
 		case IntegerIterator:
 			right = &integerFloatCastIterator{input: rhs}
+		case UnsignedIterator:
+			right = &unsignedFloatCastIterator{input: rhs}
 		default:
 			return nil, fmt.Errorf("type mismatch on RHS, unable to use %T as a FloatIterator", rhs)
 		}
@@ -1145,6 +1373,16 @@ func buildTransformIterator(lhs Iterator, rhs Iterator, op Token, opt IteratorOp
 			return nil, fmt.Errorf("type mismatch on RHS, unable to use %T as a IntegerIterator", rhs)
 		}
 		return newIntegerFloatExprIterator(left, right, opt, fn), nil
+	case func(uint64, uint64) float64:
+		left, ok := lhs.(UnsignedIterator)
+		if !ok {
+			return nil, fmt.Errorf("type mismatch on LHS, unable to use %T as an UnsignedIterator", lhs)
+		}
+		right, ok := rhs.(UnsignedIterator)
+		if !ok {
+			return nil, fmt.Errorf("type mismatch on RHS, unable to use %T as an UnsignedIterator", rhs)
+		}
+		return newUnsignedFloatExprIterator(left, right, opt, fn), nil
 	case func(int64, int64) int64:
 		left, ok := lhs.(IntegerIterator)
 		if !ok {
@@ -1155,6 +1393,16 @@ func buildTransformIterator(lhs Iterator, rhs Iterator, op Token, opt IteratorOp
 			return nil, fmt.Errorf("type mismatch on RHS, unable to use %T as a IntegerIterator", rhs)
 		}
 		return newIntegerExprIterator(left, right, opt, fn), nil
+	case func(uint64, uint64) uint64:
+		left, ok := lhs.(UnsignedIterator)
+		if !ok {
+			return nil, fmt.Errorf("type mismatch on LHS, unable to use %T as an UnsignedIterator", lhs)
+		}
+		right, ok := rhs.(UnsignedIterator)
+		if !ok {
+			return nil, fmt.Errorf("type mismatch on RHS, unable to use %T as an UnsignedIterator", rhs)
+		}
+		return newUnsignedExprIterator(left, right, opt, fn), nil
 	case func(float64, float64) bool:
 		var left FloatIterator
 		switch lhs := lhs.(type) {
@@ -1162,6 +1410,8 @@ func buildTransformIterator(lhs Iterator, rhs Iterator, op Token, opt IteratorOp
 			left = lhs
 		case IntegerIterator:
 			left = &integerFloatCastIterator{input: lhs}
+		case UnsignedIterator:
+			left = &unsignedFloatCastIterator{input: lhs}
 		default:
 			return nil, fmt.Errorf("type mismatch on LHS, unable to use %T as a FloatIterator", lhs)
 		}
@@ -1172,6 +1422,8 @@ func buildTransformIterator(lhs Iterator, rhs Iterator, op Token, opt IteratorOp
 			right = rhs
 		case IntegerIterator:
 			right = &integerFloatCastIterator{input: rhs}
+		case UnsignedIterator:
+			right = &unsignedFloatCastIterator{input: rhs}
 		default:
 			return nil, fmt.Errorf("type mismatch on RHS, unable to use %T as a FloatIterator", rhs)
 		}
@@ -1186,6 +1438,16 @@ func buildTransformIterator(lhs Iterator, rhs Iterator, op Token, opt IteratorOp
 			return nil, fmt.Errorf("type mismatch on RHS, unable to use %T as a IntegerIterator", rhs)
 		}
 		return newIntegerBooleanExprIterator(left, right, opt, fn), nil
+	case func(uint64, uint64) bool:
+		left, ok := lhs.(UnsignedIterator)
+		if !ok {
+			return nil, fmt.Errorf("type mismatch on LHS, unable to use %T as an UnsignedIterator", lhs)
+		}
+		right, ok := rhs.(UnsignedIterator)
+		if !ok {
+			return nil, fmt.Errorf("type mismatch on RHS, unable to use %T as an UnsignedIterator", rhs)
+		}
+		return newUnsignedBooleanExprIterator(left, right, opt, fn), nil
 	case func(bool, bool) bool:
 		left, ok := lhs.(BooleanIterator)
 		if !ok {
@@ -1206,6 +1468,8 @@ func iteratorDataType(itr Iterator) DataType {
 		return Float
 	case IntegerIterator:
 		return Integer
+	case UnsignedIterator:
+		return Unsigned
 	case StringIterator:
 		return String
 	case BooleanIterator:
@@ -1239,9 +1503,18 @@ func binaryExprFunc(typ1 DataType, typ2 DataType, op Token) interface{} {
 		switch typ2 {
 		case Float:
 			fn = floatBinaryExprFunc(op)
+		case Unsigned:
+			fn = unsignedBinaryExprFunc(op)
 		default:
 			fn = integerBinaryExprFunc(op)
 		}
+	case Unsigned:
+		switch typ2 {
+		case Float:
+			fn = floatBinaryExprFunc(op)
+		default:
+			fn = unsignedBinaryExprFunc(op)
+		}
 	case Boolean:
 		fn = booleanBinaryExprFunc(op)
 	}
@@ -1325,6 +1598,50 @@ func integerBinaryExprFunc(op Token) interface{} {
 	return nil
 }
 
+func unsignedBinaryExprFunc(op Token) interface{} {
+	switch op {
+	case ADD:
+		return func(lhs, rhs uint64) uint64 { return lhs + rhs }
+	case SUB:
+		return func(lhs, rhs uint64) uint64 { return lhs - rhs }
+	case MUL:
+		return func(lhs, rhs uint64) uint64 { return lhs * rhs }
+	case DIV:
+		return func(lhs, rhs uint64) float64 {
+			if rhs == 0 {
+				return float64(0)
+			}
+			return float64(lhs) / float64(rhs)
+		}
+	case MOD:
+		return func(lhs, rhs uint64) uint64 {
+			if rhs == 0 {
+				return uint64(0)
+			}
+			return lhs % rhs
+		}
+	case BITWISE_AND:
+		return func(lhs, rhs uint64) uint64 { return lhs & rhs }
+	case BITWISE_OR:
+		return func(lhs, rhs uint64) uint64 { return lhs | rhs }
+	case BITWISE_XOR:
+		return func(lhs, rhs uint64) uint64 { return lhs ^ rhs }
+	case EQ:
+		return func(lhs, rhs uint64) bool { return lhs == rhs }
+	case NEQ:
+		return func(lhs, rhs uint64) bool { return lhs != rhs }
+	case LT:
+		return func(lhs, rhs uint64) bool { return lhs < rhs }
+	case LTE:
+		return func(lhs, rhs uint64) bool { return lhs <= rhs }
+	case GT:
+		return func(lhs, rhs uint64) bool { return lhs > rhs }
+	case GTE:
+		return func(lhs, rhs uint64) bool { return lhs >= rhs }
+	}
+	return nil
+}
+
 func booleanBinaryExprFunc(op Token) interface{} {
 	switch op {
 	case BITWISE_AND:
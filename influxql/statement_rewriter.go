@@ -15,6 +15,14 @@ func RewriteStatement(stmt Statement) (Statement, error) {
 		return rewriteShowTagKeysStatement(stmt)
 	case *ShowTagValuesStatement:
 		return rewriteShowTagValuesStatement(stmt)
+	case *ShowSeriesCardinalityStatement:
+		return rewriteShowSeriesCardinalityStatement(stmt)
+	case *ShowMeasurementCardinalityStatement:
+		return rewriteShowMeasurementCardinalityStatement(stmt)
+	case *ShowTagValuesCardinalityStatement:
+		return rewriteShowTagValuesCardinalityStatement(stmt)
+	case *ShowFieldKeyCardinalityStatement:
+		return rewriteShowFieldKeyCardinalityStatement(stmt)
 	default:
 		return stmt, nil
 	}
@@ -38,11 +46,6 @@ func rewriteShowFieldKeysStatement(stmt *ShowFieldKeysStatement) (Statement, err
 }
 
 func rewriteShowMeasurementsStatement(stmt *ShowMeasurementsStatement) (Statement, error) {
-	// Check for time in WHERE clause (not supported).
-	if HasTimeExpr(stmt.Condition) {
-		return nil, errors.New("SHOW MEASUREMENTS doesn't support time in WHERE clause")
-	}
-
 	condition := stmt.Condition
 	if stmt.Source != nil {
 		condition = rewriteSourcesCondition(Sources([]Source{stmt.Source}), stmt.Condition)
@@ -57,11 +60,6 @@ func rewriteShowMeasurementsStatement(stmt *ShowMeasurementsStatement) (Statemen
 }
 
 func rewriteShowSeriesStatement(stmt *ShowSeriesStatement) (Statement, error) {
-	// Check for time in WHERE clause (not supported).
-	if HasTimeExpr(stmt.Condition) {
-		return nil, errors.New("SHOW SERIES doesn't support time in WHERE clause")
-	}
-
 	return &SelectStatement{
 		Fields: []*Field{
 			{Expr: &VarRef{Val: "key"}},
@@ -78,14 +76,25 @@ func rewriteShowSeriesStatement(stmt *ShowSeriesStatement) (Statement, error) {
 }
 
 func rewriteShowTagValuesStatement(stmt *ShowTagValuesStatement) (Statement, error) {
-	// Check for time in WHERE clause (not supported).
-	if HasTimeExpr(stmt.Condition) {
-		return nil, errors.New("SHOW TAG VALUES doesn't support time in WHERE clause")
-	}
+	condition := rewriteTagKeyCondition(stmt.Op, stmt.TagKeyExpr, stmt.Condition)
+	condition = rewriteSourcesCondition(stmt.Sources, condition)
 
-	condition := stmt.Condition
+	return &ShowTagValuesStatement{
+		Database:   stmt.Database,
+		Op:         stmt.Op,
+		TagKeyExpr: stmt.TagKeyExpr,
+		Condition:  condition,
+		SortFields: stmt.SortFields,
+		Limit:      stmt.Limit,
+		Offset:     stmt.Offset,
+	}, nil
+}
+
+// rewriteTagKeyCondition rewrites the tag key selection of a tag values statement
+// into a `_tagKey` expression.  Merges with cond and returns a new condition.
+func rewriteTagKeyCondition(op Token, tagKeyExpr Literal, cond Expr) Expr {
 	var expr Expr
-	if list, ok := stmt.TagKeyExpr.(*ListLiteral); ok {
+	if list, ok := tagKeyExpr.(*ListLiteral); ok {
 		for _, tagKey := range list.Vals {
 			tagExpr := &BinaryExpr{
 				Op:  EQ,
@@ -105,32 +114,75 @@ func rewriteShowTagValuesStatement(stmt *ShowTagValuesStatement) (Statement, err
 		}
 	} else {
 		expr = &BinaryExpr{
-			Op:  stmt.Op,
+			Op:  op,
 			LHS: &VarRef{Val: "_tagKey"},
-			RHS: stmt.TagKeyExpr,
+			RHS: tagKeyExpr,
 		}
 	}
 
 	// Set condition or "AND" together.
-	if condition == nil {
-		condition = expr
-	} else {
-		condition = &BinaryExpr{
-			Op:  AND,
-			LHS: &ParenExpr{Expr: condition},
-			RHS: &ParenExpr{Expr: expr},
-		}
+	if cond == nil {
+		return expr
 	}
-	condition = rewriteSourcesCondition(stmt.Sources, condition)
+	return &BinaryExpr{
+		Op:  AND,
+		LHS: &ParenExpr{Expr: cond},
+		RHS: &ParenExpr{Expr: expr},
+	}
+}
 
-	return &ShowTagValuesStatement{
+func rewriteShowSeriesCardinalityStatement(stmt *ShowSeriesCardinalityStatement) (Statement, error) {
+	// Check for time in WHERE clause (not supported).
+	if HasTimeExpr(stmt.Condition) {
+		return nil, errors.New("SHOW SERIES CARDINALITY doesn't support time in WHERE clause")
+	}
+
+	return &ShowSeriesCardinalityStatement{
+		Database:  stmt.Database,
+		Exact:     stmt.Exact,
+		Condition: rewriteSourcesCondition(stmt.Sources, stmt.Condition),
+	}, nil
+}
+
+func rewriteShowMeasurementCardinalityStatement(stmt *ShowMeasurementCardinalityStatement) (Statement, error) {
+	// Check for time in WHERE clause (not supported).
+	if HasTimeExpr(stmt.Condition) {
+		return nil, errors.New("SHOW MEASUREMENT CARDINALITY doesn't support time in WHERE clause")
+	}
+
+	return &ShowMeasurementCardinalityStatement{
+		Database:  stmt.Database,
+		Exact:     stmt.Exact,
+		Condition: rewriteSourcesCondition(stmt.Sources, stmt.Condition),
+	}, nil
+}
+
+func rewriteShowTagValuesCardinalityStatement(stmt *ShowTagValuesCardinalityStatement) (Statement, error) {
+	// Check for time in WHERE clause (not supported).
+	if HasTimeExpr(stmt.Condition) {
+		return nil, errors.New("SHOW TAG VALUES CARDINALITY doesn't support time in WHERE clause")
+	}
+
+	condition := rewriteTagKeyCondition(stmt.Op, stmt.TagKeyExpr, stmt.Condition)
+	return &ShowTagValuesCardinalityStatement{
 		Database:   stmt.Database,
+		Exact:      stmt.Exact,
 		Op:         stmt.Op,
 		TagKeyExpr: stmt.TagKeyExpr,
-		Condition:  condition,
-		SortFields: stmt.SortFields,
-		Limit:      stmt.Limit,
-		Offset:     stmt.Offset,
+		Condition:  rewriteSourcesCondition(stmt.Sources, condition),
+	}, nil
+}
+
+func rewriteShowFieldKeyCardinalityStatement(stmt *ShowFieldKeyCardinalityStatement) (Statement, error) {
+	// Check for time in WHERE clause (not supported).
+	if HasTimeExpr(stmt.Condition) {
+		return nil, errors.New("SHOW FIELD KEY CARDINALITY doesn't support time in WHERE clause")
+	}
+
+	return &ShowFieldKeyCardinalityStatement{
+		Database:  stmt.Database,
+		Exact:     stmt.Exact,
+		Condition: rewriteSourcesCondition(stmt.Sources, stmt.Condition),
 	}, nil
 }
 
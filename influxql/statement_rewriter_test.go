@@ -139,6 +139,26 @@ func TestRewriteStatement(t *testing.T) {
 			stmt: `SHOW TAG KEYS ON db0 FROM mydb.myrp1.cpu WHERE region = 'uswest'`,
 			s:    `SELECT tagKey FROM mydb.myrp1._tagKeys WHERE (_name = 'cpu') AND (region = 'uswest')`,
 		},
+		{
+			stmt: `SHOW SERIES CARDINALITY`,
+			s:    `SHOW SERIES CARDINALITY`,
+		},
+		{
+			stmt: `SHOW SERIES EXACT CARDINALITY ON db0 FROM cpu WHERE region = 'uswest'`,
+			s:    `SHOW SERIES EXACT CARDINALITY ON db0 WHERE (_name = 'cpu') AND (region = 'uswest')`,
+		},
+		{
+			stmt: `SHOW MEASUREMENT CARDINALITY FROM /c.*/`,
+			s:    `SHOW MEASUREMENT CARDINALITY WHERE _name =~ /c.*/`,
+		},
+		{
+			stmt: `SHOW TAG VALUES EXACT CARDINALITY FROM cpu WITH KEY = host WHERE region = 'uswest'`,
+			s:    `SHOW TAG VALUES EXACT CARDINALITY WITH KEY = host WHERE (_name = 'cpu') AND ((region = 'uswest') AND (_tagKey = 'host'))`,
+		},
+		{
+			stmt: `SHOW FIELD KEY CARDINALITY ON db0 FROM cpu, mem`,
+			s:    `SHOW FIELD KEY CARDINALITY ON db0 WHERE _name = 'cpu' OR _name = 'mem'`,
+		},
 		{
 			stmt: `SELECT value FROM cpu`,
 			s:    `SELECT value FROM cpu`,
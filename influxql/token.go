@@ -82,6 +82,7 @@ const (
 	DESTINATIONS
 	DIAGNOSTICS
 	DISTINCT
+	DOWNSAMPLE
 	DROP
 	DURATION
 	END
@@ -104,6 +105,7 @@ const (
 	LIMIT
 	MEASUREMENT
 	MEASUREMENTS
+	MERGE
 	NAME
 	OFFSET
 	ON
@@ -127,6 +129,7 @@ const (
 	SHARDS
 	SLIMIT
 	SOFFSET
+	SPLIT
 	STATS
 	SUBSCRIPTION
 	SUBSCRIPTIONS
@@ -202,6 +205,7 @@ var tokens = [...]string{
 	DESTINATIONS:  "DESTINATIONS",
 	DIAGNOSTICS:   "DIAGNOSTICS",
 	DISTINCT:      "DISTINCT",
+	DOWNSAMPLE:    "DOWNSAMPLE",
 	DROP:          "DROP",
 	DURATION:      "DURATION",
 	END:           "END",
@@ -224,6 +228,7 @@ var tokens = [...]string{
 	LIMIT:         "LIMIT",
 	MEASUREMENT:   "MEASUREMENT",
 	MEASUREMENTS:  "MEASUREMENTS",
+	MERGE:         "MERGE",
 	NAME:          "NAME",
 	OFFSET:        "OFFSET",
 	ON:            "ON",
@@ -247,6 +252,7 @@ var tokens = [...]string{
 	SHARDS:        "SHARDS",
 	SLIMIT:        "SLIMIT",
 	SOFFSET:       "SOFFSET",
+	SPLIT:         "SPLIT",
 	STATS:         "STATS",
 	SUBSCRIPTION:  "SUBSCRIPTION",
 	SUBSCRIPTIONS: "SUBSCRIPTIONS",
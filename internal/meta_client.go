@@ -30,15 +30,22 @@ type MetaClientMock struct {
 	DropShardFn           func(id uint64) error
 	DropUserFn            func(name string) error
 
+	MergeableShardGroupsFn func(ids []uint64) ([]meta.ShardGroupInfo, error)
+	MergeShardGroupsFn     func(ids []uint64) error
+	UnmergeShardGroupsFn   func(groups []meta.ShardGroupInfo) error
+
 	OpenFn func() error
 
 	RetentionPolicyFn func(database, name string) (rpi *meta.RetentionPolicyInfo, err error)
 
 	SetAdminPrivilegeFn      func(username string, admin bool) error
 	SetDataFn                func(*meta.Data) error
+	SetMeasurementQuotaFn    func(database, name, key string, n int) error
 	SetPrivilegeFn           func(username, database string, p influxql.Privilege) error
 	ShardGroupsByTimeRangeFn func(database, policy string, min, max time.Time) (a []meta.ShardGroupInfo, err error)
+	ShardGroupSplitsFn       func(id uint64, n int) ([]meta.ShardGroupInfo, error)
 	ShardOwnerFn             func(shardID uint64) (database, policy string, sgi *meta.ShardGroupInfo)
+	SplitShardGroupFn        func(id uint64, n int) ([]meta.ShardGroupInfo, error)
 	UpdateRetentionPolicyFn  func(database, name string, rpu *meta.RetentionPolicyUpdate, makeDefault bool) error
 	UpdateUserFn             func(name, password string) error
 	UserPrivilegeFn          func(username, database string) (*influxql.Privilege, error)
@@ -114,6 +121,18 @@ func (c *MetaClientMock) DropUser(name string) error {
 	return c.DropUserFn(name)
 }
 
+func (c *MetaClientMock) MergeableShardGroups(ids []uint64) ([]meta.ShardGroupInfo, error) {
+	return c.MergeableShardGroupsFn(ids)
+}
+
+func (c *MetaClientMock) MergeShardGroups(ids []uint64) error {
+	return c.MergeShardGroupsFn(ids)
+}
+
+func (c *MetaClientMock) UnmergeShardGroups(groups []meta.ShardGroupInfo) error {
+	return c.UnmergeShardGroupsFn(groups)
+}
+
 func (c *MetaClientMock) RetentionPolicy(database, name string) (rpi *meta.RetentionPolicyInfo, err error) {
 	return c.RetentionPolicyFn(database, name)
 }
@@ -122,6 +141,10 @@ func (c *MetaClientMock) SetAdminPrivilege(username string, admin bool) error {
 	return c.SetAdminPrivilegeFn(username, admin)
 }
 
+func (c *MetaClientMock) SetMeasurementQuota(database, name, key string, n int) error {
+	return c.SetMeasurementQuotaFn(database, name, key, n)
+}
+
 func (c *MetaClientMock) SetPrivilege(username, database string, p influxql.Privilege) error {
 	return c.SetPrivilegeFn(username, database, p)
 }
@@ -134,6 +157,14 @@ func (c *MetaClientMock) ShardOwner(shardID uint64) (database, policy string, sg
 	return c.ShardOwnerFn(shardID)
 }
 
+func (c *MetaClientMock) ShardGroupSplits(id uint64, n int) ([]meta.ShardGroupInfo, error) {
+	return c.ShardGroupSplitsFn(id, n)
+}
+
+func (c *MetaClientMock) SplitShardGroup(id uint64, n int) ([]meta.ShardGroupInfo, error) {
+	return c.SplitShardGroupFn(id, n)
+}
+
 func (c *MetaClientMock) UpdateRetentionPolicy(database, name string, rpu *meta.RetentionPolicyUpdate, makeDefault bool) error {
 	return c.UpdateRetentionPolicyFn(database, name, rpu, makeDefault)
 }
@@ -20,6 +20,7 @@ var (
 	fields = models.Fields{
 		"int64":         int64(math.MaxInt64),
 		"uint32":        uint32(math.MaxUint32),
+		"uint64":        uint64(math.MaxUint64),
 		"string":        "String field that has a decent length, probably some log message or something",
 		"boolean":       false,
 		"float64-tiny":  float64(math.SmallestNonzeroFloat64),
@@ -579,6 +580,34 @@ func TestParsePointMinInt64(t *testing.T) {
 	}
 }
 
+func TestParsePointMaxUint64(t *testing.T) {
+	// out of range
+	_, err := models.ParsePointsString(`cpu,host=serverA,region=us-west value=18446744073709551616u`)
+	exp := `unable to parse 'cpu,host=serverA,region=us-west value=18446744073709551616u': unable to parse unsigned 18446744073709551616: strconv.ParseUint: parsing "18446744073709551616": value out of range`
+	if err == nil || (err != nil && err.Error() != exp) {
+		t.Fatalf("Error mismatch:\nexp: %s\ngot: %v", exp, err)
+	}
+
+	// max uint
+	p, err := models.ParsePointsString(`cpu,host=serverA,region=us-west value=18446744073709551615u`)
+	if err != nil {
+		t.Fatalf(`ParsePoints("%s") mismatch. got %v, exp nil`, `cpu,host=serverA,region=us-west value=18446744073709551615u`, err)
+	}
+	fields, err := p[0].Fields()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp, got := uint64(18446744073709551615), fields["value"].(uint64); exp != got {
+		t.Fatalf("ParsePoints Value mismatch. \nexp: %v\ngot: %v", exp, got)
+	}
+
+	// leading zeros
+	_, err = models.ParsePointsString(`cpu,host=serverA,region=us-west value=00018446744073709551615u`)
+	if err != nil {
+		t.Fatalf(`ParsePoints("%s") mismatch. got %v, exp nil`, `cpu,host=serverA,region=us-west value=00018446744073709551615u`, err)
+	}
+}
+
 func TestParsePointMaxFloat64(t *testing.T) {
 	// out of range
 	_, err := models.ParsePointsString(fmt.Sprintf(`cpu,host=serverA,region=us-west value=%s`, "1"+string(maxFloat64)))
@@ -661,6 +690,34 @@ func TestParsePointNegativeInteger(t *testing.T) {
 	}
 }
 
+func TestParsePointUnsigned(t *testing.T) {
+	_, err := models.ParsePointsString(`cpu,host=serverA,region=us-west value=1u`)
+	if err != nil {
+		t.Errorf(`ParsePoints("%s") mismatch. got %v, exp nil`, `cpu,host=serverA,region=us-west value=1u`, err)
+	}
+}
+
+func TestParsePointNegativeUnsigned(t *testing.T) {
+	_, err := models.ParsePointsString(`cpu,host=serverA,region=us-west value=-1u`)
+	if err == nil {
+		t.Errorf(`ParsePoints("%s") mismatch. got nil, exp error`, `cpu,host=serverA,region=us-west value=-1u`)
+	}
+}
+
+func TestParsePointUnsignedInvalid(t *testing.T) {
+	for _, s := range []string{
+		`cpu value=1.5u`,
+		`cpu value=1e4u`,
+		`cpu value=1iu`,
+		`cpu value=1ui`,
+		`cpu value=9u10`,
+	} {
+		if _, err := models.ParsePointsString(s); err == nil {
+			t.Errorf(`ParsePoints("%s") mismatch. got nil, exp error`, s)
+		}
+	}
+}
+
 func TestParsePointNegativeFloat(t *testing.T) {
 	_, err := models.ParsePointsString(`cpu,host=serverA,region=us-west value=-1.0`)
 	if err != nil {
@@ -2137,6 +2194,34 @@ func TestPoint_FieldIterator_Simple(t *testing.T) {
 	}
 }
 
+func TestPoint_FieldIterator_Unsigned(t *testing.T) {
+	p, err := models.ParsePoints([]byte(`m v=42u 36`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi := p[0].FieldIterator()
+	if !fi.Next() {
+		t.Fatal("field iterator terminated before first field")
+	}
+
+	if fi.Type() != models.Unsigned {
+		t.Fatalf("'42u' should be an Unsigned, got %v", fi.Type())
+	}
+
+	uv, err := fi.UnsignedValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp, got := uint64(42), uv; exp != got {
+		t.Fatalf("'42u' should be %d, got %d", exp, got)
+	}
+
+	if fi.Next() {
+		t.Fatal("field iterator didn't terminate")
+	}
+}
+
 func toFields(fi models.FieldIterator) models.Fields {
 	m := make(models.Fields)
 	for fi.Next() {
@@ -2147,6 +2232,8 @@ func toFields(fi models.FieldIterator) models.Fields {
 			v, err = fi.FloatValue()
 		case models.Integer:
 			v, err = fi.IntegerValue()
+		case models.Unsigned:
+			v, err = fi.UnsignedValue()
 		case models.String:
 			v = fi.StringValue()
 		case models.Boolean:
@@ -2169,6 +2256,7 @@ func TestPoint_FieldIterator_FieldMap(t *testing.T) {
 	points, err := models.ParsePointsString(`
 m v=42
 m v=42i
+m v=42u
 m v="string"
 m v=true
 m v="string\"with\"escapes"
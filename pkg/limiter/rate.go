@@ -0,0 +1,61 @@
+package limiter
+
+import (
+	"sync"
+	"time"
+)
+
+// Rate limits the rate at which work, measured in units such as bytes, is done.
+// A Rate is started when it is created and should be used for a single run of work.
+type Rate struct {
+	mu    sync.Mutex
+	limit int
+	start time.Time
+	n     int64
+}
+
+// NewRate returns a Rate allowing limit units of work per second.  A limit of 0
+// does not limit the rate.
+func NewRate(limit int) *Rate {
+	return &Rate{
+		limit: limit,
+		start: time.Now(),
+	}
+}
+
+// Wait records n units of work and blocks until they can be done without exceeding
+// the limit.  It returns false without waiting any longer once closing is closed.
+// Wait may be called on a nil Rate, which does not limit the rate.
+func (r *Rate) Wait(n int, closing <-chan struct{}) bool {
+	if r == nil || r.limit <= 0 {
+		select {
+		case <-closing:
+			return false
+		default:
+			return true
+		}
+	}
+
+	r.mu.Lock()
+	r.n += int64(n)
+	d := time.Duration(float64(r.n)/float64(r.limit)*float64(time.Second)) - time.Since(r.start)
+	r.mu.Unlock()
+
+	if d <= 0 {
+		select {
+		case <-closing:
+			return false
+		default:
+			return true
+		}
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-closing:
+		return false
+	}
+}
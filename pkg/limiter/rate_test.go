@@ -0,0 +1,63 @@
+package limiter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/pkg/limiter"
+)
+
+// Ensure work is delayed once it exceeds the rate limit.
+func TestRate_Wait(t *testing.T) {
+	r := limiter.NewRate(1000)
+
+	start := time.Now()
+	if !r.Wait(100, nil) {
+		t.Fatal("expected wait to succeed")
+	} else if !r.Wait(100, nil) {
+		t.Fatal("expected wait to succeed")
+	}
+
+	// 200 units at 1000 units per second take at least 200ms.
+	if d := time.Since(start); d < 190*time.Millisecond {
+		t.Fatalf("work not limited: took %v", d)
+	} else if d > 2*time.Second {
+		t.Fatalf("work limited too much: took %v", d)
+	}
+}
+
+// Ensure a zero limit or a nil Rate does not limit work.
+func TestRate_Wait_Unlimited(t *testing.T) {
+	for _, r := range []*limiter.Rate{nil, limiter.NewRate(0)} {
+		start := time.Now()
+		for i := 0; i < 100; i++ {
+			if !r.Wait(1<<30, nil) {
+				t.Fatal("expected wait to succeed")
+			}
+		}
+		if d := time.Since(start); d > time.Second {
+			t.Fatalf("work limited: took %v", d)
+		}
+	}
+}
+
+// Ensure waiting stops once closing is closed.
+func TestRate_Wait_Closing(t *testing.T) {
+	r := limiter.NewRate(1)
+	closing := make(chan struct{})
+	time.AfterFunc(10*time.Millisecond, func() { close(closing) })
+
+	start := time.Now()
+	if r.Wait(1000, closing) {
+		t.Fatal("expected wait to be canceled")
+	} else if d := time.Since(start); d > 5*time.Second {
+		t.Fatalf("wait not canceled: took %v", d)
+	}
+
+	// Closed rates return immediately, including unlimited ones.
+	if r.Wait(0, closing) {
+		t.Fatal("expected wait to be canceled")
+	} else if limiter.NewRate(0).Wait(1, closing) {
+		t.Fatal("expected unlimited wait to be canceled")
+	}
+}
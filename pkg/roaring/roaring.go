@@ -0,0 +1,565 @@
+// Package roaring implements compressed bitmaps for sets of integers.
+//
+// Values are partitioned by their upper 48 bits into containers which hold the
+// lower 16 bits of the values.  A container is stored as a sorted array while it
+// has few values and as a bitmap once it becomes dense.  Set operations are done
+// container by container.
+package roaring
+
+import (
+	"encoding/binary"
+	"errors"
+	"sort"
+)
+
+const (
+	// arrayMaxSize is the maximum number of values of an array container.  Above
+	// this size a bitmap container is smaller.
+	arrayMaxSize = 4096
+
+	// bitmapN is the number of words in a bitmap container.
+	bitmapN = (1 << 16) / 64
+)
+
+// ErrInvalidBitmap is returned when unmarshaling a malformed bitmap.
+var ErrInvalidBitmap = errors.New("invalid bitmap encoding")
+
+// Bitmap represents a compressed set of uint64 values.
+type Bitmap struct {
+	containers []*container // sorted by key
+}
+
+// NewBitmap returns a new bitmap containing values.
+func NewBitmap(values ...uint64) *Bitmap {
+	b := &Bitmap{}
+	for _, v := range values {
+		b.Add(v)
+	}
+	return b
+}
+
+// Add adds v to the bitmap.  Returns true if v was not already in the bitmap.
+func (b *Bitmap) Add(v uint64) bool {
+	key := highBits(v)
+	i := b.search(key)
+	if i == len(b.containers) || b.containers[i].key != key {
+		b.containers = append(b.containers, nil)
+		copy(b.containers[i+1:], b.containers[i:])
+		b.containers[i] = &container{key: key}
+	}
+	return b.containers[i].add(lowBits(v))
+}
+
+// Remove removes v from the bitmap.  Returns true if v was in the bitmap.
+func (b *Bitmap) Remove(v uint64) bool {
+	key := highBits(v)
+	i := b.search(key)
+	if i == len(b.containers) || b.containers[i].key != key {
+		return false
+	}
+
+	c := b.containers[i]
+	if !c.remove(lowBits(v)) {
+		return false
+	} else if c.n == 0 {
+		b.containers = append(b.containers[:i], b.containers[i+1:]...)
+	}
+	return true
+}
+
+// Contains returns true if v is in the bitmap.
+func (b *Bitmap) Contains(v uint64) bool {
+	key := highBits(v)
+	i := b.search(key)
+	if i == len(b.containers) || b.containers[i].key != key {
+		return false
+	}
+	return b.containers[i].contains(lowBits(v))
+}
+
+// Cardinality returns the number of values in the bitmap.
+func (b *Bitmap) Cardinality() uint64 {
+	var n uint64
+	for _, c := range b.containers {
+		n += uint64(c.n)
+	}
+	return n
+}
+
+// IsEmpty returns true if the bitmap has no values.
+func (b *Bitmap) IsEmpty() bool { return len(b.containers) == 0 }
+
+// Slice returns the values of the bitmap in ascending order.
+func (b *Bitmap) Slice() []uint64 {
+	a := make([]uint64, 0, b.Cardinality())
+	itr := b.Iterator()
+	for v, ok := itr.Next(); ok; v, ok = itr.Next() {
+		a = append(a, v)
+	}
+	return a
+}
+
+// Select returns the value at index i of the sorted values.  Returns false if i is
+// not less than the cardinality.
+func (b *Bitmap) Select(i uint64) (uint64, bool) {
+	for _, c := range b.containers {
+		if i >= uint64(c.n) {
+			i -= uint64(c.n)
+			continue
+		}
+		return c.key<<16 | uint64(c.nth(int(i))), true
+	}
+	return 0, false
+}
+
+// And returns the intersection of b and other.
+func (b *Bitmap) And(other *Bitmap) *Bitmap {
+	result := &Bitmap{}
+	for i, j := 0, 0; i < len(b.containers) && j < len(other.containers); {
+		c0, c1 := b.containers[i], other.containers[j]
+		if c0.key < c1.key {
+			i++
+		} else if c0.key > c1.key {
+			j++
+		} else {
+			if c := c0.and(c1); c.n > 0 {
+				result.containers = append(result.containers, c)
+			}
+			i, j = i+1, j+1
+		}
+	}
+	return result
+}
+
+// Or returns the union of b and other.
+func (b *Bitmap) Or(other *Bitmap) *Bitmap {
+	result := &Bitmap{containers: make([]*container, 0, len(b.containers)+len(other.containers))}
+	i, j := 0, 0
+	for i < len(b.containers) && j < len(other.containers) {
+		c0, c1 := b.containers[i], other.containers[j]
+		if c0.key < c1.key {
+			result.containers = append(result.containers, c0.clone())
+			i++
+		} else if c0.key > c1.key {
+			result.containers = append(result.containers, c1.clone())
+			j++
+		} else {
+			result.containers = append(result.containers, c0.or(c1))
+			i, j = i+1, j+1
+		}
+	}
+	for ; i < len(b.containers); i++ {
+		result.containers = append(result.containers, b.containers[i].clone())
+	}
+	for ; j < len(other.containers); j++ {
+		result.containers = append(result.containers, other.containers[j].clone())
+	}
+	return result
+}
+
+// AndNot returns the values of b that are not in other.
+func (b *Bitmap) AndNot(other *Bitmap) *Bitmap {
+	result := &Bitmap{}
+	j := 0
+	for _, c0 := range b.containers {
+		for j < len(other.containers) && other.containers[j].key < c0.key {
+			j++
+		}
+		if j == len(other.containers) || other.containers[j].key != c0.key {
+			result.containers = append(result.containers, c0.clone())
+			continue
+		}
+
+		if c := c0.andNot(other.containers[j]); c.n > 0 {
+			result.containers = append(result.containers, c)
+		}
+	}
+	return result
+}
+
+// Iterator returns an iterator over the values of the bitmap in ascending order.
+// The bitmap must not be modified while iterating.
+func (b *Bitmap) Iterator() *Iterator {
+	return &Iterator{containers: b.containers}
+}
+
+// MarshalBinary encodes the bitmap to a binary format.
+func (b *Bitmap) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 4, b.Size())
+	binary.BigEndian.PutUint32(buf, uint32(len(b.containers)))
+
+	var hdr [12]byte
+	for _, c := range b.containers {
+		binary.BigEndian.PutUint64(hdr[:8], c.key)
+		binary.BigEndian.PutUint32(hdr[8:], uint32(c.n))
+		buf = append(buf, hdr[:]...)
+
+		if c.bitmap == nil {
+			for _, v := range c.array {
+				buf = append(buf, byte(v>>8), byte(v))
+			}
+		} else {
+			var w [8]byte
+			for _, word := range c.bitmap {
+				binary.BigEndian.PutUint64(w[:], word)
+				buf = append(buf, w[:]...)
+			}
+		}
+	}
+	return buf, nil
+}
+
+// Size returns the number of bytes of the binary format of the bitmap.
+func (b *Bitmap) Size() int {
+	n := 4
+	for _, c := range b.containers {
+		n += 12
+		if c.bitmap == nil {
+			n += 2 * len(c.array)
+		} else {
+			n += 8 * bitmapN
+		}
+	}
+	return n
+}
+
+// UnmarshalBinary decodes data into the bitmap.  The data is copied.
+func (b *Bitmap) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 {
+		return ErrInvalidBitmap
+	}
+	n, data := int(binary.BigEndian.Uint32(data)), data[4:]
+	if n > len(data)/12 {
+		return ErrInvalidBitmap
+	}
+
+	b.containers = make([]*container, 0, n)
+	for i := 0; i < n; i++ {
+		if len(data) < 12 {
+			return ErrInvalidBitmap
+		}
+		c := &container{key: binary.BigEndian.Uint64(data), n: int(binary.BigEndian.Uint32(data[8:]))}
+		data = data[12:]
+
+		if c.n == 0 || c.n > 1<<16 {
+			return ErrInvalidBitmap
+		} else if len(b.containers) > 0 && b.containers[len(b.containers)-1].key >= c.key {
+			return ErrInvalidBitmap
+		}
+
+		if c.n <= arrayMaxSize {
+			if len(data) < 2*c.n {
+				return ErrInvalidBitmap
+			}
+			c.array = make([]uint16, c.n)
+			for j := range c.array {
+				c.array[j] = binary.BigEndian.Uint16(data[2*j:])
+			}
+			data = data[2*c.n:]
+		} else {
+			if len(data) < 8*bitmapN {
+				return ErrInvalidBitmap
+			}
+			c.bitmap = make([]uint64, bitmapN)
+			for j := range c.bitmap {
+				c.bitmap[j] = binary.BigEndian.Uint64(data[8*j:])
+			}
+			data = data[8*bitmapN:]
+		}
+		b.containers = append(b.containers, c)
+	}
+
+	if len(data) != 0 {
+		return ErrInvalidBitmap
+	}
+	return nil
+}
+
+// search returns the index of the container for key or where it would be inserted.
+func (b *Bitmap) search(key uint64) int {
+	return sort.Search(len(b.containers), func(i int) bool { return b.containers[i].key >= key })
+}
+
+// Iterator iterates over the values of a bitmap.
+type Iterator struct {
+	containers []*container
+	i          int // index within current container
+	word       uint64
+}
+
+// Next returns the next value.  Returns false if there are no more values.
+func (itr *Iterator) Next() (uint64, bool) {
+	for len(itr.containers) > 0 {
+		c := itr.containers[0]
+		if c.bitmap == nil {
+			if itr.i < len(c.array) {
+				v := c.array[itr.i]
+				itr.i++
+				return c.key<<16 | uint64(v), true
+			}
+		} else {
+			// Find the next set bit, starting from the remaining bits of the
+			// current word.
+			for itr.word == 0 && itr.i < bitmapN {
+				itr.word = c.bitmap[itr.i]
+				itr.i++
+			}
+			if itr.word != 0 {
+				t := itr.word & -itr.word
+				itr.word ^= t
+				return c.key<<16 | uint64((itr.i-1)*64+trailingZeros(t)), true
+			}
+		}
+
+		itr.containers, itr.i, itr.word = itr.containers[1:], 0, 0
+	}
+	return 0, false
+}
+
+// container holds the lower 16 bits of the values which share the upper 48 bits.
+type container struct {
+	key    uint64
+	n      int      // cardinality
+	array  []uint16 // sorted values, if bitmap is nil
+	bitmap []uint64 // bitmapN words, if not nil
+}
+
+func (c *container) add(v uint16) bool {
+	if c.bitmap != nil {
+		w, bit := v/64, uint64(1)<<(v%64)
+		if c.bitmap[w]&bit != 0 {
+			return false
+		}
+		c.bitmap[w] |= bit
+		c.n++
+		return true
+	}
+
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= v })
+	if i < len(c.array) && c.array[i] == v {
+		return false
+	}
+	c.array = append(c.array, 0)
+	copy(c.array[i+1:], c.array[i:])
+	c.array[i] = v
+	c.n++
+
+	if c.n > arrayMaxSize {
+		c.bitmap, c.array = c.words(), nil
+	}
+	return true
+}
+
+func (c *container) remove(v uint16) bool {
+	if c.bitmap != nil {
+		w, bit := v/64, uint64(1)<<(v%64)
+		if c.bitmap[w]&bit == 0 {
+			return false
+		}
+		c.bitmap[w] &^= bit
+		c.n--
+
+		if c.n <= arrayMaxSize {
+			c.array, c.bitmap = c.values(), nil
+		}
+		return true
+	}
+
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= v })
+	if i == len(c.array) || c.array[i] != v {
+		return false
+	}
+	c.array = append(c.array[:i], c.array[i+1:]...)
+	c.n--
+	return true
+}
+
+func (c *container) contains(v uint16) bool {
+	if c.bitmap != nil {
+		return c.bitmap[v/64]&(uint64(1)<<(v%64)) != 0
+	}
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= v })
+	return i < len(c.array) && c.array[i] == v
+}
+
+// nth returns the value at index i of the container's sorted values.
+func (c *container) nth(i int) uint16 {
+	if c.bitmap == nil {
+		return c.array[i]
+	}
+	for w, word := range c.bitmap {
+		if n := popcount(word); i >= n {
+			i -= n
+			continue
+		}
+		for ; i > 0; i-- {
+			word &= word - 1 // clear lowest set bit
+		}
+		return uint16(w*64 + trailingZeros(word&-word))
+	}
+	return 0
+}
+
+func (c *container) clone() *container {
+	other := &container{key: c.key, n: c.n}
+	if c.bitmap != nil {
+		other.bitmap = make([]uint64, bitmapN)
+		copy(other.bitmap, c.bitmap)
+	} else {
+		other.array = make([]uint16, len(c.array))
+		copy(other.array, c.array)
+	}
+	return other
+}
+
+func (c *container) and(other *container) *container {
+	if c.bitmap != nil && other.bitmap != nil {
+		words := make([]uint64, bitmapN)
+		for i := range words {
+			words[i] = c.bitmap[i] & other.bitmap[i]
+		}
+		return newBitmapContainer(c.key, words)
+	}
+
+	// Check the values of the array against the other container.
+	if c.bitmap != nil {
+		c, other = other, c
+	}
+	result := &container{key: c.key}
+	for _, v := range c.array {
+		if other.contains(v) {
+			result.array = append(result.array, v)
+		}
+	}
+	result.n = len(result.array)
+	return result
+}
+
+func (c *container) or(other *container) *container {
+	if c.bitmap == nil && other.bitmap == nil && c.n+other.n <= arrayMaxSize {
+		result := &container{key: c.key, array: make([]uint16, 0, c.n+other.n)}
+		i, j := 0, 0
+		for i < len(c.array) && j < len(other.array) {
+			if v0, v1 := c.array[i], other.array[j]; v0 < v1 {
+				result.array = append(result.array, v0)
+				i++
+			} else if v0 > v1 {
+				result.array = append(result.array, v1)
+				j++
+			} else {
+				result.array = append(result.array, v0)
+				i, j = i+1, j+1
+			}
+		}
+		result.array = append(result.array, c.array[i:]...)
+		result.array = append(result.array, other.array[j:]...)
+		result.n = len(result.array)
+		return result
+	}
+
+	words := c.words()
+	if other.bitmap != nil {
+		for i := range words {
+			words[i] |= other.bitmap[i]
+		}
+	} else {
+		for _, v := range other.array {
+			words[v/64] |= uint64(1) << (v % 64)
+		}
+	}
+	return newBitmapContainer(c.key, words)
+}
+
+func (c *container) andNot(other *container) *container {
+	if c.bitmap == nil {
+		result := &container{key: c.key}
+		for _, v := range c.array {
+			if !other.contains(v) {
+				result.array = append(result.array, v)
+			}
+		}
+		result.n = len(result.array)
+		return result
+	}
+
+	words := c.words()
+	if other.bitmap != nil {
+		for i := range words {
+			words[i] &^= other.bitmap[i]
+		}
+	} else {
+		for _, v := range other.array {
+			words[v/64] &^= uint64(1) << (v % 64)
+		}
+	}
+	return newBitmapContainer(c.key, words)
+}
+
+// words returns a copy of the container's values as a bitmap.
+func (c *container) words() []uint64 {
+	words := make([]uint64, bitmapN)
+	if c.bitmap != nil {
+		copy(words, c.bitmap)
+		return words
+	}
+	for _, v := range c.array {
+		words[v/64] |= uint64(1) << (v % 64)
+	}
+	return words
+}
+
+// values returns the container's values as a sorted array.
+func (c *container) values() []uint16 {
+	if c.bitmap == nil {
+		return c.array
+	}
+
+	a := make([]uint16, 0, c.n)
+	for i, word := range c.bitmap {
+		for word != 0 {
+			t := word & -word
+			word ^= t
+			a = append(a, uint16(i*64+trailingZeros(t)))
+		}
+	}
+	return a
+}
+
+// newBitmapContainer returns a container for the values of a bitmap, converted to
+// an array if the values are sparse.
+func newBitmapContainer(key uint64, words []uint64) *container {
+	c := &container{key: key, bitmap: words}
+	for _, word := range words {
+		c.n += popcount(word)
+	}
+	if c.n <= arrayMaxSize {
+		c.array, c.bitmap = c.values(), nil
+	}
+	return c
+}
+
+func highBits(v uint64) uint64 { return v >> 16 }
+func lowBits(v uint64) uint16  { return uint16(v) }
+
+// popcount returns the number of set bits in x.
+func popcount(x uint64) int {
+	x = x - ((x >> 1) & 0x5555555555555555)
+	x = (x & 0x3333333333333333) + ((x >> 2) & 0x3333333333333333)
+	x = (x + (x >> 4)) & 0x0f0f0f0f0f0f0f0f
+	return int((x * 0x0101010101010101) >> 56)
+}
+
+// trailingZeros returns the number of trailing zero bits of x, which must have
+// exactly one bit set.
+func trailingZeros(x uint64) int {
+	return int(deBruijn64tab[(x*deBruijn64)>>58])
+}
+
+const deBruijn64 = 0x03f79d71b4ca8b09
+
+var deBruijn64tab = [64]byte{
+	0, 1, 56, 2, 57, 49, 28, 3, 61, 58, 42, 50, 38, 29, 17, 4,
+	62, 47, 59, 36, 45, 43, 51, 22, 53, 39, 33, 30, 24, 18, 12, 5,
+	63, 55, 48, 27, 60, 41, 37, 16, 46, 35, 44, 21, 52, 32, 23, 11,
+	54, 26, 40, 15, 34, 20, 31, 10, 25, 14, 19, 9, 13, 8, 7, 6,
+}
@@ -0,0 +1,164 @@
+package roaring_test
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/influxdata/influxdb/pkg/roaring"
+)
+
+// Ensure values can be added to, removed from and looked up in a bitmap.
+func TestBitmap(t *testing.T) {
+	b := roaring.NewBitmap(3, 1, 1<<20, 2)
+	if !b.Add(5) {
+		t.Fatal("expected value to be added")
+	} else if b.Add(3) {
+		t.Fatal("expected duplicate value not to be added")
+	}
+
+	if !b.Contains(1<<20) || !b.Contains(5) {
+		t.Fatal("expected values to exist")
+	} else if b.Contains(4) || b.Contains(1<<21) {
+		t.Fatal("expected values not to exist")
+	}
+
+	if !b.Remove(2) {
+		t.Fatal("expected value to be removed")
+	} else if b.Remove(2) {
+		t.Fatal("expected missing value not to be removed")
+	} else if !b.Remove(1 << 20) {
+		t.Fatal("expected value to be removed")
+	}
+
+	if got, exp := b.Slice(), []uint64{1, 3, 5}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected values: %v", got)
+	} else if b.Cardinality() != 3 {
+		t.Fatalf("unexpected cardinality: %d", b.Cardinality())
+	}
+}
+
+// Ensure dense containers are converted to bitmaps and back to arrays.
+func TestBitmap_Dense(t *testing.T) {
+	b := roaring.NewBitmap()
+	for v := uint64(0); v < 10000; v += 2 {
+		b.Add(v)
+	}
+	if b.Cardinality() != 5000 {
+		t.Fatalf("unexpected cardinality: %d", b.Cardinality())
+	} else if !b.Contains(9998) || b.Contains(9999) {
+		t.Fatal("unexpected membership")
+	}
+
+	for v := uint64(0); v < 2000; v += 2 {
+		b.Remove(v)
+	}
+	if got := b.Slice(); len(got) != 4000 || got[0] != 2000 || got[len(got)-1] != 9998 {
+		t.Fatalf("unexpected values: %d values", len(got))
+	}
+}
+
+// Ensure values are selected by index across array and bitmap containers.
+func TestBitmap_Select(t *testing.T) {
+	var values []uint64
+	for v := uint64(0); v < 10000; v += 2 {
+		values = append(values, v)
+	}
+	values = append(values, 1<<20, 1<<20+7)
+
+	b := roaring.NewBitmap(values...)
+	for i, exp := range values {
+		if v, ok := b.Select(uint64(i)); !ok || v != exp {
+			t.Fatalf("%d: unexpected value: %d, %v", i, v, ok)
+		}
+	}
+	if _, ok := b.Select(uint64(len(values))); ok {
+		t.Fatal("expected no value")
+	}
+}
+
+// Ensure set operations match the equivalent operations on maps.
+func TestBitmap_SetOperations(t *testing.T) {
+	rand := rand.New(rand.NewSource(0))
+	for _, n := range []int{0, 10, 1000, 20000} {
+		m0, m1 := make(map[uint64]struct{}), make(map[uint64]struct{})
+		b0, b1 := roaring.NewBitmap(), roaring.NewBitmap()
+		for i := 0; i < n; i++ {
+			v0, v1 := uint64(rand.Intn(200000)), uint64(rand.Intn(200000))
+			m0[v0], m1[v1] = struct{}{}, struct{}{}
+			b0.Add(v0)
+			b1.Add(v1)
+		}
+
+		var and, or, andNot []uint64
+		for v := range m0 {
+			if _, ok := m1[v]; ok {
+				and = append(and, v)
+			} else {
+				andNot = append(andNot, v)
+			}
+			or = append(or, v)
+		}
+		for v := range m1 {
+			if _, ok := m0[v]; !ok {
+				or = append(or, v)
+			}
+		}
+
+		for _, tt := range []struct {
+			op  string
+			got *roaring.Bitmap
+			exp []uint64
+		}{
+			{op: "and", got: b0.And(b1), exp: and},
+			{op: "or", got: b0.Or(b1), exp: or},
+			{op: "andNot", got: b0.AndNot(b1), exp: andNot},
+		} {
+			sort.Sort(uint64Slice(tt.exp))
+			if got := tt.got.Slice(); len(got) != len(tt.exp) || (len(got) > 0 && !reflect.DeepEqual(got, tt.exp)) {
+				t.Fatalf("%d values: unexpected %s result: %d values, expected %d", n, tt.op, len(got), len(tt.exp))
+			} else if tt.got.Cardinality() != uint64(len(tt.exp)) {
+				t.Fatalf("%d values: unexpected %s cardinality: %d", n, tt.op, tt.got.Cardinality())
+			}
+		}
+	}
+}
+
+// Ensure a bitmap can be marshaled and unmarshaled.
+func TestBitmap_MarshalBinary(t *testing.T) {
+	b := roaring.NewBitmap(1, 1<<40)
+	for v := uint64(1 << 16); v < 1<<16+8000; v++ {
+		b.Add(v)
+	}
+
+	buf, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	} else if len(buf) != b.Size() {
+		t.Fatalf("unexpected size: %d, expected %d", len(buf), b.Size())
+	}
+
+	var other roaring.Bitmap
+	if err := other.UnmarshalBinary(buf); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(other.Slice(), b.Slice()) {
+		t.Fatal("unexpected values after unmarshal")
+	}
+
+	if err := other.UnmarshalBinary(buf[:len(buf)-1]); err != roaring.ErrInvalidBitmap {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A container count larger than the data is rejected before allocating.
+	copy(buf, []byte{0xFF, 0xFF, 0xFF, 0xFF})
+	if err := other.UnmarshalBinary(buf); err != roaring.ErrInvalidBitmap {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+type uint64Slice []uint64
+
+func (a uint64Slice) Len() int           { return len(a) }
+func (a uint64Slice) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a uint64Slice) Less(i, j int) bool { return a[i] < a[j] }
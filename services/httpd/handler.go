@@ -42,6 +42,14 @@ const (
 	DefaultDebugRequestsInterval = 10 * time.Second
 
 	MaxDebugRequestsInterval = 6 * time.Hour
+
+	// WriteRetryAfter is the time clients are asked to wait before retrying a write
+	// rejected because the cache of a shard is full.
+	WriteRetryAfter = 5 * time.Second
+
+	// DefaultTagValuesLimit is the maximum number of tag values returned by a
+	// tag value prefix search if no limit is provided.
+	DefaultTagValuesLimit = 100
 )
 
 // AuthenticationMethod defines the type of authentication used.
@@ -99,6 +107,10 @@ type Handler struct {
 		WritePoints(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, points []models.Point) error
 	}
 
+	TSDBStore interface {
+		TagValuesByPrefix(database, measurement, key, prefix string, limit int) ([]string, error)
+	}
+
 	Config    *Config
 	Logger    zap.Logger
 	CLFLogger *log.Logger
@@ -155,6 +167,10 @@ func NewHandler(c Config) *Handler {
 			"status-head",
 			"HEAD", "/status", false, true, h.serveStatus,
 		},
+		Route{ // Tag value prefix search
+			"tagvalues",
+			"GET", "/api/v1/tagvalues", true, true, h.serveTagValues,
+		},
 	}...)
 
 	return h
@@ -168,6 +184,7 @@ type Statistics struct {
 	WriteRequests                int64
 	PingRequests                 int64
 	StatusRequests               int64
+	TagValuesRequests            int64
 	WriteRequestBytesReceived    int64
 	QueryRequestBytesTransmitted int64
 	PointsWrittenOK              int64
@@ -194,6 +211,7 @@ func (h *Handler) Statistics(tags map[string]string) []models.Statistic {
 			statWriteRequest:                 atomic.LoadInt64(&h.stats.WriteRequests),
 			statPingRequest:                  atomic.LoadInt64(&h.stats.PingRequests),
 			statStatusRequest:                atomic.LoadInt64(&h.stats.StatusRequests),
+			statTagValuesRequest:             atomic.LoadInt64(&h.stats.TagValuesRequests),
 			statWriteRequestBytesReceived:    atomic.LoadInt64(&h.stats.WriteRequestBytesReceived),
 			statQueryRequestBytesTransmitted: atomic.LoadInt64(&h.stats.QueryRequestBytesTransmitted),
 			statPointsWrittenOK:              atomic.LoadInt64(&h.stats.PointsWrittenOK),
@@ -688,6 +706,11 @@ func (h *Handler) serveWrite(w http.ResponseWriter, r *http.Request, user *meta.
 		atomic.AddInt64(&h.stats.PointsWrittenDropped, int64(werr.Dropped))
 		h.httpError(w, werr.Error(), http.StatusBadRequest)
 		return
+	} else if err == tsdb.ErrCacheFull {
+		atomic.AddInt64(&h.stats.PointsWrittenFail, int64(len(points)))
+		w.Header().Set("Retry-After", strconv.Itoa(int(WriteRetryAfter/time.Second)))
+		h.httpError(w, err.Error(), http.StatusServiceUnavailable)
+		return
 	} else if err != nil {
 		atomic.AddInt64(&h.stats.PointsWrittenFail, int64(len(points)))
 		h.httpError(w, err.Error(), http.StatusInternalServerError)
@@ -723,6 +746,77 @@ func (h *Handler) serveStatus(w http.ResponseWriter, r *http.Request) {
 	h.writeHeader(w, http.StatusNoContent)
 }
 
+// serveTagValues returns the sorted values of a tag key that begin with a prefix.
+func (h *Handler) serveTagValues(w http.ResponseWriter, r *http.Request, user *meta.UserInfo) {
+	atomic.AddInt64(&h.stats.TagValuesRequests, 1)
+	h.requestTracker.Add(r, user)
+
+	q := r.URL.Query()
+	database, measurement, key := q.Get("db"), q.Get("measurement"), q.Get("key")
+	if database == "" {
+		h.httpError(w, "database is required", http.StatusBadRequest)
+		return
+	} else if measurement == "" {
+		h.httpError(w, "measurement is required", http.StatusBadRequest)
+		return
+	} else if key == "" {
+		h.httpError(w, "tag key is required", http.StatusBadRequest)
+		return
+	}
+
+	limit := DefaultTagValuesLimit
+	if s := q.Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 {
+			h.httpError(w, fmt.Sprintf("invalid limit: %q", s), http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	if di := h.MetaClient.Database(database); di == nil {
+		h.httpError(w, fmt.Sprintf("database not found: %q", database), http.StatusNotFound)
+		return
+	}
+
+	// Authorize the request as the equivalent SHOW TAG VALUES query.
+	if h.Config.AuthEnabled {
+		query := &influxql.Query{Statements: influxql.Statements{&influxql.ShowTagValuesStatement{
+			Database:   database,
+			Sources:    influxql.Sources{&influxql.Measurement{Name: measurement}},
+			Op:         influxql.EQ,
+			TagKeyExpr: &influxql.StringLiteral{Val: key},
+		}}}
+		if err := h.QueryAuthorizer.AuthorizeQuery(user, query, database); err != nil {
+			h.httpError(w, "error authorizing query: "+err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+
+	values, err := h.TSDBStore.TagValuesByPrefix(database, measurement, key, q.Get("prefix"), limit)
+	if err != nil {
+		h.httpError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Return the values in the same form as SHOW TAG VALUES.
+	row := &models.Row{Name: measurement, Columns: []string{"key", "value"}}
+	for _, v := range values {
+		row.Values = append(row.Values, []interface{}{key, v})
+	}
+	result := &influxql.Result{}
+	if len(row.Values) > 0 {
+		result.Series = models.Rows{row}
+	}
+
+	rw, ok := w.(ResponseWriter)
+	if !ok {
+		rw = NewResponseWriter(w, r)
+	}
+	h.writeHeader(rw, http.StatusOK)
+	rw.WriteResponse(Response{Results: []*influxql.Result{result}})
+}
+
 // convertToEpoch converts result timestamps from time.Time to the specified epoch.
 func convertToEpoch(r *influxql.Result, epoch string) {
 	divisor := int64(1)
@@ -19,6 +19,7 @@ import (
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/services/httpd"
 	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/tsdb"
 )
 
 // Ensure the handler returns results from a query (including nil results).
@@ -585,6 +586,93 @@ func TestHandler_HandleBadRequestBody(t *testing.T) {
 	}
 }
 
+// Ensure the write endpoint asks clients to retry when the cache is full.
+func TestHandler_Write_CacheFull(t *testing.T) {
+	h := NewHandler(false)
+	h.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{}
+	}
+	h.PointsWriter.WritePointsFn = func(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, points []models.Point) error {
+		return tsdb.ErrCacheFull
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewRequest("POST", "/write?db=foo", strings.NewReader("cpu value=1")))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("unexpected status: %d", w.Code)
+	} else if got := w.Header().Get("Retry-After"); got != "5" {
+		t.Fatalf("unexpected Retry-After: %q", got)
+	}
+}
+
+// Ensure the handler returns tag values by prefix.
+func TestHandler_TagValues(t *testing.T) {
+	h := NewHandler(false)
+	h.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		if name != "foo" {
+			return nil
+		}
+		return &meta.DatabaseInfo{}
+	}
+	h.TSDBStore.TagValuesByPrefixFn = func(database, measurement, key, prefix string, limit int) ([]string, error) {
+		if database != "foo" || measurement != "cpu" || key != "host" || prefix != "web" {
+			t.Fatalf("unexpected arguments: %s, %s, %s, %s", database, measurement, key, prefix)
+		} else if limit != 2 {
+			t.Fatalf("unexpected limit: %d", limit)
+		}
+		return []string{"web01", "web02"}, nil
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewJSONRequest("GET", "/api/v1/tagvalues?db=foo&measurement=cpu&key=host&prefix=web&limit=2", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d", w.Code)
+	} else if body := strings.TrimSpace(w.Body.String()); body != `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["key","value"],"values":[["host","web01"],["host","web02"]]}]}]}` {
+		t.Fatalf("unexpected body: %s", body)
+	}
+
+	for _, tt := range []struct {
+		url  string
+		code int
+	}{
+		{url: "/api/v1/tagvalues?measurement=cpu&key=host", code: http.StatusBadRequest},
+		{url: "/api/v1/tagvalues?db=foo&key=host", code: http.StatusBadRequest},
+		{url: "/api/v1/tagvalues?db=foo&measurement=cpu", code: http.StatusBadRequest},
+		{url: "/api/v1/tagvalues?db=foo&measurement=cpu&key=host&limit=0", code: http.StatusBadRequest},
+		{url: "/api/v1/tagvalues?db=bar&measurement=cpu&key=host", code: http.StatusNotFound},
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, MustNewJSONRequest("GET", tt.url, nil))
+		if w.Code != tt.code {
+			t.Errorf("%s: unexpected status: got=%d exp=%d", tt.url, w.Code, tt.code)
+		}
+	}
+}
+
+// Ensure the tag values endpoint is authorized as a SHOW TAG VALUES query.
+func TestHandler_TagValues_ErrAuthorize(t *testing.T) {
+	h := NewHandler(true)
+	h.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo { return &meta.DatabaseInfo{} }
+	h.MetaClient.AdminUserExistsFn = func() bool { return true }
+	h.MetaClient.AuthenticateFn = func(u, p string) (*meta.UserInfo, error) {
+		return &meta.UserInfo{Name: u}, nil
+	}
+	h.QueryAuthorizer.AuthorizeQueryFn = func(u *meta.UserInfo, q *influxql.Query, db string) error {
+		if s := q.String(); s != `SHOW TAG VALUES ON foo FROM cpu WITH KEY = host` {
+			t.Fatalf("unexpected query: %s", s)
+		} else if db != "foo" {
+			t.Fatalf("unexpected database: %s", db)
+		}
+		return errors.New("marker")
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewJSONRequest("GET", "/api/v1/tagvalues?db=foo&measurement=cpu&key=host&u=user1&p=abcd", nil))
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("unexpected status: %d", w.Code)
+	}
+}
+
 // Ensure X-Forwarded-For header writes the correct log message.
 func TestHandler_XForwardedFor(t *testing.T) {
 	var buf bytes.Buffer
@@ -608,6 +696,8 @@ type Handler struct {
 	MetaClient        HandlerMetaStore
 	StatementExecutor HandlerStatementExecutor
 	QueryAuthorizer   HandlerQueryAuthorizer
+	PointsWriter      HandlerPointsWriter
+	TSDBStore         HandlerTSDBStore
 }
 
 // NewHandler returns a new instance of Handler.
@@ -623,6 +713,8 @@ func NewHandler(requireAuthentication bool) *Handler {
 	h.Handler.QueryExecutor = influxql.NewQueryExecutor()
 	h.Handler.QueryExecutor.StatementExecutor = &h.StatementExecutor
 	h.Handler.QueryAuthorizer = &h.QueryAuthorizer
+	h.Handler.PointsWriter = &h.PointsWriter
+	h.Handler.TSDBStore = &h.TSDBStore
 	h.Handler.Version = "0.0.0"
 	return h
 }
@@ -678,6 +770,24 @@ func (a *HandlerQueryAuthorizer) AuthorizeQuery(u *meta.UserInfo, query *influxq
 	return a.AuthorizeQueryFn(u, query, database)
 }
 
+// HandlerPointsWriter is a mock implementation of Handler.PointsWriter.
+type HandlerPointsWriter struct {
+	WritePointsFn func(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, points []models.Point) error
+}
+
+func (w *HandlerPointsWriter) WritePoints(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, points []models.Point) error {
+	return w.WritePointsFn(database, retentionPolicy, consistencyLevel, points)
+}
+
+// HandlerTSDBStore is a mock implementation of Handler.TSDBStore.
+type HandlerTSDBStore struct {
+	TagValuesByPrefixFn func(database, measurement, key, prefix string, limit int) ([]string, error)
+}
+
+func (s *HandlerTSDBStore) TagValuesByPrefix(database, measurement, key, prefix string, limit int) ([]string, error) {
+	return s.TagValuesByPrefixFn(database, measurement, key, prefix, limit)
+}
+
 // MustNewRequest returns a new HTTP request. Panic on error.
 func MustNewRequest(method, urlStr string, body io.Reader) *http.Request {
 	r, err := http.NewRequest(method, urlStr, body)
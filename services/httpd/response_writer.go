@@ -155,6 +155,8 @@ func (w *csvFormatter) WriteResponse(resp Response) (n int, err error) {
 						w.columns[i+2] = strconv.FormatFloat(v, 'f', -1, 64)
 					case int64:
 						w.columns[i+2] = strconv.FormatInt(v, 10)
+					case uint64:
+						w.columns[i+2] = strconv.FormatUint(v, 10)
 					case string:
 						w.columns[i+2] = v
 					case bool:
@@ -165,7 +167,7 @@ func (w *csvFormatter) WriteResponse(resp Response) (n int, err error) {
 						}
 					case time.Time:
 						w.columns[i+2] = strconv.FormatInt(v.UnixNano(), 10)
-					case *float64, *int64, *string, *bool:
+					case *float64, *int64, *uint64, *string, *bool:
 						w.columns[i+2] = ""
 					}
 				}
@@ -24,6 +24,7 @@ const (
 	statWriteRequest                 = "writeReq"             // Number of write requests serverd
 	statPingRequest                  = "pingReq"              // Number of ping requests served
 	statStatusRequest                = "statusReq"            // Number of status requests served
+	statTagValuesRequest             = "tagValuesReq"         // Number of tag value prefix requests served
 	statWriteRequestBytesReceived    = "writeReqBytes"        // Sum of all bytes in write requests
 	statQueryRequestBytesTransmitted = "queryRespBytes"       // Sum of all bytes returned in query reponses
 	statPointsWrittenOK              = "pointsWrittenOK"      // Number of points written OK
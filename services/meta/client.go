@@ -672,6 +672,80 @@ func (c *Client) DropShard(id uint64) error {
 	return c.commit(data)
 }
 
+// SetShardResolution records that the shard with id has been downsampled to resolution.
+func (c *Client) SetShardResolution(id uint64, resolution time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+	if err := data.SetShardResolution(id, resolution); err != nil {
+		return err
+	}
+	return c.commit(data)
+}
+
+// SplitShardGroup splits the shard group holding the shard with id into n shard groups
+// of equal duration.  It returns the shard group followed by the new shard groups.
+func (c *Client) SplitShardGroup(id uint64, n int) ([]ShardGroupInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+	groups, err := data.SplitShardGroup(id, n)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.commit(data); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// ShardGroupSplits returns the time ranges SplitShardGroup would split the shard group
+// holding the shard with id into, as shard groups without IDs or shards.
+func (c *Client) ShardGroupSplits(id uint64, n int) ([]ShardGroupInfo, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.cacheData.ShardGroupSplits(id, n)
+}
+
+// MergeableShardGroups returns the shard groups holding the shards with ids in time
+// order, or an error if they can't be merged.
+func (c *Client) MergeableShardGroups(ids []uint64) ([]ShardGroupInfo, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.cacheData.MergeableShardGroups(ids)
+}
+
+// MergeShardGroups merges the shard groups holding the shards with ids into the first
+// of them and deletes the others.
+func (c *Client) MergeShardGroups(ids []uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+	if err := data.MergeShardGroups(ids); err != nil {
+		return err
+	}
+	return c.commit(data)
+}
+
+// UnmergeShardGroups undoes MergeShardGroups for groups, as returned by
+// MergeableShardGroups before the merge.
+func (c *Client) UnmergeShardGroups(groups []ShardGroupInfo) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+	if err := data.UnmergeShardGroups(groups); err != nil {
+		return err
+	}
+	return c.commit(data)
+}
+
 // PruneShardGroups remove deleted shard groups from the data store.
 func (c *Client) PruneShardGroups() error {
 	var changed bool
@@ -879,6 +953,26 @@ func (c *Client) DropContinuousQuery(database, name string) error {
 	return nil
 }
 
+// SetMeasurementQuota sets the maximum number of series of a measurement, or if key
+// is set, the maximum number of values of the tag key in the measurement.  A limit
+// of zero removes the quota.
+func (c *Client) SetMeasurementQuota(database, name, key string, n int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+
+	if err := data.SetMeasurementQuota(database, name, key, n); err != nil {
+		return err
+	}
+
+	if err := c.commit(data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // CreateSubscription creates a subscription against the given database and retention policy.
 func (c *Client) CreateSubscription(database, rp, name, mode string, destinations []string) error {
 	c.mu.Lock()
@@ -166,13 +166,18 @@ func (data *Data) CreateRetentionPolicy(database string, rpi *RetentionPolicyInf
 		return ErrIncompatibleDurations
 	}
 
+	if err := rpi.Downsample.validate(); err != nil {
+		return err
+	}
+
 	// Find database.
 	di := data.Database(database)
 	if di == nil {
 		return influxdb.ErrDatabaseNotFound(database)
 	} else if rp := di.RetentionPolicy(rpi.Name); rp != nil {
 		// RP with that name already exists. Make sure they're the same.
-		if rp.ReplicaN != rpi.ReplicaN || rp.Duration != rpi.Duration || rp.ShardGroupDuration != rpi.ShardGroupDuration {
+		if rp.ReplicaN != rpi.ReplicaN || rp.Duration != rpi.Duration || rp.ShardGroupDuration != rpi.ShardGroupDuration ||
+			!rp.Downsample.equal(rpi.Downsample) {
 			return ErrRetentionPolicyExists
 		}
 		// if they want to make it default, and it's not the default, it's not an identical command so it's an error
@@ -219,6 +224,7 @@ type RetentionPolicyUpdate struct {
 	Duration           *time.Duration
 	ReplicaN           *int
 	ShardGroupDuration *time.Duration
+	Downsample         *DownsamplePolicy
 }
 
 // SetName sets the RetentionPolicyUpdate.Name.
@@ -233,6 +239,10 @@ func (rpu *RetentionPolicyUpdate) SetReplicaN(v int) { rpu.ReplicaN = &v }
 // SetShardGroupDuration sets the RetentionPolicyUpdate.ShardGroupDuration.
 func (rpu *RetentionPolicyUpdate) SetShardGroupDuration(v time.Duration) { rpu.ShardGroupDuration = &v }
 
+// SetDownsample sets the RetentionPolicyUpdate.Downsample.  A policy with an After of
+// zero removes the downsample policy.
+func (rpu *RetentionPolicyUpdate) SetDownsample(v DownsamplePolicy) { rpu.Downsample = &v }
+
 // UpdateRetentionPolicy updates an existing retention policy.
 func (data *Data) UpdateRetentionPolicy(database, name string, rpu *RetentionPolicyUpdate, makeDefault bool) error {
 	// Find database.
@@ -266,6 +276,12 @@ func (data *Data) UpdateRetentionPolicy(database, name string, rpu *RetentionPol
 		return ErrIncompatibleDurations
 	}
 
+	if rpu.Downsample != nil && rpu.Downsample.After != 0 {
+		if err := rpu.Downsample.validate(); err != nil {
+			return err
+		}
+	}
+
 	// Update fields.
 	if rpu.Name != nil {
 		rpi.Name = *rpu.Name
@@ -279,6 +295,13 @@ func (data *Data) UpdateRetentionPolicy(database, name string, rpu *RetentionPol
 	if rpu.ShardGroupDuration != nil {
 		rpi.ShardGroupDuration = normalisedShardDuration(*rpu.ShardGroupDuration, rpi.Duration)
 	}
+	if rpu.Downsample != nil {
+		if rpu.Downsample.After == 0 {
+			rpi.Downsample = nil
+		} else {
+			rpi.Downsample = rpu.Downsample.clone()
+		}
+	}
 
 	if di.DefaultRetentionPolicy != rpi.Name && makeDefault {
 		di.DefaultRetentionPolicy = rpi.Name
@@ -287,6 +310,293 @@ func (data *Data) UpdateRetentionPolicy(database, name string, rpu *RetentionPol
 	return nil
 }
 
+// SetShardResolution records that the shard with id has been downsampled to resolution.
+func (data *Data) SetShardResolution(id uint64, resolution time.Duration) error {
+	for dbidx, dbi := range data.Databases {
+		for rpidx, rpi := range dbi.RetentionPolicies {
+			for sgidx, sg := range rpi.ShardGroups {
+				for sidx, s := range sg.Shards {
+					if s.ID == id {
+						data.Databases[dbidx].RetentionPolicies[rpidx].ShardGroups[sgidx].Shards[sidx].Resolution = resolution
+						return nil
+					}
+				}
+			}
+		}
+	}
+	return ErrShardNotFound
+}
+
+// SplitShardGroup splits the shard group holding the shard with id into n shard groups
+// of equal duration.  The shard group keeps the first time range and a shard group is
+// created for each of the others, with shards owned by the same nodes.  It returns the
+// shard group followed by the new shard groups.
+func (data *Data) SplitShardGroup(id uint64, n int) ([]ShardGroupInfo, error) {
+	if n < 2 {
+		return nil, ErrShardGroupSplitCount
+	}
+
+	rpi, sgi := data.shardGroupByShardID(id)
+	if sgi == nil {
+		return nil, ErrShardNotFound
+	}
+	return data.splitShardGroup(rpi, sgi, n)
+}
+
+func (data *Data) splitShardGroup(rpi *RetentionPolicyInfo, sgi *ShardGroupInfo, n int) ([]ShardGroupInfo, error) {
+	ranges, err := splitTimeRange(sgi.StartTime, sgi.EndTime, n)
+	if err != nil {
+		return nil, err
+	}
+	sgi.EndTime = ranges[0].EndTime
+
+	groups := []ShardGroupInfo{sgi.clone()}
+	for _, r := range ranges[1:] {
+		data.MaxShardGroupID++
+		g := ShardGroupInfo{
+			ID:        data.MaxShardGroupID,
+			StartTime: r.StartTime,
+			EndTime:   r.EndTime,
+		}
+
+		for _, sh := range sgi.Shards {
+			data.MaxShardID++
+			si := sh.clone()
+			si.ID = data.MaxShardID
+			g.Shards = append(g.Shards, si)
+		}
+		groups = append(groups, g)
+	}
+
+	// Shard groups must be stored in sorted order.  sgi is not valid after this.
+	for _, g := range groups[1:] {
+		rpi.ShardGroups = append(rpi.ShardGroups, g.clone())
+	}
+	sort.Sort(ShardGroupInfos(rpi.ShardGroups))
+
+	return groups, nil
+}
+
+// ShardGroupSplits returns the time ranges SplitShardGroup would split the shard group
+// holding the shard with id into, as shard groups without IDs or shards.  The data is
+// not changed.
+func (data *Data) ShardGroupSplits(id uint64, n int) ([]ShardGroupInfo, error) {
+	if n < 2 {
+		return nil, ErrShardGroupSplitCount
+	}
+
+	_, sgi := data.shardGroupByShardID(id)
+	if sgi == nil {
+		return nil, ErrShardNotFound
+	}
+	return splitTimeRange(sgi.StartTime, sgi.EndTime, n)
+}
+
+// splitTimeRange returns n shard groups without IDs or shards covering start to end.
+// The shard groups have the same duration, rounded down to the second, except for the
+// last which ends at end.
+func splitTimeRange(start, end time.Time, n int) ([]ShardGroupInfo, error) {
+	d := end.Sub(start) / time.Duration(n)
+	d -= d % time.Second
+	if d < time.Second {
+		return nil, ErrShardGroupSplitDuration
+	}
+
+	groups := make([]ShardGroupInfo, n)
+	for i := range groups {
+		groups[i].StartTime = start.Add(time.Duration(i) * d)
+		groups[i].EndTime = start.Add(time.Duration(i+1) * d)
+	}
+	groups[n-1].EndTime = end
+	return groups, nil
+}
+
+// SplitShardIDs returns the IDs of the shards that take over the data of the shard
+// with id when its shard group is split into groups by SplitShardGroup, in the order
+// of the shard groups following the first.  It returns an error if groups don't have
+// the time ranges of splits, as returned by ShardGroupSplits.
+func SplitShardIDs(id uint64, splits, groups []ShardGroupInfo) ([]uint64, error) {
+	if len(groups) != len(splits) {
+		return nil, ErrShardGroupSplitChanged
+	}
+	for i := range groups {
+		if !groups[i].StartTime.Equal(splits[i].StartTime) || !groups[i].EndTime.Equal(splits[i].EndTime) {
+			return nil, ErrShardGroupSplitChanged
+		}
+	}
+
+	var ids []uint64
+	for i := range groups[0].Shards {
+		if groups[0].Shards[i].ID != id {
+			continue
+		}
+		for _, g := range groups[1:] {
+			if i >= len(g.Shards) {
+				return nil, ErrShardGroupSplitChanged
+			}
+			ids = append(ids, g.Shards[i].ID)
+		}
+	}
+	if len(ids) != len(groups)-1 {
+		return nil, ErrShardGroupSplitChanged
+	}
+	return ids, nil
+}
+
+// MergeableShardGroups returns the shard groups holding the shards with ids in time
+// order, or an error if they can't be merged.  Shard groups can be merged when they
+// belong to the same retention policy, have the same number of shards and no other
+// shard group lies between them.
+func (data *Data) MergeableShardGroups(ids []uint64) ([]ShardGroupInfo, error) {
+	_, groups, err := data.mergeableShardGroups(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	a := make([]ShardGroupInfo, len(groups))
+	for i, g := range groups {
+		a[i] = g.clone()
+	}
+	return a, nil
+}
+
+func (data *Data) mergeableShardGroups(ids []uint64) (*RetentionPolicyInfo, []*ShardGroupInfo, error) {
+	var owner *RetentionPolicyInfo
+	var groups []*ShardGroupInfo
+	for _, id := range ids {
+		rpi, sgi := data.shardGroupByShardID(id)
+		if sgi == nil {
+			return nil, nil, ErrShardNotFound
+		} else if owner != nil && rpi != owner {
+			return nil, nil, ErrShardGroupMergePolicy
+		}
+		owner = rpi
+
+		var found bool
+		for _, g := range groups {
+			if g == sgi {
+				found = true
+				break
+			}
+		}
+		if !found {
+			groups = append(groups, sgi)
+		}
+	}
+
+	if len(groups) < 2 {
+		return nil, nil, ErrShardGroupMergeCount
+	}
+
+	// Shard groups are stored in time order so the groups are adjacent when
+	// every shard group of the policy between the first and last is merged.
+	first, last := len(owner.ShardGroups), -1
+	for i := range owner.ShardGroups {
+		for _, g := range groups {
+			if &owner.ShardGroups[i] == g {
+				if i < first {
+					first = i
+				}
+				if i > last {
+					last = i
+				}
+			}
+		}
+	}
+
+	sorted := make([]*ShardGroupInfo, 0, len(groups))
+	for i := first; i <= last; i++ {
+		sgi := &owner.ShardGroups[i]
+		if sgi.Deleted() {
+			continue
+		}
+
+		var found bool
+		for _, g := range groups {
+			if g == sgi {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, nil, ErrShardGroupMergeNotAdjacent
+		} else if len(sgi.Shards) != len(groups[0].Shards) {
+			return nil, nil, ErrShardGroupMergeShardN
+		}
+		sorted = append(sorted, sgi)
+	}
+
+	return owner, sorted, nil
+}
+
+// shardGroupByShardID returns the retention policy and shard group holding the shard
+// with id.  Deleted shard groups are ignored.
+func (data *Data) shardGroupByShardID(id uint64) (*RetentionPolicyInfo, *ShardGroupInfo) {
+	for dbidx := range data.Databases {
+		for rpidx := range data.Databases[dbidx].RetentionPolicies {
+			rpi := &data.Databases[dbidx].RetentionPolicies[rpidx]
+			for sgidx := range rpi.ShardGroups {
+				sgi := &rpi.ShardGroups[sgidx]
+				if sgi.Deleted() {
+					continue
+				}
+
+				for _, sh := range sgi.Shards {
+					if sh.ID == id {
+						return rpi, sgi
+					}
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// MergeShardGroups merges the shard groups holding the shards with ids.  The first
+// shard group is extended to the end of the last and the others are marked as deleted.
+func (data *Data) MergeShardGroups(ids []uint64) error {
+	_, groups, err := data.mergeableShardGroups(ids)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	groups[0].EndTime = groups[len(groups)-1].EndTime
+	for _, g := range groups[1:] {
+		g.DeletedAt = now
+	}
+	return nil
+}
+
+// UnmergeShardGroups undoes MergeShardGroups for groups, as returned by
+// MergeableShardGroups before the merge, restoring their time ranges and the
+// shard groups that were marked as deleted.
+func (data *Data) UnmergeShardGroups(groups []ShardGroupInfo) error {
+	for _, g := range groups {
+		sgi := data.shardGroupByID(g.ID)
+		if sgi == nil {
+			return ErrShardGroupNotFound
+		}
+		sgi.EndTime, sgi.DeletedAt = g.EndTime, g.DeletedAt
+	}
+	return nil
+}
+
+// shardGroupByID returns the shard group with id, including deleted shard groups.
+func (data *Data) shardGroupByID(id uint64) *ShardGroupInfo {
+	for dbidx := range data.Databases {
+		for rpidx := range data.Databases[dbidx].RetentionPolicies {
+			rpi := &data.Databases[dbidx].RetentionPolicies[rpidx]
+			for sgidx := range rpi.ShardGroups {
+				if rpi.ShardGroups[sgidx].ID == id {
+					return &rpi.ShardGroups[sgidx]
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // DropShard removes a shard by ID.
 //
 // DropShard won't return an error if the shard can't be found, which
@@ -477,6 +787,48 @@ func (data *Data) DropContinuousQuery(database, name string) error {
 	return ErrContinuousQueryNotFound
 }
 
+// SetMeasurementQuota sets the maximum number of series of a measurement, or if key
+// is set, the maximum number of values of the tag key in the measurement.  A limit
+// of zero removes the quota.
+func (data *Data) SetMeasurementQuota(database, name, key string, n int) error {
+	if name == "" {
+		return ErrMeasurementNameRequired
+	} else if n < 0 {
+		return ErrInvalidQuota
+	}
+
+	di := data.Database(database)
+	if di == nil {
+		return influxdb.ErrDatabaseNotFound(database)
+	}
+
+	mqi := di.MeasurementQuota(name)
+	if mqi == nil {
+		if n == 0 {
+			return nil
+		}
+		di.MeasurementQuotas = append(di.MeasurementQuotas, MeasurementQuotaInfo{Name: name})
+		mqi = &di.MeasurementQuotas[len(di.MeasurementQuotas)-1]
+	}
+
+	if key == "" {
+		mqi.MaxSeries = n
+	} else {
+		mqi.setMaxValues(key, n)
+	}
+
+	// Remove the measurement once it has no quotas.
+	if mqi.MaxSeries == 0 && len(mqi.TagQuotas) == 0 {
+		for i := range di.MeasurementQuotas {
+			if di.MeasurementQuotas[i].Name == name {
+				di.MeasurementQuotas = append(di.MeasurementQuotas[:i], di.MeasurementQuotas[i+1:]...)
+				break
+			}
+		}
+	}
+	return nil
+}
+
 // validateURL returns an error if the URL does not have a port or uses a scheme other than UDP or HTTP.
 func validateURL(input string) error {
 	u, err := url.Parse(input)
@@ -795,6 +1147,17 @@ type DatabaseInfo struct {
 	DefaultRetentionPolicy string
 	RetentionPolicies      []RetentionPolicyInfo
 	ContinuousQueries      []ContinuousQueryInfo
+	MeasurementQuotas      []MeasurementQuotaInfo
+}
+
+// MeasurementQuota returns the quota of a measurement, or nil if it has none.
+func (di DatabaseInfo) MeasurementQuota(name string) *MeasurementQuotaInfo {
+	for i := range di.MeasurementQuotas {
+		if di.MeasurementQuotas[i].Name == name {
+			return &di.MeasurementQuotas[i]
+		}
+	}
+	return nil
 }
 
 // RetentionPolicy returns a retention policy by name.
@@ -858,6 +1221,14 @@ func (di DatabaseInfo) clone() DatabaseInfo {
 		}
 	}
 
+	// Copy measurement quotas.
+	if di.MeasurementQuotas != nil {
+		other.MeasurementQuotas = make([]MeasurementQuotaInfo, len(di.MeasurementQuotas))
+		for i := range di.MeasurementQuotas {
+			other.MeasurementQuotas[i] = di.MeasurementQuotas[i].clone()
+		}
+	}
+
 	return other
 }
 
@@ -876,6 +1247,11 @@ func (di DatabaseInfo) marshal() *internal.DatabaseInfo {
 	for i := range di.ContinuousQueries {
 		pb.ContinuousQueries[i] = di.ContinuousQueries[i].marshal()
 	}
+
+	pb.MeasurementQuotas = make([]*internal.MeasurementQuotaInfo, len(di.MeasurementQuotas))
+	for i := range di.MeasurementQuotas {
+		pb.MeasurementQuotas[i] = di.MeasurementQuotas[i].marshal()
+	}
 	return pb
 }
 
@@ -897,6 +1273,99 @@ func (di *DatabaseInfo) unmarshal(pb *internal.DatabaseInfo) {
 			di.ContinuousQueries[i].unmarshal(x)
 		}
 	}
+
+	if len(pb.GetMeasurementQuotas()) > 0 {
+		di.MeasurementQuotas = make([]MeasurementQuotaInfo, len(pb.GetMeasurementQuotas()))
+		for i, x := range pb.GetMeasurementQuotas() {
+			di.MeasurementQuotas[i].unmarshal(x)
+		}
+	}
+}
+
+// MeasurementQuotaInfo represents the cardinality quotas of a measurement.
+type MeasurementQuotaInfo struct {
+	Name string
+
+	// Maximum number of series of the measurement.  Zero is unlimited.
+	MaxSeries int
+
+	// Maximum number of values of tag keys of the measurement.
+	TagQuotas []TagQuotaInfo
+}
+
+// MaxValues returns the maximum number of values of a tag key, or zero if the key
+// is unlimited.
+func (mqi *MeasurementQuotaInfo) MaxValues(key string) int {
+	for _, tq := range mqi.TagQuotas {
+		if tq.Key == key {
+			return tq.MaxValues
+		}
+	}
+	return 0
+}
+
+// setMaxValues sets the maximum number of values of a tag key.  Zero removes the quota.
+func (mqi *MeasurementQuotaInfo) setMaxValues(key string, n int) {
+	for i := range mqi.TagQuotas {
+		if mqi.TagQuotas[i].Key != key {
+			continue
+		} else if n == 0 {
+			mqi.TagQuotas = append(mqi.TagQuotas[:i], mqi.TagQuotas[i+1:]...)
+		} else {
+			mqi.TagQuotas[i].MaxValues = n
+		}
+		return
+	}
+
+	if n > 0 {
+		mqi.TagQuotas = append(mqi.TagQuotas, TagQuotaInfo{Key: key, MaxValues: n})
+	}
+}
+
+// clone returns a deep copy of mqi.
+func (mqi MeasurementQuotaInfo) clone() MeasurementQuotaInfo {
+	other := mqi
+	if mqi.TagQuotas != nil {
+		other.TagQuotas = make([]TagQuotaInfo, len(mqi.TagQuotas))
+		copy(other.TagQuotas, mqi.TagQuotas)
+	}
+	return other
+}
+
+// marshal serializes to a protobuf representation.
+func (mqi MeasurementQuotaInfo) marshal() *internal.MeasurementQuotaInfo {
+	pb := &internal.MeasurementQuotaInfo{
+		Name:      proto.String(mqi.Name),
+		MaxSeries: proto.Int64(int64(mqi.MaxSeries)),
+	}
+
+	pb.TagQuotas = make([]*internal.TagQuotaInfo, len(mqi.TagQuotas))
+	for i, tq := range mqi.TagQuotas {
+		pb.TagQuotas[i] = &internal.TagQuotaInfo{
+			Key:       proto.String(tq.Key),
+			MaxValues: proto.Int64(int64(tq.MaxValues)),
+		}
+	}
+	return pb
+}
+
+// unmarshal deserializes from a protobuf representation.
+func (mqi *MeasurementQuotaInfo) unmarshal(pb *internal.MeasurementQuotaInfo) {
+	mqi.Name = pb.GetName()
+	mqi.MaxSeries = int(pb.GetMaxSeries())
+
+	if len(pb.GetTagQuotas()) > 0 {
+		mqi.TagQuotas = make([]TagQuotaInfo, len(pb.GetTagQuotas()))
+		for i, x := range pb.GetTagQuotas() {
+			mqi.TagQuotas[i] = TagQuotaInfo{Key: x.GetKey(), MaxValues: int(x.GetMaxValues())}
+		}
+	}
+}
+
+// TagQuotaInfo represents the maximum number of values of a tag key.
+type TagQuotaInfo struct {
+	Key       string
+	MaxValues int
 }
 
 // RetentionPolicySpec represents the specification for a new retention policy.
@@ -905,6 +1374,7 @@ type RetentionPolicySpec struct {
 	ReplicaN           *int
 	Duration           *time.Duration
 	ShardGroupDuration time.Duration
+	Downsample         *DownsamplePolicy
 }
 
 // NewRetentionPolicyInfo creates a new retention policy info from the specification.
@@ -929,7 +1399,7 @@ func (s *RetentionPolicySpec) Matches(rpi *RetentionPolicyInfo) bool {
 	// Normalize with the retention policy info's duration instead of the spec
 	// since they should be the same and we're performing a comparison.
 	sgDuration := normalisedShardDuration(s.ShardGroupDuration, rpi.Duration)
-	return sgDuration == rpi.ShardGroupDuration
+	return sgDuration == rpi.ShardGroupDuration && s.Downsample.equal(rpi.Downsample)
 }
 
 // marshal serializes to a protobuf representation.
@@ -947,6 +1417,9 @@ func (s *RetentionPolicySpec) marshal() *internal.RetentionPolicySpec {
 	if s.ReplicaN != nil {
 		pb.ReplicaN = proto.Uint32(uint32(*s.ReplicaN))
 	}
+	if s.Downsample != nil {
+		pb.Downsample = s.Downsample.marshal()
+	}
 	return pb
 }
 
@@ -966,6 +1439,10 @@ func (s *RetentionPolicySpec) unmarshal(pb *internal.RetentionPolicySpec) {
 		replicaN := int(pb.GetReplicaN())
 		s.ReplicaN = &replicaN
 	}
+	if pb.Downsample != nil {
+		s.Downsample = &DownsamplePolicy{}
+		s.Downsample.unmarshal(pb.GetDownsample())
+	}
 }
 
 // MarshalBinary encodes RetentionPolicySpec to a binary format.
@@ -991,6 +1468,7 @@ type RetentionPolicyInfo struct {
 	ShardGroupDuration time.Duration
 	ShardGroups        []ShardGroupInfo
 	Subscriptions      []SubscriptionInfo
+	Downsample         *DownsamplePolicy
 }
 
 // NewRetentionPolicyInfo returns a new instance of RetentionPolicyInfo
@@ -1016,6 +1494,7 @@ func (rpi *RetentionPolicyInfo) Apply(spec *RetentionPolicySpec) *RetentionPolic
 		ReplicaN:           rpi.ReplicaN,
 		Duration:           rpi.Duration,
 		ShardGroupDuration: rpi.ShardGroupDuration,
+		Downsample:         rpi.Downsample.clone(),
 	}
 	if spec.Name != "" {
 		rp.Name = spec.Name
@@ -1026,6 +1505,9 @@ func (rpi *RetentionPolicyInfo) Apply(spec *RetentionPolicySpec) *RetentionPolic
 	if spec.Duration != nil {
 		rp.Duration = *spec.Duration
 	}
+	if spec.Downsample != nil {
+		rp.Downsample = spec.Downsample.clone()
+	}
 	rp.ShardGroupDuration = normalisedShardDuration(spec.ShardGroupDuration, rp.Duration)
 	return rp
 }
@@ -1057,6 +1539,31 @@ func (rpi *RetentionPolicyInfo) ExpiredShardGroups(t time.Time) []*ShardGroupInf
 	return groups
 }
 
+// DownsampleShardGroups returns the shard groups that should be downsampled at the
+// given time.  These are the groups with shards that have not been downsampled yet
+// and that only hold data older than the policy's After duration.
+func (rpi *RetentionPolicyInfo) DownsampleShardGroups(t time.Time) []*ShardGroupInfo {
+	if rpi.Downsample == nil {
+		return nil
+	}
+
+	var groups []*ShardGroupInfo
+	for i := range rpi.ShardGroups {
+		sgi := &rpi.ShardGroups[i]
+		if sgi.Deleted() || !sgi.EndTime.Add(rpi.Downsample.After).Before(t) {
+			continue
+		}
+
+		for _, sh := range sgi.Shards {
+			if sh.Resolution == 0 {
+				groups = append(groups, sgi)
+				break
+			}
+		}
+	}
+	return groups
+}
+
 // DeletedShardGroups returns the Shard Groups which are marked as deleted.
 func (rpi *RetentionPolicyInfo) DeletedShardGroups() []*ShardGroupInfo {
 	var groups = make([]*ShardGroupInfo, 0)
@@ -1087,6 +1594,10 @@ func (rpi *RetentionPolicyInfo) marshal() *internal.RetentionPolicyInfo {
 		pb.Subscriptions[i] = sub.marshal()
 	}
 
+	if rpi.Downsample != nil {
+		pb.Downsample = rpi.Downsample.marshal()
+	}
+
 	return pb
 }
 
@@ -1109,6 +1620,10 @@ func (rpi *RetentionPolicyInfo) unmarshal(pb *internal.RetentionPolicyInfo) {
 			rpi.Subscriptions[i].unmarshal(x)
 		}
 	}
+	if pb.Downsample != nil {
+		rpi.Downsample = &DownsamplePolicy{}
+		rpi.Downsample.unmarshal(pb.GetDownsample())
+	}
 }
 
 // clone returns a deep copy of rpi.
@@ -1122,6 +1637,8 @@ func (rpi RetentionPolicyInfo) clone() RetentionPolicyInfo {
 		}
 	}
 
+	other.Downsample = rpi.Downsample.clone()
+
 	return other
 }
 
@@ -1140,6 +1657,109 @@ func (rpi *RetentionPolicyInfo) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+// DownsamplePolicy describes how the shards of a retention policy are downsampled.
+// Once all of the data in a shard is older than After, the shard is rewritten at a
+// resolution of Interval, adding one field per function in Functions for each numeric
+// field.  The field itself keeps the aggregate of the first function other than count.
+type DownsamplePolicy struct {
+	After     time.Duration
+	Interval  time.Duration
+	Functions []string
+}
+
+// downsampleFunctions is the set of functions a field can be downsampled with.
+var downsampleFunctions = map[string]struct{}{
+	"mean":  struct{}{},
+	"min":   struct{}{},
+	"max":   struct{}{},
+	"sum":   struct{}{},
+	"count": struct{}{},
+}
+
+// validate returns an error if dp is not a valid downsample policy.  A nil
+// policy is valid.
+func (dp *DownsamplePolicy) validate() error {
+	if dp == nil {
+		return nil
+	} else if dp.After <= 0 {
+		return ErrDownsampleAfterRequired
+	} else if dp.Interval <= 0 {
+		return ErrDownsampleIntervalRequired
+	} else if len(dp.Functions) == 0 {
+		return ErrDownsampleFunctionRequired
+	}
+
+	seen := make(map[string]struct{}, len(dp.Functions))
+	for _, fn := range dp.Functions {
+		if _, ok := downsampleFunctions[fn]; !ok {
+			return fmt.Errorf("unknown downsample function: %s", fn)
+		} else if _, ok := seen[fn]; ok {
+			return fmt.Errorf("duplicate downsample function: %s", fn)
+		}
+		seen[fn] = struct{}{}
+	}
+
+	if len(seen) == 1 {
+		if _, ok := seen["count"]; ok {
+			return ErrDownsampleOnlyCount
+		}
+	}
+	return nil
+}
+
+// equal returns true if dp and other describe the same policy.
+func (dp *DownsamplePolicy) equal(other *DownsamplePolicy) bool {
+	if dp == nil || other == nil {
+		return dp == other
+	} else if dp.After != other.After || dp.Interval != other.Interval || len(dp.Functions) != len(other.Functions) {
+		return false
+	}
+
+	for i := range dp.Functions {
+		if dp.Functions[i] != other.Functions[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// clone returns a deep copy of dp.
+func (dp *DownsamplePolicy) clone() *DownsamplePolicy {
+	if dp == nil {
+		return nil
+	}
+
+	other := *dp
+	if dp.Functions != nil {
+		other.Functions = make([]string, len(dp.Functions))
+		copy(other.Functions, dp.Functions)
+	}
+	return &other
+}
+
+// marshal serializes to a protobuf representation.
+func (dp *DownsamplePolicy) marshal() *internal.DownsamplePolicy {
+	pb := &internal.DownsamplePolicy{
+		After:    proto.Int64(int64(dp.After)),
+		Interval: proto.Int64(int64(dp.Interval)),
+	}
+
+	pb.Functions = make([]string, len(dp.Functions))
+	copy(pb.Functions, dp.Functions)
+	return pb
+}
+
+// unmarshal deserializes from a protobuf representation.
+func (dp *DownsamplePolicy) unmarshal(pb *internal.DownsamplePolicy) {
+	dp.After = time.Duration(pb.GetAfter())
+	dp.Interval = time.Duration(pb.GetInterval())
+
+	if len(pb.GetFunctions()) > 0 {
+		dp.Functions = make([]string, len(pb.GetFunctions()))
+		copy(dp.Functions, pb.GetFunctions())
+	}
+}
+
 // shardGroupDuration returns the default duration for a shard group based on a policy duration.
 func shardGroupDuration(d time.Duration) time.Duration {
 	if d >= 180*24*time.Hour || d == 0 { // 6 months or 0
@@ -1297,6 +1917,10 @@ func (sgi *ShardGroupInfo) unmarshal(pb *internal.ShardGroupInfo) {
 type ShardInfo struct {
 	ID     uint64
 	Owners []ShardOwner
+
+	// Resolution is the interval the shard's data has been downsampled to, or zero
+	// if the shard holds raw data.
+	Resolution time.Duration
 }
 
 // OwnedBy determines whether the shard's owner IDs includes nodeID.
@@ -1329,6 +1953,10 @@ func (si ShardInfo) marshal() *internal.ShardInfo {
 		ID: proto.Uint64(si.ID),
 	}
 
+	if si.Resolution > 0 {
+		pb.Resolution = proto.Int64(int64(si.Resolution))
+	}
+
 	pb.Owners = make([]*internal.ShardOwner, len(si.Owners))
 	for i := range si.Owners {
 		pb.Owners[i] = si.Owners[i].marshal()
@@ -1350,6 +1978,7 @@ func (si *ShardInfo) UnmarshalBinary(buf []byte) error {
 // unmarshal deserializes from a protobuf representation.
 func (si *ShardInfo) unmarshal(pb *internal.ShardInfo) {
 	si.ID = pb.GetID()
+	si.Resolution = time.Duration(pb.GetResolution())
 
 	// If deprecated "OwnerIDs" exists then convert it to "Owners" format.
 	if len(pb.GetOwnerIDs()) > 0 {
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/influxql"
 
 	"github.com/influxdata/influxdb/services/meta"
@@ -110,6 +111,336 @@ func Test_Data_CreateRetentionPolicy(t *testing.T) {
 	}
 }
 
+func Test_Data_RetentionPolicy_Downsample(t *testing.T) {
+	data := meta.Data{}
+	if err := data.CreateDatabase("foo"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A downsample policy must have an interval.
+	err := data.CreateRetentionPolicy("foo", &meta.RetentionPolicyInfo{
+		Name:               "bar",
+		ReplicaN:           1,
+		ShardGroupDuration: time.Hour,
+		Downsample:         &meta.DownsamplePolicy{After: time.Hour, Functions: []string{"mean"}},
+	}, false)
+	if err != meta.ErrDownsampleIntervalRequired {
+		t.Fatalf("unexpected error.  got: %v, exp: %s", err, meta.ErrDownsampleIntervalRequired)
+	}
+
+	// A downsample policy must have a function other than count.
+	err = data.CreateRetentionPolicy("foo", &meta.RetentionPolicyInfo{
+		Name:               "bar",
+		ReplicaN:           1,
+		ShardGroupDuration: time.Hour,
+		Downsample:         &meta.DownsamplePolicy{After: time.Hour, Interval: time.Minute, Functions: []string{"count"}},
+	}, false)
+	if err != meta.ErrDownsampleOnlyCount {
+		t.Fatalf("unexpected error.  got: %v, exp: %s", err, meta.ErrDownsampleOnlyCount)
+	}
+
+	if err := data.CreateRetentionPolicy("foo", &meta.RetentionPolicyInfo{
+		Name:               "bar",
+		ReplicaN:           1,
+		ShardGroupDuration: time.Hour,
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	rpu := &meta.RetentionPolicyUpdate{}
+	rpu.SetDownsample(meta.DownsamplePolicy{After: 24 * time.Hour, Interval: time.Minute, Functions: []string{"mean", "max"}})
+	if err := data.UpdateRetentionPolicy("foo", "bar", rpu, false); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().UTC()
+	old := now.Add(-48 * time.Hour)
+	if err := data.CreateShardGroup("foo", "bar", old); err != nil {
+		t.Fatal(err)
+	} else if err := data.CreateShardGroup("foo", "bar", now); err != nil {
+		t.Fatal(err)
+	}
+
+	// The policy and shard resolution should survive a round trip.
+	buf, err := data.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := meta.Data{}
+	if err := other.UnmarshalBinary(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	rp, err := other.RetentionPolicy("foo", "bar")
+	if err != nil {
+		t.Fatal(err)
+	} else if got, exp := rp.Downsample, rpu.Downsample; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got %v, expected %v", got, exp)
+	}
+
+	// Only the old shard group should be downsampled.
+	groups := rp.DownsampleShardGroups(now)
+	if got, exp := len(groups), 1; got != exp {
+		t.Fatalf("got %d groups, expected %d", got, exp)
+	} else if !groups[0].Contains(old) {
+		t.Fatalf("unexpected shard group: %v", groups[0])
+	}
+
+	if err := other.SetShardResolution(groups[0].Shards[0].ID, time.Minute); err != nil {
+		t.Fatal(err)
+	} else if groups := rp.DownsampleShardGroups(now); len(groups) != 0 {
+		t.Fatalf("unexpected shard groups after downsampling: %v", groups)
+	}
+
+	if err := other.SetShardResolution(100, time.Minute); err != meta.ErrShardNotFound {
+		t.Fatalf("unexpected error.  got: %v, exp: %s", err, meta.ErrShardNotFound)
+	}
+
+	// An empty policy removes downsampling.
+	rpu.SetDownsample(meta.DownsamplePolicy{})
+	if err := other.UpdateRetentionPolicy("foo", "bar", rpu, false); err != nil {
+		t.Fatal(err)
+	} else if rp.Downsample != nil {
+		t.Fatalf("expected downsample policy to be removed: %v", rp.Downsample)
+	}
+}
+
+func Test_Data_SetMeasurementQuota(t *testing.T) {
+	data := meta.Data{}
+	if err := data.CreateDatabase("foo"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := data.SetMeasurementQuota("bar", "cpu", "", 10); err == nil || err.Error() != influxdb.ErrDatabaseNotFound("bar").Error() {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := data.SetMeasurementQuota("foo", "cpu", "", -1); err != meta.ErrInvalidQuota {
+		t.Fatalf("unexpected error.  got: %v, exp: %s", err, meta.ErrInvalidQuota)
+	}
+
+	if err := data.SetMeasurementQuota("foo", "cpu", "", 100); err != nil {
+		t.Fatal(err)
+	} else if err := data.SetMeasurementQuota("foo", "cpu", "host", 10); err != nil {
+		t.Fatal(err)
+	} else if err := data.SetMeasurementQuota("foo", "cpu", "region", 5); err != nil {
+		t.Fatal(err)
+	} else if err := data.SetMeasurementQuota("foo", "cpu", "region", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// The quotas should survive a round trip.
+	buf, err := data.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := meta.Data{}
+	if err := other.UnmarshalBinary(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []meta.MeasurementQuotaInfo{{
+		Name:      "cpu",
+		MaxSeries: 100,
+		TagQuotas: []meta.TagQuotaInfo{{Key: "host", MaxValues: 10}},
+	}}
+	if got := other.Database("foo").MeasurementQuotas; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got %v, expected %v", got, exp)
+	}
+
+	// Removing every quota removes the measurement.
+	if err := other.SetMeasurementQuota("foo", "cpu", "", 0); err != nil {
+		t.Fatal(err)
+	} else if err := other.SetMeasurementQuota("foo", "cpu", "host", 0); err != nil {
+		t.Fatal(err)
+	} else if got := other.Database("foo").MeasurementQuotas; len(got) != 0 {
+		t.Fatalf("expected quotas to be removed: %v", got)
+	}
+}
+
+func Test_Data_SplitShardGroup(t *testing.T) {
+	data := meta.Data{}
+	if err := data.CreateDatabase("foo"); err != nil {
+		t.Fatal(err)
+	} else if err := data.CreateRetentionPolicy("foo", &meta.RetentionPolicyInfo{
+		Name:               "bar",
+		ReplicaN:           1,
+		ShardGroupDuration: time.Hour,
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := data.CreateShardGroup("foo", "bar", start); err != nil {
+		t.Fatal(err)
+	} else if err := data.CreateShardGroup("foo", "bar", start.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	rp, err := data.RetentionPolicy("foo", "bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := rp.ShardGroups[0].Shards[0].ID
+
+	if _, err := data.SplitShardGroup(id, 1); err != meta.ErrShardGroupSplitCount {
+		t.Fatalf("unexpected error.  got: %v, exp: %s", err, meta.ErrShardGroupSplitCount)
+	} else if _, err := data.SplitShardGroup(id, 7200); err != meta.ErrShardGroupSplitDuration {
+		t.Fatalf("unexpected error.  got: %v, exp: %s", err, meta.ErrShardGroupSplitDuration)
+	} else if _, err := data.SplitShardGroup(100, 2); err != meta.ErrShardNotFound {
+		t.Fatalf("unexpected error.  got: %v, exp: %s", err, meta.ErrShardNotFound)
+	}
+
+	// The time ranges of a split can be found without changing the shard group.
+	splits, err := data.ShardGroupSplits(id, 3)
+	if err != nil {
+		t.Fatal(err)
+	} else if got, exp := len(splits), 3; got != exp {
+		t.Fatalf("got %d splits, expected %d", got, exp)
+	} else if _, err := data.ShardGroupSplits(id, 7200); err != meta.ErrShardGroupSplitDuration {
+		t.Fatalf("unexpected error.  got: %v, exp: %s", err, meta.ErrShardGroupSplitDuration)
+	} else if got, exp := rp.ShardGroups[0].EndTime, start.Add(time.Hour); !got.Equal(exp) {
+		t.Fatalf("got end time %s, expected %s", got, exp)
+	}
+
+	groups, err := data.SplitShardGroup(id, 3)
+	if err != nil {
+		t.Fatal(err)
+	} else if got, exp := len(groups), 3; got != exp {
+		t.Fatalf("got %d groups, expected %d", got, exp)
+	}
+
+	// The new shards are found from the split shard groups.
+	if ids, err := meta.SplitShardIDs(id, splits, groups); err != nil {
+		t.Fatal(err)
+	} else if exp := []uint64{groups[1].Shards[0].ID, groups[2].Shards[0].ID}; !reflect.DeepEqual(ids, exp) {
+		t.Fatalf("got shard ids %v, expected %v", ids, exp)
+	} else if _, err := meta.SplitShardIDs(id, splits[:2], groups); err != meta.ErrShardGroupSplitChanged {
+		t.Fatalf("unexpected error.  got: %v, exp: %s", err, meta.ErrShardGroupSplitChanged)
+	}
+
+	// The shard group keeps its shard and the new shard groups cover the rest of the hour.
+	for i, g := range groups {
+		if got, exp := g.StartTime, start.Add(time.Duration(i)*20*time.Minute); !got.Equal(exp) {
+			t.Fatalf("group %d: got start time %s, expected %s", i, got, exp)
+		} else if got, exp := g.EndTime, start.Add(time.Duration(i+1)*20*time.Minute); !got.Equal(exp) {
+			t.Fatalf("group %d: got end time %s, expected %s", i, got, exp)
+		} else if got, exp := len(g.Shards), 1; got != exp {
+			t.Fatalf("group %d: got %d shards, expected %d", i, got, exp)
+		}
+	}
+	if groups[0].Shards[0].ID != id {
+		t.Fatalf("unexpected shard ID: %d", groups[0].Shards[0].ID)
+	} else if groups[1].Shards[0].ID == groups[2].Shards[0].ID || groups[1].ID == groups[2].ID {
+		t.Fatalf("expected unique IDs: %v", groups)
+	}
+
+	// The shard groups should be stored in time order.
+	if got, exp := len(rp.ShardGroups), 4; got != exp {
+		t.Fatalf("got %d groups, expected %d", got, exp)
+	}
+	for i := 1; i < len(rp.ShardGroups); i++ {
+		if rp.ShardGroups[i].StartTime.Before(rp.ShardGroups[i-1].EndTime) {
+			t.Fatalf("shard groups out of order: %v", rp.ShardGroups)
+		}
+	}
+	if sgi, err := data.ShardGroupByTimestamp("foo", "bar", start.Add(50*time.Minute)); err != nil {
+		t.Fatal(err)
+	} else if sgi.ID != groups[2].ID {
+		t.Fatalf("got shard group %d, expected %d", sgi.ID, groups[2].ID)
+	}
+}
+
+func Test_Data_MergeShardGroups(t *testing.T) {
+	data := meta.Data{}
+	if err := data.CreateDatabase("foo"); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"bar", "baz"} {
+		if err := data.CreateRetentionPolicy("foo", &meta.RetentionPolicyInfo{
+			Name:               name,
+			ReplicaN:           1,
+			ShardGroupDuration: time.Hour,
+		}, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		if err := data.CreateShardGroup("foo", "bar", start.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := data.CreateShardGroup("foo", "baz", start); err != nil {
+		t.Fatal(err)
+	}
+
+	rp, err := data.RetentionPolicy("foo", "bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := data.RetentionPolicy("foo", "baz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id0, id1, id2 := rp.ShardGroups[0].Shards[0].ID, rp.ShardGroups[1].Shards[0].ID, rp.ShardGroups[2].Shards[0].ID
+
+	for _, tt := range []struct {
+		ids []uint64
+		err error
+	}{
+		{ids: []uint64{id0}, err: meta.ErrShardGroupMergeCount},
+		{ids: []uint64{id0, id0}, err: meta.ErrShardGroupMergeCount},
+		{ids: []uint64{id0, 100}, err: meta.ErrShardNotFound},
+		{ids: []uint64{id0, other.ShardGroups[0].Shards[0].ID}, err: meta.ErrShardGroupMergePolicy},
+		{ids: []uint64{id0, id2}, err: meta.ErrShardGroupMergeNotAdjacent},
+	} {
+		if err := data.MergeShardGroups(tt.ids); err != tt.err {
+			t.Fatalf("%v: unexpected error.  got: %v, exp: %s", tt.ids, err, tt.err)
+		}
+	}
+
+	// The shard groups are returned in time order.
+	groups, err := data.MergeableShardGroups([]uint64{id2, id1})
+	if err != nil {
+		t.Fatal(err)
+	} else if got, exp := len(groups), 2; got != exp {
+		t.Fatalf("got %d groups, expected %d", got, exp)
+	} else if groups[0].Shards[0].ID != id1 || groups[1].Shards[0].ID != id2 {
+		t.Fatalf("unexpected shard groups: %v", groups)
+	}
+
+	if err := data.MergeShardGroups([]uint64{id2, id1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first shard group should cover both time ranges and the other be deleted.
+	if sgi := rp.ShardGroups[1]; !sgi.StartTime.Equal(start.Add(time.Hour)) || !sgi.EndTime.Equal(start.Add(3*time.Hour)) {
+		t.Fatalf("unexpected time range: %s - %s", sgi.StartTime, sgi.EndTime)
+	} else if !rp.ShardGroups[2].Deleted() {
+		t.Fatal("expected shard group to be deleted")
+	}
+
+	// Undoing the merge restores both shard groups.
+	if err := data.UnmergeShardGroups(groups); err != nil {
+		t.Fatal(err)
+	} else if sgi := rp.ShardGroups[1]; !sgi.EndTime.Equal(start.Add(2 * time.Hour)) {
+		t.Fatalf("unexpected end time: %s", sgi.EndTime)
+	} else if rp.ShardGroups[2].Deleted() {
+		t.Fatal("expected shard group to be restored")
+	} else if err := data.MergeShardGroups([]uint64{id2, id1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Deleted shard groups don't prevent merging the remaining ones.
+	if err := data.MergeShardGroups([]uint64{id0, id1}); err != nil {
+		t.Fatal(err)
+	} else if sgi, err := data.ShardGroupByTimestamp("foo", "bar", start.Add(150*time.Minute)); err != nil {
+		t.Fatal(err)
+	} else if sgi.Shards[0].ID != id0 {
+		t.Fatalf("got shard %d, expected %d", sgi.Shards[0].ID, id0)
+	}
+}
+
 func TestData_AdminUserExists(t *testing.T) {
 	data := meta.Data{}
 
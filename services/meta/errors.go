@@ -65,6 +65,32 @@ var (
 	// ErrReplicationFactorTooLow is returned when the replication factor is not in an
 	// acceptable range.
 	ErrReplicationFactorTooLow = errors.New("replication factor must be greater than 0")
+
+	// ErrDownsampleAfterRequired is returned when a downsample policy does not
+	// have a duration after which shards are downsampled.
+	ErrDownsampleAfterRequired = errors.New("downsample duration must be greater than 0")
+
+	// ErrDownsampleIntervalRequired is returned when a downsample policy does not
+	// have an interval to downsample to.
+	ErrDownsampleIntervalRequired = errors.New("downsample interval must be greater than 0")
+
+	// ErrDownsampleFunctionRequired is returned when a downsample policy does not
+	// have any functions to downsample with.
+	ErrDownsampleFunctionRequired = errors.New("downsample function required")
+
+	// ErrDownsampleOnlyCount is returned when count is the only function of a
+	// downsample policy.  The downsampled field keeps the aggregate of another
+	// function.
+	ErrDownsampleOnlyCount = errors.New("downsample functions must include a function other than count")
+)
+
+var (
+	// ErrMeasurementNameRequired is returned when setting a quota without a
+	// measurement name.
+	ErrMeasurementNameRequired = errors.New("measurement name required")
+
+	// ErrInvalidQuota is returned when setting a negative quota.
+	ErrInvalidQuota = errors.New("quota must not be negative")
 )
 
 var (
@@ -77,6 +103,36 @@ var (
 	// ErrShardNotReplicated is returned if the node requested to be dropped has
 	// the last copy of a shard present and the force keyword was not used
 	ErrShardNotReplicated = errors.New("shard not replicated")
+
+	// ErrShardNotFound is returned when mutating a shard that doesn't exist.
+	ErrShardNotFound = errors.New("shard not found")
+
+	// ErrShardGroupSplitCount is returned when splitting a shard group into less than
+	// two shard groups.
+	ErrShardGroupSplitCount = errors.New("shard group must be split into at least 2 shard groups")
+
+	// ErrShardGroupSplitDuration is returned when splitting a shard group would create
+	// shard groups shorter than a second.
+	ErrShardGroupSplitDuration = errors.New("split shard groups must be at least 1s long")
+
+	// ErrShardGroupSplitChanged is returned when a shard group was changed while its
+	// split was prepared.
+	ErrShardGroupSplitChanged = errors.New("shard group changed during split")
+
+	// ErrShardGroupMergeCount is returned when merging less than two shard groups.
+	ErrShardGroupMergeCount = errors.New("at least 2 shard groups are required to merge")
+
+	// ErrShardGroupMergePolicy is returned when merging shard groups of different
+	// retention policies.
+	ErrShardGroupMergePolicy = errors.New("shard groups must belong to the same retention policy")
+
+	// ErrShardGroupMergeNotAdjacent is returned when merging shard groups that are
+	// separated by another shard group.
+	ErrShardGroupMergeNotAdjacent = errors.New("shard groups must be adjacent")
+
+	// ErrShardGroupMergeShardN is returned when merging shard groups that don't have
+	// the same number of shards.
+	ErrShardGroupMergeShardN = errors.New("shard groups must have the same number of shards")
 )
 
 var (
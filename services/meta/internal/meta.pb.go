@@ -52,6 +52,9 @@ It has these top-level messages:
 	Response
 	SetMetaNodeCommand
 	DropShardCommand
+	DownsamplePolicy
+	MeasurementQuotaInfo
+	TagQuotaInfo
 */
 package meta
 
@@ -317,11 +320,12 @@ func (m *NodeInfo) GetTCPHost() string {
 }
 
 type DatabaseInfo struct {
-	Name                   *string                `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
-	DefaultRetentionPolicy *string                `protobuf:"bytes,2,req,name=DefaultRetentionPolicy" json:"DefaultRetentionPolicy,omitempty"`
-	RetentionPolicies      []*RetentionPolicyInfo `protobuf:"bytes,3,rep,name=RetentionPolicies" json:"RetentionPolicies,omitempty"`
-	ContinuousQueries      []*ContinuousQueryInfo `protobuf:"bytes,4,rep,name=ContinuousQueries" json:"ContinuousQueries,omitempty"`
-	XXX_unrecognized       []byte                 `json:"-"`
+	Name                   *string                 `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	DefaultRetentionPolicy *string                 `protobuf:"bytes,2,req,name=DefaultRetentionPolicy" json:"DefaultRetentionPolicy,omitempty"`
+	RetentionPolicies      []*RetentionPolicyInfo  `protobuf:"bytes,3,rep,name=RetentionPolicies" json:"RetentionPolicies,omitempty"`
+	ContinuousQueries      []*ContinuousQueryInfo  `protobuf:"bytes,4,rep,name=ContinuousQueries" json:"ContinuousQueries,omitempty"`
+	MeasurementQuotas      []*MeasurementQuotaInfo `protobuf:"bytes,5,rep,name=MeasurementQuotas" json:"MeasurementQuotas,omitempty"`
+	XXX_unrecognized       []byte                  `json:"-"`
 }
 
 func (m *DatabaseInfo) Reset()                    { *m = DatabaseInfo{} }
@@ -357,12 +361,20 @@ func (m *DatabaseInfo) GetContinuousQueries() []*ContinuousQueryInfo {
 	return nil
 }
 
+func (m *DatabaseInfo) GetMeasurementQuotas() []*MeasurementQuotaInfo {
+	if m != nil {
+		return m.MeasurementQuotas
+	}
+	return nil
+}
+
 type RetentionPolicySpec struct {
-	Name               *string `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
-	Duration           *int64  `protobuf:"varint,2,opt,name=Duration" json:"Duration,omitempty"`
-	ShardGroupDuration *int64  `protobuf:"varint,3,opt,name=ShardGroupDuration" json:"ShardGroupDuration,omitempty"`
-	ReplicaN           *uint32 `protobuf:"varint,4,opt,name=ReplicaN" json:"ReplicaN,omitempty"`
-	XXX_unrecognized   []byte  `json:"-"`
+	Name               *string           `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
+	Duration           *int64            `protobuf:"varint,2,opt,name=Duration" json:"Duration,omitempty"`
+	ShardGroupDuration *int64            `protobuf:"varint,3,opt,name=ShardGroupDuration" json:"ShardGroupDuration,omitempty"`
+	ReplicaN           *uint32           `protobuf:"varint,4,opt,name=ReplicaN" json:"ReplicaN,omitempty"`
+	Downsample         *DownsamplePolicy `protobuf:"bytes,5,opt,name=Downsample" json:"Downsample,omitempty"`
+	XXX_unrecognized   []byte            `json:"-"`
 }
 
 func (m *RetentionPolicySpec) Reset()                    { *m = RetentionPolicySpec{} }
@@ -398,6 +410,13 @@ func (m *RetentionPolicySpec) GetReplicaN() uint32 {
 	return 0
 }
 
+func (m *RetentionPolicySpec) GetDownsample() *DownsamplePolicy {
+	if m != nil {
+		return m.Downsample
+	}
+	return nil
+}
+
 type RetentionPolicyInfo struct {
 	Name               *string             `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
 	Duration           *int64              `protobuf:"varint,2,req,name=Duration" json:"Duration,omitempty"`
@@ -405,6 +424,7 @@ type RetentionPolicyInfo struct {
 	ReplicaN           *uint32             `protobuf:"varint,4,req,name=ReplicaN" json:"ReplicaN,omitempty"`
 	ShardGroups        []*ShardGroupInfo   `protobuf:"bytes,5,rep,name=ShardGroups" json:"ShardGroups,omitempty"`
 	Subscriptions      []*SubscriptionInfo `protobuf:"bytes,6,rep,name=Subscriptions" json:"Subscriptions,omitempty"`
+	Downsample         *DownsamplePolicy   `protobuf:"bytes,7,opt,name=Downsample" json:"Downsample,omitempty"`
 	XXX_unrecognized   []byte              `json:"-"`
 }
 
@@ -455,6 +475,13 @@ func (m *RetentionPolicyInfo) GetSubscriptions() []*SubscriptionInfo {
 	return nil
 }
 
+func (m *RetentionPolicyInfo) GetDownsample() *DownsamplePolicy {
+	if m != nil {
+		return m.Downsample
+	}
+	return nil
+}
+
 type ShardGroupInfo struct {
 	ID               *uint64      `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
 	StartTime        *int64       `protobuf:"varint,2,req,name=StartTime" json:"StartTime,omitempty"`
@@ -516,6 +543,7 @@ type ShardInfo struct {
 	ID               *uint64       `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
 	OwnerIDs         []uint64      `protobuf:"varint,2,rep,name=OwnerIDs" json:"OwnerIDs,omitempty"`
 	Owners           []*ShardOwner `protobuf:"bytes,3,rep,name=Owners" json:"Owners,omitempty"`
+	Resolution       *int64        `protobuf:"varint,4,opt,name=Resolution" json:"Resolution,omitempty"`
 	XXX_unrecognized []byte        `json:"-"`
 }
 
@@ -545,6 +573,13 @@ func (m *ShardInfo) GetOwners() []*ShardOwner {
 	return nil
 }
 
+func (m *ShardInfo) GetResolution() int64 {
+	if m != nil && m.Resolution != nil {
+		return *m.Resolution
+	}
+	return 0
+}
+
 type SubscriptionInfo struct {
 	Name             *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
 	Mode             *string  `protobuf:"bytes,2,req,name=Mode" json:"Mode,omitempty"`
@@ -1786,6 +1821,97 @@ var E_DropShardCommand_Command = &proto.ExtensionDesc{
 	Tag:           "bytes,130,opt,name=command",
 }
 
+type DownsamplePolicy struct {
+	After            *int64   `protobuf:"varint,1,req,name=After" json:"After,omitempty"`
+	Interval         *int64   `protobuf:"varint,2,req,name=Interval" json:"Interval,omitempty"`
+	Functions        []string `protobuf:"bytes,3,rep,name=Functions" json:"Functions,omitempty"`
+	XXX_unrecognized []byte   `json:"-"`
+}
+
+func (m *DownsamplePolicy) Reset()                    { *m = DownsamplePolicy{} }
+func (m *DownsamplePolicy) String() string            { return proto.CompactTextString(m) }
+func (*DownsamplePolicy) ProtoMessage()               {}
+func (*DownsamplePolicy) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{43} }
+
+func (m *DownsamplePolicy) GetAfter() int64 {
+	if m != nil && m.After != nil {
+		return *m.After
+	}
+	return 0
+}
+
+func (m *DownsamplePolicy) GetInterval() int64 {
+	if m != nil && m.Interval != nil {
+		return *m.Interval
+	}
+	return 0
+}
+
+func (m *DownsamplePolicy) GetFunctions() []string {
+	if m != nil {
+		return m.Functions
+	}
+	return nil
+}
+
+type MeasurementQuotaInfo struct {
+	Name             *string         `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	MaxSeries        *int64          `protobuf:"varint,2,opt,name=MaxSeries" json:"MaxSeries,omitempty"`
+	TagQuotas        []*TagQuotaInfo `protobuf:"bytes,3,rep,name=TagQuotas" json:"TagQuotas,omitempty"`
+	XXX_unrecognized []byte          `json:"-"`
+}
+
+func (m *MeasurementQuotaInfo) Reset()                    { *m = MeasurementQuotaInfo{} }
+func (m *MeasurementQuotaInfo) String() string            { return proto.CompactTextString(m) }
+func (*MeasurementQuotaInfo) ProtoMessage()               {}
+func (*MeasurementQuotaInfo) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{44} }
+
+func (m *MeasurementQuotaInfo) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *MeasurementQuotaInfo) GetMaxSeries() int64 {
+	if m != nil && m.MaxSeries != nil {
+		return *m.MaxSeries
+	}
+	return 0
+}
+
+func (m *MeasurementQuotaInfo) GetTagQuotas() []*TagQuotaInfo {
+	if m != nil {
+		return m.TagQuotas
+	}
+	return nil
+}
+
+type TagQuotaInfo struct {
+	Key              *string `protobuf:"bytes,1,req,name=Key" json:"Key,omitempty"`
+	MaxValues        *int64  `protobuf:"varint,2,req,name=MaxValues" json:"MaxValues,omitempty"`
+	XXX_unrecognized []byte  `json:"-"`
+}
+
+func (m *TagQuotaInfo) Reset()                    { *m = TagQuotaInfo{} }
+func (m *TagQuotaInfo) String() string            { return proto.CompactTextString(m) }
+func (*TagQuotaInfo) ProtoMessage()               {}
+func (*TagQuotaInfo) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{45} }
+
+func (m *TagQuotaInfo) GetKey() string {
+	if m != nil && m.Key != nil {
+		return *m.Key
+	}
+	return ""
+}
+
+func (m *TagQuotaInfo) GetMaxValues() int64 {
+	if m != nil && m.MaxValues != nil {
+		return *m.MaxValues
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Data)(nil), "meta.Data")
 	proto.RegisterType((*NodeInfo)(nil), "meta.NodeInfo")
@@ -1830,6 +1956,9 @@ func init() {
 	proto.RegisterType((*Response)(nil), "meta.Response")
 	proto.RegisterType((*SetMetaNodeCommand)(nil), "meta.SetMetaNodeCommand")
 	proto.RegisterType((*DropShardCommand)(nil), "meta.DropShardCommand")
+	proto.RegisterType((*DownsamplePolicy)(nil), "meta.DownsamplePolicy")
+	proto.RegisterType((*MeasurementQuotaInfo)(nil), "meta.MeasurementQuotaInfo")
+	proto.RegisterType((*TagQuotaInfo)(nil), "meta.TagQuotaInfo")
 	proto.RegisterEnum("meta.Command_Type", Command_Type_name, Command_Type_value)
 	proto.RegisterExtension(E_CreateNodeCommand_Command)
 	proto.RegisterExtension(E_DeleteNodeCommand_Command)
@@ -1865,107 +1994,115 @@ func init() {
 func init() { proto.RegisterFile("internal/meta.proto", fileDescriptorMeta) }
 
 var fileDescriptorMeta = []byte{
-	// 1617 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x9c, 0x58, 0x5b, 0x6f, 0x1b, 0xc5,
-	0x17, 0xd7, 0xda, 0x6b, 0xc7, 0x7b, 0x62, 0x27, 0xf6, 0x38, 0x97, 0x4d, 0x9b, 0xa4, 0xee, 0xe8,
-	0x7f, 0xf1, 0xff, 0x2f, 0x51, 0x24, 0x2b, 0x15, 0x42, 0x5c, 0xdb, 0xb8, 0xa5, 0x11, 0x4a, 0x1a,
-	0x62, 0x17, 0xde, 0xaa, 0x6e, 0xed, 0x49, 0xb3, 0x60, 0xef, 0x9a, 0xdd, 0x75, 0xd3, 0x50, 0x68,
-	0x03, 0x12, 0x42, 0x20, 0x21, 0xc1, 0x0b, 0x2f, 0x3c, 0xf1, 0xc6, 0x37, 0x40, 0x3c, 0xf0, 0x29,
-	0xf8, 0x42, 0x68, 0x66, 0xf6, 0x32, 0xbb, 0x3b, 0xb3, 0x69, 0xfb, 0x66, 0xcf, 0x39, 0x73, 0x7e,
-	0xbf, 0x39, 0xb7, 0x39, 0xb3, 0xd0, 0xb6, 0x9d, 0x80, 0x78, 0x8e, 0x35, 0x79, 0x7d, 0x4a, 0x02,
-	0xeb, 0xda, 0xcc, 0x73, 0x03, 0x17, 0xe9, 0xf4, 0x37, 0xfe, 0xad, 0x04, 0x7a, 0xdf, 0x0a, 0x2c,
-	0x54, 0x07, 0x7d, 0x48, 0xbc, 0xa9, 0xa9, 0x75, 0x4a, 0x5d, 0x1d, 0x35, 0xa0, 0xb2, 0xe7, 0x8c,
-	0xc9, 0x13, 0xb3, 0xc4, 0xfe, 0xb6, 0xc0, 0xd8, 0x9d, 0xcc, 0xfd, 0x80, 0x78, 0x7b, 0x7d, 0xb3,
-	0xcc, 0x96, 0xb6, 0xa0, 0x72, 0xe0, 0x8e, 0x89, 0x6f, 0xea, 0x9d, 0x72, 0x77, 0xb1, 0xb7, 0x74,
-	0x8d, 0x99, 0xa6, 0x4b, 0x7b, 0xce, 0xb1, 0x8b, 0xfe, 0x0d, 0x06, 0x35, 0xfb, 0xd0, 0xf2, 0x89,
-	0x6f, 0x56, 0x98, 0x0a, 0xe2, 0x2a, 0xd1, 0x32, 0x53, 0xdb, 0x82, 0xca, 0x3d, 0x9f, 0x78, 0xbe,
-	0x59, 0x15, 0xad, 0xd0, 0x25, 0x26, 0x6e, 0x81, 0xb1, 0x6f, 0x3d, 0x61, 0x46, 0xfb, 0xe6, 0x02,
-	0xc3, 0x5d, 0x87, 0xe5, 0x7d, 0xeb, 0xc9, 0xe0, 0xc4, 0xf2, 0xc6, 0x1f, 0x78, 0xee, 0x7c, 0xb6,
-	0xd7, 0x37, 0x6b, 0x4c, 0x80, 0x00, 0x22, 0xc1, 0x5e, 0xdf, 0x34, 0xd8, 0xda, 0x55, 0xce, 0x82,
-	0x13, 0x05, 0x29, 0xd1, 0xab, 0x60, 0xec, 0x93, 0x48, 0x65, 0x51, 0xa6, 0x82, 0xaf, 0x43, 0x2d,
-	0x56, 0x07, 0x28, 0xed, 0xf5, 0x43, 0x27, 0xd5, 0x41, 0xbf, 0xe3, 0xfa, 0x01, 0xf3, 0x91, 0x81,
-	0x96, 0x61, 0x61, 0xb8, 0x7b, 0xc8, 0x16, 0xca, 0x1d, 0xad, 0x6b, 0xe0, 0xdf, 0x35, 0xa8, 0xa7,
-	0x0e, 0x5b, 0x07, 0xfd, 0xc0, 0x9a, 0x12, 0xb6, 0xdb, 0x40, 0xdb, 0xb0, 0xd6, 0x27, 0xc7, 0xd6,
-	0x7c, 0x12, 0x1c, 0x91, 0x80, 0x38, 0x81, 0xed, 0x3a, 0x87, 0xee, 0xc4, 0x1e, 0x9d, 0x85, 0xf6,
-	0x76, 0xa0, 0x95, 0x16, 0xd8, 0xc4, 0x37, 0xcb, 0x8c, 0xe0, 0x06, 0x27, 0x98, 0xd9, 0xc7, 0x30,
-	0x76, 0xa0, 0xb5, 0xeb, 0x3a, 0x81, 0xed, 0xcc, 0xdd, 0xb9, 0xff, 0xd1, 0x9c, 0x78, 0x76, 0x1c,
-	0xa2, 0x70, 0x57, 0x5a, 0xcc, 0x76, 0xe1, 0x11, 0xb4, 0x33, 0xc6, 0x06, 0x33, 0x32, 0x12, 0x08,
-	0x6b, 0x5d, 0x03, 0x35, 0xa1, 0xd6, 0x9f, 0x7b, 0x16, 0xd5, 0x31, 0x4b, 0x1d, 0xad, 0x5b, 0x46,
-	0x97, 0x00, 0x25, 0x81, 0x88, 0x65, 0x65, 0x26, 0x6b, 0x42, 0xed, 0x88, 0xcc, 0x26, 0xf6, 0xc8,
-	0x3a, 0x30, 0xf5, 0x8e, 0xd6, 0x6d, 0xe0, 0xbf, 0xb4, 0x1c, 0x8a, 0xc4, 0x2d, 0x69, 0x94, 0x52,
-	0x01, 0x4a, 0x29, 0x87, 0x52, 0xea, 0x36, 0xd0, 0xff, 0x60, 0x31, 0xd1, 0x8e, 0x52, 0x6f, 0x85,
-	0x1f, 0x5d, 0xc8, 0x1a, 0x0a, 0xfc, 0x1a, 0x34, 0x06, 0xf3, 0x87, 0xfe, 0xc8, 0xb3, 0x67, 0xd4,
-	0x64, 0x94, 0x84, 0x6b, 0xa1, 0xb2, 0x20, 0x62, 0x4e, 0xfa, 0x5e, 0x83, 0xa5, 0x8c, 0x05, 0x31,
-	0x1b, 0x5a, 0x60, 0x0c, 0x02, 0xcb, 0x0b, 0x86, 0xf6, 0x94, 0x84, 0xcc, 0x97, 0x61, 0xe1, 0x96,
-	0x33, 0x66, 0x0b, 0x9c, 0x6e, 0x0b, 0x8c, 0x3e, 0x99, 0x90, 0x80, 0x8c, 0x6f, 0x04, 0x8c, 0x6f,
-	0x19, 0x5d, 0x81, 0x2a, 0x33, 0x1a, 0x51, 0x5d, 0x16, 0xa8, 0x32, 0x8c, 0x36, 0x2c, 0x0e, 0xbd,
-	0xb9, 0x33, 0xb2, 0xf8, 0xae, 0x2a, 0xf5, 0x2e, 0xbe, 0x0b, 0x46, 0xa2, 0x21, 0xb2, 0x58, 0x81,
-	0xda, 0xdd, 0x53, 0x87, 0xd6, 0xa9, 0x6f, 0x96, 0x3a, 0xe5, 0xae, 0x7e, 0xb3, 0x64, 0x6a, 0xa8,
-	0x03, 0x55, 0xb6, 0x1a, 0x25, 0x50, 0x53, 0x00, 0x61, 0x02, 0xdc, 0x87, 0x66, 0xf6, 0xc0, 0x99,
-	0xc0, 0xd4, 0x41, 0xdf, 0x77, 0xc7, 0x24, 0xcc, 0xce, 0x15, 0xa8, 0xf7, 0x89, 0x1f, 0xd8, 0x8e,
-	0xc5, 0x5d, 0x47, 0xed, 0x1a, 0x78, 0x13, 0x20, 0xb1, 0x89, 0x96, 0xa0, 0x1a, 0x96, 0x2e, 0xe3,
-	0x86, 0x7b, 0xd0, 0x96, 0x24, 0x5f, 0x06, 0xa6, 0x01, 0x15, 0x26, 0xe2, 0x38, 0xf8, 0x3e, 0xd4,
-	0xe2, 0x6e, 0x90, 0xe3, 0x73, 0xc7, 0xf2, 0x4f, 0x42, 0x3e, 0x0d, 0xa8, 0xdc, 0x18, 0x4f, 0x6d,
-	0x9e, 0x17, 0x35, 0xf4, 0x5f, 0x80, 0x43, 0xcf, 0x7e, 0x6c, 0x4f, 0xc8, 0xa3, 0x38, 0xff, 0xdb,
-	0x49, 0x73, 0x89, 0x65, 0x78, 0x07, 0x1a, 0xa9, 0x05, 0x96, 0x7f, 0x61, 0xd1, 0x86, 0x40, 0x2d,
-	0x30, 0x62, 0x31, 0x43, 0xab, 0xe0, 0xbf, 0xab, 0xb0, 0xb0, 0xeb, 0x4e, 0xa7, 0x96, 0x33, 0x46,
-	0x1d, 0xd0, 0x83, 0xb3, 0x19, 0x57, 0x5e, 0x8a, 0x9a, 0x5c, 0x28, 0xbc, 0x36, 0x3c, 0x9b, 0x11,
-	0xfc, 0x6b, 0x15, 0x74, 0xfa, 0x03, 0xad, 0x42, 0x6b, 0xd7, 0x23, 0x56, 0x40, 0xa8, 0x5b, 0x42,
-	0x95, 0xa6, 0x46, 0x97, 0x79, 0x56, 0x88, 0xcb, 0x25, 0xb4, 0x01, 0xab, 0x5c, 0x3b, 0xe2, 0x13,
-	0x89, 0xca, 0x68, 0x1d, 0xda, 0x7d, 0xcf, 0x9d, 0x65, 0x05, 0x3a, 0xea, 0xc0, 0x26, 0xdf, 0x93,
-	0x29, 0xb4, 0x48, 0xa3, 0x82, 0xb6, 0xe1, 0x12, 0xdd, 0xaa, 0x90, 0x57, 0xd1, 0xbf, 0xa0, 0x33,
-	0x20, 0x81, 0xbc, 0x33, 0x45, 0x5a, 0x0b, 0x14, 0xe7, 0xde, 0x6c, 0xac, 0xc6, 0xa9, 0xa1, 0xcb,
-	0xb0, 0xce, 0x99, 0x24, 0x25, 0x13, 0x09, 0x0d, 0x2a, 0xe4, 0x27, 0xce, 0x0b, 0x21, 0x39, 0x43,
-	0x26, 0x59, 0x22, 0x8d, 0xc5, 0xe8, 0x0c, 0x0a, 0x79, 0x3d, 0xf1, 0x33, 0x0d, 0x6d, 0xb4, 0xdc,
-	0x40, 0x6d, 0x58, 0xa6, 0xdb, 0xc4, 0xc5, 0x25, 0xaa, 0xcb, 0x4f, 0x22, 0x2e, 0x2f, 0x53, 0x0f,
-	0x0f, 0x48, 0x10, 0xc7, 0x3d, 0x12, 0x34, 0x11, 0x82, 0x25, 0xea, 0x1f, 0x2b, 0xb0, 0xa2, 0xb5,
-	0x16, 0xda, 0x04, 0x73, 0x40, 0x02, 0x96, 0x7f, 0xb9, 0x1d, 0x28, 0x41, 0x10, 0xc3, 0xdb, 0x46,
-	0x5b, 0xb0, 0x11, 0x3a, 0x48, 0xa8, 0xbb, 0x48, 0xbc, 0xca, 0x5c, 0xe4, 0xb9, 0x33, 0x99, 0x70,
-	0x8d, 0x9a, 0x3c, 0x22, 0x53, 0xf7, 0x31, 0x39, 0x24, 0x09, 0xe9, 0xf5, 0x24, 0x63, 0xa2, 0x1b,
-	0x2d, 0x12, 0x99, 0xe9, 0x64, 0x12, 0x45, 0x1b, 0x54, 0xc4, 0xf9, 0x65, 0x45, 0x97, 0xa8, 0x88,
-	0xc7, 0x29, 0x6b, 0xf0, 0x72, 0x22, 0xca, 0xee, 0xda, 0x44, 0x6b, 0x80, 0x06, 0x24, 0xc8, 0x6e,
-	0xd9, 0x42, 0x2b, 0xd0, 0x64, 0x47, 0xa2, 0x31, 0x8f, 0x56, 0xb7, 0xff, 0x5f, 0xab, 0x8d, 0x9b,
-	0xe7, 0xe7, 0xe7, 0xe7, 0x25, 0x7c, 0x22, 0x29, 0x8f, 0xf8, 0x92, 0x8d, 0x8b, 0xfe, 0xc8, 0x72,
-	0xc6, 0x7c, 0x2c, 0xe9, 0xbd, 0x01, 0x0b, 0xa3, 0x50, 0xad, 0x91, 0xaa, 0x3b, 0x93, 0x74, 0xb4,
-	0xee, 0x62, 0x6f, 0x3d, 0x5c, 0xcc, 0x1a, 0xc5, 0x8f, 0x24, 0x15, 0x97, 0x6a, 0xa3, 0x0d, 0xa8,
-	0xdc, 0x76, 0xbd, 0x11, 0xaf, 0xf7, 0x5a, 0x01, 0xd0, 0xb1, 0x08, 0x94, 0xb3, 0x89, 0x7f, 0xd1,
-	0x14, 0x45, 0x9c, 0x69, 0x66, 0x3d, 0x58, 0xce, 0x4f, 0x01, 0x5a, 0xe1, 0x55, 0xdf, 0x7b, 0x4b,
-	0x49, 0xea, 0x11, 0xdb, 0x7a, 0x59, 0x3c, 0x7d, 0x06, 0x1e, 0xdf, 0x97, 0x76, 0x90, 0x34, 0xab,
-	0xde, 0x9b, 0x4a, 0x84, 0x13, 0x91, 0x9c, 0xc4, 0x10, 0x1d, 0x7e, 0x0a, 0x3b, 0x91, 0xa4, 0xcf,
-	0x4a, 0x7d, 0x50, 0x2a, 0xf6, 0xc1, 0x4d, 0x25, 0x43, 0x9b, 0x31, 0xc4, 0xa2, 0x0f, 0xe4, 0x4c,
-	0xf0, 0xb3, 0xa2, 0x8e, 0x28, 0xe1, 0x19, 0xf9, 0x88, 0x5d, 0x3c, 0xbd, 0xf7, 0x95, 0x0c, 0x3e,
-	0x65, 0x0c, 0x3a, 0x89, 0x8f, 0x14, 0xf8, 0x3f, 0x68, 0x17, 0xb7, 0xdc, 0x0b, 0x69, 0xdc, 0x56,
-	0xd2, 0xf8, 0x8c, 0xd1, 0xf8, 0x4f, 0x78, 0xe3, 0x5f, 0x80, 0x83, 0xff, 0xd0, 0x8a, 0x3b, 0xfb,
-	0x45, 0x44, 0xe8, 0xcc, 0x73, 0x40, 0x4e, 0xd9, 0x42, 0x39, 0x37, 0x36, 0xea, 0xb9, 0xd1, 0xb0,
-	0x42, 0x47, 0xc3, 0x82, 0x30, 0x4e, 0xc4, 0x30, 0x16, 0x11, 0xc3, 0x3f, 0x6a, 0xca, 0x1b, 0x47,
-	0x42, 0x7a, 0x09, 0xaa, 0xa9, 0x69, 0xbb, 0x05, 0x06, 0x9d, 0xd3, 0xfc, 0xc0, 0x9a, 0xce, 0xf8,
-	0xb0, 0xd6, 0x7b, 0x47, 0x49, 0x6a, 0xca, 0x48, 0x6d, 0x89, 0xb9, 0x95, 0xc3, 0xc4, 0x3f, 0x69,
-	0xca, 0x4b, 0xee, 0x05, 0xf8, 0xac, 0x40, 0x3d, 0xf5, 0xc6, 0x61, 0x8f, 0xae, 0x02, 0x4a, 0x8e,
-	0x48, 0x49, 0x01, 0x8b, 0x7f, 0xd6, 0x8a, 0xaf, 0xd6, 0x0b, 0x83, 0x1b, 0x0f, 0x67, 0x65, 0x96,
-	0x74, 0xea, 0xb0, 0xb9, 0xf9, 0xea, 0x93, 0x43, 0x46, 0xd5, 0xf7, 0x6a, 0x84, 0x0a, 0xaa, 0x6f,
-	0x96, 0xad, 0x3e, 0x05, 0xfe, 0xa9, 0x64, 0x56, 0x78, 0x89, 0x49, 0xb3, 0xe0, 0x6a, 0xf8, 0x3c,
-	0x7f, 0x07, 0x09, 0x18, 0xf8, 0xe3, 0xdc, 0x34, 0x92, 0xe9, 0xbe, 0xd7, 0x95, 0x96, 0x3d, 0x66,
-	0x79, 0x35, 0x39, 0x9b, 0x68, 0xf7, 0x44, 0x32, 0xd0, 0x14, 0x1d, 0xa8, 0xe0, 0x04, 0xbe, 0x78,
-	0x82, 0x9c, 0x51, 0xfc, 0x9d, 0x26, 0x1d, 0x92, 0x68, 0xd0, 0xa8, 0x9a, 0x93, 0x7e, 0xd4, 0x45,
-	0x61, 0x2c, 0xe5, 0x87, 0x6a, 0xea, 0xc9, 0x4a, 0xc1, 0x6d, 0x13, 0x88, 0xb7, 0x8d, 0x04, 0x11,
-	0x3f, 0xc8, 0x0e, 0x65, 0xc8, 0xe4, 0x9f, 0x35, 0x18, 0xfe, 0x62, 0x0f, 0x92, 0x4f, 0x0f, 0xbd,
-	0x1d, 0x25, 0xcc, 0x9c, 0xc1, 0xac, 0x24, 0x9d, 0x32, 0xb1, 0x87, 0x9f, 0xaa, 0x47, 0x3c, 0xc9,
-	0x79, 0xe3, 0x1c, 0xe1, 0xe3, 0xc3, 0xbb, 0x4a, 0xc8, 0xc7, 0x0c, 0x72, 0x3b, 0x86, 0x94, 0x02,
-	0xe0, 0x63, 0xc9, 0x04, 0xa9, 0xfe, 0x12, 0x51, 0x10, 0xd0, 0xd3, 0x7c, 0x40, 0xc5, 0x69, 0xe5,
-	0x4f, 0xad, 0x60, 0x26, 0x95, 0xbc, 0xd3, 0xd3, 0x21, 0x5d, 0xcf, 0xdf, 0xdf, 0xe5, 0xd4, 0xcb,
-	0x51, 0x97, 0xbe, 0x1c, 0xe9, 0xb3, 0xd7, 0xe8, 0xbd, 0xa7, 0xe4, 0x7c, 0xc6, 0x38, 0x5f, 0x49,
-	0x35, 0xdb, 0x3c, 0x3b, 0xda, 0xdb, 0x54, 0x03, 0xf3, 0x2b, 0x33, 0x2f, 0xe8, 0xb7, 0x5f, 0xa4,
-	0xfa, 0xad, 0x1c, 0x97, 0xc6, 0x2d, 0x37, 0xa6, 0xc7, 0x71, 0xd3, 0x78, 0xdc, 0x6e, 0x8c, 0xc7,
-	0xde, 0x85, 0x71, 0x7b, 0x2a, 0xc6, 0x2d, 0x67, 0x12, 0x7f, 0xab, 0x29, 0x06, 0x7f, 0x7a, 0xd6,
-	0x3b, 0xc3, 0xe1, 0x21, 0x03, 0xd1, 0x84, 0xcf, 0x54, 0x09, 0x6a, 0x3c, 0x52, 0xf3, 0x1b, 0x46,
-	0x3d, 0x54, 0x7e, 0x99, 0x1f, 0x2a, 0x33, 0x68, 0xf8, 0x54, 0xf1, 0xc8, 0x78, 0x01, 0x1a, 0x05,
-	0xc0, 0x5f, 0xc9, 0xa7, 0x59, 0x11, 0xf8, 0xb9, 0xe2, 0x09, 0xf3, 0xa2, 0x9f, 0xeb, 0x8a, 0x09,
-	0x3c, 0x13, 0x09, 0x48, 0x71, 0xf0, 0x03, 0xc5, 0x43, 0x49, 0x24, 0x50, 0x80, 0xf0, 0x5c, 0x44,
-	0x90, 0x1a, 0xc2, 0x96, 0xe2, 0xbd, 0x95, 0x42, 0x78, 0x5b, 0x89, 0x70, 0xae, 0xe5, 0x21, 0xb2,
-	0x87, 0xd8, 0xa1, 0x73, 0x99, 0x3f, 0x73, 0x1d, 0x9f, 0x50, 0xab, 0x77, 0x3f, 0x64, 0x56, 0x6b,
-	0xb4, 0x9b, 0xdd, 0xf2, 0x3c, 0xd7, 0x63, 0x4f, 0x12, 0x23, 0xf9, 0x36, 0x4c, 0xe7, 0x3b, 0x1d,
-	0x9f, 0x6b, 0xb2, 0xe7, 0xde, 0xcb, 0x67, 0x9e, 0xba, 0xfd, 0x7f, 0xcd, 0xb9, 0x9b, 0x71, 0x97,
-	0xcc, 0xfa, 0xe6, 0x93, 0xfc, 0xc3, 0x32, 0xe5, 0x16, 0x75, 0x61, 0x7d, 0xc3, 0x4d, 0xaf, 0x09,
-	0x75, 0x2c, 0x18, 0xf9, 0x27, 0x00, 0x00, 0xff, 0xff, 0x5d, 0xc6, 0xc9, 0x45, 0x39, 0x17, 0x00,
-	0x00,
+	// 1759 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x9c, 0x58, 0x5f, 0x6f, 0x1b, 0x45,
+	0x10, 0xd7, 0xd9, 0x67, 0xc7, 0x37, 0xb6, 0x13, 0x7b, 0x9d, 0x3f, 0x97, 0x34, 0x49, 0xdd, 0x15,
+	0x05, 0x53, 0x89, 0x20, 0x59, 0xa9, 0x10, 0xe2, 0x6f, 0x1b, 0x37, 0x34, 0xaa, 0x92, 0xa6, 0xb1,
+	0x5b, 0xde, 0xaa, 0x5e, 0xed, 0x4d, 0x62, 0xb0, 0xef, 0xcc, 0xdd, 0x39, 0x69, 0x28, 0xb4, 0x01,
+	0x09, 0x21, 0x90, 0x10, 0xf0, 0xc2, 0x0b, 0x4f, 0xbc, 0xf1, 0x0d, 0x10, 0x9f, 0x83, 0x0f, 0xc0,
+	0x33, 0xdf, 0x02, 0xed, 0xee, 0xfd, 0xd9, 0xbb, 0xdb, 0xbb, 0xb4, 0x7d, 0xb3, 0x67, 0x66, 0xe7,
+	0xf7, 0x9b, 0x99, 0xdd, 0xb9, 0xd9, 0x85, 0xc6, 0xd0, 0x74, 0x89, 0x6d, 0x1a, 0xa3, 0xb7, 0xc7,
+	0xc4, 0x35, 0x36, 0x26, 0xb6, 0xe5, 0x5a, 0x48, 0xa5, 0xbf, 0xf1, 0x1f, 0x39, 0x50, 0x3b, 0x86,
+	0x6b, 0xa0, 0x0a, 0xa8, 0x3d, 0x62, 0x8f, 0x75, 0xa5, 0x99, 0x6b, 0xa9, 0xa8, 0x0a, 0x85, 0x1d,
+	0x73, 0x40, 0x9e, 0xe8, 0x39, 0xf6, 0xb7, 0x0e, 0xda, 0xd6, 0x68, 0xea, 0xb8, 0xc4, 0xde, 0xe9,
+	0xe8, 0x79, 0x26, 0x5a, 0x83, 0xc2, 0x9e, 0x35, 0x20, 0x8e, 0xae, 0x36, 0xf3, 0xad, 0x72, 0x7b,
+	0x76, 0x83, 0xb9, 0xa6, 0xa2, 0x1d, 0xf3, 0xd0, 0x42, 0x57, 0x41, 0xa3, 0x6e, 0x1f, 0x1b, 0x0e,
+	0x71, 0xf4, 0x02, 0x33, 0x41, 0xdc, 0xc4, 0x17, 0x33, 0xb3, 0x35, 0x28, 0xdc, 0x77, 0x88, 0xed,
+	0xe8, 0x45, 0xd1, 0x0b, 0x15, 0x31, 0x75, 0x1d, 0xb4, 0x5d, 0xe3, 0x09, 0x73, 0xda, 0xd1, 0x67,
+	0x18, 0xee, 0x12, 0xcc, 0xed, 0x1a, 0x4f, 0xba, 0xc7, 0x86, 0x3d, 0xf8, 0xc4, 0xb6, 0xa6, 0x93,
+	0x9d, 0x8e, 0x5e, 0x62, 0x0a, 0x04, 0xe0, 0x2b, 0x76, 0x3a, 0xba, 0xc6, 0x64, 0x57, 0x38, 0x0b,
+	0x4e, 0x14, 0xa4, 0x44, 0xaf, 0x80, 0xb6, 0x4b, 0x7c, 0x93, 0xb2, 0xcc, 0x04, 0x5f, 0x87, 0x52,
+	0x60, 0x0e, 0x90, 0xdb, 0xe9, 0x78, 0x49, 0xaa, 0x80, 0x7a, 0xdb, 0x72, 0x5c, 0x96, 0x23, 0x0d,
+	0xcd, 0xc1, 0x4c, 0x6f, 0x6b, 0x9f, 0x09, 0xf2, 0x4d, 0xa5, 0xa5, 0xe1, 0x7f, 0x15, 0xa8, 0x44,
+	0x82, 0xad, 0x80, 0xba, 0x67, 0x8c, 0x09, 0x5b, 0xad, 0xa1, 0x75, 0x58, 0xec, 0x90, 0x43, 0x63,
+	0x3a, 0x72, 0x0f, 0x88, 0x4b, 0x4c, 0x77, 0x68, 0x99, 0xfb, 0xd6, 0x68, 0xd8, 0x3f, 0xf3, 0xfc,
+	0x6d, 0x42, 0x3d, 0xaa, 0x18, 0x12, 0x47, 0xcf, 0x33, 0x82, 0xcb, 0x9c, 0x60, 0x6c, 0x1d, 0xc3,
+	0xd8, 0x84, 0xfa, 0x96, 0x65, 0xba, 0x43, 0x73, 0x6a, 0x4d, 0x9d, 0x7b, 0x53, 0x62, 0x0f, 0x83,
+	0x12, 0x79, 0xab, 0xa2, 0x6a, 0xbe, 0xea, 0x3a, 0xd4, 0x77, 0x89, 0xe1, 0x4c, 0x6d, 0x32, 0x26,
+	0xa6, 0x7b, 0x6f, 0x6a, 0xb9, 0x86, 0x5f, 0xb5, 0x15, 0xbe, 0x2a, 0xae, 0x66, 0x89, 0xf9, 0x59,
+	0x81, 0x46, 0x8c, 0x44, 0x77, 0x42, 0xfa, 0x42, 0xa0, 0x4a, 0x4b, 0x43, 0x35, 0x28, 0x75, 0xa6,
+	0xb6, 0x41, 0x6d, 0xf4, 0x5c, 0x53, 0x69, 0xe5, 0xd1, 0x0a, 0xa0, 0xb0, 0x80, 0x81, 0x2e, 0xcf,
+	0x74, 0x35, 0x28, 0x1d, 0x90, 0xc9, 0x68, 0xd8, 0x37, 0xf6, 0x74, 0xb5, 0xa9, 0xb4, 0xaa, 0xe8,
+	0x1a, 0x40, 0xc7, 0x3a, 0x35, 0x1d, 0x63, 0x3c, 0x19, 0x11, 0xbd, 0xd0, 0x54, 0x5a, 0xe5, 0xf6,
+	0xa2, 0xb7, 0x97, 0x02, 0x39, 0x47, 0xc7, 0xff, 0x25, 0x19, 0x49, 0x52, 0x1f, 0x65, 0x94, 0xcb,
+	0x60, 0x94, 0x4b, 0x30, 0xca, 0xb5, 0xaa, 0xe8, 0x4d, 0x28, 0x87, 0xd6, 0x7e, 0xa2, 0xe6, 0x39,
+	0x25, 0x61, 0x67, 0x52, 0xe0, 0xb7, 0xa0, 0xda, 0x9d, 0x3e, 0x76, 0xfa, 0xf6, 0x70, 0x42, 0x5d,
+	0xfa, 0x1b, 0xdd, 0xe3, 0x2f, 0xaa, 0x98, 0x79, 0x34, 0xd6, 0x99, 0xcc, 0x58, 0x7f, 0x50, 0x60,
+	0x36, 0x86, 0x26, 0xee, 0xce, 0x3a, 0x68, 0x5d, 0xd7, 0xb0, 0xdd, 0xde, 0x70, 0x4c, 0xbc, 0x28,
+	0xe7, 0x60, 0xe6, 0x96, 0x39, 0x60, 0x02, 0x1e, 0x5a, 0x1d, 0xb4, 0x0e, 0x19, 0x11, 0x97, 0x0c,
+	0x6e, 0xb8, 0x2c, 0xb6, 0x3c, 0xba, 0x0c, 0x45, 0xe6, 0xd4, 0x0f, 0x6b, 0x4e, 0x08, 0x8b, 0x61,
+	0x34, 0xa0, 0xdc, 0xb3, 0xa7, 0x66, 0xdf, 0xe0, 0xab, 0x8a, 0xb4, 0x6a, 0xb8, 0x0f, 0x5a, 0x68,
+	0x21, 0xb2, 0x98, 0x87, 0xd2, 0xdd, 0x53, 0x93, 0xf6, 0x0d, 0x47, 0xcf, 0x35, 0xf3, 0x2d, 0xf5,
+	0x66, 0x4e, 0x57, 0x50, 0x13, 0x8a, 0x4c, 0xea, 0x6f, 0xe8, 0x9a, 0x00, 0xc2, 0x14, 0xf4, 0x34,
+	0x1f, 0x10, 0xc7, 0x1a, 0x4d, 0x59, 0x21, 0x54, 0x06, 0xd2, 0x81, 0x5a, 0x22, 0x61, 0xd1, 0xc2,
+	0x56, 0x40, 0xdd, 0xb5, 0x06, 0xc4, 0x3b, 0x41, 0xf3, 0x50, 0xe9, 0x10, 0xc7, 0x1d, 0x9a, 0x06,
+	0x4f, 0x3d, 0xc5, 0xd2, 0xf0, 0x2a, 0x80, 0x80, 0x33, 0x0b, 0x45, 0xaf, 0xbd, 0x30, 0xbe, 0xb8,
+	0x0d, 0x0d, 0xd9, 0x01, 0x89, 0xc2, 0x54, 0xa1, 0xc0, 0x54, 0x1c, 0x07, 0x3f, 0x84, 0x52, 0xd0,
+	0xb1, 0x12, 0x7c, 0x6e, 0x1b, 0xce, 0xb1, 0xc7, 0xa7, 0x0a, 0x85, 0x1b, 0x83, 0xf1, 0x90, 0xef,
+	0xab, 0x12, 0x7a, 0x03, 0x60, 0xdf, 0x1e, 0x9e, 0x0c, 0x47, 0xe4, 0x28, 0x38, 0xa3, 0x8d, 0xb0,
+	0x01, 0x06, 0x3a, 0xbc, 0x09, 0xd5, 0x88, 0x80, 0xed, 0x5f, 0xaf, 0xb1, 0x78, 0x40, 0x75, 0xd0,
+	0x02, 0x35, 0x43, 0x2b, 0xe0, 0x7f, 0x8a, 0x30, 0xb3, 0x65, 0x8d, 0xc7, 0x86, 0x39, 0x40, 0x4d,
+	0x50, 0xdd, 0xb3, 0x09, 0x37, 0x9e, 0xf5, 0x1b, 0xb1, 0xa7, 0xdc, 0xe8, 0x9d, 0x4d, 0x08, 0xfe,
+	0xbd, 0x08, 0x2a, 0xfd, 0x81, 0x16, 0xa0, 0xbe, 0x65, 0x13, 0xc3, 0x25, 0x34, 0x2d, 0x9e, 0x49,
+	0x4d, 0xa1, 0x62, 0xbe, 0x53, 0x44, 0x71, 0x0e, 0x2d, 0xc3, 0x02, 0xb7, 0xf6, 0xf9, 0xf8, 0xaa,
+	0x3c, 0x5a, 0x82, 0x46, 0xc7, 0xb6, 0x26, 0x71, 0x85, 0x8a, 0x9a, 0xb0, 0xca, 0xd7, 0xc4, 0x0e,
+	0xaa, 0x6f, 0x51, 0x40, 0xeb, 0xb0, 0x42, 0x97, 0xa6, 0xe8, 0x8b, 0xe8, 0x35, 0x68, 0x76, 0x89,
+	0x2b, 0xef, 0x9e, 0xbe, 0xd5, 0x0c, 0xc5, 0xb9, 0x3f, 0x19, 0xa4, 0xe3, 0x94, 0xd0, 0x25, 0x58,
+	0xe2, 0x4c, 0xc2, 0x63, 0xe4, 0x2b, 0x35, 0xaa, 0xe4, 0x11, 0x27, 0x95, 0x10, 0xc6, 0x10, 0xdb,
+	0x2c, 0xbe, 0x45, 0xd9, 0x8f, 0x21, 0x45, 0x5f, 0x09, 0xf3, 0x4c, 0x4b, 0xeb, 0x8b, 0xab, 0xa8,
+	0x01, 0x73, 0x74, 0x99, 0x28, 0x9c, 0xa5, 0xb6, 0x3c, 0x12, 0x51, 0x3c, 0x47, 0x33, 0xdc, 0x25,
+	0x6e, 0x50, 0x77, 0x5f, 0x51, 0x43, 0x08, 0x66, 0x69, 0x7e, 0x0c, 0xd7, 0xf0, 0x65, 0x75, 0xb4,
+	0x0a, 0x7a, 0x97, 0xb8, 0x6c, 0xff, 0x25, 0x56, 0xa0, 0x10, 0x41, 0x2c, 0x6f, 0x03, 0xad, 0xc1,
+	0xb2, 0x97, 0x20, 0xe1, 0xdc, 0xf9, 0xea, 0x05, 0x96, 0x22, 0xdb, 0x9a, 0xc8, 0x94, 0x8b, 0xd4,
+	0xe5, 0x01, 0x19, 0x5b, 0x27, 0x64, 0x9f, 0x84, 0xa4, 0x97, 0xc2, 0x1d, 0xe3, 0x7f, 0x75, 0x7d,
+	0x95, 0x1e, 0xdd, 0x4c, 0xa2, 0x6a, 0x99, 0xaa, 0x38, 0xbf, 0xb8, 0x6a, 0x85, 0xaa, 0x78, 0x9d,
+	0xe2, 0x0e, 0x2f, 0x85, 0xaa, 0xf8, 0xaa, 0x55, 0xb4, 0x08, 0xa8, 0x4b, 0xdc, 0xf8, 0x92, 0x35,
+	0x34, 0x0f, 0x35, 0x16, 0x12, 0xad, 0xb9, 0x2f, 0x5d, 0xbf, 0x56, 0x2a, 0x0d, 0x6a, 0xe7, 0xe7,
+	0xe7, 0xe7, 0x39, 0x7c, 0x2c, 0x39, 0x1e, 0xc1, 0x20, 0x10, 0x1c, 0xfa, 0x03, 0xc3, 0x1c, 0xf0,
+	0xd1, 0xa9, 0xfd, 0x0e, 0xcc, 0xf4, 0x3d, 0xb3, 0x6a, 0xe4, 0xdc, 0xe9, 0x84, 0x75, 0xf7, 0x25,
+	0x4f, 0x18, 0x77, 0x8a, 0x8f, 0x24, 0x27, 0x2e, 0xd2, 0x5a, 0xab, 0x50, 0xd8, 0xb6, 0xec, 0x3e,
+	0x3f, 0xef, 0xa5, 0x0c, 0xa0, 0x43, 0x11, 0x28, 0xe1, 0x13, 0xff, 0xa6, 0xa4, 0x1c, 0xe2, 0x58,
+	0x33, 0x6b, 0xc3, 0x5c, 0x72, 0x52, 0x51, 0x32, 0xc7, 0x91, 0xf6, 0x7b, 0xa9, 0xa4, 0x8e, 0xd8,
+	0xd2, 0x4b, 0x62, 0xf4, 0x31, 0x78, 0xfc, 0x50, 0xda, 0x41, 0xa2, 0xac, 0xda, 0xef, 0xa6, 0x22,
+	0x1c, 0x8b, 0xe4, 0x24, 0x8e, 0xf0, 0x9f, 0x4a, 0x76, 0x27, 0x92, 0xf4, 0x59, 0x69, 0x0e, 0x72,
+	0xd9, 0x39, 0xb8, 0x99, 0xca, 0x70, 0xc8, 0x18, 0x62, 0x31, 0x07, 0x72, 0x26, 0xf8, 0x59, 0x56,
+	0x47, 0x94, 0xf0, 0xf4, 0x73, 0xc4, 0x3e, 0x3c, 0xed, 0x8f, 0x53, 0x19, 0x7c, 0xc6, 0x18, 0x34,
+	0xc3, 0x1c, 0xa5, 0xe0, 0xff, 0xa8, 0x5c, 0xdc, 0x72, 0x2f, 0xa4, 0xb1, 0x9d, 0x4a, 0xe3, 0x73,
+	0x46, 0xe3, 0x75, 0x2e, 0xbc, 0x08, 0x07, 0xff, 0xa5, 0x64, 0x77, 0xf6, 0x8b, 0x88, 0xd0, 0x39,
+	0x68, 0x8f, 0x9c, 0x32, 0x41, 0x3e, 0x31, 0xa2, 0xaa, 0x89, 0x31, 0x94, 0x8e, 0x9c, 0xd5, 0x8c,
+	0x32, 0x8e, 0xc4, 0x32, 0x66, 0x11, 0xc3, 0x3f, 0x29, 0xa9, 0x5f, 0x1c, 0x09, 0xe9, 0x59, 0x28,
+	0x46, 0x6e, 0x04, 0x75, 0xd0, 0xe8, 0xec, 0xe6, 0xb8, 0xc6, 0x78, 0xc2, 0x07, 0xb8, 0xf6, 0x07,
+	0xa9, 0xa4, 0xc6, 0x8c, 0xd4, 0x9a, 0xb8, 0xb7, 0x12, 0x98, 0xf8, 0x17, 0x25, 0xf5, 0x23, 0xf7,
+	0x02, 0x7c, 0xe6, 0xa1, 0x12, 0xb9, 0x87, 0xb1, 0x8b, 0x61, 0x06, 0x25, 0x53, 0xa4, 0x94, 0x02,
+	0x8b, 0x7f, 0x55, 0xb2, 0x3f, 0xad, 0x17, 0x16, 0x37, 0x18, 0xce, 0x28, 0x1d, 0x2d, 0xa3, 0x6c,
+	0x56, 0xf2, 0xf4, 0xc9, 0x21, 0xfd, 0xd3, 0xf7, 0x6a, 0x84, 0x32, 0x4e, 0xdf, 0x24, 0x7e, 0xfa,
+	0x52, 0xf0, 0x4f, 0x25, 0xb3, 0xc2, 0x4b, 0x4c, 0x9a, 0x19, 0x9f, 0x86, 0x2f, 0x92, 0xdf, 0x20,
+	0x01, 0x03, 0x3f, 0x48, 0x4c, 0x23, 0xb1, 0xee, 0x7b, 0x3d, 0xd5, 0xb3, 0xcd, 0x3c, 0x2f, 0x84,
+	0xb1, 0x89, 0x7e, 0x8f, 0x25, 0x03, 0x4d, 0x56, 0x40, 0x19, 0x11, 0x38, 0x62, 0x04, 0x09, 0xa7,
+	0xf8, 0x7b, 0x45, 0x3a, 0x24, 0xd1, 0xa2, 0x51, 0x33, 0x33, 0x7a, 0x29, 0xf4, 0xcb, 0x98, 0x4b,
+	0x0e, 0xd5, 0x34, 0x93, 0x85, 0x8c, 0xaf, 0x8d, 0x2b, 0x7e, 0x6d, 0x24, 0x88, 0xf8, 0x51, 0x7c,
+	0x28, 0x43, 0x3a, 0x7f, 0x7a, 0x61, 0xf8, 0xe5, 0x36, 0x84, 0xcf, 0x23, 0xed, 0xcd, 0x54, 0x98,
+	0x69, 0x53, 0x11, 0xee, 0x9a, 0x11, 0x7f, 0xf8, 0x69, 0xfa, 0x88, 0x27, 0x89, 0x37, 0xd8, 0x23,
+	0x7c, 0x7c, 0xf8, 0x30, 0x15, 0xf2, 0x84, 0x41, 0xae, 0x07, 0x90, 0x52, 0x00, 0x7c, 0x28, 0x99,
+	0x20, 0xd3, 0x5f, 0x4b, 0x32, 0x0a, 0x7a, 0x9a, 0x2c, 0xa8, 0x38, 0xad, 0xfc, 0xad, 0x64, 0xcc,
+	0xa4, 0x92, 0x7b, 0x7e, 0xb4, 0xa4, 0x4b, 0xc9, 0xef, 0x77, 0x3e, 0x72, 0x73, 0x54, 0xa5, 0x37,
+	0x47, 0x7a, 0x15, 0xd6, 0xda, 0x1f, 0xa5, 0x72, 0x3e, 0x63, 0x9c, 0x2f, 0x47, 0x9a, 0x6d, 0x92,
+	0x1d, 0xed, 0x6d, 0x69, 0x03, 0xf3, 0x2b, 0x33, 0xcf, 0xe8, 0xb7, 0x5f, 0x46, 0xfa, 0xad, 0x1c,
+	0x17, 0x1f, 0x4a, 0xc6, 0xf4, 0xa0, 0x6e, 0x0a, 0xaf, 0xdb, 0x8d, 0xc1, 0xc0, 0xbe, 0xb0, 0x6e,
+	0x4f, 0xc5, 0xba, 0x25, 0x5c, 0xe2, 0xef, 0x94, 0x94, 0xc1, 0x9f, 0xc6, 0x7a, 0xbb, 0xd7, 0xdb,
+	0x67, 0x20, 0x8a, 0xf0, 0x94, 0x16, 0xa2, 0x06, 0x23, 0x35, 0xff, 0xc2, 0xa4, 0x0f, 0x95, 0x5f,
+	0x25, 0x87, 0xca, 0x18, 0x1a, 0x3e, 0x4d, 0xb9, 0x64, 0xbc, 0x00, 0x8d, 0x0c, 0xe0, 0xaf, 0xe5,
+	0xd3, 0xac, 0x08, 0xfc, 0x3c, 0xe5, 0x0a, 0xf3, 0xa2, 0x4f, 0x8a, 0xd9, 0x04, 0x9e, 0x89, 0x04,
+	0xa4, 0x38, 0xf8, 0x51, 0xca, 0x45, 0x49, 0x24, 0x90, 0x81, 0xf0, 0x5c, 0x44, 0x90, 0x3a, 0xc2,
+	0x46, 0xca, 0x7d, 0x2b, 0x82, 0xf0, 0x7e, 0x2a, 0xc2, 0xb9, 0x92, 0x84, 0x88, 0x07, 0xb1, 0x49,
+	0xe7, 0x32, 0x67, 0x62, 0x99, 0x0e, 0xa1, 0x5e, 0xef, 0xde, 0x61, 0x5e, 0x4b, 0xb4, 0x9b, 0xdd,
+	0xb2, 0x6d, 0xcb, 0x66, 0x57, 0x12, 0x2d, 0x7c, 0xbf, 0xa6, 0xf3, 0x9d, 0x8a, 0xcf, 0x15, 0xd9,
+	0x75, 0xef, 0xe5, 0x77, 0x5e, 0x7a, 0xfb, 0xff, 0x86, 0x73, 0xd7, 0x83, 0x2e, 0x19, 0xcf, 0xcd,
+	0xa7, 0xc9, 0x8b, 0x65, 0x24, 0x2d, 0xe9, 0x07, 0xeb, 0x5b, 0x25, 0xf2, 0x0c, 0x18, 0x73, 0x82,
+	0xb7, 0xa1, 0x16, 0x7f, 0x1a, 0x64, 0xbd, 0xfd, 0xd0, 0x25, 0x3c, 0x2a, 0x36, 0xcc, 0xee, 0x98,
+	0x2e, 0xb1, 0x4f, 0x8c, 0x91, 0xf7, 0x12, 0x58, 0x07, 0x6d, 0x7b, 0x6a, 0xf6, 0xc5, 0x77, 0xb1,
+	0x87, 0x30, 0x2f, 0x7b, 0xe4, 0x8d, 0x35, 0x26, 0xfe, 0x22, 0xdf, 0xe5, 0xef, 0xca, 0xfc, 0x35,
+	0xf7, 0x2a, 0x68, 0x3d, 0xe3, 0xc8, 0x7b, 0x34, 0xce, 0x8b, 0x4f, 0xfd, 0xbe, 0x98, 0x3d, 0x16,
+	0x6f, 0x40, 0x45, 0xfc, 0x8f, 0xca, 0x90, 0xbf, 0x43, 0xce, 0x22, 0x6e, 0x1f, 0x18, 0xa3, 0x29,
+	0x73, 0x9b, 0x6b, 0xe5, 0xff, 0x1f, 0x00, 0xd0, 0x7b, 0xd8, 0x04, 0xb5, 0x18, 0x00, 0x00,
 }
@@ -12,6 +12,11 @@ import (
 type Config struct {
 	Enabled       bool          `toml:"enabled"`
 	CheckInterval toml.Duration `toml:"check-interval"`
+
+	// ShardSplitSize is the size on disk above which a shard that no longer receives
+	// writes is split into shards with shorter time ranges.  A value of 0 disables
+	// splitting shards.
+	ShardSplitSize int64 `toml:"shard-split-size"`
 }
 
 // NewConfig returns an instance of Config with defaults.
@@ -31,6 +36,10 @@ func (c Config) Validate() error {
 		return errors.New("check-interval must be positive")
 	}
 
+	if c.ShardSplitSize < 0 {
+		return errors.New("shard-split-size must be greater than or equal to 0")
+	}
+
 	return nil
 }
 
@@ -43,7 +52,8 @@ func (c Config) Diagnostics() (*diagnostics.Diagnostics, error) {
 	}
 
 	return diagnostics.RowFromMap(map[string]interface{}{
-		"enabled":        true,
-		"check-interval": c.CheckInterval,
+		"enabled":          true,
+		"check-interval":   c.CheckInterval,
+		"shard-split-size": c.ShardSplitSize,
 	}), nil
 }
@@ -14,6 +14,7 @@ func TestConfig_Parse(t *testing.T) {
 	if _, err := toml.Decode(`
 enabled = true
 check-interval = "1s"
+shard-split-size = 1073741824
 `, &c); err != nil {
 		t.Fatal(err)
 	}
@@ -23,6 +24,8 @@ check-interval = "1s"
 		t.Fatalf("unexpected enabled state: %v", c.Enabled)
 	} else if time.Duration(c.CheckInterval) != time.Second {
 		t.Fatalf("unexpected check interval: %v", c.CheckInterval)
+	} else if c.ShardSplitSize != 1<<30 {
+		t.Fatalf("unexpected shard split size: %v", c.ShardSplitSize)
 	}
 }
 
@@ -43,4 +46,10 @@ func TestConfig_Validate(t *testing.T) {
 	if err := c.Validate(); err == nil {
 		t.Fatal("expected error for negative check-interval, got nil")
 	}
+
+	c = retention.NewConfig()
+	c.ShardSplitSize = -1
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for negative shard-split-size, got nil")
+	}
 }
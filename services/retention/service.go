@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/tsdb"
 	"github.com/uber-go/zap"
 )
 
@@ -16,15 +17,24 @@ type Service struct {
 		Databases() []meta.DatabaseInfo
 		DeleteShardGroup(database, policy string, id uint64) error
 		PruneShardGroups() error
+		MergeShardGroups(ids []uint64) error
+		SetShardResolution(id uint64, resolution time.Duration) error
+		ShardGroupSplits(id uint64, n int) ([]meta.ShardGroupInfo, error)
+		SplitShardGroup(id uint64, n int) ([]meta.ShardGroupInfo, error)
 	}
 	TSDBStore interface {
 		ShardIDs() []uint64
 		DeleteShard(shardID uint64) error
+		DownsampleShard(shardID uint64, interval time.Duration, fns []string) error
+		ShardResolution(shardID uint64) time.Duration
+		IdleShardSizes() map[uint64]int64
+		SplitShard(id uint64, start, end time.Time, splits []tsdb.ShardSplit, commit func() ([]uint64, error)) error
 	}
 
-	checkInterval time.Duration
-	wg            sync.WaitGroup
-	done          chan struct{}
+	checkInterval  time.Duration
+	shardSplitSize int64
+	wg             sync.WaitGroup
+	done           chan struct{}
 
 	logger zap.Logger
 }
@@ -32,18 +42,24 @@ type Service struct {
 // NewService returns a configured retention policy enforcement service.
 func NewService(c Config) *Service {
 	return &Service{
-		checkInterval: time.Duration(c.CheckInterval),
-		done:          make(chan struct{}),
-		logger:        zap.New(zap.NullEncoder()),
+		checkInterval:  time.Duration(c.CheckInterval),
+		shardSplitSize: c.ShardSplitSize,
+		done:           make(chan struct{}),
+		logger:         zap.New(zap.NullEncoder()),
 	}
 }
 
 // Open starts retention policy enforcement.
 func (s *Service) Open() error {
 	s.logger.Info(fmt.Sprint("Starting retention policy enforcement service with check interval of ", s.checkInterval))
-	s.wg.Add(2)
+	s.wg.Add(3)
 	go s.deleteShardGroups()
 	go s.deleteShards()
+	go s.downsampleShards()
+	if s.shardSplitSize > 0 {
+		s.wg.Add(1)
+		go s.splitShards()
+	}
 	return nil
 }
 
@@ -135,3 +151,157 @@ func (s *Service) deleteShards() {
 		}
 	}
 }
+
+func (s *Service) downsampleShards() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+
+		case <-ticker.C:
+			local := make(map[uint64]struct{})
+			for _, id := range s.TSDBStore.ShardIDs() {
+				local[id] = struct{}{}
+			}
+
+			dbs := s.MetaClient.Databases()
+			for _, d := range dbs {
+				for _, r := range d.RetentionPolicies {
+					for _, g := range r.DownsampleShardGroups(time.Now().UTC()) {
+						for _, sh := range g.Shards {
+							if _, ok := local[sh.ID]; !ok || sh.Resolution != 0 {
+								continue
+							}
+
+							select {
+							case <-s.done:
+								return
+							default:
+							}
+
+							// A shard downsampled before the policy's interval was changed
+							// keeps its resolution, which is recorded so it isn't checked again.
+							if res := s.TSDBStore.ShardResolution(sh.ID); res != 0 && res != r.Downsample.Interval {
+								if err := s.MetaClient.SetShardResolution(sh.ID, res); err != nil {
+									s.logger.Error(fmt.Sprintf("failed to record resolution of shard ID %d from database %s, retention policy %s: %s",
+										sh.ID, d.Name, r.Name, err.Error()))
+								}
+								continue
+							}
+
+							if err := s.downsampleShard(sh.ID, r.Downsample); err != nil {
+								s.logger.Error(fmt.Sprintf("failed to downsample shard ID %d from database %s, retention policy %s: %s",
+									sh.ID, d.Name, r.Name, err.Error()))
+								continue
+							}
+							s.logger.Info(fmt.Sprintf("shard ID %d from database %s, retention policy %s, downsampled to %s",
+								sh.ID, d.Name, r.Name, r.Downsample.Interval))
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// downsampleShard downsamples the shard with id and records its new resolution.
+func (s *Service) downsampleShard(id uint64, dp *meta.DownsamplePolicy) error {
+	if err := s.TSDBStore.DownsampleShard(id, dp.Interval, dp.Functions); err != nil {
+		return err
+	}
+	return s.MetaClient.SetShardResolution(id, dp.Interval)
+}
+
+func (s *Service) splitShards() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+
+		case <-ticker.C:
+			// Only idle shards, which are fully compacted, are considered.
+			sizes := s.TSDBStore.IdleShardSizes()
+			now := time.Now().UTC()
+
+			dbs := s.MetaClient.Databases()
+			for _, d := range dbs {
+				for _, r := range d.RetentionPolicies {
+					for _, g := range r.ShardGroups {
+						if g.Deleted() || g.EndTime.After(now) {
+							continue
+						}
+
+						for _, sh := range g.Shards {
+							size, ok := sizes[sh.ID]
+							if !ok || size <= s.shardSplitSize {
+								continue
+							}
+
+							select {
+							case <-s.done:
+								return
+							default:
+							}
+
+							n := int((size + s.shardSplitSize - 1) / s.shardSplitSize)
+							if err := s.splitShard(sh.ID, n); err != nil {
+								s.logger.Error(fmt.Sprintf("failed to split shard ID %d from database %s, retention policy %s: %s",
+									sh.ID, d.Name, r.Name, err.Error()))
+							} else {
+								s.logger.Info(fmt.Sprintf("shard ID %d from database %s, retention policy %s, split into %d shards",
+									sh.ID, d.Name, r.Name, n))
+							}
+
+							// The shard group has changed so its other shards are checked next time.
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// splitShard splits the shard group of the shard with id into n shard groups and moves
+// the data of the shard into the shards of the new shard groups.  The split is only
+// committed to the meta store once the data has been copied, and is undone if the
+// shards can't be opened afterwards.
+func (s *Service) splitShard(id uint64, n int) error {
+	groups, err := s.MetaClient.ShardGroupSplits(id, n)
+	if err != nil {
+		return err
+	}
+
+	var splits []tsdb.ShardSplit
+	for _, g := range groups[1:] {
+		splits = append(splits, tsdb.ShardSplit{StartTime: g.StartTime, EndTime: g.EndTime})
+	}
+
+	var committed []meta.ShardGroupInfo
+	err = s.TSDBStore.SplitShard(id, groups[0].StartTime, groups[0].EndTime, splits, func() ([]uint64, error) {
+		a, err := s.MetaClient.SplitShardGroup(id, n)
+		if err != nil {
+			return nil, err
+		}
+		committed = a
+		return meta.SplitShardIDs(id, groups, committed)
+	})
+	if err != nil && committed != nil {
+		ids := make([]uint64, len(committed))
+		for i, g := range committed {
+			ids[i] = g.Shards[0].ID
+		}
+		if merr := s.MetaClient.MergeShardGroups(ids); merr != nil {
+			return fmt.Errorf("%s, and undoing the split failed: %s", err, merr)
+		}
+	}
+	return err
+}
@@ -6719,9 +6719,21 @@ func TestServer_Query_ShowSeries(t *testing.T) {
 			params:  url.Values{"db": []string{"db0"}},
 		},
 		&Query{
-			name:    `show series with WHERE time should fail`,
+			name:    `show series with WHERE time`,
 			command: "SHOW SERIES WHERE time > now() - 1h",
-			exp:     `{"results":[{"statement_id":0,"error":"SHOW SERIES doesn't support time in WHERE clause"}]}`,
+			exp:     `{"results":[{"statement_id":0}]}`,
+			params:  url.Values{"db": []string{"db0"}},
+		},
+		&Query{
+			name:    `show series with WHERE time and tag`,
+			command: "SHOW SERIES WHERE time >= '2009-11-10T23:00:04Z' AND host =~ /server0[23]/",
+			exp:     `{"results":[{"statement_id":0,"series":[{"columns":["key"],"values":[["cpu,host=server02,region=useast"],["disk,host=server03,region=caeast"],["gpu,host=server02,region=useast"],["gpu,host=server03,region=caeast"]]}]}]}`,
+			params:  url.Values{"db": []string{"db0"}},
+		},
+		&Query{
+			name:    `show series with WHERE time range`,
+			command: "SHOW SERIES WHERE time >= '2009-11-10T23:00:02Z' AND time < '2009-11-10T23:00:04Z'",
+			exp:     `{"results":[{"statement_id":0,"series":[{"columns":["key"],"values":[["cpu,host=server01,region=useast"],["cpu,host=server01,region=uswest"]]}]}]}`,
 			params:  url.Values{"db": []string{"db0"}},
 		},
 		&Query{
@@ -6853,9 +6865,15 @@ func TestServer_Query_ShowMeasurements(t *testing.T) {
 			params:  url.Values{"db": []string{"db0"}},
 		},
 		&Query{
-			name:    `show measurements with time in WHERE clauses errors`,
+			name:    `show measurements with time in WHERE clause`,
 			command: `SHOW MEASUREMENTS WHERE time > now() - 1h`,
-			exp:     `{"results":[{"statement_id":0,"error":"SHOW MEASUREMENTS doesn't support time in WHERE clause"}]}`,
+			exp:     `{"results":[{"statement_id":0}]}`,
+			params:  url.Values{"db": []string{"db0"}},
+		},
+		&Query{
+			name:    `show measurements with time and tag in WHERE clause`,
+			command: `SHOW MEASUREMENTS WHERE time >= '2009-11-10T00:00:00Z' AND region =~ /ca.*/`,
+			exp:     `{"results":[{"statement_id":0,"series":[{"name":"measurements","columns":["name"],"values":[["gpu"],["other"]]}]}]}`,
 			params:  url.Values{"db": []string{"db0"}},
 		},
 	}...)
@@ -7000,9 +7018,15 @@ func TestServer_Query_ShowTagKeys(t *testing.T) {
 			params:  url.Values{"db": []string{"db0"}},
 		},
 		&Query{
-			name:    `show tag values with key and time in WHERE clause should error`,
+			name:    `show tag values with key and time in WHERE clause`,
 			command: `SHOW TAG VALUES WITH KEY = host WHERE time > now() - 1h`,
-			exp:     `{"results":[{"statement_id":0,"error":"SHOW TAG VALUES doesn't support time in WHERE clause"}]}`,
+			exp:     `{"results":[{"statement_id":0}]}`,
+			params:  url.Values{"db": []string{"db0"}},
+		},
+		&Query{
+			name:    `show tag values with key and time range in WHERE clause`,
+			command: `SHOW TAG VALUES FROM cpu WITH KEY = host WHERE time >= '2009-11-10T00:00:00Z' AND time < '2009-11-11T00:00:00Z'`,
+			exp:     `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["key","value"],"values":[["host","server01"],["host","server02"]]}]}]}`,
 			params:  url.Values{"db": []string{"db0"}},
 		},
 	}...)
@@ -3,6 +3,7 @@ package tsdb
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/influxdata/influxdb/monitor/diagnostics"
@@ -24,6 +25,10 @@ const (
 	// reach before it starts rejecting writes.
 	DefaultCacheMaxMemorySize = 1024 * 1024 * 1024 // 1GB
 
+	// DefaultCacheMaxWriteWait is the maximum amount of time a write waits for room
+	// in a full cache before it is rejected.
+	DefaultCacheMaxWriteWait = time.Duration(5 * time.Second)
+
 	// DefaultCacheSnapshotMemorySize is the size at which the engine will
 	// snapshot the cache and write it to a TSM file, freeing up memory
 	DefaultCacheSnapshotMemorySize = 25 * 1024 * 1024 // 25MB
@@ -42,7 +47,6 @@ const (
 	DefaultMaxPointsPerBlock = 1000
 
 	// DefaultMaxSeriesPerDatabase is the maximum number of series a node can hold per database.
-	// This limit only applies to the "inmem" index.
 	DefaultMaxSeriesPerDatabase = 1000000
 
 	// DefaultMaxValuesPerTag is the maximum number of values a tag can have within a measurement.
@@ -51,6 +55,31 @@ const (
 	// DefaultMaxConcurrentCompactions is the maximum number of concurrent full and level compactions
 	// that can run at one time.  A value of results in runtime.GOMAXPROCS(0) used at runtime.
 	DefaultMaxConcurrentCompactions = 0
+
+	// DefaultWALMaxBatchSize is the maximum number of writes committed to the WAL with a single fsync.
+	DefaultWALMaxBatchSize = 256
+
+	// DefaultScrubInterval is the interval at which the data files of every shard are verified.
+	DefaultScrubInterval = time.Duration(24 * time.Hour)
+
+	// DefaultScrubRateLimit is the maximum number of bytes per second read when verifying
+	// the data files of shards.
+	DefaultScrubRateLimit = 8 * 1024 * 1024 // 8MB
+
+	// DefaultColdAfter is the age of the newest data in a shard after which its TSM files
+	// are moved to the cold tier directory, if one is configured.
+	DefaultColdAfter = time.Duration(30 * 24 * time.Hour)
+
+	// DefaultTSMAccessPolicy leaves the residency of the pages of mapped TSM files to
+	// the kernel.
+	DefaultTSMAccessPolicy = "kernel"
+
+	// DefaultTSMColdReader maps cold TSM files into memory like any other TSM file.
+	DefaultTSMColdReader = "mmap"
+
+	// DefaultDuplicatePolicy merges a point into a point already written with the same
+	// series key and timestamp.
+	DefaultDuplicatePolicy = "merge"
 )
 
 // Config holds the configuration for the tsbd package.
@@ -64,23 +93,89 @@ type Config struct {
 
 	// WALFsyncDelay is the amount of time that a write will wait before fsyncing.  A duration
 	// greater than 0 can be used to batch up multiple fsync calls.  This is useful for slower
-	// disks or when WAL write contention is seen.  A value of 0 fsyncs every write to the WAL.
+	// disks or when WAL write contention is seen.  A value of 0 fsyncs every write to the WAL,
+	// along with any other writes already waiting to be written.
 	WALFsyncDelay toml.Duration `toml:"wal-fsync-delay"`
 
+	// WALMaxBatchSize is the maximum number of writes committed to the WAL with a single fsync.
+	// A batch is committed as soon as it is full, without waiting for WALFsyncDelay.
+	WALMaxBatchSize int `toml:"wal-max-batch-size"`
+
+	// WALSegmentCompression is the codec used to compress batches of entries written to
+	// new WAL segments, either "snappy" or "deflate".  A value of "" compresses each entry
+	// on its own with snappy, which is the format used by previous versions.
+	WALSegmentCompression string `toml:"wal-segment-compression"`
+
 	// Query logging
 	QueryLogEnabled bool `toml:"query-log-enabled"`
 
+	// Scrubbing options
+
+	// ScrubInterval is the interval at which the block checksums, indexes and tombstones of
+	// every shard are verified in the background.  Corrupt shards are made read-only.  A value
+	// of 0 disables scrubbing.
+	ScrubInterval toml.Duration `toml:"scrub-interval"`
+
+	// ScrubRateLimit is the maximum number of bytes per second read when scrubbing shards.
+	// A value of 0 disables the limit.
+	ScrubRateLimit int `toml:"scrub-rate-limit"`
+
+	// ScrubRepairEnabled repairs corrupt shards by rewriting their TSM files without
+	// the blocks that fail verification.  Dropped blocks are recorded in quarantine files.
+	ScrubRepairEnabled bool `toml:"scrub-repair-enabled"`
+
+	// Tiered storage options
+
+	// ColdDir is the directory the TSM files of old shards are moved to.  It is typically
+	// on slower, cheaper disks than Dir.  A value of "" disables tiered storage.
+	ColdDir string `toml:"cold-dir"`
+
+	// ColdAfter is the age the newest data in a fully compacted shard must reach before its
+	// TSM files are moved to ColdDir.
+	ColdAfter toml.Duration `toml:"cold-after"`
+
 	// Compaction options for tsm1 (descriptions above with defaults)
 	CacheMaxMemorySize             uint64        `toml:"cache-max-memory-size"`
+	CacheMaxWriteWait              toml.Duration `toml:"cache-max-write-wait"`
 	CacheSnapshotMemorySize        uint64        `toml:"cache-snapshot-memory-size"`
 	CacheSnapshotWriteColdDuration toml.Duration `toml:"cache-snapshot-write-cold-duration"`
 	CompactFullWriteColdDuration   toml.Duration `toml:"compact-full-write-cold-duration"`
 
+	// BlockStatsEnabled stores the count, sum, minimum and maximum of each numeric block
+	// in new TSM files so aggregate queries can skip decoding blocks.  Files written with
+	// block statistics can not be read by versions without support for them.
+	BlockStatsEnabled bool `toml:"block-stats-enabled"`
+
+	// TSM file access options.  A TSM file is cold when it is in the cold tier or has
+	// not been modified for CompactFullWriteColdDuration.
+
+	// TSMAccessPolicy selects the access pattern hints given to the kernel for mapped TSM
+	// files.  "kernel" gives no hints.  "age" asks for the newest generation to be read
+	// ahead and for cold files to be read randomly, so a large scan of old data doesn't
+	// evict the recently written blocks from the page cache.
+	TSMAccessPolicy string `toml:"tsm-access-policy"`
+
+	// TSMColdReader selects how the blocks of cold TSM files are read.  "mmap" maps them
+	// into memory.  "pread" reads each block with a system call instead, bypassing mmap
+	// entirely.  Files are checked when they are opened.
+	TSMColdReader string `toml:"tsm-cold-reader"`
+
+	// DuplicatePolicy determines what happens to a point written with the series key and
+	// timestamp of a point already stored.  "merge" replaces the values of the fields of
+	// the existing point, "first" keeps the existing point and drops the new one and
+	// "reject" drops the new point and returns a partial write error.
+	DuplicatePolicy string `toml:"duplicate-policy"`
+
+	// DuplicatePolicies overrides DuplicatePolicy for a retention policy, keyed by
+	// "database.retention-policy", or for a measurement, keyed by
+	// "database.retention-policy.measurement".
+	DuplicatePolicies map[string]string `toml:"duplicate-policies"`
+
 	// Limits
 
 	// MaxSeriesPerDatabase is the maximum number of series a node can hold per database.
 	// When this limit is exceeded, writes return a 'max series per database exceeded' error.
-	// A value of 0 disables the limit. This limit only applies when using the "inmem" index.
+	// A value of 0 disables the limit.
 	MaxSeriesPerDatabase int `toml:"max-series-per-database"`
 
 	// MaxValuesPerTag is the maximum number of tag values a single tag key can have within
@@ -105,7 +200,20 @@ func NewConfig() Config {
 
 		QueryLogEnabled: true,
 
+		WALMaxBatchSize: DefaultWALMaxBatchSize,
+
+		ScrubInterval:  toml.Duration(DefaultScrubInterval),
+		ScrubRateLimit: DefaultScrubRateLimit,
+
+		ColdAfter: toml.Duration(DefaultColdAfter),
+
+		TSMAccessPolicy: DefaultTSMAccessPolicy,
+		TSMColdReader:   DefaultTSMColdReader,
+
+		DuplicatePolicy: DefaultDuplicatePolicy,
+
 		CacheMaxMemorySize:             DefaultCacheMaxMemorySize,
+		CacheMaxWriteWait:              toml.Duration(DefaultCacheMaxWriteWait),
 		CacheSnapshotMemorySize:        DefaultCacheSnapshotMemorySize,
 		CacheSnapshotWriteColdDuration: toml.Duration(DefaultCacheSnapshotWriteColdDuration),
 		CompactFullWriteColdDuration:   toml.Duration(DefaultCompactFullWriteColdDuration),
@@ -126,6 +234,56 @@ func (c *Config) Validate() error {
 		return errors.New("Data.WALDir must be specified")
 	}
 
+	if c.WALMaxBatchSize <= 0 {
+		return errors.New("wal-max-batch-size must be greater than 0")
+	}
+
+	switch c.WALSegmentCompression {
+	case "", "snappy", "deflate":
+	default:
+		return fmt.Errorf("unrecognized wal-segment-compression %s", c.WALSegmentCompression)
+	}
+
+	if c.ScrubInterval < 0 {
+		return errors.New("scrub-interval must be greater than or equal to 0")
+	}
+
+	if c.ScrubRateLimit < 0 {
+		return errors.New("scrub-rate-limit must be greater than or equal to 0")
+	}
+
+	if c.ColdDir != "" && c.ColdAfter <= 0 {
+		return errors.New("cold-after must be greater than 0")
+	}
+
+	switch c.TSMAccessPolicy {
+	case "kernel", "age":
+	default:
+		return fmt.Errorf("unrecognized tsm-access-policy %s", c.TSMAccessPolicy)
+	}
+
+	switch c.TSMColdReader {
+	case "mmap", "pread":
+	default:
+		return fmt.Errorf("unrecognized tsm-cold-reader %s", c.TSMColdReader)
+	}
+
+	if err := validateDuplicatePolicy(c.DuplicatePolicy); err != nil {
+		return err
+	}
+	for k, policy := range c.DuplicatePolicies {
+		if !strings.Contains(k, ".") {
+			return fmt.Errorf("invalid duplicate-policies key %s", k)
+		}
+		if err := validateDuplicatePolicy(policy); err != nil {
+			return err
+		}
+	}
+
+	if c.CacheMaxWriteWait < 0 {
+		return errors.New("cache-max-write-wait must be greater than or equal to 0")
+	}
+
 	if c.MaxConcurrentCompactions < 0 {
 		return errors.New("max-concurrent-compactions must be greater than 0")
 	}
@@ -155,16 +313,58 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+func validateDuplicatePolicy(policy string) error {
+	switch policy {
+	case "merge", "first", "reject":
+		return nil
+	default:
+		return fmt.Errorf("unrecognized duplicate-policy %s", policy)
+	}
+}
+
+// DuplicatePoliciesFor returns the duplicate policy of the shards of a retention policy and
+// the policies of the measurements that override it.
+func (c Config) DuplicatePoliciesFor(database, retentionPolicy string) (string, map[string]string) {
+	policy := c.DuplicatePolicy
+	if p, ok := c.DuplicatePolicies[database+"."+retentionPolicy]; ok {
+		policy = p
+	}
+
+	var measurements map[string]string
+	prefix := database + "." + retentionPolicy + "."
+	for k, p := range c.DuplicatePolicies {
+		if strings.HasPrefix(k, prefix) && len(k) > len(prefix) {
+			if measurements == nil {
+				measurements = make(map[string]string)
+			}
+			measurements[k[len(prefix):]] = p
+		}
+	}
+	return policy, measurements
+}
+
 // Diagnostics returns a diagnostics representation of a subset of the Config.
 func (c Config) Diagnostics() (*diagnostics.Diagnostics, error) {
 	return diagnostics.RowFromMap(map[string]interface{}{
 		"dir":                                c.Dir,
 		"wal-dir":                            c.WALDir,
 		"wal-fsync-delay":                    c.WALFsyncDelay,
+		"wal-max-batch-size":                 c.WALMaxBatchSize,
+		"wal-segment-compression":            c.WALSegmentCompression,
+		"scrub-interval":                     c.ScrubInterval,
+		"scrub-rate-limit":                   c.ScrubRateLimit,
+		"scrub-repair-enabled":               c.ScrubRepairEnabled,
+		"cold-dir":                           c.ColdDir,
+		"cold-after":                         c.ColdAfter,
 		"cache-max-memory-size":              c.CacheMaxMemorySize,
+		"cache-max-write-wait":               c.CacheMaxWriteWait,
 		"cache-snapshot-memory-size":         c.CacheSnapshotMemorySize,
 		"cache-snapshot-write-cold-duration": c.CacheSnapshotWriteColdDuration,
 		"compact-full-write-cold-duration":   c.CompactFullWriteColdDuration,
+		"block-stats-enabled":                c.BlockStatsEnabled,
+		"tsm-access-policy":                  c.TSMAccessPolicy,
+		"tsm-cold-reader":                    c.TSMColdReader,
+		"duplicate-policy":                   c.DuplicatePolicy,
 		"max-series-per-database":            c.MaxSeriesPerDatabase,
 		"max-values-per-tag":                 c.MaxValuesPerTag,
 		"max-concurrent-compactions":         c.MaxConcurrentCompactions,
@@ -15,6 +15,19 @@ func TestConfig_Parse(t *testing.T) {
 dir = "/var/lib/influxdb/data"
 wal-dir = "/var/lib/influxdb/wal"
 wal-fsync-delay = "10s"
+wal-max-batch-size = 64
+wal-segment-compression = "deflate"
+cold-dir = "/mnt/cold/influxdb/data"
+cold-after = "168h"
+block-stats-enabled = true
+cache-max-write-wait = "2s"
+tsm-access-policy = "age"
+tsm-cold-reader = "pread"
+duplicate-policy = "first"
+
+[duplicate-policies]
+"db0.rp0" = "reject"
+"db0.rp0.cpu.load" = "merge"
 `, &c); err != nil {
 		t.Fatal(err)
 	}
@@ -32,7 +45,44 @@ wal-fsync-delay = "10s"
 	if got, exp := c.WALFsyncDelay, time.Duration(10*time.Second); time.Duration(got).Nanoseconds() != exp.Nanoseconds() {
 		t.Errorf("unexpected wal-fsync-delay:\n\nexp=%v\n\ngot=%v\n\n", exp, got)
 	}
+	if got, exp := c.WALMaxBatchSize, 64; got != exp {
+		t.Errorf("unexpected wal-max-batch-size:\n\nexp=%v\n\ngot=%v\n\n", exp, got)
+	}
+	if got, exp := c.WALSegmentCompression, "deflate"; got != exp {
+		t.Errorf("unexpected wal-segment-compression:\n\nexp=%v\n\ngot=%v\n\n", exp, got)
+	}
+	if got, exp := c.ColdDir, "/mnt/cold/influxdb/data"; got != exp {
+		t.Errorf("unexpected cold-dir:\n\nexp=%v\n\ngot=%v\n\n", exp, got)
+	}
+	if got, exp := c.ColdAfter, time.Duration(7*24*time.Hour); time.Duration(got).Nanoseconds() != exp.Nanoseconds() {
+		t.Errorf("unexpected cold-after:\n\nexp=%v\n\ngot=%v\n\n", exp, got)
+	}
+	if !c.BlockStatsEnabled {
+		t.Errorf("unexpected block-stats-enabled: got=false")
+	}
+	if got, exp := c.CacheMaxWriteWait, time.Duration(2*time.Second); time.Duration(got).Nanoseconds() != exp.Nanoseconds() {
+		t.Errorf("unexpected cache-max-write-wait:\n\nexp=%v\n\ngot=%v\n\n", exp, got)
+	}
+	if got, exp := c.TSMAccessPolicy, "age"; got != exp {
+		t.Errorf("unexpected tsm-access-policy:\n\nexp=%v\n\ngot=%v\n\n", exp, got)
+	}
+	if got, exp := c.TSMColdReader, "pread"; got != exp {
+		t.Errorf("unexpected tsm-cold-reader:\n\nexp=%v\n\ngot=%v\n\n", exp, got)
+	}
+	if got, exp := c.DuplicatePolicy, "first"; got != exp {
+		t.Errorf("unexpected duplicate-policy:\n\nexp=%v\n\ngot=%v\n\n", exp, got)
+	}
 
+	policy, measurements := c.DuplicatePoliciesFor("db0", "rp0")
+	if exp := "reject"; policy != exp {
+		t.Errorf("unexpected db0.rp0 duplicate policy:\n\nexp=%v\n\ngot=%v\n\n", exp, policy)
+	}
+	if got, exp := len(measurements), 1; got != exp || measurements["cpu.load"] != "merge" {
+		t.Errorf("unexpected db0.rp0 measurement duplicate policies: %v", measurements)
+	}
+	if policy, measurements := c.DuplicatePoliciesFor("db0", "rp1"); policy != "first" || measurements != nil {
+		t.Errorf("unexpected db0.rp1 duplicate policies: %v, %v", policy, measurements)
+	}
 }
 
 func TestConfig_Validate_Error(t *testing.T) {
@@ -67,4 +117,52 @@ func TestConfig_Validate_Error(t *testing.T) {
 	if err := c.Validate(); err != nil {
 		t.Error(err)
 	}
+
+	c.WALMaxBatchSize = 0
+	if err := c.Validate(); err == nil || err.Error() != "wal-max-batch-size must be greater than 0" {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	c.WALMaxBatchSize = tsdb.DefaultWALMaxBatchSize
+	c.WALSegmentCompression = "zstd"
+	if err := c.Validate(); err == nil || err.Error() != "unrecognized wal-segment-compression zstd" {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	c.WALSegmentCompression = ""
+	c.ColdDir = "/mnt/cold/influxdb/data"
+	c.ColdAfter = 0
+	if err := c.Validate(); err == nil || err.Error() != "cold-after must be greater than 0" {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	c.ColdDir = ""
+	c.TSMAccessPolicy = "random"
+	if err := c.Validate(); err == nil || err.Error() != "unrecognized tsm-access-policy random" {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	c.TSMAccessPolicy = tsdb.DefaultTSMAccessPolicy
+	c.TSMColdReader = "direct"
+	if err := c.Validate(); err == nil || err.Error() != "unrecognized tsm-cold-reader direct" {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	c.TSMColdReader = tsdb.DefaultTSMColdReader
+	c.DuplicatePolicy = "last"
+	if err := c.Validate(); err == nil || err.Error() != "unrecognized duplicate-policy last" {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	c.DuplicatePolicy = tsdb.DefaultDuplicatePolicy
+	c.DuplicatePolicies = map[string]string{"db0": "first"}
+	if err := c.Validate(); err == nil || err.Error() != "invalid duplicate-policies key db0" {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	c.DuplicatePolicies = nil
+	c.CacheMaxWriteWait = -1
+	if err := c.Validate(); err == nil || err.Error() != "cache-max-write-wait must be greater than or equal to 0" {
+		t.Errorf("unexpected error: %s", err)
+	}
 }
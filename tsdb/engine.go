@@ -65,11 +65,14 @@ type Engine interface {
 	MeasurementTagKeysByExpr(name []byte, expr influxql.Expr) (map[string]struct{}, error)
 	ForEachMeasurementTagKey(name []byte, fn func(key []byte) error) error
 	TagKeyCardinality(name, key []byte) int
+	TagValuesByPrefix(name, key, prefix []byte, limit int) ([][]byte, error)
 
 	// InfluxQL iterators
 	MeasurementSeriesKeysByExpr(name []byte, condition influxql.Expr) ([][]byte, error)
 	ForEachMeasurementSeriesByExpr(name []byte, expr influxql.Expr, fn func(tags models.Tags) error) error
 	SeriesPointIterator(opt influxql.IteratorOptions) (influxql.Iterator, error)
+	HasSeriesValues(key []byte, min, max int64) (bool, error)
+	SeriesLastWrite(key []byte) (int64, bool, error)
 
 	// Statistics will return statistics relevant to this engine.
 	Statistics(tags map[string]string) []models.Statistic
@@ -77,6 +80,26 @@ type Engine interface {
 	DiskSize() int64
 	IsIdle() bool
 
+	// Scrub verifies the integrity of the engine's data files, reading at no more than
+	// the rate allowed by rate.  It returns an error describing the first corruption found,
+	// or nil if none was found before closing was closed.
+	Scrub(rate *limiter.Rate, closing <-chan struct{}) error
+
+	// Repair rewrites the engine's corrupt data files without the data that fails
+	// verification.  It returns an error if the data files are still corrupt.
+	Repair(closing <-chan struct{}) error
+
+	// Downsample rewrites the engine's data at a resolution of interval, aggregating
+	// the values of each numeric field with the functions in fns.
+	Downsample(interval time.Duration, fns []string) error
+
+	// Export writes a tar archive of the engine's values between start and end, inclusive,
+	// that can be added to another engine with Import.
+	Export(w io.Writer, basePath string, start, end time.Time) error
+
+	// Trim removes the engine's values outside of start and end, inclusive.
+	Trim(start, end time.Time) error
+
 	io.WriterTo
 }
 
@@ -145,8 +168,21 @@ type EngineOptions struct {
 	IndexVersion      string
 	ShardID           uint64
 	InmemIndex        interface{} // shared in-memory index
+	ColdPath          string      // cold tier directory for the shard, "" if disabled
 	CompactionLimiter limiter.Fixed
 
+	// DuplicatePolicy is the duplicate policy of the shard and MeasurementDuplicatePolicies
+	// holds the policies of measurements that override it.
+	DuplicatePolicy              string
+	MeasurementDuplicatePolicies map[string]string
+
+	// MeasurementQuotas holds the quotas of the measurements of the shard's database.
+	MeasurementQuotas *MeasurementQuotas
+
+	// SeriesLimiter enforces the limits of the shard's database across the indexes of
+	// its shards when each shard has its own index.
+	SeriesLimiter *SeriesLimiter
+
 	Config Config
 }
 
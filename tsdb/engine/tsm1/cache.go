@@ -124,6 +124,38 @@ func (e *entry) deduplicate() {
 	e.values = e.values.Deduplicate()
 }
 
+// contains returns true if the entry holds a value at time t.
+func (e *entry) contains(t int64) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	// Values are usually written in time order so the newest are checked first.
+	for i := len(e.values) - 1; i >= 0; i-- {
+		if e.values[i].UnixNano() == t {
+			return true
+		}
+	}
+	return false
+}
+
+// maxTime returns the newest time of the entry's values.  It returns false if the
+// entry has no values.
+func (e *entry) maxTime() (int64, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if len(e.values) == 0 {
+		return 0, false
+	}
+	max := e.values[0].UnixNano()
+	for _, v := range e.values[1:] {
+		if t := v.UnixNano(); t > max {
+			max = t
+		}
+	}
+	return max, true
+}
+
 // count returns the number of values in this entry.
 func (e *entry) count() int {
 	e.mu.RLock()
@@ -164,6 +196,8 @@ const (
 	statCacheWriteOK      = "writeOk"
 	statCacheWriteErr     = "writeErr"
 	statCacheWriteDropped = "writeDropped"
+	statCacheWriteWait    = "writeWait"    // counter: Number of writes that waited for room in the cache.
+	statCacheWriteTimeout = "writeTimeout" // counter: Number of writes that timed out waiting for room in the cache.
 )
 
 // storer is the interface that descibes a cache's store.
@@ -199,6 +233,14 @@ type Cache struct {
 	// This number is the number of pending or failed WriteSnaphot attempts since the last successful one.
 	snapshotAttempts int
 
+	// maxWriteWait is the maximum amount of time a write waits for room in a full cache.
+	// Writes waiting for room signal pressure to have a snapshot written, and are woken
+	// up when freed is closed after a snapshot has been cleared.
+	maxWriteWait time.Duration
+	waiting      int64
+	pressure     chan struct{}
+	freed        chan struct{}
+
 	stats        *CacheStatistics
 	lastSnapshot time.Time
 }
@@ -212,6 +254,8 @@ func NewCache(maxSize uint64, path string) *Cache {
 		store:        store, // Max size for now..
 		stats:        &CacheStatistics{},
 		lastSnapshot: time.Now(),
+		pressure:     make(chan struct{}, 1),
+		freed:        make(chan struct{}),
 	}
 	c.UpdateAge()
 	c.UpdateCompactTime(0)
@@ -232,6 +276,8 @@ type CacheStatistics struct {
 	WriteOK             int64
 	WriteErr            int64
 	WriteDropped        int64
+	WriteWait           int64
+	WriteTimeout        int64
 }
 
 // Statistics returns statistics for periodic monitoring.
@@ -249,22 +295,85 @@ func (c *Cache) Statistics(tags map[string]string) []models.Statistic {
 			statCacheWriteOK:        atomic.LoadInt64(&c.stats.WriteOK),
 			statCacheWriteErr:       atomic.LoadInt64(&c.stats.WriteErr),
 			statCacheWriteDropped:   atomic.LoadInt64(&c.stats.WriteDropped),
+			statCacheWriteWait:      atomic.LoadInt64(&c.stats.WriteWait),
+			statCacheWriteTimeout:   atomic.LoadInt64(&c.stats.WriteTimeout),
 		},
 	}}
 }
 
+// SetMaxWriteWait sets the maximum amount of time a write waits for a snapshot to
+// free room in the cache before it is rejected.  A value of 0 rejects writes as soon
+// as the cache is full.
+func (c *Cache) SetMaxWriteWait(d time.Duration) {
+	c.maxWriteWait = d
+}
+
+// Pressure returns a channel that is signalled when a write is waiting for room in
+// the cache.
+func (c *Cache) Pressure() <-chan struct{} {
+	return c.pressure
+}
+
+// WritesWaiting returns true if any write is waiting for room in the cache.
+func (c *Cache) WritesWaiting() bool {
+	return atomic.LoadInt64(&c.waiting) > 0
+}
+
+// waitForRoom waits until there is room in the cache for addedSize bytes.  It returns
+// ErrCacheMemorySizeLimitExceeded if waiting is disabled or the values could never fit,
+// and tsdb.ErrCacheFull if there is still no room after the max write wait.
+func (c *Cache) waitForRoom(addedSize uint64) error {
+	limit := c.maxSize // maxSize is safe for reading without a lock.
+	n := c.Size() + addedSize
+	if limit == 0 || n <= limit {
+		return nil
+	} else if c.maxWriteWait <= 0 || addedSize > limit {
+		return ErrCacheMemorySizeLimitExceeded(n, limit)
+	}
+
+	atomic.AddInt64(&c.waiting, 1)
+	defer atomic.AddInt64(&c.waiting, -1)
+	atomic.AddInt64(&c.stats.WriteWait, 1)
+
+	timer := time.NewTimer(c.maxWriteWait)
+	defer timer.Stop()
+
+	for {
+		// Grab the channel before checking the size so a snapshot cleared in between
+		// isn't missed.
+		c.mu.RLock()
+		freed := c.freed
+		c.mu.RUnlock()
+
+		if c.Size()+addedSize <= limit {
+			return nil
+		}
+
+		// Ask for a snapshot to be written.
+		select {
+		case c.pressure <- struct{}{}:
+		default:
+		}
+
+		select {
+		case <-freed:
+		case <-timer.C:
+			atomic.AddInt64(&c.stats.WriteTimeout, 1)
+			return tsdb.ErrCacheFull
+		}
+	}
+}
+
 // Write writes the set of values for the key to the cache. This function is goroutine-safe.
-// It returns an error if the cache will exceed its max size by adding the new values.
+// If the cache will exceed its max size by adding the new values, it waits up to the max
+// write wait for a snapshot to free room and returns an error if there is still no room.
 func (c *Cache) Write(key string, values []Value) error {
 	addedSize := uint64(Values(values).Size())
 
 	// Enough room in the cache?
-	limit := c.maxSize
-	n := c.Size() + addedSize
-
-	if limit > 0 && n > limit {
+	if err := c.waitForRoom(addedSize); err != nil {
 		atomic.AddInt64(&c.stats.WriteErr, 1)
-		return ErrCacheMemorySizeLimitExceeded(n, limit)
+		return err
 	}
 
 	if err := c.store.write(key, values); err != nil {
@@ -281,24 +390,34 @@ func (c *Cache) Write(key string, values []Value) error {
 }
 
 // WriteMulti writes the map of keys and associated values to the cache. This
-// function is goroutine-safe. It returns an error if the cache will exceeded
-// its max size by adding the new values.  The write attempts to write as many
+// function is goroutine-safe. It waits like Write if the cache will exceed its
+// max size by adding the new values.  The write attempts to write as many
 // values as possible.  If one key fails, the others can still succeed and an
 // error will be returned.
 func (c *Cache) WriteMulti(values map[string][]Value) error {
-	var addedSize uint64
-	for _, v := range values {
-		addedSize += uint64(Values(v).Size())
-	}
+	addedSize := valuesSize(values)
 
 	// Enough room in the cache?
-	limit := c.maxSize // maxSize is safe for reading without a lock.
-	n := c.Size() + addedSize
-	if limit > 0 && n > limit {
+	if err := c.waitForRoom(addedSize); err != nil {
 		atomic.AddInt64(&c.stats.WriteErr, 1)
-		return ErrCacheMemorySizeLimitExceeded(n, limit)
+		return err
 	}
+	return c.writeMulti(values, addedSize)
+}
 
+// valuesSize returns the size of the values to be added to the cache.
+func valuesSize(values map[string][]Value) uint64 {
+	var n uint64
+	for _, v := range values {
+		n += uint64(Values(v).Size())
+	}
+	return n
+}
+
+// writeMulti writes values of addedSize bytes to the cache without waiting for room.
+// Callers that hold a lock a snapshot needs, such as the engine, wait for room before
+// taking the lock and write with writeMulti.
+func (c *Cache) writeMulti(values map[string][]Value, addedSize uint64) error {
 	var werr error
 	c.mu.RLock()
 	store := c.store
@@ -411,6 +530,12 @@ func (c *Cache) ClearSnapshot(success bool) {
 
 		atomic.StoreUint64(&c.snapshotSize, 0)
 		c.updateSnapshots()
+
+		// Wake up writes waiting for room in the cache.
+		if c.freed != nil {
+			close(c.freed)
+			c.freed = make(chan struct{})
+		}
 	}
 }
 
@@ -452,6 +577,49 @@ func (c *Cache) unsortedKeys() []string {
 	return store.keys(false)
 }
 
+// ContainsValue returns true if the cache, or the snapshot being written, holds a value
+// for key at time t.
+func (c *Cache) ContainsValue(key string, t int64) bool {
+	var snapshotEntries *entry
+
+	c.mu.RLock()
+	e, ok := c.store.entry(key)
+	if c.snapshot != nil {
+		snapshotEntries, _ = c.snapshot.store.entry(key)
+	}
+	c.mu.RUnlock()
+
+	if ok && e.contains(t) {
+		return true
+	}
+	return snapshotEntries != nil && snapshotEntries.contains(t)
+}
+
+// MaxTime returns the newest time of the values for key in the cache and its
+// snapshot, without copying them.  It returns false if there are no values.
+func (c *Cache) MaxTime(key string) (int64, bool) {
+	var snapshotEntries *entry
+
+	c.mu.RLock()
+	e, ok := c.store.entry(key)
+	if c.snapshot != nil {
+		snapshotEntries, _ = c.snapshot.store.entry(key)
+	}
+	c.mu.RUnlock()
+
+	var max int64
+	var found bool
+	if ok {
+		max, found = e.maxTime()
+	}
+	if snapshotEntries != nil {
+		if t, ok := snapshotEntries.maxTime(); ok && (!found || t > max) {
+			max, found = t, true
+		}
+	}
+	return max, found
+}
+
 // Values returns a copy of all values, deduped and sorted, for the given key.
 func (c *Cache) Values(key string) Values {
 	var snapshotEntries *entry
@@ -687,6 +855,8 @@ func valueType(v Value) int {
 		return 3
 	case BooleanValue:
 		return 4
+	case UnsignedValue:
+		return 5
 	default:
 		return 0
 	}
@@ -7,14 +7,17 @@ import (
 	"math"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"reflect"
 	"runtime"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/snappy"
+	"github.com/influxdata/influxdb/tsdb"
 )
 
 func TestCache_NewCache(t *testing.T) {
@@ -348,6 +351,36 @@ func TestCache_CacheValues(t *testing.T) {
 	}
 }
 
+func TestCache_MaxTime(t *testing.T) {
+	c := NewCache(512, "")
+	if _, ok := c.MaxTime("foo"); ok {
+		t.Fatalf("max time returned for no such key")
+	}
+
+	if err := c.Write("foo", Values{NewValue(2, 2.0), NewValue(5, 5.0), NewValue(1, 1.0)}); err != nil {
+		t.Fatalf("failed to write values to cache: %s", err.Error())
+	}
+	if max, ok := c.MaxTime("foo"); !ok || max != 5 {
+		t.Fatalf("unexpected max time: %d, %v", max, ok)
+	}
+
+	// The snapshot's values are used as well.
+	if _, err := c.Snapshot(); err != nil {
+		t.Fatalf("failed to snapshot cache: %v", err)
+	}
+	if err := c.Write("foo", Values{NewValue(3, 3.0)}); err != nil {
+		t.Fatalf("failed to write values to cache: %s", err.Error())
+	}
+	if max, ok := c.MaxTime("foo"); !ok || max != 5 {
+		t.Fatalf("unexpected max time: %d, %v", max, ok)
+	}
+
+	c.ClearSnapshot(true)
+	if max, ok := c.MaxTime("foo"); !ok || max != 3 {
+		t.Fatalf("unexpected max time: %d, %v", max, ok)
+	}
+}
+
 func TestCache_CacheSnapshot(t *testing.T) {
 	v0 := NewValue(2, 0.0)
 	v1 := NewValue(3, 2.0)
@@ -518,6 +551,59 @@ func TestCache_CacheWriteMemoryExceeded(t *testing.T) {
 	}
 }
 
+func TestCache_CacheWriteMemoryExceeded_Wait(t *testing.T) {
+	v0 := NewValue(1, 1.0)
+	v1 := NewValue(2, 2.0)
+
+	c := NewCache(uint64(v1.Size()), "")
+	c.SetMaxWriteWait(10 * time.Second)
+
+	if err := c.Write("foo", Values{v0}); err != nil {
+		t.Fatalf("failed to write key foo to cache: %s", err.Error())
+	}
+	if _, err := c.Snapshot(); err != nil {
+		t.Fatalf("failed to snapshot cache: %v", err)
+	}
+
+	// The write waits for the snapshot to be cleared.
+	errC := make(chan error)
+	go func() { errC <- c.Write("bar", Values{v1}) }()
+
+	select {
+	case <-c.Pressure():
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for cache pressure")
+	}
+	if !c.WritesWaiting() {
+		t.Fatal("expected write to be waiting")
+	}
+
+	c.ClearSnapshot(true)
+	if err := <-errC; err != nil {
+		t.Fatalf("failed to write key bar to cache: %s", err.Error())
+	}
+	if exp, keys := []string{"bar"}, c.Keys(); !reflect.DeepEqual(keys, exp) {
+		t.Fatalf("cache keys incorrect after writes, exp %v, got %v", exp, keys)
+	}
+
+	// A write that doesn't get room in time is rejected.
+	c.SetMaxWriteWait(10 * time.Millisecond)
+	if err := c.Write("baz", Values{v0}); err != tsdb.ErrCacheFull {
+		t.Fatalf("wrong error writing key baz to cache: %v", err)
+	}
+
+	// Values that can never fit are rejected without waiting.
+	if err := c.WriteMulti(map[string][]Value{"baz": {v0, v1}}); err == nil || !strings.Contains(err.Error(), "cache-max-memory-size") {
+		t.Fatalf("wrong error writing key baz to cache: %v", err)
+	}
+
+	if got, exp := c.stats.WriteWait, int64(2); got != exp {
+		t.Fatalf("got %d write waits, expected %d", got, exp)
+	} else if got, exp := c.stats.WriteTimeout, int64(1); got != exp {
+		t.Fatalf("got %d write timeouts, expected %d", got, exp)
+	}
+}
+
 func TestCache_Deduplicate_Concurrent(t *testing.T) {
 	if testing.Short() || os.Getenv("GORACE") != "" || os.Getenv("APPVEYOR") != "" {
 		t.Skip("Skipping test in short, race, appveyor mode.")
@@ -690,6 +776,75 @@ func TestCacheLoader_LoadDouble(t *testing.T) {
 	}
 }
 
+// Ensure the CacheLoader can load segments written with and without segment compression.
+func TestCacheLoader_LoadCompressed(t *testing.T) {
+	dir := mustTempDir()
+	defer os.RemoveAll(dir)
+
+	// Write a segment in the format used before segment compression.
+	f, err := os.Create(filepath.Join(dir, fmt.Sprintf("%s%05d.%s", WALFilePrefix, 1, WALFileExtension)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := NewWALSegmentWriter(f)
+
+	p1 := NewValue(1, 1.1)
+	if err := w.Write(mustMarshalEntry(&WriteWALEntry{Values: map[string][]Value{"foo": []Value{p1}}})); err != nil {
+		t.Fatal("write points", err)
+	}
+	if err := w.close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	// Append compressed segments with a WAL.
+	wal := NewWAL(dir)
+	wal.compression = WALCompressionDeflate
+	if err := wal.Open(); err != nil {
+		t.Fatalf("error opening WAL: %v", err)
+	}
+
+	var exp Values
+	for i := 0; i < 100; i++ {
+		exp = append(exp, NewValue(int64(i), "value"))
+	}
+	if _, err := wal.WriteMulti(map[string][]Value{"bar": exp}); err != nil {
+		t.Fatalf("error writing points: %v", err)
+	}
+	if _, err := wal.Delete([]string{"baz"}); err != nil {
+		t.Fatalf("error deleting keys: %v", err)
+	}
+
+	stats := wal.Statistics(nil)[0].Values
+	if ratio := stats[statWALCompressionRatio].(float64); ratio <= 1 {
+		t.Fatalf("unexpected compression ratio: %v", ratio)
+	}
+
+	if err := wal.Close(); err != nil {
+		t.Fatalf("error closing wal: %v", err)
+	}
+
+	files, err := segmentFileNames(dir)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(files) != 2 {
+		t.Fatalf("unexpected segments: %v", files)
+	}
+
+	// Load the cache using both segments.
+	cache := NewCache(1024*1024, "")
+	loader := NewCacheLoader(files)
+	if err := loader.Load(cache); err != nil {
+		t.Fatalf("failed to load cache: %s", err.Error())
+	}
+
+	if values := cache.Values("foo"); !reflect.DeepEqual(values, Values{p1}) {
+		t.Fatalf("cache key foo not as expected, got %v, exp %v", values, Values{p1})
+	}
+	if values := cache.Values("bar"); !reflect.DeepEqual(values, exp) {
+		t.Fatalf("cache key bar not as expected, got %v, exp %v", values, exp)
+	}
+}
+
 // Ensure the CacheLoader can load deleted series
 func TestCacheLoader_LoadDeleted(t *testing.T) {
 	// Create a WAL segment.
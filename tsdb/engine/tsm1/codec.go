@@ -0,0 +1,251 @@
+package tsm1
+
+// The values section of every TSM block starts with a 1 byte header.  The 4 high
+// bits of the header identify the encoding used for the remaining bytes, leaving
+// room for 16 encodings per block type.  The built-in encodings (gorilla for floats,
+// simple8b and RLE for integers, bit packing for booleans and snappy or a dictionary
+// for strings) occupy the lowest identifiers.  Additional encodings can be added by
+// registering a Codec under one of the free identifiers.
+//
+// When a block is encoded, the values are first encoded with the built-in encoder for
+// the block type and then with every registered codec for that block type.  The
+// smallest result is written to the block.  Decoding dispatches on the identifier
+// stored in the header.
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Codec encodes and decodes the values section of a TSM block.
+type Codec interface {
+	// Name returns a short name for the codec as reported by influx_inspect dumptsm.
+	Name() string
+
+	// Encode encodes values, which is a []float64, []int64, []bool or []string depending
+	// on the block type the codec was registered for.  The 1 byte encoding header must not
+	// be included in the returned bytes.  Encode may return a nil slice and no error if the
+	// codec is not suitable for values, in which case another encoding is used.
+	Encode(values interface{}) ([]byte, error)
+
+	// Decode decodes b, as returned by Encode, into a slice of the same type as the
+	// values passed to Encode.
+	Decode(b []byte) (interface{}, error)
+}
+
+// maxCodecEncoding is the largest encoding identifier that fits in the 4 high bits
+// of the values header.
+const maxCodecEncoding = 15
+
+var (
+	// builtinCodecs holds the names of the built-in encodings for each block type,
+	// indexed by encoding identifier.
+	builtinCodecs = [][]string{
+		BlockFloat64: {"none", "gor"},
+		BlockInteger: {"none", "s8b", "rle"},
+		BlockBoolean: {"none", "bp"},
+		BlockString:  {"none", "snpy", "dict"},
+	}
+
+	// codecs holds the registered codecs for each block type, indexed by encoding identifier.
+	codecs [BlockString + 1][maxCodecEncoding + 1]Codec
+)
+
+// codecBlockType returns the block type whose encodings are used for blocks of type typ.
+// Unsigned blocks store the bit pattern of their values using the integer encodings.
+func codecBlockType(typ byte) byte {
+	if typ == BlockUnsigned {
+		return BlockInteger
+	}
+	return typ
+}
+
+// RegisterCodec registers c as the encoding identified by enc for blocks of type typ.
+// Codecs registered for BlockInteger are also used for BlockUnsigned, which receive
+// the two's complement bit pattern of each value as an int64.  RegisterCodec panics if
+// enc is already in use and is not safe to call concurrently with encoding or decoding
+// blocks, so it should be called from an init function.
+func RegisterCodec(typ byte, enc byte, c Codec) {
+	typ = codecBlockType(typ)
+	if int(typ) >= len(codecs) {
+		panic(fmt.Sprintf("unknown block type: %d", typ))
+	}
+	if enc > maxCodecEncoding {
+		panic(fmt.Sprintf("codec encoding out of range: %d", enc))
+	}
+	if int(enc) < len(builtinCodecs[typ]) || codecs[typ][enc] != nil {
+		panic(fmt.Sprintf("codec encoding already registered: %d", enc))
+	}
+	codecs[typ][enc] = c
+}
+
+// CodecName returns the name of the encoding identified by enc for blocks of type typ.
+func CodecName(typ byte, enc byte) string {
+	typ = codecBlockType(typ)
+	if int(typ) >= len(codecs) || enc > maxCodecEncoding {
+		return "unknown"
+	}
+	if int(enc) < len(builtinCodecs[typ]) {
+		return builtinCodecs[typ][enc]
+	}
+	if c := codecs[typ][enc]; c != nil {
+		return c.Name()
+	}
+	return strconv.Itoa(int(enc))
+}
+
+// hasCodecs returns true if any codecs are registered for blocks of type typ.
+func hasCodecs(typ byte) bool {
+	for _, c := range codecs[codecBlockType(typ)] {
+		if c != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupCodec returns the registered codec used to encode vb, the values section
+// of a block of type typ.  It returns nil if vb uses a built-in encoding.
+func lookupCodec(typ byte, vb []byte) Codec {
+	if len(vb) == 0 {
+		return nil
+	}
+	return codecs[codecBlockType(typ)][vb[0]>>4]
+}
+
+// encodeSmallest encodes values with every registered codec for blocks of type typ
+// and returns the smallest of the results and vb, the values encoded by the built-in
+// encoder.
+func encodeSmallest(typ byte, vb []byte, values interface{}) ([]byte, error) {
+	for enc, c := range codecs[codecBlockType(typ)] {
+		if c == nil {
+			continue
+		}
+
+		b, err := c.Encode(values)
+		if err != nil {
+			return nil, fmt.Errorf("codec %s: %s", c.Name(), err)
+		} else if b == nil || 1+len(b) >= len(vb) {
+			continue
+		}
+
+		vb = append([]byte{byte(enc) << 4}, b...)
+	}
+	return vb, nil
+}
+
+// codecValues returns the raw values of a slice of Values of the same type
+// in the form accepted by Codec.Encode.
+func codecValues(values []Value) interface{} {
+	switch values[0].(type) {
+	case FloatValue:
+		a := make([]float64, len(values))
+		for i, v := range values {
+			a[i] = v.(FloatValue).value
+		}
+		return a
+	case IntegerValue:
+		a := make([]int64, len(values))
+		for i, v := range values {
+			a[i] = v.(IntegerValue).value
+		}
+		return a
+	case UnsignedValue:
+		a := make([]int64, len(values))
+		for i, v := range values {
+			a[i] = int64(v.(UnsignedValue).value)
+		}
+		return a
+	case BooleanValue:
+		a := make([]bool, len(values))
+		for i, v := range values {
+			a[i] = v.(BooleanValue).value
+		}
+		return a
+	case StringValue:
+		a := make([]string, len(values))
+		for i, v := range values {
+			a[i] = v.(StringValue).value
+		}
+		return a
+	}
+	return nil
+}
+
+// decodeCodecBlock decodes the timestamps in tb and the values in vb, which were
+// encoded by c, into a.  a must be a pointer to a slice of the value type of the block.
+// It returns the number of values decoded.
+func decodeCodecBlock(c Codec, tb, vb []byte, a interface{}) (int, error) {
+	tdec := timeDecoderPool.Get(0).(*TimeDecoder)
+	var ts []int64
+	tdec.Init(tb)
+	for tdec.Next() {
+		ts = append(ts, tdec.Read())
+	}
+	err := tdec.Error()
+	timeDecoderPool.Put(tdec)
+	if err != nil {
+		return 0, err
+	}
+
+	values, err := c.Decode(vb[1:])
+	if err != nil {
+		return 0, fmt.Errorf("codec %s: %s", c.Name(), err)
+	}
+
+	var n int
+	switch a := a.(type) {
+	case *[]FloatValue:
+		vs, _ := values.([]float64)
+		n = len(vs)
+		if n == len(ts) {
+			*a = (*a)[:0]
+			for i, v := range vs {
+				*a = append(*a, FloatValue{ts[i], v})
+			}
+		}
+	case *[]IntegerValue:
+		vs, _ := values.([]int64)
+		n = len(vs)
+		if n == len(ts) {
+			*a = (*a)[:0]
+			for i, v := range vs {
+				*a = append(*a, IntegerValue{ts[i], v})
+			}
+		}
+	case *[]UnsignedValue:
+		vs, _ := values.([]int64)
+		n = len(vs)
+		if n == len(ts) {
+			*a = (*a)[:0]
+			for i, v := range vs {
+				*a = append(*a, UnsignedValue{ts[i], uint64(v)})
+			}
+		}
+	case *[]BooleanValue:
+		vs, _ := values.([]bool)
+		n = len(vs)
+		if n == len(ts) {
+			*a = (*a)[:0]
+			for i, v := range vs {
+				*a = append(*a, BooleanValue{ts[i], v})
+			}
+		}
+	case *[]StringValue:
+		vs, _ := values.([]string)
+		n = len(vs)
+		if n == len(ts) {
+			*a = (*a)[:0]
+			for i, v := range vs {
+				*a = append(*a, StringValue{ts[i], v})
+			}
+		}
+	default:
+		return 0, fmt.Errorf("unsupported value slice %T", a)
+	}
+
+	if n != len(ts) {
+		return 0, fmt.Errorf("codec %s: decoded %d values for %d timestamps", c.Name(), n, len(ts))
+	}
+	return n, nil
+}
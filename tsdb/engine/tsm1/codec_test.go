@@ -0,0 +1,166 @@
+package tsm1_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
+)
+
+// constantBooleanEncoding is the encoding identifier used by constantBooleanCodec.
+const constantBooleanEncoding = 15
+
+func init() {
+	tsm1.RegisterCodec(tsm1.BlockBoolean, constantBooleanEncoding, constantBooleanCodec{})
+}
+
+// constantBooleanCodec encodes blocks where every value is the same as a count
+// followed by the value.
+type constantBooleanCodec struct{}
+
+func (constantBooleanCodec) Name() string { return "const" }
+
+func (constantBooleanCodec) Encode(values interface{}) ([]byte, error) {
+	a := values.([]bool)
+	for _, v := range a {
+		if v != a[0] {
+			return nil, nil
+		}
+	}
+
+	b := make([]byte, binary.MaxVarintLen64+1)
+	n := binary.PutUvarint(b, uint64(len(a)))
+	if a[0] {
+		b[n] = 1
+	}
+	return b[:n+1], nil
+}
+
+func (constantBooleanCodec) Decode(b []byte) (interface{}, error) {
+	count, n := binary.Uvarint(b)
+	if n <= 0 || n >= len(b) {
+		return nil, fmt.Errorf("invalid count")
+	}
+
+	a := make([]bool, count)
+	for i := range a {
+		a[i] = b[n] == 1
+	}
+	return a, nil
+}
+
+func TestCodec_Encode_Smallest(t *testing.T) {
+	times := getTimes(1000, 60, time.Second)
+	values := make([]tsm1.Value, len(times))
+	for i, t := range times {
+		values[i] = tsm1.NewValue(t, true)
+	}
+
+	b, err := tsm1.Values(values).Encode(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, exp := blockValueEncoding(t, b), byte(constantBooleanEncoding); got != exp {
+		t.Fatalf("encoding mismatch: got %v, exp %v", got, exp)
+	}
+
+	var decoded []tsm1.Value
+	decoded, err = tsm1.DecodeBlock(b, decoded)
+	if err != nil {
+		t.Fatalf("unexpected error decoding block: %v", err)
+	}
+
+	if !reflect.DeepEqual(decoded, values) {
+		t.Fatalf("unexpected results:\n\tgot: %v\n\texp: %v\n", decoded, values)
+	}
+
+	// The typed encoder used by the compactor should make the same choice.
+	a := make(tsm1.BooleanValues, len(values))
+	for i, v := range values {
+		a[i] = v.(tsm1.BooleanValue)
+	}
+
+	tb, err := a.Encode(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(tb, b) {
+		t.Fatalf("typed encoding mismatch:\n\tgot: %v\n\texp: %v\n", tb, b)
+	}
+}
+
+func TestCodec_Encode_Builtin(t *testing.T) {
+	times := getTimes(1000, 60, time.Second)
+	values := make([]tsm1.Value, len(times))
+	for i, t := range times {
+		values[i] = tsm1.NewValue(t, i%2 == 0)
+	}
+
+	b, err := tsm1.Values(values).Encode(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The codec can't encode mixed values so the bit packed encoding is used.
+	if got, exp := blockValueEncoding(t, b), byte(1); got != exp {
+		t.Fatalf("encoding mismatch: got %v, exp %v", got, exp)
+	}
+
+	var decoded []tsm1.Value
+	decoded, err = tsm1.DecodeBlock(b, decoded)
+	if err != nil {
+		t.Fatalf("unexpected error decoding block: %v", err)
+	}
+
+	if !reflect.DeepEqual(decoded, values) {
+		t.Fatalf("unexpected results:\n\tgot: %v\n\texp: %v\n", decoded, values)
+	}
+}
+
+func TestRegisterCodec_Duplicate(t *testing.T) {
+	for _, enc := range []byte{1, constantBooleanEncoding, 16} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected panic registering encoding %d", enc)
+				}
+			}()
+			tsm1.RegisterCodec(tsm1.BlockBoolean, enc, constantBooleanCodec{})
+		}()
+	}
+}
+
+func TestCodecName(t *testing.T) {
+	for _, tt := range []struct {
+		typ byte
+		enc byte
+		exp string
+	}{
+		{tsm1.BlockFloat64, 1, "gor"},
+		{tsm1.BlockInteger, 2, "rle"},
+		{tsm1.BlockUnsigned, 1, "s8b"},
+		{tsm1.BlockString, 1, "snpy"},
+		{tsm1.BlockBoolean, 1, "bp"},
+		{tsm1.BlockBoolean, constantBooleanEncoding, "const"},
+		{tsm1.BlockFloat64, 7, "7"},
+		{tsm1.BlockFloat64, 16, "unknown"},
+	} {
+		if got := tsm1.CodecName(tt.typ, tt.enc); got != tt.exp {
+			t.Errorf("CodecName(%d, %d): got %s, exp %s", tt.typ, tt.enc, got, tt.exp)
+		}
+	}
+}
+
+// blockValueEncoding returns the encoding identifier of the values in an encoded block.
+func blockValueEncoding(t *testing.T, b []byte) byte {
+	tsLen, n := binary.Uvarint(b[1:])
+	if n <= 0 {
+		t.Fatalf("unable to read timestamp length")
+	}
+	return b[1+n+int(tsLen)] >> 4
+}
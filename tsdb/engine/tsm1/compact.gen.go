@@ -97,7 +97,15 @@ func (k *tsmKeyIterator) combineFloat(dedup bool) blocks {
 					v = FloatValues(v).Exclude(ts.Min, ts.Max)
 				}
 
-				k.mergedFloatValues = k.mergedFloatValues.Merge(v)
+				// Values of older files are merged first, so the merged values are kept for
+				// duplicate timestamps when the key keeps its first values.
+				n := len(k.mergedFloatValues) + len(v)
+				if k.first {
+					k.mergedFloatValues = FloatValues(v).Merge(k.mergedFloatValues)
+				} else {
+					k.mergedFloatValues = k.mergedFloatValues.Merge(v)
+				}
+				k.duplicates += int64(n - len(k.mergedFloatValues))
 
 				// Allow other goroutines to run
 				runtime.Gosched()
@@ -311,7 +319,15 @@ func (k *tsmKeyIterator) combineInteger(dedup bool) blocks {
 					v = IntegerValues(v).Exclude(ts.Min, ts.Max)
 				}
 
-				k.mergedIntegerValues = k.mergedIntegerValues.Merge(v)
+				// Values of older files are merged first, so the merged values are kept for
+				// duplicate timestamps when the key keeps its first values.
+				n := len(k.mergedIntegerValues) + len(v)
+				if k.first {
+					k.mergedIntegerValues = IntegerValues(v).Merge(k.mergedIntegerValues)
+				} else {
+					k.mergedIntegerValues = k.mergedIntegerValues.Merge(v)
+				}
+				k.duplicates += int64(n - len(k.mergedIntegerValues))
 
 				// Allow other goroutines to run
 				runtime.Gosched()
@@ -438,6 +454,228 @@ func (k *tsmKeyIterator) chunkInteger(dst blocks) blocks {
 	return dst
 }
 
+// merge combines the next set of blocks into merged blocks.
+func (k *tsmKeyIterator) mergeUnsigned() {
+	// No blocks left, or pending merged values, we're done
+	if len(k.blocks) == 0 && len(k.merged) == 0 && len(k.mergedUnsignedValues) == 0 {
+		return
+	}
+
+	dedup := len(k.mergedUnsignedValues) != 0
+	if len(k.blocks) > 0 && !dedup {
+		// If we have more than one block or any partially tombstoned blocks, we many need to dedup
+		dedup = len(k.blocks[0].tombstones) > 0 || k.blocks[0].partiallyRead()
+
+		// Quickly scan each block to see if any overlap with the prior block, if they overlap then
+		// we need to dedup as there may be duplicate points now
+		for i := 1; !dedup && i < len(k.blocks); i++ {
+			if k.blocks[i].partiallyRead() {
+				dedup = true
+				break
+			}
+
+			if k.blocks[i].minTime <= k.blocks[i-1].maxTime || len(k.blocks[i].tombstones) > 0 {
+				dedup = true
+				break
+			}
+		}
+
+	}
+
+	k.merged = k.combineUnsigned(dedup)
+}
+
+// combine returns a new set of blocks using the current blocks in the buffers.  If dedup
+// is true, all the blocks will be decoded, dedup and sorted in in order.  If dedup is false,
+// only blocks that are smaller than the chunk size will be decoded and combined.
+func (k *tsmKeyIterator) combineUnsigned(dedup bool) blocks {
+	if dedup {
+		for len(k.mergedUnsignedValues) < k.size && len(k.blocks) > 0 {
+			for len(k.blocks) > 0 && k.blocks[0].read() {
+				k.blocks = k.blocks[1:]
+			}
+
+			if len(k.blocks) == 0 {
+				break
+			}
+			first := k.blocks[0]
+			minTime := first.minTime
+			maxTime := first.maxTime
+
+			// Adjust the min time to the start of any overlapping blocks.
+			for i := 0; i < len(k.blocks); i++ {
+				if k.blocks[i].overlapsTimeRange(minTime, maxTime) && !k.blocks[i].read() {
+					if k.blocks[i].minTime < minTime {
+						minTime = k.blocks[i].minTime
+					}
+					if k.blocks[i].maxTime > minTime && k.blocks[i].maxTime < maxTime {
+						maxTime = k.blocks[i].maxTime
+					}
+				}
+			}
+
+			// We have some overlapping blocks so decode all, append in order and then dedup
+			for i := 0; i < len(k.blocks); i++ {
+				if !k.blocks[i].overlapsTimeRange(minTime, maxTime) || k.blocks[i].read() {
+					continue
+				}
+
+				v, err := DecodeUnsignedBlock(k.blocks[i].b, &[]UnsignedValue{})
+				if err != nil {
+					k.err = err
+					return nil
+				}
+
+				// Remove values we already read
+				v = UnsignedValues(v).Exclude(k.blocks[i].readMin, k.blocks[i].readMax)
+
+				// Filter out only the values for overlapping block
+				v = UnsignedValues(v).Include(minTime, maxTime)
+				if len(v) > 0 {
+					// Record that we read a subset of the block
+					k.blocks[i].markRead(v[0].UnixNano(), v[len(v)-1].UnixNano())
+				}
+
+				// Apply each tombstone to the block
+				for _, ts := range k.blocks[i].tombstones {
+					v = UnsignedValues(v).Exclude(ts.Min, ts.Max)
+				}
+
+				// Values of older files are merged first, so the merged values are kept for
+				// duplicate timestamps when the key keeps its first values.
+				n := len(k.mergedUnsignedValues) + len(v)
+				if k.first {
+					k.mergedUnsignedValues = UnsignedValues(v).Merge(k.mergedUnsignedValues)
+				} else {
+					k.mergedUnsignedValues = k.mergedUnsignedValues.Merge(v)
+				}
+				k.duplicates += int64(n - len(k.mergedUnsignedValues))
+
+				// Allow other goroutines to run
+				runtime.Gosched()
+
+			}
+		}
+
+		// Since we combined multiple blocks, we could have more values than we should put into
+		// a single block.  We need to chunk them up into groups and re-encode them.
+		return k.chunkUnsigned(nil)
+	} else {
+		var chunked blocks
+		var i int
+
+		for i < len(k.blocks) {
+
+			// skip this block if it's values were already read
+			if k.blocks[i].read() {
+				i++
+				continue
+			}
+			// If we this block is already full, just add it as is
+			if BlockCount(k.blocks[i].b) >= k.size {
+				chunked = append(chunked, k.blocks[i])
+			} else {
+				break
+			}
+			i++
+			// Allow other goroutines to run
+			runtime.Gosched()
+		}
+
+		if k.fast {
+			for i < len(k.blocks) {
+				// skip this block if it's values were already read
+				if k.blocks[i].read() {
+					i++
+					continue
+				}
+
+				chunked = append(chunked, k.blocks[i])
+				i++
+				// Allow other goroutines to run
+				runtime.Gosched()
+			}
+		}
+
+		// If we only have 1 blocks left, just append it as is and avoid decoding/recoding
+		if i == len(k.blocks)-1 {
+			if !k.blocks[i].read() {
+				chunked = append(chunked, k.blocks[i])
+			}
+			i++
+		}
+
+		// The remaining blocks can be combined and we know that they do not overlap and
+		// so we can just append each, sort and re-encode.
+		for i < len(k.blocks) && len(k.mergedUnsignedValues) < k.size {
+			if k.blocks[i].read() {
+				i++
+				continue
+			}
+
+			v, err := DecodeUnsignedBlock(k.blocks[i].b, &[]UnsignedValue{})
+			if err != nil {
+				k.err = err
+				return nil
+			}
+
+			// Apply each tombstone to the block
+			for _, ts := range k.blocks[i].tombstones {
+				v = UnsignedValues(v).Exclude(ts.Min, ts.Max)
+			}
+
+			k.blocks[i].markRead(k.blocks[i].minTime, k.blocks[i].maxTime)
+
+			k.mergedUnsignedValues = k.mergedUnsignedValues.Merge(v)
+			i++
+			// Allow other goroutines to run
+			runtime.Gosched()
+		}
+
+		k.blocks = k.blocks[i:]
+
+		return k.chunkUnsigned(chunked)
+	}
+}
+
+func (k *tsmKeyIterator) chunkUnsigned(dst blocks) blocks {
+	if len(k.mergedUnsignedValues) > k.size {
+		values := k.mergedUnsignedValues[:k.size]
+		cb, err := UnsignedValues(values).Encode(nil)
+		if err != nil {
+			k.err = err
+			return nil
+		}
+
+		dst = append(dst, &block{
+			minTime: values[0].UnixNano(),
+			maxTime: values[len(values)-1].UnixNano(),
+			key:     k.key,
+			b:       cb,
+		})
+		k.mergedUnsignedValues = k.mergedUnsignedValues[k.size:]
+		return dst
+	}
+
+	// Re-encode the remaining values into the last block
+	if len(k.mergedUnsignedValues) > 0 {
+		cb, err := UnsignedValues(k.mergedUnsignedValues).Encode(nil)
+		if err != nil {
+			k.err = err
+			return nil
+		}
+
+		dst = append(dst, &block{
+			minTime: k.mergedUnsignedValues[0].UnixNano(),
+			maxTime: k.mergedUnsignedValues[len(k.mergedUnsignedValues)-1].UnixNano(),
+			key:     k.key,
+			b:       cb,
+		})
+		k.mergedUnsignedValues = k.mergedUnsignedValues[:0]
+	}
+	return dst
+}
+
 // merge combines the next set of blocks into merged blocks.
 func (k *tsmKeyIterator) mergeString() {
 	// No blocks left, or pending merged values, we're done
@@ -525,7 +763,15 @@ func (k *tsmKeyIterator) combineString(dedup bool) blocks {
 					v = StringValues(v).Exclude(ts.Min, ts.Max)
 				}
 
-				k.mergedStringValues = k.mergedStringValues.Merge(v)
+				// Values of older files are merged first, so the merged values are kept for
+				// duplicate timestamps when the key keeps its first values.
+				n := len(k.mergedStringValues) + len(v)
+				if k.first {
+					k.mergedStringValues = StringValues(v).Merge(k.mergedStringValues)
+				} else {
+					k.mergedStringValues = k.mergedStringValues.Merge(v)
+				}
+				k.duplicates += int64(n - len(k.mergedStringValues))
 
 				// Allow other goroutines to run
 				runtime.Gosched()
@@ -739,7 +985,15 @@ func (k *tsmKeyIterator) combineBoolean(dedup bool) blocks {
 					v = BooleanValues(v).Exclude(ts.Min, ts.Max)
 				}
 
-				k.mergedBooleanValues = k.mergedBooleanValues.Merge(v)
+				// Values of older files are merged first, so the merged values are kept for
+				// duplicate timestamps when the key keeps its first values.
+				n := len(k.mergedBooleanValues) + len(v)
+				if k.first {
+					k.mergedBooleanValues = BooleanValues(v).Merge(k.mergedBooleanValues)
+				} else {
+					k.mergedBooleanValues = k.mergedBooleanValues.Merge(v)
+				}
+				k.duplicates += int64(n - len(k.mergedBooleanValues))
 
 				// Allow other goroutines to run
 				runtime.Gosched()
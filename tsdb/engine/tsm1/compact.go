@@ -13,7 +13,9 @@ package tsm1
 // one-pass writing of a new TSM file.
 
 import (
+	"bufio"
 	"fmt"
+	"hash/crc32"
 	"math"
 	"os"
 	"path/filepath"
@@ -34,6 +36,10 @@ const (
 
 	// TSMFileExtension is the extension used for TSM files.
 	TSMFileExtension = "tsm"
+
+	// QuarantineFileExtension is the extension appended to the name of a TSM file for
+	// the file listing the blocks dropped when it was repaired.
+	QuarantineFileExtension = "quarantine"
 )
 
 var (
@@ -573,6 +579,13 @@ type Compactor struct {
 	Dir  string
 	Size int
 
+	// BlockStats is set when the statistics of numeric blocks are written to new files.
+	BlockStats bool
+
+	// KeepFirst returns true if the oldest of the values of a key with the same timestamp
+	// is kept when files are compacted, rather than the newest.  It may be nil.
+	KeepFirst func(key string) bool
+
 	FileStore interface {
 		NextGeneration() int
 	}
@@ -582,6 +595,16 @@ type Compactor struct {
 	compactionsEnabled bool
 
 	files map[string]struct{}
+
+	// duplicates is the number of values dropped by compactions because another value
+	// of the same key had the same timestamp.
+	duplicates int64
+}
+
+// Duplicates returns the number of values dropped by compactions because another value
+// of the same key had the same timestamp.
+func (c *Compactor) Duplicates() int64 {
+	return atomic.LoadInt64(&c.duplicates)
 }
 
 // Open initializes the Compactor.
@@ -647,7 +670,7 @@ func (c *Compactor) WriteSnapshot(cache *Cache) ([]string, error) {
 	}
 
 	iter := NewCacheKeyIterator(cache, tsdb.DefaultMaxPointsPerBlock)
-	files, err := c.writeNewFiles(c.FileStore.NextGeneration(), 0, iter)
+	files, err := c.writeNewFiles(c.Dir, c.FileStore.NextGeneration(), 0, iter)
 
 	// See if we were disabled while writing a snapshot
 	c.mu.RLock()
@@ -663,6 +686,21 @@ func (c *Compactor) WriteSnapshot(cache *Cache) ([]string, error) {
 
 // compact writes multiple smaller TSM files into 1 or more larger files.
 func (c *Compactor) compact(fast bool, tsmFiles []string) ([]string, error) {
+	var iter *tsmKeyIterator
+	files, err := c.compactIter(tsmFiles, func(size int, trs []*TSMReader) (KeyIterator, error) {
+		iter = newTSMKeyIterator(size, fast, trs...)
+		iter.keepFirst = c.KeepFirst
+		return iter, nil
+	})
+	if iter != nil {
+		atomic.AddInt64(&c.duplicates, iter.duplicates)
+	}
+	return files, err
+}
+
+// compactIter writes the blocks returned by the iterator created by newIter from
+// readers of tsmFiles into new files of the highest generation of tsmFiles.
+func (c *Compactor) compactIter(tsmFiles []string, newIter func(size int, trs []*TSMReader) (KeyIterator, error)) ([]string, error) {
 	size := c.Size
 	if size <= 0 {
 		size = tsdb.DefaultMaxPointsPerBlock
@@ -707,12 +745,12 @@ func (c *Compactor) compact(fast bool, tsmFiles []string) ([]string, error) {
 		return nil, nil
 	}
 
-	tsm, err := NewTSMKeyIterator(size, fast, trs...)
+	tsm, err := newIter(size, trs)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.writeNewFiles(maxGeneration, maxSequence, tsm)
+	return c.writeNewFiles(c.Dir, maxGeneration, maxSequence, tsm)
 }
 
 // CompactFull writes multiple smaller TSM files into 1 or more larger files.
@@ -774,16 +812,214 @@ func (c *Compactor) CompactFast(tsmFiles []string) ([]string, error) {
 
 }
 
-// writeNewFiles writes from the iterator into new TSM files, rotating
+// Downsample rewrites tsmFiles into new files where the values of each numeric field
+// are aggregated into windows of interval using the functions in fns.
+func (c *Compactor) Downsample(tsmFiles []string, interval time.Duration, fns []string) ([]string, error) {
+	c.mu.RLock()
+	enabled := c.compactionsEnabled
+	c.mu.RUnlock()
+
+	if !enabled {
+		return nil, errCompactionsDisabled
+	}
+
+	if !c.add(tsmFiles) {
+		return nil, errCompactionInProgress
+	}
+	defer c.remove(tsmFiles)
+
+	files, err := c.compactIter(tsmFiles, func(size int, trs []*TSMReader) (KeyIterator, error) {
+		iter, err := NewTSMKeyIterator(size, false, trs...)
+		if err != nil {
+			return nil, err
+		}
+		return NewDownsampleKeyIterator(iter, size, interval, fns)
+	})
+
+	// See if we were disabled while downsampling
+	c.mu.RLock()
+	enabled = c.compactionsEnabled
+	c.mu.RUnlock()
+
+	if !enabled {
+		return nil, errCompactionsDisabled
+	}
+
+	return files, err
+}
+
+// CompactRange rewrites tsmFiles into new files holding only the values between min
+// and max, inclusive.  Unlike other compactions, it runs while compactions are disabled
+// so the caller must ensure no other compaction uses tsmFiles.
+func (c *Compactor) CompactRange(tsmFiles []string, min, max int64) ([]string, error) {
+	if !c.add(tsmFiles) {
+		return nil, errCompactionInProgress
+	}
+	defer c.remove(tsmFiles)
+
+	return c.compactIter(tsmFiles, func(size int, trs []*TSMReader) (KeyIterator, error) {
+		iter, err := NewTSMKeyIterator(size, false, trs...)
+		if err != nil {
+			return nil, err
+		}
+		return &timeRangeKeyIterator{iter: iter, min: min, max: max}, nil
+	})
+}
+
+// QuarantinedBlock describes a block dropped from a TSM file by Compactor.Repair.
+type QuarantinedBlock struct {
+	Key     string
+	MinTime int64
+	MaxTime int64
+	Reason  string
+}
+
+// Repair rewrites the TSM file at path, skipping any blocks that fail verification.
+// The repaired file is written alongside path with the same generation and a higher
+// sequence and should be swapped in for path using FileStore.Replace.  The key and
+// time range of every skipped block is appended to the quarantine file for path.
+//
+// Repair returns no files if every block is valid.  If no block is valid, it returns
+// no files along with the quarantined blocks and path should be removed.
+func (c *Compactor) Repair(path string) (files []string, quarantined []QuarantinedBlock, err error) {
+	c.mu.RLock()
+	enabled := c.compactionsEnabled
+	c.mu.RUnlock()
+
+	if !enabled {
+		return nil, nil, errCompactionsDisabled
+	}
+
+	if !c.add([]string{path}) {
+		return nil, nil, errCompactionInProgress
+	}
+	defer c.remove([]string{path})
+
+	generation, _, err := ParseTSMFileName(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The repaired file must sort after path and any other files of the same generation.
+	dir := filepath.Dir(path)
+	sequence, err := maxSequence(dir, generation)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tr, err := NewTSMReader(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tr.Close()
+
+	// A corrupt index can hold offsets outside of the memory map.
+	defer func() {
+		if r := recover(); r != nil {
+			for _, f := range files {
+				os.RemoveAll(f)
+			}
+			files, quarantined, err = nil, nil, fmt.Errorf("%s: corrupt index: %v", path, r)
+		}
+	}()
+
+	iter := newRepairKeyIterator(tr)
+	files, err = c.writeNewFiles(dir, generation, sequence, iter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Nothing was dropped so the original file is fine as it is.
+	if len(iter.quarantined) == 0 {
+		for _, f := range files {
+			if err := os.RemoveAll(f); err != nil {
+				return nil, nil, err
+			}
+		}
+		return nil, nil, nil
+	}
+
+	if err := writeQuarantine(path, iter.quarantined); err != nil {
+		for _, f := range files {
+			os.RemoveAll(f)
+		}
+		return nil, nil, err
+	}
+
+	// See if we were disabled while repairing
+	c.mu.RLock()
+	enabled = c.compactionsEnabled
+	c.mu.RUnlock()
+
+	if !enabled {
+		return nil, nil, errCompactionsDisabled
+	}
+
+	return files, iter.quarantined, nil
+}
+
+// maxSequence returns the highest sequence of the TSM files, including temporary
+// files, of generation in dir.
+func maxSequence(dir string, generation int) (int, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, fmt.Sprintf("%09d-*.%s*", generation, TSMFileExtension)))
+	if err != nil {
+		return 0, err
+	}
+
+	var max int
+	for _, path := range paths {
+		_, seq, err := ParseTSMFileName(path)
+		if err != nil {
+			continue
+		}
+
+		if seq > max {
+			max = seq
+		}
+	}
+	return max, nil
+}
+
+// writeQuarantine appends a line with the key, time range and reason of each block
+// to the quarantine file for the TSM file at path.
+func writeQuarantine(path string, blocks []QuarantinedBlock) error {
+	f, err := os.OpenFile(fmt.Sprintf("%s.%s", path, QuarantineFileExtension), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	for _, b := range blocks {
+		fmt.Fprintf(w, "%q\t%d\t%d\t%s\n", b.Key, b.MinTime, b.MaxTime, b.Reason)
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeNewFiles writes from the iterator into new TSM files in dir, rotating
 // to a new file once it has reached the max TSM file size.
-func (c *Compactor) writeNewFiles(generation, sequence int, iter KeyIterator) ([]string, error) {
+func (c *Compactor) writeNewFiles(dir string, generation, sequence int, iter KeyIterator) ([]string, error) {
 	// These are the new TSM files written
 	var files []string
 
 	for {
 		sequence++
 		// New TSM files are written to a temp file and renamed when fully completed.
-		fileName := filepath.Join(c.Dir, fmt.Sprintf("%09d-%09d.%s.tmp", generation, sequence, TSMFileExtension))
+		fileName := filepath.Join(dir, fmt.Sprintf("%09d-%09d.%s.tmp", generation, sequence, TSMFileExtension))
 
 		// Write as much as possible to this file
 		err := c.write(fileName, iter)
@@ -821,7 +1057,11 @@ func (c *Compactor) write(path string, iter KeyIterator) (err error) {
 	}
 
 	// Create the write for the new TSM file.
-	w, err := NewTSMWriter(fd)
+	newWriter := NewTSMWriter
+	if c.BlockStats {
+		newWriter = NewTSMWriterWithBlockStats
+	}
+	w, err := newWriter(fd)
 	if err != nil {
 		return err
 	}
@@ -954,14 +1194,24 @@ type tsmKeyIterator struct {
 	buf []blocks
 
 	// mergeValues are decoded blocks that have been combined
-	mergedFloatValues   FloatValues
-	mergedIntegerValues IntegerValues
-	mergedBooleanValues BooleanValues
-	mergedStringValues  StringValues
+	mergedFloatValues    FloatValues
+	mergedIntegerValues  IntegerValues
+	mergedUnsignedValues UnsignedValues
+	mergedBooleanValues  BooleanValues
+	mergedStringValues   StringValues
 
 	// merged are encoded blocks that have been combined or used as is
 	// without decode
 	merged blocks
+
+	// keepFirst returns true if the oldest of the values of a key with the same
+	// timestamp is kept.  first is set when it is true for the current key.
+	keepFirst func(key string) bool
+	first     bool
+
+	// duplicates is the number of values dropped because another value of the key had
+	// the same timestamp.
+	duplicates int64
 }
 
 type block struct {
@@ -1014,6 +1264,10 @@ func (a blocks) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 // NewTSMKeyIterator returns a new TSM key iterator from readers.
 // size indicates the maximum number of values to encode in a single block.
 func NewTSMKeyIterator(size int, fast bool, readers ...*TSMReader) (KeyIterator, error) {
+	return newTSMKeyIterator(size, fast, readers...), nil
+}
+
+func newTSMKeyIterator(size int, fast bool, readers ...*TSMReader) *tsmKeyIterator {
 	var iter []*BlockIterator
 	for _, r := range readers {
 		iter = append(iter, r.BlockIterator())
@@ -1027,12 +1281,13 @@ func NewTSMKeyIterator(size int, fast bool, readers ...*TSMReader) (KeyIterator,
 		iterators: iter,
 		fast:      fast,
 		buf:       make([]blocks, len(iter)),
-	}, nil
+	}
 }
 
 func (k *tsmKeyIterator) hasMergedValues() bool {
 	return len(k.mergedFloatValues) > 0 ||
 		len(k.mergedIntegerValues) > 0 ||
+		len(k.mergedUnsignedValues) > 0 ||
 		len(k.mergedStringValues) > 0 ||
 		len(k.mergedBooleanValues) > 0
 }
@@ -1128,6 +1383,7 @@ func (k *tsmKeyIterator) Next() bool {
 	}
 	k.key = minKey
 	k.typ = minType
+	k.first = k.keepFirst != nil && k.keepFirst(k.key)
 
 	// Now we need to find all blocks that match the min key so we can combine and dedupe
 	// the blocks if necessary
@@ -1157,6 +1413,8 @@ func (k *tsmKeyIterator) merge() {
 		k.mergeFloat()
 	case BlockInteger:
 		k.mergeInteger()
+	case BlockUnsigned:
+		k.mergeUnsigned()
 	case BlockBoolean:
 		k.mergeBoolean()
 	case BlockString:
@@ -1187,6 +1445,63 @@ func (k *tsmKeyIterator) Close() error {
 	return nil
 }
 
+// timeRangeKeyIterator is a KeyIterator that only returns the values of another
+// KeyIterator between min and max, inclusive.
+type timeRangeKeyIterator struct {
+	iter     KeyIterator
+	min, max int64
+
+	key        string
+	minT, maxT int64
+	block      []byte
+	buf        []Value
+	err        error
+}
+
+func (k *timeRangeKeyIterator) Next() bool {
+	for k.iter.Next() {
+		key, minT, maxT, block, err := k.iter.Read()
+		if err != nil {
+			k.err = err
+			return true
+		}
+
+		if maxT < k.min || minT > k.max {
+			continue
+		} else if minT >= k.min && maxT <= k.max {
+			k.key, k.minT, k.maxT, k.block = key, minT, maxT, block
+			return true
+		}
+
+		// The block is partially in the range so drop the values outside of it.
+		values, err := DecodeBlock(block, k.buf[:0])
+		if err != nil {
+			k.err = err
+			return true
+		}
+		k.buf = values
+
+		values = Values(values).Include(k.min, k.max)
+		if len(values) == 0 {
+			continue
+		}
+
+		k.key = key
+		k.minT, k.maxT = values[0].UnixNano(), values[len(values)-1].UnixNano()
+		k.block, k.err = Values(values).Encode(nil)
+		return true
+	}
+	return false
+}
+
+func (k *timeRangeKeyIterator) Read() (string, int64, int64, []byte, error) {
+	return k.key, k.minT, k.maxT, k.block, k.err
+}
+
+func (k *timeRangeKeyIterator) Close() error {
+	return k.iter.Close()
+}
+
 type cacheKeyIterator struct {
 	cache *Cache
 	size  int
@@ -1306,6 +1621,155 @@ func (c *cacheKeyIterator) Close() error {
 	return nil
 }
 
+// repairKeyIterator is a KeyIterator over the blocks of a single TSM file that pass
+// verification.  Blocks that fail are skipped and recorded as quarantined.  Any
+// tombstoned values are removed from the blocks returned.
+type repairKeyIterator struct {
+	r                *TSMReader
+	size             int64
+	minTime, maxTime int64
+
+	// i is the position of the next key in the index, n is the number of keys.
+	i, n int
+
+	// key, typ and entries describe the current key and j is the position of the
+	// next block of the key.
+	key        string
+	typ        byte
+	entries    []IndexEntry
+	tombstones []TimeRange
+	j          int
+
+	// lastTime is the min time of the last block returned for key.
+	lastTime int64
+
+	// The current block.
+	minT, maxT int64
+	block      []byte
+	values     []Value
+
+	quarantined []QuarantinedBlock
+}
+
+func newRepairKeyIterator(r *TSMReader) *repairKeyIterator {
+	minTime, maxTime := r.index.TimeRange()
+	return &repairKeyIterator{
+		r:       r,
+		size:    int64(r.Size()),
+		minTime: minTime,
+		maxTime: maxTime,
+		n:       r.index.KeyCount(),
+	}
+}
+
+func (k *repairKeyIterator) Next() bool {
+	for {
+		if k.j >= len(k.entries) {
+			if k.i >= k.n {
+				return false
+			}
+
+			key, typ, entries := k.r.index.Key(k.i)
+			k.i++
+
+			// The blocks of keys that are out of order can't be written to the repaired file.
+			if k.key != "" && key <= k.key {
+				for _, e := range entries {
+					k.quarantine(key, &e, fmt.Sprintf("key out of order after %q", k.key))
+				}
+				continue
+			}
+
+			k.key, k.typ, k.entries, k.j = key, typ, entries, 0
+			k.tombstones = k.r.TombstoneRange(key)
+			k.lastTime = math.MinInt64
+			continue
+		}
+
+		e := &k.entries[k.j]
+		k.j++
+
+		if err := k.read(e); err != nil {
+			k.quarantine(k.key, e, err.Error())
+			continue
+		}
+
+		k.lastTime = e.MinTime
+
+		// Skip blocks where every value has been deleted.
+		if len(k.block) == 0 {
+			continue
+		}
+		return true
+	}
+}
+
+// read verifies the block for e and sets the current block to its contents,
+// less any tombstoned values.
+func (k *repairKeyIterator) read(e *IndexEntry) error {
+	if e.MinTime > e.MaxTime || e.MinTime < k.minTime || e.MaxTime > k.maxTime {
+		return fmt.Errorf("invalid time range")
+	} else if e.MinTime < k.lastTime {
+		return fmt.Errorf("block out of order")
+	} else if e.Offset < 5 || e.Size < 5 || e.Offset+int64(e.Size) > k.size {
+		return fmt.Errorf("invalid offset %d and size %d", e.Offset, e.Size)
+	}
+
+	checksum, buf, err := k.r.ReadBytes(e, nil)
+	if err != nil {
+		return err
+	} else if exp := crc32.ChecksumIEEE(buf); checksum != exp {
+		return fmt.Errorf("checksum %d, expected %d", checksum, exp)
+	} else if len(buf) == 0 || buf[0] != k.typ {
+		return fmt.Errorf("block type does not match index")
+	}
+
+	k.values, err = DecodeBlock(buf, k.values[:0])
+	if err != nil {
+		return fmt.Errorf("decode: %v", err)
+	} else if len(k.values) == 0 || k.values[0].UnixNano() != e.MinTime || k.values[len(k.values)-1].UnixNano() != e.MaxTime {
+		return fmt.Errorf("block time range does not match index")
+	}
+
+	k.minT, k.maxT, k.block = e.MinTime, e.MaxTime, buf
+
+	var deleted bool
+	for _, t := range k.tombstones {
+		if t.Min <= e.MaxTime && t.Max >= e.MinTime {
+			k.values = Values(k.values).Exclude(t.Min, t.Max)
+			deleted = true
+		}
+	}
+
+	if !deleted {
+		return nil
+	} else if len(k.values) == 0 {
+		k.block = nil
+		return nil
+	}
+
+	k.minT, k.maxT = k.values[0].UnixNano(), k.values[len(k.values)-1].UnixNano()
+	k.block, err = Values(k.values).Encode(nil)
+	return err
+}
+
+func (k *repairKeyIterator) quarantine(key string, e *IndexEntry, reason string) {
+	k.quarantined = append(k.quarantined, QuarantinedBlock{
+		Key:     key,
+		MinTime: e.MinTime,
+		MaxTime: e.MaxTime,
+		Reason:  reason,
+	})
+}
+
+func (k *repairKeyIterator) Read() (string, int64, int64, []byte, error) {
+	return k.key, k.minT, k.maxT, k.block, nil
+}
+
+func (k *repairKeyIterator) Close() error {
+	return nil
+}
+
 type tsmGenerations []*tsmGeneration
 
 func (a tsmGenerations) Len() int           { return len(a) }
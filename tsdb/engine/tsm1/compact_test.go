@@ -2,9 +2,11 @@ package tsm1_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"math"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -818,6 +820,451 @@ func TestCompactor_CompactFull_MaxKeys(t *testing.T) {
 	}
 }
 
+// Ensures that a repair drops corrupt blocks, applies tombstones and records the
+// dropped blocks in the quarantine file.
+func TestCompactor_Repair(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	a1, a2, a3 := tsm1.NewValue(1, 1.1), tsm1.NewValue(2, 1.2), tsm1.NewValue(3, 1.3)
+	b1 := tsm1.NewValue(1, 2.1)
+	c1 := tsm1.NewValue(1, 3.1)
+	writes := map[string][]tsm1.Value{
+		"cpu,host=A#!~#value": []tsm1.Value{a1, a2, a3},
+		"cpu,host=B#!~#value": []tsm1.Value{b1},
+		"cpu,host=C#!~#value": []tsm1.Value{c1},
+	}
+	f1 := MustWriteTSM(dir, 1, writes)
+
+	r := MustOpenTSMReader(f1)
+	if err := r.DeleteRange([]string{"cpu,host=A#!~#value"}, 2, 2); err != nil {
+		t.Fatalf("unexpected error deleting range: %v", err)
+	}
+	offset := r.Entries("cpu,host=B#!~#value")[0].Offset
+	r.Close()
+
+	// Corrupt the values of the block for B.
+	fw, err := os.OpenFile(f1, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("unexpected error opening file: %v", err)
+	}
+	if _, err := fw.WriteAt([]byte{0xff, 0xff}, offset+10); err != nil {
+		t.Fatalf("unexpected error corrupting block: %v", err)
+	}
+	fw.Close()
+
+	fs := tsm1.NewFileStore(dir)
+	if err := fs.Open(); err != nil {
+		t.Fatalf("unexpected error opening file store: %v", err)
+	}
+	defer fs.Close()
+
+	compactor := &tsm1.Compactor{
+		Dir:       dir,
+		FileStore: fs,
+	}
+
+	if _, _, err := compactor.Repair(f1); err == nil {
+		t.Fatalf("expected error repairing with compactions disabled")
+	}
+
+	compactor.Open()
+
+	files, quarantined, err := compactor.Repair(f1)
+	if err != nil {
+		t.Fatalf("unexpected error repairing file: %v", err)
+	}
+
+	if got, exp := len(files), 1; got != exp {
+		t.Fatalf("files length mismatch: got %v, exp %v", got, exp)
+	}
+
+	if gen, seq, err := tsm1.ParseTSMFileName(files[0]); err != nil {
+		t.Fatalf("unexpected error parsing file name: %v", err)
+	} else if gen != 1 || seq != 2 {
+		t.Fatalf("wrong generation and sequence for new file: got %v-%v, exp 1-2", gen, seq)
+	}
+
+	if got, exp := len(quarantined), 1; got != exp {
+		t.Fatalf("quarantined length mismatch: got %v, exp %v", got, exp)
+	}
+	if got, exp := quarantined[0].Key, "cpu,host=B#!~#value"; got != exp {
+		t.Fatalf("quarantined key mismatch: got %v, exp %v", got, exp)
+	}
+
+	b, err := ioutil.ReadFile(f1 + "." + tsm1.QuarantineFileExtension)
+	if err != nil {
+		t.Fatalf("unexpected error reading quarantine file: %v", err)
+	}
+	if got, exp := string(b), "\"cpu,host=B#!~#value\"\t1\t1\t"; !strings.HasPrefix(got, exp) {
+		t.Fatalf("quarantine file mismatch: got %q, exp prefix %q", got, exp)
+	}
+
+	if err := fs.Replace([]string{f1}, files); err != nil {
+		t.Fatalf("unexpected error replacing files: %v", err)
+	}
+
+	if _, err := os.Stat(f1); !os.IsNotExist(err) {
+		t.Fatalf("expected original file to be removed: %v", err)
+	}
+
+	var data = []struct {
+		key    string
+		points []tsm1.Value
+	}{
+		{"cpu,host=A#!~#value", []tsm1.Value{a1, a3}},
+		{"cpu,host=B#!~#value", nil},
+		{"cpu,host=C#!~#value", []tsm1.Value{c1}},
+	}
+
+	// The file store should read from the repaired file.
+	for _, p := range data {
+		values, err := fs.Read(p.key, 1)
+		if err != nil {
+			t.Fatalf("unexpected error reading: %v", err)
+		}
+
+		if got, exp := len(values) > 0, p.points != nil; got != exp {
+			t.Fatalf("values mismatch %s: got %v", p.key, values)
+		}
+	}
+
+	// The repaired file was renamed when it was swapped in.
+	r = MustOpenTSMReader(strings.TrimSuffix(files[0], "."+tsm1.CompactionTempExtension))
+	defer r.Close()
+	for _, p := range data {
+		values, err := r.ReadAll(p.key)
+		if err != nil {
+			t.Fatalf("unexpected error reading: %v", err)
+		}
+
+		if got, exp := len(values), len(p.points); got != exp {
+			t.Fatalf("values length mismatch %s: got %v, exp %v", p.key, got, exp)
+		}
+
+		for i, point := range p.points {
+			assertValueEqual(t, values[i], point)
+		}
+	}
+}
+
+// Ensures that repairing a healthy file leaves it in place.
+func TestCompactor_Repair_Healthy(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	f1 := MustWriteTSM(dir, 1, map[string][]tsm1.Value{
+		"cpu,host=A#!~#value": []tsm1.Value{tsm1.NewValue(1, 1.1)},
+	})
+
+	compactor := &tsm1.Compactor{
+		Dir:       dir,
+		FileStore: &fakeFileStore{},
+	}
+	compactor.Open()
+
+	files, quarantined, err := compactor.Repair(f1)
+	if err != nil {
+		t.Fatalf("unexpected error repairing file: %v", err)
+	} else if len(files) != 0 || len(quarantined) != 0 {
+		t.Fatalf("expected no files or quarantined blocks: got %v, %v", files, quarantined)
+	}
+
+	tmp, err := filepath.Glob(filepath.Join(dir, "*."+tsm1.CompactionTempExtension))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(tmp) != 0 {
+		t.Fatalf("expected temp files to be removed: got %v", tmp)
+	}
+
+	if _, err := os.Stat(f1 + "." + tsm1.QuarantineFileExtension); !os.IsNotExist(err) {
+		t.Fatalf("expected no quarantine file: %v", err)
+	}
+}
+
+// Ensures that downsampling replaces numeric fields with their aggregates.
+func TestCompactor_Downsample(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	f1 := MustWriteTSM(dir, 1, map[string][]tsm1.Value{
+		"cpu,host=A#!~#value": []tsm1.Value{
+			tsm1.NewValue(1, 1.0),
+			tsm1.NewValue(2, 4.0),
+			tsm1.NewValue(11, 2.0),
+		},
+		"cpu,host=A#!~#status": []tsm1.Value{tsm1.NewValue(1, "ok")},
+	})
+	f2 := MustWriteTSM(dir, 2, map[string][]tsm1.Value{
+		"cpu,host=B#!~#count": []tsm1.Value{
+			tsm1.NewValue(3, int64(3)),
+			tsm1.NewValue(5, int64(5)),
+		},
+	})
+
+	compactor := &tsm1.Compactor{
+		Dir:       dir,
+		FileStore: &fakeFileStore{},
+	}
+	compactor.Open()
+
+	if _, err := compactor.Downsample([]string{f1, f2}, 10, []string{"bad"}); err == nil {
+		t.Fatalf("expected error downsampling with unknown function")
+	}
+
+	files, err := compactor.Downsample([]string{f1, f2}, 10, []string{tsm1.DownsampleMean, tsm1.DownsampleMax, tsm1.DownsampleCount})
+	if err != nil {
+		t.Fatalf("unexpected error downsampling: %v", err)
+	}
+
+	if got, exp := len(files), 1; got != exp {
+		t.Fatalf("files length mismatch: got %v, exp %v", got, exp)
+	}
+
+	r := MustOpenTSMReader(files[0])
+	defer r.Close()
+
+	var data = []struct {
+		key    string
+		points []tsm1.Value
+	}{
+		{"cpu,host=A#!~#count_value", []tsm1.Value{tsm1.NewValue(0, int64(2)), tsm1.NewValue(10, int64(1))}},
+		{"cpu,host=A#!~#max_value", []tsm1.Value{tsm1.NewValue(0, 4.0), tsm1.NewValue(10, 2.0)}},
+		{"cpu,host=A#!~#mean_value", []tsm1.Value{tsm1.NewValue(0, 2.5), tsm1.NewValue(10, 2.0)}},
+		{"cpu,host=A#!~#status", []tsm1.Value{tsm1.NewValue(1, "ok")}},
+		{"cpu,host=A#!~#value", []tsm1.Value{tsm1.NewValue(0, 2.5), tsm1.NewValue(10, 2.0)}},
+		{"cpu,host=B#!~#count", []tsm1.Value{tsm1.NewValue(0, int64(4))}},
+		{"cpu,host=B#!~#count_count", []tsm1.Value{tsm1.NewValue(0, int64(2))}},
+		{"cpu,host=B#!~#max_count", []tsm1.Value{tsm1.NewValue(0, int64(5))}},
+		{"cpu,host=B#!~#mean_count", []tsm1.Value{tsm1.NewValue(0, 4.0)}},
+	}
+
+	for _, p := range data {
+		values, err := r.ReadAll(p.key)
+		if err != nil {
+			t.Fatalf("unexpected error reading: %v", err)
+		}
+
+		if got, exp := len(values), len(p.points); got != exp {
+			t.Fatalf("values length mismatch %s: got %v, exp %v", p.key, got, exp)
+		}
+
+		for i, point := range p.points {
+			assertValueEqual(t, values[i], point)
+		}
+	}
+
+	if got, exp := r.KeyCount(), len(data); got != exp {
+		t.Fatalf("keys length mismatch: got %v, exp %v", got, exp)
+	}
+
+	// Downsampling the files again leaves the data unchanged.
+	again, err := compactor.Downsample(files, 10, []string{tsm1.DownsampleMean, tsm1.DownsampleMax, tsm1.DownsampleCount})
+	if err != nil {
+		t.Fatalf("unexpected error downsampling: %v", err)
+	}
+
+	r2 := MustOpenTSMReader(again[0])
+	defer r2.Close()
+
+	if got, exp := r2.KeyCount(), len(data); got != exp {
+		t.Fatalf("keys length mismatch: got %v, exp %v", got, exp)
+	}
+
+	for _, p := range data {
+		values, err := r2.ReadAll(p.key)
+		if err != nil {
+			t.Fatalf("unexpected error reading: %v", err)
+		}
+
+		if got, exp := len(values), len(p.points); got != exp {
+			t.Fatalf("values length mismatch %s: got %v, exp %v", p.key, got, exp)
+		}
+
+		for i, point := range p.points {
+			assertValueEqual(t, values[i], point)
+		}
+	}
+}
+
+// Ensures that an existing field with the name of an aggregate of a different type
+// is kept instead of the aggregate.
+func TestCompactor_Downsample_ExistingAggregate(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	f1 := MustWriteTSM(dir, 1, map[string][]tsm1.Value{
+		"cpu#!~#value":     []tsm1.Value{tsm1.NewValue(1, 1.0), tsm1.NewValue(2, 3.0)},
+		"cpu#!~#max_value": []tsm1.Value{tsm1.NewValue(1, "high")},
+	})
+
+	compactor := &tsm1.Compactor{
+		Dir:       dir,
+		FileStore: &fakeFileStore{},
+	}
+	compactor.Open()
+
+	if _, err := compactor.Downsample([]string{f1}, 10, []string{tsm1.DownsampleCount}); err == nil {
+		t.Fatalf("expected error downsampling with only count")
+	}
+
+	files, err := compactor.Downsample([]string{f1}, 10, []string{tsm1.DownsampleMax, tsm1.DownsampleMin})
+	if err != nil {
+		t.Fatalf("unexpected error downsampling: %v", err)
+	}
+
+	r := MustOpenTSMReader(files[0])
+	defer r.Close()
+
+	var data = []struct {
+		key    string
+		points []tsm1.Value
+	}{
+		{"cpu#!~#max_value", []tsm1.Value{tsm1.NewValue(1, "high")}},
+		{"cpu#!~#min_value", []tsm1.Value{tsm1.NewValue(0, 1.0)}},
+		{"cpu#!~#value", []tsm1.Value{tsm1.NewValue(0, 3.0)}},
+	}
+
+	if got, exp := r.KeyCount(), len(data); got != exp {
+		t.Fatalf("keys length mismatch: got %v, exp %v", got, exp)
+	}
+
+	for _, p := range data {
+		values, err := r.ReadAll(p.key)
+		if err != nil {
+			t.Fatalf("unexpected error reading: %v", err)
+		}
+
+		if got, exp := len(values), len(p.points); got != exp {
+			t.Fatalf("values length mismatch %s: got %v, exp %v", p.key, got, exp)
+		}
+
+		for i, point := range p.points {
+			assertValueEqual(t, values[i], point)
+		}
+	}
+}
+
+// Ensures that the oldest of duplicate values is kept for keys that keep their first values.
+func TestCompactor_CompactFull_KeepFirst(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	f1 := MustWriteTSM(dir, 1, map[string][]tsm1.Value{
+		"cpu,host=A#!~#value": []tsm1.Value{tsm1.NewValue(1, 1.1), tsm1.NewValue(2, 1.2)},
+		"mem,host=A#!~#value": []tsm1.Value{tsm1.NewValue(1, 2.1), tsm1.NewValue(2, 2.2)},
+	})
+	f2 := MustWriteTSM(dir, 2, map[string][]tsm1.Value{
+		"cpu,host=A#!~#value": []tsm1.Value{tsm1.NewValue(2, 1.3), tsm1.NewValue(3, 1.4)},
+		"mem,host=A#!~#value": []tsm1.Value{tsm1.NewValue(2, 2.3), tsm1.NewValue(3, 2.4)},
+	})
+
+	compactor := &tsm1.Compactor{
+		Dir:       dir,
+		FileStore: &fakeFileStore{},
+		KeepFirst: func(key string) bool { return strings.HasPrefix(key, "cpu,") },
+	}
+	compactor.Open()
+
+	files, err := compactor.CompactFull([]string{f1, f2})
+	if err != nil {
+		t.Fatalf("unexpected error compacting: %v", err)
+	} else if got, exp := len(files), 1; got != exp {
+		t.Fatalf("files length mismatch: got %v, exp %v", got, exp)
+	} else if got, exp := compactor.Duplicates(), int64(2); got != exp {
+		t.Fatalf("duplicates mismatch: got %v, exp %v", got, exp)
+	}
+
+	r := MustOpenTSMReader(files[0])
+	defer r.Close()
+
+	var data = []struct {
+		key    string
+		points []tsm1.Value
+	}{
+		{"cpu,host=A#!~#value", []tsm1.Value{tsm1.NewValue(1, 1.1), tsm1.NewValue(2, 1.2), tsm1.NewValue(3, 1.4)}},
+		{"mem,host=A#!~#value", []tsm1.Value{tsm1.NewValue(1, 2.1), tsm1.NewValue(2, 2.3), tsm1.NewValue(3, 2.4)}},
+	}
+
+	for _, p := range data {
+		values, err := r.ReadAll(p.key)
+		if err != nil {
+			t.Fatalf("unexpected error reading: %v", err)
+		}
+
+		if got, exp := len(values), len(p.points); got != exp {
+			t.Fatalf("values length mismatch %s: got %v, exp %v", p.key, got, exp)
+		}
+
+		for i, point := range p.points {
+			assertValueEqual(t, values[i], point)
+		}
+	}
+}
+
+func TestCompactor_CompactRange(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	f1 := MustWriteTSM(dir, 1, map[string][]tsm1.Value{
+		"cpu,host=A#!~#value": []tsm1.Value{
+			tsm1.NewValue(1, 1.1),
+			tsm1.NewValue(5, 1.2),
+			tsm1.NewValue(10, 1.3),
+		},
+		"cpu,host=B#!~#value": []tsm1.Value{tsm1.NewValue(5, 2.1)},
+	})
+	f2 := MustWriteTSM(dir, 2, map[string][]tsm1.Value{
+		"cpu,host=C#!~#value": []tsm1.Value{tsm1.NewValue(20, 3.1)},
+	})
+
+	compactor := &tsm1.Compactor{
+		Dir:       dir,
+		FileStore: &fakeFileStore{},
+	}
+	compactor.Open()
+
+	files, err := compactor.CompactRange([]string{f1, f2}, 2, 10)
+	if err != nil {
+		t.Fatalf("unexpected error compacting: %v", err)
+	}
+
+	if got, exp := len(files), 1; got != exp {
+		t.Fatalf("files length mismatch: got %v, exp %v", got, exp)
+	}
+
+	r := MustOpenTSMReader(files[0])
+	defer r.Close()
+
+	// Keys with no values in the range are dropped.
+	var data = []struct {
+		key    string
+		points []tsm1.Value
+	}{
+		{"cpu,host=A#!~#value", []tsm1.Value{tsm1.NewValue(5, 1.2), tsm1.NewValue(10, 1.3)}},
+		{"cpu,host=B#!~#value", []tsm1.Value{tsm1.NewValue(5, 2.1)}},
+	}
+
+	if got, exp := r.KeyCount(), len(data); got != exp {
+		t.Fatalf("keys length mismatch: got %v, exp %v", got, exp)
+	}
+
+	for _, p := range data {
+		values, err := r.ReadAll(p.key)
+		if err != nil {
+			t.Fatalf("unexpected error reading: %v", err)
+		}
+
+		if got, exp := len(values), len(p.points); got != exp {
+			t.Fatalf("values length mismatch %s: got %v, exp %v", p.key, got, exp)
+		}
+
+		for i, point := range p.points {
+			assertValueEqual(t, values[i], point)
+		}
+	}
+}
+
 // Tests that a single TSM file can be read and iterated over
 func TestTSMKeyIterator_Single(t *testing.T) {
 	dir := MustTempDir()
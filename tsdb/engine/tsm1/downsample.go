@@ -0,0 +1,395 @@
+package tsm1
+
+// Downsampling rewrites the TSM files of a shard at a lower resolution.  The values
+// of each numeric field are grouped into windows of a fixed interval and every
+// window is replaced by one value per aggregate function.  The aggregates are
+// written as new fields named after the function and the original field, such as
+// mean_value, matching the names produced by a continuous query using mean(*).
+// The original field is kept and holds the aggregate of the first function other
+// than count, converted to the type of the field, so that queries reading the field
+// return values across the boundary of downsampled shards.  Fields of other types
+// are written unchanged.
+//
+// A field is only downsampled once.  A field with every one of its aggregate fields
+// is written unchanged along with the aggregates, so downsampling a shard again,
+// e.g. after a failure to record that it was downsampled, doesn't change its data.
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Functions that can be used to downsample a field.
+const (
+	DownsampleMean  = "mean"
+	DownsampleMin   = "min"
+	DownsampleMax   = "max"
+	DownsampleSum   = "sum"
+	DownsampleCount = "count"
+)
+
+// downsampleKeyIterator is a KeyIterator that downsamples the blocks read from
+// another KeyIterator.  Since the aggregate fields of a series don't sort in the
+// same order as the fields they are computed from, the fields of each series are
+// aggregated in full before any of them are returned.
+type downsampleKeyIterator struct {
+	iter     KeyIterator
+	size     int
+	interval int64
+	fns      []string
+
+	// keep is the index of the function in fns whose aggregate is stored under
+	// the name of the original field.
+	keep int
+
+	// pending is set when pendingKey and pendingBlock hold a block read from iter
+	// that has not been aggregated yet.
+	pending      bool
+	pendingKey   string
+	pendingBlock []byte
+	done         bool
+
+	// out holds the values of the current series that have not been returned yet,
+	// with the keys in sorted order.
+	out  map[string][]Value
+	keys []string
+
+	// The current key, the values of the key not returned yet and the current block.
+	key        string
+	values     []Value
+	minT, maxT int64
+	block      []byte
+	err        error
+}
+
+// NewDownsampleKeyIterator returns a KeyIterator that aggregates the values read from
+// iter into windows of interval using the functions in fns.  At least one function
+// other than count is required.
+func NewDownsampleKeyIterator(iter KeyIterator, size int, interval time.Duration, fns []string) (KeyIterator, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid downsample interval: %v", interval)
+	} else if len(fns) == 0 {
+		return nil, fmt.Errorf("no downsample functions")
+	}
+
+	keep := -1
+	for i, fn := range fns {
+		switch fn {
+		case DownsampleMean, DownsampleMin, DownsampleMax, DownsampleSum:
+			if keep == -1 {
+				keep = i
+			}
+		case DownsampleCount:
+		default:
+			return nil, fmt.Errorf("unknown downsample function: %s", fn)
+		}
+	}
+	if keep == -1 {
+		return nil, fmt.Errorf("downsample functions must include a function other than count")
+	}
+
+	return &downsampleKeyIterator{
+		iter:     iter,
+		size:     size,
+		interval: int64(interval),
+		fns:      fns,
+		keep:     keep,
+		out:      make(map[string][]Value),
+	}, nil
+}
+
+func (k *downsampleKeyIterator) Next() bool {
+	for {
+		if k.err != nil {
+			return true
+		}
+
+		// Return the next block of the current key.
+		if len(k.values) > 0 {
+			values := k.values
+			if len(values) > k.size {
+				values = values[:k.size]
+			}
+			k.values = k.values[len(values):]
+
+			k.minT, k.maxT = values[0].UnixNano(), values[len(values)-1].UnixNano()
+			k.block, k.err = Values(values).Encode(nil)
+			return true
+		}
+
+		if len(k.keys) > 0 {
+			k.key, k.keys = k.keys[0], k.keys[1:]
+			k.values = k.out[k.key]
+			delete(k.out, k.key)
+			continue
+		}
+
+		if k.done {
+			return false
+		}
+		k.err = k.readSeries()
+	}
+}
+
+// readSeries reads and aggregates every field of the next series from iter.
+func (k *downsampleKeyIterator) readSeries() error {
+	var series []byte
+	fields := make(map[string][]Value)
+	types := make(map[string]byte)
+	for {
+		if !k.pending {
+			if !k.iter.Next() {
+				k.done = true
+				break
+			}
+
+			key, _, _, block, err := k.iter.Read()
+			if err != nil {
+				return err
+			}
+			k.pending, k.pendingKey, k.pendingBlock = true, key, block
+		}
+
+		seriesKey, field := SeriesAndFieldFromCompositeKey([]byte(k.pendingKey))
+		if series != nil && !bytes.Equal(seriesKey, series) {
+			break
+		}
+		series = seriesKey
+		k.pending = false
+
+		typ, err := BlockType(k.pendingBlock)
+		if err != nil {
+			return err
+		} else if t, ok := types[string(field)]; ok && t != typ {
+			return fmt.Errorf("cannot downsample %s: blocks of different types", k.pendingKey)
+		}
+		types[string(field)] = typ
+
+		// Blocks of the same key are read in time order.
+		values, err := DecodeBlock(k.pendingBlock, nil)
+		if err != nil {
+			return err
+		}
+		fields[string(field)] = append(fields[string(field)], values...)
+	}
+
+	// Fields that were downsampled before are written unchanged, along with their
+	// aggregates.
+	unchanged := make(map[string]struct{})
+	for field := range fields {
+		if k.downsampled(field, fields) {
+			unchanged[field] = struct{}{}
+			for _, fn := range k.fns {
+				unchanged[fn+"_"+field] = struct{}{}
+			}
+		}
+	}
+
+	for field, values := range fields {
+		if len(values) == 0 {
+			continue
+		}
+
+		key := SeriesFieldKey(string(series), field)
+		if _, ok := unchanged[field]; ok || !isNumericValue(values[0]) {
+			k.out[key] = values
+			continue
+		}
+
+		d := newDownsampler(k.interval, k.fns)
+		for _, v := range values {
+			d.add(v)
+		}
+		aggregates := d.flush()
+
+		// An existing field with the name of an aggregate is kept instead of the
+		// aggregate since the two can't be merged, and may not have the same type.
+		for i, fn := range k.fns {
+			name := fn + "_" + field
+			if _, ok := fields[name]; !ok {
+				k.out[SeriesFieldKey(string(series), name)] = aggregates[i]
+			}
+		}
+		k.out[key] = convertValues(aggregates[k.keep], values[0])
+	}
+
+	k.keys = k.keys[:0]
+	for key := range k.out {
+		k.keys = append(k.keys, key)
+	}
+	sort.Strings(k.keys)
+	return nil
+}
+
+// downsampled returns true if field has been downsampled before, which is when
+// fields holds every one of its aggregate fields.
+func (k *downsampleKeyIterator) downsampled(field string, fields map[string][]Value) bool {
+	for _, fn := range k.fns {
+		if _, ok := fields[fn+"_"+field]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (k *downsampleKeyIterator) Read() (string, int64, int64, []byte, error) {
+	return k.key, k.minT, k.maxT, k.block, k.err
+}
+
+func (k *downsampleKeyIterator) Close() error {
+	return k.iter.Close()
+}
+
+// downsampler aggregates the values of a numeric field into windows.  Values
+// must be added in time order.
+type downsampler struct {
+	interval int64
+	fns      []string
+
+	// The aggregates of the current window.
+	window   int64
+	n        int64
+	mean     float64
+	isum     int64
+	usum     uint64
+	fsum     float64
+	min, max Value
+
+	out [][]Value
+}
+
+func newDownsampler(interval int64, fns []string) *downsampler {
+	return &downsampler{
+		interval: interval,
+		fns:      fns,
+		out:      make([][]Value, len(fns)),
+	}
+}
+
+// add adds v to the aggregates of the window containing it.
+func (d *downsampler) add(v Value) {
+	t := v.UnixNano()
+	window := t - t%d.interval
+	if t%d.interval < 0 {
+		window -= d.interval
+	}
+
+	if d.n > 0 && window != d.window {
+		d.emit()
+	}
+	d.window = window
+	d.n++
+
+	var f float64
+	switch v := v.(type) {
+	case FloatValue:
+		f = v.value
+		d.fsum += v.value
+	case IntegerValue:
+		f = float64(v.value)
+		d.isum += v.value
+	case UnsignedValue:
+		f = float64(v.value)
+		d.usum += v.value
+	}
+
+	// Keep a running mean to avoid overflowing the sum of large values.
+	d.mean += (f - d.mean) / float64(d.n)
+
+	if d.n == 1 || numericLess(v, d.min) {
+		d.min = v
+	}
+	if d.n == 1 || numericLess(d.max, v) {
+		d.max = v
+	}
+}
+
+// emit appends the aggregates of the current window to the output and resets them.
+func (d *downsampler) emit() {
+	for i, fn := range d.fns {
+		var v Value
+		switch fn {
+		case DownsampleMean:
+			v = FloatValue{unixnano: d.window, value: d.mean}
+		case DownsampleMin:
+			v = NewValue(d.window, d.min.Value())
+		case DownsampleMax:
+			v = NewValue(d.window, d.max.Value())
+		case DownsampleSum:
+			switch d.min.(type) {
+			case FloatValue:
+				v = FloatValue{unixnano: d.window, value: d.fsum}
+			case IntegerValue:
+				v = IntegerValue{unixnano: d.window, value: d.isum}
+			case UnsignedValue:
+				v = UnsignedValue{unixnano: d.window, value: d.usum}
+			}
+		case DownsampleCount:
+			v = IntegerValue{unixnano: d.window, value: d.n}
+		}
+		d.out[i] = append(d.out[i], v)
+	}
+
+	d.n, d.mean, d.fsum, d.isum, d.usum = 0, 0, 0, 0, 0
+	d.min, d.max = nil, nil
+}
+
+// flush returns the aggregates of every window for each function, in the order of
+// the functions.
+func (d *downsampler) flush() [][]Value {
+	if d.n > 0 {
+		d.emit()
+	}
+	return d.out
+}
+
+// isNumericValue returns true if v holds a value that can be downsampled.
+func isNumericValue(v Value) bool {
+	switch v.(type) {
+	case FloatValue, IntegerValue, UnsignedValue:
+		return true
+	}
+	return false
+}
+
+// convertValues returns the float values in values, such as means, converted to
+// the type of like and rounded to the nearest integer.  If no conversion is needed,
+// values is returned.
+func convertValues(values []Value, like Value) []Value {
+	if len(values) == 0 {
+		return values
+	} else if _, ok := values[0].(FloatValue); !ok {
+		return values
+	}
+
+	a := make([]Value, len(values))
+	for i, v := range values {
+		f := v.(FloatValue)
+		switch like.(type) {
+		case IntegerValue:
+			a[i] = IntegerValue{unixnano: f.unixnano, value: int64(math.Floor(f.value + 0.5))}
+		case UnsignedValue:
+			a[i] = UnsignedValue{unixnano: f.unixnano, value: uint64(math.Floor(f.value + 0.5))}
+		default:
+			return values
+		}
+	}
+	return a
+}
+
+// numericLess returns true if the value of a is less than the value of b.  Both
+// values must be of the same numeric type.
+func numericLess(a, b Value) bool {
+	switch a := a.(type) {
+	case FloatValue:
+		return a.value < b.(FloatValue).value
+	case IntegerValue:
+		return a.value < b.(IntegerValue).value
+	case UnsignedValue:
+		return a.value < b.(UnsignedValue).value
+	}
+	return false
+}
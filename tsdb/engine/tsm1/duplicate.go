@@ -0,0 +1,163 @@
+package tsm1
+
+import (
+	"bytes"
+	"sync/atomic"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/escape"
+)
+
+// Policies for a point written with the series key and timestamp of a point already
+// stored.
+const (
+	// DuplicateMerge replaces the values of the fields of the existing point.
+	DuplicateMerge = "merge"
+
+	// DuplicateFirst keeps the existing point and drops the new point.
+	DuplicateFirst = "first"
+
+	// DuplicateReject drops the new point and returns a partial write error.
+	DuplicateReject = "reject"
+)
+
+// duplicatePolicies holds the duplicate policies of the measurements of a shard.
+type duplicatePolicies struct {
+	policy       string
+	measurements map[string]string
+}
+
+// enabled returns true if the points of any measurement may be dropped as duplicates.
+func (p *duplicatePolicies) enabled() bool {
+	if p.policy != "" && p.policy != DuplicateMerge {
+		return true
+	}
+	for _, policy := range p.measurements {
+		if policy != DuplicateMerge {
+			return true
+		}
+	}
+	return false
+}
+
+// measurementPolicy returns the duplicate policy of the measurement name.
+func (p *duplicatePolicies) measurementPolicy(name []byte) string {
+	if policy, ok := p.measurements[string(name)]; ok {
+		return policy
+	} else if p.policy == "" {
+		return DuplicateMerge
+	}
+	return p.policy
+}
+
+// keyPolicy returns the duplicate policy of the measurement of a series field key.
+func (p *duplicatePolicies) keyPolicy(key string) string {
+	if len(p.measurements) == 0 {
+		return p.measurementPolicy(nil)
+	}
+
+	seriesKey, _ := SeriesAndFieldFromCompositeKey([]byte(key))
+	name, err := models.ParseName(seriesKey)
+	if err != nil {
+		return p.measurementPolicy(nil)
+	}
+	return p.measurementPolicy(escape.Unescape(name))
+}
+
+// dropDuplicates removes the points of measurements with the first or reject duplicate
+// policies that have the series key and timestamp of a point already stored, or of an
+// earlier point in points.  A point is a duplicate if a value of any field of its
+// measurement is stored at its timestamp.  It returns the remaining points and the
+// number of points rejected.  The caller must hold duplicateMu until the remaining
+// points are written to the cache.
+func (e *Engine) dropDuplicates(points []models.Point) ([]models.Point, int, error) {
+	if !e.duplicates.enabled() {
+		return points, 0, nil
+	}
+
+	// The fields of each measurement in points, the field keys written by points and
+	// the blocks decoded to look up stored values.
+	fields := make(map[string][][]byte)
+	written := make(map[string]map[int64]struct{})
+	blocks := make(decodedBlocks)
+
+	var keyBuf []byte
+	var dropped, rejected int
+	n := 0
+	for _, p := range points {
+		policy := e.duplicates.measurementPolicy(p.Name())
+		if policy == DuplicateMerge {
+			points[n] = p
+			n++
+			continue
+		}
+
+		names, ok := fields[string(p.Name())]
+		if !ok {
+			if mf := e.fieldset.Fields(string(p.Name())); mf != nil {
+				for name := range mf.FieldSet() {
+					names = append(names, []byte(name))
+				}
+			}
+			fields[string(p.Name())] = names
+		}
+
+		// Fields of the point are checked first since they are the most likely to be
+		// stored at its timestamp.
+		iter := p.FieldIterator()
+		for iter.Next() {
+			if !bytes.Equal(iter.FieldKey(), timeBytes) {
+				names = append(names[:len(names):len(names)], iter.FieldKey())
+			}
+		}
+
+		keyBuf = append(keyBuf[:0], p.Key()...)
+		keyBuf = append(keyBuf, keyFieldSeparator...)
+		baseLen := len(keyBuf)
+		t := p.Time().UnixNano()
+
+		var duplicate bool
+		for i := len(names) - 1; i >= 0; i-- {
+			name := names[i]
+			key := string(append(keyBuf[:baseLen], name...))
+			if _, ok := written[key][t]; ok || e.Cache.ContainsValue(key, t) {
+				duplicate = true
+				break
+			}
+
+			ok, err := e.FileStore.ContainsValue(key, t, blocks)
+			if err != nil {
+				return nil, 0, err
+			} else if ok {
+				duplicate = true
+				break
+			}
+		}
+
+		if duplicate {
+			dropped++
+			if policy == DuplicateReject {
+				rejected++
+			}
+			continue
+		}
+
+		iter.Reset()
+		for iter.Next() {
+			if bytes.Equal(iter.FieldKey(), timeBytes) {
+				continue
+			}
+			key := string(append(keyBuf[:baseLen], iter.FieldKey()...))
+			if written[key] == nil {
+				written[key] = make(map[int64]struct{})
+			}
+			written[key][t] = struct{}{}
+		}
+
+		points[n] = p
+		n++
+	}
+
+	atomic.AddInt64(&e.stats.WriteDuplicates, int64(dropped))
+	return points[:n], rejected, nil
+}
@@ -24,6 +24,9 @@ const (
 	// BlockString designates a block encodes string values.
 	BlockString = byte(3)
 
+	// BlockUnsigned designates a block encodes uint64 values.
+	BlockUnsigned = byte(4)
+
 	// encodedBlockHeaderSize is the size of the header for an encoded block.  There is one
 	// byte encoding the type of the block.
 	encodedBlockHeaderSize = 1
@@ -114,6 +117,8 @@ func NewValue(t int64, value interface{}) Value {
 	switch v := value.(type) {
 	case int64:
 		return IntegerValue{unixnano: t, value: v}
+	case uint64:
+		return UnsignedValue{unixnano: t, value: v}
 	case float64:
 		return FloatValue{unixnano: t, value: v}
 	case bool:
@@ -129,6 +134,11 @@ func NewIntegerValue(t int64, v int64) Value {
 	return IntegerValue{unixnano: t, value: v}
 }
 
+// NewUnsignedValue returns a new unsigned integer value.
+func NewUnsignedValue(t int64, v uint64) Value {
+	return UnsignedValue{unixnano: t, value: v}
+}
+
 // NewFloatValue returns a new float value.
 func NewFloatValue(t int64, v float64) Value {
 	return FloatValue{unixnano: t, value: v}
@@ -159,11 +169,12 @@ func (e EmptyValue) Size() int { return 0 }
 // String returns the empty string.
 func (e EmptyValue) String() string { return "" }
 
-func (_ EmptyValue) internalOnly()   {}
-func (_ StringValue) internalOnly()  {}
-func (_ IntegerValue) internalOnly() {}
-func (_ BooleanValue) internalOnly() {}
-func (_ FloatValue) internalOnly()   {}
+func (_ EmptyValue) internalOnly()    {}
+func (_ StringValue) internalOnly()   {}
+func (_ IntegerValue) internalOnly()  {}
+func (_ UnsignedValue) internalOnly() {}
+func (_ BooleanValue) internalOnly()  {}
+func (_ FloatValue) internalOnly()    {}
 
 // Encode converts the values to a byte slice.  If there are no values,
 // this function panics.
@@ -177,6 +188,8 @@ func (a Values) Encode(buf []byte) ([]byte, error) {
 		return encodeFloatBlock(buf, a)
 	case IntegerValue:
 		return encodeIntegerBlock(buf, a)
+	case UnsignedValue:
+		return encodeUnsignedBlock(buf, a)
 	case BooleanValue:
 		return encodeBooleanBlock(buf, a)
 	case StringValue:
@@ -197,6 +210,8 @@ func (a Values) InfluxQLType() (influxql.DataType, error) {
 		return influxql.Float, nil
 	case IntegerValue:
 		return influxql.Integer, nil
+	case UnsignedValue:
+		return influxql.Unsigned, nil
 	case BooleanValue:
 		return influxql.Boolean, nil
 	case StringValue:
@@ -211,7 +226,7 @@ func (a Values) InfluxQLType() (influxql.DataType, error) {
 func BlockType(block []byte) (byte, error) {
 	blockType := block[0]
 	switch blockType {
-	case BlockFloat64, BlockInteger, BlockBoolean, BlockString:
+	case BlockFloat64, BlockInteger, BlockUnsigned, BlockBoolean, BlockString:
 		return blockType, nil
 	default:
 		return 0, fmt.Errorf("unknown block type: %d", blockType)
@@ -265,6 +280,17 @@ func DecodeBlock(block []byte, vals []Value) ([]Value, error) {
 		}
 		return vals[:len(decoded)], err
 
+	case BlockUnsigned:
+		var buf []UnsignedValue
+		decoded, err := DecodeUnsignedBlock(block, &buf)
+		if len(vals) < len(decoded) {
+			vals = make([]Value, len(decoded))
+		}
+		for i := range decoded {
+			vals[i] = decoded[i]
+		}
+		return vals[:len(decoded)], err
+
 	case BlockBoolean:
 		var buf []BooleanValue
 		decoded, err := DecodeBooleanBlock(block, &buf)
@@ -353,6 +379,12 @@ func encodeFloatBlock(buf []byte, values []Value) ([]byte, error) {
 			return err
 		}
 
+		if hasCodecs(BlockFloat64) {
+			if vb, err = encodeSmallest(BlockFloat64, vb, codecValues(values)); err != nil {
+				return err
+			}
+		}
+
 		// Prepend the first timestamp of the block in the first 8 bytes and the block
 		// in the next byte, followed by the block
 		b = packBlock(buf, BlockFloat64, tb, vb)
@@ -381,6 +413,11 @@ func DecodeFloatBlock(block []byte, a *[]FloatValue) ([]FloatValue, error) {
 		return nil, err
 	}
 
+	if c := lookupCodec(BlockFloat64, vb); c != nil {
+		n, err := decodeCodecBlock(c, tb, vb, a)
+		return (*a)[:n], err
+	}
+
 	tdec := timeDecoderPool.Get(0).(*TimeDecoder)
 	vdec := floatDecoderPool.Get(0).(*FloatDecoder)
 
@@ -484,6 +521,12 @@ func encodeBooleanBlock(buf []byte, values []Value) ([]byte, error) {
 			return err
 		}
 
+		if hasCodecs(BlockBoolean) {
+			if vb, err = encodeSmallest(BlockBoolean, vb, codecValues(values)); err != nil {
+				return err
+			}
+		}
+
 		// Prepend the first timestamp of the block in the first 8 bytes and the block
 		// in the next byte, followed by the block
 		b = packBlock(buf, BlockBoolean, tb, vb)
@@ -511,6 +554,11 @@ func DecodeBooleanBlock(block []byte, a *[]BooleanValue) ([]BooleanValue, error)
 		return nil, err
 	}
 
+	if c := lookupCodec(BlockBoolean, vb); c != nil {
+		n, err := decodeCodecBlock(c, tb, vb, a)
+		return (*a)[:n], err
+	}
+
 	tdec := timeDecoderPool.Get(0).(*TimeDecoder)
 	vdec := booleanDecoderPool.Get(0).(*BooleanDecoder)
 
@@ -602,6 +650,12 @@ func encodeIntegerBlock(buf []byte, values []Value) ([]byte, error) {
 			return err
 		}
 
+		if hasCodecs(BlockInteger) {
+			if vb, err = encodeSmallest(BlockInteger, vb, codecValues(values)); err != nil {
+				return err
+			}
+		}
+
 		// Prepend the first timestamp of the block in the first 8 bytes
 		b = packBlock(buf, BlockInteger, tb, vb)
 		return nil
@@ -629,6 +683,11 @@ func DecodeIntegerBlock(block []byte, a *[]IntegerValue) ([]IntegerValue, error)
 		return nil, err
 	}
 
+	if c := lookupCodec(BlockInteger, vb); c != nil {
+		n, err := decodeCodecBlock(c, tb, vb, a)
+		return (*a)[:n], err
+	}
+
 	tdec := timeDecoderPool.Get(0).(*TimeDecoder)
 	vdec := integerDecoderPool.Get(0).(*IntegerDecoder)
 
@@ -671,6 +730,138 @@ func DecodeIntegerBlock(block []byte, a *[]IntegerValue) ([]IntegerValue, error)
 	return (*a)[:i], err
 }
 
+// UnsignedValue represents a uint64 value.
+type UnsignedValue struct {
+	unixnano int64
+	value    uint64
+}
+
+// Value returns the underlying uint64 value.
+func (v UnsignedValue) Value() interface{} {
+	return v.value
+}
+
+// UnixNano returns the timestamp of the value.
+func (v UnsignedValue) UnixNano() int64 {
+	return v.unixnano
+}
+
+// Size returns the number of bytes necessary to represent the value and its timestamp.
+func (v UnsignedValue) Size() int {
+	return 16
+}
+
+// String returns the string representation of the value and its timestamp.
+func (v UnsignedValue) String() string {
+	return fmt.Sprintf("%v %v", time.Unix(0, v.unixnano), v.Value())
+}
+
+// encodeUnsignedBlock encodes uint64 values using the integer encoder. The
+// values are stored as their two's complement int64 bit pattern so the
+// zig-zag and simple8b/RLE encodings are shared with BlockInteger.
+func encodeUnsignedBlock(buf []byte, values []Value) ([]byte, error) {
+	tsEnc := getTimeEncoder(len(values))
+	vEnc := getIntegerEncoder(len(values))
+
+	var b []byte
+	err := func() error {
+		for _, v := range values {
+			vv := v.(UnsignedValue)
+			tsEnc.Write(vv.unixnano)
+			vEnc.Write(int64(vv.value))
+		}
+
+		// Encoded timestamp values
+		tb, err := tsEnc.Bytes()
+		if err != nil {
+			return err
+		}
+		// Encoded uint64 values
+		vb, err := vEnc.Bytes()
+		if err != nil {
+			return err
+		}
+
+		if hasCodecs(BlockUnsigned) {
+			if vb, err = encodeSmallest(BlockUnsigned, vb, codecValues(values)); err != nil {
+				return err
+			}
+		}
+
+		// Prepend the first timestamp of the block in the first 8 bytes
+		b = packBlock(buf, BlockUnsigned, tb, vb)
+		return nil
+	}()
+
+	putTimeEncoder(tsEnc)
+	putIntegerEncoder(vEnc)
+
+	return b, err
+}
+
+// DecodeUnsignedBlock decodes the unsigned integer block from the byte slice
+// and appends the unsigned integer values to a.
+func DecodeUnsignedBlock(block []byte, a *[]UnsignedValue) ([]UnsignedValue, error) {
+	blockType := block[0]
+	if blockType != BlockUnsigned {
+		return nil, fmt.Errorf("invalid block type: exp %d, got %d", BlockUnsigned, blockType)
+	}
+
+	block = block[1:]
+
+	// The first 8 bytes is the minimum timestamp of the block
+	tb, vb, err := unpackBlock(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if c := lookupCodec(BlockUnsigned, vb); c != nil {
+		n, err := decodeCodecBlock(c, tb, vb, a)
+		return (*a)[:n], err
+	}
+
+	tdec := timeDecoderPool.Get(0).(*TimeDecoder)
+	vdec := integerDecoderPool.Get(0).(*IntegerDecoder)
+
+	var i int
+	err = func() error {
+		// Setup our timestamp and value decoders
+		tdec.Init(tb)
+		vdec.SetBytes(vb)
+
+		// Decode both a timestamp and value
+		for tdec.Next() && vdec.Next() {
+			ts := tdec.Read()
+			v := uint64(vdec.Read())
+			if i < len(*a) {
+				elem := &(*a)[i]
+				elem.unixnano = ts
+				elem.value = v
+			} else {
+				*a = append(*a, UnsignedValue{ts, v})
+			}
+			i++
+		}
+
+		// Did timestamp decoding have an error?
+		err = tdec.Error()
+		if err != nil {
+			return err
+		}
+		// Did uint64 decoding have an error?
+		err = vdec.Error()
+		if err != nil {
+			return err
+		}
+		return nil
+	}()
+
+	timeDecoderPool.Put(tdec)
+	integerDecoderPool.Put(vdec)
+
+	return (*a)[:i], err
+}
+
 // StringValue represents a string value.
 type StringValue struct {
 	unixnano int64
@@ -720,6 +911,12 @@ func encodeStringBlock(buf []byte, values []Value) ([]byte, error) {
 			return err
 		}
 
+		if hasCodecs(BlockString) {
+			if vb, err = encodeSmallest(BlockString, vb, codecValues(values)); err != nil {
+				return err
+			}
+		}
+
 		// Prepend the first timestamp of the block in the first 8 bytes
 		b = packBlock(buf, BlockString, tb, vb)
 
@@ -748,6 +945,11 @@ func DecodeStringBlock(block []byte, a *[]StringValue) ([]StringValue, error) {
 		return nil, err
 	}
 
+	if c := lookupCodec(BlockString, vb); c != nil {
+		n, err := decodeCodecBlock(c, tb, vb, a)
+		return (*a)[:n], err
+	}
+
 	tdec := timeDecoderPool.Get(0).(*TimeDecoder)
 	vdec := stringDecoderPool.Get(0).(*StringDecoder)
 
@@ -858,6 +1060,10 @@ func getIntegerEncoder(sz int) IntegerEncoder {
 }
 func putIntegerEncoder(enc IntegerEncoder) { integerEncoderPool.Put(enc) }
 
+// Unsigned values share the integer encoder; see encodeUnsignedBlock.
+func getUnsignedEncoder(sz int) IntegerEncoder { return getIntegerEncoder(sz) }
+func putUnsignedEncoder(enc IntegerEncoder)    { putIntegerEncoder(enc) }
+
 func getFloatEncoder(sz int) *FloatEncoder {
 	x := floatEncoderPool.Get(sz).(*FloatEncoder)
 	x.Reset()
@@ -2,6 +2,7 @@ package tsm1_test
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -142,6 +143,34 @@ func TestEncoding_IntBlock_Negatives(t *testing.T) {
 	}
 }
 
+func TestEncoding_UnsignedBlock_Basic(t *testing.T) {
+	valueCount := 1000
+	times := getTimes(valueCount, 60, time.Second)
+	values := make([]tsm1.Value, len(times))
+	for i, t := range times {
+		v := uint64(i)
+		if i%2 == 0 {
+			v = math.MaxUint64 - v
+		}
+		values[i] = tsm1.NewValue(t, v)
+	}
+
+	b, err := tsm1.Values(values).Encode(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decodedValues []tsm1.Value
+	decodedValues, err = tsm1.DecodeBlock(b, decodedValues)
+	if err != nil {
+		t.Fatalf("unexpected error decoding block: %v", err)
+	}
+
+	if !reflect.DeepEqual(decodedValues, values) {
+		t.Fatalf("unexpected results:\n\tgot: %v\n\texp: %v\n", decodedValues, values)
+	}
+}
+
 func TestEncoding_BooleanBlock_Basic(t *testing.T) {
 	valueCount := 1000
 	times := getTimes(valueCount, 60, time.Second)
@@ -201,6 +230,7 @@ func TestEncoding_BlockType(t *testing.T) {
 	}{
 		{value: float64(1.0), blockType: tsm1.BlockFloat64},
 		{value: int64(1), blockType: tsm1.BlockInteger},
+		{value: uint64(1), blockType: tsm1.BlockUnsigned},
 		{value: true, blockType: tsm1.BlockBoolean},
 		{value: "string", blockType: tsm1.BlockString},
 	}
@@ -237,6 +267,7 @@ func TestEncoding_Count(t *testing.T) {
 	}{
 		{value: float64(1.0), blockType: tsm1.BlockFloat64},
 		{value: int64(1), blockType: tsm1.BlockInteger},
+		{value: uint64(1), blockType: tsm1.BlockUnsigned},
 		{value: true, blockType: tsm1.BlockBoolean},
 		{value: "string", blockType: tsm1.BlockString},
 	}
@@ -83,6 +83,12 @@ const (
 	statTSMFullCompactionsActive  = "tsmFullCompactionsActive"
 	statTSMFullCompactionError    = "tsmFullCompactionErr"
 	statTSMFullCompactionDuration = "tsmFullCompactionDuration"
+
+	statColdTierMoves     = "coldTierMoves"
+	statColdTierMoveError = "coldTierMoveErr"
+
+	statWriteDuplicates = "writeDuplicates"
+	statTSMDuplicates   = "tsmDuplicates"
 )
 
 // Engine represents a storage engine with compressed blocks.
@@ -113,6 +119,14 @@ type Engine struct {
 	index    tsdb.Index
 	fieldset *tsdb.MeasurementFieldSet
 
+	// duplicates holds the policies for points written with the series key and
+	// timestamp of a stored point.
+	duplicates duplicatePolicies
+
+	// duplicateMu serializes writes that are checked for duplicates, so that points
+	// written concurrently are checked against each other.
+	duplicateMu sync.Mutex
+
 	WAL            *WAL
 	Cache          *Cache
 	Compactor      *Compactor
@@ -130,6 +144,10 @@ type Engine struct {
 	// a snapshot of the cache to a TSM file
 	CacheFlushWriteColdDuration time.Duration
 
+	// ColdAfter specifies the age the newest data in a fully compacted shard must
+	// reach before its TSM files are moved to the cold tier of the FileStore.
+	ColdAfter time.Duration
+
 	// Controls whether to enabled compactions when the engine is open
 	enableCompactionsOnOpen bool
 
@@ -143,13 +161,31 @@ type Engine struct {
 func NewEngine(id uint64, idx tsdb.Index, path string, walPath string, opt tsdb.EngineOptions) tsdb.Engine {
 	w := NewWAL(walPath)
 	w.syncDelay = time.Duration(opt.Config.WALFsyncDelay)
+	if opt.Config.WALMaxBatchSize > 0 {
+		w.maxBatchSize = opt.Config.WALMaxBatchSize
+	}
+
+	// The compression is checked when the config is validated.
+	w.compression, _ = ParseWALCompression(opt.Config.WALSegmentCompression)
 
 	fs := NewFileStore(path)
+	fs.SetColdDir(opt.ColdPath)
+	fs.SetAccessPolicy(opt.Config.TSMAccessPolicy, time.Duration(opt.Config.CompactFullWriteColdDuration), opt.Config.TSMColdReader)
 	cache := NewCache(uint64(opt.Config.CacheMaxMemorySize), path)
+	cache.SetMaxWriteWait(time.Duration(opt.Config.CacheMaxWriteWait))
+
+	duplicates := duplicatePolicies{
+		policy:       opt.DuplicatePolicy,
+		measurements: opt.MeasurementDuplicatePolicies,
+	}
 
 	c := &Compactor{
-		Dir:       path,
-		FileStore: fs,
+		Dir:        path,
+		FileStore:  fs,
+		BlockStats: opt.Config.BlockStatsEnabled,
+		KeepFirst: func(key string) bool {
+			return duplicates.keyPolicy(key) != DuplicateMerge
+		},
 	}
 
 	logger := zap.New(zap.NullEncoder())
@@ -161,7 +197,8 @@ func NewEngine(id uint64, idx tsdb.Index, path string, walPath string, opt tsdb.
 		traceLogger:  logger,
 		traceLogging: opt.Config.TraceLoggingEnabled,
 
-		fieldset: tsdb.NewMeasurementFieldSet(),
+		fieldset:   tsdb.NewMeasurementFieldSet(),
+		duplicates: duplicates,
 
 		WAL:   w,
 		Cache: cache,
@@ -172,9 +209,10 @@ func NewEngine(id uint64, idx tsdb.Index, path string, walPath string, opt tsdb.
 
 		CacheFlushMemorySizeThreshold: opt.Config.CacheSnapshotMemorySize,
 		CacheFlushWriteColdDuration:   time.Duration(opt.Config.CacheSnapshotWriteColdDuration),
+		ColdAfter:                     time.Duration(opt.Config.ColdAfter),
 		enableCompactionsOnOpen:       true,
-		stats:             &EngineStatistics{},
-		compactionLimiter: opt.CompactionLimiter,
+		stats:                         &EngineStatistics{},
+		compactionLimiter:             opt.CompactionLimiter,
 	}
 
 	// Attach fieldset to index.
@@ -338,6 +376,10 @@ func (e *Engine) TagKeyCardinality(name, key []byte) int {
 	return e.index.TagKeyCardinality(name, key)
 }
 
+func (e *Engine) TagValuesByPrefix(name, key, prefix []byte, limit int) ([][]byte, error) {
+	return e.index.TagValuesByPrefix(name, key, prefix, limit)
+}
+
 // SeriesN returns the unique number of series in the index.
 func (e *Engine) SeriesN() int64 {
 	return e.index.SeriesN()
@@ -384,6 +426,11 @@ type EngineStatistics struct {
 	TSMFullCompactionsActive  int64 // Gauge of full compactions currently running.
 	TSMFullCompactionErrors   int64 // Counter of full compactions that have failed due to error.
 	TSMFullCompactionDuration int64 // Counter of number of wall nanoseconds spent in full compactions.
+
+	ColdTierMoves      int64 // Counter of moves of TSM files to the cold tier.
+	ColdTierMoveErrors int64 // Counter of moves of TSM files to the cold tier that have failed due to error.
+
+	WriteDuplicates int64 // Counter of points dropped by the duplicate policy.
 }
 
 // Statistics returns statistics for periodic monitoring.
@@ -422,6 +469,12 @@ func (e *Engine) Statistics(tags map[string]string) []models.Statistic {
 			statTSMFullCompactionsActive:  atomic.LoadInt64(&e.stats.TSMFullCompactionsActive),
 			statTSMFullCompactionError:    atomic.LoadInt64(&e.stats.TSMFullCompactionErrors),
 			statTSMFullCompactionDuration: atomic.LoadInt64(&e.stats.TSMFullCompactionDuration),
+
+			statColdTierMoves:     atomic.LoadInt64(&e.stats.ColdTierMoves),
+			statColdTierMoveError: atomic.LoadInt64(&e.stats.ColdTierMoveErrors),
+
+			statWriteDuplicates: atomic.LoadInt64(&e.stats.WriteDuplicates),
+			statTSMDuplicates:   e.Compactor.Duplicates(),
 		},
 	})
 
@@ -549,6 +602,228 @@ func (e *Engine) IsIdle() bool {
 	return cacheEmpty && runningCompactions == 0 && e.CompactionPlan.FullyCompacted()
 }
 
+// Scrub verifies the index, block checksums and tombstones of every TSM file, reading
+// at no more than the rate allowed by rate.  It returns an error describing the first
+// corruption found, or nil if none was found before closing was closed.
+func (e *Engine) Scrub(rate *limiter.Rate, closing <-chan struct{}) error {
+	if err := e.FileStore.Verify(rate, closing); err != nil && err != errVerifyCanceled {
+		return err
+	}
+	return nil
+}
+
+// Repair rewrites every TSM file containing blocks that fail verification without those
+// blocks.  It returns an error if any file is still corrupt afterwards.  The engine's
+// compactions must be disabled.
+func (e *Engine) Repair(closing <-chan struct{}) error {
+	// The compactor rewrites the files even though level compactions are disabled.
+	e.Compactor.EnableCompactions()
+	defer e.Compactor.DisableCompactions()
+
+	for _, stat := range e.FileStore.Stats() {
+		select {
+		case <-closing:
+			return errVerifyCanceled
+		default:
+		}
+
+		if _, err := e.RepairFile(stat.Path); err != nil {
+			return fmt.Errorf("%s: %v", stat.Path, err)
+		}
+	}
+	return e.FileStore.Verify(nil, closing)
+}
+
+// RepairFile rewrites the TSM file at path without the blocks that fail verification and
+// swaps the repaired file in for it.  It returns the blocks that were dropped, which are
+// also recorded in the quarantine file for path.
+func (e *Engine) RepairFile(path string) ([]QuarantinedBlock, error) {
+	files, quarantined, err := e.Compactor.Repair(path)
+	if err != nil {
+		return nil, err
+	} else if len(quarantined) == 0 {
+		return nil, nil
+	}
+
+	if err := e.FileStore.Replace([]string{path}, files); err != nil {
+		for _, f := range files {
+			os.RemoveAll(f)
+		}
+		return nil, err
+	}
+
+	e.logger.Info(fmt.Sprintf("repaired %s into %d files, quarantined %d blocks", path, len(files), len(quarantined)))
+	return quarantined, nil
+}
+
+// Downsample rewrites the data of the engine at a resolution of interval, aggregating
+// the values of each numeric field with the functions in fns.  The aggregates are
+// stored as new fields, such as mean_value for the mean of the field value, and the
+// field itself holds the aggregate of the first function other than count.  Fields
+// that were downsampled before are left unchanged.
+func (e *Engine) Downsample(interval time.Duration, fns []string) error {
+	if err := e.WriteSnapshot(); err != nil {
+		return err
+	}
+
+	var paths []string
+	for _, stat := range e.FileStore.Stats() {
+		paths = append(paths, stat.Path)
+	}
+
+	if len(paths) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	files, err := e.Compactor.Downsample(paths, interval, fns)
+	if err != nil {
+		return err
+	}
+
+	if err := e.FileStore.Replace(paths, files); err != nil {
+		for _, f := range files {
+			os.RemoveAll(f)
+		}
+		return err
+	}
+
+	// Add the aggregate fields to the index.
+	if err := e.FileStore.WalkKeys(func(key []byte, typ byte) error {
+		fieldType, err := tsmFieldTypeToInfluxQLDataType(typ)
+		if err != nil {
+			return err
+		}
+		return e.addToIndexFromKey(key, fieldType, e.index)
+	}); err != nil {
+		return err
+	}
+
+	e.logger.Info(fmt.Sprintf("downsampled %d files in %s to %v into %d files in %v", len(paths), e.path, interval, len(files), time.Since(start)))
+	return nil
+}
+
+// Export writes a tar archive of new TSM files holding the values of the engine between
+// start and end, inclusive, to w.  The basePath will be prepended to the names of the
+// files in the archive, which can be added to another shard with Import.
+func (e *Engine) Export(w io.Writer, basePath string, start, end time.Time) error {
+	// Snapshots are disabled on idle shards but there is nothing cached to write then.
+	if e.Cache.Size() > 0 {
+		if err := e.WriteSnapshot(); err != nil {
+			return err
+		}
+	}
+
+	e.mu.RLock()
+	path, err := e.FileStore.CreateSnapshot()
+	e.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	// Remove the temporary snapshot dir
+	defer os.RemoveAll(path)
+
+	tw := tar.NewWriter(w)
+	defer tw.Close()
+
+	names, err := readDir(path, "")
+	if err != nil {
+		return err
+	}
+
+	var trs []*TSMReader
+	for _, name := range names {
+		if filepath.Ext(name) != "."+TSMFileExtension {
+			continue
+		}
+
+		f, err := os.Open(filepath.Join(path, name))
+		if err != nil {
+			return err
+		}
+
+		tr, err := NewTSMReader(f)
+		if err != nil {
+			return err
+		}
+		defer tr.Close()
+
+		if minTime, maxTime := tr.TimeRange(); maxTime < start.UnixNano() || minTime > end.UnixNano() {
+			continue
+		}
+		trs = append(trs, tr)
+	}
+	if len(trs) == 0 {
+		return nil
+	}
+
+	iter, err := NewTSMKeyIterator(tsdb.DefaultMaxPointsPerBlock, false, trs...)
+	if err != nil {
+		return err
+	}
+
+	files, err := e.Compactor.writeNewFiles(path, e.FileStore.NextGeneration(), 0, &timeRangeKeyIterator{
+		iter: iter,
+		min:  start.UnixNano(),
+		max:  end.UnixNano(),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		name := strings.TrimSuffix(filepath.Base(f), ".tmp")
+		if err := e.writeFileToBackup(name, basePath, f, tw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Trim rewrites the TSM files of the engine without the values outside of start and
+// end, inclusive.
+func (e *Engine) Trim(start, end time.Time) error {
+	if e.Cache.Size() > 0 {
+		if err := e.WriteSnapshot(); err != nil {
+			return err
+		}
+	}
+
+	// Stop level compactions so every file can be rewritten.
+	e.disableLevelCompactions(true)
+	defer e.enableLevelCompactions(true)
+
+	// Every file is rewritten, as for a full compaction, so the names of the new files
+	// can't conflict with files that are kept.
+	var paths []string
+	var trim bool
+	for _, stat := range e.FileStore.Stats() {
+		paths = append(paths, stat.Path)
+		trim = trim || stat.MinTime < start.UnixNano() || stat.MaxTime > end.UnixNano()
+	}
+
+	if !trim {
+		return nil
+	}
+
+	now := time.Now()
+	files, err := e.Compactor.CompactRange(paths, start.UnixNano(), end.UnixNano())
+	if err != nil {
+		return err
+	}
+
+	if err := e.FileStore.Replace(paths, files); err != nil {
+		for _, f := range files {
+			os.RemoveAll(f)
+		}
+		return err
+	}
+
+	e.logger.Info(fmt.Sprintf("trimmed %d files in %s to %v-%v in %v", len(paths), e.path, start, end, time.Since(now)))
+	return nil
+}
+
 // Backup writes a tar archive of any TSM files modified since the passed
 // in time to the passed in writer. The basePath will be prepended to the names
 // of the files in the archive. It will force a snapshot of the WAL first
@@ -802,6 +1077,16 @@ func (e *Engine) addToIndexFromKey(key []byte, fieldType influxql.DataType, inde
 // WritePoints writes metadata and point data into the engine.
 // It returns an error if new points are added to an existing key.
 func (e *Engine) WritePoints(points []models.Point) error {
+	if e.duplicates.enabled() {
+		e.duplicateMu.Lock()
+		defer e.duplicateMu.Unlock()
+	}
+
+	points, rejected, err := e.dropDuplicates(points)
+	if err != nil {
+		return err
+	}
+
 	values := make(map[string][]Value, len(points))
 	var keyBuf []byte
 	var baseLen int
@@ -832,6 +1117,12 @@ func (e *Engine) WritePoints(points []models.Point) error {
 					return err
 				}
 				v = NewIntegerValue(t, iv)
+			case models.Unsigned:
+				iv, err := iter.UnsignedValue()
+				if err != nil {
+					return err
+				}
+				v = NewUnsignedValue(t, iv)
 			case models.String:
 				v = NewStringValue(t, iter.StringValue())
 			case models.Boolean:
@@ -847,17 +1138,30 @@ func (e *Engine) WritePoints(points []models.Point) error {
 		}
 	}
 
+	// Wait for room in the cache before taking the lock since writing the snapshot
+	// that frees room needs it.
+	addedSize := valuesSize(values)
+	if err := e.Cache.waitForRoom(addedSize); err != nil {
+		atomic.AddInt64(&e.Cache.stats.WriteErr, 1)
+		return err
+	}
+
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 
 	// first try to write to the cache
-	err := e.Cache.WriteMulti(values)
-	if err != nil {
+	if err := e.Cache.writeMulti(values, addedSize); err != nil {
 		return err
 	}
 
-	_, err = e.WAL.WriteMulti(values)
-	return err
+	if _, err := e.WAL.WriteMulti(values); err != nil {
+		return err
+	}
+
+	if rejected > 0 {
+		return tsdb.PartialWriteError{Reason: "duplicate points rejected", Dropped: rejected}
+	}
+	return nil
 }
 
 // containsSeries returns a map of keys indicating whether the key exists and
@@ -1148,21 +1452,32 @@ func (e *Engine) compactCache(quit <-chan struct{}) {
 		case <-t.C:
 			e.Cache.UpdateAge()
 			if e.ShouldCompactCache(e.WAL.LastWriteTime()) {
-				start := time.Now()
-				e.traceLogger.Info(fmt.Sprintf("Compacting cache for %s", e.path))
-				err := e.WriteSnapshot()
-				if err != nil && err != errCompactionsDisabled {
-					e.logger.Info(fmt.Sprintf("error writing snapshot: %v", err))
-					atomic.AddInt64(&e.stats.CacheCompactionErrors, 1)
-				} else {
-					atomic.AddInt64(&e.stats.CacheCompactions, 1)
-				}
-				atomic.AddInt64(&e.stats.CacheCompactionDuration, time.Since(start).Nanoseconds())
+				e.compactCacheSnapshot()
+			}
+
+		case <-e.Cache.Pressure():
+			// Writes are waiting for room in the cache so don't wait for the next tick.
+			if e.Cache.Size() > 0 {
+				e.compactCacheSnapshot()
 			}
 		}
 	}
 }
 
+// compactCacheSnapshot writes a snapshot of the cache to a new TSM file.
+func (e *Engine) compactCacheSnapshot() {
+	start := time.Now()
+	e.traceLogger.Info(fmt.Sprintf("Compacting cache for %s", e.path))
+	err := e.WriteSnapshot()
+	if err != nil && err != errCompactionsDisabled {
+		e.logger.Info(fmt.Sprintf("error writing snapshot: %v", err))
+		atomic.AddInt64(&e.stats.CacheCompactionErrors, 1)
+	} else {
+		atomic.AddInt64(&e.stats.CacheCompactions, 1)
+	}
+	atomic.AddInt64(&e.stats.CacheCompactionDuration, time.Since(start).Nanoseconds())
+}
+
 // ShouldCompactCache returns true if the Cache is over its flush threshold
 // or if the passed in lastWriteTime is older than the write cold threshold.
 func (e *Engine) ShouldCompactCache(lastWriteTime time.Time) bool {
@@ -1186,6 +1501,11 @@ func (e *Engine) compactTSMLevel(fast bool, level int, quit <-chan struct{}) {
 			return
 
 		case <-t.C:
+			// Leave the disk to snapshots while writes are waiting for room in the cache.
+			if e.Cache.WritesWaiting() {
+				continue
+			}
+
 			s := e.levelCompactionStrategy(fast, level)
 			if s != nil {
 				// Release the files in the compaction plan
@@ -1208,17 +1528,58 @@ func (e *Engine) compactTSMFull(quit <-chan struct{}) {
 			return
 
 		case <-t.C:
+			if e.Cache.WritesWaiting() {
+				continue
+			}
+
 			s := e.fullCompactionStrategy()
 			if s != nil {
 				// Release the files in the compaction plan
 				defer e.CompactionPlan.Release(s.compactionGroups)
 				s.Apply()
+			} else {
+				e.moveToColdTier()
+				e.FileStore.Advise()
 			}
 
 		}
 	}
 }
 
+// moveToColdTier moves the TSM files of the shard to the cold tier once the shard
+// is fully compacted and the newest data in it is older than ColdAfter.
+func (e *Engine) moveToColdTier() {
+	if e.FileStore.ColdDir() == "" || e.ColdAfter <= 0 || !e.IsIdle() {
+		return
+	}
+
+	cutoff := time.Now().Add(-e.ColdAfter).UnixNano()
+
+	var paths []string
+	for _, stat := range e.FileStore.Stats() {
+		if stat.MaxTime >= cutoff {
+			return
+		}
+
+		if stat.Tier == HotTier {
+			paths = append(paths, stat.Path)
+		}
+	}
+
+	if len(paths) == 0 {
+		return
+	}
+
+	start := time.Now()
+	if err := e.FileStore.MoveToColdTier(paths); err != nil {
+		e.logger.Info(fmt.Sprintf("error moving %d TSM files to %s: %v", len(paths), e.FileStore.ColdDir(), err))
+		atomic.AddInt64(&e.stats.ColdTierMoveErrors, 1)
+		return
+	}
+	e.logger.Info(fmt.Sprintf("moved %d TSM files to %s in %v", len(paths), e.FileStore.ColdDir(), time.Since(start)))
+	atomic.AddInt64(&e.stats.ColdTierMoves, 1)
+}
+
 // compactionStrategy holds the details of what to do in a compaction.
 type compactionStrategy struct {
 	compactionGroups []CompactionGroup
@@ -1447,6 +1808,15 @@ func (e *Engine) cleanupTempTSMFiles() error {
 		return fmt.Errorf("error getting compaction temp files: %s", err.Error())
 	}
 
+	// Remove partial copies from moves to the cold tier as well
+	if dir := e.FileStore.ColdDir(); dir != "" {
+		coldFiles, err := filepath.Glob(filepath.Join(dir, fmt.Sprintf("*.%s", CompactionTempExtension)))
+		if err != nil {
+			return fmt.Errorf("error getting cold tier temp files: %s", err.Error())
+		}
+		files = append(files, coldFiles...)
+	}
+
 	for _, f := range files {
 		if err := os.Remove(f); err != nil {
 			return fmt.Errorf("error removing temp compaction files: %v", err)
@@ -1540,10 +1910,17 @@ func (e *Engine) createCallIterator(measurement string, call *influxql.Call, opt
 
 			// Wrap each series in a call iterator.
 			for i, input := range inputs {
+				_, aggregated := input.(blockStatsIterator)
 				if opt.InterruptCh != nil {
 					input = influxql.NewInterruptIterator(input, opt.InterruptCh)
 				}
 
+				// Series read from block statistics are already aggregated.
+				if aggregated {
+					inputs[i] = input
+					continue
+				}
+
 				itr, err := influxql.NewCallIterator(input, opt)
 				if err != nil {
 					return err
@@ -1724,7 +2101,15 @@ func (e *Engine) createTagSetGroupIterators(ref *influxql.VarRef, name string, s
 			}
 		}
 
-		itr, err := e.createVarRefSeriesIterator(ref, name, seriesKey, t, filters[i], conditionFields[:fields], opt)
+		// Aggregates of series without a filter may be read from block statistics.
+		var itr influxql.Iterator
+		var err error
+		if call, ok := opt.Expr.(*influxql.Call); ok && filters[i] == nil {
+			itr, err = e.createBlockStatsSeriesIterator(call, ref, name, seriesKey, opt)
+		}
+		if itr == nil && err == nil {
+			itr, err = e.createVarRefSeriesIterator(ref, name, seriesKey, t, filters[i], conditionFields[:fields], opt)
+		}
 		if err != nil {
 			return itrs, err
 		} else if itr == nil {
@@ -1781,6 +2166,9 @@ func (e *Engine) createVarRefSeriesIterator(ref *influxql.VarRef, name string, s
 				case influxql.Integer:
 					aux[i] = &integerNilLiteralCursor{}
 					continue
+				case influxql.Unsigned:
+					aux[i] = &unsignedNilLiteralCursor{}
+					continue
 				case influxql.String:
 					aux[i] = &stringNilLiteralCursor{}
 					continue
@@ -1822,6 +2210,9 @@ func (e *Engine) createVarRefSeriesIterator(ref *influxql.VarRef, name string, s
 				case influxql.Integer:
 					conds[i] = &integerNilLiteralCursor{}
 					continue
+				case influxql.Unsigned:
+					conds[i] = &unsignedNilLiteralCursor{}
+					continue
 				case influxql.String:
 					conds[i] = &stringNilLiteralCursor{}
 					continue
@@ -1864,6 +2255,8 @@ func (e *Engine) createVarRefSeriesIterator(ref *influxql.VarRef, name string, s
 		return newFloatIterator(name, tags, itrOpt, cur, aux, conds, condNames), nil
 	case integerCursor:
 		return newIntegerIterator(name, tags, itrOpt, cur, aux, conds, condNames), nil
+	case unsignedCursor:
+		return newUnsignedIterator(name, tags, itrOpt, cur, aux, conds, condNames), nil
 	case stringCursor:
 		return newStringIterator(name, tags, itrOpt, cur, aux, conds, condNames), nil
 	case booleanCursor:
@@ -1896,12 +2289,27 @@ func (e *Engine) buildCursor(measurement, seriesKey string, ref *influxql.VarRef
 			case influxql.Integer:
 				cur := e.buildIntegerCursor(measurement, seriesKey, ref.Val, opt)
 				return &floatCastIntegerCursor{cursor: cur}
+			case influxql.Unsigned:
+				cur := e.buildUnsignedCursor(measurement, seriesKey, ref.Val, opt)
+				return &floatCastUnsignedCursor{cursor: cur}
 			}
 		case influxql.Integer:
 			switch f.Type {
 			case influxql.Float:
 				cur := e.buildFloatCursor(measurement, seriesKey, ref.Val, opt)
 				return &integerCastFloatCursor{cursor: cur}
+			case influxql.Unsigned:
+				cur := e.buildUnsignedCursor(measurement, seriesKey, ref.Val, opt)
+				return &integerCastUnsignedCursor{cursor: cur}
+			}
+		case influxql.Unsigned:
+			switch f.Type {
+			case influxql.Float:
+				cur := e.buildFloatCursor(measurement, seriesKey, ref.Val, opt)
+				return &unsignedCastFloatCursor{cursor: cur}
+			case influxql.Integer:
+				cur := e.buildIntegerCursor(measurement, seriesKey, ref.Val, opt)
+				return &unsignedCastIntegerCursor{cursor: cur}
 			}
 		}
 		return nil
@@ -1913,6 +2321,8 @@ func (e *Engine) buildCursor(measurement, seriesKey string, ref *influxql.VarRef
 		return e.buildFloatCursor(measurement, seriesKey, ref.Val, opt)
 	case influxql.Integer:
 		return e.buildIntegerCursor(measurement, seriesKey, ref.Val, opt)
+	case influxql.Unsigned:
+		return e.buildUnsignedCursor(measurement, seriesKey, ref.Val, opt)
 	case influxql.String:
 		return e.buildStringCursor(measurement, seriesKey, ref.Val, opt)
 	case influxql.Boolean:
@@ -1936,6 +2346,13 @@ func (e *Engine) buildIntegerCursor(measurement, seriesKey, field string, opt in
 	return newIntegerCursor(opt.SeekTime(), opt.Ascending, cacheValues, keyCursor)
 }
 
+// buildUnsignedCursor creates a cursor for an unsigned field.
+func (e *Engine) buildUnsignedCursor(measurement, seriesKey, field string, opt influxql.IteratorOptions) unsignedCursor {
+	cacheValues := e.Cache.Values(SeriesFieldKey(seriesKey, field))
+	keyCursor := e.KeyCursor(SeriesFieldKey(seriesKey, field), opt.SeekTime(), opt.Ascending)
+	return newUnsignedCursor(opt.SeekTime(), opt.Ascending, cacheValues, keyCursor)
+}
+
 // buildStringCursor creates a cursor for a string field.
 func (e *Engine) buildStringCursor(measurement, seriesKey, field string, opt influxql.IteratorOptions) stringCursor {
 	cacheValues := e.Cache.Values(SeriesFieldKey(seriesKey, field))
@@ -1954,6 +2371,66 @@ func (e *Engine) SeriesPointIterator(opt influxql.IteratorOptions) (influxql.Ite
 	return e.index.SeriesPointIterator(opt)
 }
 
+// HasSeriesValues returns true if the series has values between min and max, inclusive.
+// Values in TSM files are found from the time ranges of their blocks in the index, so a
+// block that overlaps the range counts even if none of its values fall within it.
+func (e *Engine) HasSeriesValues(key []byte, min, max int64) (bool, error) {
+	name, err := models.ParseName(key)
+	if err != nil {
+		return false, err
+	}
+
+	mf := e.fieldset.Fields(string(name))
+	if mf == nil {
+		return false, nil
+	}
+
+	for field := range mf.FieldSet() {
+		k := SeriesFieldKey(string(key), field)
+		for _, v := range e.Cache.Values(k) {
+			if t := v.UnixNano(); t > max {
+				break
+			} else if t >= min {
+				return true, nil
+			}
+		}
+
+		if e.FileStore.OverlapsTimeRange(k, min, max) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// SeriesLastWrite returns the time of the last value written to any field of the
+// series, from the cache and the TSM files.  It returns false if the series has no
+// values.
+func (e *Engine) SeriesLastWrite(key []byte) (int64, bool, error) {
+	name, err := models.ParseName(key)
+	if err != nil {
+		return 0, false, err
+	}
+
+	mf := e.fieldset.Fields(string(name))
+	if mf == nil {
+		return 0, false, nil
+	}
+
+	var max int64
+	var found bool
+	for field := range mf.FieldSet() {
+		k := SeriesFieldKey(string(key), field)
+		if t, ok := e.Cache.MaxTime(k); ok && (!found || t > max) {
+			max, found = t, true
+		}
+
+		if t, ok := e.FileStore.MaxTime(k); ok && (!found || t > max) {
+			max, found = t, true
+		}
+	}
+	return max, found, nil
+}
+
 // SeriesFieldKey combine a series key and field name for a unique string to be hashed to a numeric ID.
 func SeriesFieldKey(seriesKey, field string) string {
 	return seriesKey + keyFieldSeparator + field
@@ -1965,6 +2442,8 @@ func tsmFieldTypeToInfluxQLDataType(typ byte) (influxql.DataType, error) {
 		return influxql.Float, nil
 	case BlockInteger:
 		return influxql.Integer, nil
+	case BlockUnsigned:
+		return influxql.Unsigned, nil
 	case BlockBoolean:
 		return influxql.Boolean, nil
 	case BlockString:
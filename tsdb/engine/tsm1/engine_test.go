@@ -20,6 +20,7 @@ import (
 	"github.com/influxdata/influxdb/influxql"
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/pkg/deep"
+	"github.com/influxdata/influxdb/toml"
 	"github.com/influxdata/influxdb/tsdb"
 	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
 	"github.com/influxdata/influxdb/tsdb/index/inmem"
@@ -405,6 +406,53 @@ func TestEngine_CreateIterator_TSM_Ascending(t *testing.T) {
 	}
 }
 
+// Ensure engine can create an iterator for unsigned values written to TSM.
+func TestEngine_CreateIterator_TSM_Unsigned(t *testing.T) {
+	t.Parallel()
+
+	e := MustOpenEngine()
+	defer e.Close()
+
+	e.MeasurementFields([]byte("cpu")).CreateFieldIfNotExists([]byte("value"), influxql.Unsigned, false)
+	e.CreateSeriesIfNotExists([]byte("cpu,host=A"), []byte("cpu"), models.NewTags(map[string]string{"host": "A"}))
+
+	if err := e.WritePointsString(
+		`cpu,host=A value=1u 1000000000`,
+		`cpu,host=A value=18446744073709551615u 2000000000`,
+	); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+	e.MustWriteSnapshot()
+
+	itr, err := e.CreateIterator("cpu", influxql.IteratorOptions{
+		Expr:       influxql.MustParseExpr(`value`),
+		Dimensions: []string{"host"},
+		StartTime:  influxql.MinTime,
+		EndTime:    influxql.MaxTime,
+		Ascending:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	uitr := itr.(influxql.UnsignedIterator)
+
+	if p, err := uitr.Next(); err != nil {
+		t.Fatalf("unexpected error(0): %v", err)
+	} else if !reflect.DeepEqual(p, &influxql.UnsignedPoint{Name: "cpu", Tags: ParseTags("host=A"), Time: 1000000000, Value: 1}) {
+		t.Fatalf("unexpected point(0): %v", p)
+	}
+	if p, err := uitr.Next(); err != nil {
+		t.Fatalf("unexpected error(1): %v", err)
+	} else if !reflect.DeepEqual(p, &influxql.UnsignedPoint{Name: "cpu", Tags: ParseTags("host=A"), Time: 2000000000, Value: math.MaxUint64}) {
+		t.Fatalf("unexpected point(1): %v", p)
+	}
+	if p, err := uitr.Next(); err != nil {
+		t.Fatalf("expected eof, got error: %v", err)
+	} else if p != nil {
+		t.Fatalf("expected eof: %v", p)
+	}
+}
+
 // Ensure engine can create an descending iterator for cached values.
 func TestEngine_CreateIterator_TSM_Descending(t *testing.T) {
 	t.Parallel()
@@ -458,6 +506,87 @@ func TestEngine_CreateIterator_TSM_Descending(t *testing.T) {
 	}
 }
 
+// Ensure engine can answer aggregates using the statistics of TSM blocks, the cache
+// and overlapping blocks together.
+func TestEngine_CreateIterator_BlockStats(t *testing.T) {
+	t.Parallel()
+
+	e := MustOpenEngine()
+	defer e.Close()
+	e.Compactor.BlockStats = true
+
+	e.MeasurementFields([]byte("cpu")).CreateFieldIfNotExists([]byte("value"), influxql.Float, false)
+	e.CreateSeriesIfNotExists([]byte("cpu,host=A"), []byte("cpu"), models.NewTags(map[string]string{"host": "A"}))
+
+	for _, points := range [][]string{
+		{`cpu,host=A value=1 1000000000`, `cpu,host=A value=3 2000000000`},
+		{`cpu,host=A value=5 11000000000`, `cpu,host=A value=7 12000000000`},
+		{`cpu,host=A value=1 25000000000`},
+		{`cpu,host=A value=2 25000000000`, `cpu,host=A value=4 26000000000`},
+	} {
+		if err := e.WritePointsString(points...); err != nil {
+			t.Fatalf("failed to write points: %s", err.Error())
+		}
+		e.MustWriteSnapshot()
+	}
+
+	// Leave one point in the cache.
+	if err := e.WritePointsString(`cpu,host=A value=9 13000000000`); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	opt := influxql.IteratorOptions{
+		Expr:       influxql.MustParseExpr(`count(value)`),
+		Dimensions: []string{"host"},
+		Interval:   influxql.Interval{Duration: 10 * time.Second},
+		StartTime:  0,
+		EndTime:    60*int64(time.Second) - 1,
+		Ascending:  true,
+	}
+
+	itr, err := e.CreateIterator("cpu", opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iitr := itr.(influxql.IntegerIterator)
+	for i, exp := range []struct{ time, value int64 }{{0, 2}, {10000000000, 3}, {20000000000, 2}} {
+		if p, err := iitr.Next(); err != nil {
+			t.Fatalf("unexpected error(%d): %v", i, err)
+		} else if p == nil || p.Time != exp.time || p.Value != exp.value {
+			t.Fatalf("unexpected point(%d): %v", i, p)
+		}
+	}
+	if p, err := iitr.Next(); err != nil {
+		t.Fatalf("expected eof, got error: %v", err)
+	} else if p != nil {
+		t.Fatalf("expected eof: %v", p)
+	}
+	itr.Close()
+
+	opt.Expr = influxql.MustParseExpr(`mean(value)`)
+	itr, err = e.CreateIterator("cpu", opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fitr := itr.(influxql.FloatIterator)
+	for i, exp := range []struct {
+		time  int64
+		value float64
+	}{{0, 2}, {10000000000, 7}, {20000000000, 3}} {
+		if p, err := fitr.Next(); err != nil {
+			t.Fatalf("unexpected error(%d): %v", i, err)
+		} else if p == nil || p.Time != exp.time || p.Value != exp.value {
+			t.Fatalf("unexpected point(%d): %v", i, p)
+		}
+	}
+	if p, err := fitr.Next(); err != nil {
+		t.Fatalf("expected eof, got error: %v", err)
+	} else if p != nil {
+		t.Fatalf("expected eof: %v", p)
+	}
+	itr.Close()
+}
+
 // Ensure engine can create an iterator with auxilary fields.
 func TestEngine_CreateIterator_Aux(t *testing.T) {
 	t.Parallel()
@@ -631,6 +760,76 @@ func TestEngine_DeleteSeries(t *testing.T) {
 
 }
 
+// Ensure writes waiting for room in a full cache don't block the snapshots that
+// free the room.
+func TestEngine_WritePoints_CacheFull(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tsm1-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	opt := tsdb.NewEngineOptions()
+	opt.InmemIndex = inmem.NewIndex()
+	opt.Config.CacheMaxMemorySize = 4096
+	opt.Config.CacheMaxWriteWait = toml.Duration(10 * time.Second)
+
+	idx := tsdb.MustOpenIndex(1, filepath.Join(dir, "data", "index"), opt)
+	defer idx.Close()
+
+	e := tsm1.NewEngine(1, idx, filepath.Join(dir, "data"), filepath.Join(dir, "wal"), opt).(*tsm1.Engine)
+	if err := e.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	done := make(chan struct{})
+	defer func() { <-done }()
+
+	// Write snapshots alongside the snapshots requested by waiting writes.
+	closing := make(chan struct{})
+	defer close(closing)
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-closing:
+				return
+			case <-time.After(time.Millisecond):
+				e.WriteSnapshot()
+			}
+		}
+	}()
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	errC := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				var buf bytes.Buffer
+				for k := 0; k < 10; k++ {
+					fmt.Fprintf(&buf, "cpu,host=%d value=%d %d\n", i, k, j*10+k)
+				}
+				if err := e.WritePoints(MustParsePointsString(buf.String())); err != nil {
+					errC <- err
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errC)
+
+	if err := <-errC; err != nil {
+		t.Fatalf("unexpected error writing points: %v", err)
+	} else if d := time.Since(start); d >= 10*time.Second {
+		t.Fatalf("writes blocked until the max write wait: %v", d)
+	}
+}
+
 func TestEngine_LastModified(t *testing.T) {
 	// Generate temporary file.
 	dir, _ := ioutil.TempDir("", "tsm")
@@ -693,6 +892,124 @@ func TestEngine_LastModified(t *testing.T) {
 	}
 }
 
+func TestEngine_WritePoints_DuplicatePolicy(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "tsm")
+	walPath := filepath.Join(dir, "wal")
+	os.MkdirAll(walPath, 0777)
+	defer os.RemoveAll(dir)
+
+	opt := tsdb.NewEngineOptions()
+	opt.InmemIndex = inmem.NewIndex()
+	opt.DuplicatePolicy = tsm1.DuplicateFirst
+	opt.MeasurementDuplicatePolicies = map[string]string{"mem": tsm1.DuplicateReject}
+	idx := tsdb.MustOpenIndex(1, filepath.Join(dir, "index"), opt)
+	defer idx.Close()
+
+	e := tsm1.NewEngine(1, idx, dir, walPath, opt).(*tsm1.Engine)
+	e.CompactionPlan = &mockPlanner{}
+	if err := e.Open(); err != nil {
+		t.Fatalf("failed to open tsm1 engine: %s", err.Error())
+	}
+	defer e.Close()
+
+	for _, name := range []string{"cpu", "mem"} {
+		e.MeasurementFields([]byte(name)).CreateFieldIfNotExists([]byte("value"), influxql.Float, false)
+		e.MeasurementFields([]byte(name)).CreateFieldIfNotExists([]byte("idle"), influxql.Float, false)
+	}
+
+	// The first point of each measurement is written to a TSM file.
+	points := MustParsePointsString("cpu,host=A value=1 1000000000\nmem,host=A value=1 1000000000")
+	if err := e.WritePoints(points); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	} else if err := e.WriteSnapshot(); err != nil {
+		t.Fatalf("failed to snapshot: %s", err.Error())
+	}
+
+	// Points duplicating a point in a file, in the cache or earlier in the batch are
+	// dropped, even if they write other fields.
+	points = MustParsePointsString(strings.Join([]string{
+		"cpu,host=A idle=2 1000000000",
+		"cpu,host=A value=3 2000000000",
+		"cpu,host=A value=4 2000000000",
+	}, "\n"))
+	if err := e.WritePoints(points); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+	points = MustParsePointsString("cpu,host=A value=5 2000000000\ncpu,host=A value=6 3000000000")
+	if err := e.WritePoints(points); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	if values := e.Cache.Values(tsm1.SeriesFieldKey("cpu,host=A", "value")); len(values) != 2 || values[0].Value() != 3.0 || values[1].Value() != 6.0 {
+		t.Fatalf("unexpected cpu values: %v", values)
+	} else if values := e.Cache.Values(tsm1.SeriesFieldKey("cpu,host=A", "idle")); len(values) != 0 {
+		t.Fatalf("unexpected cpu idle values: %v", values)
+	}
+
+	// Duplicates of a measurement with the reject policy return a partial write error.
+	points = MustParsePointsString("mem,host=A value=2 1000000000\nmem,host=A value=3 2000000000")
+	if err, ok := e.WritePoints(points).(tsdb.PartialWriteError); !ok || err.Dropped != 1 {
+		t.Fatalf("unexpected error: %v", err)
+	} else if values := e.Cache.Values(tsm1.SeriesFieldKey("mem,host=A", "value")); len(values) != 1 || values[0].Value() != 3.0 {
+		t.Fatalf("unexpected mem values: %v", values)
+	}
+}
+
+// Ensure points written concurrently are checked for duplicates against each other.
+func TestEngine_WritePoints_DuplicatePolicy_Concurrent(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "tsm")
+	walPath := filepath.Join(dir, "wal")
+	os.MkdirAll(walPath, 0777)
+	defer os.RemoveAll(dir)
+
+	opt := tsdb.NewEngineOptions()
+	opt.InmemIndex = inmem.NewIndex()
+	opt.DuplicatePolicy = tsm1.DuplicateReject
+	idx := tsdb.MustOpenIndex(1, filepath.Join(dir, "index"), opt)
+	defer idx.Close()
+
+	e := tsm1.NewEngine(1, idx, dir, walPath, opt).(*tsm1.Engine)
+	e.CompactionPlan = &mockPlanner{}
+	if err := e.Open(); err != nil {
+		t.Fatalf("failed to open tsm1 engine: %s", err.Error())
+	}
+	defer e.Close()
+	e.MeasurementFields([]byte("cpu")).CreateFieldIfNotExists([]byte("value"), influxql.Float, false)
+
+	// Every writer writes the same points, so each point is only written once.
+	const writerN, pointN = 8, 1000
+	var lines []string
+	for i := 0; i < pointN; i++ {
+		lines = append(lines, fmt.Sprintf("cpu,host=A value=%d %d", i, i+1))
+	}
+
+	errs := make(chan error, writerN)
+	var wg sync.WaitGroup
+	for i := 0; i < writerN; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- e.WritePoints(MustParsePointsString(strings.Join(lines, "\n")))
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var rejected int
+	for err := range errs {
+		if err == nil {
+			continue
+		} else if err, ok := err.(tsdb.PartialWriteError); !ok {
+			t.Fatalf("unexpected error: %v", err)
+		} else {
+			rejected += err.Dropped
+		}
+	}
+	if exp := (writerN - 1) * pointN; rejected != exp {
+		t.Fatalf("got %d points rejected, expected %d", rejected, exp)
+	}
+}
+
 func BenchmarkEngine_CreateIterator_Count_1K(b *testing.B) {
 	benchmarkEngineCreateIteratorCount(b, 1000)
 }
@@ -2,7 +2,9 @@ package tsm1
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math"
 	"os"
@@ -15,6 +17,7 @@ import (
 	"time"
 
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/limiter"
 	"github.com/uber-go/zap"
 )
 
@@ -31,6 +34,7 @@ type TSMFile interface {
 	ReadAt(entry *IndexEntry, values []Value) ([]Value, error)
 	ReadFloatBlockAt(entry *IndexEntry, values *[]FloatValue) ([]FloatValue, error)
 	ReadIntegerBlockAt(entry *IndexEntry, values *[]IntegerValue) ([]IntegerValue, error)
+	ReadUnsignedBlockAt(entry *IndexEntry, values *[]UnsignedValue) ([]UnsignedValue, error)
 	ReadStringBlockAt(entry *IndexEntry, values *[]StringValue) ([]StringValue, error)
 	ReadBooleanBlockAt(entry *IndexEntry, values *[]BooleanValue) ([]BooleanValue, error)
 
@@ -52,6 +56,10 @@ type TSMFile interface {
 	// TombstoneRange returns ranges of time that are deleted for the given key.
 	TombstoneRange(key string) []TimeRange
 
+	// BlockStats returns the statistics of the values of the block for entry, if
+	// the file stores them.
+	BlockStats(entry *IndexEntry) (BlockStats, bool)
+
 	// KeyRange returns the min and max keys in the file.
 	KeyRange() (string, string)
 
@@ -107,14 +115,66 @@ type TSMFile interface {
 	// BlockIterator returns an iterator pointing to the first block in the file and
 	// allows sequential iteration to each and every block.
 	BlockIterator() *BlockIterator
+
+	// Verify checks the index, block checksums and tombstones of the file, reading
+	// blocks at no more than the rate allowed by rate.
+	Verify(rate *limiter.Rate, closing <-chan struct{}) error
 }
 
 // Statistics gathered by the FileStore.
 const (
-	statFileStoreBytes = "diskBytes"
-	statFileStoreCount = "numFiles"
+	statFileStoreBytes     = "diskBytes"
+	statFileStoreCount     = "numFiles"
+	statFileStoreColdBytes = "coldDiskBytes"
+	statFileStoreColdCount = "numColdFiles"
 )
 
+// Access policies for the pages of memory mapped TSM files.
+const (
+	// AccessPolicyKernel leaves the residency of pages entirely to the kernel.
+	AccessPolicyKernel = "kernel"
+
+	// AccessPolicyAge advises the kernel based on the age of each file.  The files of
+	// the newest generation are prefetched and cold files are read randomly so large
+	// scans of old data don't evict the pages of recent files.
+	AccessPolicyAge = "age"
+)
+
+// Readers used to access the blocks of cold TSM files.
+const (
+	// ColdReaderMmap reads cold files through a memory map like every other file.
+	ColdReaderMmap = "mmap"
+
+	// ColdReaderPread reads cold files with pread, bypassing the memory map.
+	ColdReaderPread = "pread"
+)
+
+// ErrColdTierDisabled is returned when moving files to the cold tier of a FileStore
+// without a cold tier directory.
+var ErrColdTierDisabled = errors.New("cold tier not configured")
+
+// Tier identifies the storage tier a TSM file is stored in.
+type Tier int
+
+const (
+	// HotTier is the directory of the FileStore.
+	HotTier Tier = iota
+
+	// ColdTier is the directory TSM files of old shards are moved to.
+	ColdTier
+)
+
+// String returns the name of the tier.
+func (t Tier) String() string {
+	switch t {
+	case HotTier:
+		return "hot"
+	case ColdTier:
+		return "cold"
+	}
+	return "unknown"
+}
+
 // FileStore is an abstraction around multiple TSM files.
 type FileStore struct {
 	mu           sync.RWMutex
@@ -125,6 +185,14 @@ type FileStore struct {
 
 	currentGeneration int
 	dir               string
+	coldDir           string
+
+	// accessPolicy is the policy used to advise the kernel about the pages of files.
+	// Files in the cold tier, or not modified for coldAge, are cold.  Cold files are
+	// opened with pread when preadCold is set.
+	accessPolicy string
+	coldAge      time.Duration
+	preadCold    bool
 
 	files []TSMFile
 
@@ -136,11 +204,16 @@ type FileStore struct {
 	purger *purger
 
 	currentTempDirID int
+
+	// deleteMu is held for reading while tombstones are written and for writing
+	// while files are moved to the cold tier, so no tombstone is lost in the move.
+	deleteMu sync.RWMutex
 }
 
 // FileStat holds information about a TSM file on disk.
 type FileStat struct {
 	Path             string
+	Tier             Tier
 	HasTombstone     bool
 	Size             uint32
 	LastModified     int64
@@ -181,6 +254,102 @@ func NewFileStore(dir string) *FileStore {
 	return fs
 }
 
+// SetColdDir sets the directory TSM files are moved to by MoveToColdTier.  Files in
+// dir are loaded along with the files in the FileStore directory when the FileStore
+// is opened.  SetColdDir must be called before the FileStore is opened.
+func (f *FileStore) SetColdDir(dir string) {
+	f.coldDir = dir
+}
+
+// ColdDir returns the cold tier directory of the FileStore.
+func (f *FileStore) ColdDir() string {
+	return f.coldDir
+}
+
+// SetAccessPolicy sets the policy used to manage the pages of TSM files, the age
+// after which files are cold and the reader used for cold files.  Files only
+// switch to the pread reader when they are opened.  SetAccessPolicy must be called
+// before the FileStore is opened.
+func (f *FileStore) SetAccessPolicy(policy string, coldAge time.Duration, coldReader string) {
+	f.accessPolicy = policy
+	f.coldAge = coldAge
+	f.preadCold = coldReader == ColdReaderPread
+}
+
+// isCold returns true if the TSM file at path, last modified at lastModified, is cold.
+func (f *FileStore) isCold(path string, lastModified int64) bool {
+	if f.tier(path) == ColdTier {
+		return true
+	}
+	return f.coldAge > 0 && time.Since(time.Unix(0, lastModified)) > f.coldAge
+}
+
+// openTSMReader returns a reader for file, using pread if the file is cold and
+// cold files are read with pread.
+func (f *FileStore) openTSMReader(file *os.File) (*TSMReader, error) {
+	var pread bool
+	if f.preadCold {
+		stat, err := file.Stat()
+		if err != nil {
+			return nil, err
+		}
+		pread = f.isCold(file.Name(), stat.ModTime().UnixNano())
+	}
+	return NewTSMReader(file, withPread(pread))
+}
+
+// Advise applies the access policy to the pages of the TSM files.  It must be
+// called periodically for files to be advised as they become cold.
+func (f *FileStore) Advise() {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	f.advise()
+}
+
+// advise applies the access policy to the pages of the TSM files.  The lock must
+// be held by the caller.
+func (f *FileStore) advise() {
+	if f.accessPolicy != AccessPolicyAge {
+		return
+	}
+
+	var newest int
+	for _, file := range f.files {
+		if generation, _, err := ParseTSMFileName(file.Path()); err == nil && generation > newest {
+			newest = generation
+		}
+	}
+
+	for _, file := range f.files {
+		r, ok := file.(interface {
+			LastModified() int64
+			advise(advice int) error
+		})
+		if !ok {
+			continue
+		}
+
+		advice := madvNormal
+		if generation, _, err := ParseTSMFileName(file.Path()); err == nil && generation == newest {
+			advice = madvWillNeed
+		} else if f.isCold(file.Path(), r.LastModified()) {
+			advice = madvRandom
+		}
+
+		if err := r.advise(advice); err != nil {
+			f.logger.Info(fmt.Sprintf("error advising access to %s: %v", file.Path(), err))
+		}
+	}
+}
+
+// tier returns the tier the TSM file at path is stored in.
+func (f *FileStore) tier(path string) Tier {
+	if f.coldDir != "" && filepath.Dir(path) == filepath.Clean(f.coldDir) {
+		return ColdTier
+	}
+	return HotTier
+}
+
 // enableTraceLogging must be called before the FileStore is opened.
 func (f *FileStore) enableTraceLogging(enabled bool) {
 	f.traceLogging = enabled
@@ -201,8 +370,10 @@ func (f *FileStore) WithLogger(log zap.Logger) {
 
 // FileStoreStatistics keeps statistics about the file store.
 type FileStoreStatistics struct {
-	DiskBytes int64
-	FileCount int64
+	DiskBytes     int64
+	FileCount     int64
+	ColdDiskBytes int64
+	ColdFileCount int64
 }
 
 // Statistics returns statistics for periodic monitoring.
@@ -211,8 +382,10 @@ func (f *FileStore) Statistics(tags map[string]string) []models.Statistic {
 		Name: "tsm1_filestore",
 		Tags: tags,
 		Values: map[string]interface{}{
-			statFileStoreBytes: atomic.LoadInt64(&f.stats.DiskBytes),
-			statFileStoreCount: atomic.LoadInt64(&f.stats.FileCount),
+			statFileStoreBytes:     atomic.LoadInt64(&f.stats.DiskBytes),
+			statFileStoreCount:     atomic.LoadInt64(&f.stats.FileCount),
+			statFileStoreColdBytes: atomic.LoadInt64(&f.stats.ColdDiskBytes),
+			statFileStoreColdCount: atomic.LoadInt64(&f.stats.ColdFileCount),
 		},
 	}}
 }
@@ -231,6 +404,31 @@ func (f *FileStore) Files() []TSMFile {
 	return f.files
 }
 
+// Verify verifies every TSM file currently loaded, sharing rate between them.  It returns
+// an error describing the first problem found.
+func (f *FileStore) Verify(rate *limiter.Rate, closing <-chan struct{}) error {
+	f.mu.RLock()
+	files := make([]TSMFile, len(f.files))
+	copy(files, f.files)
+	for _, file := range files {
+		file.Ref()
+	}
+	f.mu.RUnlock()
+
+	defer func() {
+		for _, file := range files {
+			file.Unref()
+		}
+	}()
+
+	for _, file := range files {
+		if err := file.Verify(rate, closing); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // CurrentGeneration returns the current generation of the TSM files.
 func (f *FileStore) CurrentGeneration() int {
 	f.mu.RLock()
@@ -317,6 +515,9 @@ func (f *FileStore) Delete(keys []string) error {
 
 // DeleteRange removes the values for keys between timestamps min and max.
 func (f *FileStore) DeleteRange(keys []string, min, max int64) error {
+	f.deleteMu.RLock()
+	defer f.deleteMu.RUnlock()
+
 	if err := f.walkFiles(func(tsm TSMFile) error {
 		return tsm.DeleteRange(keys, min, max)
 	}); err != nil {
@@ -363,6 +564,15 @@ func (f *FileStore) Open() error {
 		return err
 	}
 
+	// Load the files previously moved to the cold tier
+	if f.coldDir != "" {
+		coldFiles, err := filepath.Glob(filepath.Join(f.coldDir, fmt.Sprintf("*.%s", TSMFileExtension)))
+		if err != nil {
+			return err
+		}
+		files = append(files, coldFiles...)
+	}
+
 	// struct to hold the result of opening each reader in a goroutine
 	type res struct {
 		r   *TSMReader
@@ -388,7 +598,7 @@ func (f *FileStore) Open() error {
 
 		go func(idx int, file *os.File) {
 			start := time.Now()
-			df, err := NewTSMReader(file)
+			df, err := f.openTSMReader(file)
 			f.logger.Info(fmt.Sprintf("%s (#%d) opened in %v", file.Name(), idx, time.Since(start)))
 
 			if err != nil {
@@ -408,9 +618,14 @@ func (f *FileStore) Open() error {
 		}
 		f.files = append(f.files, res.r)
 		// Accumulate file store size stats
-		atomic.AddInt64(&f.stats.DiskBytes, int64(res.r.Size()))
+		size := int64(res.r.Size())
 		for _, ts := range res.r.TombstoneFiles() {
-			atomic.AddInt64(&f.stats.DiskBytes, int64(ts.Size))
+			size += int64(ts.Size)
+		}
+		atomic.AddInt64(&f.stats.DiskBytes, size)
+		if f.tier(res.r.Path()) == ColdTier {
+			atomic.AddInt64(&f.stats.ColdDiskBytes, size)
+			atomic.AddInt64(&f.stats.ColdFileCount, 1)
 		}
 
 		// Re-initialize the lastModified time for the file store
@@ -424,6 +639,7 @@ func (f *FileStore) Open() error {
 
 	sort.Sort(tsmReaders(f.files))
 	atomic.StoreInt64(&f.stats.FileCount, int64(len(f.files)))
+	f.advise()
 	return nil
 }
 
@@ -439,6 +655,7 @@ func (f *FileStore) Close() error {
 	f.lastFileStats = nil
 	f.files = nil
 	atomic.StoreInt64(&f.stats.FileCount, 0)
+	atomic.StoreInt64(&f.stats.ColdFileCount, 0)
 	return nil
 }
 
@@ -471,6 +688,145 @@ func (f *FileStore) Read(key string, t int64) ([]Value, error) {
 	return nil, nil
 }
 
+// ContainsValue returns true if any file holds a value for key at time t that has not
+// been deleted.  The times of the blocks decoded are kept in blocks, if not nil, so
+// that looking up other times of the same blocks doesn't decode them again.
+func (f *FileStore) ContainsValue(key string, t int64, blocks decodedBlocks) (bool, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	var entries []IndexEntry
+	for _, file := range f.files {
+		// The index is checked first so that only blocks covering t are decoded.
+		if !file.ContainsValue(key, t) {
+			continue
+		}
+
+		file.ReadEntries(key, &entries)
+		for i := range entries {
+			entry := &entries[i]
+			if !entry.Contains(t) {
+				continue
+			}
+
+			k := decodedBlockKey{file: file, offset: entry.Offset}
+			times, ok := blocks[k]
+			if !ok {
+				values, err := file.ReadAt(entry, nil)
+				if err != nil {
+					return false, err
+				}
+				times = make([]int64, len(values))
+				for j, v := range values {
+					times[j] = v.UnixNano()
+				}
+				if blocks != nil {
+					blocks[k] = times
+				}
+			}
+
+			if j := sort.Search(len(times), func(j int) bool { return times[j] >= t }); j < len(times) && times[j] == t {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// decodedBlocks holds the sorted times of the values of blocks decoded by
+// FileStore.ContainsValue.
+type decodedBlocks map[decodedBlockKey][]int64
+
+// decodedBlockKey identifies a block of a file.
+type decodedBlockKey struct {
+	file   TSMFile
+	offset int64
+}
+
+// OverlapsTimeRange returns true if any file has a block for key that overlaps min and
+// max, inclusive, and has not been deleted within the range.
+func (f *FileStore) OverlapsTimeRange(key string, min, max int64) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	var entries []IndexEntry
+	for _, file := range f.files {
+		if !file.Contains(key) {
+			continue
+		}
+
+		file.ReadEntries(key, &entries)
+		tombstones := file.TombstoneRange(key)
+	outer:
+		for _, entry := range entries {
+			if !entry.OverlapsTimeRange(min, max) {
+				continue
+			}
+
+			// Skip the block if a tombstone covers the part of it within the range.
+			lo, hi := entry.MinTime, entry.MaxTime
+			if lo < min {
+				lo = min
+			}
+			if hi > max {
+				hi = max
+			}
+			for _, t := range tombstones {
+				if t.Min <= lo && t.Max >= hi {
+					continue outer
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// MaxTime returns the time of the last value of key in the files, ignoring values
+// removed by tombstones.  Blocks are not decoded, so when a tombstone covers the end
+// of a block the time just before the tombstone is returned.  It returns false if no
+// file has values for key.
+func (f *FileStore) MaxTime(key string) (int64, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	var max int64
+	var found bool
+	var entries []IndexEntry
+	for _, file := range f.files {
+		if !file.Contains(key) {
+			continue
+		}
+
+		file.ReadEntries(key, &entries)
+		tombstones := file.TombstoneRange(key)
+	outer:
+		for i := len(entries) - 1; i >= 0; i-- {
+			entry := entries[i]
+			if found && entry.MaxTime <= max {
+				break
+			}
+
+			// Skip the block if a tombstone covers it and exclude any tombstoned
+			// values at its end.
+			t := entry.MaxTime
+			for _, ts := range tombstones {
+				if ts.Min <= entry.MinTime && ts.Max >= t {
+					continue outer
+				} else if ts.Min > entry.MinTime && ts.Min <= t && ts.Max >= t {
+					t = ts.Min - 1
+				}
+			}
+
+			if !found || t > max {
+				max, found = t, true
+			}
+			break
+		}
+	}
+	return max, found
+}
+
 // KeyCursor returns a KeyCursor for key and t across the files in the FileStore.
 func (f *FileStore) KeyCursor(key string, t int64, ascending bool) *KeyCursor {
 	f.mu.RLock()
@@ -498,7 +854,9 @@ func (f *FileStore) Stats() []FileStat {
 	}
 
 	for _, fd := range f.files {
-		f.lastFileStats = append(f.lastFileStats, fd.Stats())
+		stat := fd.Stats()
+		stat.Tier = f.tier(stat.Path)
+		f.lastFileStats = append(f.lastFileStats, stat)
 	}
 	defer f.mu.Unlock()
 	return f.lastFileStats
@@ -506,6 +864,13 @@ func (f *FileStore) Stats() []FileStat {
 
 // Replace replaces oldFiles with newFiles.
 func (f *FileStore) Replace(oldFiles, newFiles []string) error {
+	return f.replace(oldFiles, newFiles, nil)
+}
+
+// replace replaces oldFiles with newFiles.  If validate is not nil, it is called
+// with the current files under the write lock before any file is removed and
+// the new files are discarded if it returns an error.
+func (f *FileStore) replace(oldFiles, newFiles []string, validate func(files []TSMFile) error) error {
 	if len(oldFiles) == 0 && len(newFiles) == 0 {
 		return nil
 	}
@@ -515,9 +880,14 @@ func (f *FileStore) Replace(oldFiles, newFiles []string) error {
 	f.mu.RUnlock()
 
 	updated := make([]TSMFile, 0, len(newFiles))
+	var syncCold bool
 
 	// Rename all the new files to make them live on restart
 	for _, file := range newFiles {
+		if f.tier(file) == ColdTier {
+			syncCold = true
+		}
+
 		var newName = file
 		if strings.HasSuffix(file, ".tmp") {
 			// The new TSM files have a tmp extension.  First rename them.
@@ -539,7 +909,7 @@ func (f *FileStore) Replace(oldFiles, newFiles []string) error {
 			}
 		}
 
-		tsm, err := NewTSMReader(fd)
+		tsm, err := f.openTSMReader(fd)
 		if err != nil {
 			return err
 		}
@@ -549,6 +919,16 @@ func (f *FileStore) Replace(oldFiles, newFiles []string) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
+	if validate != nil {
+		if err := validate(f.files); err != nil {
+			for _, tsm := range updated {
+				tsm.Close()
+				tsm.Remove()
+			}
+			return err
+		}
+	}
+
 	// Copy the current set of active files while we rename
 	// and load the new files.  We copy the pointers here to minimize
 	// the time that locks are held as well as to ensure that the replacement
@@ -614,6 +994,12 @@ func (f *FileStore) Replace(oldFiles, newFiles []string) error {
 		return err
 	}
 
+	if syncCold {
+		if err := syncDir(f.coldDir); err != nil {
+			return err
+		}
+	}
+
 	// Tell the purger about our in-use files we need to remove
 	f.purger.add(inuse)
 
@@ -632,19 +1018,123 @@ func (f *FileStore) Replace(oldFiles, newFiles []string) error {
 	atomic.StoreInt64(&f.stats.FileCount, int64(len(f.files)))
 
 	// Recalculate the disk size stat
-	var totalSize int64
+	var totalSize, coldSize, coldCount int64
 	for _, file := range f.files {
-		totalSize += int64(file.Size())
+		size := int64(file.Size())
 		for _, ts := range file.TombstoneFiles() {
-			totalSize += int64(ts.Size)
+			size += int64(ts.Size)
 		}
+		totalSize += size
 
+		if f.tier(file.Path()) == ColdTier {
+			coldSize += size
+			coldCount++
+		}
 	}
 	atomic.StoreInt64(&f.stats.DiskBytes, totalSize)
+	atomic.StoreInt64(&f.stats.ColdDiskBytes, coldSize)
+	atomic.StoreInt64(&f.stats.ColdFileCount, coldCount)
 
+	f.advise()
 	return nil
 }
 
+// MoveToColdTier moves the TSM files at paths, along with their tombstones, to the
+// cold tier directory.  The files are copied and then swapped in place of the
+// originals so queries are not interrupted.  Files already in the cold tier are
+// skipped.  Deletes are blocked during the move and the move is abandoned if any
+// of the files was replaced, e.g. by a compaction, while it was copied.
+func (f *FileStore) MoveToColdTier(paths []string) error {
+	if f.coldDir == "" {
+		return ErrColdTierDisabled
+	}
+
+	f.deleteMu.Lock()
+	defer f.deleteMu.Unlock()
+
+	if err := os.MkdirAll(f.coldDir, 0777); err != nil {
+		return err
+	}
+
+	f.mu.RLock()
+	files := make(map[string]TSMFile, len(f.files))
+	for _, file := range f.files {
+		files[file.Path()] = file
+	}
+	f.mu.RUnlock()
+
+	var oldFiles, newFiles, copied []string
+	tombstones := make(map[string][]FileStat)
+	err := func() error {
+		for _, path := range paths {
+			file, ok := files[path]
+			if !ok {
+				return fmt.Errorf("file %s not found", path)
+			} else if f.tier(path) == ColdTier {
+				continue
+			}
+
+			tombstones[path] = append([]FileStat{}, file.TombstoneFiles()...)
+			for _, ts := range tombstones[path] {
+				dst := filepath.Join(f.coldDir, filepath.Base(ts.Path))
+				copied = append(copied, dst)
+				if err := copyFile(ts.Path, dst); err != nil {
+					return err
+				}
+			}
+
+			// The copy is written with a temp extension which is removed by Replace.
+			dst := filepath.Join(f.coldDir, fmt.Sprintf("%s.%s", filepath.Base(path), CompactionTempExtension))
+			copied = append(copied, dst)
+			if err := copyFile(path, dst); err != nil {
+				return err
+			}
+
+			oldFiles = append(oldFiles, path)
+			newFiles = append(newFiles, dst)
+		}
+		return nil
+	}()
+
+	if err != nil {
+		for _, path := range copied {
+			os.RemoveAll(path)
+		}
+		return err
+	}
+
+	// Verify the copied files and tombstones are still current before the swap.
+	return f.replace(oldFiles, newFiles, func(files []TSMFile) error {
+		current := make(map[string]TSMFile, len(files))
+		for _, file := range files {
+			current[file.Path()] = file
+		}
+
+		for _, path := range oldFiles {
+			file, ok := current[path]
+			if !ok {
+				return fmt.Errorf("file %s was replaced while moving to the cold tier", path)
+			} else if !equalFileStats(file.TombstoneFiles(), tombstones[path]) {
+				return fmt.Errorf("tombstones of file %s changed while moving to the cold tier", path)
+			}
+		}
+		return nil
+	})
+}
+
+// equalFileStats returns true if a and b describe the same files.
+func equalFileStats(a, b []FileStat) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Path != b[i].Path || a[i].Size != b[i].Size || a[i].LastModified != b[i].LastModified {
+			return false
+		}
+	}
+	return true
+}
+
 // LastModified returns the last time the file store was updated with new
 // TSM files or a delete.
 func (f *FileStore) LastModified() time.Time {
@@ -809,13 +1299,13 @@ func (f *FileStore) CreateSnapshot() (string, error) {
 
 	for _, tsmf := range files {
 		newpath := filepath.Join(tmpPath, filepath.Base(tsmf.Path()))
-		if err := os.Link(tsmf.Path(), newpath); err != nil {
+		if err := f.linkFile(tsmf.Path(), newpath); err != nil {
 			return "", fmt.Errorf("error creating tsm hard link: %q", err)
 		}
 		// Check for tombstones and link those as well
 		for _, tf := range tsmf.TombstoneFiles() {
 			newpath := filepath.Join(tmpPath, filepath.Base(tf.Path))
-			if err := os.Link(tf.Path, newpath); err != nil {
+			if err := f.linkFile(tf.Path, newpath); err != nil {
 				return "", fmt.Errorf("error creating tombstone hard link: %q", err)
 			}
 		}
@@ -824,6 +1314,50 @@ func (f *FileStore) CreateSnapshot() (string, error) {
 	return tmpPath, nil
 }
 
+// linkFile creates a hard link to the file at src in dst.  Files in the cold tier
+// are copied instead since the tiers are typically on different devices.
+func (f *FileStore) linkFile(src, dst string) error {
+	if f.tier(src) == ColdTier {
+		return copyFile(src, dst)
+	}
+	return os.Link(src, dst)
+}
+
+// copyFile copies the file at src to dst, preserving its modification time.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	stat, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_RDWR|os.O_EXCL, 0666)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	return os.Chtimes(dst, stat.ModTime(), stat.ModTime())
+}
+
 // ParseTSMFileName parses the generation and sequence from a TSM file name.
 func ParseTSMFileName(name string) (int, int, error) {
 	base := filepath.Base(name)
@@ -926,6 +1460,13 @@ func (a ascLocations) Less(i, j int) bool {
 	return a[i].entry.MinTime < a[j].entry.MinTime
 }
 
+// minTimeLocations sorts locations by the min time of their blocks only.
+type minTimeLocations []*location
+
+func (a minTimeLocations) Len() int           { return len(a) }
+func (a minTimeLocations) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a minTimeLocations) Less(i, j int) bool { return a[i].entry.MinTime < a[j].entry.MinTime }
+
 // newKeyCursor returns a new instance of KeyCursor.
 // This function assumes the read-lock has been taken.
 func newKeyCursor(fs *FileStore, key string, t int64, ascending bool) *KeyCursor {
@@ -987,6 +1528,81 @@ func (c *KeyCursor) hasOverlappingBlocks() bool {
 	return false
 }
 
+// blockSummary holds the time range and statistics of a block that is summarized
+// rather than read.
+type blockSummary struct {
+	minTime, maxTime int64
+	stats            BlockStats
+}
+
+// takeBlockStats removes the blocks of type typ for which fn returns true from the
+// cursor and returns their statistics in time order.  Blocks are only removed if their
+// file stores statistics for them and they do not overlap another block of the key or a
+// deleted time range.  The cursor is then positioned at t.  This must be called on an
+// ascending cursor before any blocks are read.
+func (c *KeyCursor) takeBlockStats(typ byte, t int64, fn func(min, max int64) bool) []blockSummary {
+	if !c.ascending || len(c.seeks) == 0 {
+		return nil
+	}
+
+	// Overlapping blocks are ordered by file rather than time in seeks.
+	sorted := make([]*location, len(c.seeks))
+	copy(sorted, c.seeks)
+	sort.Stable(minTimeLocations(sorted))
+
+	taken := make(map[*location]BlockStats)
+	prevMax := int64(math.MinInt64)
+	for i, l := range sorted {
+		overlaps := l.entry.MinTime <= prevMax || (i+1 < len(sorted) && sorted[i+1].entry.MinTime <= l.entry.MaxTime)
+		if l.entry.MaxTime > prevMax {
+			prevMax = l.entry.MaxTime
+		}
+		if overlaps || !fn(l.entry.MinTime, l.entry.MaxTime) {
+			continue
+		}
+
+		if ftyp, err := l.r.Type(c.key); err != nil || ftyp != typ {
+			continue
+		}
+
+		deleted := false
+		for _, tr := range l.r.TombstoneRange(c.key) {
+			if l.entry.OverlapsTimeRange(tr.Min, tr.Max) {
+				deleted = true
+				break
+			}
+		}
+		if deleted {
+			continue
+		}
+
+		if s, ok := l.r.BlockStats(&l.entry); ok {
+			taken[l] = s
+		}
+	}
+
+	if len(taken) == 0 {
+		return nil
+	}
+
+	blocks := make([]blockSummary, 0, len(taken))
+	for _, l := range sorted {
+		if s, ok := taken[l]; ok {
+			blocks = append(blocks, blockSummary{minTime: l.entry.MinTime, maxTime: l.entry.MaxTime, stats: s})
+		}
+	}
+
+	seeks := c.seeks[:0]
+	for _, l := range c.seeks {
+		if _, ok := taken[l]; !ok {
+			seeks = append(seeks, l)
+		}
+	}
+	c.seeks = seeks
+	c.seek(t)
+	return blocks
+}
+
 // seek positions the cursor at the given time.
 func (c *KeyCursor) seek(t int64) {
 	if len(c.seeks) == 0 {
@@ -1136,6 +1752,13 @@ func (c *KeyCursor) filterIntegerValues(tombstones []TimeRange, values IntegerVa
 	return values
 }
 
+func (c *KeyCursor) filterUnsignedValues(tombstones []TimeRange, values UnsignedValues) UnsignedValues {
+	for _, t := range tombstones {
+		values = values.Exclude(t.Min, t.Max)
+	}
+	return values
+}
+
 func (c *KeyCursor) filterStringValues(tombstones []TimeRange, values StringValues) StringValues {
 	for _, t := range tombstones {
 		values = values.Exclude(t.Min, t.Max)
@@ -3,7 +3,11 @@ package tsm1
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestMergeSeriesKey_Single(t *testing.T) {
@@ -247,3 +251,166 @@ func TestMergeSeriesKey_Overlapping(t *testing.T) {
 		t.Fatalf("missed values: %v", exp)
 	}
 }
+
+func TestKeyCursor_TakeBlockStats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tsm1-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The second block of the first file overlaps the block in the second file.
+	for i, blocks := range [][][]Value{
+		{{NewValue(1, 1.0), NewValue(2, 2.0)}, {NewValue(5, 5.0), NewValue(6, 6.0)}},
+		{{NewValue(6, 7.0), NewValue(8, 8.0)}},
+	} {
+		f, err := os.Create(filepath.Join(dir, fmt.Sprintf("%09d-%09d.%s", i+1, 1, TSMFileExtension)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		w, err := NewTSMWriterWithBlockStats(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, values := range blocks {
+			if err := w.Write("cpu", values); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.WriteIndex(); err != nil {
+			t.Fatal(err)
+		} else if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fs := NewFileStore(dir)
+	if err := fs.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	c := fs.KeyCursor("cpu", 0, true)
+	defer c.Close()
+
+	blocks := c.takeBlockStats(BlockFloat64, 0, func(min, max int64) bool { return true })
+	if got, exp := len(blocks), 1; got != exp {
+		t.Fatalf("blocks length mismatch: got %v, exp %v", got, exp)
+	} else if b := blocks[0]; b.minTime != 1 || b.maxTime != 2 || b.stats.Count != 2 {
+		t.Fatalf("unexpected block: %+v", b)
+	}
+
+	// The cursor only returns the values of the overlapping blocks.
+	var buf []FloatValue
+	var times []int64
+	for {
+		values, err := c.ReadFloatBlock(&buf)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(values) == 0 {
+			break
+		}
+		for _, v := range values {
+			times = append(times, v.UnixNano())
+		}
+		c.Next()
+	}
+	if got, exp := fmt.Sprint(times), "[5 6 8]"; got != exp {
+		t.Fatalf("values mismatch: got %v, exp %v", got, exp)
+	}
+}
+
+func TestFileStore_SetAccessPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tsm1-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The first file is older than the cold age.
+	for i, values := range [][]Value{
+		{NewValue(1, 1.0), NewValue(2, 2.0)},
+		{NewValue(3, 3.0)},
+	} {
+		path := filepath.Join(dir, fmt.Sprintf("%09d-%09d.%s", i+1, 1, TSMFileExtension))
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w, err := NewTSMWriterWithBlockStats(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Write("cpu", values); err != nil {
+			t.Fatal(err)
+		} else if err := w.WriteIndex(); err != nil {
+			t.Fatal(err)
+		} else if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if i == 0 {
+			old := time.Now().Add(-2 * time.Hour)
+			if err := os.Chtimes(path, old, old); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	fs := NewFileStore(dir)
+	fs.SetAccessPolicy(AccessPolicyAge, time.Hour, ColdReaderPread)
+	if err := fs.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	files := fs.Files()
+	if got, exp := len(files), 2; got != exp {
+		t.Fatalf("files length mismatch: got %v, exp %v", got, exp)
+	}
+
+	cold, ok := files[0].(*TSMReader).accessor.(*preadAccessor)
+	if !ok {
+		t.Fatalf("cold file accessor mismatch: got %T, exp *preadAccessor", files[0].(*TSMReader).accessor)
+	}
+	hot, ok := files[1].(*TSMReader).accessor.(*mmapAccessor)
+	if !ok {
+		t.Fatalf("hot file accessor mismatch: got %T, exp *mmapAccessor", files[1].(*TSMReader).accessor)
+	} else if got, exp := hot.advice, madvWillNeed; got != exp {
+		t.Fatalf("advice mismatch: got %v, exp %v", got, exp)
+	}
+
+	// Values and block statistics are read from both files.
+	for i, exp := range []string{"[1 2]", "[3]"} {
+		values, err := files[i].(*TSMReader).ReadAll("cpu")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var times []int64
+		for _, v := range values {
+			times = append(times, v.UnixNano())
+		}
+		if got := fmt.Sprint(times); got != exp {
+			t.Fatalf("values mismatch: got %v, exp %v", got, exp)
+		}
+
+		entries := files[i].Entries("cpu")
+		if s, ok := files[i].BlockStats(&entries[0]); !ok || s.Count != uint32(len(values)) {
+			t.Fatalf("unexpected block stats: %+v, %v", s, ok)
+		}
+	}
+
+	// A pread file can still be read after it is renamed.
+	path := files[0].Path() + ".tmp"
+	if err := files[0].Rename(path); err != nil {
+		t.Fatal(err)
+	} else if got, exp := cold.path(), path; got != exp {
+		t.Fatalf("path mismatch: got %v, exp %v", got, exp)
+	}
+	entries := files[0].Entries("cpu")
+	if values, err := files[0].ReadAt(&entries[0], nil); err != nil {
+		t.Fatal(err)
+	} else if got, exp := len(values), 2; got != exp {
+		t.Fatalf("values length mismatch: got %v, exp %v", got, exp)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -51,6 +52,85 @@ func TestFileStore_Read(t *testing.T) {
 	}
 }
 
+func TestFileStore_OverlapsTimeRange(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	fs := tsm1.NewFileStore(dir)
+
+	data := []keyValues{
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(0, 1.0), tsm1.NewValue(10, 2.0)}},
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(20, 3.0)}},
+		keyValues{"mem", []tsm1.Value{tsm1.NewValue(0, 1.0)}},
+	}
+
+	files, err := newFiles(dir, data...)
+	if err != nil {
+		t.Fatalf("unexpected error creating files: %v", err)
+	}
+	fs.Replace(nil, files)
+
+	if !fs.OverlapsTimeRange("cpu", 5, 15) {
+		t.Fatal("expected block to overlap time range")
+	} else if fs.OverlapsTimeRange("cpu", 21, 30) {
+		t.Fatal("expected no block to overlap time range")
+	} else if fs.OverlapsTimeRange("disk", 0, 30) {
+		t.Fatal("expected no block for missing key")
+	}
+
+	// A block deleted within the range does not overlap.
+	if err := fs.DeleteRange([]string{"cpu"}, 15, 25); err != nil {
+		t.Fatal(err)
+	} else if fs.OverlapsTimeRange("cpu", 15, 30) {
+		t.Fatal("expected deleted block not to overlap time range")
+	} else if !fs.OverlapsTimeRange("mem", 0, 0) {
+		t.Fatal("expected block to overlap time range")
+	}
+}
+
+func TestFileStore_MaxTime(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	fs := tsm1.NewFileStore(dir)
+
+	data := []keyValues{
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(0, 1.0), tsm1.NewValue(10, 2.0)}},
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(20, 3.0), tsm1.NewValue(30, 4.0)}},
+		keyValues{"mem", []tsm1.Value{tsm1.NewValue(5, 1.0)}},
+	}
+
+	files, err := newFiles(dir, data...)
+	if err != nil {
+		t.Fatalf("unexpected error creating files: %v", err)
+	}
+	fs.Replace(nil, files)
+
+	if max, ok := fs.MaxTime("cpu"); !ok || max != 30 {
+		t.Fatalf("unexpected max time: %d, %v", max, ok)
+	} else if max, ok := fs.MaxTime("mem"); !ok || max != 5 {
+		t.Fatalf("unexpected max time: %d, %v", max, ok)
+	} else if _, ok := fs.MaxTime("disk"); ok {
+		t.Fatal("expected no max time for missing key")
+	}
+
+	// Values removed by tombstones are ignored.  A block partially covered by a
+	// tombstone ends just before the tombstone.
+	if err := fs.DeleteRange([]string{"cpu"}, 25, 40); err != nil {
+		t.Fatal(err)
+	} else if max, ok := fs.MaxTime("cpu"); !ok || max != 24 {
+		t.Fatalf("unexpected max time: %d, %v", max, ok)
+	}
+	if err := fs.DeleteRange([]string{"cpu"}, 15, 40); err != nil {
+		t.Fatal(err)
+	} else if max, ok := fs.MaxTime("cpu"); !ok || max != 10 {
+		t.Fatalf("unexpected max time: %d, %v", max, ok)
+	}
+	if err := fs.DeleteRange([]string{"mem"}, 0, 10); err != nil {
+		t.Fatal(err)
+	} else if _, ok := fs.MaxTime("mem"); ok {
+		t.Fatal("expected no max time for deleted key")
+	}
+}
+
 func TestFileStore_SeekToAsc_FromStart(t *testing.T) {
 	dir := MustTempDir()
 	defer os.RemoveAll(dir)
@@ -2135,6 +2215,184 @@ func TestFileStore_Replace(t *testing.T) {
 
 }
 
+func TestFileStore_MoveToColdTier(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	coldDir := filepath.Join(MustTempDir(), "cold")
+	defer os.RemoveAll(filepath.Dir(coldDir))
+
+	// Create 3 TSM files...
+	data := []keyValues{
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(0, 1.0)}},
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(1, 2.0)}},
+		keyValues{"mem", []tsm1.Value{tsm1.NewValue(0, 1.0)}},
+	}
+
+	files, err := newFileDir(dir, data...)
+	if err != nil {
+		fatal(t, "creating test files", err)
+	}
+
+	fs := tsm1.NewFileStore(dir)
+	fs.SetColdDir(coldDir)
+	if err := fs.Open(); err != nil {
+		fatal(t, "opening file store", err)
+	}
+	defer fs.Close()
+
+	// Create a tombstone which must move with the file
+	if err := fs.DeleteRange([]string{"cpu"}, 1, 1); err != nil {
+		t.Fatalf("unexpected error delete range: %v", err)
+	}
+
+	// Should keep the moved files readable while the cursor is open
+	cur := fs.KeyCursor("mem", 0, true)
+
+	if err := fs.MoveToColdTier(files[1:]); err != nil {
+		t.Fatalf("move to cold tier: %v", err)
+	}
+
+	buf := make([]tsm1.FloatValue, 10)
+	values, err := cur.ReadFloatBlock(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := len(values), 1; got != exp {
+		t.Fatalf("value len mismatch: got %v, exp %v", got, exp)
+	}
+	cur.Close()
+
+	if got, exp := fs.Count(), 3; got != exp {
+		t.Fatalf("file count mismatch: got %v, exp %v", got, exp)
+	}
+
+	var cold int
+	for _, stat := range fs.Stats() {
+		if exp := filepath.Dir(stat.Path) == coldDir; (stat.Tier == tsm1.ColdTier) != exp {
+			t.Fatalf("tier mismatch for %s: got %v", stat.Path, stat.Tier)
+		} else if exp {
+			cold++
+		}
+	}
+	if got, exp := cold, 2; got != exp {
+		t.Fatalf("cold file count mismatch: got %v, exp %v", got, exp)
+	}
+
+	for _, f := range files[1:] {
+		name := filepath.Base(f)
+		if _, err := os.Stat(filepath.Join(coldDir, name)); err != nil {
+			t.Fatalf("stat cold file: %v", err)
+		}
+	}
+
+	// The deleted value must stay deleted after reopening from the cold tier
+	fs2 := tsm1.NewFileStore(dir)
+	fs2.SetColdDir(coldDir)
+	if err := fs2.Open(); err != nil {
+		fatal(t, "opening file store", err)
+	}
+	defer fs2.Close()
+
+	if got, exp := fs2.Count(), 3; got != exp {
+		t.Fatalf("file count mismatch: got %v, exp %v", got, exp)
+	}
+
+	v, err := fs2.Read("cpu", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := len(v), 0; got != exp {
+		t.Fatalf("value len mismatch: got %v, exp %v", got, exp)
+	}
+
+	v, err = fs2.Read("mem", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := len(v), 1; got != exp {
+		t.Fatalf("value len mismatch: got %v, exp %v", got, exp)
+	}
+
+	time.Sleep(time.Second)
+	// Make sure the hot copies are gone
+	for _, f := range files[1:] {
+		if _, err := os.Stat(f); !os.IsNotExist(err) {
+			t.Fatalf("stat file: %v", err)
+		}
+	}
+}
+
+// Ensure deletes made while files are moved to the cold tier are not lost.
+func TestFileStore_MoveToColdTier_ConcurrentDelete(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	coldDir := filepath.Join(MustTempDir(), "cold")
+	defer os.RemoveAll(filepath.Dir(coldDir))
+
+	var data []keyValues
+	for i := 0; i < 10; i++ {
+		data = append(data, keyValues{fmt.Sprintf("cpu%d", i), []tsm1.Value{tsm1.NewValue(0, 1.0)}})
+	}
+
+	files, err := newFileDir(dir, data...)
+	if err != nil {
+		fatal(t, "creating test files", err)
+	}
+
+	fs := tsm1.NewFileStore(dir)
+	fs.SetColdDir(coldDir)
+	if err := fs.Open(); err != nil {
+		fatal(t, "opening file store", err)
+	}
+	defer fs.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := fs.MoveToColdTier(files); err != nil {
+			t.Errorf("move to cold tier: %v", err)
+		}
+	}()
+	for i := 0; i < len(data); i++ {
+		if err := fs.Delete([]string{data[i].key}); err != nil {
+			t.Fatalf("unexpected error delete: %v", err)
+		}
+	}
+	wg.Wait()
+
+	// Every deleted key must stay deleted after reopening.
+	fs2 := tsm1.NewFileStore(dir)
+	fs2.SetColdDir(coldDir)
+	if err := fs2.Open(); err != nil {
+		fatal(t, "opening file store", err)
+	}
+	defer fs2.Close()
+
+	for _, d := range data {
+		if v, err := fs2.Read(d.key, 0); err != nil {
+			t.Fatal(err)
+		} else if len(v) != 0 {
+			t.Fatalf("%s: deleted value returned: %v", d.key, v)
+		}
+	}
+}
+
+func TestFileStore_MoveToColdTier_Disabled(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	fs := tsm1.NewFileStore(dir)
+	if err := fs.Open(); err != nil {
+		fatal(t, "opening file store", err)
+	}
+	defer fs.Close()
+
+	if err := fs.MoveToColdTier(nil); err != tsm1.ErrColdTierDisabled {
+		t.Fatalf("unexpected error: got %v, exp %v", err, tsm1.ErrColdTierDisabled)
+	}
+}
+
 func TestFileStore_Open_Deleted(t *testing.T) {
 	dir := MustTempDir()
 	defer os.RemoveAll(dir)
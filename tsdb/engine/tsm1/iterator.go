@@ -12,6 +12,8 @@ func newLimitIterator(input influxql.Iterator, opt influxql.IteratorOptions) inf
 		return newFloatLimitIterator(input, opt)
 	case influxql.IntegerIterator:
 		return newIntegerLimitIterator(input, opt)
+	case influxql.UnsignedIterator:
+		return newUnsignedLimitIterator(input, opt)
 	case influxql.StringIterator:
 		return newStringLimitIterator(input, opt)
 	case influxql.BooleanIterator:
@@ -34,6 +36,19 @@ func (c *floatCastIntegerCursor) nextFloat() (int64, float64) {
 	return t, float64(v)
 }
 
+type floatCastUnsignedCursor struct {
+	cursor unsignedCursor
+}
+
+func (c *floatCastUnsignedCursor) close() error { return c.cursor.close() }
+
+func (c *floatCastUnsignedCursor) next() (t int64, v interface{}) { return c.nextFloat() }
+
+func (c *floatCastUnsignedCursor) nextFloat() (int64, float64) {
+	t, v := c.cursor.nextUnsigned()
+	return t, float64(v)
+}
+
 type integerCastFloatCursor struct {
 	cursor floatCursor
 }
@@ -46,3 +61,42 @@ func (c *integerCastFloatCursor) nextInteger() (int64, int64) {
 	t, v := c.cursor.nextFloat()
 	return t, int64(v)
 }
+
+type integerCastUnsignedCursor struct {
+	cursor unsignedCursor
+}
+
+func (c *integerCastUnsignedCursor) close() error { return c.cursor.close() }
+
+func (c *integerCastUnsignedCursor) next() (t int64, v interface{}) { return c.nextInteger() }
+
+func (c *integerCastUnsignedCursor) nextInteger() (int64, int64) {
+	t, v := c.cursor.nextUnsigned()
+	return t, int64(v)
+}
+
+type unsignedCastFloatCursor struct {
+	cursor floatCursor
+}
+
+func (c *unsignedCastFloatCursor) close() error { return c.cursor.close() }
+
+func (c *unsignedCastFloatCursor) next() (t int64, v interface{}) { return c.nextUnsigned() }
+
+func (c *unsignedCastFloatCursor) nextUnsigned() (int64, uint64) {
+	t, v := c.cursor.nextFloat()
+	return t, uint64(v)
+}
+
+type unsignedCastIntegerCursor struct {
+	cursor integerCursor
+}
+
+func (c *unsignedCastIntegerCursor) close() error { return c.cursor.close() }
+
+func (c *unsignedCastIntegerCursor) next() (t int64, v interface{}) { return c.nextUnsigned() }
+
+func (c *unsignedCastIntegerCursor) nextUnsigned() (int64, uint64) {
+	t, v := c.cursor.nextInteger()
+	return t, uint64(v)
+}
@@ -0,0 +1,15 @@
+package tsm1
+
+import "syscall"
+
+// Access pattern hints for madvise.
+const (
+	madvNormal   = syscall.MADV_NORMAL
+	madvRandom   = syscall.MADV_RANDOM
+	madvWillNeed = syscall.MADV_WILLNEED
+)
+
+// madvise gives the kernel a hint about how b will be accessed.
+func madvise(b []byte, advice int) error {
+	return syscall.Madvise(b, advice)
+}
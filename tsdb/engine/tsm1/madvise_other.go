@@ -0,0 +1,15 @@
+// +build !linux,!solaris
+
+package tsm1
+
+// Access pattern hints for madvise.
+const (
+	madvNormal = iota
+	madvRandom
+	madvWillNeed
+)
+
+// madvise is a no-op on platforms without support for access pattern hints.
+func madvise(b []byte, advice int) error {
+	return nil
+}
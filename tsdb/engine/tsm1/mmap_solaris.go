@@ -26,6 +26,13 @@ func munmap(b []byte) (err error) {
 	return unix.Munmap(b)
 }
 
+// Access pattern hints for madvise.
+const (
+	madvNormal   = syscall.MADV_NORMAL
+	madvRandom   = syscall.MADV_RANDOM
+	madvWillNeed = syscall.MADV_WILLNEED
+)
+
 // From: github.com/boltdb/bolt/bolt_unix.go
 func madvise(b []byte, advice int) (err error) {
 	return unix.Madvise(b, advice)
@@ -4,17 +4,23 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"math"
 	"os"
 	"sort"
 	"sync"
 	"sync/atomic"
+
+	"github.com/influxdata/influxdb/pkg/limiter"
 )
 
 // ErrFileInUse is returned when attempting to remove or close a TSM file that is still being used.
 var ErrFileInUse = fmt.Errorf("file still in use")
 
+// errVerifyCanceled is returned when verifying a TSM file is stopped before it completes.
+var errVerifyCanceled = fmt.Errorf("verify canceled")
+
 // TSMReader is a reader for a TSM file.
 type TSMReader struct {
 	// refs is the count of active references to this reader.
@@ -176,16 +182,31 @@ type blockAccessor interface {
 	readBlock(entry *IndexEntry, values []Value) ([]Value, error)
 	readFloatBlock(entry *IndexEntry, values *[]FloatValue) ([]FloatValue, error)
 	readIntegerBlock(entry *IndexEntry, values *[]IntegerValue) ([]IntegerValue, error)
+	readUnsignedBlock(entry *IndexEntry, values *[]UnsignedValue) ([]UnsignedValue, error)
 	readStringBlock(entry *IndexEntry, values *[]StringValue) ([]StringValue, error)
 	readBooleanBlock(entry *IndexEntry, values *[]BooleanValue) ([]BooleanValue, error)
 	readBytes(entry *IndexEntry, buf []byte) (uint32, []byte, error)
+	blockStats(entry *IndexEntry) (BlockStats, bool)
+	advise(advice int) error
 	rename(path string) error
 	path() string
 	close() error
 }
 
+// tsmReaderOption is a functional option for NewTSMReader.
+type tsmReaderOption func(*TSMReader)
+
+// withPread sets whether the reader accesses blocks with pread rather than mmap.
+func withPread(enabled bool) tsmReaderOption {
+	return func(t *TSMReader) {
+		if enabled {
+			t.accessor = &preadAccessor{f: t.accessor.(*mmapAccessor).f}
+		}
+	}
+}
+
 // NewTSMReader returns a new TSMReader from the given file.
-func NewTSMReader(f *os.File) (*TSMReader, error) {
+func NewTSMReader(f *os.File, options ...tsmReaderOption) (*TSMReader, error) {
 	t := &TSMReader{}
 
 	stat, err := f.Stat()
@@ -198,6 +219,10 @@ func NewTSMReader(f *os.File) (*TSMReader, error) {
 		f: f,
 	}
 
+	for _, option := range options {
+		option(t)
+	}
+
 	index, err := t.accessor.init()
 	if err != nil {
 		return nil, err
@@ -285,6 +310,14 @@ func (t *TSMReader) ReadIntegerBlockAt(entry *IndexEntry, vals *[]IntegerValue)
 	return v, err
 }
 
+// ReadUnsignedBlockAt returns the unsigned values corresponding to the given index entry.
+func (t *TSMReader) ReadUnsignedBlockAt(entry *IndexEntry, vals *[]UnsignedValue) ([]UnsignedValue, error) {
+	t.mu.RLock()
+	v, err := t.accessor.readUnsignedBlock(entry, vals)
+	t.mu.RUnlock()
+	return v, err
+}
+
 // ReadStringBlockAt returns the string values corresponding to the given index entry.
 func (t *TSMReader) ReadStringBlockAt(entry *IndexEntry, vals *[]StringValue) ([]StringValue, error) {
 	t.mu.RLock()
@@ -512,6 +545,24 @@ func (t *TSMReader) TombstoneFiles() []FileStat {
 	return fs
 }
 
+// BlockStats returns the statistics of the values of the block for entry.  It returns
+// false if the file does not store statistics for the block.
+func (t *TSMReader) BlockStats(entry *IndexEntry) (BlockStats, bool) {
+	t.mu.RLock()
+	s, ok := t.accessor.blockStats(entry)
+	t.mu.RUnlock()
+	return s, ok
+}
+
+// advise hints to the kernel how the pages of the file will be accessed.  advice is
+// one of the madv constants.  It is a no-op for readers that don't use mmap.
+func (t *TSMReader) advise(advice int) error {
+	t.mu.RLock()
+	err := t.accessor.advise(advice)
+	t.mu.RUnlock()
+	return err
+}
+
 // TombstoneRange returns ranges of time that are deleted for the given key.
 func (t *TSMReader) TombstoneRange(key string) []TimeRange {
 	t.mu.RLock()
@@ -536,6 +587,67 @@ func (t *TSMReader) Stats() FileStat {
 	}
 }
 
+// Verify checks that the keys and blocks of the index are ordered and consistent, that
+// the checksum of every block matches its data and that the tombstone file is readable.
+// Blocks are read at no more than the rate allowed by rate, which may be nil.  Verify
+// returns an error describing the first problem found.
+func (t *TSMReader) Verify(rate *limiter.Rate, closing <-chan struct{}) (err error) {
+	// The index and blocks are read from the file's memory map, which could have been
+	// changed on disk since the file was opened, so treat invalid offsets as corruption.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s: corrupt index: %v", t.Path(), r)
+		}
+	}()
+
+	minTime, maxTime := t.index.TimeRange()
+	size := t.Size()
+
+	var prevKey string
+	for i, n := 0, t.index.KeyCount(); i < n; i++ {
+		key, typ, entries := t.index.Key(i)
+		if i > 0 && key <= prevKey {
+			return fmt.Errorf("%s: key %q out of order after %q", t.Path(), key, prevKey)
+		} else if len(entries) == 0 {
+			return fmt.Errorf("%s: key %q has no blocks", t.Path(), key)
+		}
+		prevKey = key
+
+		for j := range entries {
+			e := &entries[j]
+			if e.MinTime > e.MaxTime || e.MinTime < minTime || e.MaxTime > maxTime {
+				return fmt.Errorf("%s: key %q block %d has invalid time range %d-%d", t.Path(), key, j, e.MinTime, e.MaxTime)
+			} else if j > 0 && e.MinTime < entries[j-1].MinTime {
+				return fmt.Errorf("%s: key %q block %d out of order", t.Path(), key, j)
+			} else if e.Offset < 5 || e.Size < 5 || e.Offset+int64(e.Size) > int64(size) {
+				return fmt.Errorf("%s: key %q block %d has invalid offset %d and size %d", t.Path(), key, j, e.Offset, e.Size)
+			}
+
+			if !rate.Wait(int(e.Size), closing) {
+				return errVerifyCanceled
+			}
+
+			checksum, buf, err := t.ReadBytes(e, nil)
+			if err != nil {
+				return fmt.Errorf("%s: key %q block %d: %v", t.Path(), key, j, err)
+			} else if exp := crc32.ChecksumIEEE(buf); checksum != exp {
+				return fmt.Errorf("%s: key %q block %d has checksum %d, expected %d", t.Path(), key, j, checksum, exp)
+			} else if len(buf) == 0 || buf[0] != typ {
+				return fmt.Errorf("%s: key %q block %d type does not match index", t.Path(), key, j)
+			}
+		}
+	}
+
+	t.mu.RLock()
+	err = t.tombstoner.Walk(func(Tombstone) error { return nil })
+	t.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("%s: corrupt tombstone: %v", t.Path(), err)
+	}
+
+	return nil
+}
+
 // BlockIterator returns a BlockIterator for the underlying TSM file.
 func (t *TSMReader) BlockIterator() *BlockIterator {
 	return &BlockIterator{
@@ -1014,18 +1126,24 @@ type mmapAccessor struct {
 	f     *os.File
 	b     []byte
 	index *indirectIndex
+
+	// The position of the block statistics within b.  Both are zero for files without
+	// block statistics.
+	statsStart, statsEnd int
+
+	// advice is the last madvise hint given for b.
+	advice int
 }
 
 func (m *mmapAccessor) init() (*indirectIndex, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if err := verifyVersion(m.f); err != nil {
+	version, err := verifyVersion(m.f)
+	if err != nil {
 		return nil, err
 	}
 
-	var err error
-
 	if _, err := m.f.Seek(0, 0); err != nil {
 		return nil, err
 	}
@@ -1049,6 +1167,19 @@ func (m *mmapAccessor) init() (*indirectIndex, error) {
 		return nil, fmt.Errorf("mmapAccessor: invalid indexStart")
 	}
 
+	// The block statistics and their count precede the index.
+	if version == VersionBlockStats {
+		if indexStart < 5+8 {
+			return nil, fmt.Errorf("mmapAccessor: invalid block stats count")
+		}
+		m.statsEnd = int(indexStart) - 8
+		n := binary.BigEndian.Uint64(m.b[m.statsEnd:indexStart])
+		if n > uint64(m.statsEnd-5)/blockStatsSize {
+			return nil, fmt.Errorf("mmapAccessor: invalid block stats count")
+		}
+		m.statsStart = m.statsEnd - int(n)*blockStatsSize
+	}
+
 	m.index = NewIndirectIndex()
 	if err := m.index.UnmarshalBinary(m.b[indexStart:indexOfsPos]); err != nil {
 		return nil, err
@@ -1092,6 +1223,7 @@ func (m *mmapAccessor) rename(path string) error {
 	if err != nil {
 		return err
 	}
+	m.advice = madvNormal
 
 	return nil
 }
@@ -1158,6 +1290,24 @@ func (m *mmapAccessor) readIntegerBlock(entry *IndexEntry, values *[]IntegerValu
 	return a, nil
 }
 
+func (m *mmapAccessor) readUnsignedBlock(entry *IndexEntry, values *[]UnsignedValue) ([]UnsignedValue, error) {
+	m.mu.RLock()
+
+	if int64(len(m.b)) < entry.Offset+int64(entry.Size) {
+		m.mu.RUnlock()
+		return nil, ErrTSMClosed
+	}
+
+	a, err := DecodeUnsignedBlock(m.b[entry.Offset+4:entry.Offset+int64(entry.Size)], values)
+	m.mu.RUnlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
 func (m *mmapAccessor) readStringBlock(entry *IndexEntry, values *[]StringValue) ([]StringValue, error) {
 	m.mu.RLock()
 
@@ -1253,6 +1403,31 @@ func (m *mmapAccessor) readAll(key string) ([]Value, error) {
 	return values, nil
 }
 
+func (m *mmapAccessor) blockStats(entry *IndexEntry) (BlockStats, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.b == nil {
+		return BlockStats{}, false
+	}
+	return blockStatsAt(m.b[m.statsStart:m.statsEnd], entry.Offset)
+}
+
+func (m *mmapAccessor) advise(advice int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.b == nil || m.advice == advice {
+		return nil
+	}
+
+	if err := madvise(m.b, advice); err != nil {
+		return err
+	}
+	m.advice = advice
+	return nil
+}
+
 func (m *mmapAccessor) path() string {
 	m.mu.RLock()
 	path := m.f.Name()
@@ -1277,6 +1452,297 @@ func (m *mmapAccessor) close() error {
 	return m.f.Close()
 }
 
+// blockStatsAt returns the statistics for the block at offset from the block
+// statistics records in b.
+func blockStatsAt(b []byte, offset int64) (BlockStats, bool) {
+	// Records are sorted by the offset of their block.
+	n := len(b) / blockStatsSize
+	i := sort.Search(n, func(i int) bool {
+		return int64(binary.BigEndian.Uint64(b[i*blockStatsSize:])) >= offset
+	})
+	if i == n {
+		return BlockStats{}, false
+	}
+
+	rec := b[i*blockStatsSize : (i+1)*blockStatsSize]
+	if int64(binary.BigEndian.Uint64(rec[0:8])) != offset {
+		return BlockStats{}, false
+	}
+	return BlockStats{
+		Count: binary.BigEndian.Uint32(rec[8:12]),
+		Sum:   binary.BigEndian.Uint64(rec[12:20]),
+		Min:   binary.BigEndian.Uint64(rec[20:28]),
+		Max:   binary.BigEndian.Uint64(rec[28:36]),
+	}, true
+}
+
+// preadAccessor is a block accessor that reads blocks from the file with pread
+// rather than mapping it into memory.  Only the index and the block statistics are
+// kept in memory.  It is used for cold files to keep scans of old data from
+// evicting the pages of recent files from the page cache.
+type preadAccessor struct {
+	mu sync.RWMutex
+
+	f      *os.File
+	closed bool
+	index  *indirectIndex
+
+	// stats holds the block statistics records, if the file stores them.
+	stats []byte
+}
+
+func (m *preadAccessor) init() (*indirectIndex, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	version, err := verifyVersion(m.f)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := m.f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := stat.Size()
+	if size < 8 {
+		return nil, fmt.Errorf("preadAccessor: file too small for indirectIndex")
+	}
+
+	var buf [8]byte
+	indexOfsPos := size - 8
+	if _, err := m.f.ReadAt(buf[:], indexOfsPos); err != nil {
+		return nil, err
+	}
+	indexStart := int64(binary.BigEndian.Uint64(buf[:]))
+	if indexStart < 0 || indexStart >= indexOfsPos {
+		return nil, fmt.Errorf("preadAccessor: invalid indexStart")
+	}
+
+	// The block statistics and their count precede the index.
+	if version == VersionBlockStats {
+		if indexStart < 5+8 {
+			return nil, fmt.Errorf("preadAccessor: invalid block stats count")
+		}
+		statsEnd := indexStart - 8
+		if _, err := m.f.ReadAt(buf[:], statsEnd); err != nil {
+			return nil, err
+		}
+		n := binary.BigEndian.Uint64(buf[:])
+		if n > uint64(statsEnd-5)/blockStatsSize {
+			return nil, fmt.Errorf("preadAccessor: invalid block stats count")
+		}
+		m.stats = make([]byte, int(n)*blockStatsSize)
+		if _, err := m.f.ReadAt(m.stats, statsEnd-int64(len(m.stats))); err != nil {
+			return nil, err
+		}
+	}
+
+	b := make([]byte, indexOfsPos-indexStart)
+	if _, err := m.f.ReadAt(b, indexStart); err != nil {
+		return nil, err
+	}
+
+	m.index = NewIndirectIndex()
+	if err := m.index.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+
+	return m.index, nil
+}
+
+// readEntry returns the bytes of the block for entry, including the checksum.
+func (m *preadAccessor) readEntry(entry *IndexEntry) ([]byte, error) {
+	if m.closed {
+		return nil, ErrTSMClosed
+	}
+
+	b := make([]byte, entry.Size)
+	if _, err := m.f.ReadAt(b, entry.Offset); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (m *preadAccessor) read(key string, timestamp int64) ([]Value, error) {
+	entry := m.index.Entry(key, timestamp)
+	if entry == nil {
+		return nil, nil
+	}
+
+	return m.readBlock(entry, nil)
+}
+
+func (m *preadAccessor) readBlock(entry *IndexEntry, values []Value) ([]Value, error) {
+	m.mu.RLock()
+	b, err := m.readEntry(entry)
+	m.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return DecodeBlock(b[4:], values)
+}
+
+func (m *preadAccessor) readFloatBlock(entry *IndexEntry, values *[]FloatValue) ([]FloatValue, error) {
+	m.mu.RLock()
+	b, err := m.readEntry(entry)
+	m.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return DecodeFloatBlock(b[4:], values)
+}
+
+func (m *preadAccessor) readIntegerBlock(entry *IndexEntry, values *[]IntegerValue) ([]IntegerValue, error) {
+	m.mu.RLock()
+	b, err := m.readEntry(entry)
+	m.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return DecodeIntegerBlock(b[4:], values)
+}
+
+func (m *preadAccessor) readUnsignedBlock(entry *IndexEntry, values *[]UnsignedValue) ([]UnsignedValue, error) {
+	m.mu.RLock()
+	b, err := m.readEntry(entry)
+	m.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return DecodeUnsignedBlock(b[4:], values)
+}
+
+func (m *preadAccessor) readStringBlock(entry *IndexEntry, values *[]StringValue) ([]StringValue, error) {
+	m.mu.RLock()
+	b, err := m.readEntry(entry)
+	m.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return DecodeStringBlock(b[4:], values)
+}
+
+func (m *preadAccessor) readBooleanBlock(entry *IndexEntry, values *[]BooleanValue) ([]BooleanValue, error) {
+	m.mu.RLock()
+	b, err := m.readEntry(entry)
+	m.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return DecodeBooleanBlock(b[4:], values)
+}
+
+func (m *preadAccessor) readBytes(entry *IndexEntry, buf []byte) (uint32, []byte, error) {
+	m.mu.RLock()
+	b, err := m.readEntry(entry)
+	m.mu.RUnlock()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// return the bytes after the 4 byte checksum
+	return binary.BigEndian.Uint32(b[:4]), b[4:], nil
+}
+
+// readAll returns all values for a key in all blocks.
+func (m *preadAccessor) readAll(key string) ([]Value, error) {
+	blocks := m.index.Entries(key)
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+
+	tombstones := m.index.TombstoneRange(key)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var temp []Value
+	var values []Value
+	for i := range blocks {
+		block := &blocks[i]
+
+		var skip bool
+		for _, t := range tombstones {
+			// Should we skip this block because it contains points that have been deleted
+			if t.Min <= block.MinTime && t.Max >= block.MaxTime {
+				skip = true
+				break
+			}
+		}
+
+		if skip {
+			continue
+		}
+
+		b, err := m.readEntry(block)
+		if err != nil {
+			return nil, err
+		}
+
+		temp = temp[:0]
+		// The +4 is the 4 byte checksum length
+		temp, err = DecodeBlock(b[4:], temp)
+		if err != nil {
+			return nil, err
+		}
+
+		// Filter out any values that were deleted
+		for _, t := range tombstones {
+			temp = Values(temp).Exclude(t.Min, t.Max)
+		}
+
+		values = append(values, temp...)
+	}
+
+	return values, nil
+}
+
+func (m *preadAccessor) blockStats(entry *IndexEntry) (BlockStats, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return blockStatsAt(m.stats, entry.Offset)
+}
+
+// advise is a no-op since the file is not mapped.
+func (m *preadAccessor) advise(advice int) error { return nil }
+
+func (m *preadAccessor) rename(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.f.Close(); err != nil {
+		return err
+	}
+
+	if err := renameFile(m.f.Name(), path); err != nil {
+		return err
+	}
+
+	var err error
+	m.f, err = os.Open(path)
+	return err
+}
+
+func (m *preadAccessor) path() string {
+	m.mu.RLock()
+	path := m.f.Name()
+	m.mu.RUnlock()
+	return path
+}
+
+func (m *preadAccessor) close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil
+	}
+
+	m.closed = true
+	return m.f.Close()
+}
+
 type indexEntries struct {
 	Type    byte
 	entries []IndexEntry
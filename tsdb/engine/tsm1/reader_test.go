@@ -6,6 +6,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
@@ -807,6 +808,83 @@ func TestTSMReader_VerifiesFileType(t *testing.T) {
 	}
 }
 
+func TestTSMReader_Verify(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	f := MustTempFile(dir)
+	defer f.Close()
+
+	w, err := tsm1.NewTSMWriter(f)
+	if err != nil {
+		t.Fatalf("unexpected error creating writer: %v", err)
+	}
+
+	for _, key := range []string{"cpu", "mem"} {
+		if err := w.Write(key, []tsm1.Value{tsm1.NewValue(1, 1.0), tsm1.NewValue(2, 2.0)}); err != nil {
+			t.Fatalf("unexpected error writing: %v", err)
+		}
+	}
+
+	if err := w.WriteIndex(); err != nil {
+		t.Fatalf("unexpected error writing index: %v", err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+
+	f, err = os.Open(f.Name())
+	if err != nil {
+		t.Fatalf("unexpected error open file: %v", err)
+	}
+
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		t.Fatalf("unexpected error created reader: %v", err)
+	}
+	defer r.Close()
+
+	if err := r.DeleteRange([]string{"mem"}, 2, 2); err != nil {
+		t.Fatalf("unexpected error deleting: %v", err)
+	}
+
+	if err := r.Verify(nil, nil); err != nil {
+		t.Fatalf("unexpected error verifying: %v", err)
+	}
+
+	// Corrupt the tombstone file.
+	ts := r.TombstoneFiles()
+	if len(ts) != 1 {
+		t.Fatalf("unexpected tombstone files: %v", ts)
+	}
+	if err := ioutil.WriteFile(ts[0].Path, []byte{0, 0, 0x15, 0x02, 0, 0, 0, 16, 'c'}, 0666); err != nil {
+		t.Fatalf("unexpected error corrupting tombstone: %v", err)
+	}
+
+	if err := r.Verify(nil, nil); err == nil || !strings.Contains(err.Error(), "corrupt tombstone") {
+		t.Fatalf("unexpected error verifying: %v", err)
+	}
+
+	if err := os.Remove(ts[0].Path); err != nil {
+		t.Fatalf("unexpected error removing tombstone: %v", err)
+	}
+
+	// Corrupt the data of the first block, after the header and checksum.
+	fw, err := os.OpenFile(f.Name(), os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("unexpected error open file: %v", err)
+	}
+	defer fw.Close()
+
+	if _, err := fw.WriteAt([]byte{0xff, 0xff}, 10); err != nil {
+		t.Fatalf("unexpected error corrupting block: %v", err)
+	}
+
+	if err := r.Verify(nil, nil); err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Fatalf("unexpected error verifying: %v", err)
+	}
+}
+
 func TestIndirectIndex_Entries(t *testing.T) {
 	index := tsm1.NewIndexWriter()
 	index.Add("cpu", tsm1.BlockFloat64, 0, 1, 10, 100)
@@ -0,0 +1,400 @@
+package tsm1
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/influxdata/influxdb/influxql"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+// blockStatsIterator is implemented by iterators that return the aggregates of a
+// series computed from block statistics.  They are not wrapped in a call iterator.
+type blockStatsIterator interface {
+	influxql.Iterator
+	blockStats()
+}
+
+// blockStatsCall returns true if call can be answered from block statistics.
+func blockStatsCall(call *influxql.Call, opt influxql.IteratorOptions) bool {
+	if !opt.Ascending || opt.Interval.IsZero() || len(opt.Aux) > 0 {
+		return false
+	}
+
+	switch call.Name {
+	case "count", "sum", "min", "max", "mean":
+		return true
+	}
+	return false
+}
+
+// createBlockStatsSeriesIterator returns an iterator for the aggregates of a numeric field
+// in a series that reads the statistics of blocks that fit entirely in a window rather
+// than decoding them.  It returns nil if no blocks can be summarized.
+func (e *Engine) createBlockStatsSeriesIterator(call *influxql.Call, ref *influxql.VarRef, name, seriesKey string, opt influxql.IteratorOptions) (influxql.Iterator, error) {
+	if ref == nil || !blockStatsCall(call, opt) {
+		return nil, nil
+	}
+
+	mf := e.fieldset.Fields(name)
+	if mf == nil {
+		return nil, nil
+	}
+	f := mf.Field(ref.Val)
+	if f == nil || (ref.Type != influxql.Unknown && ref.Type != influxql.AnyField && ref.Type != f.Type) {
+		return nil, nil
+	}
+
+	var typ byte
+	switch f.Type {
+	case influxql.Float:
+		typ = BlockFloat64
+	case influxql.Integer:
+		typ = BlockInteger
+	case influxql.Unsigned:
+		typ = BlockUnsigned
+	default:
+		return nil, nil
+	}
+
+	key := SeriesFieldKey(seriesKey, ref.Val)
+	cacheValues := e.Cache.Values(key)
+	keyCursor := e.KeyCursor(key, opt.SeekTime(), opt.Ascending)
+
+	// Only blocks within a single window and the time range of the query, that don't
+	// overlap values in the cache, can be summarized.
+	blocks := keyCursor.takeBlockStats(typ, opt.SeekTime(), func(min, max int64) bool {
+		if min < opt.StartTime || max > opt.EndTime {
+			return false
+		} else if _, end := opt.Window(min); max >= end {
+			return false
+		}
+		i := sort.Search(len(cacheValues), func(i int) bool { return cacheValues[i].UnixNano() >= min })
+		return i == len(cacheValues) || cacheValues[i].UnixNano() > max
+	})
+	if len(blocks) == 0 {
+		keyCursor.Close()
+		return nil, nil
+	}
+
+	_, tfs, _ := models.ParseKey([]byte(seriesKey))
+	tags := influxql.NewTags(tfs.Map())
+	tags = tags.Subset(opt.GetDimensions())
+
+	itr := &blockStatsSeriesIterator{
+		call:   call.Name,
+		typ:    typ,
+		opt:    opt,
+		blocks: blocks,
+		name:   name,
+		tags:   tags,
+	}
+	itr.stats.SeriesN = 1
+
+	switch typ {
+	case BlockFloat64:
+		itr.cur = newFloatCursor(opt.SeekTime(), opt.Ascending, cacheValues, keyCursor)
+	case BlockInteger:
+		itr.cur = newIntegerCursor(opt.SeekTime(), opt.Ascending, cacheValues, keyCursor)
+	case BlockUnsigned:
+		itr.cur = newUnsignedCursor(opt.SeekTime(), opt.Ascending, cacheValues, keyCursor)
+	}
+	itr.readValue()
+
+	switch {
+	case call.Name == "count":
+		return &integerBlockStatsIterator{itr}, nil
+	case call.Name == "mean" || typ == BlockFloat64:
+		return &floatBlockStatsIterator{itr}, nil
+	case typ == BlockInteger:
+		return &integerBlockStatsIterator{itr}, nil
+	default:
+		return &unsignedBlockStatsIterator{itr}, nil
+	}
+}
+
+// blockStatsSeriesIterator aggregates the values of a series into windows.  Values are
+// read from the summarized blocks and from a cursor over the remaining values.
+type blockStatsSeriesIterator struct {
+	call string
+	typ  byte
+	opt  influxql.IteratorOptions
+	name string
+	tags influxql.Tags
+
+	blocks []blockSummary
+	cur    cursor
+
+	// The next value read from cur.  t is tsdb.EOF once cur is exhausted.
+	t       int64
+	f       float64
+	i       int64
+	u       uint64
+	agg     blockStatsAggregate
+	aggTime int64
+
+	statsLock sync.Mutex
+	stats     influxql.IteratorStats
+}
+
+// readValue reads the next value from the cursor.
+func (itr *blockStatsSeriesIterator) readValue() {
+	switch cur := itr.cur.(type) {
+	case floatCursor:
+		itr.t, itr.f = cur.nextFloat()
+	case integerCursor:
+		itr.t, itr.i = cur.nextInteger()
+	case unsignedCursor:
+		itr.t, itr.u = cur.nextUnsigned()
+	}
+	if itr.t != tsdb.EOF && itr.t > itr.opt.EndTime {
+		itr.t = tsdb.EOF
+	}
+}
+
+// next aggregates the values of the next window.  It returns false when there are no
+// more values.
+func (itr *blockStatsSeriesIterator) next() bool {
+	// The window starts at the earliest of the next value and the next block.
+	t := itr.t
+	if len(itr.blocks) > 0 && (t == tsdb.EOF || itr.blocks[0].minTime < t) {
+		t = itr.blocks[0].minTime
+	}
+	if t == tsdb.EOF {
+		return false
+	}
+
+	start, end := itr.opt.Window(t)
+	itr.agg = blockStatsAggregate{}
+	itr.aggTime = start
+
+	var pointN int
+	for {
+		if len(itr.blocks) > 0 && itr.blocks[0].minTime < end && (itr.t == tsdb.EOF || itr.blocks[0].minTime < itr.t) {
+			itr.agg.addStats(itr.typ, &itr.blocks[0].stats)
+			pointN += int(itr.blocks[0].stats.Count)
+			itr.blocks = itr.blocks[1:]
+			continue
+		}
+
+		if itr.t == tsdb.EOF || itr.t >= end {
+			break
+		}
+		switch itr.typ {
+		case BlockFloat64:
+			itr.agg.addFloat(itr.f)
+		case BlockInteger:
+			itr.agg.addInteger(itr.i)
+		case BlockUnsigned:
+			itr.agg.addUnsigned(itr.u)
+		}
+		pointN++
+		itr.readValue()
+	}
+
+	itr.statsLock.Lock()
+	itr.stats.PointN += pointN
+	itr.statsLock.Unlock()
+	return true
+}
+
+// Stats returns stats on the points processed.
+func (itr *blockStatsSeriesIterator) Stats() influxql.IteratorStats {
+	itr.statsLock.Lock()
+	stats := itr.stats
+	itr.statsLock.Unlock()
+	return stats
+}
+
+// Close closes the iterator.
+func (itr *blockStatsSeriesIterator) Close() error {
+	return itr.cur.close()
+}
+
+func (itr *blockStatsSeriesIterator) blockStats() {}
+
+// floatBlockStatsIterator returns the float aggregates of a series.
+type floatBlockStatsIterator struct {
+	*blockStatsSeriesIterator
+}
+
+// Next returns the aggregate of the next window.
+func (itr *floatBlockStatsIterator) Next() (*influxql.FloatPoint, error) {
+	if !itr.next() {
+		return nil, nil
+	}
+
+	p := &influxql.FloatPoint{
+		Name:       itr.name,
+		Tags:       itr.tags,
+		Time:       itr.aggTime,
+		Aggregated: uint32(itr.agg.count),
+	}
+	switch itr.call {
+	case "mean":
+		p.Value = itr.agg.mean(itr.typ)
+	case "sum":
+		p.Value = itr.agg.fsum
+	case "min":
+		p.Value = itr.agg.fmin
+	case "max":
+		p.Value = itr.agg.fmax
+	}
+	return p, nil
+}
+
+// integerBlockStatsIterator returns the integer aggregates of a series.
+type integerBlockStatsIterator struct {
+	*blockStatsSeriesIterator
+}
+
+// Next returns the aggregate of the next window.
+func (itr *integerBlockStatsIterator) Next() (*influxql.IntegerPoint, error) {
+	if !itr.next() {
+		return nil, nil
+	}
+
+	p := &influxql.IntegerPoint{
+		Name:       itr.name,
+		Tags:       itr.tags,
+		Time:       itr.aggTime,
+		Aggregated: uint32(itr.agg.count),
+	}
+	switch itr.call {
+	case "count":
+		p.Value = itr.agg.count
+	case "sum":
+		p.Value = itr.agg.isum
+	case "min":
+		p.Value = itr.agg.imin
+	case "max":
+		p.Value = itr.agg.imax
+	}
+	return p, nil
+}
+
+// unsignedBlockStatsIterator returns the unsigned aggregates of a series.
+type unsignedBlockStatsIterator struct {
+	*blockStatsSeriesIterator
+}
+
+// Next returns the aggregate of the next window.
+func (itr *unsignedBlockStatsIterator) Next() (*influxql.UnsignedPoint, error) {
+	if !itr.next() {
+		return nil, nil
+	}
+
+	p := &influxql.UnsignedPoint{
+		Name:       itr.name,
+		Tags:       itr.tags,
+		Time:       itr.aggTime,
+		Aggregated: uint32(itr.agg.count),
+	}
+	switch itr.call {
+	case "sum":
+		p.Value = itr.agg.usum
+	case "min":
+		p.Value = itr.agg.umin
+	case "max":
+		p.Value = itr.agg.umax
+	}
+	return p, nil
+}
+
+// blockStatsAggregate holds the count, sum, minimum and maximum of the values in a window.
+type blockStatsAggregate struct {
+	count int64
+
+	fsum, fmin, fmax float64
+	isum, imin, imax int64
+	usum, umin, umax uint64
+}
+
+func (a *blockStatsAggregate) addFloat(v float64) {
+	if a.count == 0 || v < a.fmin {
+		a.fmin = v
+	}
+	if a.count == 0 || v > a.fmax {
+		a.fmax = v
+	}
+	a.fsum += v
+	a.count++
+}
+
+func (a *blockStatsAggregate) addInteger(v int64) {
+	if a.count == 0 || v < a.imin {
+		a.imin = v
+	}
+	if a.count == 0 || v > a.imax {
+		a.imax = v
+	}
+	a.isum += v
+	a.count++
+}
+
+func (a *blockStatsAggregate) addUnsigned(v uint64) {
+	if a.count == 0 || v < a.umin {
+		a.umin = v
+	}
+	if a.count == 0 || v > a.umax {
+		a.umax = v
+	}
+	a.usum += v
+	a.count++
+}
+
+// addStats adds the statistics of a block of type typ.
+func (a *blockStatsAggregate) addStats(typ byte, s *BlockStats) {
+	if s.Count == 0 {
+		return
+	}
+
+	switch typ {
+	case BlockFloat64:
+		sum, min, max := s.FloatStats()
+		if a.count == 0 || min < a.fmin {
+			a.fmin = min
+		}
+		if a.count == 0 || max > a.fmax {
+			a.fmax = max
+		}
+		a.fsum += sum
+	case BlockInteger:
+		sum, min, max := s.IntegerStats()
+		if a.count == 0 || min < a.imin {
+			a.imin = min
+		}
+		if a.count == 0 || max > a.imax {
+			a.imax = max
+		}
+		a.isum += sum
+	case BlockUnsigned:
+		sum, min, max := s.UnsignedStats()
+		if a.count == 0 || min < a.umin {
+			a.umin = min
+		}
+		if a.count == 0 || max > a.umax {
+			a.umax = max
+		}
+		a.usum += sum
+	}
+	a.count += int64(s.Count)
+}
+
+// mean returns the mean of the values of type typ.
+func (a *blockStatsAggregate) mean(typ byte) float64 {
+	if a.count == 0 {
+		return math.NaN()
+	}
+
+	switch typ {
+	case BlockInteger:
+		return float64(a.isum) / float64(a.count)
+	case BlockUnsigned:
+		return float64(a.usum) / float64(a.count)
+	default:
+		return a.fsum / float64(a.count)
+	}
+}
@@ -4,10 +4,16 @@ package tsm1
 // appended to byte slice prefixed with a variable byte length followed by the string
 // bytes.  The bytes are compressed using snappy compressor and a 1 byte header is used
 // to indicate the type of encoding.
+//
+// Blocks with a small number of distinct strings, such as status fields, are instead
+// dictionary encoded.  The distinct strings are stored once, in the order they were
+// first written, followed by the index of each value in the dictionary bit-packed
+// using the fewest bits able to represent the largest index.
 
 import (
 	"encoding/binary"
 	"fmt"
+	"math"
 
 	"github.com/golang/snappy"
 )
@@ -19,18 +25,34 @@ const (
 
 	// stringCompressedSnappy is a compressed encoding using Snappy compression
 	stringCompressedSnappy = 1
+
+	// stringCompressedDictionary is a compressed encoding using a dictionary of the
+	// distinct strings and bit-packed indices into the dictionary.
+	stringCompressedDictionary = 2
+
+	// stringDictionaryMaxSize is the maximum number of distinct strings in a block
+	// for it to be dictionary encoded.
+	stringDictionaryMaxSize = 256
 )
 
 // StringEncoder encodes multiple strings into a byte slice.
 type StringEncoder struct {
 	// The encoded bytes
 	bytes []byte
+
+	// The distinct strings written, mapped to their index in the dictionary.
+	// Tracking stops once there are more than stringDictionaryMaxSize.
+	dict     map[string]int
+	keys     []string
+	indices  []uint8
+	overflow bool
 }
 
 // NewStringEncoder returns a new StringEncoder with an initial buffer ready to hold sz bytes.
 func NewStringEncoder(sz int) StringEncoder {
 	return StringEncoder{
 		bytes: make([]byte, 0, sz),
+		dict:  make(map[string]int),
 	}
 }
 
@@ -40,6 +62,12 @@ func (e *StringEncoder) Flush() {}
 // Reset sets the encoder back to its initial state.
 func (e *StringEncoder) Reset() {
 	e.bytes = e.bytes[:0]
+	for k := range e.dict {
+		delete(e.dict, k)
+	}
+	e.keys = e.keys[:0]
+	e.indices = e.indices[:0]
+	e.overflow = false
 }
 
 // Write encodes s to the underlying buffer.
@@ -51,29 +79,113 @@ func (e *StringEncoder) Write(s string) {
 
 	// Append the string bytes
 	e.bytes = append(e.bytes, s...)
+
+	if e.overflow {
+		return
+	}
+
+	idx, ok := e.dict[s]
+	if !ok {
+		if len(e.keys) == stringDictionaryMaxSize {
+			e.overflow = true
+			return
+		}
+
+		if e.dict == nil {
+			e.dict = make(map[string]int)
+		}
+		idx = len(e.keys)
+		e.dict[s] = idx
+		e.keys = append(e.keys, s)
+	}
+	e.indices = append(e.indices, uint8(idx))
 }
 
 // Bytes returns a copy of the underlying buffer.
 func (e *StringEncoder) Bytes() ([]byte, error) {
+	// Only dictionary encode if values repeat often enough that storing each
+	// distinct string once is worth the cost of the indices.
+	if !e.overflow && len(e.keys) > 0 && 2*len(e.keys) <= len(e.indices) {
+		return e.encodeDictionary(), nil
+	}
+
 	// Compress the currently appended bytes using snappy and prefix with
 	// a 1 byte header for future extension
 	data := snappy.Encode(nil, e.bytes)
 	return append([]byte{stringCompressedSnappy << 4}, data...), nil
 }
 
+func (e *StringEncoder) encodeDictionary() []byte {
+	width := stringDictionaryWidth(len(e.keys))
+
+	var tmp [binary.MaxVarintLen64]byte
+
+	// 4 high bits used for the encoding type
+	b := []byte{stringCompressedDictionary << 4}
+
+	// The number of distinct strings followed by each length prefixed string
+	b = append(b, tmp[:binary.PutUvarint(tmp[:], uint64(len(e.keys)))]...)
+	for _, k := range e.keys {
+		b = append(b, tmp[:binary.PutUvarint(tmp[:], uint64(len(k)))]...)
+		b = append(b, k...)
+	}
+
+	// The number of values followed by the packed indices
+	b = append(b, tmp[:binary.PutUvarint(tmp[:], uint64(len(e.indices)))]...)
+
+	if width == 0 {
+		// A single string needs no indices
+		return b
+	}
+
+	i := len(b)
+	b = append(b, make([]byte, (len(e.indices)*int(width)+7)/8)...)
+	packed := b[i:]
+
+	for j, idx := range e.indices {
+		pos := uint(j) * width
+		v := uint16(idx) << (pos & 7)
+		packed[pos>>3] |= byte(v)
+		if v>>8 != 0 {
+			packed[pos>>3+1] |= byte(v >> 8)
+		}
+	}
+	return b
+}
+
+// stringDictionaryWidth returns the number of bits used to pack an index into a
+// dictionary of n strings.
+func stringDictionaryWidth(n int) uint {
+	var width uint
+	for (1 << width) < n {
+		width++
+	}
+	return width
+}
+
 // StringDecoder decodes a byte slice into strings.
 type StringDecoder struct {
 	b   []byte
 	l   int
 	i   int
 	err error
+
+	// State for dictionary encoded bytes
+	dictionary bool
+	dict       []string
+	width      uint
+	n          int
 }
 
 // SetBytes initializes the decoder with bytes to read from.
 // This must be called before calling any other method.
 func (e *StringDecoder) SetBytes(b []byte) error {
-	// First byte stores the encoding type, only have snappy format
-	// currently so ignore for now.
+	e.dictionary = false
+	if len(b) > 0 && b[0]>>4 == stringCompressedDictionary {
+		return e.setDictionaryBytes(b[1:])
+	}
+
+	// Any other encoding type is snappy.
 	var data []byte
 	if len(b) > 0 {
 		var err error
@@ -97,12 +209,21 @@ func (e *StringDecoder) Next() bool {
 		return false
 	}
 
+	if e.dictionary {
+		e.i++
+		return e.i < e.n
+	}
+
 	e.i += e.l
 	return e.i < len(e.b)
 }
 
 // Read returns the next value from the decoder.
 func (e *StringDecoder) Read() string {
+	if e.dictionary {
+		return e.readDictionary()
+	}
+
 	// Read the length of the string
 	length, n := binary.Uvarint(e.b[e.i:])
 	if n <= 0 {
@@ -131,3 +252,62 @@ func (e *StringDecoder) Read() string {
 func (e *StringDecoder) Error() error {
 	return e.err
 }
+
+func (e *StringDecoder) setDictionaryBytes(b []byte) error {
+	e.dictionary = true
+	e.dict = e.dict[:0]
+	e.b = nil
+	e.l = 0
+	e.i = -1
+	e.n = 0
+	e.err = nil
+
+	count, n := binary.Uvarint(b)
+	if n <= 0 || count == 0 || count > stringDictionaryMaxSize {
+		return fmt.Errorf("StringDecoder: invalid dictionary size")
+	}
+	b = b[n:]
+
+	for i := 0; i < int(count); i++ {
+		length, n := binary.Uvarint(b)
+		if n <= 0 || length > uint64(len(b)-n) {
+			return fmt.Errorf("StringDecoder: not enough data to represent dictionary string")
+		}
+		e.dict = append(e.dict, string(b[n:n+int(length)]))
+		b = b[n+int(length):]
+	}
+
+	values, n := binary.Uvarint(b)
+	if n <= 0 {
+		return fmt.Errorf("StringDecoder: invalid value count")
+	}
+	b = b[n:]
+
+	e.width = stringDictionaryWidth(len(e.dict))
+	if values > math.MaxUint32 || values*uint64(e.width) > uint64(len(b))*8 {
+		return fmt.Errorf("StringDecoder: not enough data to represent dictionary indices")
+	}
+
+	e.b = b
+	e.n = int(values)
+	return nil
+}
+
+func (e *StringDecoder) readDictionary() string {
+	if e.width == 0 {
+		return e.dict[0]
+	}
+
+	pos := uint(e.i) * e.width
+	v := uint16(e.b[pos>>3])
+	if int(pos>>3)+1 < len(e.b) {
+		v |= uint16(e.b[pos>>3+1]) << 8
+	}
+	idx := int(v>>(pos&7)) & (1<<e.width - 1)
+
+	if idx >= len(e.dict) {
+		e.err = fmt.Errorf("StringDecoder: invalid dictionary index")
+		return ""
+	}
+	return e.dict[idx]
+}
@@ -86,6 +86,126 @@ func Test_StringEncoder_Multi_Compressed(t *testing.T) {
 	}
 }
 
+func Test_StringEncoder_Multi_Dictionary(t *testing.T) {
+	enc := NewStringEncoder(1024)
+
+	states := []string{"ok", "warn", "crit"}
+	values := make([]string, 1000)
+	for i := range values {
+		values[i] = states[(i/7)%len(states)]
+		enc.Write(values[i])
+	}
+
+	b, err := enc.Bytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if b[0]>>4 != stringCompressedDictionary {
+		t.Fatalf("unexpected encoding: got %v, exp %v", b[0]>>4, stringCompressedDictionary)
+	}
+
+	// header + dictionary + count + 2 bits per value
+	if exp := 1 + 14 + 2 + 250; len(b) != exp {
+		t.Fatalf("unexpected length: got %v, exp %v", len(b), exp)
+	}
+
+	var dec StringDecoder
+	if err := dec.SetBytes(b); err != nil {
+		t.Fatalf("unexpected error creating string decoder: %v", err)
+	}
+
+	for i, v := range values {
+		if !dec.Next() {
+			t.Fatalf("unexpected next value: got false, exp true")
+		}
+		if got := dec.Read(); v != got {
+			t.Fatalf("unexpected value at pos %d: got %v, exp %v", i, got, v)
+		}
+	}
+
+	if dec.Next() {
+		t.Fatalf("unexpected next value: got true, exp false")
+	}
+	if err := dec.Error(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func Test_StringEncoder_Dictionary_Sizes(t *testing.T) {
+	for _, distinct := range []int{1, 2, 5, 128, 255, 256, 257} {
+		enc := NewStringEncoder(1024)
+
+		values := make([]string, 1000)
+		for i := range values {
+			values[i] = fmt.Sprintf("value %d", (i*7)%distinct)
+			enc.Write(values[i])
+		}
+
+		b, err := enc.Bytes()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		exp := byte(stringCompressedDictionary)
+		if distinct > stringDictionaryMaxSize {
+			exp = stringCompressedSnappy
+		}
+		if b[0]>>4 != exp {
+			t.Fatalf("unexpected encoding for %d distinct values: got %v, exp %v", distinct, b[0]>>4, exp)
+		}
+
+		var dec StringDecoder
+		if err := dec.SetBytes(b); err != nil {
+			t.Fatalf("unexpected error creating string decoder: %v", err)
+		}
+
+		got := make([]string, 0, len(values))
+		for dec.Next() {
+			got = append(got, dec.Read())
+		}
+		if err := dec.Error(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(values, got) {
+			t.Fatalf("mismatch for %d distinct values:\n\nexp=%#v\n\ngot=%#v\n\n", distinct, values, got)
+		}
+	}
+}
+
+func Test_StringEncoder_Dictionary_Reset(t *testing.T) {
+	enc := NewStringEncoder(1024)
+	for i := 0; i < 10; i++ {
+		enc.Write("a")
+	}
+	enc.Reset()
+
+	values := []string{"b", "c", "b", "c"}
+	for _, v := range values {
+		enc.Write(v)
+	}
+
+	b, err := enc.Bytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var dec StringDecoder
+	if err := dec.SetBytes(b); err != nil {
+		t.Fatalf("unexpected error creating string decoder: %v", err)
+	}
+
+	got := make([]string, 0, len(values))
+	for dec.Next() {
+		got = append(got, dec.Read())
+	}
+
+	if !reflect.DeepEqual(values, got) {
+		t.Fatalf("mismatch:\n\nexp=%#v\n\ngot=%#v\n\n", values, got)
+	}
+}
+
 func Test_StringEncoder_Quick(t *testing.T) {
 	quick.Check(func(values []string) bool {
 		expected := values
@@ -175,3 +295,37 @@ func Test_StringDecoder_CorruptSetBytes(t *testing.T) {
 		}
 	}
 }
+
+func Test_StringDecoder_CorruptDictionary(t *testing.T) {
+	cases := []string{
+		"\x20",                        // Missing dictionary size
+		"\x20\x00",                    // Empty dictionary
+		"\x20\x02\x02ok",              // Missing dictionary string
+		"\x20\x01\x02ok",              // Missing value count
+		"\x20\x02\x02ok\x01a\x09\x00", // Not enough indices
+	}
+
+	for _, c := range cases {
+		var dec StringDecoder
+		if err := dec.SetBytes([]byte(c)); err == nil {
+			t.Fatalf("exp an err, got nil: %q", c)
+		}
+	}
+}
+
+func Test_StringDecoder_CorruptDictionaryIndex(t *testing.T) {
+	// Three strings in the dictionary use 2 bits per index, the index 3 is invalid.
+	var dec StringDecoder
+	if err := dec.SetBytes([]byte("\x20\x03\x01a\x01b\x01c\x01\x03")); err != nil {
+		t.Fatal(err)
+	}
+
+	if !dec.Next() {
+		t.Fatalf("exp Next() to return true, got false")
+	}
+
+	_ = dec.Read()
+	if dec.Error() == nil {
+		t.Fatalf("exp an err, got nil")
+	}
+}
@@ -2,6 +2,8 @@ package tsm1
 
 import (
 	"bufio"
+	"bytes"
+	"compress/flate"
 	"encoding/binary"
 	"fmt"
 	"io"
@@ -20,6 +22,7 @@ import (
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/pkg/limiter"
 	"github.com/influxdata/influxdb/pkg/pool"
+	"github.com/influxdata/influxdb/tsdb"
 	"github.com/uber-go/zap"
 )
 
@@ -36,12 +39,66 @@ const (
 	// walEncodeBufSize is the size of the wal entry encoding buffer
 	walEncodeBufSize = 4 * 1024 * 1024
 
-	float64EntryType = 1
-	integerEntryType = 2
-	booleanEntryType = 3
-	stringEntryType  = 4
+	// walSegmentMagic starts the header of segments written with segment compression.
+	// Segments without a header start with an entry type and compress each entry on its
+	// own with snappy.
+	walSegmentMagic = 0x57414C53 // "WALS"
+
+	// walSegmentVersion is the version of the segment format written after walSegmentMagic.
+	walSegmentVersion = 2
+
+	// walSegmentHeaderSize is the size of the magic, version and compression codec.
+	walSegmentHeaderSize = 6
+
+	float64EntryType  = 1
+	integerEntryType  = 2
+	booleanEntryType  = 3
+	stringEntryType   = 4
+	unsignedEntryType = 5
+)
+
+// WALCompression identifies how the entries of a WAL segment are compressed.
+type WALCompression byte
+
+const (
+	// WALCompressionNone writes segments without a header, with every entry compressed
+	// on its own with snappy.  This is the format written by previous versions.
+	WALCompressionNone WALCompression = 0
+
+	// WALCompressionSnappy compresses frames of entries with snappy.
+	WALCompressionSnappy WALCompression = 1
+
+	// WALCompressionDeflate compresses frames of entries with deflate.
+	WALCompressionDeflate WALCompression = 2
 )
 
+// ParseWALCompression returns the WALCompression named s.  An empty name
+// returns WALCompressionNone.
+func ParseWALCompression(s string) (WALCompression, error) {
+	switch s {
+	case "":
+		return WALCompressionNone, nil
+	case "snappy":
+		return WALCompressionSnappy, nil
+	case "deflate":
+		return WALCompressionDeflate, nil
+	}
+	return WALCompressionNone, fmt.Errorf("unknown wal compression: %s", s)
+}
+
+// String returns the name of the compression codec.
+func (c WALCompression) String() string {
+	switch c {
+	case WALCompressionNone:
+		return ""
+	case WALCompressionSnappy:
+		return "snappy"
+	case WALCompressionDeflate:
+		return "deflate"
+	}
+	return strconv.Itoa(int(c))
+}
+
 // WalEntryType is a byte written to a wal segment file that indicates what the following compressed block contains.
 type WalEntryType byte
 
@@ -75,13 +132,25 @@ const (
 	statWALCurrentBytes = "currentSegmentDiskBytes"
 	statWriteOk         = "writeOk"
 	statWriteErr        = "writeErr"
+
+	statWALUncompressedBytes = "uncompressedBytes"
+	statWALCompressedBytes   = "compressedBytes"
+	statWALCompressionRatio  = "compressionRatio"
+
+	statWALBatches    = "batches"
+	statWALBatchSize  = "batchSize"
+	statWALQueueDepth = "queueDepth"
 )
 
+// walHistogramBuckets is the number of buckets of a WALHistogram.  Bucket i counts
+// values up to 1<<i, except for the last bucket which counts all larger values.
+const walHistogramBuckets = 12
+
 // WAL represents the write-ahead log used for writing TSM files.
 type WAL struct {
-	// goroutines waiting for the next fsync
-	syncCount   uint64
-	syncWaiters chan chan error
+	// writes waiting to be committed and the number of writers sending them
+	writes chan *walWrite
+	queued int64
 
 	mu            sync.RWMutex
 	lastWriteTime time.Time
@@ -95,12 +164,21 @@ type WAL struct {
 	// cache and flush variables
 	once    sync.Once
 	closing chan struct{}
+	wg      sync.WaitGroup
 
-	// syncDelay sets the duration to wait before fsyncing writes.  A value of 0 (default)
-	// will cause every write to be fsync'd.  This must be set before the WAL
-	// is opened if a non-default value is required.
+	// syncDelay sets the longest duration to wait for more writes to commit with the
+	// first write of a batch.  A value of 0 (default) commits the writes already waiting.
+	// This must be set before the WAL is opened if a non-default value is required.
 	syncDelay time.Duration
 
+	// maxBatchSize sets the maximum number of writes committed with a single fsync.
+	// This must be set before the WAL is opened if a non-default value is required.
+	maxBatchSize int
+
+	// compression sets how new segments are compressed.  This must be set before
+	// the WAL is opened if a non-default value is required.
+	compression WALCompression
+
 	// WALOutput is the writer used by the logger.
 	logger       zap.Logger // Logger to be used for important messages
 	traceLogger  zap.Logger // Logger to be used when trace-logging is on.
@@ -121,13 +199,14 @@ func NewWAL(path string) *WAL {
 		path: path,
 
 		// these options should be overriden by any options in the config
-		SegmentSize: DefaultSegmentSize,
-		closing:     make(chan struct{}),
-		syncWaiters: make(chan chan error, 1024),
-		stats:       &WALStatistics{},
-		limiter:     limiter.NewFixed(defaultWaitingWALWrites),
-		logger:      logger,
-		traceLogger: logger,
+		SegmentSize:  DefaultSegmentSize,
+		closing:      make(chan struct{}),
+		writes:       make(chan *walWrite),
+		maxBatchSize: tsdb.DefaultWALMaxBatchSize,
+		stats:        &WALStatistics{},
+		limiter:      limiter.NewFixed(defaultWaitingWALWrites),
+		logger:       logger,
+		traceLogger:  logger,
 	}
 }
 
@@ -150,23 +229,72 @@ func (l *WAL) WithLogger(log zap.Logger) {
 
 // WALStatistics maintains statistics about the WAL.
 type WALStatistics struct {
-	OldBytes     int64
-	CurrentBytes int64
-	WriteOK      int64
-	WriteErr     int64
+	OldBytes          int64
+	CurrentBytes      int64
+	WriteOK           int64
+	WriteErr          int64
+	UncompressedBytes int64
+	CompressedBytes   int64
+	Batches           int64
+	BatchSize         WALHistogram
+	QueueDepth        WALHistogram
+}
+
+// WALHistogram counts values in power of two buckets.
+type WALHistogram [walHistogramBuckets]int64
+
+// add adds v to the bucket holding it.
+func (h *WALHistogram) add(v int) {
+	i := 0
+	for i < len(h)-1 && v > 1<<uint(i) {
+		i++
+	}
+	atomic.AddInt64(&h[i], 1)
+}
+
+// addStatistics adds the cumulative count of values less than or equal to the upper bound
+// of each bucket to values.  The keys are name followed by "Le" and the bound.
+func (h *WALHistogram) addStatistics(name string, values map[string]interface{}) {
+	var n int64
+	for i := range h {
+		n += atomic.LoadInt64(&h[i])
+		if i < len(h)-1 {
+			values[fmt.Sprintf("%sLe%d", name, 1<<uint(i))] = n
+		} else {
+			values[name+"LeInf"] = n
+		}
+	}
 }
 
 // Statistics returns statistics for periodic monitoring.
 func (l *WAL) Statistics(tags map[string]string) []models.Statistic {
+	uncompressed := atomic.LoadInt64(&l.stats.UncompressedBytes)
+	compressed := atomic.LoadInt64(&l.stats.CompressedBytes)
+
+	var ratio float64
+	if compressed > 0 {
+		ratio = float64(uncompressed) / float64(compressed)
+	}
+
+	values := map[string]interface{}{
+		statWALOldBytes:     atomic.LoadInt64(&l.stats.OldBytes),
+		statWALCurrentBytes: atomic.LoadInt64(&l.stats.CurrentBytes),
+		statWriteOk:         atomic.LoadInt64(&l.stats.WriteOK),
+		statWriteErr:        atomic.LoadInt64(&l.stats.WriteErr),
+
+		statWALUncompressedBytes: uncompressed,
+		statWALCompressedBytes:   compressed,
+		statWALCompressionRatio:  ratio,
+
+		statWALBatches: atomic.LoadInt64(&l.stats.Batches),
+	}
+	l.stats.BatchSize.addStatistics(statWALBatchSize, values)
+	l.stats.QueueDepth.addStatistics(statWALQueueDepth, values)
+
 	return []models.Statistic{{
-		Name: "tsm1_wal",
-		Tags: tags,
-		Values: map[string]interface{}{
-			statWALOldBytes:     atomic.LoadInt64(&l.stats.OldBytes),
-			statWALCurrentBytes: atomic.LoadInt64(&l.stats.CurrentBytes),
-			statWriteOk:         atomic.LoadInt64(&l.stats.WriteOK),
-			statWriteErr:        atomic.LoadInt64(&l.stats.WriteErr),
-		},
+		Name:   "tsm1_wal",
+		Tags:   tags,
+		Values: values,
 	}}
 }
 
@@ -189,6 +317,13 @@ func (l *WAL) Open() error {
 		return err
 	}
 
+	// Allow enough writes in flight to fill a batch.
+	n := defaultWaitingWALWrites
+	if l.maxBatchSize > n {
+		n = l.maxBatchSize
+	}
+	l.limiter = limiter.NewFixed(n)
+
 	segments, err := segmentFileNames(l.path)
 	if err != nil {
 		return err
@@ -232,62 +367,142 @@ func (l *WAL) Open() error {
 
 	l.closing = make(chan struct{})
 
+	l.wg.Add(1)
+	go l.commitWrites(l.closing)
+
 	return nil
 }
 
-// scheduleSync will schedule an fsync to the current wal segment and notify any
-// waiting gorutines.  If an fsync is already scheduled, subsequent calls will
-// not schedule a new fsync and will be handle by the existing scheduled fsync.
-func (l *WAL) scheduleSync() {
-	// If we're not the first to sync, then another goroutine is fsyncing the wal for us.
-	if !atomic.CompareAndSwapUint64(&l.syncCount, 0, 1) {
-		return
-	}
+// walWrite is an encoded entry waiting to be committed to the WAL.
+type walWrite struct {
+	typ  WalEntryType
+	data []byte // entry data, compressed with snappy for uncompressed segments
+	size int    // size of the uncompressed entry data
 
-	// Fsync the wal and notify all pending waiters
-	go func() {
-		defer atomic.StoreUint64(&l.syncCount, 0)
-		var timerCh <-chan time.Time
+	segID int
+	err   error
+	done  chan struct{}
+}
 
-		// time.NewTicker requires a > 0 delay, since 0 indicates no delay, use a closed
-		// channel which will always be ready to read from.
-		if l.syncDelay == 0 {
-			// Create a RW chan and close it
-			timerChrw := make(chan time.Time)
-			close(timerChrw)
-			// Convert it to a read-only
-			timerCh = timerChrw
-		} else {
-			t := time.NewTicker(l.syncDelay)
-			defer t.Stop()
-			timerCh = t.C
+// commitWrites commits the writes sent to the WAL in batches until closing is closed.
+// Each batch holds the first waiting write and the writes sent within syncDelay of it,
+// up to maxBatchSize writes, and is written to the current segment with a single fsync.
+func (l *WAL) commitWrites(closing <-chan struct{}) {
+	defer l.wg.Done()
+
+	batch := make([]*walWrite, 0, l.maxBatchSize)
+	for {
+		batch = batch[:0]
+		select {
+		case w := <-l.writes:
+			batch = append(batch, w)
+		case <-closing:
+			return
 		}
-		for {
-			select {
-			case <-timerCh:
-				l.mu.Lock()
-				if len(l.syncWaiters) == 0 {
-					l.mu.Unlock()
-					return
+		l.stats.QueueDepth.add(int(atomic.LoadInt64(&l.queued)))
+
+		var timer *time.Timer
+		if l.syncDelay > 0 {
+			timer = time.NewTimer(l.syncDelay)
+		}
+
+	collect:
+		for len(batch) < l.maxBatchSize {
+			if timer == nil {
+				select {
+				case w := <-l.writes:
+					batch = append(batch, w)
+				default:
+					break collect
 				}
+				continue
+			}
 
-				l.sync()
-				l.mu.Unlock()
-			case <-l.closing:
-				return
+			select {
+			case w := <-l.writes:
+				batch = append(batch, w)
+			case <-timer.C:
+				break collect
+			case <-closing:
+				break collect
 			}
 		}
-	}()
+		if timer != nil {
+			timer.Stop()
+		}
+
+		l.commitBatch(batch)
+		l.stats.BatchSize.add(len(batch))
+		atomic.AddInt64(&l.stats.Batches, 1)
+
+		for _, w := range batch {
+			close(w.done)
+		}
+	}
 }
 
-// sync fsyncs the current wal segments and notifies any waiters.  Callers must ensure
-// a write lock on the WAL is obtained before calling sync.
-func (l *WAL) sync() {
-	err := l.currentSegmentWriter.sync()
-	for len(l.syncWaiters) > 0 {
-		errC := <-l.syncWaiters
-		errC <- err
+// commitBatch writes batch to the current segment, rolling it if needed, and fsyncs
+// it.  The result of each write is stored in the write.
+func (l *WAL) commitBatch(batch []*walWrite) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Make sure the log has not been closed
+	select {
+	case <-l.closing:
+		for _, w := range batch {
+			w.segID, w.err = -1, ErrWALClosed
+		}
+		return
+	default:
+	}
+
+	var written []*walWrite
+	for _, w := range batch {
+		// roll the segment file if needed
+		if err := l.rollSegment(); err != nil {
+			// Writes to the previous segment may not have been synced.
+			err = fmt.Errorf("error rolling WAL segment: %v", err)
+			for _, w := range written {
+				w.segID, w.err = -1, err
+			}
+			written = written[:0]
+			w.segID, w.err = -1, err
+			continue
+		}
+
+		if err := l.currentSegmentWriter.Write(w.typ, w.data); err != nil {
+			w.segID, w.err = -1, fmt.Errorf("error writing WAL entry: %v", err)
+			continue
+		}
+		atomic.AddInt64(&l.stats.UncompressedBytes, int64(w.size))
+
+		w.segID = l.currentSegmentID
+		written = append(written, w)
+	}
+
+	if len(written) == 0 {
+		return
+	}
+
+	if err := l.sync(); err != nil {
+		for _, w := range written {
+			w.segID, w.err = -1, err
+		}
 	}
+
+	l.lastWriteTime = time.Now()
+}
+
+// sync fsyncs the current wal segment.  Callers must ensure a write lock on the WAL
+// is obtained before calling sync.
+func (l *WAL) sync() error {
+	err := l.currentSegmentWriter.sync()
+
+	// Update stats for current segment size
+	atomic.StoreInt64(&l.stats.CurrentBytes, int64(l.currentSegmentWriter.size))
+
+	return err
 }
 
 // WriteMulti writes the given values to the WAL. It returns the WAL segment ID to
@@ -382,8 +597,12 @@ func (l *WAL) DiskSizeBytes() int64 {
 
 func (l *WAL) writeToLog(entry WALEntry) (int, error) {
 	// limit how many concurrent encodings can be in flight.  Since we can only
-	// write one at a time to disk, a slow disk can cause the allocations below
+	// write one batch at a time to disk, a slow disk can cause the allocations below
 	// to increase quickly.  If we're backed up, wait until others have completed.
+	l.limiter.Take()
+	defer l.limiter.Release()
+
+	// Entries are encoded by the writers so that only writing to the segment is serialized.
 	bytes := bytesPool.Get(entry.MarshalSize())
 
 	b, err := entry.Encode(bytes)
@@ -392,58 +611,43 @@ func (l *WAL) writeToLog(entry WALEntry) (int, error) {
 		return -1, err
 	}
 
-	encBuf := bytesPool.Get(snappy.MaxEncodedLen(len(b)))
-
-	compressed := snappy.Encode(encBuf, b)
-	bytesPool.Put(bytes)
-
-	syncErr := make(chan error)
-
-	segID, err := func() (int, error) {
-		l.mu.Lock()
-		defer l.mu.Unlock()
-
-		// Make sure the log has not been closed
-		select {
-		case <-l.closing:
-			return -1, ErrWALClosed
-		default:
-		}
-
-		// roll the segment file if needed
-		if err := l.rollSegment(); err != nil {
-			return -1, fmt.Errorf("error rolling WAL segment: %v", err)
-		}
-
-		// write and sync
-		if err := l.currentSegmentWriter.Write(entry.Type(), compressed); err != nil {
-			return -1, fmt.Errorf("error writing WAL entry: %v", err)
-		}
+	// Entries of segments with segment compression are compressed with the rest of
+	// their frame when the segment is synced.
+	w := &walWrite{
+		typ:  entry.Type(),
+		data: b,
+		size: len(b),
+		done: make(chan struct{}),
+	}
+	var encBuf []byte
+	if l.compression == WALCompressionNone {
+		encBuf = bytesPool.Get(snappy.MaxEncodedLen(len(b)))
+		w.data = snappy.Encode(encBuf, b)
+	}
 
-		select {
-		case l.syncWaiters <- syncErr:
-		default:
-			return -1, fmt.Errorf("error syncing wal")
+	defer func() {
+		bytesPool.Put(bytes)
+		if encBuf != nil {
+			bytesPool.Put(encBuf)
 		}
-		l.scheduleSync()
-
-		// Update stats for current segment size
-		atomic.StoreInt64(&l.stats.CurrentBytes, int64(l.currentSegmentWriter.size))
-
-		l.lastWriteTime = time.Now()
-
-		return l.currentSegmentID, nil
-
 	}()
 
-	bytesPool.Put(encBuf)
+	l.mu.RLock()
+	closing := l.closing
+	l.mu.RUnlock()
 
-	if err != nil {
-		return segID, err
+	// Wait for the write to be committed with the next batch.
+	atomic.AddInt64(&l.queued, 1)
+	select {
+	case l.writes <- w:
+		atomic.AddInt64(&l.queued, -1)
+	case <-closing:
+		atomic.AddInt64(&l.queued, -1)
+		return -1, ErrWALClosed
 	}
+	<-w.done
 
-	// schedule an fsync and wait for it to complete
-	return segID, <-syncErr
+	return w.segID, w.err
 }
 
 // rollSegment checks if the current segment is due to roll over to a new segment;
@@ -514,8 +718,6 @@ func (l *WAL) DeleteRange(keys []string, min, max int64) (int, error) {
 // Close will finish any flush that is currently in progress and close file handles.
 func (l *WAL) Close() error {
 	l.mu.Lock()
-	defer l.mu.Unlock()
-
 	l.once.Do(func() {
 		// Close, but don't set to nil so future goroutines can still be signaled
 		l.traceLogger.Info(fmt.Sprintf("Closing %s", l.path))
@@ -527,6 +729,10 @@ func (l *WAL) Close() error {
 			l.currentSegmentWriter = nil
 		}
 	})
+	l.mu.Unlock()
+
+	// Wait for writes waiting to be committed to fail.
+	l.wg.Wait()
 
 	return nil
 }
@@ -545,7 +751,9 @@ func segmentFileNames(dir string) ([]string, error) {
 func (l *WAL) newSegmentFile() error {
 	l.currentSegmentID++
 	if l.currentSegmentWriter != nil {
-		l.sync()
+		if err := l.sync(); err != nil {
+			return err
+		}
 
 		if err := l.currentSegmentWriter.close(); err != nil {
 			return err
@@ -558,7 +766,13 @@ func (l *WAL) newSegmentFile() error {
 	if err != nil {
 		return err
 	}
-	l.currentSegmentWriter = NewWALSegmentWriter(fd)
+	w, err := NewCompressedWALSegmentWriter(fd, l.compression)
+	if err != nil {
+		fd.Close()
+		return err
+	}
+	w.stats = l.stats
+	l.currentSegmentWriter = w
 
 	if stat, err := fd.Stat(); err == nil {
 		l.lastWriteTime = stat.ModTime()
@@ -603,7 +817,7 @@ func (w *WriteWALEntry) MarshalSize() int {
 		encLen += 8 * len(v) // timestamps (8)
 
 		switch v[0].(type) {
-		case FloatValue, IntegerValue:
+		case FloatValue, IntegerValue, UnsignedValue:
 			encLen += 8 * len(v)
 		case BooleanValue:
 			encLen += 1 * len(v)
@@ -635,7 +849,7 @@ func (w *WriteWALEntry) Encode(dst []byte) ([]byte, error) {
 	// slice is written.  Following the type, the length and key bytes are written.
 	// Following the key, a 4 byte count followed by each value as a 8 byte time
 	// and N byte value.  The value is dependent on the type being encoded.  float64,
-	// int64, use 8 bytes, boolean uses 1 byte, and string is similar to the key encoding,
+	// int64 and uint64 use 8 bytes, boolean uses 1 byte, and string is similar to the key encoding,
 	// except that string values have a 4-byte length, and keys only use 2 bytes.
 	//
 	// This structure is then repeated for each key an value slices.
@@ -666,6 +880,8 @@ func (w *WriteWALEntry) Encode(dst []byte) ([]byte, error) {
 			curType = float64EntryType
 		case IntegerValue:
 			curType = integerEntryType
+		case UnsignedValue:
+			curType = unsignedEntryType
 		case BooleanValue:
 			curType = booleanEntryType
 		case StringValue:
@@ -700,6 +916,12 @@ func (w *WriteWALEntry) Encode(dst []byte) ([]byte, error) {
 				}
 				binary.BigEndian.PutUint64(dst[n:n+8], uint64(vv.value))
 				n += 8
+			case UnsignedValue:
+				if curType != unsignedEntryType {
+					return nil, fmt.Errorf("incorrect value found in %T slice: %T", v[0].Value(), vv)
+				}
+				binary.BigEndian.PutUint64(dst[n:n+8], vv.value)
+				n += 8
 			case BooleanValue:
 				if curType != booleanEntryType {
 					return nil, fmt.Errorf("incorrect value found in %T slice: %T", v[0].Value(), vv)
@@ -791,6 +1013,21 @@ func (w *WriteWALEntry) UnmarshalBinary(b []byte) error {
 			}
 			w.Values[k] = values
 
+		case unsignedEntryType:
+			if i+16*nvals > len(b) {
+				return ErrWALCorrupt
+			}
+
+			values := make([]Value, 0, nvals)
+			for j := 0; j < nvals; j++ {
+				un := int64(binary.BigEndian.Uint64(b[i : i+8]))
+				i += 8
+				v := binary.BigEndian.Uint64(b[i : i+8])
+				i += 8
+				values = append(values, NewUnsignedValue(un, v))
+			}
+			w.Values[k] = values
+
 		case booleanEntryType:
 			if i+9*nvals > len(b) {
 				return ErrWALCorrupt
@@ -988,13 +1225,30 @@ func (w *DeleteRangeWALEntry) Type() WalEntryType {
 }
 
 // WALSegmentWriter writes WAL segments.
+//
+// Segments written without compression are a sequence of entries, each made of a 1 byte
+// entry type, a 4 byte length and the entry data compressed with snappy.
+//
+// Compressed segments start with a header made of a 4 byte magic number, a 1 byte version
+// and a 1 byte compression codec, followed by a sequence of frames.  Each frame is a 4 byte
+// length and a compressed block holding the entries written between two syncs, in the same
+// layout as uncompressed segments but without compressing each entry.
 type WALSegmentWriter struct {
 	bw   *bufio.Writer
 	w    io.WriteCloser
 	size int
+
+	compression WALCompression
+	written     int    // bytes of the header and frames written to bw
+	frame       []byte // entries of the frame being built
+	buf         []byte // compressed frame buffer
+	fw          *flate.Writer
+	fbuf        bytes.Buffer
+
+	stats *WALStatistics
 }
 
-// NewWALSegmentWriter returns a new WALSegmentWriter writing to w.
+// NewWALSegmentWriter returns a new WALSegmentWriter writing an uncompressed segment to w.
 func NewWALSegmentWriter(w io.WriteCloser) *WALSegmentWriter {
 	return &WALSegmentWriter{
 		bw: bufio.NewWriter(w),
@@ -1002,6 +1256,25 @@ func NewWALSegmentWriter(w io.WriteCloser) *WALSegmentWriter {
 	}
 }
 
+// NewCompressedWALSegmentWriter returns a new WALSegmentWriter writing a segment to w
+// whose entries are compressed in frames using c.
+func NewCompressedWALSegmentWriter(w io.WriteCloser, c WALCompression) (*WALSegmentWriter, error) {
+	sw := NewWALSegmentWriter(w)
+	switch c {
+	case WALCompressionNone, WALCompressionSnappy:
+	case WALCompressionDeflate:
+		fw, err := flate.NewWriter(&sw.fbuf, flate.BestSpeed)
+		if err != nil {
+			return nil, err
+		}
+		sw.fw = fw
+	default:
+		return nil, fmt.Errorf("unknown wal compression: %d", c)
+	}
+	sw.compression = c
+	return sw, nil
+}
+
 func (w *WALSegmentWriter) path() string {
 	if f, ok := w.w.(*os.File); ok {
 		return f.Name()
@@ -1009,29 +1282,103 @@ func (w *WALSegmentWriter) path() string {
 	return ""
 }
 
-// Write writes entryType and the buffer containing compressed entry data.
-func (w *WALSegmentWriter) Write(entryType WalEntryType, compressed []byte) error {
+// Write writes entryType and the buffer containing the entry data.  The data must be
+// compressed with snappy if the segment is not compressed.  Otherwise it is buffered
+// uncompressed until the next frame is written by a call to Flush.
+func (w *WALSegmentWriter) Write(entryType WalEntryType, data []byte) error {
 	var buf [5]byte
 	buf[0] = byte(entryType)
-	binary.BigEndian.PutUint32(buf[1:5], uint32(len(compressed)))
+	binary.BigEndian.PutUint32(buf[1:5], uint32(len(data)))
+
+	if w.compression != WALCompressionNone {
+		w.frame = append(w.frame, buf[:]...)
+		w.frame = append(w.frame, data...)
+		w.size = w.written + len(w.frame)
+		return nil
+	}
 
 	if _, err := w.bw.Write(buf[:]); err != nil {
 		return err
 	}
 
-	if _, err := w.bw.Write(compressed); err != nil {
+	if _, err := w.bw.Write(data); err != nil {
+		return err
+	}
+
+	w.size += len(buf) + len(data)
+	w.addCompressedBytes(len(buf) + len(data))
+
+	return nil
+}
+
+// writeFrame compresses the buffered entries of a compressed segment and writes them
+// as a frame, writing the segment header first if needed.
+func (w *WALSegmentWriter) writeFrame() error {
+	if len(w.frame) == 0 {
+		return nil
+	}
+
+	var b []byte
+	switch w.compression {
+	case WALCompressionSnappy:
+		if n := snappy.MaxEncodedLen(len(w.frame)); cap(w.buf) < n {
+			w.buf = make([]byte, n)
+		}
+		b = snappy.Encode(w.buf[:cap(w.buf)], w.frame)
+	case WALCompressionDeflate:
+		w.fbuf.Reset()
+		w.fw.Reset(&w.fbuf)
+		if _, err := w.fw.Write(w.frame); err != nil {
+			return err
+		}
+		if err := w.fw.Close(); err != nil {
+			return err
+		}
+		b = w.fbuf.Bytes()
+	}
+
+	var n int
+	if w.written == 0 {
+		var hdr [walSegmentHeaderSize]byte
+		binary.BigEndian.PutUint32(hdr[0:4], walSegmentMagic)
+		hdr[4] = walSegmentVersion
+		hdr[5] = byte(w.compression)
+		if _, err := w.bw.Write(hdr[:]); err != nil {
+			return err
+		}
+		n += len(hdr)
+	}
+
+	var lv [4]byte
+	binary.BigEndian.PutUint32(lv[:], uint32(len(b)))
+	if _, err := w.bw.Write(lv[:]); err != nil {
+		return err
+	}
+
+	if _, err := w.bw.Write(b); err != nil {
 		return err
 	}
+	n += len(lv) + len(b)
 
-	w.size += len(buf) + len(compressed)
+	w.written += n
+	w.frame = w.frame[:0]
+	w.size = w.written
+	w.addCompressedBytes(n)
 
 	return nil
 }
 
+// addCompressedBytes adds n to the compressed bytes statistic of the WAL, if any.
+func (w *WALSegmentWriter) addCompressedBytes(n int) {
+	if w.stats != nil {
+		atomic.AddInt64(&w.stats.CompressedBytes, int64(n))
+	}
+}
+
 // Sync flushes the file systems in-memory copy of recently written data to disk,
 // if w is writing to an os.File.
 func (w *WALSegmentWriter) sync() error {
-	if err := w.bw.Flush(); err != nil {
+	if err := w.Flush(); err != nil {
 		return err
 	}
 
@@ -1041,7 +1388,11 @@ func (w *WALSegmentWriter) sync() error {
 	return nil
 }
 
+// Flush writes any buffered entries to the underlying writer.
 func (w *WALSegmentWriter) Flush() error {
+	if err := w.writeFrame(); err != nil {
+		return err
+	}
 	return w.bw.Flush()
 }
 
@@ -1052,13 +1403,22 @@ func (w *WALSegmentWriter) close() error {
 	return w.w.Close()
 }
 
-// WALSegmentReader reads WAL segments.
+// WALSegmentReader reads WAL segments.  It reads both uncompressed segments and
+// segments compressed in frames.
 type WALSegmentReader struct {
 	rc    io.ReadCloser
-	r     io.Reader
+	r     *bufio.Reader
 	entry WALEntry
 	n     int64
 	err   error
+
+	header      bool           // whether the segment header was read
+	compression WALCompression // compression of the segment
+	frame       []byte         // unread entries of the current frame
+	frameEnd    int64          // offset of the end of the current frame
+	buf         []byte         // decompressed frame buffer
+	fr          io.ReadCloser
+	fbuf        bytes.Buffer
 }
 
 // NewWALSegmentReader returns a new WALSegmentReader reading from r.
@@ -1071,6 +1431,18 @@ func NewWALSegmentReader(r io.ReadCloser) *WALSegmentReader {
 
 // Next indicates if there is a value to read.
 func (r *WALSegmentReader) Next() bool {
+	if !r.header {
+		r.header = true
+		if err := r.readHeader(); err != nil {
+			r.err = err
+			return true
+		}
+	}
+
+	if r.compression != WALCompressionNone {
+		return r.nextFrameEntry()
+	}
+
 	var nReadOK int
 
 	// read the type and the length of the entry
@@ -1116,7 +1488,134 @@ func (r *WALSegmentReader) Next() bool {
 		return true
 	}
 
-	// and marshal it and send it to the cache
+	r.err = r.decodeEntry(entryType, data)
+	if r.err == nil {
+		// Read and decode of this entry was successful.
+		r.n += int64(nReadOK)
+	}
+
+	return true
+}
+
+// readHeader reads the header of compressed segments.  Segments that don't start
+// with walSegmentMagic are read as uncompressed segments.
+func (r *WALSegmentReader) readHeader() error {
+	b, err := r.r.Peek(4)
+	if err != nil || binary.BigEndian.Uint32(b) != walSegmentMagic {
+		// Short segments are handled when reading the first entry.
+		return nil
+	}
+
+	var hdr [walSegmentHeaderSize]byte
+	if _, err := io.ReadFull(r.r, hdr[:]); err != nil {
+		return err
+	}
+
+	if hdr[4] != walSegmentVersion {
+		return fmt.Errorf("unsupported wal segment version: %d", hdr[4])
+	}
+
+	switch c := WALCompression(hdr[5]); c {
+	case WALCompressionSnappy, WALCompressionDeflate:
+		r.compression = c
+	default:
+		return fmt.Errorf("unknown wal compression: %d", c)
+	}
+
+	r.n = walSegmentHeaderSize
+	r.frameEnd = r.n
+	return nil
+}
+
+// nextFrameEntry reads the next entry of a compressed segment, reading the next frame
+// once every entry of the current one has been read.
+func (r *WALSegmentReader) nextFrameEntry() bool {
+	for len(r.frame) == 0 {
+		err := r.readFrame()
+		if err == io.EOF {
+			return false
+		} else if err != nil {
+			r.err = err
+			return true
+		}
+	}
+
+	if len(r.frame) < 5 {
+		r.err = ErrWALCorrupt
+		return true
+	}
+
+	entryType := r.frame[0]
+	length := binary.BigEndian.Uint32(r.frame[1:5])
+	if uint64(len(r.frame)-5) < uint64(length) {
+		r.err = ErrWALCorrupt
+		return true
+	}
+
+	data := r.frame[5 : 5+length]
+	r.frame = r.frame[5+length:]
+
+	r.err = r.decodeEntry(entryType, data)
+	if r.err == nil && len(r.frame) == 0 {
+		// Every entry of the frame was read and decoded successfully.  The segment is
+		// only truncated on frame boundaries since entries can't be read without the
+		// frames that precede them.
+		r.n = r.frameEnd
+	}
+
+	return true
+}
+
+// readFrame reads and decompresses the next frame of a compressed segment.
+func (r *WALSegmentReader) readFrame() error {
+	var lv [4]byte
+	if _, err := io.ReadFull(r.r, lv[:]); err != nil {
+		return err
+	}
+	length := binary.BigEndian.Uint32(lv[:])
+
+	b := *(getBuf(int(length)))
+	defer putBuf(&b)
+
+	if _, err := io.ReadFull(r.r, b[:length]); err != nil {
+		return err
+	}
+
+	switch r.compression {
+	case WALCompressionSnappy:
+		decLen, err := snappy.DecodedLen(b[:length])
+		if err != nil {
+			return err
+		}
+		if cap(r.buf) < decLen {
+			r.buf = make([]byte, decLen)
+		}
+
+		data, err := snappy.Decode(r.buf[:decLen], b[:length])
+		if err != nil {
+			return err
+		}
+		r.frame = data
+	case WALCompressionDeflate:
+		if r.fr == nil {
+			r.fr = flate.NewReader(bytes.NewReader(b[:length]))
+		} else if err := r.fr.(flate.Resetter).Reset(bytes.NewReader(b[:length]), nil); err != nil {
+			return err
+		}
+
+		r.fbuf.Reset()
+		if _, err := r.fbuf.ReadFrom(r.fr); err != nil {
+			return err
+		}
+		r.frame = r.fbuf.Bytes()
+	}
+
+	r.frameEnd += int64(len(lv)) + int64(length)
+	return nil
+}
+
+// decodeEntry unmarshals data into a new entry of type entryType.
+func (r *WALSegmentReader) decodeEntry(entryType byte, data []byte) error {
 	switch WalEntryType(entryType) {
 	case WriteWALEntryType:
 		r.entry = &WriteWALEntry{
@@ -1127,16 +1626,9 @@ func (r *WALSegmentReader) Next() bool {
 	case DeleteRangeWALEntryType:
 		r.entry = &DeleteRangeWALEntry{}
 	default:
-		r.err = fmt.Errorf("unknown wal entry type: %v", entryType)
-		return true
+		return fmt.Errorf("unknown wal entry type: %v", entryType)
 	}
-	r.err = r.entry.UnmarshalBinary(data)
-	if r.err == nil {
-		// Read and decode of this entry was successful.
-		r.n += int64(nReadOK)
-	}
-
-	return true
+	return r.entry.UnmarshalBinary(data)
 }
 
 // Read returns the next entry in the reader.
@@ -0,0 +1,163 @@
+package tsm1
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Ensure concurrent writes are committed to the WAL in a single batch.
+func TestWAL_GroupCommit(t *testing.T) {
+	dir := mustTempDir()
+	defer os.RemoveAll(dir)
+
+	const n = 8
+
+	// The batch is committed once it is full, long before the sync delay.
+	w := NewWAL(dir)
+	w.syncDelay = time.Hour
+	w.maxBatchSize = n
+	if err := w.Open(); err != nil {
+		t.Fatalf("error opening WAL: %v", err)
+	}
+	defer w.Close()
+
+	var wg sync.WaitGroup
+	errC := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := w.WriteMulti(map[string][]Value{
+				fmt.Sprintf("cpu,host=%d#!~#value", i): []Value{NewValue(1, float64(i))},
+			})
+			errC <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errC)
+
+	for err := range errC {
+		if err != nil {
+			t.Fatalf("error writing points: %v", err)
+		}
+	}
+
+	if got, exp := atomic.LoadInt64(&w.stats.Batches), int64(1); got != exp {
+		t.Fatalf("batches mismatch: got %v, exp %v", got, exp)
+	}
+
+	stats := w.Statistics(nil)[0].Values
+	if got, exp := stats["batchSizeLe4"], int64(0); got != exp {
+		t.Fatalf("batchSizeLe4 mismatch: got %v, exp %v", got, exp)
+	}
+	if got, exp := stats["batchSizeLe8"], int64(1); got != exp {
+		t.Fatalf("batchSizeLe8 mismatch: got %v, exp %v", got, exp)
+	}
+
+	// Every write must be durable once WriteMulti returns.
+	files, err := segmentFileNames(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache := NewCache(1024*1024, "")
+	if err := NewCacheLoader(files).Load(cache); err != nil {
+		t.Fatalf("failed to load cache: %s", err.Error())
+	}
+	if got, exp := len(cache.Keys()), n; got != exp {
+		t.Fatalf("cache keys mismatch: got %v, exp %v", got, exp)
+	}
+}
+
+// Ensure a write is committed after the sync delay when no other writes are sent.
+func TestWAL_GroupCommit_MaxWait(t *testing.T) {
+	dir := mustTempDir()
+	defer os.RemoveAll(dir)
+
+	w := NewWAL(dir)
+	w.syncDelay = 10 * time.Millisecond
+	if err := w.Open(); err != nil {
+		t.Fatalf("error opening WAL: %v", err)
+	}
+	defer w.Close()
+
+	if _, err := w.WriteMulti(map[string][]Value{
+		"cpu,host=A#!~#value": []Value{NewValue(1, 1.1)},
+	}); err != nil {
+		t.Fatalf("error writing points: %v", err)
+	}
+
+	if got, exp := atomic.LoadInt64(&w.stats.Batches), int64(1); got != exp {
+		t.Fatalf("batches mismatch: got %v, exp %v", got, exp)
+	}
+}
+
+// Ensure the writes in flight are limited but can always fill a batch.
+func TestWAL_Open_Limiter(t *testing.T) {
+	dir := mustTempDir()
+	defer os.RemoveAll(dir)
+
+	for _, n := range []int{1, defaultWaitingWALWrites + 1} {
+		w := NewWAL(dir)
+		w.maxBatchSize = n
+		if err := w.Open(); err != nil {
+			t.Fatalf("error opening WAL: %v", err)
+		}
+
+		exp := defaultWaitingWALWrites
+		if n > exp {
+			exp = n
+		}
+		if got := cap(w.limiter); got != exp {
+			t.Fatalf("limiter size mismatch for batch size %d: got %v, exp %v", n, got, exp)
+		}
+		w.Close()
+	}
+}
+
+// Ensure writes fail once the WAL is closed.
+func TestWAL_WriteMulti_Closed(t *testing.T) {
+	dir := mustTempDir()
+	defer os.RemoveAll(dir)
+
+	w := NewWAL(dir)
+	if err := w.Open(); err != nil {
+		t.Fatalf("error opening WAL: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("error closing WAL: %v", err)
+	}
+
+	if _, err := w.WriteMulti(map[string][]Value{
+		"cpu,host=A#!~#value": []Value{NewValue(1, 1.1)},
+	}); err != ErrWALClosed {
+		t.Fatalf("unexpected error: got %v, exp %v", err, ErrWALClosed)
+	}
+}
+
+func TestWALHistogram(t *testing.T) {
+	var h WALHistogram
+	for _, v := range []int{0, 1, 2, 3, 4, 5, 1024, 1025, 100000} {
+		h.add(v)
+	}
+
+	values := make(map[string]interface{})
+	h.addStatistics("size", values)
+
+	for k, exp := range map[string]int64{
+		"sizeLe1":    2,
+		"sizeLe2":    3,
+		"sizeLe4":    5,
+		"sizeLe8":    6,
+		"sizeLe1024": 7,
+		"sizeLeInf":  9,
+	} {
+		if got := values[k]; got != exp {
+			t.Errorf("%s mismatch: got %v, exp %v", k, got, exp)
+		}
+	}
+}
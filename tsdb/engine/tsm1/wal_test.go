@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
@@ -21,12 +22,14 @@ func TestWALWriter_WriteMulti_Single(t *testing.T) {
 	p2 := tsm1.NewValue(1, int64(1))
 	p3 := tsm1.NewValue(1, true)
 	p4 := tsm1.NewValue(1, "string")
+	p5 := tsm1.NewValue(1, uint64(1))
 
 	values := map[string][]tsm1.Value{
-		"cpu,host=A#!~#float":  []tsm1.Value{p1},
-		"cpu,host=A#!~#int":    []tsm1.Value{p2},
-		"cpu,host=A#!~#bool":   []tsm1.Value{p3},
-		"cpu,host=A#!~#string": []tsm1.Value{p4},
+		"cpu,host=A#!~#float":    []tsm1.Value{p1},
+		"cpu,host=A#!~#int":      []tsm1.Value{p2},
+		"cpu,host=A#!~#bool":     []tsm1.Value{p3},
+		"cpu,host=A#!~#string":   []tsm1.Value{p4},
+		"cpu,host=A#!~#unsigned": []tsm1.Value{p5},
 	}
 
 	entry := &tsm1.WriteWALEntry{
@@ -602,6 +605,143 @@ func TestWALWriter_Corrupt(t *testing.T) {
 	}
 }
 
+func TestWALWriter_Compressed(t *testing.T) {
+	for _, c := range []tsm1.WALCompression{tsm1.WALCompressionSnappy, tsm1.WALCompressionDeflate} {
+		t.Run(c.String(), func(t *testing.T) {
+			dir := MustTempDir()
+			defer os.RemoveAll(dir)
+			f := MustTempFile(dir)
+			w, err := tsm1.NewCompressedWALSegmentWriter(f, c)
+			if err != nil {
+				fatal(t, "new writer", err)
+			}
+
+			var entries []tsm1.WALEntry
+			for i := 0; i < 10; i++ {
+				entries = append(entries, &tsm1.WriteWALEntry{
+					Values: map[string][]tsm1.Value{
+						"cpu,host=A#!~#value": []tsm1.Value{tsm1.NewValue(int64(i), float64(i))},
+						"cpu,host=B#!~#value": []tsm1.Value{tsm1.NewValue(int64(i), fmt.Sprintf("value-%d", i))},
+					},
+				})
+			}
+			entries = append(entries,
+				&tsm1.DeleteWALEntry{Keys: []string{"cpu,host=A#!~#value"}},
+				&tsm1.DeleteRangeWALEntry{Keys: []string{"cpu,host=B#!~#value"}, Min: 3, Max: 5},
+			)
+
+			// Flush after every few entries to write several frames.
+			for i, e := range entries {
+				if err := w.Write(mustEncodeEntry(e)); err != nil {
+					fatal(t, "write entry", err)
+				}
+				if i%4 == 3 {
+					if err := w.Flush(); err != nil {
+						fatal(t, "flush", err)
+					}
+				}
+			}
+
+			if err := w.Flush(); err != nil {
+				fatal(t, "flush", err)
+			}
+
+			if _, err := f.Seek(0, io.SeekStart); err != nil {
+				fatal(t, "seek", err)
+			}
+
+			r := tsm1.NewWALSegmentReader(f)
+			for i, exp := range entries {
+				if !r.Next() {
+					t.Fatalf("expected next for entry %d, got false", i)
+				}
+
+				got, err := r.Read()
+				if err != nil {
+					fatal(t, "read entry", err)
+				}
+
+				var equal bool
+				switch exp := exp.(type) {
+				case *tsm1.WriteWALEntry:
+					g, ok := got.(*tsm1.WriteWALEntry)
+					equal = ok && reflect.DeepEqual(g.Values, exp.Values)
+				case *tsm1.DeleteWALEntry:
+					g, ok := got.(*tsm1.DeleteWALEntry)
+					equal = ok && reflect.DeepEqual(g.Keys, exp.Keys)
+				case *tsm1.DeleteRangeWALEntry:
+					g, ok := got.(*tsm1.DeleteRangeWALEntry)
+					equal = ok && reflect.DeepEqual(g.Keys, exp.Keys) && g.Min == exp.Min && g.Max == exp.Max
+				}
+				if !equal {
+					t.Fatalf("entry %d mismatch: got %#v, exp %#v", i, got, exp)
+				}
+			}
+
+			if r.Next() {
+				t.Fatalf("expected no more entries")
+			}
+
+			if n := r.Count(); n != MustReadFileSize(f) {
+				t.Fatalf("wrong count of bytes read, got %d, exp %d", n, MustReadFileSize(f))
+			}
+		})
+	}
+}
+
+func TestWALWriter_Compressed_Corrupt(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	f := MustTempFile(dir)
+	w, err := tsm1.NewCompressedWALSegmentWriter(f, tsm1.WALCompressionDeflate)
+	if err != nil {
+		fatal(t, "new writer", err)
+	}
+
+	entry := &tsm1.WriteWALEntry{
+		Values: map[string][]tsm1.Value{
+			"cpu,host=A#!~#float": []tsm1.Value{tsm1.NewValue(1, 1.1)},
+		},
+	}
+	if err := w.Write(mustEncodeEntry(entry)); err != nil {
+		fatal(t, "write points", err)
+	}
+
+	if err := w.Flush(); err != nil {
+		fatal(t, "flush", err)
+	}
+	size := MustReadFileSize(f)
+
+	// Write a frame that is shorter than its length to simulate a partial write.
+	if _, err := f.Write([]byte{0, 0, 0, 10, 1, 2}); err != nil {
+		fatal(t, "corrupt WAL segment", err)
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		fatal(t, "seek", err)
+	}
+	r := tsm1.NewWALSegmentReader(f)
+
+	if !r.Next() {
+		t.Fatalf("expected next, got false")
+	}
+	if _, err := r.Read(); err != nil {
+		fatal(t, "read entry", err)
+	}
+
+	if !r.Next() {
+		t.Fatalf("expected next, got false")
+	}
+	if _, err := r.Read(); err == nil {
+		fatal(t, "read entry did not return err", nil)
+	}
+
+	// Count should only return size of valid frames.
+	if n := r.Count(); n != size {
+		t.Fatalf("wrong count of bytes read, got %d, exp %d", n, size)
+	}
+}
+
 func TestWriteWALSegment_UnmarshalBinary_WriteWALCorrupt(t *testing.T) {
 	p1 := tsm1.NewValue(1, 1.1)
 	p2 := tsm1.NewValue(1, int64(1))
@@ -766,3 +906,13 @@ func mustMarshalEntry(entry tsm1.WALEntry) (tsm1.WalEntryType, []byte) {
 
 	return entry.Type(), snappy.Encode(b, b)
 }
+
+// mustEncodeEntry returns the type and uncompressed data of entry, as written
+// to compressed segments.
+func mustEncodeEntry(entry tsm1.WALEntry) (tsm1.WalEntryType, []byte) {
+	b, err := entry.Encode(nil)
+	if err != nil {
+		panic(fmt.Sprintf("error encoding: %v", err))
+	}
+	return entry.Type(), b
+}
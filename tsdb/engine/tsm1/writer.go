@@ -52,6 +52,20 @@ file, we can use the size to determine how much to read in a given IO.
 │ 2 bytes │ N bytes │1 byte│2 bytes│ 8 bytes │ 8 bytes │8 bytes │4 bytes │   │
 └─────────┴─────────┴──────┴───────┴─────────┴─────────┴────────┴────────┴───┘
 
+Files of version 2 store statistics about the values of each numeric block
+between the blocks and the index.  Each record holds the offset of the block it
+describes, the number of values in the block and their sum, minimum and maximum.
+The sum, minimum and maximum are stored as the bits of a float64, int64 or uint64
+depending on the type of the block.  Records are ordered by block offset and are
+followed by the number of records.  Blocks of other types have no record.
+
+┌───────────────────────────────────────────────────────────────┐
+│                          Block Stats                          │
+├─────────┬─────────┬─────────┬─────────┬─────────┬───┬─────────┤
+│ Offset  │  Count  │   Sum   │   Min   │   Max   │...│    N    │
+│ 8 bytes │ 4 bytes │ 8 bytes │ 8 bytes │ 8 bytes │   │ 8 bytes │
+└─────────┴─────────┴─────────┴─────────┴─────────┴───┴─────────┘
+
 The last section is the footer that stores the offset of the start of the index.
 
 ┌─────────┐
@@ -69,6 +83,7 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io"
+	"math"
 	"os"
 	"sort"
 	"sync"
@@ -83,9 +98,16 @@ const (
 	// Version indicates the version of the TSM file format.
 	Version byte = 1
 
+	// VersionBlockStats is the version of TSM files that store statistics about the
+	// values of each numeric block.
+	VersionBlockStats byte = 2
+
 	// Size in bytes of an index entry
 	indexEntrySize = 28
 
+	// Size in bytes of the statistics of a block
+	blockStatsSize = 36
+
 	// Size in bytes used to store the count of index entries for a key
 	indexCountSize = 2
 
@@ -228,6 +250,99 @@ func (e *IndexEntry) String() string {
 		time.Unix(0, e.MinTime).UTC(), time.Unix(0, e.MaxTime).UTC(), e.Offset, e.Size)
 }
 
+// BlockStats holds statistics about the values of a numeric block in a TSM file.
+type BlockStats struct {
+	// The number of values in the block.
+	Count uint32
+
+	// The sum, minimum and maximum of the values.  These hold the bits of a float64,
+	// int64 or uint64 depending on the type of the block.
+	Sum, Min, Max uint64
+}
+
+// FloatStats returns the sum, minimum and maximum of a float block.
+func (s *BlockStats) FloatStats() (sum, min, max float64) {
+	return math.Float64frombits(s.Sum), math.Float64frombits(s.Min), math.Float64frombits(s.Max)
+}
+
+// IntegerStats returns the sum, minimum and maximum of an integer block.
+func (s *BlockStats) IntegerStats() (sum, min, max int64) {
+	return int64(s.Sum), int64(s.Min), int64(s.Max)
+}
+
+// UnsignedStats returns the sum, minimum and maximum of an unsigned block.
+func (s *BlockStats) UnsignedStats() (sum, min, max uint64) {
+	return s.Sum, s.Min, s.Max
+}
+
+// appendBlockStats appends the encoded statistics of the block at offset to b.
+func appendBlockStats(b []byte, offset int64, s BlockStats) []byte {
+	var buf [blockStatsSize]byte
+	binary.BigEndian.PutUint64(buf[0:8], uint64(offset))
+	binary.BigEndian.PutUint32(buf[8:12], s.Count)
+	binary.BigEndian.PutUint64(buf[12:20], s.Sum)
+	binary.BigEndian.PutUint64(buf[20:28], s.Min)
+	binary.BigEndian.PutUint64(buf[28:36], s.Max)
+	return append(b, buf[:]...)
+}
+
+// computeBlockStats returns the statistics of values.  It returns false if the values
+// are not numeric.
+func computeBlockStats(values Values) (BlockStats, bool) {
+	if len(values) == 0 {
+		return BlockStats{}, false
+	}
+
+	s := BlockStats{Count: uint32(len(values))}
+	switch values[0].(type) {
+	case FloatValue:
+		var sum float64
+		min, max := values[0].(FloatValue).value, values[0].(FloatValue).value
+		for _, v := range values {
+			f := v.(FloatValue).value
+			sum += f
+			if f < min {
+				min = f
+			}
+			if f > max {
+				max = f
+			}
+		}
+		s.Sum, s.Min, s.Max = math.Float64bits(sum), math.Float64bits(min), math.Float64bits(max)
+	case IntegerValue:
+		var sum int64
+		min, max := values[0].(IntegerValue).value, values[0].(IntegerValue).value
+		for _, v := range values {
+			i := v.(IntegerValue).value
+			sum += i
+			if i < min {
+				min = i
+			}
+			if i > max {
+				max = i
+			}
+		}
+		s.Sum, s.Min, s.Max = uint64(sum), uint64(min), uint64(max)
+	case UnsignedValue:
+		var sum uint64
+		min, max := values[0].(UnsignedValue).value, values[0].(UnsignedValue).value
+		for _, v := range values {
+			u := v.(UnsignedValue).value
+			sum += u
+			if u < min {
+				min = u
+			}
+			if u > max {
+				max = u
+			}
+		}
+		s.Sum, s.Min, s.Max = sum, min, max
+	default:
+		return BlockStats{}, false
+	}
+	return s, true
+}
+
 // NewIndexWriter returns a new IndexWriter.
 func NewIndexWriter() IndexWriter {
 	return &directIndex{
@@ -429,6 +544,12 @@ type tsmWriter struct {
 	w       *bufio.Writer
 	index   IndexWriter
 	n       int64
+
+	// blockStats is set when the statistics of numeric blocks are written.  stats
+	// holds the encoded statistics of the blocks written so far.
+	blockStats bool
+	stats      []byte
+	statsN     int
 }
 
 // NewTSMWriter returns a new TSMWriter writing to w.
@@ -440,10 +561,25 @@ func NewTSMWriter(w io.Writer) (TSMWriter, error) {
 	return &tsmWriter{wrapped: w, w: bufio.NewWriterSize(w, 1024*1024), index: index}, nil
 }
 
+// NewTSMWriterWithBlockStats returns a new TSMWriter writing to w that also writes the
+// statistics of each numeric block.  Files written with block statistics can not be
+// read by versions that only support version 1 TSM files.
+func NewTSMWriterWithBlockStats(w io.Writer) (TSMWriter, error) {
+	tw, err := NewTSMWriter(w)
+	if err != nil {
+		return nil, err
+	}
+	tw.(*tsmWriter).blockStats = true
+	return tw, nil
+}
+
 func (t *tsmWriter) writeHeader() error {
 	var buf [5]byte
 	binary.BigEndian.PutUint32(buf[0:4], MagicNumber)
 	buf[4] = Version
+	if t.blockStats {
+		buf[4] = VersionBlockStats
+	}
 
 	n, err := t.w.Write(buf[:])
 	if err != nil {
@@ -498,11 +634,23 @@ func (t *tsmWriter) Write(key string, values Values) error {
 	// Record this block in index
 	t.index.Add(key, blockType, values[0].UnixNano(), values[len(values)-1].UnixNano(), t.n, uint32(n))
 
+	if t.blockStats {
+		if s, ok := computeBlockStats(values); ok {
+			t.addBlockStats(s)
+		}
+	}
+
 	// Increment file position pointer
 	t.n += int64(n)
 	return nil
 }
 
+// addBlockStats records the statistics of the block being written at the current position.
+func (t *tsmWriter) addBlockStats(s BlockStats) {
+	t.stats = appendBlockStats(t.stats, t.n, s)
+	t.statsN++
+}
+
 // WriteBlock writes block for the given key and time range to the TSM file.  If the write
 // exceeds max entries for a given key, ErrMaxBlocksExceeded is returned.  This indicates
 // that the index is now full for this key and no future writes to this key will succeed.
@@ -541,6 +689,17 @@ func (t *tsmWriter) WriteBlock(key string, minTime, maxTime int64, block []byte)
 	// Record this block in index
 	t.index.Add(key, blockType, minTime, maxTime, t.n, uint32(n))
 
+	// The statistics of numeric blocks require decoding the block.
+	if t.blockStats && blockType != BlockString && blockType != BlockBoolean {
+		values, err := DecodeBlock(block, nil)
+		if err != nil {
+			return err
+		}
+		if s, ok := computeBlockStats(values); ok {
+			t.addBlockStats(s)
+		}
+	}
+
 	// Increment file position pointer (checksum + block len)
 	t.n += int64(n)
 
@@ -554,12 +713,25 @@ func (t *tsmWriter) WriteBlock(key string, minTime, maxTime int64, block []byte)
 // WriteIndex writes the index section of the file.  If there are no index entries to write,
 // this returns ErrNoValues.
 func (t *tsmWriter) WriteIndex() error {
-	indexPos := t.n
-
 	if t.index.KeyCount() == 0 {
 		return ErrNoValues
 	}
 
+	// Write the block statistics followed by their count.
+	if t.blockStats {
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], uint64(t.statsN))
+		if _, err := t.w.Write(t.stats); err != nil {
+			return err
+		} else if _, err := t.w.Write(buf[:]); err != nil {
+			return err
+		}
+		t.n += int64(len(t.stats) + len(buf))
+		t.stats = nil
+	}
+
+	indexPos := t.n
+
 	// Write the index
 	if _, err := t.index.WriteTo(t.w); err != nil {
 		return err
@@ -598,31 +770,31 @@ func (t *tsmWriter) Close() error {
 }
 
 func (t *tsmWriter) Size() uint32 {
-	return uint32(t.n) + t.index.Size()
+	return uint32(t.n) + uint32(len(t.stats)) + t.index.Size()
 }
 
 // verifyVersion verifies that the reader's bytes are a TSM byte
-// stream of the correct version (1)
-func verifyVersion(r io.ReadSeeker) error {
+// stream of a supported version (1 or 2) and returns the version.
+func verifyVersion(r io.ReadSeeker) (byte, error) {
 	_, err := r.Seek(0, 0)
 	if err != nil {
-		return fmt.Errorf("init: failed to seek: %v", err)
+		return 0, fmt.Errorf("init: failed to seek: %v", err)
 	}
 	var b [4]byte
 	_, err = io.ReadFull(r, b[:])
 	if err != nil {
-		return fmt.Errorf("init: error reading magic number of file: %v", err)
+		return 0, fmt.Errorf("init: error reading magic number of file: %v", err)
 	}
 	if binary.BigEndian.Uint32(b[:]) != MagicNumber {
-		return fmt.Errorf("can only read from tsm file")
+		return 0, fmt.Errorf("can only read from tsm file")
 	}
 	_, err = io.ReadFull(r, b[:1])
 	if err != nil {
-		return fmt.Errorf("init: error reading version: %v", err)
+		return 0, fmt.Errorf("init: error reading version: %v", err)
 	}
-	if b[0] != Version {
-		return fmt.Errorf("init: file is version %b. expected %b", b[0], Version)
+	if b[0] != Version && b[0] != VersionBlockStats {
+		return 0, fmt.Errorf("init: file is version %b. expected %b or %b", b[0], Version, VersionBlockStats)
 	}
 
-	return nil
+	return b[0], nil
 }
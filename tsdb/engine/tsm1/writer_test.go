@@ -631,3 +631,80 @@ func TestTSMWriter_Write_MaxKey(t *testing.T) {
 		t.Fatalf("expected max key length error writing key: %v", err)
 	}
 }
+
+func TestTSMWriter_BlockStats(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	f := MustTempFile(dir)
+
+	w, err := tsm1.NewTSMWriterWithBlockStats(f)
+	if err != nil {
+		t.Fatalf("unexpected error creating writer: %v", err)
+	}
+
+	floats := []tsm1.Value{tsm1.NewValue(1, 2.5), tsm1.NewValue(2, -1.0), tsm1.NewValue(3, 4.0)}
+	if err := w.Write("cpu", floats); err != nil {
+		t.Fatalf("unexpected error writing: %v", err)
+	}
+
+	// Blocks written directly are decoded for their statistics.
+	ints := []tsm1.Value{tsm1.NewValue(1, int64(7)), tsm1.NewValue(2, int64(-3))}
+	block, err := tsm1.Values(ints).Encode(nil)
+	if err != nil {
+		t.Fatalf("unexpected error encoding: %v", err)
+	}
+	if err := w.WriteBlock("mem", 1, 2, block); err != nil {
+		t.Fatalf("unexpected error writing block: %v", err)
+	}
+
+	if err := w.Write("status", []tsm1.Value{tsm1.NewValue(1, "ok")}); err != nil {
+		t.Fatalf("unexpected error writing: %v", err)
+	}
+
+	if err := w.WriteIndex(); err != nil {
+		t.Fatalf("unexpected error writing index: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+
+	fd, err := os.Open(f.Name())
+	if err != nil {
+		t.Fatalf("unexpected error open file: %v", err)
+	}
+
+	r, err := tsm1.NewTSMReader(fd)
+	if err != nil {
+		t.Fatalf("unexpected error created reader: %v", err)
+	}
+	defer r.Close()
+
+	s, ok := r.BlockStats(&r.Entries("cpu")[0])
+	if !ok {
+		t.Fatalf("expected stats for float block")
+	}
+	if sum, min, max := s.FloatStats(); s.Count != 3 || sum != 5.5 || min != -1.0 || max != 4.0 {
+		t.Fatalf("float stats mismatch: count=%d sum=%v min=%v max=%v", s.Count, sum, min, max)
+	}
+
+	s, ok = r.BlockStats(&r.Entries("mem")[0])
+	if !ok {
+		t.Fatalf("expected stats for integer block")
+	}
+	if sum, min, max := s.IntegerStats(); s.Count != 2 || sum != 4 || min != -3 || max != 7 {
+		t.Fatalf("integer stats mismatch: count=%d sum=%v min=%v max=%v", s.Count, sum, min, max)
+	}
+
+	if _, ok := r.BlockStats(&r.Entries("status")[0]); ok {
+		t.Fatalf("unexpected stats for string block")
+	}
+
+	// Values are still readable.
+	readValues, err := r.ReadAll("cpu")
+	if err != nil {
+		t.Fatalf("unexpected error reading: %v", err)
+	}
+	if got, exp := len(readValues), len(floats); got != exp {
+		t.Fatalf("values length mismatch: got %v, exp %v", got, exp)
+	}
+}
@@ -240,6 +240,18 @@ func (i *Index) HasTagValue(name, key, value []byte) bool {
 	return mm.HasTagKeyValue(key, value)
 }
 
+// measurementSeriesN returns the number of series in a measurement.
+func (i *Index) measurementSeriesN(name []byte) int {
+	i.mu.RLock()
+	mm := i.measurements[string(name)]
+	i.mu.RUnlock()
+
+	if mm == nil {
+		return 0
+	}
+	return mm.SeriesN()
+}
+
 // TagValueN returns the cardinality of a tag value.
 func (i *Index) TagValueN(name, key []byte) int {
 	i.mu.RLock()
@@ -298,6 +310,25 @@ func (i *Index) TagKeyCardinality(name, key []byte) int {
 	return mm.CardinalityBytes(key)
 }
 
+// TagValuesByPrefix returns the sorted values of a measurement's tag key that
+// begin with prefix.  At most limit values are returned if limit is greater than zero.
+func (i *Index) TagValuesByPrefix(name, key, prefix []byte, limit int) ([][]byte, error) {
+	i.mu.RLock()
+	mm := i.measurements[string(name)]
+	i.mu.RUnlock()
+
+	if mm == nil {
+		return nil, nil
+	}
+
+	values := mm.TagValuesByPrefix(string(key), string(prefix), limit)
+	a := make([][]byte, len(values))
+	for j, v := range values {
+		a[j] = []byte(v)
+	}
+	return a, nil
+}
+
 // TagsForSeries returns the tag map for the passed in series
 func (i *Index) TagsForSeries(key string) (models.Tags, error) {
 	i.mu.RLock()
@@ -785,6 +816,30 @@ func (idx *ShardIndex) CreateSeriesListIfNotExists(keys, names [][]byte, tagsSli
 		keys, names, tagsSlice = keys[:n], names[:n], tagsSlice[:n]
 	}
 
+	// Ensure that no measurement goes over its quota.
+	if quotas := idx.opt.MeasurementQuotas; quotas.Enabled() {
+		c := tsdb.NewMeasurementQuotaChecker(quotas, idx.measurementSeriesN, idx.HasTagValue, idx.TagValueN)
+
+		var n int
+		for i := range keys {
+			if !c.Accept(keys[i], names[i], tagsSlice[i]) {
+				if droppedKeys == nil {
+					droppedKeys = make(map[string]struct{})
+				}
+				droppedKeys[string(keys[i])] = struct{}{}
+				continue
+			}
+			keys[n], names[n], tagsSlice[n] = keys[i], names[i], tagsSlice[i]
+			n++
+		}
+		keys, names, tagsSlice = keys[:n], names[:n], tagsSlice[:n]
+
+		if c.Dropped() > 0 {
+			dropped += c.Dropped()
+			reason = c.Reason()
+		}
+	}
+
 	// Write
 	for i := range keys {
 		if err := idx.CreateSeriesIfNotExists(keys[i], names[i], tagsSlice[i]); err == errMaxSeriesPerDatabaseExceeded {
@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"regexp"
+
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/pkg/estimator"
 	"github.com/influxdata/influxdb/tsdb/index/tsi1"
@@ -20,6 +22,7 @@ type File struct {
 	TagKeyIteratorf            func(name []byte) tsi1.TagKeyIterator
 	TagValuef                  func(name, key, value []byte) tsi1.TagValueElem
 	TagValueIteratorf          func(name, key []byte) tsi1.TagValueIterator
+	TagValueRegexIteratorf     func(name, key []byte, re *regexp.Regexp) tsi1.TagValueIterator
 	SeriesIteratorf            func() tsi1.SeriesIterator
 	MeasurementSeriesIteratorf func(name []byte) tsi1.SeriesIterator
 	TagKeySeriesIteratorf      func(name, key []byte) tsi1.SeriesIterator
@@ -50,6 +53,9 @@ func (f *File) TagValue(name, key, value []byte) tsi1.TagValueElem {
 func (f *File) TagValueIterator(name, key []byte) tsi1.TagValueIterator {
 	return f.TagValueIteratorf(name, key)
 }
+func (f *File) TagValueRegexIterator(name, key []byte, re *regexp.Regexp) tsi1.TagValueIterator {
+	return f.TagValueRegexIteratorf(name, key, re)
+}
 func (f *File) SeriesIterator() tsi1.SeriesIterator { return f.SeriesIteratorf() }
 func (f *File) MeasurementSeriesIterator(name []byte) tsi1.SeriesIterator {
 	return f.MeasurementSeriesIteratorf(name)
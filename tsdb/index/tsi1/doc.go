@@ -155,9 +155,16 @@ has their own hash index for fast direct lookups.
 	┗━━━━━━━━━━━━━━━━━━━━━━━━━━┛
 
 Each entry for values contains a sorted list of offsets for series keys that use
-that value. Series iterators can be built around a single tag key value or
-multiple iterators can be merged with set operators such as union or
-intersection.
+that value. When it is smaller, the offsets are instead stored as a roaring
+bitmap and the value is marked with a bitmap flag. Series iterators can be
+built around a single tag key value or multiple iterators can be merged with
+set operators such as union or intersection. Iterators over the same index
+file are merged with bitmap operations.
+
+Each key may also have a trigram index after its value hash index. The trigram
+index is a sorted list of every three byte sequence found in the key's values,
+each with a bitmap of the offsets of the values containing it. It is used to
+narrow down the values that need to be checked against a regular expression.
 
 
 Measurement block
@@ -299,6 +299,43 @@ func (fs FileSet) TagValueIterator(name, key []byte) TagValueIterator {
 	return MergeTagValueIterators(a...)
 }
 
+// TagValueRegexIterator returns a value iterator for the values of a tag key that
+// may match re.  The caller must still match the values against re.
+func (fs FileSet) TagValueRegexIterator(name, key []byte, re *regexp.Regexp) TagValueIterator {
+	a := make([]TagValueIterator, 0, len(fs))
+	for _, f := range fs {
+		itr := f.TagValueRegexIterator(name, key, re)
+		if itr != nil {
+			a = append(a, itr)
+		}
+	}
+	return MergeTagValueIterators(a...)
+}
+
+// TagValuesByPrefix returns the sorted values of a tag key that begin with prefix
+// and are not deleted.  At most limit values are returned if limit is greater than zero.
+func (fs FileSet) TagValuesByPrefix(name, key, prefix []byte, limit int) [][]byte {
+	a := make([]TagValueIterator, 0, len(fs))
+	for _, f := range fs {
+		if itr := f.TagValueIterator(name, key); itr != nil {
+			a = append(a, newTagValuePrefixIterator(itr, prefix))
+		}
+	}
+
+	itr := MergeTagValueIterators(a...)
+	if itr == nil {
+		return nil
+	}
+
+	var values [][]byte
+	for e := itr.Next(); e != nil && (limit <= 0 || len(values) < limit); e = itr.Next() {
+		if !e.Deleted() {
+			values = append(values, copyBytes(e.Value()))
+		}
+	}
+	return values
+}
+
 // TagValueSeriesIterator returns a series iterator for a single tag value.
 func (fs FileSet) TagValueSeriesIterator(name, key, value []byte) SeriesIterator {
 	a := make([]SeriesIterator, 0, len(fs))
@@ -349,7 +386,7 @@ func (fs FileSet) matchTagValueEqualEmptySeriesIterator(name, key []byte, value
 }
 
 func (fs FileSet) matchTagValueEqualNotEmptySeriesIterator(name, key []byte, value *regexp.Regexp) SeriesIterator {
-	vitr := fs.TagValueIterator(name, key)
+	vitr := fs.TagValueRegexIterator(name, key, value)
 	if vitr == nil {
 		return nil
 	}
@@ -379,7 +416,7 @@ func (fs FileSet) matchTagValueNotEqualEmptySeriesIterator(name, key []byte, val
 }
 
 func (fs FileSet) matchTagValueNotEqualNotEmptySeriesIterator(name, key []byte, value *regexp.Regexp) SeriesIterator {
-	vitr := fs.TagValueIterator(name, key)
+	vitr := fs.TagValueRegexIterator(name, key, value)
 	if vitr == nil {
 		return fs.MeasurementSeriesIterator(name)
 	}
@@ -515,9 +552,9 @@ func (fs FileSet) measurementNamesByTagFilter(op influxql.Token, key, val string
 				tagMatch = true
 			}
 		} else {
-			// Else, the operator is a regex and we have to check all tag
-			// values against the regular expression.
-			vitr := fs.TagValueIterator(me.Name(), []byte(key))
+			// Else, the operator is a regex and we have to check the tag
+			// values that may match against the regular expression.
+			vitr := fs.TagValueRegexIterator(me.Name(), []byte(key), regex)
 			if vitr != nil {
 				for ve := vitr.Next(); ve != nil; ve = vitr.Next() {
 					if regex.Match(ve.Value()) {
@@ -628,6 +665,12 @@ func (fs FileSet) MeasurementSeriesKeysByExpr(name []byte, expr influxql.Expr, f
 }
 
 func (fs FileSet) seriesByExprIterator(name []byte, expr influxql.Expr, mf *tsdb.MeasurementFields) (SeriesIterator, error) {
+	// Evaluate tag predicates file by file so the series id sets of index files
+	// can be combined directly.
+	if isTagExpr(expr, mf) {
+		return fs.tagExprSeriesIterator(name, expr), nil
+	}
+
 	switch expr := expr.(type) {
 	case *influxql.BinaryExpr:
 		switch expr.Op {
@@ -776,6 +819,156 @@ func (fs FileSet) seriesByBinaryExprVarRefIterator(name, key []byte, value *infl
 	), nil
 }
 
+// tagExprSeriesIterator returns an iterator over the series matching a tag
+// expression.  The expression is evaluated against each file separately, which
+// gives the same result as evaluating it against the whole set because a series
+// has the same tags in every file.  Tombstoned series are kept until the results
+// are merged so that tombstones in newer files take precedence.
+func (fs FileSet) tagExprSeriesIterator(name []byte, expr influxql.Expr) SeriesIterator {
+	a := make([]SeriesIterator, 0, len(fs))
+	for _, f := range fs {
+		if itr := fileSeriesByTagExprIterator(f, name, expr); itr != nil {
+			a = append(a, itr)
+		}
+	}
+	return FilterUndeletedSeriesIterator(MergeSeriesIterators(a...))
+}
+
+// fileSeriesByTagExprIterator returns an iterator over the series of a single file
+// matching a tag expression, including tombstoned series.
+func fileSeriesByTagExprIterator(f File, name []byte, expr influxql.Expr) SeriesIterator {
+	switch expr := expr.(type) {
+	case *influxql.BinaryExpr:
+		switch expr.Op {
+		case influxql.AND:
+			return IntersectSeriesIterators(
+				fileSeriesByTagExprIterator(f, name, expr.LHS),
+				fileSeriesByTagExprIterator(f, name, expr.RHS),
+			)
+		case influxql.OR:
+			return UnionSeriesIterators(
+				fileSeriesByTagExprIterator(f, name, expr.LHS),
+				fileSeriesByTagExprIterator(f, name, expr.RHS),
+			)
+		}
+
+		key, value := tagExprOperands(expr)
+		switch value := value.(type) {
+		case *influxql.StringLiteral:
+			return fileSeriesByTagValueIterator(f, name, []byte(key.Val), []byte(value.Val), expr.Op)
+		case *influxql.RegexLiteral:
+			return fileSeriesByTagRegexIterator(f, name, []byte(key.Val), value.Val, expr.Op)
+		}
+
+	case *influxql.ParenExpr:
+		return fileSeriesByTagExprIterator(f, name, expr.Expr)
+	}
+	return nil
+}
+
+func fileSeriesByTagValueIterator(f File, name, key, value []byte, op influxql.Token) SeriesIterator {
+	if op == influxql.EQ {
+		// Match a specific value.
+		if len(value) != 0 {
+			return f.TagValueSeriesIterator(name, key, value)
+		}
+
+		// Return all measurement series that have no values from this tag key.
+		return DifferenceSeriesIterators(
+			f.MeasurementSeriesIterator(name),
+			f.TagKeySeriesIterator(name, key),
+		)
+	}
+
+	// Return all measurement series without this tag value.
+	if len(value) != 0 {
+		return DifferenceSeriesIterators(
+			f.MeasurementSeriesIterator(name),
+			f.TagValueSeriesIterator(name, key, value),
+		)
+	}
+
+	// Return all series across all values of this tag key.
+	return f.TagKeySeriesIterator(name, key)
+}
+
+func fileSeriesByTagRegexIterator(f File, name, key []byte, value *regexp.Regexp, op influxql.Token) SeriesIterator {
+	// Only values that may match are needed unless the regex matches empty values.
+	matchEmpty := value.MatchString("")
+	vitr := f.TagValueIterator(name, key)
+	if !matchEmpty {
+		vitr = f.TagValueRegexIterator(name, key, value)
+	}
+
+	// Split the series of the tag values by whether the value matches.
+	var matched, unmatched []SeriesIterator
+	if vitr != nil {
+		for e := vitr.Next(); e != nil; e = vitr.Next() {
+			itr := f.TagValueSeriesIterator(name, key, e.Value())
+			if itr == nil {
+				continue
+			} else if value.Match(e.Value()) {
+				matched = append(matched, itr)
+			} else {
+				unmatched = append(unmatched, itr)
+			}
+		}
+	}
+
+	// Series without the tag key have an empty value.
+	if op == influxql.EQREGEX {
+		if matchEmpty {
+			return DifferenceSeriesIterators(f.MeasurementSeriesIterator(name), MergeSeriesIterators(unmatched...))
+		}
+		return MergeSeriesIterators(matched...)
+	}
+
+	if matchEmpty {
+		return MergeSeriesIterators(unmatched...)
+	}
+	return DifferenceSeriesIterators(f.MeasurementSeriesIterator(name), MergeSeriesIterators(matched...))
+}
+
+// isTagExpr returns true if expr only compares tags to string or regex literals.
+func isTagExpr(expr influxql.Expr, mf *tsdb.MeasurementFields) bool {
+	switch expr := expr.(type) {
+	case *influxql.BinaryExpr:
+		switch expr.Op {
+		case influxql.AND, influxql.OR:
+			return isTagExpr(expr.LHS, mf) && isTagExpr(expr.RHS, mf)
+		}
+
+		key, value := tagExprOperands(expr)
+		if key == nil || key.Val == "time" || key.Val == "_name" {
+			return false
+		} else if key.Type != influxql.Tag && (key.Type != influxql.Unknown || mf.HasField(key.Val)) {
+			return false
+		}
+
+		switch value.(type) {
+		case *influxql.StringLiteral:
+			return expr.Op == influxql.EQ || expr.Op == influxql.NEQ
+		case *influxql.RegexLiteral:
+			return expr.Op == influxql.EQREGEX || expr.Op == influxql.NEQREGEX
+		}
+
+	case *influxql.ParenExpr:
+		return isTagExpr(expr.Expr, mf)
+	}
+	return false
+}
+
+// tagExprOperands returns the variable reference and the value of a comparison.
+// Returns a nil reference if neither side is a variable reference.
+func tagExprOperands(expr *influxql.BinaryExpr) (*influxql.VarRef, influxql.Expr) {
+	if key, ok := expr.LHS.(*influxql.VarRef); ok {
+		return key, expr.RHS
+	} else if key, ok := expr.RHS.(*influxql.VarRef); ok {
+		return key, expr.LHS
+	}
+	return nil, nil
+}
+
 // File represents a log or index file.
 type File interface {
 	Close() error
@@ -793,6 +986,7 @@ type File interface {
 
 	TagValue(name, key, value []byte) TagValueElem
 	TagValueIterator(name, key []byte) TagValueIterator
+	TagValueRegexIterator(name, key []byte, re *regexp.Regexp) TagValueIterator
 
 	// Series iteration.
 	SeriesIterator() SeriesIterator
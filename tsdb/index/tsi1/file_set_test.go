@@ -3,9 +3,12 @@ package tsi1_test
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"testing"
 
+	"github.com/influxdata/influxdb/influxql"
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
 	"github.com/influxdata/influxdb/tsdb/index/internal"
 	"github.com/influxdata/influxdb/tsdb/index/tsi1"
 )
@@ -143,6 +146,161 @@ func TestFileSet_MeasurementSeriesIterator(t *testing.T) {
 	})
 }
 
+// Ensure fileset can evaluate tag expressions across index and log files.
+func TestFileSet_MeasurementSeriesKeysByExpr(t *testing.T) {
+	idx := MustOpenIndex()
+	defer idx.Close()
+
+	// Create series and compact them into an index file.
+	var a []Series
+	for _, region := range []string{"east", "west"} {
+		for i := 0; i < 5; i++ {
+			a = append(a, Series{Name: []byte("cpu"), Tags: models.NewTags(map[string]string{"region": region, "host": fmt.Sprintf("server%d", i)})})
+		}
+	}
+	if err := idx.CreateSeriesSliceIfNotExists(a); err != nil {
+		t.Fatal(err)
+	}
+
+	idx.MaxLogFileSize = 1
+	if err := idx.CheckLogFile(); err != nil {
+		t.Fatal(err)
+	} else if err := idx.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Drop and add series in the new log file.
+	if err := idx.DropSeries([]byte("cpu,host=server1,region=east")); err != nil {
+		t.Fatal(err)
+	} else if err := idx.CreateSeriesSliceIfNotExists([]Series{
+		{Name: []byte("cpu"), Tags: models.NewTags(map[string]string{"region": "west", "host": "server5"})},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	fs := idx.RetainFileSet()
+	defer fs.Release()
+	if len(fs.IndexFiles()) != 1 {
+		t.Fatalf("expected one index file, got %d", len(fs.IndexFiles()))
+	}
+
+	for _, tt := range []struct {
+		expr string
+		keys []string
+	}{
+		{
+			expr: `region = 'east'`,
+			keys: []string{"cpu,host=server0,region=east", "cpu,host=server2,region=east", "cpu,host=server3,region=east", "cpu,host=server4,region=east"},
+		},
+		{
+			expr: `region = 'west' AND host != 'server0'`,
+			keys: []string{"cpu,host=server1,region=west", "cpu,host=server2,region=west", "cpu,host=server3,region=west", "cpu,host=server4,region=west", "cpu,host=server5,region=west"},
+		},
+		{
+			expr: `host = 'server0' OR host =~ /server[45]/`,
+			keys: []string{"cpu,host=server0,region=east", "cpu,host=server0,region=west", "cpu,host=server4,region=east", "cpu,host=server4,region=west", "cpu,host=server5,region=west"},
+		},
+		{
+			expr: `region !~ /west/ AND (host = 'server1' OR host = 'server2')`,
+			keys: []string{"cpu,host=server2,region=east"},
+		},
+		{
+			expr: `region = 'east' AND host = 'server1'`,
+		},
+	} {
+		keys, err := fs.MeasurementSeriesKeysByExpr([]byte("cpu"), influxql.MustParseExpr(tt.expr), tsdb.NewMeasurementFieldSet())
+		if err != nil {
+			t.Fatalf("%s: %s", tt.expr, err)
+		}
+
+		var got []string
+		for _, key := range keys {
+			got = append(got, string(key))
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tt.keys) {
+			t.Fatalf("%s: unexpected keys: %v", tt.expr, got)
+		}
+	}
+
+	// Measurement names are filtered by regex using the index file.
+	for _, tt := range []struct {
+		expr  string
+		names []string
+	}{
+		{expr: `host =~ /server[45]/`, names: []string{"cpu"}},
+		{expr: `host =~ /server[67]/`},
+		{expr: `host !~ /server[67]/`, names: []string{"cpu"}},
+	} {
+		names, err := fs.MeasurementNamesByExpr(influxql.MustParseExpr(tt.expr))
+		if err != nil {
+			t.Fatalf("%s: %s", tt.expr, err)
+		}
+
+		var got []string
+		for _, name := range names {
+			got = append(got, string(name))
+		}
+		if !reflect.DeepEqual(got, tt.names) {
+			t.Fatalf("%s: unexpected names: %v", tt.expr, got)
+		}
+	}
+}
+
+// Ensure fileset can search tag values by prefix across index and log files.
+func TestFileSet_TagValuesByPrefix(t *testing.T) {
+	idx := MustOpenIndex()
+	defer idx.Close()
+
+	// Create series and compact them into an index file.
+	if err := idx.CreateSeriesSliceIfNotExists([]Series{
+		{Name: []byte("cpu"), Tags: models.NewTags(map[string]string{"host": "web02"})},
+		{Name: []byte("cpu"), Tags: models.NewTags(map[string]string{"host": "db01"})},
+		{Name: []byte("cpu"), Tags: models.NewTags(map[string]string{"host": "web10"})},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	idx.MaxLogFileSize = 1
+	if err := idx.CheckLogFile(); err != nil {
+		t.Fatal(err)
+	} else if err := idx.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Add values to the new log file.
+	if err := idx.CreateSeriesSliceIfNotExists([]Series{
+		{Name: []byte("cpu"), Tags: models.NewTags(map[string]string{"host": "web01"})},
+		{Name: []byte("cpu"), Tags: models.NewTags(map[string]string{"host": "web02"})},
+		{Name: []byte("cpu"), Tags: models.NewTags(map[string]string{"host": "webby"})},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	fs := idx.RetainFileSet()
+	defer fs.Release()
+
+	for _, tt := range []struct {
+		prefix string
+		limit  int
+		exp    []string
+	}{
+		{prefix: "web", exp: []string{"web01", "web02", "web10", "webby"}},
+		{prefix: "web0", exp: []string{"web01", "web02"}},
+		{prefix: "web", limit: 3, exp: []string{"web01", "web02", "web10"}},
+		{prefix: "", limit: 2, exp: []string{"db01", "web01"}},
+		{prefix: "x"},
+	} {
+		var got []string
+		for _, v := range fs.TagValuesByPrefix([]byte("cpu"), []byte("host"), []byte(tt.prefix), tt.limit) {
+			got = append(got, string(v))
+		}
+		if !reflect.DeepEqual(got, tt.exp) {
+			t.Fatalf("%q/%d: unexpected values: %v", tt.prefix, tt.limit, got)
+		}
+	}
+}
+
 // Ensure fileset can return an iterator over all measurements for the index.
 func TestFileSet_MeasurementIterator(t *testing.T) {
 	idx := MustOpenIndex()
@@ -85,6 +85,9 @@ type Index struct {
 	// Frequency of compaction checks.
 	CompactionEnabled         bool
 	CompactionMonitorInterval time.Duration
+
+	// Enforces the limits of the database of the index while creating series.
+	limiter *tsdb.SeriesLimiter
 }
 
 // NewIndex returns a new instance of Index.
@@ -163,6 +166,13 @@ func (i *Index) Open() error {
 		}
 	}
 
+	// Count the series of the index towards the limits of its database.
+	i.limiter = i.options.SeriesLimiter
+	if i.limiter == nil {
+		i.limiter = tsdb.NewSeriesLimiter(i.options.Config, i.options.MeasurementQuotas)
+	}
+	i.limiter.AddIndex(i.ShardID, i)
+
 	// Mark opened.
 	i.opened = true
 
@@ -221,6 +231,10 @@ func (i *Index) deleteNonManifestFiles(m *Manifest) error {
 
 // Close closes the index.
 func (i *Index) Close() error {
+	if i.limiter != nil {
+		i.limiter.RemoveIndex(i.ShardID, i)
+	}
+
 	// Wait for goroutines to finish.
 	i.once.Do(func() { close(i.closing) })
 	i.wg.Wait()
@@ -365,6 +379,8 @@ func (i *Index) MeasurementNamesByRegex(re *regexp.Regexp) ([][]byte, error) {
 
 // DropMeasurement deletes a measurement from the index.
 func (i *Index) DropMeasurement(name []byte) error {
+	defer i.resetLimits()
+
 	fs := i.RetainFileSet()
 	defer fs.Release()
 
@@ -432,12 +448,18 @@ func (i *Index) DropMeasurement(name []byte) error {
 }
 
 // CreateSeriesListIfNotExists creates a list of series if they doesn't exist in bulk.
-func (i *Index) CreateSeriesListIfNotExists(_, names [][]byte, tagsSlice []models.Tags) error {
+// Series that would exceed the max-series-per-database or max-values-per-tag limits
+// are dropped and returned in a PartialWriteError.
+func (i *Index) CreateSeriesListIfNotExists(keys, names [][]byte, tagsSlice []models.Tags) error {
 	// All slices must be of equal length.
 	if len(names) != len(tagsSlice) {
 		return errors.New("names/tags length mismatch")
 	}
 
+	if i.limitsEnabled() {
+		return i.createSeriesListWithLimits(keys, names, tagsSlice)
+	}
+
 	// Maintain reference count on files in file set.
 	fs := i.RetainFileSet()
 	defer fs.Release()
@@ -448,6 +470,11 @@ func (i *Index) CreateSeriesListIfNotExists(_, names [][]byte, tagsSlice []model
 		return nil
 	}
 
+	return i.addSeriesList(names, tagsSlice)
+}
+
+// addSeriesList adds new series to the active log file.
+func (i *Index) addSeriesList(names [][]byte, tagsSlice []models.Tags) error {
 	// Ensure fileset cannot change during insert.
 	i.mu.RLock()
 	// Insert series into log file.
@@ -467,6 +494,10 @@ func (i *Index) InitializeSeries(key, name []byte, tags models.Tags) error {
 
 // CreateSeriesIfNotExists creates a series if it doesn't exist or is deleted.
 func (i *Index) CreateSeriesIfNotExists(key, name []byte, tags models.Tags) error {
+	if i.limitsEnabled() {
+		return i.createSeriesListWithLimits([][]byte{key}, [][]byte{name}, []models.Tags{tags})
+	}
+
 	if err := func() error {
 		i.mu.RLock()
 		defer i.mu.RUnlock()
@@ -494,6 +525,8 @@ func (i *Index) CreateSeriesIfNotExists(key, name []byte, tags models.Tags) erro
 }
 
 func (i *Index) DropSeries(key []byte) error {
+	defer i.resetLimits()
+
 	if err := func() error {
 		i.mu.RLock()
 		defer i.mu.RUnlock()
@@ -628,6 +661,14 @@ func (i *Index) TagKeyCardinality(name, key []byte) int {
 	return 0
 }
 
+// TagValuesByPrefix returns the sorted values of a measurement's tag key that
+// begin with prefix.  At most limit values are returned if limit is greater than zero.
+func (i *Index) TagValuesByPrefix(name, key, prefix []byte, limit int) ([][]byte, error) {
+	fs := i.RetainFileSet()
+	defer fs.Release()
+	return fs.TagValuesByPrefix(name, key, prefix, limit), nil
+}
+
 // MeasurementSeriesKeysByExpr returns a list of series keys matching expr.
 func (i *Index) MeasurementSeriesKeysByExpr(name []byte, expr influxql.Expr) ([][]byte, error) {
 	fs := i.RetainFileSet()
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
 	"sync"
 
 	"github.com/influxdata/influxdb/models"
@@ -13,8 +14,10 @@ import (
 	"github.com/influxdata/influxdb/pkg/mmap"
 )
 
-// IndexFileVersion is the current TSI1 index file version.
-const IndexFileVersion = 1
+// IndexFileVersion is the current TSI1 index file version.  Version 2 adds series
+// id bitmaps to tag blocks, version 3 sorts the values of tag blocks and version 4
+// adds trigram indexes to tag blocks.  Files of earlier versions can still be read.
+const IndexFileVersion = 4
 
 // FileSignature represents a magic number at the header of the index file.
 const FileSignature = "TSI1"
@@ -222,6 +225,23 @@ func (f *IndexFile) TagValueIterator(name, key []byte) TagValueIterator {
 	return ke.TagValueIterator()
 }
 
+// TagValueRegexIterator returns an iterator over the values of a tag key that
+// may match re.  The values are narrowed down using the trigram index of the
+// tag key, if available.  The caller must still match the values against re.
+func (f *IndexFile) TagValueRegexIterator(name, key []byte, re *regexp.Regexp) TagValueIterator {
+	tblk := f.tblks[string(name)]
+	if tblk == nil {
+		return nil
+	}
+
+	// Find key element.
+	ke, _ := tblk.TagKeyElem(key).(*TagBlockKeyElem)
+	if ke == nil {
+		return nil
+	}
+	return ke.TagValueRegexIterator(re)
+}
+
 // TagKeySeriesIterator returns a series iterator for a tag key and a flag
 // indicating if a tombstone exists on the measurement or key.
 func (f *IndexFile) TagKeySeriesIterator(name, key []byte) SeriesIterator {
@@ -240,8 +260,9 @@ func (f *IndexFile) TagKeySeriesIterator(name, key []byte) SeriesIterator {
 	vitr := ke.TagValueIterator()
 	var itrs []SeriesIterator
 	for ve := vitr.Next(); ve != nil; ve = vitr.Next() {
-		sitr := &rawSeriesIDIterator{data: ve.(*TagBlockValueElem).series.data}
-		itrs = append(itrs, newSeriesDecodeIterator(&f.sblk, sitr))
+		if itr := f.tagValueSeriesIterator(ve.(*TagBlockValueElem)); itr != nil {
+			itrs = append(itrs, itr)
+		}
 	}
 
 	return MergeSeriesIterators(itrs...)
@@ -262,12 +283,17 @@ func (f *IndexFile) TagValueSeriesIterator(name, key, value []byte) SeriesIterat
 	}
 
 	// Create an iterator over value's series.
-	return newSeriesDecodeIterator(
-		&f.sblk,
-		&rawSeriesIDIterator{
-			data: ve.(*TagBlockValueElem).series.data,
-		},
-	)
+	return f.tagValueSeriesIterator(ve.(*TagBlockValueElem))
+}
+
+// tagValueSeriesIterator returns a series iterator for a tag value element.
+// Returns nil if the value's series ids cannot be decoded.
+func (f *IndexFile) tagValueSeriesIterator(ve *TagBlockValueElem) SeriesIterator {
+	itr, err := ve.seriesIDIterator()
+	if err != nil {
+		return nil
+	}
+	return newSeriesDecodeIterator(&f.sblk, itr)
 }
 
 // TagKey returns a tag key.
@@ -378,7 +404,7 @@ func ReadIndexFileTrailer(data []byte) (IndexFileTrailer, error) {
 
 	// Read version.
 	t.Version = int(binary.BigEndian.Uint16(data[len(data)-IndexFileVersionSize:]))
-	if t.Version != IndexFileVersion {
+	if t.Version < 1 || t.Version > IndexFileVersion {
 		return t, ErrUnsupportedIndexFileVersion
 	}
 
@@ -2,6 +2,8 @@ package tsi1_test
 
 import (
 	"bytes"
+	"encoding/binary"
+	"reflect"
 	"testing"
 
 	"github.com/influxdata/influxdb/models"
@@ -51,3 +53,78 @@ func TestIndexFiles_WriteTo(t *testing.T) {
 		t.Fatalf("unexpected series count: %d", n)
 	}
 }
+
+// Ensure tag values of files from versions that may store them unsorted are
+// sorted when compacted, so that prefix searches on the compacted file find them.
+func TestIndexFiles_WriteTo_UnsortedTagValues(t *testing.T) {
+	lf, err := CreateLogFile([]Series{
+		{Name: []byte("cpu"), Tags: models.NewTags(map[string]string{"host": "server-a"})},
+		{Name: []byte("cpu"), Tags: models.NewTags(map[string]string{"host": "server-b"})},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lf.Close()
+
+	var buf bytes.Buffer
+	if _, err := lf.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	// Locate the tag block, which follows the series block.
+	trailer, err := tsi1.ReadIndexFileTrailer(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blk := data[trailer.SeriesBlock.Offset+trailer.SeriesBlock.Size : trailer.MeasurementBlock.Offset]
+	if tt, err := tsi1.ReadTagBlockTrailer(blk); err != nil {
+		t.Fatal(err)
+	} else if tt.Size != int64(len(blk)) {
+		t.Fatalf("unexpected tag block size: %d", tt.Size)
+	}
+
+	// Swap the two value elements and their hash index offsets so the values are
+	// stored unsorted, then mark the block and file as version 2.
+	a := bytes.Index(blk, []byte("\x08server-a")) - 1
+	b := bytes.Index(blk, []byte("\x08server-b")) - 1
+	n := b - a
+	elem := append([]byte(nil), blk[a:b]...)
+	copy(blk[a:b], blk[b:b+n])
+	copy(blk[b:b+n], elem)
+
+	var offsetA, offsetB [8]byte
+	binary.BigEndian.PutUint64(offsetA[:], uint64(a))
+	binary.BigEndian.PutUint64(offsetB[:], uint64(b))
+	i := b + n + bytes.Index(blk[b+n:], offsetA[:])
+	j := b + n + bytes.Index(blk[b+n:], offsetB[:])
+	copy(blk[i:], offsetB[:])
+	copy(blk[j:], offsetA[:])
+
+	binary.BigEndian.PutUint16(blk[len(blk)-2:], 2)
+	binary.BigEndian.PutUint16(data[len(data)-2:], 2)
+
+	var f tsi1.IndexFile
+	if err := f.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	} else if e := f.TagValueElem([]byte("cpu"), []byte("host"), []byte("server-a")); e == nil {
+		t.Fatal("expected element")
+	}
+
+	// Compact the file and search the compacted file by prefix.
+	var out bytes.Buffer
+	if _, err := (tsi1.IndexFiles{&f}).WriteTo(&out); err != nil {
+		t.Fatal(err)
+	}
+	var cf tsi1.IndexFile
+	if err := cf.UnmarshalBinary(out.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+
+	fs := tsi1.FileSet{&cf}
+	if got := fs.TagValuesByPrefix([]byte("cpu"), []byte("host"), []byte("server-a"), 0); !reflect.DeepEqual(got, [][]byte{[]byte("server-a")}) {
+		t.Fatalf("unexpected values: %q", got)
+	} else if got := fs.TagValuesByPrefix([]byte("cpu"), []byte("host"), []byte("server"), 0); !reflect.DeepEqual(got, [][]byte{[]byte("server-a"), []byte("server-b")}) {
+		t.Fatalf("unexpected values: %q", got)
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"os"
 	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/influxdata/influxdb/influxql"
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
 	"github.com/influxdata/influxdb/tsdb/index/tsi1"
 )
 
@@ -240,6 +242,127 @@ func TestIndex_DropMeasurement(t *testing.T) {
 	})
 }
 
+// Ensure index drops series that exceed the max-values-per-tag limit.
+func TestIndex_CreateSeriesListIfNotExists_MaxValuesPerTag(t *testing.T) {
+	opt := tsdb.NewEngineOptions()
+	opt.Config.MaxSeriesPerDatabase = 0
+	opt.Config.MaxValuesPerTag = 2
+	idx := MustOpenIndexWithOptions(opt)
+	defer idx.Close()
+
+	keys, names, tagsSlice := seriesList("cpu,host=a", "cpu,host=b", "cpu,host=b,region=east", "cpu,host=c", "mem,host=c")
+	err := idx.CreateSeriesListIfNotExists(keys, names, tagsSlice)
+	if perr, ok := err.(*tsdb.PartialWriteError); !ok {
+		t.Fatalf("expected partial write error, got %v", err)
+	} else if perr.Dropped != 1 {
+		t.Fatalf("unexpected dropped count: %d", perr.Dropped)
+	} else if _, ok := perr.DroppedKeys["cpu,host=c"]; !ok {
+		t.Fatalf("unexpected dropped keys: %v", perr.DroppedKeys)
+	} else if !strings.Contains(perr.Reason, "max-values-per-tag limit exceeded (2/2)") {
+		t.Fatalf("unexpected reason: %s", perr.Reason)
+	}
+
+	// Existing tag values can still be used by new series.
+	keys, names, tagsSlice = seriesList("cpu,host=a,region=west")
+	if err := idx.CreateSeriesListIfNotExists(keys, names, tagsSlice); err != nil {
+		t.Fatal(err)
+	}
+
+	fs := idx.RetainFileSet()
+	defer fs.Release()
+	if fs.HasSeries([]byte("cpu"), models.NewTags(map[string]string{"host": "c"}), nil) {
+		t.Fatal("expected series to be dropped")
+	} else if !fs.HasSeries([]byte("mem"), models.NewTags(map[string]string{"host": "c"}), nil) {
+		t.Fatal("expected series to exist")
+	}
+}
+
+// Ensure index drops series that exceed the max-series-per-database limit.
+func TestIndex_CreateSeriesListIfNotExists_MaxSeriesPerDatabase(t *testing.T) {
+	opt := tsdb.NewEngineOptions()
+	opt.Config.MaxSeriesPerDatabase = 3
+	opt.Config.MaxValuesPerTag = 0
+	idx := MustOpenIndexWithOptions(opt)
+	defer idx.Close()
+
+	keys, names, tagsSlice := seriesList("cpu,host=a", "cpu,host=a", "cpu,host=b", "cpu,host=c", "cpu,host=d", "mem")
+	err := idx.CreateSeriesListIfNotExists(keys, names, tagsSlice)
+	if perr, ok := err.(*tsdb.PartialWriteError); !ok {
+		t.Fatalf("expected partial write error, got %v", err)
+	} else if perr.Dropped != 2 {
+		t.Fatalf("unexpected dropped count: %d", perr.Dropped)
+	} else if perr.Reason != "max-series-per-database limit exceeded: (3)" {
+		t.Fatalf("unexpected reason: %s", perr.Reason)
+	}
+
+	// Existing series can still be written.
+	keys, names, tagsSlice = seriesList("cpu,host=a")
+	if err := idx.CreateSeriesListIfNotExists(keys, names, tagsSlice); err != nil {
+		t.Fatal(err)
+	}
+
+	// Dropping a series makes room for a new series.
+	if err := idx.DropSeries([]byte("cpu,host=a")); err != nil {
+		t.Fatal(err)
+	}
+	keys, names, tagsSlice = seriesList("mem")
+	if err := idx.CreateSeriesListIfNotExists(keys, names, tagsSlice); err != nil {
+		t.Fatal(err)
+	}
+	keys, names, tagsSlice = seriesList("disk")
+	if err := idx.CreateSeriesListIfNotExists(keys, names, tagsSlice); err == nil {
+		t.Fatal("expected partial write error")
+	}
+}
+
+// Ensure index drops series that exceed the quotas of their measurement.
+func TestIndex_CreateSeriesListIfNotExists_MeasurementQuotas(t *testing.T) {
+	opt := tsdb.NewEngineOptions()
+	opt.Config.MaxSeriesPerDatabase = 0
+	opt.Config.MaxValuesPerTag = 0
+	opt.MeasurementQuotas = tsdb.NewMeasurementQuotas()
+	opt.MeasurementQuotas.Set(map[string]tsdb.MeasurementQuota{
+		"cpu": {MaxSeries: 3},
+		"mem": {MaxTagValues: map[string]int{"host": 1}},
+	})
+	idx := MustOpenIndexWithOptions(opt)
+	defer idx.Close()
+
+	keys, names, tagsSlice := seriesList("cpu,host=a", "cpu,host=b", "cpu,host=b", "cpu,host=c", "cpu,host=d", "mem,host=a", "mem,host=a,region=east", "mem,host=b", "disk,host=a", "disk,host=b")
+	err := idx.CreateSeriesListIfNotExists(keys, names, tagsSlice)
+	if perr, ok := err.(*tsdb.PartialWriteError); !ok {
+		t.Fatalf("expected partial write error, got %v", err)
+	} else if perr.Dropped != 2 {
+		t.Fatalf("unexpected dropped count: %d", perr.Dropped)
+	} else if !reflect.DeepEqual(perr.DroppedKeys, map[string]struct{}{"cpu,host=d": {}, "mem,host=b": {}}) {
+		t.Fatalf("unexpected dropped keys: %v", perr.DroppedKeys)
+	} else if exp := `measurement quota exceeded: measurement="cpu" max-series=3 dropped=1; measurement="mem" max-values=1 tag="host" dropped=1`; perr.Reason != exp {
+		t.Fatalf("unexpected reason: %s", perr.Reason)
+	}
+
+	// Raising a quota applies to new series.
+	opt.MeasurementQuotas.Set(map[string]tsdb.MeasurementQuota{"cpu": {MaxSeries: 4}})
+	keys, names, tagsSlice = seriesList("cpu,host=d", "mem,host=b")
+	if err := idx.CreateSeriesListIfNotExists(keys, names, tagsSlice); err != nil {
+		t.Fatal(err)
+	}
+	keys, names, tagsSlice = seriesList("cpu,host=e")
+	if err := idx.CreateSeriesListIfNotExists(keys, names, tagsSlice); err == nil {
+		t.Fatal("expected partial write error")
+	}
+}
+
+// seriesList returns the keys, names and tags of series keys.
+func seriesList(a ...string) (keys, names [][]byte, tagsSlice []models.Tags) {
+	for _, key := range a {
+		name, tags, _ := models.ParseKey([]byte(key))
+		keys = append(keys, []byte(key))
+		names = append(names, []byte(name))
+		tagsSlice = append(tagsSlice, tags)
+	}
+	return keys, names, tagsSlice
+}
+
 // Index is a test wrapper for tsi1.Index.
 type Index struct {
 	*tsi1.Index
@@ -261,6 +384,18 @@ func MustOpenIndex() *Index {
 	return idx
 }
 
+// MustOpenIndexWithOptions returns a new, open index created with opt. Panic on error.
+func MustOpenIndexWithOptions(opt tsdb.EngineOptions) *Index {
+	opt.IndexVersion = tsi1.IndexName
+	idx, err := tsdb.NewIndex(0, MustTempDir(), opt)
+	if err != nil {
+		panic(err)
+	} else if err := idx.Open(); err != nil {
+		panic(err)
+	}
+	return &Index{Index: idx.(*tsi1.Index)}
+}
+
 // Close closes and removes the index directory.
 func (idx *Index) Close() error {
 	defer os.RemoveAll(idx.Path)
@@ -0,0 +1,135 @@
+package tsi1
+
+import (
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+// Ensure index can be limited with the indexes of the other shards of its database.
+var _ tsdb.SeriesLimiterIndex = &Index{}
+
+// limitsEnabled returns true if the max-series-per-database or max-values-per-tag
+// limits are set or any measurement of the database has a quota.
+func (i *Index) limitsEnabled() bool {
+	return i.options.Config.MaxSeriesPerDatabase > 0 || i.options.Config.MaxValuesPerTag > 0 ||
+		i.options.MeasurementQuotas.Enabled()
+}
+
+// resetLimits discards the counts of the series limiter after series are dropped.
+func (i *Index) resetLimits() {
+	if i.limiter != nil {
+		i.limiter.Reset()
+	}
+}
+
+// createSeriesListWithLimits creates the series that don't exist and don't exceed the
+// max-values-per-tag or max-series-per-database limits or the measurement quotas of
+// the database.  Only new series are checked against the limits, by the series
+// limiter shared with the indexes of the other shards of the database.  It returns a
+// PartialWriteError if any series are dropped.
+func (i *Index) createSeriesListWithLimits(keys, names [][]byte, tagsSlice []models.Tags) error {
+	fs := i.RetainFileSet()
+	defer fs.Release()
+
+	// Filter out existing series and series repeated in the batch.
+	var newKeys, newNames [][]byte
+	var newTagsSlice []models.Tags
+	seen := make(map[string]struct{})
+	var buf []byte
+	for j := range names {
+		if fs.HasSeries(names[j], tagsSlice[j], buf) {
+			continue
+		}
+
+		var key []byte
+		if j < len(keys) && keys[j] != nil {
+			key = keys[j]
+		} else {
+			key = models.MakeKey(names[j], tagsSlice[j])
+		}
+		if _, ok := seen[string(key)]; ok {
+			continue
+		}
+		seen[string(key)] = struct{}{}
+
+		newKeys = append(newKeys, key)
+		newNames = append(newNames, names[j])
+		newTagsSlice = append(newTagsSlice, tagsSlice[j])
+	}
+	if len(newNames) == 0 {
+		return nil
+	}
+
+	return i.limiter.CreateSeriesList(newKeys, newNames, newTagsSlice, i.addSeriesList)
+}
+
+// HasSeries returns true if the series exists and is not deleted.
+func (i *Index) HasSeries(name []byte, tags models.Tags) bool {
+	fs := i.RetainFileSet()
+	defer fs.Release()
+	return fs.HasSeries(name, tags, nil)
+}
+
+// HasTagValue returns true if the tag value exists and is not deleted.
+func (i *Index) HasTagValue(name, key, value []byte) bool {
+	fs := i.RetainFileSet()
+	defer fs.Release()
+	return fs.HasTagValue(name, key, value)
+}
+
+// ForEachSeries calls fn for each series that is not deleted.
+func (i *Index) ForEachSeries(fn func(name []byte, tags models.Tags) error) error {
+	fs := i.RetainFileSet()
+	defer fs.Release()
+
+	itr := fs.SeriesIterator()
+	if itr == nil {
+		return nil
+	}
+	for e := itr.Next(); e != nil; e = itr.Next() {
+		if e.Deleted() {
+			continue
+		} else if err := fn(e.Name(), e.Tags()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForEachMeasurementSeries calls fn for each series of a measurement that is not deleted.
+func (i *Index) ForEachMeasurementSeries(name []byte, fn func(tags models.Tags) error) error {
+	fs := i.RetainFileSet()
+	defer fs.Release()
+
+	itr := fs.MeasurementSeriesIterator(name)
+	if itr == nil {
+		return nil
+	}
+	for e := itr.Next(); e != nil; e = itr.Next() {
+		if e.Deleted() {
+			continue
+		} else if err := fn(e.Tags()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForEachTagValue calls fn for each value of a tag key that is not deleted.
+func (i *Index) ForEachTagValue(name, key []byte, fn func(value []byte) error) error {
+	fs := i.RetainFileSet()
+	defer fs.Release()
+
+	itr := fs.TagValueIterator(name, key)
+	if itr == nil {
+		return nil
+	}
+	for e := itr.Next(); e != nil; e = itr.Next() {
+		if e.Deleted() {
+			continue
+		} else if err := fn(e.Value()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"hash/crc32"
 	"io"
 	"os"
+	"regexp"
 	"sort"
 	"sync"
 	"time"
@@ -334,6 +335,12 @@ func (f *LogFile) TagValueIterator(name, key []byte) TagValueIterator {
 	return tk.TagValueIterator()
 }
 
+// TagValueRegexIterator returns a value iterator for a tag key.  Log files do not
+// have a trigram index so all values are returned.
+func (f *LogFile) TagValueRegexIterator(name, key []byte, re *regexp.Regexp) TagValueIterator {
+	return f.TagValueIterator(name, key)
+}
+
 // DeleteTagKey adds a tombstone for a tag key to the log file.
 func (f *LogFile) DeleteTagKey(name, key []byte) error {
 	f.mu.Lock()
@@ -908,8 +915,9 @@ func (f *LogFile) writeTagsetTo(w io.Writer, name string, info *logFileCompactIn
 		tagSetInfo := mmInfo.tagSet[k]
 		assert(tagSetInfo != nil, "tag set info not found")
 
-		// Add each value.
-		for v, value := range tag.tagValues {
+		// Add each value in sorted order.
+		for _, v := range tag.keys() {
+			value := tag.tagValues[v]
 			tagValueInfo := tagSetInfo.tagValues[v]
 			sort.Sort(uint64Slice(tagValueInfo.seriesIDs))
 
@@ -1284,6 +1292,16 @@ type logTagKey struct {
 func (tk *logTagKey) Key() []byte   { return tk.name }
 func (tk *logTagKey) Deleted() bool { return tk.deleted }
 
+// keys returns a sorted list of tag values.
+func (tk *logTagKey) keys() []string {
+	a := make([]string, 0, len(tk.tagValues))
+	for v := range tk.tagValues {
+		a = append(a, v)
+	}
+	sort.Strings(a)
+	return a
+}
+
 func (tk *logTagKey) TagValueIterator() TagValueIterator {
 	a := make([]logTagValue, 0, len(tk.tagValues))
 	for _, v := range tk.tagValues {
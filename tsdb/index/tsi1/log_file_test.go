@@ -146,6 +146,41 @@ func TestLogFile_DeleteMeasurement(t *testing.T) {
 	}
 }
 
+// Ensure tag values are written to the index file in sorted order.
+func TestLogFile_WriteTo_SortedTagValues(t *testing.T) {
+	f := MustOpenLogFile()
+	defer f.Close()
+
+	var values []string
+	for i := 0; i < 100; i++ {
+		values = append(values, fmt.Sprintf("server-%03d", i))
+	}
+	for _, i := range rand.Perm(len(values)) {
+		if err := f.AddSeries([]byte("cpu"), models.Tags{models.NewTag([]byte("host"), []byte(values[i]))}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Compact log file into an index file.
+	var buf bytes.Buffer
+	if _, err := f.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	idx := tsi1.NewIndexFile()
+	if err := idx.UnmarshalBinary(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	itr := idx.TagValueIterator([]byte("cpu"), []byte("host"))
+	for e := itr.Next(); e != nil; e = itr.Next() {
+		got = append(got, string(e.Value()))
+	}
+	if len(got) != len(values) || !sort.StringsAreSorted(got) {
+		t.Fatalf("unexpected tag values: %v", got)
+	}
+}
+
 // LogFile is a test wrapper for tsi1.LogFile.
 type LogFile struct {
 	*tsi1.LogFile
@@ -6,21 +6,28 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
+	"sort"
 
 	"github.com/influxdata/influxdb/pkg/rhh"
+	"github.com/influxdata/influxdb/pkg/roaring"
 )
 
-// TagBlockVersion is the version of the tag block.
-const TagBlockVersion = 1
+// TagBlockVersion is the version of the tag block.  Version 2 adds series id
+// bitmaps, version 3 guarantees that the values of each key are sorted and version
+// 4 adds trigram indexes.  Blocks of earlier versions can still be read.
+const TagBlockVersion = 4
 
 // Tag key flag constants.
 const (
 	TagKeyTombstoneFlag = 0x01
+	TagKeyTrigramFlag   = 0x02 // values have a trigram index
 )
 
 // Tag value flag constants.
 const (
 	TagValueTombstoneFlag = 0x01
+	TagValueBitmapFlag    = 0x02 // series ids are encoded as a bitmap
 )
 
 // TagBlock variable size constants.
@@ -32,12 +39,17 @@ const (
 	// TagBlock value block fields.
 	TagValueNSize      = 8
 	TagValueOffsetSize = 8
+
+	// TagBlock trigram index fields.
+	TagTrigramNSize     = 8
+	TagTrigramEntrySize = TrigramSize + 8 + 8 // trigram, bitmap offset & size
 )
 
 // TagBlock errors.
 var (
 	ErrUnsupportedTagBlockVersion = errors.New("unsupported tag block version")
 	ErrTagBlockSizeMismatch       = errors.New("tag block size mismatch")
+	ErrInvalidTrigramIndex        = errors.New("invalid trigram index")
 )
 
 // TagBlock represents tag key/value block for a single measurement.
@@ -83,6 +95,7 @@ func (blk *TagBlock) UnmarshalBinary(data []byte) error {
 
 	// Save entire block.
 	blk.data = data
+	blk.version = t.Version
 
 	return nil
 }
@@ -106,6 +119,7 @@ func (blk *TagBlock) TagKeyElem(key []byte) TagKeyElem {
 		// Parse into element.
 		var e TagBlockKeyElem
 		e.unmarshal(blk.data[offset:], blk.data)
+		e.version = blk.version
 
 		// Return if keys match.
 		if bytes.Equal(e.key, key) {
@@ -200,6 +214,7 @@ func (itr *tagBlockKeyIterator) Next() TagKeyElem {
 
 	// Unmarshal next element & move data forward.
 	itr.e.unmarshal(itr.keyData, itr.blk.data)
+	itr.e.version = itr.blk.version
 	itr.keyData = itr.keyData[itr.e.size:]
 
 	assert(len(itr.e.Key()) > 0, "invalid zero-length tag key")
@@ -227,10 +242,51 @@ func (itr *tagBlockValueIterator) Next() TagValueElem {
 	return &itr.e
 }
 
+// tagBlockValueSliceIterator represents an iterator over a slice of tag values.
+type tagBlockValueSliceIterator struct {
+	a []TagBlockValueElem
+}
+
+// Next returns the next element in the iterator.
+func (itr *tagBlockValueSliceIterator) Next() (e TagValueElem) {
+	if len(itr.a) == 0 {
+		return nil
+	}
+	e, itr.a = &itr.a[0], itr.a[1:]
+	return e
+}
+
+// tagBlockValueElems sorts tag value elements by value.
+type tagBlockValueElems []TagBlockValueElem
+
+func (a tagBlockValueElems) Len() int           { return len(a) }
+func (a tagBlockValueElems) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a tagBlockValueElems) Less(i, j int) bool { return bytes.Compare(a[i].value, a[j].value) == -1 }
+
+// tagBlockValueOffsetIterator represents an iterator over the values of a tag key
+// at a set of offsets.
+type tagBlockValueOffsetIterator struct {
+	data []byte
+	itr  *roaring.Iterator
+	e    TagBlockValueElem
+}
+
+// Next returns the next element in the iterator.
+func (itr *tagBlockValueOffsetIterator) Next() TagValueElem {
+	offset, ok := itr.itr.Next()
+	if !ok {
+		return nil
+	}
+
+	itr.e.unmarshal(itr.data[offset:])
+	return &itr.e
+}
+
 // TagBlockKeyElem represents a tag key element in a TagBlock.
 type TagBlockKeyElem struct {
-	flag byte
-	key  []byte
+	flag    byte
+	key     []byte
+	version int // tag block version
 
 	// Value data
 	data struct {
@@ -246,6 +302,13 @@ type TagBlockKeyElem struct {
 		buf    []byte
 	}
 
+	// Value trigram index data
+	trigramIndex struct {
+		offset uint64
+		size   uint64
+		buf    []byte
+	}
+
 	size int
 
 	// Reusable iterator.
@@ -258,9 +321,99 @@ func (e *TagBlockKeyElem) Deleted() bool { return (e.flag & TagKeyTombstoneFlag)
 // Key returns the key name of the element.
 func (e *TagBlockKeyElem) Key() []byte { return e.key }
 
-// TagValueIterator returns an iterator over the key's values.
+// TagValueIterator returns an iterator over the key's values in sorted order.
+// Values may be unsorted before version 3 so the values of earlier blocks are
+// read and sorted first.
 func (e *TagBlockKeyElem) TagValueIterator() TagValueIterator {
-	return &tagBlockValueIterator{data: e.data.buf}
+	itr := &tagBlockValueIterator{data: e.data.buf}
+	if e.version >= 3 {
+		return itr
+	}
+
+	var a []TagBlockValueElem
+	for ve := itr.Next(); ve != nil; ve = itr.Next() {
+		a = append(a, *ve.(*TagBlockValueElem))
+	}
+	sort.Sort(tagBlockValueElems(a))
+	return &tagBlockValueSliceIterator{a: a}
+}
+
+// TagValueRegexIterator returns an iterator over the key's values that may match
+// re.  The values are narrowed down by the trigram index if one exists and re can
+// be decomposed into trigrams.  Otherwise all values are returned.  The caller is
+// still required to match the values against re.
+func (e *TagBlockKeyElem) TagValueRegexIterator(re *regexp.Regexp) TagValueIterator {
+	if (e.flag & TagKeyTrigramFlag) == 0 {
+		return e.TagValueIterator()
+	}
+
+	q := newTrigramQuery(re)
+	if q == nil {
+		return e.TagValueIterator()
+	}
+
+	// Union the values containing all trigrams of each alternative.  All values are
+	// returned if the trigram index cannot be read.
+	offsets := roaring.NewBitmap()
+	for _, trigrams := range q {
+		var a *roaring.Bitmap
+		for _, trigram := range trigrams {
+			b, err := e.trigramBitmap(trigram)
+			if err != nil {
+				return e.TagValueIterator()
+			} else if b == nil {
+				a = nil
+				break
+			} else if a == nil {
+				a = b
+			} else {
+				a = a.And(b)
+			}
+		}
+
+		if a != nil {
+			offsets = offsets.Or(a)
+		}
+	}
+	return &tagBlockValueOffsetIterator{data: e.data.buf, itr: offsets.Iterator()}
+}
+
+// trigramBitmap returns the value offsets of values containing trigram.
+// Returns nil if no value contains the trigram.
+func (e *TagBlockKeyElem) trigramBitmap(trigram string) (*roaring.Bitmap, error) {
+	buf := e.trigramIndex.buf
+	if len(buf) < TagTrigramNSize {
+		return nil, ErrInvalidTrigramIndex
+	}
+	n := int(binary.BigEndian.Uint64(buf[:TagTrigramNSize]))
+	entries := buf[TagTrigramNSize:]
+	if n < 0 || n > len(entries)/TagTrigramEntrySize {
+		return nil, ErrInvalidTrigramIndex
+	}
+
+	// Binary search the sorted entries.
+	i := sort.Search(n, func(i int) bool {
+		return string(entries[i*TagTrigramEntrySize:i*TagTrigramEntrySize+TrigramSize]) >= trigram
+	})
+	if i == n {
+		return nil, nil
+	}
+	entry := entries[i*TagTrigramEntrySize:]
+	if string(entry[:TrigramSize]) != trigram {
+		return nil, nil
+	}
+
+	offset := binary.BigEndian.Uint64(entry[TrigramSize:])
+	size := binary.BigEndian.Uint64(entry[TrigramSize+8:])
+	if offset > uint64(len(buf)) || size > uint64(len(buf))-offset {
+		return nil, ErrInvalidTrigramIndex
+	}
+
+	var b roaring.Bitmap
+	if err := b.UnmarshalBinary(buf[offset : offset+size]); err != nil {
+		return nil, ErrInvalidTrigramIndex
+	}
+	return &b, nil
 }
 
 // unmarshal unmarshals buf into e.
@@ -287,6 +440,15 @@ func (e *TagBlockKeyElem) unmarshal(buf, data []byte) {
 	e.hashIndex.buf = data[e.hashIndex.offset:]
 	e.hashIndex.buf = e.hashIndex.buf[:e.hashIndex.size]
 
+	// Parse trigram index offset/size and slice data, if available.
+	if (e.flag & TagKeyTrigramFlag) != 0 {
+		e.trigramIndex.offset, buf = binary.BigEndian.Uint64(buf), buf[8:]
+		e.trigramIndex.size, buf = binary.BigEndian.Uint64(buf), buf[8:]
+
+		e.trigramIndex.buf = data[e.trigramIndex.offset:]
+		e.trigramIndex.buf = e.trigramIndex.buf[:e.trigramIndex.size]
+	}
+
 	// Parse key.
 	n, sz := binary.Uvarint(buf)
 	e.key, buf = buf[sz:sz+int(n)], buf[int(n)+sz:]
@@ -300,8 +462,9 @@ type TagBlockValueElem struct {
 	flag   byte
 	value  []byte
 	series struct {
-		n    uint64 // Series count
-		data []byte // Raw series data
+		n    uint64          // Series count
+		data []byte          // Raw series data
+		ids  *roaring.Bitmap // Decoded series ids
 	}
 
 	size int
@@ -320,19 +483,75 @@ func (e *TagBlockValueElem) SeriesN() uint64 { return e.series.n }
 func (e *TagBlockValueElem) SeriesData() []byte { return e.series.data }
 
 // SeriesID returns series ID at an index.
-func (e *TagBlockValueElem) SeriesID(i int) uint64 {
-	return binary.BigEndian.Uint64(e.series.data[i*SeriesIDSize:])
+func (e *TagBlockValueElem) SeriesID(i int) (uint64, error) {
+	if !e.bitmap() {
+		return binary.BigEndian.Uint64(e.series.data[i*SeriesIDSize:]), nil
+	}
+
+	ids, err := e.SeriesIDSet()
+	if err != nil {
+		return 0, err
+	}
+	id, ok := ids.Select(uint64(i))
+	if !ok {
+		return 0, fmt.Errorf("series id index out of range: %d", i)
+	}
+	return id, nil
 }
 
 // SeriesIDs returns a list decoded series ids.
-func (e *TagBlockValueElem) SeriesIDs() []uint64 {
+func (e *TagBlockValueElem) SeriesIDs() ([]uint64, error) {
+	if e.bitmap() {
+		ids, err := e.SeriesIDSet()
+		if err != nil {
+			return nil, err
+		}
+		return ids.Slice(), nil
+	}
+
 	a := make([]uint64, e.series.n)
 	for i := 0; i < int(e.series.n); i++ {
-		a[i] = e.SeriesID(i)
+		a[i] = binary.BigEndian.Uint64(e.series.data[i*SeriesIDSize:])
+	}
+	return a, nil
+}
+
+// SeriesIDSet returns the series ids as a bitmap.  The bitmap is decoded once and
+// must not be modified.
+func (e *TagBlockValueElem) SeriesIDSet() (*roaring.Bitmap, error) {
+	if e.series.ids != nil {
+		return e.series.ids, nil
+	}
+
+	if !e.bitmap() {
+		e.series.ids = newSeriesIDSet(&rawSeriesIDIterator{data: e.series.data})
+		return e.series.ids, nil
+	}
+
+	var b roaring.Bitmap
+	if err := b.UnmarshalBinary(e.series.data); err != nil {
+		return nil, fmt.Errorf("invalid series bitmap: %s", err)
+	}
+	e.series.ids = &b
+	return e.series.ids, nil
+}
+
+// seriesIDIterator returns an iterator over the series ids.
+func (e *TagBlockValueElem) seriesIDIterator() (seriesIDIterator, error) {
+	if !e.bitmap() {
+		return &rawSeriesIDIterator{data: e.series.data}, nil
+	}
+
+	ids, err := e.SeriesIDSet()
+	if err != nil {
+		return nil, err
 	}
-	return a
+	return newBitmapSeriesIDIterator(ids), nil
 }
 
+// bitmap returns true if the series ids are encoded as a bitmap.
+func (e *TagBlockValueElem) bitmap() bool { return (e.flag & TagValueBitmapFlag) != 0 }
+
 // Size returns the size of the element.
 func (e *TagBlockValueElem) Size() int { return e.size }
 
@@ -352,8 +571,14 @@ func (e *TagBlockValueElem) unmarshal(buf []byte) {
 	buf = buf[n:]
 
 	// Save reference to series data.
-	e.series.data = buf[:e.series.n*SeriesIDSize]
-	buf = buf[e.series.n*SeriesIDSize:]
+	e.series.ids = nil
+	if e.bitmap() {
+		sz, n = binary.Uvarint(buf)
+		e.series.data, buf = buf[n:n+int(sz)], buf[n+int(sz):]
+	} else {
+		e.series.data = buf[:e.series.n*SeriesIDSize]
+		buf = buf[e.series.n*SeriesIDSize:]
+	}
 
 	// Save length of elem.
 	e.size = start - len(buf)
@@ -417,7 +642,7 @@ func (t *TagBlockTrailer) WriteTo(w io.Writer) (n int64, err error) {
 	// Write total size & encoding version.
 	if err := writeUint64To(w, uint64(t.Size), &n); err != nil {
 		return n, err
-	} else if err := writeUint16To(w, IndexFileVersion, &n); err != nil {
+	} else if err := writeUint16To(w, TagBlockVersion, &n); err != nil {
 		return n, err
 	}
 
@@ -430,7 +655,7 @@ func ReadTagBlockTrailer(data []byte) (TagBlockTrailer, error) {
 
 	// Read version.
 	t.Version = int(binary.BigEndian.Uint16(data[len(data)-2:]))
-	if t.Version != TagBlockVersion {
+	if t.Version < 1 || t.Version > TagBlockVersion {
 		return t, ErrUnsupportedTagBlockVersion
 	}
 
@@ -462,6 +687,9 @@ type TagBlockEncoder struct {
 	// Track value offsets.
 	offsets *rhh.HashMap
 
+	// Track offsets of values by trigram, relative to the key's value data.
+	trigrams map[string]*roaring.Bitmap
+
 	// Track bytes written, sections.
 	n       int64
 	trailer TagBlockTrailer
@@ -473,8 +701,9 @@ type TagBlockEncoder struct {
 // NewTagBlockEncoder returns a new TagBlockEncoder.
 func NewTagBlockEncoder(w io.Writer) *TagBlockEncoder {
 	return &TagBlockEncoder{
-		w:       w,
-		offsets: rhh.NewHashMap(rhh.Options{LoadFactor: LoadFactor}),
+		w:        w,
+		offsets:  rhh.NewHashMap(rhh.Options{LoadFactor: LoadFactor}),
+		trigrams: make(map[string]*roaring.Bitmap),
 		trailer: TagBlockTrailer{
 			Version: TagBlockVersion,
 		},
@@ -520,6 +749,7 @@ func (enc *TagBlockEncoder) EncodeKey(key []byte, deleted bool) error {
 
 // EncodeValue writes a tag value to the underlying writer.
 // The tag key must be lexicographical sorted after the previous encoded tag key.
+// The sorted series ids are encoded as a bitmap if it is smaller than the list.
 func (enc *TagBlockEncoder) EncodeValue(value []byte, deleted bool, seriesIDs []uint64) error {
 	if len(enc.keys) == 0 {
 		return fmt.Errorf("tag key must be encoded before encoding values")
@@ -530,8 +760,29 @@ func (enc *TagBlockEncoder) EncodeValue(value []byte, deleted bool, seriesIDs []
 	// Save offset to hash map.
 	enc.offsets.Put(value, enc.n)
 
+	// Add offset to the trigram index.
+	offset := uint64(enc.n - enc.keys[len(enc.keys)-1].data.offset)
+	for _, trigram := range appendTrigrams(nil, value) {
+		b := enc.trigrams[trigram]
+		if b == nil {
+			b = roaring.NewBitmap()
+			enc.trigrams[trigram] = b
+		}
+		b.Add(offset)
+	}
+
+	// Encode series ids as a bitmap if it is smaller.
+	var bitmap []byte
+	if b := roaring.NewBitmap(seriesIDs...); b.Size() < len(seriesIDs)*SeriesIDSize {
+		bitmap, _ = b.MarshalBinary()
+	}
+
 	// Write flag.
-	if err := writeUint8To(enc.w, encodeTagValueFlag(deleted), &enc.n); err != nil {
+	flag := encodeTagValueFlag(deleted)
+	if bitmap != nil {
+		flag |= TagValueBitmapFlag
+	}
+	if err := writeUint8To(enc.w, flag, &enc.n); err != nil {
 		return err
 	}
 
@@ -547,7 +798,15 @@ func (enc *TagBlockEncoder) EncodeValue(value []byte, deleted bool, seriesIDs []
 		return err
 	}
 
-	// Write series ids.
+	// Write series bitmap or ids.
+	if bitmap != nil {
+		if err := writeUvarintTo(enc.w, uint64(len(bitmap)), &enc.n); err != nil {
+			return err
+		} else if err := writeTo(enc.w, bitmap, &enc.n); err != nil {
+			return err
+		}
+		return nil
+	}
 	for _, seriesID := range seriesIDs {
 		if err := writeUint64To(enc.w, seriesID, &enc.n); err != nil {
 			return err
@@ -631,6 +890,59 @@ func (enc *TagBlockEncoder) flushValueHashIndex() error {
 	// Clear offsets.
 	enc.offsets = rhh.NewHashMap(rhh.Options{LoadFactor: LoadFactor})
 
+	return enc.flushValueTrigramIndex()
+}
+
+// flushValueTrigramIndex writes the trigram index at the end of a value set.
+// The index is a sorted list of trigrams with the offsets and sizes of bitmaps
+// of the offsets of the values containing the trigram, followed by the bitmaps.
+func (enc *TagBlockEncoder) flushValueTrigramIndex() error {
+	key := &enc.keys[len(enc.keys)-1]
+	if len(enc.trigrams) == 0 {
+		return nil
+	}
+
+	trigrams := make([]string, 0, len(enc.trigrams))
+	for trigram := range enc.trigrams {
+		trigrams = append(trigrams, trigram)
+	}
+	sort.Strings(trigrams)
+
+	bitmaps := make([][]byte, len(trigrams))
+	for i, trigram := range trigrams {
+		bitmaps[i], _ = enc.trigrams[trigram].MarshalBinary()
+	}
+
+	// Encode trigram count.
+	key.trigramIndex.offset = enc.n
+	if err := writeUint64To(enc.w, uint64(len(trigrams)), &enc.n); err != nil {
+		return err
+	}
+
+	// Encode trigram entries.
+	offset := uint64(TagTrigramNSize + len(trigrams)*TagTrigramEntrySize)
+	for i, trigram := range trigrams {
+		if err := writeTo(enc.w, []byte(trigram), &enc.n); err != nil {
+			return err
+		} else if err := writeUint64To(enc.w, offset, &enc.n); err != nil {
+			return err
+		} else if err := writeUint64To(enc.w, uint64(len(bitmaps[i])), &enc.n); err != nil {
+			return err
+		}
+		offset += uint64(len(bitmaps[i]))
+	}
+
+	// Encode bitmaps.
+	for _, bitmap := range bitmaps {
+		if err := writeTo(enc.w, bitmap, &enc.n); err != nil {
+			return err
+		}
+	}
+	key.trigramIndex.size = enc.n - key.trigramIndex.offset
+
+	// Clear trigrams.
+	enc.trigrams = make(map[string]*roaring.Bitmap)
+
 	return nil
 }
 
@@ -646,7 +958,11 @@ func (enc *TagBlockEncoder) encodeTagKeyBlock() error {
 		// Save current offset so we can use it in the hash index.
 		offsets.Put(entry.key, enc.n)
 
-		if err := writeUint8To(enc.w, encodeTagKeyFlag(entry.deleted), &enc.n); err != nil {
+		flag := encodeTagKeyFlag(entry.deleted)
+		if entry.trigramIndex.size > 0 {
+			flag |= TagKeyTrigramFlag
+		}
+		if err := writeUint8To(enc.w, flag, &enc.n); err != nil {
 			return err
 		}
 
@@ -664,6 +980,15 @@ func (enc *TagBlockEncoder) encodeTagKeyBlock() error {
 			return err
 		}
 
+		// Write value trigram index offset & size, if available.
+		if entry.trigramIndex.size > 0 {
+			if err := writeUint64To(enc.w, uint64(entry.trigramIndex.offset), &enc.n); err != nil {
+				return err
+			} else if err := writeUint64To(enc.w, uint64(entry.trigramIndex.size), &enc.n); err != nil {
+				return err
+			}
+		}
+
 		// Write key length and data.
 		if err := writeUvarintTo(enc.w, uint64(len(entry.key)), &enc.n); err != nil {
 			return err
@@ -704,6 +1029,10 @@ type tagKeyEncodeEntry struct {
 		offset int64
 		size   int64
 	}
+	trigramIndex struct {
+		offset int64
+		size   int64
+	}
 }
 
 func encodeTagKeyFlag(deleted bool) byte {
@@ -2,8 +2,10 @@ package tsi1_test
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/influxdata/influxdb/tsdb/index/tsi1"
@@ -49,32 +51,282 @@ func TestTagBlockWriter(t *testing.T) {
 	// Verify data.
 	if e := blk.TagValueElem([]byte("region"), []byte("us-east")); e == nil {
 		t.Fatal("expected element")
-	} else if a := e.(*tsi1.TagBlockValueElem).SeriesIDs(); !reflect.DeepEqual(a, []uint64{1, 2}) {
+	} else if a, err := e.(*tsi1.TagBlockValueElem).SeriesIDs(); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(a, []uint64{1, 2}) {
 		t.Fatalf("unexpected series ids: %#v", a)
 	}
 
 	if e := blk.TagValueElem([]byte("region"), []byte("us-west")); e == nil {
 		t.Fatal("expected element")
-	} else if a := e.(*tsi1.TagBlockValueElem).SeriesIDs(); !reflect.DeepEqual(a, []uint64{3}) {
+	} else if a, err := e.(*tsi1.TagBlockValueElem).SeriesIDs(); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(a, []uint64{3}) {
 		t.Fatalf("unexpected series ids: %#v", a)
 	}
 	if e := blk.TagValueElem([]byte("host"), []byte("server0")); e == nil {
 		t.Fatal("expected element")
-	} else if a := e.(*tsi1.TagBlockValueElem).SeriesIDs(); !reflect.DeepEqual(a, []uint64{1}) {
+	} else if a, err := e.(*tsi1.TagBlockValueElem).SeriesIDs(); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(a, []uint64{1}) {
 		t.Fatalf("unexpected series ids: %#v", a)
 	}
 	if e := blk.TagValueElem([]byte("host"), []byte("server1")); e == nil {
 		t.Fatal("expected element")
-	} else if a := e.(*tsi1.TagBlockValueElem).SeriesIDs(); !reflect.DeepEqual(a, []uint64{2}) {
+	} else if a, err := e.(*tsi1.TagBlockValueElem).SeriesIDs(); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(a, []uint64{2}) {
 		t.Fatalf("unexpected series ids: %#v", a)
 	}
 	if e := blk.TagValueElem([]byte("host"), []byte("server2")); e == nil {
 		t.Fatal("expected element")
-	} else if a := e.(*tsi1.TagBlockValueElem).SeriesIDs(); !reflect.DeepEqual(a, []uint64{3}) {
+	} else if a, err := e.(*tsi1.TagBlockValueElem).SeriesIDs(); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(a, []uint64{3}) {
 		t.Fatalf("unexpected series ids: %#v", a)
 	}
 }
 
+// Ensure large series sets are stored as bitmaps and can be read back.
+func TestTagBlockWriter_Bitmap(t *testing.T) {
+	seriesIDs := make([]uint64, 1000)
+	for i := range seriesIDs {
+		seriesIDs[i] = uint64(i*3 + 1)
+	}
+
+	var buf bytes.Buffer
+	enc := tsi1.NewTagBlockEncoder(&buf)
+	if err := enc.EncodeKey([]byte("host"), false); err != nil {
+		t.Fatal(err)
+	} else if err := enc.EncodeValue([]byte("server0"), false, seriesIDs); err != nil {
+		t.Fatal(err)
+	} else if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	} else if buf.Len() >= len(seriesIDs)*tsi1.SeriesIDSize {
+		t.Fatalf("expected bitmap encoding, block size: %d", buf.Len())
+	}
+
+	var blk tsi1.TagBlock
+	if err := blk.UnmarshalBinary(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+
+	e := blk.TagValueElem([]byte("host"), []byte("server0"))
+	if e == nil {
+		t.Fatal("expected element")
+	}
+	elem := e.(*tsi1.TagBlockValueElem)
+	if a, err := elem.SeriesIDs(); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(a, seriesIDs) {
+		t.Fatalf("unexpected series ids: %d ids", len(a))
+	} else if n := elem.SeriesN(); n != uint64(len(seriesIDs)) {
+		t.Fatalf("unexpected series count: %d", n)
+	} else if ids, err := elem.SeriesIDSet(); err != nil {
+		t.Fatal(err)
+	} else if a := ids.Slice(); !reflect.DeepEqual(a, seriesIDs) {
+		t.Fatalf("unexpected series id set: %d ids", len(a))
+	}
+	for i := range seriesIDs {
+		if id, err := elem.SeriesID(i); err != nil {
+			t.Fatal(err)
+		} else if id != seriesIDs[i] {
+			t.Fatalf("unexpected series id at %d: %d", i, id)
+		}
+	}
+	if _, err := elem.SeriesID(len(seriesIDs)); err == nil {
+		t.Fatal("expected error")
+	}
+
+	// Corrupt the bitmap's container count.
+	copy(elem.SeriesData(), []byte{0xFF, 0xFF, 0xFF, 0xFF})
+	elem = blk.TagValueElem([]byte("host"), []byte("server0")).(*tsi1.TagBlockValueElem)
+	if _, err := elem.SeriesIDs(); err == nil {
+		t.Fatal("expected error")
+	} else if _, err := elem.SeriesID(0); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+// Ensure tag blocks of earlier versions can be read and later versions are rejected.
+func TestReadTagBlockTrailer_Version(t *testing.T) {
+	var buf bytes.Buffer
+	enc := tsi1.NewTagBlockEncoder(&buf)
+	if err := enc.EncodeKey([]byte("host"), false); err != nil {
+		t.Fatal(err)
+	} else if err := enc.EncodeValue([]byte("server0"), false, []uint64{1}); err != nil {
+		t.Fatal(err)
+	} else if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	if tr, err := tsi1.ReadTagBlockTrailer(data); err != nil {
+		t.Fatal(err)
+	} else if tr.Version != tsi1.TagBlockVersion {
+		t.Fatalf("unexpected version: %d", tr.Version)
+	}
+
+	binary.BigEndian.PutUint16(data[len(data)-2:], 1)
+	var blk tsi1.TagBlock
+	if err := blk.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	} else if blk.Version() != 1 {
+		t.Fatalf("unexpected version: %d", blk.Version())
+	}
+
+	binary.BigEndian.PutUint16(data[len(data)-2:], tsi1.TagBlockVersion+1)
+	if _, err := tsi1.ReadTagBlockTrailer(data); err != tsi1.ErrUnsupportedTagBlockVersion {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Ensure values are returned in order, including from blocks of versions whose
+// values may be unsorted.
+func TestTagBlockKeyElem_TagValueIterator_Sorted(t *testing.T) {
+	for _, tt := range []struct {
+		version int
+		values  []string
+	}{
+		{version: tsi1.TagBlockVersion, values: []string{"api01", "db-prod-01", "db-prod-02", "web01"}},
+		{version: 2, values: []string{"db-prod-02", "web01", "db-prod-01", "api01"}},
+	} {
+		var buf bytes.Buffer
+		enc := tsi1.NewTagBlockEncoder(&buf)
+		if err := enc.EncodeKey([]byte("host"), false); err != nil {
+			t.Fatal(err)
+		}
+		for i, v := range tt.values {
+			if err := enc.EncodeValue([]byte(v), false, []uint64{uint64(i + 1)}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := enc.Close(); err != nil {
+			t.Fatal(err)
+		}
+		data := buf.Bytes()
+		binary.BigEndian.PutUint16(data[len(data)-2:], uint16(tt.version))
+
+		var blk tsi1.TagBlock
+		if err := blk.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+
+		var got []string
+		itr := blk.TagKeyElem([]byte("host")).TagValueIterator()
+		for e := itr.Next(); e != nil; e = itr.Next() {
+			got = append(got, string(e.Value()))
+		}
+		if exp := []string{"api01", "db-prod-01", "db-prod-02", "web01"}; !reflect.DeepEqual(got, exp) {
+			t.Fatalf("version %d: unexpected values: %v", tt.version, got)
+		}
+	}
+}
+
+// Ensure all values are returned for a regex if the trigram index is invalid.
+func TestTagBlockKeyElem_TagValueRegexIterator_InvalidTrigramIndex(t *testing.T) {
+	var buf bytes.Buffer
+	enc := tsi1.NewTagBlockEncoder(&buf)
+	if err := enc.EncodeKey([]byte("host"), false); err != nil {
+		t.Fatal(err)
+	} else if err := enc.EncodeValue([]byte("abc"), false, []uint64{1}); err != nil {
+		t.Fatal(err)
+	} else if err := enc.EncodeValue([]byte("abd"), false, []uint64{2}); err != nil {
+		t.Fatal(err)
+	} else if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	// Corrupt the bitmap size of the first trigram entry.
+	hdr := make([]byte, 8, 8+tsi1.TrigramSize)
+	binary.BigEndian.PutUint64(hdr, 2)
+	i := bytes.Index(data, append(hdr, "abc"...))
+	if i == -1 {
+		t.Fatal("trigram index not found")
+	}
+	binary.BigEndian.PutUint64(data[i+8+tsi1.TrigramSize+8:], 1<<40)
+
+	var blk tsi1.TagBlock
+	if err := blk.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	ke := blk.TagKeyElem([]byte("host")).(*tsi1.TagBlockKeyElem)
+
+	var got []string
+	itr := ke.TagValueRegexIterator(regexp.MustCompile(`abc`))
+	for e := itr.Next(); e != nil; e = itr.Next() {
+		got = append(got, string(e.Value()))
+	}
+	if exp := []string{"abc", "abd"}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected values: %v", got)
+	}
+}
+
+// Ensure the trigram index narrows down the values that may match a regex.
+func TestTagBlockKeyElem_TagValueRegexIterator(t *testing.T) {
+	values := []string{"api01", "db-prod-01", "db-prod-02", "web-prod-01", "web-stage-01", "x"}
+
+	var buf bytes.Buffer
+	enc := tsi1.NewTagBlockEncoder(&buf)
+	if err := enc.EncodeKey([]byte("host"), false); err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range values {
+		if err := enc.EncodeValue([]byte(v), false, []uint64{uint64(i + 1)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var blk tsi1.TagBlock
+	if err := blk.UnmarshalBinary(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	ke := blk.TagKeyElem([]byte("host")).(*tsi1.TagBlockKeyElem)
+
+	for _, tt := range []struct {
+		re  string
+		exp []string
+	}{
+		{re: `prod`, exp: []string{"db-prod-01", "db-prod-02", "web-prod-01"}},
+		{re: `.*prod.*`, exp: []string{"db-prod-01", "db-prod-02", "web-prod-01"}},
+		{re: `^web-(prod|stage)`, exp: []string{"web-prod-01", "web-stage-01"}},
+		{re: `prod-0[12]$`, exp: []string{"db-prod-01", "db-prod-02", "web-prod-01"}},
+		{re: `(api|db-prod-)0\d`, exp: []string{"api01", "db-prod-01", "db-prod-02"}},
+		{re: `zzz`},
+
+		// Regexes that cannot be decomposed return all values.
+		{re: `(?i)PROD`, exp: values},
+		{re: `x|prod`, exp: values},
+		{re: `^.*$`, exp: values},
+	} {
+		re := regexp.MustCompile(tt.re)
+
+		var got []string
+		itr := ke.TagValueRegexIterator(re)
+		for e := itr.Next(); e != nil; e = itr.Next() {
+			got = append(got, string(e.Value()))
+		}
+		if !reflect.DeepEqual(got, tt.exp) {
+			t.Errorf("%s: unexpected values: %v", tt.re, got)
+		}
+
+		// Every matching value must be returned.
+		m := make(map[string]struct{})
+		for _, v := range got {
+			m[v] = struct{}{}
+		}
+		for _, v := range values {
+			if _, ok := m[v]; re.MatchString(v) && !ok {
+				t.Errorf("%s: matching value not returned: %s", tt.re, v)
+			}
+		}
+	}
+}
+
 var benchmarkTagBlock10x1000 *tsi1.TagBlock
 var benchmarkTagBlock100x1000 *tsi1.TagBlock
 var benchmarkTagBlock1000x1000 *tsi1.TagBlock
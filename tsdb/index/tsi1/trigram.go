@@ -0,0 +1,208 @@
+package tsi1
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"sort"
+)
+
+// TrigramSize is the size of a trigram in bytes.
+const TrigramSize = 3
+
+// maxTrigramAlternatives is the maximum number of exact strings or alternatives
+// tracked while decomposing a regular expression.
+const maxTrigramAlternatives = 16
+
+// appendTrigrams appends the trigrams of value to dst.  Trigrams that occur more
+// than once in value are appended more than once.
+func appendTrigrams(dst []string, value []byte) []string {
+	for i := 0; i+TrigramSize <= len(value); i++ {
+		dst = append(dst, string(value[i:i+TrigramSize]))
+	}
+	return dst
+}
+
+// dedupeStrings removes duplicates from a sorted slice of strings in place.
+func dedupeStrings(a []string) []string {
+	if len(a) < 2 {
+		return a
+	}
+
+	j := 1
+	for i := 1; i < len(a); i++ {
+		if a[i] != a[j-1] {
+			a[j] = a[i]
+			j++
+		}
+	}
+	return a[:j]
+}
+
+// trigramQuery represents the trigrams required by a regular expression.  A value
+// can only match if it contains every trigram of at least one of the alternatives.
+type trigramQuery [][]string
+
+// newTrigramQuery returns the trigram query for re.  Returns nil if re cannot be
+// decomposed into required trigrams, e.g. if it can match the empty string.
+func newTrigramQuery(re *regexp.Regexp) trigramQuery {
+	expr, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return nil
+	}
+
+	var q trigramQuery
+	for _, fragments := range analyzeRegex(expr.Simplify()).fragments() {
+		var trigrams []string
+		for _, s := range fragments {
+			trigrams = appendTrigrams(trigrams, []byte(s))
+		}
+
+		// Every value may match if an alternative requires no trigrams.
+		if len(trigrams) == 0 {
+			return nil
+		}
+		sort.Strings(trigrams)
+		q = append(q, dedupeStrings(trigrams))
+	}
+	return q
+}
+
+// regexInfo describes the strings matched by a regular expression.
+type regexInfo struct {
+	// If exact is true, the expression matches exactly the strings in set.
+	exact bool
+	set   []string
+
+	// Otherwise any match contains all strings of at least one alternative.
+	alternatives [][]string
+}
+
+// anyMatch returns info for an expression that may match any string.
+func anyMatch() regexInfo { return regexInfo{alternatives: [][]string{{}}} }
+
+// exactMatch returns info for an expression that matches exactly the strings in set.
+func exactMatch(set ...string) regexInfo { return regexInfo{exact: true, set: set} }
+
+// fragments returns the alternatives of substrings required by a match.
+func (info regexInfo) fragments() [][]string {
+	if !info.exact {
+		return info.alternatives
+	}
+
+	a := make([][]string, len(info.set))
+	for i, s := range info.set {
+		a[i] = []string{s}
+	}
+	return a
+}
+
+// analyzeRegex returns the strings matched by a simplified regular expression.
+func analyzeRegex(re *syntax.Regexp) regexInfo {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return exactMatch()
+
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine,
+		syntax.OpBeginText, syntax.OpEndText, syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return exactMatch("")
+
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase != 0 {
+			return anyMatch()
+		}
+		return exactMatch(string(re.Rune))
+
+	case syntax.OpCharClass:
+		// Expand small classes such as [ab] into their characters.
+		var set []string
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			for r := re.Rune[i]; r <= re.Rune[i+1]; r++ {
+				if len(set) == maxTrigramAlternatives {
+					return anyMatch()
+				}
+				set = append(set, string(r))
+			}
+		}
+		return exactMatch(set...)
+
+	case syntax.OpCapture:
+		return analyzeRegex(re.Sub[0])
+
+	case syntax.OpQuest:
+		if info := analyzeRegex(re.Sub[0]); info.exact {
+			return alternateRegexInfo(info, exactMatch(""))
+		}
+		return anyMatch()
+
+	case syntax.OpPlus:
+		// A match contains at least one match of the sub-expression.
+		return regexInfo{alternatives: analyzeRegex(re.Sub[0]).fragments()}
+
+	case syntax.OpRepeat:
+		if re.Min == 0 {
+			return anyMatch()
+		}
+		return regexInfo{alternatives: analyzeRegex(re.Sub[0]).fragments()}
+
+	case syntax.OpConcat:
+		info := exactMatch("")
+		for _, sub := range re.Sub {
+			info = concatRegexInfo(info, analyzeRegex(sub))
+		}
+		return info
+
+	case syntax.OpAlternate:
+		info := analyzeRegex(re.Sub[0])
+		for _, sub := range re.Sub[1:] {
+			info = alternateRegexInfo(info, analyzeRegex(sub))
+		}
+		return info
+	}
+
+	// Any character, star and everything else can match any string.
+	return anyMatch()
+}
+
+// concatRegexInfo returns the info for the concatenation of two expressions.
+func concatRegexInfo(x, y regexInfo) regexInfo {
+	if x.exact && y.exact && len(x.set)*len(y.set) <= maxTrigramAlternatives {
+		set := make([]string, 0, len(x.set)*len(y.set))
+		for _, a := range x.set {
+			for _, b := range y.set {
+				set = append(set, a+b)
+			}
+		}
+		return exactMatch(set...)
+	}
+
+	// A match contains the required substrings of both expressions.  If there
+	// are too many combinations then only the side with fewer is required.
+	xa, ya := x.fragments(), y.fragments()
+	if len(xa)*len(ya) > maxTrigramAlternatives {
+		if len(xa) <= len(ya) {
+			return regexInfo{alternatives: xa}
+		}
+		return regexInfo{alternatives: ya}
+	}
+
+	alternatives := make([][]string, 0, len(xa)*len(ya))
+	for _, a := range xa {
+		for _, b := range ya {
+			alternatives = append(alternatives, append(append([]string{}, a...), b...))
+		}
+	}
+	return regexInfo{alternatives: alternatives}
+}
+
+// alternateRegexInfo returns the info for the alternation of two expressions.
+func alternateRegexInfo(x, y regexInfo) regexInfo {
+	if x.exact && y.exact && len(x.set)+len(y.set) <= maxTrigramAlternatives {
+		return exactMatch(append(append([]string{}, x.set...), y.set...)...)
+	}
+
+	alternatives := append(append([][]string{}, x.fragments()...), y.fragments()...)
+	if len(alternatives) > maxTrigramAlternatives {
+		return anyMatch()
+	}
+	return regexInfo{alternatives: alternatives}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/influxdata/influxdb/influxql"
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/roaring"
 )
 
 // LoadFactor is the fill percent for RHH indexes.
@@ -340,6 +341,36 @@ func (p tagValueMergeElem) Deleted() bool {
 	return p[0].Deleted()
 }
 
+// tagValuePrefixIterator returns the values of a sorted iterator that begin with a prefix.
+type tagValuePrefixIterator struct {
+	itr    TagValueIterator
+	prefix []byte
+	done   bool
+}
+
+// newTagValuePrefixIterator returns an iterator over the values of itr that begin with prefix.
+func newTagValuePrefixIterator(itr TagValueIterator, prefix []byte) *tagValuePrefixIterator {
+	return &tagValuePrefixIterator{itr: itr, prefix: prefix}
+}
+
+// Next returns the next value with the prefix.  The underlying iterator is not
+// read once the values sort after the prefix.
+func (itr *tagValuePrefixIterator) Next() TagValueElem {
+	if itr.done {
+		return nil
+	}
+
+	for e := itr.itr.Next(); e != nil; e = itr.itr.Next() {
+		if bytes.HasPrefix(e.Value(), itr.prefix) {
+			return e
+		} else if bytes.Compare(e.Value(), itr.prefix) > 0 {
+			break
+		}
+	}
+	itr.done = true
+	return nil
+}
+
 // SeriesElem represents a generic series element.
 type SeriesElem interface {
 	Name() []byte
@@ -403,12 +434,37 @@ func MergeSeriesIterators(itrs ...SeriesIterator) SeriesIterator {
 		return itrs[0]
 	}
 
+	// Union the series ids of iterators over the same series block.
+	if itr := mergeSeriesIDSetIterators(itrs); itr != nil {
+		return itr
+	}
+
 	return &seriesMergeIterator{
 		buf:  make([]SeriesElem, len(itrs)),
 		itrs: itrs,
 	}
 }
 
+// mergeSeriesIDSetIterators returns an iterator over the union of the series ids
+// of itrs if they all iterate over series ids of the same series block.
+// Otherwise it returns nil and the iterators are not read.
+func mergeSeriesIDSetIterators(itrs []SeriesIterator) SeriesIterator {
+	var sblk *SeriesBlock
+	for _, itr := range itrs {
+		itr, ok := itr.(*seriesDecodeIterator)
+		if !ok || (sblk != nil && itr.sblk != sblk) {
+			return nil
+		}
+		sblk = itr.sblk
+	}
+
+	ids := roaring.NewBitmap()
+	for _, itr := range itrs {
+		ids = ids.Or(newSeriesIDSet(itr.(*seriesDecodeIterator).itr))
+	}
+	return newSeriesDecodeIterator(sblk, newBitmapSeriesIDIterator(ids))
+}
+
 // seriesMergeIterator is an iterator that merges multiple iterators together.
 type seriesMergeIterator struct {
 	buf  []SeriesElem
@@ -476,6 +532,11 @@ func IntersectSeriesIterators(itr0, itr1 SeriesIterator) SeriesIterator {
 		return nil
 	}
 
+	// Intersect the series ids of iterators over the same series block.
+	if sblk, ids0, ids1 := seriesIDSets(itr0, itr1); sblk != nil {
+		return newSeriesDecodeIterator(sblk, newBitmapSeriesIDIterator(ids0.And(ids1)))
+	}
+
 	return &seriesIntersectIterator{itrs: [2]SeriesIterator{itr0, itr1}}
 }
 
@@ -545,6 +606,11 @@ func UnionSeriesIterators(itr0, itr1 SeriesIterator) SeriesIterator {
 		return itr0
 	}
 
+	// Union the series ids of iterators over the same series block.
+	if sblk, ids0, ids1 := seriesIDSets(itr0, itr1); sblk != nil {
+		return newSeriesDecodeIterator(sblk, newBitmapSeriesIDIterator(ids0.Or(ids1)))
+	}
+
 	return &seriesUnionIterator{itrs: [2]SeriesIterator{itr0, itr1}}
 }
 
@@ -611,6 +677,11 @@ func DifferenceSeriesIterators(itr0, itr1 SeriesIterator) SeriesIterator {
 	} else if itr0 == nil {
 		return nil
 	}
+
+	// Subtract the series ids of iterators over the same series block.
+	if sblk, ids0, ids1 := seriesIDSets(itr0, itr1); sblk != nil {
+		return newSeriesDecodeIterator(sblk, newBitmapSeriesIDIterator(ids0.AndNot(ids1)))
+	}
 	return &seriesDifferenceIterator{itrs: [2]SeriesIterator{itr0, itr1}}
 }
 
@@ -723,6 +794,57 @@ type seriesIDIterator interface {
 	next() uint64
 }
 
+// bitmapSeriesIDIterator iterates over the series ids of a bitmap.
+type bitmapSeriesIDIterator struct {
+	ids *roaring.Bitmap
+	itr *roaring.Iterator
+}
+
+// newBitmapSeriesIDIterator returns a new iterator over ids.
+func newBitmapSeriesIDIterator(ids *roaring.Bitmap) *bitmapSeriesIDIterator {
+	return &bitmapSeriesIDIterator{ids: ids}
+}
+
+// next returns the next series id.  Returns zero when the iterator is complete.
+func (itr *bitmapSeriesIDIterator) next() uint64 {
+	if itr.itr == nil {
+		itr.itr = itr.ids.Iterator()
+	}
+	id, ok := itr.itr.Next()
+	if !ok {
+		return 0
+	}
+	return id
+}
+
+// newSeriesIDSet returns a bitmap of the remaining series ids of itr.
+func newSeriesIDSet(itr seriesIDIterator) *roaring.Bitmap {
+	if itr, ok := itr.(*bitmapSeriesIDIterator); ok && itr.itr == nil {
+		return itr.ids
+	}
+
+	ids := roaring.NewBitmap()
+	for id := itr.next(); id != 0; id = itr.next() {
+		ids.Add(id)
+	}
+	return ids
+}
+
+// seriesIDSets returns the series block and the series id sets of two iterators
+// if both iterate over series ids of the same series block.  Otherwise it returns
+// a nil series block and the iterators are not read.
+func seriesIDSets(itr0, itr1 SeriesIterator) (*SeriesBlock, *roaring.Bitmap, *roaring.Bitmap) {
+	a, ok := itr0.(*seriesDecodeIterator)
+	if !ok {
+		return nil, nil, nil
+	}
+	b, ok := itr1.(*seriesDecodeIterator)
+	if !ok || a.sblk != b.sblk {
+		return nil, nil, nil
+	}
+	return a.sblk, newSeriesIDSet(a.itr), newSeriesIDSet(b.itr)
+}
+
 // writeTo writes write v into w. Updates n.
 func writeTo(w io.Writer, v []byte, n *int64) error {
 	nn, err := w.Write(v)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/influxdata/influxdb/influxql"
@@ -32,6 +33,9 @@ type Measurement struct {
 
 	// lazyily created sorted series IDs
 	sortedSeriesIDs SeriesIDs // sorted list of series IDs in this measurement
+
+	// lazily created sorted tag values, cleared when a key's values change
+	sortedTagValues map[string][]string
 }
 
 // NewMeasurement allocates and initializes a new Measurement.
@@ -42,6 +46,7 @@ func NewMeasurement(name string) *Measurement {
 
 		seriesByID:          make(map[uint64]*Series),
 		seriesByTagKeyValue: make(map[string]map[string]SeriesIDs),
+		sortedTagValues:     make(map[string][]string),
 	}
 }
 
@@ -133,6 +138,8 @@ func (m *Measurement) SeriesIDs() SeriesIDs {
 
 	if cap(m.sortedSeriesIDs) < len(m.seriesByID) {
 		m.sortedSeriesIDs = make(SeriesIDs, 0, len(m.seriesByID))
+	} else {
+		m.sortedSeriesIDs = m.sortedSeriesIDs[:0]
 	}
 	for k := range m.seriesByID {
 		m.sortedSeriesIDs = append(m.sortedSeriesIDs, k)
@@ -169,6 +176,13 @@ func (m *Measurement) HasSeries() bool {
 	return len(m.seriesByID) > 0
 }
 
+// SeriesN returns the number of series in the measurement.
+func (m *Measurement) SeriesN() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.seriesByID)
+}
+
 // Cardinality returns the number of values associated with the given tag key.
 func (m *Measurement) Cardinality(key string) int {
 	var n int
@@ -222,6 +236,9 @@ func (m *Measurement) AddSeries(s *Series) bool {
 			m.seriesByTagKeyValue[string(t.Key)] = valueMap
 		}
 		ids := valueMap[string(t.Value)]
+		if ids == nil {
+			delete(m.sortedTagValues, string(t.Key))
+		}
 		ids = append(ids, s.ID)
 
 		// most of the time the series ID will be higher than all others because it's a new
@@ -257,6 +274,7 @@ func (m *Measurement) DropSeries(series *Series) {
 		// Check to see if we have any ids, if not, remove the key
 		if len(ids) == 0 {
 			delete(m.seriesByTagKeyValue[string(t.Key)], string(t.Value))
+			delete(m.sortedTagValues, string(t.Key))
 		} else {
 			m.seriesByTagKeyValue[string(t.Key)][string(t.Value)] = ids
 		}
@@ -1495,6 +1513,52 @@ func (m *Measurement) TagValues(key string) []string {
 	return values
 }
 
+// TagValuesByPrefix returns the values for the given tag key that begin with
+// prefix, in sorted order.  At most limit values are returned if limit is
+// greater than zero.
+func (m *Measurement) TagValuesByPrefix(key, prefix string, limit int) []string {
+	values := m.sortedTagValuesByKey(key)
+
+	var a []string
+	for i := sort.SearchStrings(values, prefix); i < len(values); i++ {
+		if !strings.HasPrefix(values[i], prefix) || (limit > 0 && len(a) >= limit) {
+			break
+		}
+		a = append(a, values[i])
+	}
+	return a
+}
+
+// sortedTagValuesByKey returns the sorted values for a tag key.  The values are
+// sorted on first use and cached until a value is added or removed.
+func (m *Measurement) sortedTagValuesByKey(key string) []string {
+	m.mu.RLock()
+	values, ok := m.sortedTagValues[key]
+	m.mu.RUnlock()
+	if ok {
+		return values
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if values, ok := m.sortedTagValues[key]; ok {
+		return values
+	}
+
+	valueMap := m.seriesByTagKeyValue[key]
+	if len(valueMap) == 0 {
+		return nil
+	}
+
+	values = make([]string, 0, len(valueMap))
+	for v := range valueMap {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	m.sortedTagValues[key] = values
+	return values
+}
+
 // SetFieldName adds the field name to the measurement.
 func (m *Measurement) SetFieldName(name string) {
 	m.mu.RLock()
@@ -3,6 +3,7 @@ package tsdb_test
 import (
 	"bytes"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -119,6 +120,60 @@ func TestMeasurement_AppendSeriesKeysByID_Exists(t *testing.T) {
 	}
 }
 
+// Ensure series IDs aren't duplicated after a series is dropped and another added.
+func TestMeasurement_SeriesIDs_DropAdd(t *testing.T) {
+	m := tsdb.NewMeasurement("cpu")
+	s1 := tsdb.NewSeries([]byte("cpu,host=foo"), models.Tags{models.NewTag([]byte("host"), []byte("foo"))})
+	s1.ID = 1
+	m.AddSeries(s1)
+
+	s2 := tsdb.NewSeries([]byte("cpu,host=bar"), models.Tags{models.NewTag([]byte("host"), []byte("bar"))})
+	s2.ID = 2
+	m.AddSeries(s2)
+
+	m.DropSeries(s2)
+
+	s3 := tsdb.NewSeries([]byte("cpu,host=bar"), models.Tags{models.NewTag([]byte("host"), []byte("bar"))})
+	s3.ID = 3
+	m.AddSeries(s3)
+
+	if got, exp := m.SeriesIDs(), (tsdb.SeriesIDs{1, 3}); !got.Equals(exp) {
+		t.Fatalf("series ids mismatch: got %v, exp %v", got, exp)
+	}
+}
+
+// Ensure tag values can be searched by prefix as series are added and dropped.
+func TestMeasurement_TagValuesByPrefix(t *testing.T) {
+	m := tsdb.NewMeasurement("cpu")
+	var series []*tsdb.Series
+	for i, host := range []string{"web02", "db01", "web01", "web10", "webby"} {
+		s := tsdb.NewSeries([]byte("cpu,host="+host), models.Tags{models.NewTag([]byte("host"), []byte(host))})
+		s.ID = uint64(i + 1)
+		m.AddSeries(s)
+		series = append(series, s)
+	}
+
+	if got, exp := m.TagValuesByPrefix("host", "web0", 0), []string{"web01", "web02"}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected values: %v", got)
+	} else if got, exp := m.TagValuesByPrefix("host", "web", 3), []string{"web01", "web02", "web10"}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected values: %v", got)
+	} else if got := m.TagValuesByPrefix("host", "x", 0); len(got) != 0 {
+		t.Fatalf("unexpected values: %v", got)
+	} else if got := m.TagValuesByPrefix("region", "", 0); len(got) != 0 {
+		t.Fatalf("unexpected values: %v", got)
+	}
+
+	// Values are updated after the sorted values have been cached.
+	m.DropSeries(series[0])
+	s := tsdb.NewSeries([]byte("cpu,host=web03"), models.Tags{models.NewTag([]byte("host"), []byte("web03"))})
+	s.ID = 6
+	m.AddSeries(s)
+
+	if got, exp := m.TagValuesByPrefix("host", "web0", 0), []string{"web01", "web03"}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected values after update: %v", got)
+	}
+}
+
 func TestMeasurement_TagsSet_Deadlock(t *testing.T) {
 	m := tsdb.NewMeasurement("cpu")
 	s1 := tsdb.NewSeries([]byte("cpu,host=foo"), models.Tags{models.NewTag([]byte("host"), []byte("foo"))})
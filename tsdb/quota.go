@@ -0,0 +1,215 @@
+package tsdb
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/influxdata/influxdb/models"
+)
+
+// MeasurementQuota limits the number of series of a measurement and the number
+// of values of its tag keys.  Zero means no limit.
+type MeasurementQuota struct {
+	MaxSeries    int
+	MaxTagValues map[string]int
+}
+
+// MeasurementQuotas holds the quotas of the measurements of a database.  It is
+// shared by the indexes of all shards in the database and is safe for concurrent
+// use.  A nil MeasurementQuotas has no quotas.
+type MeasurementQuotas struct {
+	mu     sync.RWMutex
+	quotas map[string]MeasurementQuota
+}
+
+// NewMeasurementQuotas returns a new instance of MeasurementQuotas.
+func NewMeasurementQuotas() *MeasurementQuotas {
+	return &MeasurementQuotas{quotas: make(map[string]MeasurementQuota)}
+}
+
+// Set replaces the quotas, keyed by measurement name.
+func (q *MeasurementQuotas) Set(quotas map[string]MeasurementQuota) {
+	m := make(map[string]MeasurementQuota, len(quotas))
+	for name, quota := range quotas {
+		m[name] = quota
+	}
+
+	q.mu.Lock()
+	q.quotas = m
+	q.mu.Unlock()
+}
+
+// Quota returns the quota of a measurement.
+func (q *MeasurementQuotas) Quota(name []byte) (MeasurementQuota, bool) {
+	if q == nil {
+		return MeasurementQuota{}, false
+	}
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	quota, ok := q.quotas[string(name)]
+	return quota, ok
+}
+
+// Enabled returns true if any measurement has a quota.
+func (q *MeasurementQuotas) Enabled() bool {
+	if q == nil {
+		return false
+	}
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return len(q.quotas) > 0
+}
+
+// MeasurementQuotaChecker checks a batch of new series against the measurement
+// quotas.  The series and tag values accepted earlier in the batch count towards
+// the quotas.  The functions read the current state of the index.
+type MeasurementQuotaChecker struct {
+	quotas *MeasurementQuotas
+
+	seriesNFn     func(name []byte) int
+	hasTagValueFn func(name, key, value []byte) bool
+	tagValueNFn   func(name, key []byte) int
+
+	accepted  map[string]struct{} // accepted series keys
+	seriesN   map[string]int      // series per measurement
+	values    map[string]struct{} // accepted tag values
+	tagValueN map[string]int      // values per measurement and tag key
+
+	dropped map[string]*quotaDrop
+}
+
+// quotaDrop counts the series of a measurement dropped by its quota.
+type quotaDrop struct {
+	n     int
+	limit string // first limit exceeded
+}
+
+// NewMeasurementQuotaChecker returns a checker for quotas that reads the number of
+// series of a measurement, whether a tag value exists and the number of values of a
+// tag key from the index.
+func NewMeasurementQuotaChecker(
+	quotas *MeasurementQuotas,
+	seriesN func(name []byte) int,
+	hasTagValue func(name, key, value []byte) bool,
+	tagValueN func(name, key []byte) int,
+) *MeasurementQuotaChecker {
+	return &MeasurementQuotaChecker{
+		quotas:        quotas,
+		seriesNFn:     seriesN,
+		hasTagValueFn: hasTagValue,
+		tagValueNFn:   tagValueN,
+		accepted:      make(map[string]struct{}),
+		seriesN:       make(map[string]int),
+		values:        make(map[string]struct{}),
+		tagValueN:     make(map[string]int),
+	}
+}
+
+// Accept returns true if the new series fits within the quota of its measurement
+// and counts it towards the quota.  Otherwise the series is recorded as dropped.
+func (c *MeasurementQuotaChecker) Accept(key, name []byte, tags models.Tags) bool {
+	quota, ok := c.quotas.Quota(name)
+	if !ok {
+		return true
+	} else if _, ok := c.accepted[string(key)]; ok {
+		return true
+	}
+
+	if quota.MaxSeries > 0 {
+		n, ok := c.seriesN[string(name)]
+		if !ok {
+			n = c.seriesNFn(name)
+			c.seriesN[string(name)] = n
+		}
+		if n >= quota.MaxSeries {
+			c.drop(name, fmt.Sprintf("max-series=%d", quota.MaxSeries))
+			return false
+		}
+	}
+
+	// The new values of the series and their tag keys.
+	var values, tagKeys []string
+	for _, tag := range tags {
+		max := quota.MaxTagValues[string(tag.Key)]
+		if max <= 0 {
+			continue
+		}
+
+		v := string(name) + "\x00" + string(tag.Key) + "\x00" + string(tag.Value)
+		if _, ok := c.values[v]; ok || c.hasTagValueFn(name, tag.Key, tag.Value) {
+			continue
+		}
+
+		k := string(name) + "\x00" + string(tag.Key)
+		n, ok := c.tagValueN[k]
+		if !ok {
+			n = c.tagValueNFn(name, tag.Key)
+			c.tagValueN[k] = n
+		}
+		if n >= max {
+			c.drop(name, fmt.Sprintf("max-values=%d tag=%q", max, string(tag.Key)))
+			return false
+		}
+		values, tagKeys = append(values, v), append(tagKeys, k)
+	}
+
+	c.accepted[string(key)] = struct{}{}
+	if quota.MaxSeries > 0 {
+		c.seriesN[string(name)]++
+	}
+	for i := range values {
+		c.values[values[i]] = struct{}{}
+		c.tagValueN[tagKeys[i]]++
+	}
+	return true
+}
+
+func (c *MeasurementQuotaChecker) drop(name []byte, limit string) {
+	if c.dropped == nil {
+		c.dropped = make(map[string]*quotaDrop)
+	}
+	d := c.dropped[string(name)]
+	if d == nil {
+		d = &quotaDrop{limit: limit}
+		c.dropped[string(name)] = d
+	}
+	d.n++
+}
+
+// Dropped returns the number of series dropped.
+func (c *MeasurementQuotaChecker) Dropped() int {
+	var n int
+	for _, d := range c.dropped {
+		n += d.n
+	}
+	return n
+}
+
+// Reason returns the reason for a partial write listing the series dropped for
+// each measurement, or an empty string if no series were dropped.
+func (c *MeasurementQuotaChecker) Reason() string {
+	if len(c.dropped) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(c.dropped))
+	for name := range c.dropped {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	buf.WriteString("measurement quota exceeded:")
+	for i, name := range names {
+		if i > 0 {
+			buf.WriteString(";")
+		}
+		d := c.dropped[name]
+		fmt.Fprintf(&buf, " measurement=%q %s dropped=%d", name, d.limit, d.n)
+	}
+	return buf.String()
+}
@@ -0,0 +1,404 @@
+package tsdb
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/estimator"
+)
+
+// seriesSketchMargin is the fraction of max-series-per-database below the limit at
+// which the estimate from the series sketches is no longer trusted and the series
+// are counted exactly.
+const seriesSketchMargin = 0.02
+
+// SeriesLimiterIndex is an index holding the series of a single shard, whose series
+// count towards the limits of the shard's database.
+type SeriesLimiterIndex interface {
+	HasSeries(name []byte, tags models.Tags) bool
+	HasTagValue(name, key, value []byte) bool
+
+	// SeriesSketches returns the sketches of the series and deleted series.
+	SeriesSketches() (estimator.Sketch, estimator.Sketch, error)
+
+	// Iterate over the series that are not deleted, of every measurement or of one,
+	// and over the values of a tag key that are not deleted.
+	ForEachSeries(fn func(name []byte, tags models.Tags) error) error
+	ForEachMeasurementSeries(name []byte, fn func(tags models.Tags) error) error
+	ForEachTagValue(name, key []byte, fn func(value []byte) error) error
+}
+
+// SeriesLimiter enforces the max-series-per-database and max-values-per-tag limits and
+// the measurement quotas of a database across the indexes of its shards, when each
+// index only holds the series of its shard.  It is shared by the indexes of the
+// database and is safe for concurrent use.
+//
+// The number of series of the database, of the series of a measurement and of the
+// values of a tag key are counted across the indexes when first needed and kept up to
+// date as series are created, so that the indexes are only read again after series
+// are dropped or an index is added or removed.  Series are only created by one index
+// of the database at a time.
+type SeriesLimiter struct {
+	mu      sync.Mutex
+	indexes map[uint64]SeriesLimiterIndex
+
+	maxSeriesN      int
+	maxValuesPerTag int
+	quotas          *MeasurementQuotas
+
+	// The number of series of the database, or -1 if not known.  The number is an
+	// estimate from the series sketches unless seriesNExact is set.
+	seriesN      int
+	seriesNExact bool
+
+	measurementSeriesN map[string]int // series by measurement
+	tagValueN          map[string]int // values by measurement and tag key
+}
+
+// NewSeriesLimiter returns a new instance of SeriesLimiter enforcing the limits of
+// config and quotas.
+func NewSeriesLimiter(config Config, quotas *MeasurementQuotas) *SeriesLimiter {
+	l := &SeriesLimiter{
+		indexes:         make(map[uint64]SeriesLimiterIndex),
+		maxSeriesN:      config.MaxSeriesPerDatabase,
+		maxValuesPerTag: config.MaxValuesPerTag,
+		quotas:          quotas,
+	}
+	l.reset()
+	return l
+}
+
+// AddIndex adds the index of the shard with id to the indexes of the database.
+func (l *SeriesLimiter) AddIndex(id uint64, idx SeriesLimiterIndex) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.indexes[id] = idx
+	l.reset()
+}
+
+// RemoveIndex removes idx, the index of the shard with id, from the indexes of the
+// database.
+func (l *SeriesLimiter) RemoveIndex(id uint64, idx SeriesLimiterIndex) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.indexes[id] == idx {
+		delete(l.indexes, id)
+		l.reset()
+	}
+}
+
+// Reset discards the counts after series are dropped from an index.
+func (l *SeriesLimiter) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.reset()
+}
+
+func (l *SeriesLimiter) reset() {
+	l.seriesN, l.seriesNExact = -1, false
+	l.measurementSeriesN = make(map[string]int)
+	l.tagValueN = make(map[string]int)
+}
+
+// CreateSeriesList calls create with the series that fit within the limits and quotas
+// of the database.  Series that exist in any index of the database always fit.  It
+// returns a PartialWriteError if any series are dropped.
+func (l *SeriesLimiter) CreateSeriesList(keys, names [][]byte, tagsSlice []models.Tags, create func(names [][]byte, tagsSlice []models.Tags) error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	indexes := l.sortedIndexes()
+
+	var reason string
+	var dropped int
+	var droppedKeys map[string]struct{}
+	drop := func(key []byte, r string) {
+		dropped++
+		reason = r
+		if droppedKeys == nil {
+			droppedKeys = make(map[string]struct{})
+		}
+		droppedKeys[string(key)] = struct{}{}
+	}
+
+	// Split the series that are new to the database from the series of other shards.
+	var newKeys, newNames [][]byte
+	var newTagsSlice []models.Tags
+	var existingNames [][]byte
+	var existingTagsSlice []models.Tags
+	for j := range names {
+		if hasSeries(indexes, names[j], tagsSlice[j]) {
+			existingNames = append(existingNames, names[j])
+			existingTagsSlice = append(existingTagsSlice, tagsSlice[j])
+			continue
+		}
+		newKeys = append(newKeys, keys[j])
+		newNames = append(newNames, names[j])
+		newTagsSlice = append(newTagsSlice, tagsSlice[j])
+	}
+
+	// Ensure that no tags go over the maximum cardinality.  Values added earlier in
+	// the batch count towards the limit.
+	if l.maxValuesPerTag > 0 && len(newNames) > 0 {
+		tagValueN := make(map[string]int)
+		added := make(map[string]struct{})
+
+		var n int
+	outer:
+		for j, name := range newNames {
+			// The new values of the series and their tag keys.
+			var values, tagKeys []string
+			for _, tag := range newTagsSlice[j] {
+				v := string(name) + "\x00" + string(tag.Key) + "\x00" + string(tag.Value)
+				if _, ok := added[v]; ok || hasTagValue(indexes, name, tag.Key, tag.Value) {
+					continue
+				}
+
+				k := string(name) + "\x00" + string(tag.Key)
+				valueN, ok := tagValueN[k]
+				if !ok {
+					valueN = l.tagValues(indexes, name, tag.Key)
+					tagValueN[k] = valueN
+				}
+				if valueN >= l.maxValuesPerTag {
+					drop(newKeys[j], fmt.Sprintf("max-values-per-tag limit exceeded (%d/%d): measurement=%q tag=%q value=%q",
+						valueN, l.maxValuesPerTag, name, string(tag.Key), string(tag.Value)))
+					continue outer
+				}
+				values, tagKeys = append(values, v), append(tagKeys, k)
+			}
+
+			for k := range values {
+				added[values[k]] = struct{}{}
+				tagValueN[tagKeys[k]]++
+			}
+
+			newKeys[n], newNames[n], newTagsSlice[n] = newKeys[j], newNames[j], newTagsSlice[j]
+			n++
+		}
+		newKeys, newNames, newTagsSlice = newKeys[:n], newNames[:n], newTagsSlice[:n]
+	}
+
+	// Ensure that no measurement goes over its quota.
+	if l.quotas.Enabled() && len(newNames) > 0 {
+		c := NewMeasurementQuotaChecker(l.quotas,
+			func(name []byte) int { return l.measurementSeries(indexes, name) },
+			func(name, key, value []byte) bool { return hasTagValue(indexes, name, key, value) },
+			func(name, key []byte) int { return l.tagValues(indexes, name, key) },
+		)
+
+		var n int
+		for j := range newNames {
+			if !c.Accept(newKeys[j], newNames[j], newTagsSlice[j]) {
+				drop(newKeys[j], "")
+				continue
+			}
+			newKeys[n], newNames[n], newTagsSlice[n] = newKeys[j], newNames[j], newTagsSlice[j]
+			n++
+		}
+		newKeys, newNames, newTagsSlice = newKeys[:n], newNames[:n], newTagsSlice[:n]
+
+		if c.Dropped() > 0 {
+			reason = c.Reason()
+		}
+	}
+
+	// Only create as many series as remain below the series limit.
+	if l.maxSeriesN > 0 && len(newNames) > 0 {
+		if err := l.countSeries(indexes, len(newNames)); err != nil {
+			return err
+		}
+
+		remaining := l.maxSeriesN - l.seriesN
+		if remaining < 0 {
+			remaining = 0
+		}
+		if remaining < len(newNames) {
+			for _, key := range newKeys[remaining:] {
+				drop(key, fmt.Sprintf("max-series-per-database limit exceeded: (%d)", l.maxSeriesN))
+			}
+			newKeys, newNames, newTagsSlice = newKeys[:remaining], newNames[:remaining], newTagsSlice[:remaining]
+		}
+	}
+
+	// The values of the new series that are new to the database.
+	type tagKeyValue struct{ k, v string }
+	var newValues []tagKeyValue
+	added := make(map[tagKeyValue]struct{})
+	for j, name := range newNames {
+		for _, tag := range newTagsSlice[j] {
+			kv := tagKeyValue{k: string(name) + "\x00" + string(tag.Key), v: string(tag.Value)}
+			if _, ok := added[kv]; ok || hasTagValue(indexes, name, tag.Key, tag.Value) {
+				continue
+			}
+			added[kv] = struct{}{}
+			newValues = append(newValues, kv)
+		}
+	}
+
+	if len(existingNames)+len(newNames) > 0 {
+		if err := create(append(existingNames, newNames...), append(existingTagsSlice, newTagsSlice...)); err != nil {
+			// The series may have been partially created.
+			l.reset()
+			return err
+		}
+	}
+
+	// Count the series and values added to the database.
+	if l.seriesN >= 0 {
+		l.seriesN += len(newNames)
+	}
+	for _, name := range newNames {
+		if n, ok := l.measurementSeriesN[string(name)]; ok {
+			l.measurementSeriesN[string(name)] = n + 1
+		}
+	}
+	for _, kv := range newValues {
+		if n, ok := l.tagValueN[kv.k]; ok {
+			l.tagValueN[kv.k] = n + 1
+		}
+	}
+
+	// Report partial writes back to shard.
+	if dropped > 0 {
+		return &PartialWriteError{
+			Reason:      reason,
+			Dropped:     dropped,
+			DroppedKeys: droppedKeys,
+		}
+	}
+	return nil
+}
+
+// sortedIndexes returns the indexes of the database, newest shard first, as series
+// are most likely to exist in recent shards.
+func (l *SeriesLimiter) sortedIndexes() []SeriesLimiterIndex {
+	ids := make([]uint64, 0, len(l.indexes))
+	for id := range l.indexes {
+		ids = append(ids, id)
+	}
+	sort.Sort(sort.Reverse(uint64Slice(ids)))
+
+	indexes := make([]SeriesLimiterIndex, len(ids))
+	for i, id := range ids {
+		indexes[i] = l.indexes[id]
+	}
+	return indexes
+}
+
+// countSeries sets the number of series of the database for checking whether n new
+// series exceed the limit.  The series sketches are used while the estimate is well
+// below the limit.  Otherwise the series are counted exactly.
+func (l *SeriesLimiter) countSeries(indexes []SeriesLimiterIndex, n int) error {
+	if l.seriesNExact {
+		return nil
+	}
+
+	if l.seriesN < 0 {
+		var sketch, tsketch estimator.Sketch
+		for _, idx := range indexes {
+			s, t, err := idx.SeriesSketches()
+			if err != nil {
+				return err
+			} else if sketch == nil {
+				sketch, tsketch = s, t
+			} else if err := sketch.Merge(s); err != nil {
+				return err
+			} else if err := tsketch.Merge(t); err != nil {
+				return err
+			}
+		}
+
+		l.seriesN = 0
+		if sketch != nil {
+			if l.seriesN = int(sketch.Count()) - int(tsketch.Count()); l.seriesN < 0 {
+				l.seriesN = 0
+			}
+		}
+	}
+	if float64(l.seriesN+n) <= float64(l.maxSeriesN)*(1-seriesSketchMargin) {
+		return nil
+	}
+
+	// Series of more than one shard are counted in the newest one.
+	seriesN := 0
+	for i, idx := range indexes {
+		if err := idx.ForEachSeries(func(name []byte, tags models.Tags) error {
+			if !hasSeries(indexes[:i], name, tags) {
+				seriesN++
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	l.seriesN, l.seriesNExact = seriesN, true
+	return nil
+}
+
+// measurementSeries returns the number of series of the measurement name.
+func (l *SeriesLimiter) measurementSeries(indexes []SeriesLimiterIndex, name []byte) int {
+	if n, ok := l.measurementSeriesN[string(name)]; ok {
+		return n
+	}
+
+	var n int
+	for i, idx := range indexes {
+		idx.ForEachMeasurementSeries(name, func(tags models.Tags) error {
+			if !hasSeries(indexes[:i], name, tags) {
+				n++
+			}
+			return nil
+		})
+	}
+	l.measurementSeriesN[string(name)] = n
+	return n
+}
+
+// tagValues returns the number of values of the tag key of the measurement name.
+func (l *SeriesLimiter) tagValues(indexes []SeriesLimiterIndex, name, key []byte) int {
+	k := string(name) + "\x00" + string(key)
+	if n, ok := l.tagValueN[k]; ok {
+		return n
+	}
+
+	n := countTagValues(indexes, name, key)
+	l.tagValueN[k] = n
+	return n
+}
+
+// countTagValues counts the values of a tag key across indexes.
+func countTagValues(indexes []SeriesLimiterIndex, name, key []byte) int {
+	var n int
+	for i, idx := range indexes {
+		idx.ForEachTagValue(name, key, func(value []byte) error {
+			if !hasTagValue(indexes[:i], name, key, value) {
+				n++
+			}
+			return nil
+		})
+	}
+	return n
+}
+
+// hasSeries returns true if any of indexes holds the series.
+func hasSeries(indexes []SeriesLimiterIndex, name []byte, tags models.Tags) bool {
+	for _, idx := range indexes {
+		if idx.HasSeries(name, tags) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTagValue returns true if any of indexes holds the tag value.
+func hasTagValue(indexes []SeriesLimiterIndex, name, key, value []byte) bool {
+	for _, idx := range indexes {
+		if idx.HasTagValue(name, key, value) {
+			return true
+		}
+	}
+	return false
+}
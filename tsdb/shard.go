@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math"
+	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -17,7 +19,9 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/influxdata/influxdb/influxql"
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/bytesutil"
 	"github.com/influxdata/influxdb/pkg/estimator"
+	"github.com/influxdata/influxdb/pkg/limiter"
 	internal "github.com/influxdata/influxdb/tsdb/internal"
 	"github.com/uber-go/zap"
 )
@@ -37,6 +41,7 @@ const (
 	statWritePointsOK      = "writePointsOk"
 	statWriteBytes         = "writeBytes"
 	statDiskBytes          = "diskBytes"
+	statCorrupt            = "corrupt"
 )
 
 var (
@@ -60,8 +65,28 @@ var (
 	// ErrShardDisabled is returned when a the shard is not available for
 	// queries or writes.
 	ErrShardDisabled = errors.New("shard is disabled")
+
+	// ErrShardCorrupt is returned when writing to a shard that was made read-only
+	// because its data files are corrupt.
+	ErrShardCorrupt = errors.New("shard is corrupt")
+
+	// ErrShardReadOnly is returned when writing to a shard whose data is being copied
+	// into other shards.
+	ErrShardReadOnly = errors.New("shard is read-only")
+
+	// ErrCacheFull is returned when a write times out waiting for room in the cache
+	// of a shard.  The write can be retried once the cache has been written to disk.
+	ErrCacheFull = errors.New("cache is full")
 )
 
+// CorruptFileName is the name of the file recording the corruption found in a shard
+// by a scrub.  The shard stays read-only across restarts until the file is removed.
+const CorruptFileName = "corrupt"
+
+// DownsampleFileName is the name of the file recording the resolution a shard has
+// been downsampled to and the functions used to aggregate its fields.
+const DownsampleFileName = "downsample"
+
 var (
 	// Static objects to prevent small allocs.
 	timeBytes = []byte("time")
@@ -123,6 +148,18 @@ type Shard struct {
 	closing chan struct{}
 	enabled bool
 
+	// corrupt is the corruption found by the last scrub of the shard, if any.
+	// Corrupt shards are read-only.
+	corrupt error
+
+	// readOnly is set while the shard's data is copied into other shards.
+	readOnly bool
+
+	// The resolution the shard has been downsampled to and the aggregate functions
+	// used, if the shard has been downsampled.
+	resolution    time.Duration
+	downsampleFns []string
+
 	// expvar-based stats.
 	stats       *ShardStatistics
 	defaultTags models.StatisticTags
@@ -181,8 +218,9 @@ func (s *Shard) SetEnabled(enabled bool) {
 	// Prevent writes and queries
 	s.enabled = enabled
 	if s.engine != nil {
-		// Disable background compactions and snapshotting
-		s.engine.SetEnabled(enabled)
+		// Disable background compactions and snapshotting.  Corrupt shards are
+		// never compacted.
+		s.engine.SetEnabled(enabled && s.corrupt == nil)
 	}
 	s.mu.Unlock()
 }
@@ -210,6 +248,11 @@ func (s *Shard) Statistics(tags map[string]string) []models.Statistic {
 	_, _ = s.DiskSize()
 	seriesN := s.engine.SeriesN()
 
+	var corrupt int64
+	if s.Corrupt() != nil {
+		corrupt = 1
+	}
+
 	tags = s.defaultTags.Merge(tags)
 	statistics := []models.Statistic{{
 		Name: "shard",
@@ -225,6 +268,7 @@ func (s *Shard) Statistics(tags map[string]string) []models.Statistic {
 			statWritePointsOK:      atomic.LoadInt64(&s.stats.WritePointsOK),
 			statWriteBytes:         atomic.LoadInt64(&s.stats.BytesWritten),
 			statDiskBytes:          atomic.LoadInt64(&s.stats.DiskBytes),
+			statCorrupt:            corrupt,
 		},
 	}}
 
@@ -281,6 +325,22 @@ func (s *Shard) Open() error {
 		if err := e.LoadMetadataIndex(s.id, s.index); err != nil {
 			return err
 		}
+
+		// Restore the corruption found by a previous scrub.
+		if buf, err := ioutil.ReadFile(filepath.Join(s.path, CorruptFileName)); err == nil {
+			s.corrupt = errors.New(string(buf))
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+
+		// Restore the resolution the shard was downsampled to.
+		if buf, err := ioutil.ReadFile(filepath.Join(s.path, DownsampleFileName)); err == nil {
+			if s.resolution, s.downsampleFns, err = parseDownsampleFile(buf); err != nil {
+				return err
+			}
+		} else if !os.IsNotExist(err) {
+			return err
+		}
 		s.engine = e
 
 		return nil
@@ -371,6 +431,223 @@ func (s *Shard) ready() error {
 	return err
 }
 
+// writable determines if the Shard is ready for writes.  It returns the error
+// returned by ready or ErrShardCorrupt if the shard is read-only.
+func (s *Shard) writable() error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.corrupt != nil {
+		return ErrShardCorrupt
+	} else if s.readOnly {
+		return ErrShardReadOnly
+	}
+	return nil
+}
+
+// lockWrites blocks writes and deletes to the shard until the returned function is
+// called.  It returns the error returned by writable instead if the shard can't be
+// written.
+func (s *Shard) lockWrites() (func(), error) {
+	s.mu.Lock()
+
+	var err error
+	if s.engine == nil {
+		err = ErrEngineClosed
+	} else if !s.enabled {
+		err = ErrShardDisabled
+	} else if s.corrupt != nil {
+		err = ErrShardCorrupt
+	} else if s.readOnly {
+		err = ErrShardReadOnly
+	}
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	return s.mu.Unlock, nil
+}
+
+// SetReadOnly sets whether the shard rejects writes and deletes, such as while its
+// data is copied into other shards.  Writes in progress complete before SetReadOnly
+// returns.  It returns ErrShardReadOnly if the shard is already read-only.
+func (s *Shard) SetReadOnly(readOnly bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if readOnly && s.readOnly {
+		return ErrShardReadOnly
+	}
+	s.readOnly = readOnly
+	return nil
+}
+
+// Corrupt returns the corruption found by the last scrub of the shard, or nil
+// if no corruption was found.
+func (s *Shard) Corrupt() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.corrupt
+}
+
+// Scrub verifies the integrity of the shard's data files, reading at no more than the
+// rate allowed by rate.  If corruption is found, the shard is made read-only and its
+// compactions are disabled so that the corrupt files are left untouched.  The corruption
+// is recorded in the shard's CorruptFileName file so the shard remains read-only when
+// it is reopened.  Scrub returns the corruption found, or nil if none was found before
+// closing was closed.
+func (s *Shard) Scrub(rate *limiter.Rate, closing <-chan struct{}) error {
+	// The lock isn't held while scrubbing so writes and queries are not blocked.
+	s.mu.RLock()
+	engine := s.engine
+	s.mu.RUnlock()
+	if engine == nil {
+		return nil
+	}
+
+	err := engine.Scrub(rate, closing)
+	if err == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.engine != engine {
+		// The shard was closed while it was scrubbed.
+		return nil
+	}
+
+	s.corrupt = err
+	s.engine.SetCompactionsEnabled(false)
+	s.logger.Error(fmt.Sprintf("shard %d is corrupt, disabling writes and compactions", s.id), zap.Error(err))
+
+	if err := ioutil.WriteFile(filepath.Join(s.path, CorruptFileName), []byte(err.Error()), 0666); err != nil {
+		s.logger.Error(fmt.Sprintf("failed to record corruption of shard %d", s.id), zap.Error(err))
+	}
+	return err
+}
+
+// Repair rewrites the data files of a corrupt shard without the blocks that fail
+// verification.  If the data files are valid afterwards, the shard is made writable
+// again and its CorruptFileName file is removed.
+func (s *Shard) Repair(closing <-chan struct{}) error {
+	s.mu.RLock()
+	engine, corrupt := s.engine, s.corrupt
+	s.mu.RUnlock()
+	if engine == nil || corrupt == nil {
+		return nil
+	}
+
+	// Compactions of corrupt shards are disabled so the files can be rewritten safely.
+	if err := engine.Repair(closing); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.engine != engine {
+		// The shard was closed while it was repaired.
+		return nil
+	}
+
+	if err := os.Remove(filepath.Join(s.path, CorruptFileName)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	s.corrupt = nil
+	s.engine.SetCompactionsEnabled(s.enabled)
+	s.logger.Info(fmt.Sprintf("shard %d repaired, enabling writes and compactions", s.id))
+	return nil
+}
+
+// Downsample rewrites the shard's data at a resolution of interval, aggregating the
+// values of each numeric field with the functions in fns.  The resolution is recorded
+// in the shard's DownsampleFileName file once the data is rewritten.  The shard is
+// read-only while it is downsampled so that no values are left at a finer resolution.
+// Downsampling a shard again at the same resolution does nothing.
+func (s *Shard) Downsample(interval time.Duration, fns []string) error {
+	if err := s.writable(); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	resolution := s.resolution
+	s.mu.RUnlock()
+	if resolution == interval {
+		return nil
+	} else if resolution != 0 {
+		return fmt.Errorf("shard already downsampled to %v", resolution)
+	}
+
+	if err := s.SetReadOnly(true); err != nil {
+		return err
+	}
+	defer s.SetReadOnly(false)
+
+	// Rewriting data that is already downsampled leaves it unchanged, so the data
+	// is safe to downsample again if the resolution fails to be recorded.
+	if err := s.engine.Downsample(interval, fns); err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.path, DownsampleFileName)
+	buf := []byte(fmt.Sprintf("%s %s", interval, strings.Join(fns, ",")))
+	if err := ioutil.WriteFile(path+".tmp", buf, 0666); err != nil {
+		return err
+	} else if err := os.Rename(path+".tmp", path); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.resolution, s.downsampleFns = interval, fns
+	s.mu.Unlock()
+	return nil
+}
+
+// Resolution returns the resolution the shard has been downsampled to, or zero if
+// the shard has not been downsampled.
+func (s *Shard) Resolution() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.resolution
+}
+
+// parseDownsampleFile returns the resolution and functions recorded in the contents
+// of a DownsampleFileName file.
+func parseDownsampleFile(buf []byte) (time.Duration, []string, error) {
+	a := strings.Fields(string(buf))
+	if len(a) != 2 {
+		return 0, nil, fmt.Errorf("invalid %s file: %q", DownsampleFileName, buf)
+	}
+
+	resolution, err := time.ParseDuration(a[0])
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid %s file: %s", DownsampleFileName, err)
+	}
+	return resolution, strings.Split(a[1], ","), nil
+}
+
+// Export writes a tar archive of the shard's values between start and end, inclusive,
+// to w.  The basePath will be prepended to the names of the files in the archive.
+func (s *Shard) Export(w io.Writer, basePath string, start, end time.Time) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+	return s.engine.Export(w, basePath, start, end)
+}
+
+// Trim removes the shard's values outside of start and end, inclusive.  Read-only
+// shards can be trimmed once their data has been copied.
+func (s *Shard) Trim(start, end time.Time) error {
+	if err := s.ready(); err != nil {
+		return err
+	} else if err := s.Corrupt(); err != nil {
+		return ErrShardCorrupt
+	}
+	return s.engine.Trim(start, end)
+}
+
 // LastModified returns the time when this shard was last modified.
 func (s *Shard) LastModified() time.Time {
 	if err := s.ready(); err != nil {
@@ -394,11 +671,12 @@ func (s *Shard) IsIdle() bool {
 }
 
 // SetCompactionsEnabled enables or disable shard background compactions.
+// Compactions of corrupt shards can't be enabled.
 func (s *Shard) SetCompactionsEnabled(enabled bool) {
 	if err := s.ready(); err != nil {
 		return
 	}
-	s.engine.SetCompactionsEnabled(enabled)
+	s.engine.SetCompactionsEnabled(enabled && s.Corrupt() == nil)
 }
 
 // DiskSize returns the size on disk of this shard
@@ -422,7 +700,7 @@ type SeriesCreate struct {
 
 // WritePoints will write the raw data points and any new metadata to the index in the shard.
 func (s *Shard) WritePoints(points []models.Point) error {
-	if err := s.ready(); err != nil {
+	if err := s.writable(); err != nil {
 		return err
 	}
 
@@ -431,6 +709,11 @@ func (s *Shard) WritePoints(points []models.Point) error {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	// The shard may have been made read-only since it was checked.
+	if s.readOnly {
+		return ErrShardReadOnly
+	}
+
 	atomic.AddInt64(&s.stats.WriteReq, 1)
 
 	points, fieldsToCreate, err := s.validateSeriesAndFields(points)
@@ -451,8 +734,23 @@ func (s *Shard) WritePoints(points []models.Point) error {
 
 	// Write to the engine.
 	if err := s.engine.WritePoints(points); err != nil {
+		// Points rejected by the duplicate policy were dropped but the others written.
+		if perr, ok := err.(PartialWriteError); ok {
+			atomic.AddInt64(&s.stats.WritePointsDropped, int64(perr.Dropped))
+			atomic.AddInt64(&s.stats.WritePointsOK, int64(len(points)-perr.Dropped))
+			atomic.AddInt64(&s.stats.WriteReqOK, 1)
+			if werr, ok := writeError.(PartialWriteError); ok {
+				perr.Reason = werr.Reason + ", " + perr.Reason
+				perr.Dropped += werr.Dropped
+			}
+			return perr
+		}
+
 		atomic.AddInt64(&s.stats.WritePointsErr, int64(len(points)))
 		atomic.AddInt64(&s.stats.WriteReqErr, 1)
+		if err == ErrCacheFull {
+			return err
+		}
 		return fmt.Errorf("engine: %s", err)
 	}
 	atomic.AddInt64(&s.stats.WritePointsOK, int64(len(points)))
@@ -468,7 +766,7 @@ func (s *Shard) DeleteSeries(seriesKeys [][]byte) error {
 
 // DeleteSeriesRange deletes all values from for seriesKeys between min and max (inclusive)
 func (s *Shard) DeleteSeriesRange(seriesKeys [][]byte, min, max int64) error {
-	if err := s.ready(); err != nil {
+	if err := s.writable(); err != nil {
 		return err
 	}
 
@@ -479,9 +777,52 @@ func (s *Shard) DeleteSeriesRange(seriesKeys [][]byte, min, max int64) error {
 	return nil
 }
 
+// seriesLastWrite returns the last write of the series key in the shard.
+func (s *Shard) seriesLastWrite(key []byte) (SeriesLastWrite, error) {
+	t, ok, err := s.engine.SeriesLastWrite(key)
+	if err != nil {
+		return SeriesLastWrite{}, err
+	}
+	return SeriesLastWrite{Key: string(key), LastWrite: t, HasValues: ok}, nil
+}
+
+// seriesKeysBySources returns the sorted keys of the series of the sources matching
+// condition.  All measurements are used if there are no sources.
+func (s *Shard) seriesKeysBySources(sources []influxql.Source, condition influxql.Expr) ([][]byte, error) {
+	var names []string
+	if len(sources) > 0 {
+		for _, source := range sources {
+			names = append(names, source.(*influxql.Measurement).Name)
+		}
+	} else {
+		if err := s.engine.ForEachMeasurementName(func(name []byte) error {
+			names = append(names, string(name))
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(names)
+
+	// Find matching series keys for each measurement.
+	var keys [][]byte
+	for _, name := range names {
+		a, err := s.engine.MeasurementSeriesKeysByExpr([]byte(name), condition)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, a...)
+	}
+
+	if !bytesutil.IsSorted(keys) {
+		bytesutil.Sort(keys)
+	}
+	return keys, nil
+}
+
 // DeleteMeasurement deletes a measurement and all underlying series.
 func (s *Shard) DeleteMeasurement(name []byte) error {
-	if err := s.ready(); err != nil {
+	if err := s.writable(); err != nil {
 		return err
 	}
 	return s.engine.DeleteMeasurement(name)
@@ -636,6 +977,8 @@ func (s *Shard) validateSeriesAndFields(points []models.Point) ([]models.Point,
 				fieldType = influxql.Float
 			case models.Integer:
 				fieldType = influxql.Integer
+			case models.Unsigned:
+				fieldType = influxql.Unsigned
 			case models.Boolean:
 				fieldType = influxql.Boolean
 			case models.String:
@@ -688,6 +1031,24 @@ func (s *Shard) MeasurementFields(name []byte) *MeasurementFields {
 	return s.engine.MeasurementFields(name)
 }
 
+// hasMeasurementValues returns true if any series of the measurement matching expr has
+// values between min and max, inclusive.
+func (s *Shard) hasMeasurementValues(name []byte, expr influxql.Expr, min, max int64) (bool, error) {
+	var found bool
+	if err := s.engine.ForEachMeasurementSeriesByExpr(name, expr, func(tags models.Tags) error {
+		if found {
+			return nil
+		}
+
+		ok, err := s.engine.HasSeriesValues(models.MakeKey(name, tags), min, max)
+		found = ok
+		return err
+	}); err != nil {
+		return false, err
+	}
+	return found, nil
+}
+
 func (s *Shard) MeasurementExists(name []byte) (bool, error) {
 	return s.engine.MeasurementExists(name)
 }
@@ -714,9 +1075,68 @@ func (s *Shard) CreateIterator(measurement string, opt influxql.IteratorOptions)
 		}
 		// Unknown system source so pass this to the engine.
 	}
+
+	s.mu.RLock()
+	fns := s.downsampleFns
+	s.mu.RUnlock()
+	if len(fns) > 0 {
+		opt.Expr = s.downsampledExpr(measurement, opt.Expr, fns)
+	}
 	return s.engine.CreateIterator(measurement, opt)
 }
 
+// downsampledExpr returns the expression to evaluate instead of expr on a shard that
+// was downsampled with the functions in fns.  A call to an aggregate that was stored
+// when the shard was downsampled reads the stored aggregate, e.g. max(value) reads
+// max(max_value) and count(value) reads sum(count_value), since the field itself only
+// holds one value per window.
+func (s *Shard) downsampledExpr(measurement string, expr influxql.Expr, fns []string) influxql.Expr {
+	call, ok := expr.(*influxql.Call)
+	if !ok || len(call.Args) != 1 {
+		return expr
+	}
+	ref, ok := call.Args[0].(*influxql.VarRef)
+	if !ok {
+		return expr
+	}
+
+	switch call.Name {
+	case "min", "max", "sum", "count":
+	default:
+		return expr
+	}
+
+	var stored bool
+	for _, fn := range fns {
+		if fn == call.Name {
+			stored = true
+			break
+		}
+	}
+	if !stored {
+		return expr
+	}
+
+	name := call.Name + "_" + ref.Val
+	mf := s.engine.MeasurementFields([]byte(measurement))
+	if mf == nil {
+		return expr
+	}
+	f := mf.Field(name)
+	if f == nil {
+		return expr
+	}
+
+	fn := call.Name
+	if fn == "count" {
+		fn = "sum"
+	}
+	return &influxql.Call{
+		Name: fn,
+		Args: []influxql.Expr{&influxql.VarRef{Val: name, Type: f.Type}},
+	}
+}
+
 // createSystemIterator returns an iterator for a system source.
 func (s *Shard) createSystemIterator(measurement string, opt influxql.IteratorOptions) (influxql.Iterator, bool, error) {
 	switch measurement {
@@ -733,10 +1153,17 @@ func (s *Shard) createSystemIterator(measurement string, opt influxql.IteratorOp
 	return nil, false, nil
 }
 
-// createSeriesIterator returns a new instance of SeriesIterator.
+// createSeriesIterator returns a new instance of SeriesIterator.  If the time range of
+// opt is bounded, only the series with values within the range are returned.
 func (s *Shard) createSeriesIterator(opt influxql.IteratorOptions) (influxql.Iterator, error) {
+	// The time range is taken from opt, so remove time from the condition.
+	cond, _, _, err := splitTimeCondition(opt.Condition)
+	if err != nil {
+		return nil, err
+	}
+	opt.Condition = cond
+
 	// Only equality operators are allowed.
-	var err error
 	influxql.WalkFunc(opt.Condition, func(n influxql.Node) {
 		switch n := n.(type) {
 		case *influxql.BinaryExpr:
@@ -752,7 +1179,60 @@ func (s *Shard) createSeriesIterator(opt influxql.IteratorOptions) (influxql.Ite
 		return nil, err
 	}
 
-	return s.engine.SeriesPointIterator(opt)
+	itr, err := s.engine.SeriesPointIterator(opt)
+	if err != nil || itr == nil {
+		return itr, err
+	} else if opt.StartTime <= influxql.MinTime && opt.EndTime >= influxql.MaxTime {
+		return itr, nil
+	}
+
+	// Filter the series by time on the key.
+	for i, ref := range opt.Aux {
+		if ref.Val == "key" {
+			if itr, ok := itr.(influxql.FloatIterator); ok {
+				return &seriesTimeRangeIterator{
+					itr:    itr,
+					engine: s.engine,
+					i:      i,
+					min:    opt.StartTime,
+					max:    opt.EndTime,
+				}, nil
+			}
+		}
+	}
+	return itr, nil
+}
+
+// seriesTimeRangeIterator filters the points of a series iterator down to the series
+// that have values between min and max.
+type seriesTimeRangeIterator struct {
+	itr      influxql.FloatIterator
+	engine   Engine
+	i        int // index of the series key in the auxiliary fields
+	min, max int64
+}
+
+// Stats returns stats about the points processed.
+func (itr *seriesTimeRangeIterator) Stats() influxql.IteratorStats { return itr.itr.Stats() }
+
+// Close closes the iterator.
+func (itr *seriesTimeRangeIterator) Close() error { return itr.itr.Close() }
+
+// Next emits the next series with values within the time range.
+func (itr *seriesTimeRangeIterator) Next() (*influxql.FloatPoint, error) {
+	for {
+		p, err := itr.itr.Next()
+		if p == nil || err != nil {
+			return p, err
+		}
+
+		key, _ := p.Aux[itr.i].(string)
+		if ok, err := itr.engine.HasSeriesValues([]byte(key), itr.min, itr.max); err != nil {
+			return nil, err
+		} else if ok {
+			return p, nil
+		}
+	}
 }
 
 // FieldDimensions returns unique sets of fields and dimensions across a list of sources.
@@ -799,7 +1279,7 @@ func (s *Shard) FieldDimensions(measurements []string) (fields map[string]influx
 		mf := s.engine.MeasurementFields([]byte(name))
 		if mf != nil {
 			for k, typ := range mf.FieldSet() {
-				if _, ok := fields[k]; !ok || typ < fields[k] {
+				if _, ok := fields[k]; !ok || fields[k].LessThan(typ) {
 					fields[k] = typ
 				}
 			}
@@ -973,6 +1453,17 @@ func (s *Shard) TagKeyCardinality(name, key []byte) int {
 	return s.engine.TagKeyCardinality(name, key)
 }
 
+// TagValuesByPrefix returns the sorted values of a measurement's tag key that
+// begin with prefix.  At most limit values are returned if limit is greater than zero.
+func (s *Shard) TagValuesByPrefix(name, key, prefix []byte, limit int) ([][]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.engine == nil {
+		return nil, ErrEngineClosed
+	}
+	return s.engine.TagValuesByPrefix(name, key, prefix, limit)
+}
+
 type ShardGroup interface {
 	MeasurementsByRegex(re *regexp.Regexp) []string
 	FieldDimensions(measurements []string) (fields map[string]influxql.DataType, dimensions map[string]struct{}, err error)
@@ -1024,7 +1515,7 @@ func (a Shards) FieldDimensions(measurements []string) (fields map[string]influx
 			return nil, nil, err
 		}
 		for k, typ := range f {
-			if _, ok := fields[k]; typ != influxql.Unknown && (!ok || typ < fields[k]) {
+			if _, ok := fields[k]; typ != influxql.Unknown && (!ok || fields[k].LessThan(typ)) {
 				fields[k] = typ
 			}
 		}
@@ -195,6 +195,45 @@ func TestShard_MaxTagValuesLimit(t *testing.T) {
 	sh.Close()
 }
 
+func TestShard_MeasurementQuotas(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "shard_test")
+	defer os.RemoveAll(tmpDir)
+	tmpShard := path.Join(tmpDir, "db", "rp", "1")
+	tmpWal := path.Join(tmpDir, "wal")
+
+	opts := tsdb.NewEngineOptions()
+	opts.Config.WALDir = filepath.Join(tmpDir, "wal")
+	opts.InmemIndex = inmem.NewIndex()
+	opts.MeasurementQuotas = tsdb.NewMeasurementQuotas()
+	opts.MeasurementQuotas.Set(map[string]tsdb.MeasurementQuota{
+		"cpu": {MaxSeries: 2},
+		"mem": {MaxTagValues: map[string]int{"host": 1}},
+	})
+
+	sh := tsdb.NewShard(1, tmpShard, tmpWal, opts)
+	if err := sh.Open(); err != nil {
+		t.Fatalf("error opening shard: %s", err.Error())
+	}
+	defer sh.Close()
+
+	var points []models.Point
+	for _, key := range []string{"cpu,host=a", "cpu,host=b", "cpu,host=c", "mem,host=a", "mem,host=b", "disk,host=a", "disk,host=b"} {
+		name, tags, _ := models.ParseKey([]byte(key))
+		points = append(points, models.MustNewPoint(name, tags, map[string]interface{}{"value": 1.0}, time.Unix(1, 2)))
+	}
+
+	err := sh.WritePoints(points)
+	if err == nil {
+		t.Fatal("expected error")
+	} else if exp, got := `partial write: measurement quota exceeded: measurement="cpu" max-series=2 dropped=1; measurement="mem" max-values=1 tag="host" dropped=1 dropped=2`, err.Error(); exp != got {
+		t.Fatalf("unexpected error message:\n\texp = %s\n\tgot = %s", exp, got)
+	}
+
+	if n := sh.SeriesN(); n != 5 {
+		t.Fatalf("unexpected series count: %d", n)
+	}
+}
+
 func TestWriteTimeTag(t *testing.T) {
 	tmpDir, _ := ioutil.TempDir("", "shard_test")
 	defer os.RemoveAll(tmpDir)
@@ -321,6 +360,48 @@ func TestShardWriteAddNewField(t *testing.T) {
 	}
 }
 
+// Ensure an unsigned field cannot be written to a field that already exists as
+// an integer, and vice versa.
+func TestShard_WritePoints_UnsignedFieldConflict(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "shard_test")
+	defer os.RemoveAll(tmpDir)
+	tmpShard := path.Join(tmpDir, "shard")
+	tmpWal := path.Join(tmpDir, "wal")
+
+	opts := tsdb.NewEngineOptions()
+	opts.Config.WALDir = filepath.Join(tmpDir, "wal")
+	opts.InmemIndex = inmem.NewIndex()
+
+	sh := tsdb.NewShard(1, tmpShard, tmpWal, opts)
+	if err := sh.Open(); err != nil {
+		t.Fatalf("error opening shard: %s", err.Error())
+	}
+	defer sh.Close()
+
+	points, err := models.ParsePointsString("cpu,host=server value=1i,unsigned=1u 1000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sh.WritePoints(points); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	points, err = models.ParsePointsString("cpu,host=server value=2u 2000000000\ncpu,host=server unsigned=2i 2000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sh.WritePoints(points)
+	if err == nil {
+		t.Fatal("expected error")
+	} else if exp, got := `partial write: field type conflict: input field "value" on measurement "cpu" is type unsigned, already exists as type integer dropped=2`, err.Error(); exp != got {
+		t.Fatalf("unexpected error message:\n\texp = %s\n\tgot = %s", exp, got)
+	}
+
+	if typ := sh.MapType("cpu", "unsigned"); typ != influxql.Unsigned {
+		t.Fatalf("unexpected field type: got %s, exp %s", typ, influxql.DataType(influxql.Unsigned))
+	}
+}
+
 // Tests concurrently writing to the same shard with different field types which
 // can trigger a panic when the shard is snapshotted to TSM files.
 func TestShard_WritePoints_FieldConflictConcurrent(t *testing.T) {
@@ -745,6 +826,127 @@ cpu,host=serverB,region=uswest value=25  0
 	}
 }
 
+// Ensure a downsampled shard keeps its fields and answers aggregate queries from the
+// stored aggregates.
+func TestShard_Downsample(t *testing.T) {
+	sh := NewShard()
+	if err := sh.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer sh.Close()
+
+	sh.MustWritePointsString(`
+cpu value=1 0
+cpu value=3 1
+cpu value=5 10
+`)
+
+	fns := []string{"mean", "max", "count"}
+
+	// A read-only shard, such as one being split, is not downsampled.
+	if err := sh.SetReadOnly(true); err != nil {
+		t.Fatal(err)
+	} else if err := sh.Downsample(10*time.Second, fns); err != tsdb.ErrShardReadOnly {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := sh.SetReadOnly(false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sh.Downsample(10*time.Second, fns); err != nil {
+		t.Fatal(err)
+	} else if got, exp := sh.Resolution(), 10*time.Second; got != exp {
+		t.Fatalf("unexpected resolution: got %v, exp %v", got, exp)
+	}
+
+	// The shard is writable again once it is downsampled.
+	if err := sh.SetReadOnly(true); err != nil {
+		t.Fatalf("shard left read-only: %v", err)
+	} else if err := sh.SetReadOnly(false); err != nil {
+		t.Fatal(err)
+	}
+
+	// The field holds the mean of each window.
+	itr, err := sh.CreateIterator("cpu", influxql.IteratorOptions{
+		Expr:      influxql.MustParseExpr(`value`),
+		Ascending: true,
+		StartTime: influxql.MinTime,
+		EndTime:   influxql.MaxTime,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var values []float64
+	for {
+		p, err := itr.(influxql.FloatIterator).Next()
+		if err != nil {
+			t.Fatal(err)
+		} else if p == nil {
+			break
+		}
+		values = append(values, p.Value)
+	}
+	itr.Close()
+	if exp := []float64{2, 5}; !reflect.DeepEqual(values, exp) {
+		t.Fatalf("unexpected values: got %v, exp %v", values, exp)
+	}
+
+	// Aggregates are read from the stored aggregates of each window.
+	for _, tt := range []struct {
+		expr string
+		exp  interface{}
+	}{
+		{expr: `max(value)`, exp: float64(5)},
+		{expr: `count(value)`, exp: int64(3)},
+	} {
+		itr, err := sh.CreateIterator("cpu", influxql.IteratorOptions{
+			Expr:      influxql.MustParseExpr(tt.expr),
+			Ascending: true,
+			StartTime: influxql.MinTime,
+			EndTime:   influxql.MaxTime,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got interface{}
+		switch itr := itr.(type) {
+		case influxql.FloatIterator:
+			if p, err := itr.Next(); err != nil {
+				t.Fatal(err)
+			} else if p != nil {
+				got = p.Value
+			}
+		case influxql.IntegerIterator:
+			if p, err := itr.Next(); err != nil {
+				t.Fatal(err)
+			} else if p != nil {
+				got = p.Value
+			}
+		}
+		itr.Close()
+
+		if got != tt.exp {
+			t.Fatalf("%s: got %v, exp %v", tt.expr, got, tt.exp)
+		}
+	}
+
+	// Downsampling again at the same resolution does nothing.
+	if err := sh.Downsample(10*time.Second, fns); err != nil {
+		t.Fatal(err)
+	} else if err := sh.Downsample(time.Minute, fns); err == nil {
+		t.Fatal("expected error downsampling to another resolution")
+	}
+
+	// The resolution is restored when the shard is reopened.
+	if err := sh.Shard.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := sh.Open(); err != nil {
+		t.Fatal(err)
+	} else if got, exp := sh.Resolution(), 10*time.Second; got != exp {
+		t.Fatalf("unexpected resolution after reopen: got %v, exp %v", got, exp)
+	}
+}
+
 func TestShard_Disabled_WriteQuery(t *testing.T) {
 	sh := NewShard()
 	if err := sh.Open(); err != nil {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -12,10 +13,12 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/influxdata/influxdb/influxql"
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/monitor/diagnostics"
 	"github.com/influxdata/influxdb/pkg/bytesutil"
 	"github.com/influxdata/influxdb/pkg/estimator"
 	"github.com/influxdata/influxdb/pkg/limiter"
@@ -33,11 +36,21 @@ var (
 const (
 	statDatabaseSeries       = "numSeries"       // number of series in a database
 	statDatabaseMeasurements = "numMeasurements" // number of measurements in a database
+
+	statScrubs         = "scrubs"         // number of completed scrubs of all shards
+	statShardsScrubbed = "shardsScrubbed" // number of shards scrubbed
+	statShardsCorrupt  = "shardsCorrupt"  // number of shards found to be corrupt
 )
 
 // Store manages shards and indexes for databases.
 type Store struct {
 	mu sync.RWMutex
+
+	// createMu is held while a shard is created, and by SplitShard from committing
+	// new shard IDs until their shards are installed, so that the shards of a split
+	// are not created empty in between.
+	createMu sync.Mutex
+
 	// databases keeps track of the number of databases being managed by the store.
 	databases map[string]struct{}
 
@@ -46,11 +59,19 @@ type Store struct {
 	// shared per-database indexes, only if using "inmem".
 	indexes map[string]interface{}
 
+	// shared per-database measurement quotas.
+	quotas map[string]*MeasurementQuotas
+
+	// shared per-database series limiters, used by the "tsi1" indexes.
+	limiters map[string]*SeriesLimiter
+
 	// shards is a map of shard IDs to the associated Shard.
 	shards map[uint64]*Shard
 
 	EngineOptions EngineOptions
 
+	scrubStats *ScrubStatistics
+
 	baseLogger zap.Logger
 	Logger     zap.Logger
 
@@ -67,7 +88,10 @@ func NewStore(path string) *Store {
 		databases:     make(map[string]struct{}),
 		path:          path,
 		indexes:       make(map[string]interface{}),
+		quotas:        make(map[string]*MeasurementQuotas),
+		limiters:      make(map[string]*SeriesLimiter),
 		EngineOptions: NewEngineOptions(),
+		scrubStats:    &ScrubStatistics{},
 		Logger:        logger,
 		baseLogger:    logger,
 	}
@@ -114,6 +138,22 @@ func (s *Store) Statistics(tags map[string]string) []models.Statistic {
 		})
 	}
 
+	var corrupt int64
+	for _, shard := range shards {
+		if shard.Corrupt() != nil {
+			corrupt++
+		}
+	}
+	statistics = append(statistics, models.Statistic{
+		Name: "scrub",
+		Tags: tags,
+		Values: map[string]interface{}{
+			statScrubs:         atomic.LoadInt64(&s.scrubStats.Scrubs),
+			statShardsScrubbed: atomic.LoadInt64(&s.scrubStats.ShardsScrubbed),
+			statShardsCorrupt:  corrupt,
+		},
+	})
+
 	// Gather all statistics for all shards.
 	for _, shard := range shards {
 		statistics = append(statistics, shard.Statistics(tags)...)
@@ -121,6 +161,27 @@ func (s *Store) Statistics(tags map[string]string) []models.Statistic {
 	return statistics
 }
 
+// ScrubStatistics maintains statistics about scrubbing shards.
+type ScrubStatistics struct {
+	Scrubs         int64
+	ShardsScrubbed int64
+}
+
+// Diagnostics returns the shards found to be corrupt, for display by SHOW DIAGNOSTICS.
+func (s *Store) Diagnostics() (*diagnostics.Diagnostics, error) {
+	s.mu.RLock()
+	shards := s.shardsSlice()
+	s.mu.RUnlock()
+
+	d := diagnostics.NewDiagnostics([]string{"id", "database", "retention_policy", "path", "error"})
+	for _, sh := range shards {
+		if err := sh.Corrupt(); err != nil {
+			d.AddRow([]interface{}{sh.id, sh.database, sh.retentionPolicy, sh.path, err.Error()})
+		}
+	}
+	return d, nil
+}
+
 // Path returns the store's root path.
 func (s *Store) Path() string { return s.path }
 
@@ -148,6 +209,11 @@ func (s *Store) Open() error {
 	s.wg.Add(1)
 	go s.monitorShards()
 
+	if s.EngineOptions.Config.ScrubInterval > 0 {
+		s.wg.Add(1)
+		go s.scrubShards()
+	}
+
 	return nil
 }
 
@@ -187,6 +253,8 @@ func (s *Store) loadShards() error {
 		if err != nil {
 			return err
 		}
+		quotas := s.measurementQuotas(db.Name())
+		limiter := s.seriesLimiter(db.Name())
 
 		// Load each retention policy within the database directory.
 		rpDirs, err := ioutil.ReadDir(filepath.Join(s.path, db.Name()))
@@ -200,6 +268,19 @@ func (s *Store) loadShards() error {
 				continue
 			}
 
+			// Recover shards that were being merged or split when the process stopped.
+			for _, dir := range []string{
+				filepath.Join(s.path, db.Name(), rp.Name()),
+				filepath.Join(s.EngineOptions.Config.WALDir, db.Name(), rp.Name()),
+				s.coldPath(db.Name(), rp.Name()),
+			} {
+				if dir == "" {
+					continue
+				} else if err := recoverShardDirs(dir); err != nil {
+					return err
+				}
+			}
+
 			shardDirs, err := ioutil.ReadDir(filepath.Join(s.path, db.Name(), rp.Name()))
 			if err != nil {
 				return err
@@ -225,6 +306,10 @@ func (s *Store) loadShards() error {
 					// Copy options and assign shared index.
 					opt := s.EngineOptions
 					opt.InmemIndex = idx
+					opt.ColdPath = s.coldPath(db, rp, sh)
+					opt.DuplicatePolicy, opt.MeasurementDuplicatePolicies = s.EngineOptions.Config.DuplicatePoliciesFor(db, rp)
+					opt.MeasurementQuotas = quotas
+					opt.SeriesLimiter = limiter
 
 					// Existing shards should continue to use inmem index.
 					if _, err := os.Stat(filepath.Join(path, "index")); os.IsNotExist(err) {
@@ -356,6 +441,14 @@ func (s *Store) ShardN() int {
 
 // CreateShard creates a shard with the given id and retention policy on a database.
 func (s *Store) CreateShard(database, retentionPolicy string, shardID uint64, enabled bool) error {
+	s.createMu.Lock()
+	defer s.createMu.Unlock()
+	return s.createShard(database, retentionPolicy, shardID, enabled)
+}
+
+// createShard creates a shard with the given id and retention policy on a database.
+// The caller must hold createMu.
+func (s *Store) createShard(database, retentionPolicy string, shardID uint64, enabled bool) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -390,6 +483,10 @@ func (s *Store) CreateShard(database, retentionPolicy string, shardID uint64, en
 	// Copy index options and pass in shared index.
 	opt := s.EngineOptions
 	opt.InmemIndex = idx
+	opt.ColdPath = s.coldPath(database, retentionPolicy, strconv.FormatUint(shardID, 10))
+	opt.DuplicatePolicy, opt.MeasurementDuplicatePolicies = s.EngineOptions.Config.DuplicatePoliciesFor(database, retentionPolicy)
+	opt.MeasurementQuotas = s.measurementQuotas(database)
+	opt.SeriesLimiter = s.seriesLimiter(database)
 
 	path := filepath.Join(s.path, database, retentionPolicy, strconv.FormatUint(shardID, 10))
 	shard := NewShard(shardID, path, walPath, opt)
@@ -406,6 +503,38 @@ func (s *Store) CreateShard(database, retentionPolicy string, shardID uint64, en
 	return nil
 }
 
+// measurementQuotas returns the shared measurement quotas of a database, creating
+// them if needed.  The caller must hold the store's write lock.
+func (s *Store) measurementQuotas(database string) *MeasurementQuotas {
+	if q := s.quotas[database]; q != nil {
+		return q
+	}
+	q := NewMeasurementQuotas()
+	s.quotas[database] = q
+	return q
+}
+
+// seriesLimiter returns the shared series limiter of a database, creating it if
+// needed.  The caller must hold the store's write lock.
+func (s *Store) seriesLimiter(database string) *SeriesLimiter {
+	if l := s.limiters[database]; l != nil {
+		return l
+	}
+	l := NewSeriesLimiter(s.EngineOptions.Config, s.measurementQuotas(database))
+	s.limiters[database] = l
+	return l
+}
+
+// SetMeasurementQuotas replaces the measurement quotas of a database.  The quotas
+// apply to series created after they are set.
+func (s *Store) SetMeasurementQuotas(database string, quotas map[string]MeasurementQuota) {
+	s.mu.Lock()
+	q := s.measurementQuotas(database)
+	s.mu.Unlock()
+
+	q.Set(quotas)
+}
+
 // CreateShardSnapShot will create a hard link to the underlying shard and return a path.
 // The caller is responsible for cleaning up (removing) the file path returned.
 func (s *Store) CreateShardSnapshot(id uint64) (string, error) {
@@ -427,6 +556,394 @@ func (s *Store) SetShardEnabled(shardID uint64, enabled bool) error {
 	return nil
 }
 
+// coldPath returns the path of elem under the cold tier directory, or "" if
+// tiered storage is disabled.
+func (s *Store) coldPath(elem ...string) string {
+	if s.EngineOptions.Config.ColdDir == "" {
+		return ""
+	}
+	return filepath.Join(append([]string{s.EngineOptions.Config.ColdDir}, elem...)...)
+}
+
+// DownsampleShard rewrites the data of the shard with id at a resolution of interval,
+// aggregating the values of each numeric field with the functions in fns.
+func (s *Store) DownsampleShard(shardID uint64, interval time.Duration, fns []string) error {
+	sh := s.Shard(shardID)
+	if sh == nil {
+		return ErrShardNotFound
+	}
+	return sh.Downsample(interval, fns)
+}
+
+// ShardResolution returns the resolution the shard with id has been downsampled to,
+// or zero if the shard has not been downsampled or is not found.
+func (s *Store) ShardResolution(shardID uint64) time.Duration {
+	sh := s.Shard(shardID)
+	if sh == nil {
+		return 0
+	}
+	return sh.Resolution()
+}
+
+// ShardSplit is a time range of a shard moved into a new shard by SplitShard.
+type ShardSplit struct {
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// SplitShard moves the data of the shard with id in the time range of each split into
+// a new shard.  The shard then only keeps its data between start and end.  Time ranges
+// include their start but not their end.
+//
+// The shard is read-only while its data is copied into new shards next to it.  Once
+// the data is copied, commit is called to return the IDs of the new shards, in the
+// order of splits, such as by committing the split to the meta store.  The new shards
+// are then opened and the shard is trimmed.  If an error is returned, the new shards
+// have been removed and the shard is unchanged, but the caller must undo a commit
+// that succeeded.  Shards are not created from when commit is called until the new
+// shards are opened, so commit must not create shards itself.
+func (s *Store) SplitShard(id uint64, start, end time.Time, splits []ShardSplit, commit func() ([]uint64, error)) error {
+	sh := s.Shard(id)
+	if sh == nil {
+		return ErrShardNotFound
+	}
+
+	if err := sh.SetReadOnly(true); err != nil {
+		return err
+	}
+	defer sh.SetReadOnly(false)
+
+	// Copy the data of each split into a shard next to the shard.
+	paths := make([][2]string, len(splits))
+	for i := range splits {
+		paths[i] = [2]string{fmt.Sprintf("%s.split%d", sh.path, i), fmt.Sprintf("%s.split%d", sh.walPath, i)}
+	}
+	defer func() {
+		for _, p := range paths {
+			os.RemoveAll(p[0])
+			os.RemoveAll(p[1])
+		}
+	}()
+
+	for i, split := range splits {
+		tmp, err := s.openTempShard(sh, paths[i][0], paths[i][1])
+		if err != nil {
+			return err
+		}
+
+		if err := s.copyShard(sh, tmp, split.StartTime, split.EndTime.Add(-1)); err != nil {
+			tmp.Close()
+			return err
+		} else if err := tmp.Close(); err != nil {
+			return err
+		}
+	}
+
+	ids, err := s.installSplits(sh, paths, commit)
+	if err != nil {
+		return err
+	}
+
+	if err := sh.Trim(start, end.Add(-1)); err != nil {
+		s.deleteShards(ids)
+		return err
+	}
+	return nil
+}
+
+// installSplits commits a split of sh and moves the copies at paths into place as
+// the new shards.  Shards may not be created until the new shards are installed,
+// since writes could otherwise create them empty once the split is committed.
+func (s *Store) installSplits(sh *Shard, paths [][2]string, commit func() ([]uint64, error)) ([]uint64, error) {
+	s.createMu.Lock()
+	defer s.createMu.Unlock()
+
+	ids, err := commit()
+	if err != nil {
+		return nil, err
+	} else if len(ids) != len(paths) {
+		return nil, fmt.Errorf("split into %d shards, got %d shard ids", len(paths), len(ids))
+	}
+
+	for i, id := range ids {
+		if err := s.installShard(sh.database, sh.retentionPolicy, id, paths[i][0], paths[i][1]); err != nil {
+			s.deleteShards(ids[:i])
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// openTempShard opens a new shard at path and walPath with the options of sh, which
+// the data of sh can be copied into before the shard is moved into place.  The shard
+// has an index of its own so the series of sh's database are unaffected.
+func (s *Store) openTempShard(sh *Shard, path, walPath string) (*Shard, error) {
+	// The data copied into the shard is not limited, and the shard's index is not
+	// one of the indexes of its database.
+	opt := sh.options
+	opt.ColdPath = ""
+	opt.Config.MaxSeriesPerDatabase, opt.Config.MaxValuesPerTag = 0, 0
+	opt.MeasurementQuotas, opt.SeriesLimiter = nil, nil
+	if sh.IndexType() == "inmem" {
+		idx, err := NewInmemIndex(sh.database)
+		if err != nil {
+			return nil, err
+		}
+		opt.InmemIndex = idx
+	}
+
+	if err := os.RemoveAll(path); err != nil {
+		return nil, err
+	} else if err := os.RemoveAll(walPath); err != nil {
+		return nil, err
+	} else if err := os.MkdirAll(walPath, 0700); err != nil {
+		return nil, err
+	}
+
+	tmp := NewShard(sh.id, path, walPath, opt)
+	tmp.WithLogger(s.baseLogger)
+	tmp.EnableOnOpen = true
+	if err := tmp.Open(); err != nil {
+		return nil, err
+	}
+	return tmp, nil
+}
+
+// installShard moves the closed shard at path and walPath into place as the shard
+// with id and opens it.  The caller must hold createMu.
+func (s *Store) installShard(database, retentionPolicy string, id uint64, path, walPath string) error {
+	if sh := s.Shard(id); sh != nil {
+		return fmt.Errorf("shard %d already exists", id)
+	}
+
+	dst := filepath.Join(s.path, database, retentionPolicy, strconv.FormatUint(id, 10))
+	walDst := filepath.Join(s.EngineOptions.Config.WALDir, database, retentionPolicy, strconv.FormatUint(id, 10))
+	if err := os.Rename(path, dst); err != nil {
+		return err
+	} else if err := os.MkdirAll(filepath.Dir(walDst), 0700); err != nil {
+		os.RemoveAll(dst)
+		return err
+	} else if err := os.Rename(walPath, walDst); err != nil {
+		os.RemoveAll(dst)
+		return err
+	}
+
+	if err := s.createShard(database, retentionPolicy, id, true); err != nil {
+		os.RemoveAll(dst)
+		os.RemoveAll(walDst)
+		return err
+	}
+	return nil
+}
+
+// deleteShards deletes the shards with ids, logging any errors.
+func (s *Store) deleteShards(ids []uint64) {
+	for _, id := range ids {
+		if err := s.DeleteShard(id); err != nil {
+			s.Logger.Error(fmt.Sprintf("failed to delete shard %d", id), zap.Error(err))
+		}
+	}
+}
+
+// MergeShards rewrites the shard with id to also hold the data of the shards with ids.
+// The shards are read-only while the merged shard is built next to the shard.  Once
+// the data is copied, commit is called, such as to commit the merge to the meta store,
+// and the merged shard then replaces the shard.  The shards with ids are left
+// unchanged and read-only, to be deleted by the caller.  If an error is returned, the
+// shards are unchanged and writable, but the caller must undo a commit that succeeded.
+func (s *Store) MergeShards(id uint64, ids []uint64, commit func() error) error {
+	sh := s.Shard(id)
+	if sh == nil {
+		return ErrShardNotFound
+	}
+
+	shards := []*Shard{sh}
+	for _, id := range ids {
+		src := s.Shard(id)
+		if src == nil {
+			return ErrShardNotFound
+		}
+		shards = append(shards, src)
+	}
+
+	for i, x := range shards {
+		if err := x.SetReadOnly(true); err != nil {
+			for _, y := range shards[:i] {
+				y.SetReadOnly(false)
+			}
+			return err
+		}
+	}
+
+	merged := false
+	defer func() {
+		sh.SetReadOnly(false)
+		if !merged {
+			for _, src := range shards[1:] {
+				src.SetReadOnly(false)
+			}
+		}
+	}()
+
+	tmpPath, tmpWALPath := sh.path+".merge", sh.walPath+".merge"
+	defer os.RemoveAll(tmpPath)
+	defer os.RemoveAll(tmpWALPath)
+
+	tmp, err := s.openTempShard(sh, tmpPath, tmpWALPath)
+	if err != nil {
+		return err
+	}
+
+	for _, src := range shards {
+		if err := s.copyShard(src, tmp, time.Unix(0, influxql.MinTime), time.Unix(0, influxql.MaxTime)); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	} else if err := commit(); err != nil {
+		return err
+	} else if err := s.replaceShardFiles(sh, tmpPath, tmpWALPath); err != nil {
+		return err
+	}
+	merged = true
+	return nil
+}
+
+// copyShard copies the data of src between start and end, inclusive, into dst.  The
+// data is streamed from an export of src straight into an import by dst.
+func (s *Store) copyShard(src, dst *Shard, start, end time.Time) error {
+	path, err := relativePath(s.path, src.path)
+	if err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	errC := make(chan error, 1)
+	go func() {
+		err := src.Export(pw, path, start, end)
+		pw.CloseWithError(err)
+		errC <- err
+	}()
+
+	err = dst.Import(pr, path)
+	if err == nil {
+		// Drain the end of the archive so the export can finish.
+		_, err = io.Copy(ioutil.Discard, pr)
+	}
+	pr.CloseWithError(err)
+
+	if exportErr := <-errC; exportErr != nil {
+		return exportErr
+	}
+	return err
+}
+
+// replaceShardFiles closes sh, replaces its data and WAL directories with path and
+// walPath and reopens it.  The replaced directories, and the shard's directory on the
+// cold tier, are kept with an oldShardDirSuffix until the shard is reopened, so they
+// are restored if replacing the shard fails, or by recoverShardDirs when the store is
+// opened if the process stops first.
+func (s *Store) replaceShardFiles(sh *Shard, path, walPath string) error {
+	if err := sh.Close(); err != nil {
+		return err
+	}
+
+	// The shard's directory on the cold tier is replaced by nothing since every file
+	// of the new shard is in its data directory.
+	dirs := [][2]string{{path, sh.path}, {walPath, sh.walPath}}
+	if sh.options.ColdPath != "" {
+		dirs = append(dirs, [2]string{"", sh.options.ColdPath})
+	}
+
+	var replaced [][2]string
+	restore := func() {
+		for _, p := range replaced {
+			if p[0] != "" {
+				os.RemoveAll(p[1])
+			}
+			os.Rename(p[1]+oldShardDirSuffix, p[1])
+		}
+	}
+
+	for _, p := range dirs {
+		old := p[1] + oldShardDirSuffix
+		if err := os.RemoveAll(old); err != nil {
+			restore()
+			return err
+		} else if err := os.Rename(p[1], old); err != nil && !os.IsNotExist(err) {
+			restore()
+			return err
+		}
+		replaced = append(replaced, p)
+
+		if p[0] != "" {
+			if err := os.Rename(p[0], p[1]); err != nil {
+				restore()
+				return err
+			}
+		}
+	}
+
+	if err := sh.Open(); err != nil {
+		sh.Close()
+		restore()
+		if oerr := sh.Open(); oerr != nil {
+			return fmt.Errorf("%s, and reopening the replaced shard failed: %s", err, oerr)
+		}
+		return err
+	}
+
+	for _, p := range replaced {
+		if err := os.RemoveAll(p[1] + oldShardDirSuffix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// oldShardDirSuffix is added to the directories of a shard while they are replaced.
+const oldShardDirSuffix = ".old"
+
+// recoverShardDirs restores the shard directories in dir that were being replaced when
+// the process stopped and removes the directories of shards that were being built by
+// a merge or split of a shard.
+func recoverShardDirs(dir string) error {
+	fis, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	for _, fi := range fis {
+		path := filepath.Join(dir, fi.Name())
+		ext := filepath.Ext(fi.Name())
+		switch {
+		case ext == oldShardDirSuffix:
+			// The shard was replaced if its directory exists.
+			shardPath := strings.TrimSuffix(path, ext)
+			if _, err := os.Stat(shardPath); os.IsNotExist(err) {
+				if err := os.Rename(path, shardPath); err != nil {
+					return err
+				}
+			} else if err != nil {
+				return err
+			} else if err := os.RemoveAll(path); err != nil {
+				return err
+			}
+
+		case ext == ".merge", strings.HasPrefix(ext, ".split"):
+			if err := os.RemoveAll(path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // DeleteShard removes a shard from disk.
 func (s *Store) DeleteShard(shardID uint64) error {
 	sh := s.Shard(shardID)
@@ -451,6 +968,12 @@ func (s *Store) DeleteShard(shardID uint64) error {
 		return err
 	}
 
+	if sh.options.ColdPath != "" {
+		if err := os.RemoveAll(sh.options.ColdPath); err != nil {
+			return err
+		}
+	}
+
 	s.mu.Lock()
 	delete(s.shards, shardID)
 	s.mu.Unlock()
@@ -463,7 +986,11 @@ func (s *Store) DeleteDatabase(name string) error {
 	s.mu.RLock()
 	if _, ok := s.databases[name]; !ok {
 		s.mu.RUnlock()
-		// no files locally, so nothing to do
+		// no files locally, so only the quotas need removing
+		s.mu.Lock()
+		delete(s.quotas, name)
+		delete(s.limiters, name)
+		s.mu.Unlock()
 		return nil
 	}
 	shards := s.filterShards(func(sh *Shard) bool {
@@ -495,6 +1022,11 @@ func (s *Store) DeleteDatabase(name string) error {
 	if err := os.RemoveAll(filepath.Join(s.EngineOptions.Config.WALDir, name)); err != nil {
 		return err
 	}
+	if coldPath := s.coldPath(name); coldPath != "" {
+		if err := os.RemoveAll(coldPath); err != nil {
+			return err
+		}
+	}
 
 	s.mu.Lock()
 	for _, sh := range shards {
@@ -506,6 +1038,8 @@ func (s *Store) DeleteDatabase(name string) error {
 
 	// Remove shared index for database if using inmem index.
 	delete(s.indexes, name)
+	delete(s.quotas, name)
+	delete(s.limiters, name)
 	s.mu.Unlock()
 
 	return nil
@@ -556,6 +1090,13 @@ func (s *Store) DeleteRetentionPolicy(database, name string) error {
 		return err
 	}
 
+	// Remove the retention policy folder from the cold tier.
+	if coldPath := s.coldPath(database, name); coldPath != "" {
+		if err := os.RemoveAll(coldPath); err != nil {
+			return err
+		}
+	}
+
 	s.mu.Lock()
 	for _, sh := range shards {
 		delete(s.shards, sh.id)
@@ -696,6 +1237,27 @@ func (s *Store) DiskSize() (int64, error) {
 	return size, nil
 }
 
+// IdleShardSizes returns the size on disk of every shard that is not receiving writes
+// and is fully compacted, keyed by shard ID.
+func (s *Store) IdleShardSizes() map[uint64]int64 {
+	s.mu.RLock()
+	allShards := s.filterShards(nil)
+	s.mu.RUnlock()
+
+	sizes := make(map[uint64]int64)
+	for _, sh := range allShards {
+		if !sh.IsIdle() {
+			continue
+		}
+		sz, err := sh.DiskSize()
+		if err != nil {
+			continue
+		}
+		sizes[sh.id] = sz
+	}
+	return sizes
+}
+
 func (s *Store) estimateCardinality(dbName string, getSketches func(*Shard) (estimator.Sketch, estimator.Sketch, error)) (int64, error) {
 	var (
 		ss estimator.Sketch // Sketch estimating number of items.
@@ -835,35 +1397,9 @@ func (s *Store) DeleteSeries(database string, sources []influxql.Source, conditi
 	defer s.mu.RUnlock()
 
 	return s.walkShards(shards, func(sh *Shard) error {
-		// Determine list of measurements from sources.
-		// Use all measurements if no FROM clause was provided.
-		var names []string
-		if len(sources) > 0 {
-			for _, source := range sources {
-				names = append(names, source.(*influxql.Measurement).Name)
-			}
-		} else {
-			if err := sh.engine.ForEachMeasurementName(func(name []byte) error {
-				names = append(names, string(name))
-				return nil
-			}); err != nil {
-				return err
-			}
-		}
-		sort.Strings(names)
-
-		// Find matching series keys for each measurement.
-		var keys [][]byte
-		for _, name := range names {
-			a, err := sh.engine.MeasurementSeriesKeysByExpr([]byte(name), condition)
-			if err != nil {
-				return err
-			}
-			keys = append(keys, a...)
-		}
-
-		if !bytesutil.IsSorted(keys) {
-			bytesutil.Sort(keys)
+		keys, err := sh.seriesKeysBySources(sources, condition)
+		if err != nil {
+			return err
 		}
 
 		// Delete all matching keys.
@@ -874,6 +1410,146 @@ func (s *Store) DeleteSeries(database string, sources []influxql.Source, conditi
 	})
 }
 
+// SeriesLastWrite is the time of the last value written to a series.
+type SeriesLastWrite struct {
+	Key       string
+	LastWrite int64 // zero if HasValues is false
+	HasValues bool
+}
+
+// InactiveSeries returns the series of the sources in a database matching condition
+// whose last write across all shards is between min and max, inclusive, sorted by
+// key.  Series without values are inactive if min is influxql.MinTime.  The
+// condition must not contain time expressions.
+func (s *Store) InactiveSeries(database string, sources []influxql.Source, condition influxql.Expr, min, max int64) ([]SeriesLastWrite, error) {
+	// Expand regex expressions in the FROM clause.
+	a, err := s.ExpandSources(sources)
+	if err != nil {
+		return nil, err
+	} else if sources != nil && len(sources) != 0 && len(a) == 0 {
+		return nil, nil
+	}
+	sources = a
+
+	s.mu.RLock()
+	shards := s.filterShards(byDatabase(database))
+	s.mu.RUnlock()
+
+	// Determine the last write of each series across shards.
+	var mu sync.Mutex
+	series := make(map[string]SeriesLastWrite)
+	if err := s.walkShards(shards, func(sh *Shard) error {
+		keys, err := sh.seriesKeysBySources(sources, condition)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			lw, err := sh.seriesLastWrite(key)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			series[string(key)] = series[string(key)].newest(lw)
+			mu.Unlock()
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var inactive []SeriesLastWrite
+	for _, lw := range series {
+		if lw.inactive(min, max) {
+			inactive = append(inactive, lw)
+		}
+	}
+	sort.Sort(seriesLastWrites(inactive))
+	return inactive, nil
+}
+
+// DeleteInactiveSeries removes the series returned by InactiveSeries from all shards
+// of a database and returns them.  Writes to the database's shards are blocked while
+// the series are checked again and removed, so series written to since they were
+// found are kept.
+func (s *Store) DeleteInactiveSeries(database string, sources []influxql.Source, condition influxql.Expr, min, max int64) ([]SeriesLastWrite, error) {
+	inactive, err := s.InactiveSeries(database, sources, condition, min, max)
+	if err != nil || len(inactive) == 0 {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	shards := s.filterShards(byDatabase(database))
+	sort.Sort(Shards(shards))
+
+	// Block writes to the shards while the series are checked again and deleted, so
+	// that series written to since they were found are kept.
+	for _, sh := range shards {
+		unlock, err := sh.lockWrites()
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+
+	var deleted []SeriesLastWrite
+	var keys [][]byte
+	for i := range inactive {
+		key := []byte(inactive[i].Key)
+
+		var lw SeriesLastWrite
+		for _, sh := range shards {
+			other, err := sh.seriesLastWrite(key)
+			if err != nil {
+				return nil, err
+			}
+			lw = lw.newest(other)
+		}
+		if lw.inactive(min, max) {
+			deleted = append(deleted, lw)
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	for _, sh := range shards {
+		if err := sh.engine.DeleteSeriesRange(keys, math.MinInt64, math.MaxInt64); err != nil {
+			return nil, err
+		}
+	}
+	return deleted, nil
+}
+
+// newest returns the later of two last writes of a series.  A zero SeriesLastWrite
+// is replaced by other.
+func (lw SeriesLastWrite) newest(other SeriesLastWrite) SeriesLastWrite {
+	if lw.Key == "" || (other.HasValues && (!lw.HasValues || other.LastWrite > lw.LastWrite)) {
+		return other
+	}
+	return lw
+}
+
+// inactive returns true if the last write of the series is between min and max,
+// or if the series has no values and min is influxql.MinTime.
+func (lw SeriesLastWrite) inactive(min, max int64) bool {
+	if !lw.HasValues {
+		return min == influxql.MinTime
+	}
+	return lw.LastWrite >= min && lw.LastWrite <= max
+}
+
+// seriesLastWrites sorts series by key.
+type seriesLastWrites []SeriesLastWrite
+
+func (a seriesLastWrites) Len() int           { return len(a) }
+func (a seriesLastWrites) Less(i, j int) bool { return a[i].Key < a[j].Key }
+func (a seriesLastWrites) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+
 // ExpandSources expands sources against all local shards.
 func (s *Store) ExpandSources(sources influxql.Sources) (influxql.Sources, error) {
 	shards := func() Shards {
@@ -907,12 +1583,31 @@ func (s *Store) WriteToShard(shardID uint64, points []models.Point) error {
 
 // MeasurementNames returns a slice of all measurements. Measurements accepts an
 // optional condition expression. If cond is nil, then all measurements for the
-// database will be returned.
+// database will be returned.  If cond has a time range, only the measurements with
+// values within the range are returned.
 func (s *Store) MeasurementNames(database string, cond influxql.Expr) ([][]byte, error) {
 	s.mu.RLock()
 	shards := s.filterShards(byDatabase(database))
 	s.mu.RUnlock()
 
+	return shardMeasurementNames(shards, cond)
+}
+
+// MeasurementNamesInShards returns the measurements of the shards matching the
+// condition, like MeasurementNames.
+func (s *Store) MeasurementNamesInShards(shardIDs []uint64, cond influxql.Expr) ([][]byte, error) {
+	return shardMeasurementNames(s.Shards(shardIDs), cond)
+}
+
+// shardMeasurementNames returns the sorted names of the measurements in the shards
+// matching the condition.
+func shardMeasurementNames(shards []*Shard, cond influxql.Expr) ([][]byte, error) {
+	cond, min, max, err := splitTimeCondition(cond)
+	if err != nil {
+		return nil, err
+	}
+	_, filterExpr := splitCondition(cond)
+
 	// Map to deduplicate measurement names across all shards.  This is kind of naive
 	// and could be improved using a sorted merge of the already sorted measurements in
 	// each shard.
@@ -925,10 +1620,21 @@ func (s *Store) MeasurementNames(database string, cond influxql.Expr) ([][]byte,
 		}
 
 		for _, m := range a {
-			if _, ok := set[string(m)]; !ok {
-				set[string(m)] = struct{}{}
-				names = append(names, m)
+			if _, ok := set[string(m)]; ok {
+				continue
 			}
+
+			// Skip measurements without values in the time range of this shard.
+			if min > influxql.MinTime || max < influxql.MaxTime {
+				if ok, err := sh.hasMeasurementValues(m, filterExpr, min, max); err != nil {
+					return nil, err
+				} else if !ok {
+					continue
+				}
+			}
+
+			set[string(m)] = struct{}{}
+			names = append(names, m)
 		}
 	}
 	bytesutil.Sort(names)
@@ -948,48 +1654,48 @@ type TagValues struct {
 	Values      []KeyValue
 }
 
+// tagValuesSlice is a slice of TagValues sortable by measurement.
+type tagValuesSlice []TagValues
+
+func (a tagValuesSlice) Len() int           { return len(a) }
+func (a tagValuesSlice) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a tagValuesSlice) Less(i, j int) bool { return a[i].Measurement < a[j].Measurement }
+
 // TagValues returns the tag keys and values in the given database, matching the condition.
+// If cond has a time range, only the values of series with values within the range are
+// returned.
 func (s *Store) TagValues(database string, cond influxql.Expr) ([]TagValues, error) {
+	// Get all measurements for the shards we're interested in.
+	s.mu.RLock()
+	shards := s.filterShards(byDatabase(database))
+	s.mu.RUnlock()
+
+	return shardTagValues(shards, cond)
+}
+
+// TagValuesInShards returns the tag keys and values in the shards matching the condition,
+// like TagValues.
+func (s *Store) TagValuesInShards(shardIDs []uint64, cond influxql.Expr) ([]TagValues, error) {
+	return shardTagValues(s.Shards(shardIDs), cond)
+}
+
+// shardTagValues returns the tag keys and values in the shards matching the condition,
+// merged across shards and sorted by measurement.
+func shardTagValues(shards []*Shard, cond influxql.Expr) ([]TagValues, error) {
 	if cond == nil {
 		return nil, errors.New("a condition is required")
 	}
 
-	measurementExpr := influxql.CloneExpr(cond)
-	measurementExpr = influxql.Reduce(influxql.RewriteExpr(measurementExpr, func(e influxql.Expr) influxql.Expr {
-		switch e := e.(type) {
-		case *influxql.BinaryExpr:
-			switch e.Op {
-			case influxql.EQ, influxql.NEQ, influxql.EQREGEX, influxql.NEQREGEX:
-				tag, ok := e.LHS.(*influxql.VarRef)
-				if !ok || tag.Val != "_name" {
-					return nil
-				}
-			}
-		}
-		return e
-	}), nil)
-
-	filterExpr := influxql.CloneExpr(cond)
-	filterExpr = influxql.Reduce(influxql.RewriteExpr(filterExpr, func(e influxql.Expr) influxql.Expr {
-		switch e := e.(type) {
-		case *influxql.BinaryExpr:
-			switch e.Op {
-			case influxql.EQ, influxql.NEQ, influxql.EQREGEX, influxql.NEQREGEX:
-				tag, ok := e.LHS.(*influxql.VarRef)
-				if !ok || strings.HasPrefix(tag.Val, "_") {
-					return nil
-				}
-			}
-		}
-		return e
-	}), nil)
+	cond, min, max, err := splitTimeCondition(cond)
+	if err != nil {
+		return nil, err
+	}
+	timeRange := min > influxql.MinTime || max < influxql.MaxTime
 
-	// Get all measurements for the shards we're interested in.
-	s.mu.RLock()
-	shards := s.filterShards(byDatabase(database))
-	s.mu.RUnlock()
+	measurementExpr, filterExpr := splitCondition(cond)
 
 	var tagValues []TagValues
+	indexes := make(map[string]int) // index of each measurement in tagValues
 	for _, sh := range shards {
 		names, err := sh.MeasurementNamesByExpr(measurementExpr)
 		if err != nil {
@@ -1006,6 +1712,12 @@ func (s *Store) TagValues(database string, cond influxql.Expr) ([]TagValues, err
 			// Loop over all keys for each series.
 			m := make(map[KeyValue]struct{})
 			if err := sh.engine.ForEachMeasurementSeriesByExpr(name, filterExpr, func(tags models.Tags) error {
+				if timeRange {
+					if ok, err := sh.engine.HasSeriesValues(models.MakeKey(name, tags), min, max); err != nil || !ok {
+						return err
+					}
+				}
+
 				for _, t := range tags {
 					if _, ok := keySet[string(t.Key)]; ok {
 						m[KeyValue{string(t.Key), string(t.Value)}] = struct{}{}
@@ -1031,6 +1743,14 @@ func (s *Store) TagValues(database string, cond influxql.Expr) ([]TagValues, err
 				}
 			*/
 
+			// Merge with the values of the measurement in earlier shards.
+			i, ok := indexes[string(name)]
+			if ok {
+				for _, kv := range tagValues[i].Values {
+					m[kv] = struct{}{}
+				}
+			}
+
 			// Sort key/value set.
 			var a []KeyValue
 			if len(m) > 0 {
@@ -1041,16 +1761,306 @@ func (s *Store) TagValues(database string, cond influxql.Expr) ([]TagValues, err
 				sort.Sort(KeyValues(a))
 			}
 
+			if ok {
+				tagValues[i].Values = a
+				continue
+			}
+			indexes[string(name)] = len(tagValues)
 			tagValues = append(tagValues, TagValues{
 				Measurement: string(name),
 				Values:      a,
 			})
 		}
 	}
+	sort.Sort(tagValuesSlice(tagValues))
 
 	return tagValues, nil
 }
 
+// TagValuesByPrefix returns the sorted values of a measurement's tag key in the
+// given database that begin with prefix.  At most limit values are returned if
+// limit is greater than zero.
+func (s *Store) TagValuesByPrefix(database, measurement, key, prefix string, limit int) ([]string, error) {
+	s.mu.RLock()
+	shards := indexShards(s.filterShards(byDatabase(database)))
+	s.mu.RUnlock()
+
+	// Merge the values of each shard.  Each shard returns at most limit values
+	// so the first limit values of the merged set are the smallest overall.
+	var mu sync.Mutex
+	m := make(map[string]struct{})
+	if err := s.walkShards(shards, func(sh *Shard) error {
+		values, err := sh.TagValuesByPrefix([]byte(measurement), []byte(key), []byte(prefix), limit)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		for _, v := range values {
+			m[string(v)] = struct{}{}
+		}
+		mu.Unlock()
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(m))
+	for v := range m {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+
+	if limit > 0 && len(values) > limit {
+		values = values[:limit]
+	}
+	return values, nil
+}
+
+// splitCondition splits a condition into the expression on measurement names and the
+// expression on the tags of series.
+func splitCondition(cond influxql.Expr) (measurementExpr, filterExpr influxql.Expr) {
+	if cond == nil {
+		return nil, nil
+	}
+
+	measurementExpr = influxql.CloneExpr(cond)
+	measurementExpr = influxql.Reduce(influxql.RewriteExpr(measurementExpr, func(e influxql.Expr) influxql.Expr {
+		switch e := e.(type) {
+		case *influxql.BinaryExpr:
+			switch e.Op {
+			case influxql.EQ, influxql.NEQ, influxql.EQREGEX, influxql.NEQREGEX:
+				tag, ok := e.LHS.(*influxql.VarRef)
+				if !ok || tag.Val != "_name" {
+					return nil
+				}
+			}
+		}
+		return e
+	}), nil)
+
+	filterExpr = influxql.CloneExpr(cond)
+	filterExpr = influxql.Reduce(influxql.RewriteExpr(filterExpr, func(e influxql.Expr) influxql.Expr {
+		switch e := e.(type) {
+		case *influxql.BinaryExpr:
+			switch e.Op {
+			case influxql.EQ, influxql.NEQ, influxql.EQREGEX, influxql.NEQREGEX:
+				tag, ok := e.LHS.(*influxql.VarRef)
+				if !ok || strings.HasPrefix(tag.Val, "_") {
+					return nil
+				}
+			}
+		}
+		return e
+	}), nil)
+
+	return measurementExpr, filterExpr
+}
+
+// splitTimeCondition removes the time expressions from a condition.  It returns the
+// remaining condition and the time range selected, as epoch nanoseconds.
+func splitTimeCondition(cond influxql.Expr) (influxql.Expr, int64, int64, error) {
+	if !influxql.HasTimeExpr(cond) {
+		return cond, influxql.MinTime, influxql.MaxTime, nil
+	}
+
+	min, max, err := influxql.TimeRangeAsEpochNano(cond)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	cond = influxql.RewriteExpr(influxql.CloneExpr(cond), func(e influxql.Expr) influxql.Expr {
+		if e, ok := e.(*influxql.BinaryExpr); ok {
+			for _, expr := range []influxql.Expr{e.LHS, e.RHS} {
+				if ref, ok := expr.(*influxql.VarRef); ok && strings.ToLower(ref.Val) == "time" {
+					return nil
+				}
+			}
+		}
+		return e
+	})
+	return cond, min, max, nil
+}
+
+// MeasurementCount holds the number of series, tag values or field keys of a measurement.
+type MeasurementCount struct {
+	Measurement string
+	N           int
+}
+
+// newMeasurementCounts returns the sizes of sets keyed by measurement, sorted by
+// measurement.  Empty sets are skipped.
+func newMeasurementCounts(sets map[string]map[string]struct{}) []MeasurementCount {
+	a := make([]MeasurementCount, 0, len(sets))
+	for name, set := range sets {
+		if len(set) > 0 {
+			a = append(a, MeasurementCount{Measurement: name, N: len(set)})
+		}
+	}
+	sort.Sort(measurementCounts(a))
+	return a
+}
+
+// measurementCounts is a slice of MeasurementCount sortable by measurement.
+type measurementCounts []MeasurementCount
+
+func (a measurementCounts) Len() int           { return len(a) }
+func (a measurementCounts) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a measurementCounts) Less(i, j int) bool { return a[i].Measurement < a[j].Measurement }
+
+// indexShards returns the shards with distinct indexes.  Shards using the inmem
+// index share the index of the database, so only the first of them is returned.
+func indexShards(shards []*Shard) []*Shard {
+	var a []*Shard
+	var inmem bool
+	for _, sh := range shards {
+		if sh.IndexType() == "inmem" {
+			if inmem {
+				continue
+			}
+			inmem = true
+		}
+		a = append(a, sh)
+	}
+	return a
+}
+
+// SeriesCardinalityByMeasurement returns the exact number of series of each measurement
+// in the database matching the condition.  If cond is nil, all series are counted.
+func (s *Store) SeriesCardinalityByMeasurement(database string, cond influxql.Expr) ([]MeasurementCount, error) {
+	measurementExpr, filterExpr := splitCondition(cond)
+
+	s.mu.RLock()
+	shards := indexShards(s.filterShards(byDatabase(database)))
+	s.mu.RUnlock()
+
+	// Series keys of each measurement, deduplicated across shards.
+	sets := make(map[string]map[string]struct{})
+	for _, sh := range shards {
+		names, err := sh.MeasurementNamesByExpr(measurementExpr)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range names {
+			keys, err := sh.engine.MeasurementSeriesKeysByExpr(name, filterExpr)
+			if err != nil {
+				return nil, err
+			}
+
+			set := sets[string(name)]
+			if set == nil {
+				set = make(map[string]struct{}, len(keys))
+				sets[string(name)] = set
+			}
+			for _, key := range keys {
+				set[string(key)] = struct{}{}
+			}
+		}
+	}
+	return newMeasurementCounts(sets), nil
+}
+
+// TagValuesCardinalityByMeasurement returns the exact number of tag key/value pairs of
+// each measurement in the database matching the condition.
+func (s *Store) TagValuesCardinalityByMeasurement(database string, cond influxql.Expr) ([]MeasurementCount, error) {
+	tagValues, err := s.TagValues(database, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tag values of each measurement, deduplicated across shards.
+	sets := make(map[string]map[string]struct{})
+	for _, tv := range tagValues {
+		set := sets[tv.Measurement]
+		if set == nil {
+			set = make(map[string]struct{}, len(tv.Values))
+			sets[tv.Measurement] = set
+		}
+		for _, kv := range tv.Values {
+			set[kv.Key+"\x00"+kv.Value] = struct{}{}
+		}
+	}
+	return newMeasurementCounts(sets), nil
+}
+
+// FieldKeysCardinalityByMeasurement returns the exact number of field keys of each
+// measurement in the database matching the condition.  If cond is nil, the field
+// keys of all measurements are counted.
+func (s *Store) FieldKeysCardinalityByMeasurement(database string, cond influxql.Expr) ([]MeasurementCount, error) {
+	s.mu.RLock()
+	shards := s.filterShards(byDatabase(database))
+	s.mu.RUnlock()
+
+	// Field keys of each measurement, deduplicated across shards.
+	sets := make(map[string]map[string]struct{})
+	for _, sh := range shards {
+		names, err := sh.MeasurementNamesByExpr(cond)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range names {
+			set := sets[string(name)]
+			if set == nil {
+				set = make(map[string]struct{})
+				sets[string(name)] = set
+			}
+			for key := range sh.MeasurementFields(name).FieldSet() {
+				set[key] = struct{}{}
+			}
+		}
+	}
+	return newMeasurementCounts(sets), nil
+}
+
+// scrubShards scrubs the shards of the store periodically.
+func (s *Store) scrubShards() {
+	defer s.wg.Done()
+	t := time.NewTicker(time.Duration(s.EngineOptions.Config.ScrubInterval))
+	defer t.Stop()
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-t.C:
+			s.Scrub()
+		}
+	}
+}
+
+// Scrub verifies the integrity of the data files of every shard not already known to
+// be corrupt.  The shards are read one at a time at no more than the configured rate
+// limit.  Corrupt shards are made read-only and reported by Diagnostics.  If repairs
+// are enabled, corrupt shards are repaired and made writable again.
+func (s *Store) Scrub() {
+	s.mu.RLock()
+	shards := s.shardsSlice()
+	closing := s.closing
+	s.mu.RUnlock()
+
+	rate := limiter.NewRate(s.EngineOptions.Config.ScrubRateLimit)
+	for _, sh := range shards {
+		if sh.Corrupt() == nil {
+			sh.Scrub(rate, closing)
+		}
+
+		if s.EngineOptions.Config.ScrubRepairEnabled && sh.Corrupt() != nil {
+			if err := sh.Repair(closing); err != nil {
+				s.Logger.Error(fmt.Sprintf("failed to repair shard %d", sh.id), zap.Error(err))
+			}
+		}
+
+		select {
+		case <-closing:
+			return
+		default:
+		}
+		atomic.AddInt64(&s.scrubStats.ShardsScrubbed, 1)
+	}
+	atomic.AddInt64(&s.scrubStats.Scrubs, 1)
+}
+
 func (s *Store) monitorShards() {
 	defer s.wg.Done()
 	t := time.NewTicker(10 * time.Second)
@@ -2,6 +2,7 @@ package tsdb_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math"
@@ -124,6 +125,102 @@ func TestStore_CreateShard(t *testing.T) {
 	}
 }
 
+// Ensure the store applies the measurement quotas of a database to its shards.
+func TestStore_SetMeasurementQuotas(t *testing.T) {
+	t.Parallel()
+
+	s := MustOpenStore()
+	defer s.Close()
+
+	s.SetMeasurementQuotas("db0", map[string]tsdb.MeasurementQuota{"cpu": {MaxSeries: 1}})
+	if err := s.CreateShard("db0", "rp0", 1, true); err != nil {
+		t.Fatal(err)
+	}
+	s.MustWriteToShardString(1, `cpu,host=a value=1 0`)
+
+	points, err := models.ParsePointsString(`cpu,host=b value=1 0`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.WriteToShard(1, points); err == nil {
+		t.Fatal("expected partial write error")
+	}
+
+	// Raising the quota applies to existing shards.
+	s.SetMeasurementQuotas("db0", map[string]tsdb.MeasurementQuota{"cpu": {MaxSeries: 2}})
+	if err := s.WriteToShard(1, points); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Ensure the series limits and measurement quotas apply to the series of every shard
+// of a database.
+func TestStore_SeriesLimits_Inmem(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore()
+	store.EngineOptions.Config.Index = "inmem"
+	store.EngineOptions.Config.MaxSeriesPerDatabase = 4
+	if err := store.Open(); err != nil {
+		panic(err)
+	}
+	defer store.Close()
+	testStoreSeriesLimits(t, store)
+}
+
+func TestStore_SeriesLimits_TSI(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore()
+	store.EngineOptions.Config.Index = "tsi1"
+	store.EngineOptions.Config.MaxSeriesPerDatabase = 4
+	if err := store.Open(); err != nil {
+		panic(err)
+	}
+	defer store.Close()
+	testStoreSeriesLimits(t, store)
+}
+
+func testStoreSeriesLimits(t *testing.T, store *Store) {
+	store.MustCreateShardWithData("db0", "rp0", 1, `cpu,host=a value=1 0`, `cpu,host=b value=1 0`, `mem,host=a value=1 0`)
+	if err := store.CreateShard("db0", "rp0", 2, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// Series of other shards don't count again.
+	points, err := models.ParsePointsString("cpu,host=a value=1 10\ncpu,host=c value=1 10\ncpu,host=d value=1 10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err, ok := store.WriteToShard(2, points).(tsdb.PartialWriteError); !ok || err.Dropped != 1 {
+		t.Fatalf("unexpected error: %v", err)
+	} else if !strings.Contains(err.Reason, "max-series-per-database limit exceeded") {
+		t.Fatalf("unexpected reason: %s", err.Reason)
+	}
+
+	store.SetMeasurementQuotas("db0", map[string]tsdb.MeasurementQuota{"mem": {MaxSeries: 1}})
+	points, err = models.ParsePointsString("mem,host=a value=1 10\nmem,host=b value=1 10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err, ok := store.WriteToShard(2, points).(tsdb.PartialWriteError); !ok || err.Dropped != 1 {
+		t.Fatalf("unexpected error: %v", err)
+	} else if !strings.Contains(err.Reason, "measurement quota exceeded") {
+		t.Fatalf("unexpected reason: %s", err.Reason)
+	}
+
+	// Removing a shard frees its series.
+	if err := store.DeleteShard(1); err != nil {
+		t.Fatal(err)
+	}
+	points, err = models.ParsePointsString("cpu,host=d value=1 10")
+	if err != nil {
+		t.Fatal(err)
+	} else if err := store.WriteToShard(2, points); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // Ensure the store can delete an existing shard.
 func TestStore_DeleteShard(t *testing.T) {
 	t.Parallel()
@@ -169,6 +266,135 @@ func TestStore_CreateShardSnapShot(t *testing.T) {
 	}
 }
 
+// Ensure the store makes shards with corrupt data files read-only.
+func TestStore_Scrub(t *testing.T) {
+	t.Parallel()
+
+	s := MustOpenStore()
+	defer s.Close()
+
+	s.MustCreateShardWithData("db0", "rp0", 1, `cpu,host=serverA value=1 0`, `cpu,host=serverB value=2 10`)
+
+	// Snapshot the cache to write a TSM file.
+	dir, err := s.CreateShardSnapshot(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.RemoveAll(dir)
+
+	s.Scrub()
+	if err := s.Shard(1).Corrupt(); err != nil {
+		t.Fatalf("unexpected corruption: %v", err)
+	}
+
+	// Corrupt the data of the first block, after the file header and block checksum.
+	files, err := filepath.Glob(filepath.Join(s.Path(), "db0", "rp0", "1", "*.tsm"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(files) != 1 {
+		t.Fatalf("unexpected TSM files: %v", files)
+	}
+
+	f, err := os.OpenFile(files[0], os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteAt([]byte{0xff, 0xff}, 10); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	s.Scrub()
+	if err := s.Shard(1).Corrupt(); err == nil {
+		t.Fatal("expected corruption")
+	}
+
+	// The shard is read-only.
+	points, err := models.ParsePointsString(`cpu,host=serverA value=3 20`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.WriteToShard(1, points); err != tsdb.ErrShardCorrupt {
+		t.Fatalf("unexpected error: got %v, exp %v", err, tsdb.ErrShardCorrupt)
+	}
+
+	d, err := s.Diagnostics()
+	if err != nil {
+		t.Fatal(err)
+	} else if len(d.Rows) != 1 || d.Rows[0][0] != uint64(1) {
+		t.Fatalf("unexpected diagnostics: %v", d.Rows)
+	}
+
+	// The shard remains read-only after a restart.
+	if err := s.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if err := s.Shard(1).Corrupt(); err == nil {
+		t.Fatal("expected corruption after reopen")
+	} else if err := s.WriteToShard(1, points); err != tsdb.ErrShardCorrupt {
+		t.Fatalf("unexpected error: got %v, exp %v", err, tsdb.ErrShardCorrupt)
+	}
+}
+
+// Ensure the store repairs corrupt shards when repairs are enabled.
+func TestStore_Scrub_Repair(t *testing.T) {
+	t.Parallel()
+
+	s := NewStore()
+	s.EngineOptions.Config.ScrubRepairEnabled = true
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	s.MustCreateShardWithData("db0", "rp0", 1, `cpu,host=serverA value=1 0`, `cpu,host=serverB value=2 10`)
+
+	// Snapshot the cache to write a TSM file.
+	dir, err := s.CreateShardSnapshot(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.RemoveAll(dir)
+
+	// Corrupt the data of the first block, after the file header and block checksum.
+	shardPath := filepath.Join(s.Path(), "db0", "rp0", "1")
+	files, err := filepath.Glob(filepath.Join(shardPath, "*.tsm"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(files) != 1 {
+		t.Fatalf("unexpected TSM files: %v", files)
+	}
+
+	f, err := os.OpenFile(files[0], os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteAt([]byte{0xff, 0xff}, 10); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	// The corrupt block is quarantined and the shard is writable again.
+	s.Scrub()
+	if err := s.Shard(1).Corrupt(); err != nil {
+		t.Fatalf("unexpected corruption after repair: %v", err)
+	} else if _, err := os.Stat(filepath.Join(shardPath, tsdb.CorruptFileName)); !os.IsNotExist(err) {
+		t.Fatalf("unexpected corrupt file: %v", err)
+	}
+
+	if files, err := filepath.Glob(filepath.Join(shardPath, "*.quarantine")); err != nil {
+		t.Fatal(err)
+	} else if len(files) != 1 {
+		t.Fatalf("unexpected quarantine files: %v", files)
+	}
+
+	points, err := models.ParsePointsString(`cpu,host=serverA value=3 20`)
+	if err != nil {
+		t.Fatal(err)
+	} else if err := s.WriteToShard(1, points); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestStore_Open(t *testing.T) {
 	t.Parallel()
 
@@ -419,6 +645,265 @@ func TestStore_BackupRestoreShard(t *testing.T) {
 	}
 }
 
+// Ensure the store returns the resolution shards have been downsampled to.
+func TestStore_ShardResolution(t *testing.T) {
+	t.Parallel()
+
+	s := MustOpenStore()
+	defer s.Close()
+
+	s.MustCreateShardWithData("db0", "rp0", 1,
+		`cpu value=1 0`,
+		`cpu value=3 1`,
+	)
+
+	if res := s.ShardResolution(1); res != 0 {
+		t.Fatalf("unexpected resolution: %v", res)
+	} else if err := s.DownsampleShard(1, 10*time.Second, []string{"mean"}); err != nil {
+		t.Fatal(err)
+	} else if res := s.ShardResolution(1); res != 10*time.Second {
+		t.Fatalf("unexpected resolution: %v", res)
+	} else if res := s.ShardResolution(2); res != 0 {
+		t.Fatalf("unexpected resolution of missing shard: %v", res)
+	}
+}
+
+// Ensure the store can split a shard into shards with shorter time ranges.
+func TestStore_SplitShard(t *testing.T) {
+	t.Parallel()
+
+	s := MustOpenStore()
+	defer s.Close()
+
+	s.MustCreateShardWithData("db0", "rp0", 1,
+		`cpu,host=serverA value=1 0`,
+		`cpu,host=serverA value=2 10`,
+		`cpu,host=serverB value=3 20`,
+		`cpu,host=serverA value=4 30`,
+	)
+
+	splits := []tsdb.ShardSplit{{StartTime: time.Unix(20, 0), EndTime: time.Unix(40, 0)}}
+
+	// A failed commit leaves the shard unchanged and writable.
+	if err := s.SplitShard(1, time.Unix(0, 0), time.Unix(20, 0), splits, func() ([]uint64, error) {
+		return nil, errors.New("marker")
+	}); err == nil || err.Error() != "marker" {
+		t.Fatalf("unexpected error: %v", err)
+	} else if sh := s.Shard(2); sh != nil {
+		t.Fatal("unexpected shard 2")
+	} else if n := s.Shard(1).SeriesN(); n != 2 {
+		t.Fatalf("unexpected series count: %d", n)
+	}
+
+	created := make(chan error, 1)
+	if err := s.SplitShard(1, time.Unix(0, 0), time.Unix(20, 0), splits, func() ([]uint64, error) {
+		// The shard is read-only until it is split.
+		if err := s.Shard(1).WritePoints([]models.Point{models.MustNewPoint("cpu", nil, map[string]interface{}{"value": 5.0}, time.Unix(25, 0))}); err != tsdb.ErrShardReadOnly {
+			t.Fatalf("unexpected error writing to shard: %v", err)
+		}
+
+		// Creating a new shard of the split waits until the split is installed.
+		go func() { created <- s.CreateShard("db0", "rp0", 2, true) }()
+		select {
+		case err := <-created:
+			t.Fatalf("shard created during split: %v", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+		return []uint64{2}, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := <-created; err != nil {
+		t.Fatal(err)
+	} else if err := s.Shard(2).WritePoints([]models.Point{models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "serverA"}), map[string]interface{}{"value": 6.0}, time.Unix(35, 0))}); err != nil {
+		t.Fatalf("unexpected error writing to shard: %v", err)
+	}
+
+	if err := s.Shard(1).WritePoints([]models.Point{models.MustNewPoint("cpu", nil, map[string]interface{}{"value": 5.0}, time.Unix(15, 0))}); err != nil {
+		t.Fatalf("unexpected error writing to shard: %v", err)
+	}
+
+	// Each shard should only hold the values within its time range.
+	for _, tt := range []struct {
+		id    uint64
+		times []int64
+	}{
+		{id: 1, times: []int64{15, 0, 10}},
+		{id: 2, times: []int64{30, 35, 20}},
+	} {
+		sh := s.Shard(tt.id)
+		if sh == nil {
+			t.Fatalf("shard %d not found", tt.id)
+		}
+
+		itr, err := sh.CreateIterator("cpu", influxql.IteratorOptions{
+			Expr:       influxql.MustParseExpr(`value`),
+			Dimensions: []string{"host"},
+			Ascending:  true,
+			StartTime:  influxql.MinTime,
+			EndTime:    influxql.MaxTime,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		fitr := itr.(influxql.FloatIterator)
+
+		var times []int64
+		for {
+			p, err := fitr.Next()
+			if err != nil {
+				t.Fatal(err)
+			} else if p == nil {
+				break
+			}
+			times = append(times, p.Time/int64(time.Second))
+		}
+		itr.Close()
+
+		if !reflect.DeepEqual(times, tt.times) {
+			t.Fatalf("shard %d: got times %v, expected %v", tt.id, times, tt.times)
+		}
+	}
+}
+
+// Ensure the store can merge the data of shards into a single shard.
+func TestStore_MergeShards(t *testing.T) {
+	t.Parallel()
+
+	s := MustOpenStore()
+	defer s.Close()
+
+	s.MustCreateShardWithData("db0", "rp0", 1,
+		`cpu,host=serverA value=1 0`,
+		`cpu,host=serverB value=2 10`,
+	)
+	s.MustCreateShardWithData("db0", "rp0", 2,
+		`cpu,host=serverA value=3 20`,
+		`mem,host=serverC value=4 30`,
+	)
+
+	if err := s.MergeShards(1, []uint64{2, 3}, func() error { return nil }); err != tsdb.ErrShardNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A failed commit leaves the shards unchanged and writable.
+	if err := s.MergeShards(1, []uint64{2}, func() error { return errors.New("marker") }); err == nil || err.Error() != "marker" {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, err := os.Stat(filepath.Join(s.Path(), "db0", "rp0", "1.merge")); !os.IsNotExist(err) {
+		t.Fatalf("expected merged shard to be removed: %v", err)
+	}
+	s.MustWriteToShardString(1, `cpu,host=serverA value=1 0`)
+	s.MustWriteToShardString(2, `cpu,host=serverA value=3 20`)
+
+	// The shards are read-only while the merge is committed.
+	if err := s.MergeShards(1, []uint64{2}, func() error {
+		for _, id := range []uint64{1, 2} {
+			if err := s.Shard(id).WritePoints([]models.Point{models.MustNewPoint("cpu", nil, map[string]interface{}{"value": 5.0}, time.Unix(25, 0))}); err != tsdb.ErrShardReadOnly {
+				t.Fatalf("shard %d: unexpected error: %v", id, err)
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The merged shard is writable again, the other is left read-only to be deleted.
+	if err := s.Shard(2).WritePoints([]models.Point{models.MustNewPoint("cpu", nil, map[string]interface{}{"value": 5.0}, time.Unix(25, 0))}); err != tsdb.ErrShardReadOnly {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := s.DeleteShard(2); err != nil {
+		t.Fatal(err)
+	}
+
+	// The merged shard should hold every series and value.
+	sh := s.Shard(1)
+	if got, exp := sh.SeriesN(), int64(3); got != exp {
+		t.Fatalf("got %d series, expected %d", got, exp)
+	}
+
+	itr, err := sh.CreateIterator("cpu", influxql.IteratorOptions{
+		Expr:       influxql.MustParseExpr(`value`),
+		Dimensions: []string{"host"},
+		Ascending:  true,
+		StartTime:  influxql.MinTime,
+		EndTime:    influxql.MaxTime,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer itr.Close()
+	fitr := itr.(influxql.FloatIterator)
+
+	var values []float64
+	for {
+		p, err := fitr.Next()
+		if err != nil {
+			t.Fatal(err)
+		} else if p == nil {
+			break
+		}
+		values = append(values, p.Value)
+	}
+	if exp := []float64{1, 3, 2}; !reflect.DeepEqual(values, exp) {
+		t.Fatalf("got values %v, expected %v", values, exp)
+	}
+
+	// The merged shard should survive a restart.
+	if err := s.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if got, exp := s.Shard(1).SeriesN(), int64(3); got != exp {
+		t.Fatalf("got %d series after reopen, expected %d", got, exp)
+	}
+	s.MustWriteToShardString(1, `cpu,host=serverD value=5 40`)
+}
+
+// Ensure a shard that was being replaced when the store was closed is restored on open.
+func TestStore_Open_ReplacedShard(t *testing.T) {
+	t.Parallel()
+
+	s := MustOpenStore()
+	defer s.Close()
+
+	s.MustCreateShardWithData("db0", "rp0", 1, `cpu,host=serverA value=1 0`)
+	s.MustCreateShardWithData("db0", "rp0", 2, `cpu,host=serverB value=2 0`)
+	if err := s.Store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Shard 1 was moved aside before the merged shard was moved into place, and
+	// shard 2 was replaced but its old directory wasn't removed.
+	dir := filepath.Join(s.Path(), "db0", "rp0")
+	for _, names := range [][2]string{{"1", "1.old"}, {"2", "2.merge"}} {
+		if err := os.Rename(filepath.Join(dir, names[0]), filepath.Join(dir, names[1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "2.old"), 0777); err != nil {
+		t.Fatal(err)
+	} else if err := os.Rename(filepath.Join(dir, "2.merge"), filepath.Join(dir, "2")); err != nil {
+		t.Fatal(err)
+	} else if err := os.MkdirAll(filepath.Join(dir, "3.merge"), 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []uint64{1, 2} {
+		if s.Shard(id) == nil {
+			t.Fatalf("shard %d not restored", id)
+		}
+	}
+
+	names, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(names) != 2 || names[0].Name() != "1" || names[1].Name() != "2" {
+		t.Fatalf("unexpected shard directories: %v", names)
+	}
+}
+
 func TestStore_MeasurementNames_Deduplicate(t *testing.T) {
 	t.Parallel()
 
@@ -613,6 +1098,344 @@ func TestStore_Cardinality_Unique_TSI1(t *testing.T) {
 	testStoreCardinalityUnique(t, store)
 }
 
+func testStoreCardinalityByMeasurement(t *testing.T, store *Store) {
+	store.MustCreateShardWithData("db0", "rp0", 1,
+		`cpu,host=a value=1 0`,
+		`cpu,host=b value=1,v2=2 0`,
+		`mem,host=a value=1 0`,
+	)
+	store.MustCreateShardWithData("db0", "rp0", 2,
+		`cpu,host=a value=1 0`,
+		`cpu,host=c other=1 0`,
+		`disk,host=a value=1 0`,
+	)
+
+	for _, tt := range []struct {
+		fn   func(database string, cond influxql.Expr) ([]tsdb.MeasurementCount, error)
+		cond string
+		exp  []tsdb.MeasurementCount
+	}{
+		{
+			fn:  store.SeriesCardinalityByMeasurement,
+			exp: []tsdb.MeasurementCount{{"cpu", 3}, {"disk", 1}, {"mem", 1}},
+		},
+		{
+			fn:   store.SeriesCardinalityByMeasurement,
+			cond: `(_name = 'cpu') AND (host = 'a')`,
+			exp:  []tsdb.MeasurementCount{{"cpu", 1}},
+		},
+		{
+			fn:   store.TagValuesCardinalityByMeasurement,
+			cond: `_tagKey = 'host'`,
+			exp:  []tsdb.MeasurementCount{{"cpu", 3}, {"disk", 1}, {"mem", 1}},
+		},
+		{
+			fn:  store.FieldKeysCardinalityByMeasurement,
+			exp: []tsdb.MeasurementCount{{"cpu", 3}, {"disk", 1}, {"mem", 1}},
+		},
+		{
+			fn:   store.FieldKeysCardinalityByMeasurement,
+			cond: `_name = 'cpu'`,
+			exp:  []tsdb.MeasurementCount{{"cpu", 3}},
+		},
+	} {
+		var cond influxql.Expr
+		if tt.cond != "" {
+			cond = influxql.MustParseExpr(tt.cond)
+		}
+		if got, err := tt.fn("db0", cond); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(got, tt.exp) {
+			t.Fatalf("unexpected counts for %q: exp %v, got %v", tt.cond, tt.exp, got)
+		}
+	}
+}
+
+func testStoreTimeRange(t *testing.T, store *Store) {
+	store.MustCreateShardWithData("db0", "rp0", 1,
+		`cpu,host=a value=1 10`,
+		`mem,host=b value=1 20`,
+	)
+	store.MustCreateShardWithData("db0", "rp0", 2,
+		`cpu,host=c value=1 100`,
+		`disk,host=a value=1 100`,
+	)
+
+	// Measurements are only returned if they have values in the time range.
+	for _, tt := range []struct {
+		shardIDs []uint64
+		cond     string
+		exp      []string
+	}{
+		{cond: `time >= '1970-01-01T00:00:50Z'`, exp: []string{"cpu", "disk"}},
+		{cond: `time < '1970-01-01T00:00:50Z' AND host = 'a'`, exp: []string{"cpu"}},
+		{cond: `time > '1970-01-01T00:00:10Z' AND time < '1970-01-01T00:00:50Z'`, exp: []string{"mem"}},
+		{shardIDs: []uint64{1}, cond: `time >= '1970-01-01T00:00:00Z'`, exp: []string{"cpu", "mem"}},
+		{shardIDs: []uint64{2}, cond: `time >= '1970-01-01T00:00:00Z' AND host = 'a'`, exp: []string{"disk"}},
+	} {
+		var names [][]byte
+		var err error
+		if tt.shardIDs == nil {
+			names, err = store.MeasurementNames("db0", influxql.MustParseExpr(tt.cond))
+		} else {
+			names, err = store.MeasurementNamesInShards(tt.shardIDs, influxql.MustParseExpr(tt.cond))
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := make([]string, len(names))
+		for i, name := range names {
+			got[i] = string(name)
+		}
+		if !reflect.DeepEqual(got, tt.exp) {
+			t.Fatalf("unexpected measurements for %q in shards %v: exp %v, got %v", tt.cond, tt.shardIDs, tt.exp, got)
+		}
+	}
+
+	// Tag values are merged across shards and only returned if their series have
+	// values in the time range.
+	if got, err := store.TagValues("db0", influxql.MustParseExpr(`_tagKey = 'host'`)); err != nil {
+		t.Fatal(err)
+	} else if exp := []tsdb.TagValues{
+		{Measurement: "cpu", Values: []tsdb.KeyValue{{Key: "host", Value: "a"}, {Key: "host", Value: "c"}}},
+		{Measurement: "disk", Values: []tsdb.KeyValue{{Key: "host", Value: "a"}}},
+		{Measurement: "mem", Values: []tsdb.KeyValue{{Key: "host", Value: "b"}}},
+	}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected tag values: %v", got)
+	}
+
+	if got, err := store.TagValuesInShards([]uint64{1, 2}, influxql.MustParseExpr(`_name = 'cpu' AND _tagKey = 'host' AND time >= '1970-01-01T00:00:50Z'`)); err != nil {
+		t.Fatal(err)
+	} else if exp := []tsdb.TagValues{
+		{Measurement: "cpu", Values: []tsdb.KeyValue{{Key: "host", Value: "c"}}},
+	}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected tag values: %v", got)
+	}
+
+	// Series are only returned if they have values in the time range.
+	itr, err := store.Shard(1).CreateIterator("_series", influxql.IteratorOptions{
+		Aux:       []influxql.VarRef{{Val: "key"}},
+		Condition: influxql.MustParseExpr(`time >= '1970-01-01T00:00:15Z' AND host =~ /a|b/`),
+		StartTime: int64(15 * time.Second),
+		EndTime:   influxql.MaxTime,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer itr.Close()
+
+	var keys []string
+	fitr := itr.(influxql.FloatIterator)
+	for p, err := fitr.Next(); p != nil || err != nil; p, err = fitr.Next() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, p.Aux[0].(string))
+	}
+	if exp := []string{"mem,host=b"}; !reflect.DeepEqual(keys, exp) {
+		t.Fatalf("unexpected series: exp %v, got %v", exp, keys)
+	}
+}
+
+func TestStore_TimeRange_Inmem(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore()
+	store.EngineOptions.Config.Index = "inmem"
+	if err := store.Open(); err != nil {
+		panic(err)
+	}
+	defer store.Close()
+	testStoreTimeRange(t, store)
+}
+
+func TestStore_TimeRange_TSI1(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore()
+	store.EngineOptions.Config.Index = "tsi1"
+	if err := store.Open(); err != nil {
+		panic(err)
+	}
+	defer store.Close()
+	testStoreTimeRange(t, store)
+}
+
+func testStoreInactiveSeries(t *testing.T, store *Store) {
+	store.MustCreateShardWithData("db0", "rp0", 1,
+		`cpu,host=a value=1 10`,
+		`cpu,host=b value=1 20`,
+		`mem,host=a value=1 30`,
+	)
+	store.MustCreateShardWithData("db0", "rp0", 2,
+		`cpu,host=a value=1 100`,
+		`cpu,host=c value=1 50`,
+	)
+
+	// The last write of a series is the latest across shards.
+	for _, tt := range []struct {
+		sources  []influxql.Source
+		cond     string
+		min, max int64
+		exp      []tsdb.SeriesLastWrite
+	}{
+		{
+			min: influxql.MinTime, max: int64(60 * time.Second),
+			exp: []tsdb.SeriesLastWrite{
+				{Key: "cpu,host=b", LastWrite: int64(20 * time.Second), HasValues: true},
+				{Key: "cpu,host=c", LastWrite: int64(50 * time.Second), HasValues: true},
+				{Key: "mem,host=a", LastWrite: int64(30 * time.Second), HasValues: true},
+			},
+		},
+		{
+			sources: []influxql.Source{&influxql.Measurement{Name: "cpu"}},
+			cond:    `host = 'b'`,
+			min:     influxql.MinTime, max: int64(60 * time.Second),
+			exp: []tsdb.SeriesLastWrite{
+				{Key: "cpu,host=b", LastWrite: int64(20 * time.Second), HasValues: true},
+			},
+		},
+		{
+			min: int64(25 * time.Second), max: int64(60 * time.Second),
+			exp: []tsdb.SeriesLastWrite{
+				{Key: "cpu,host=c", LastWrite: int64(50 * time.Second), HasValues: true},
+				{Key: "mem,host=a", LastWrite: int64(30 * time.Second), HasValues: true},
+			},
+		},
+	} {
+		var cond influxql.Expr
+		if tt.cond != "" {
+			cond = influxql.MustParseExpr(tt.cond)
+		}
+		if got, err := store.InactiveSeries("db0", tt.sources, cond, tt.min, tt.max); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(got, tt.exp) {
+			t.Fatalf("unexpected inactive series for %q %d-%d: %v", tt.cond, tt.min, tt.max, got)
+		}
+	}
+
+	// Inactive series are dropped from all shards.
+	sources := []influxql.Source{&influxql.Measurement{Name: "cpu"}}
+	if got, err := store.DeleteInactiveSeries("db0", sources, nil, influxql.MinTime, int64(60*time.Second)); err != nil {
+		t.Fatal(err)
+	} else if len(got) != 2 {
+		t.Fatalf("unexpected deleted series: %v", got)
+	}
+	if got, err := store.InactiveSeries("db0", nil, nil, influxql.MinTime, influxql.MaxTime); err != nil {
+		t.Fatal(err)
+	} else if exp := []tsdb.SeriesLastWrite{
+		{Key: "cpu,host=a", LastWrite: int64(100 * time.Second), HasValues: true},
+		{Key: "mem,host=a", LastWrite: int64(30 * time.Second), HasValues: true},
+	}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected series after drop: %v", got)
+	}
+}
+
+func TestStore_InactiveSeries_Inmem(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore()
+	store.EngineOptions.Config.Index = "inmem"
+	if err := store.Open(); err != nil {
+		panic(err)
+	}
+	defer store.Close()
+	testStoreInactiveSeries(t, store)
+}
+
+func TestStore_InactiveSeries_TSI1(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore()
+	store.EngineOptions.Config.Index = "tsi1"
+	if err := store.Open(); err != nil {
+		panic(err)
+	}
+	defer store.Close()
+	testStoreInactiveSeries(t, store)
+}
+
+func testStoreTagValuesByPrefix(t *testing.T, store *Store) {
+	store.MustCreateShardWithData("db0", "rp0", 1,
+		`cpu,host=web02 value=1 10`,
+		`cpu,host=db01 value=1 20`,
+		`cpu,host=web10 value=1 30`,
+		`mem,host=web03 value=1 30`,
+	)
+	store.MustCreateShardWithData("db0", "rp0", 2,
+		`cpu,host=web01 value=1 100`,
+		`cpu,host=web02 value=1 100`,
+	)
+
+	for _, tt := range []struct {
+		measurement, key, prefix string
+		limit                    int
+		exp                      []string
+	}{
+		{measurement: "cpu", key: "host", prefix: "web", exp: []string{"web01", "web02", "web10"}},
+		{measurement: "cpu", key: "host", prefix: "web", limit: 2, exp: []string{"web01", "web02"}},
+		{measurement: "cpu", key: "host", prefix: "", limit: 1, exp: []string{"db01"}},
+		{measurement: "cpu", key: "host", prefix: "x", exp: []string{}},
+		{measurement: "cpu", key: "region", prefix: "", exp: []string{}},
+		{measurement: "disk", key: "host", prefix: "", exp: []string{}},
+	} {
+		if got, err := store.TagValuesByPrefix("db0", tt.measurement, tt.key, tt.prefix, tt.limit); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(got, tt.exp) {
+			t.Fatalf("unexpected values for %s/%s %q: %v", tt.measurement, tt.key, tt.prefix, got)
+		}
+	}
+}
+
+func TestStore_TagValuesByPrefix_Inmem(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore()
+	store.EngineOptions.Config.Index = "inmem"
+	if err := store.Open(); err != nil {
+		panic(err)
+	}
+	defer store.Close()
+	testStoreTagValuesByPrefix(t, store)
+}
+
+func TestStore_TagValuesByPrefix_TSI1(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore()
+	store.EngineOptions.Config.Index = "tsi1"
+	if err := store.Open(); err != nil {
+		panic(err)
+	}
+	defer store.Close()
+	testStoreTagValuesByPrefix(t, store)
+}
+
+func TestStore_CardinalityByMeasurement_Inmem(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore()
+	store.EngineOptions.Config.Index = "inmem"
+	if err := store.Open(); err != nil {
+		panic(err)
+	}
+	defer store.Close()
+	testStoreCardinalityByMeasurement(t, store)
+}
+
+func TestStore_CardinalityByMeasurement_TSI1(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore()
+	store.EngineOptions.Config.Index = "tsi1"
+	if err := store.Open(); err != nil {
+		panic(err)
+	}
+	defer store.Close()
+	testStoreCardinalityByMeasurement(t, store)
+}
+
 // This test tests cardinality estimation when series data is duplicated across
 // multiple shards.
 func testStoreCardinalityDuplicates(t *testing.T, store *Store) {